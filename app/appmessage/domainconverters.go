@@ -96,6 +96,11 @@ func DomainTransactionToMsgTx(domainTransaction *externalapi.DomainTransaction)
 		SubnetworkID: domainTransaction.SubnetworkID,
 		Gas:          domainTransaction.Gas,
 		Payload:      domainTransaction.Payload,
+
+		VProgVersion:  domainTransaction.VProgVersion,
+		VProgCode:     domainTransaction.VProgCode,
+		VProgData:     domainTransaction.VProgData,
+		VProgGasLimit: domainTransaction.VProgGasLimit,
 	}
 }
 
@@ -139,13 +144,17 @@ func MsgTxToDomainTransaction(msgTx *MsgTx) *externalapi.DomainTransaction {
 	}
 
 	return &externalapi.DomainTransaction{
-		Version:      msgTx.Version,
-		Inputs:       transactionInputs,
-		Outputs:      transactionOutputs,
-		LockTime:     msgTx.LockTime,
-		SubnetworkID: msgTx.SubnetworkID,
-		Gas:          msgTx.Gas,
-		Payload:      payload,
+		Version:       msgTx.Version,
+		Inputs:        transactionInputs,
+		Outputs:       transactionOutputs,
+		LockTime:      msgTx.LockTime,
+		SubnetworkID:  msgTx.SubnetworkID,
+		Gas:           msgTx.Gas,
+		Payload:       payload,
+		VProgVersion:  msgTx.VProgVersion,
+		VProgCode:     msgTx.VProgCode,
+		VProgData:     msgTx.VProgData,
+		VProgGasLimit: msgTx.VProgGasLimit,
 	}
 }
 
@@ -211,15 +220,27 @@ func RPCTransactionToDomainTransaction(rpcTransaction *RPCTransaction) (*externa
 	if err != nil {
 		return nil, err
 	}
+	vprogCode, err := hex.DecodeString(rpcTransaction.VProgCode)
+	if err != nil {
+		return nil, err
+	}
+	vprogData, err := hex.DecodeString(rpcTransaction.VProgData)
+	if err != nil {
+		return nil, err
+	}
 
 	return &externalapi.DomainTransaction{
-		Version:      rpcTransaction.Version,
-		Inputs:       inputs,
-		Outputs:      outputs,
-		LockTime:     rpcTransaction.LockTime,
-		SubnetworkID: *subnetworkID,
-		Gas:          rpcTransaction.LockTime,
-		Payload:      payload,
+		Version:       rpcTransaction.Version,
+		Inputs:        inputs,
+		Outputs:       outputs,
+		LockTime:      rpcTransaction.LockTime,
+		SubnetworkID:  *subnetworkID,
+		Gas:           rpcTransaction.LockTime,
+		Payload:       payload,
+		VProgVersion:  rpcTransaction.VProgVersion,
+		VProgCode:     vprogCode,
+		VProgData:     vprogData,
+		VProgGasLimit: rpcTransaction.VProgGasLimit,
 	}, nil
 }
 
@@ -280,14 +301,20 @@ func DomainTransactionToRPCTransaction(transaction *externalapi.DomainTransactio
 	}
 	subnetworkID := transaction.SubnetworkID.String()
 	payload := hex.EncodeToString(transaction.Payload)
+	vprogCode := hex.EncodeToString(transaction.VProgCode)
+	vprogData := hex.EncodeToString(transaction.VProgData)
 	return &RPCTransaction{
-		Version:      transaction.Version,
-		Inputs:       inputs,
-		Outputs:      outputs,
-		LockTime:     transaction.LockTime,
-		SubnetworkID: subnetworkID,
-		Gas:          transaction.LockTime,
-		Payload:      payload,
+		Version:       transaction.Version,
+		Inputs:        inputs,
+		Outputs:       outputs,
+		LockTime:      transaction.LockTime,
+		SubnetworkID:  subnetworkID,
+		Gas:           transaction.LockTime,
+		Payload:       payload,
+		VProgVersion:  transaction.VProgVersion,
+		VProgCode:     vprogCode,
+		VProgData:     vprogData,
+		VProgGasLimit: transaction.VProgGasLimit,
 	}
 }
 
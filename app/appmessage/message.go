@@ -73,6 +73,13 @@ const (
 	CmdRequestIBDChainBlockLocator
 	CmdIBDChainBlockLocator
 	CmdRequestAnticone
+	CmdTransactionPackage
+	CmdRequestCompactBlock
+	CmdCompactBlock
+	CmdRequestBlockTransactions
+	CmdBlockTransactions
+	CmdInvTransactionPackage
+	CmdRequestTransactionPackage
 
 	// rpc
 	CmdGetCurrentNetworkRequestMessage
@@ -163,6 +170,29 @@ const (
 	CmdGetMempoolEntriesByAddressesResponseMessage
 	CmdGetCoinSupplyRequestMessage
 	CmdGetCoinSupplyResponseMessage
+	CmdSimulateVProgCallRequestMessage
+	CmdSimulateVProgCallResponseMessage
+	CmdGetVProgCodeRequestMessage
+	CmdGetVProgCodeResponseMessage
+	CmdGetVProgStorageRequestMessage
+	CmdGetVProgStorageResponseMessage
+	CmdGetTransactionRequestMessage
+	CmdGetTransactionResponseMessage
+	CmdGetTransactionAcceptanceRequestMessage
+	CmdGetTransactionAcceptanceResponseMessage
+	CmdGetTransactionsByAddressesRequestMessage
+	CmdGetTransactionsByAddressesResponseMessage
+	CmdGetFeeEstimateRequestMessage
+	CmdGetFeeEstimateResponseMessage
+	CmdSubmitTransactionReplacementRequestMessage
+	CmdSubmitTransactionReplacementResponseMessage
+	CmdNotifyMempoolChangedRequestMessage
+	CmdNotifyMempoolChangedResponseMessage
+	CmdMempoolChangedNotificationMessage
+	CmdValidateTransactionRequestMessage
+	CmdValidateTransactionResponseMessage
+	CmdSubmitTransactionPackageRequestMessage
+	CmdSubmitTransactionPackageResponseMessage
 )
 
 // ProtocolMessageCommandToString maps all MessageCommands to their string representation
@@ -210,6 +240,13 @@ var ProtocolMessageCommandToString = map[MessageCommand]string{
 	CmdRequestIBDChainBlockLocator:                 "RequestIBDChainBlockLocator",
 	CmdIBDChainBlockLocator:                        "IBDChainBlockLocator",
 	CmdRequestAnticone:                             "RequestAnticone",
+	CmdTransactionPackage:                          "TransactionPackage",
+	CmdRequestCompactBlock:                         "RequestCompactBlock",
+	CmdCompactBlock:                                "CompactBlock",
+	CmdRequestBlockTransactions:                    "RequestBlockTransactions",
+	CmdBlockTransactions:                           "BlockTransactions",
+	CmdInvTransactionPackage:                       "InvTransactionPackage",
+	CmdRequestTransactionPackage:                   "RequestTransactionPackage",
 }
 
 // RPCMessageCommandToString maps all MessageCommands to their string representation
@@ -300,6 +337,29 @@ var RPCMessageCommandToString = map[MessageCommand]string{
 	CmdGetMempoolEntriesByAddressesResponseMessage:                "GetMempoolEntriesByAddressesResponse",
 	CmdGetCoinSupplyRequestMessage:                                "GetCoinSupplyRequest",
 	CmdGetCoinSupplyResponseMessage:                               "GetCoinSupplyResponse",
+	CmdSimulateVProgCallRequestMessage:                            "SimulateVProgCallRequest",
+	CmdSimulateVProgCallResponseMessage:                           "SimulateVProgCallResponse",
+	CmdGetVProgCodeRequestMessage:                                 "GetVProgCodeRequest",
+	CmdGetVProgCodeResponseMessage:                                "GetVProgCodeResponse",
+	CmdGetVProgStorageRequestMessage:                              "GetVProgStorageRequest",
+	CmdGetVProgStorageResponseMessage:                             "GetVProgStorageResponse",
+	CmdGetTransactionRequestMessage:                               "GetTransactionRequest",
+	CmdGetTransactionResponseMessage:                              "GetTransactionResponse",
+	CmdGetTransactionAcceptanceRequestMessage:                     "GetTransactionAcceptanceRequest",
+	CmdGetTransactionAcceptanceResponseMessage:                    "GetTransactionAcceptanceResponse",
+	CmdGetTransactionsByAddressesRequestMessage:                   "GetTransactionsByAddressesRequest",
+	CmdGetTransactionsByAddressesResponseMessage:                  "GetTransactionsByAddressesResponse",
+	CmdGetFeeEstimateRequestMessage:                               "GetFeeEstimateRequest",
+	CmdGetFeeEstimateResponseMessage:                              "GetFeeEstimateResponse",
+	CmdSubmitTransactionReplacementRequestMessage:                 "SubmitTransactionReplacementRequest",
+	CmdSubmitTransactionReplacementResponseMessage:                "SubmitTransactionReplacementResponse",
+	CmdNotifyMempoolChangedRequestMessage:                         "NotifyMempoolChangedRequest",
+	CmdNotifyMempoolChangedResponseMessage:                        "NotifyMempoolChangedResponse",
+	CmdMempoolChangedNotificationMessage:                          "MempoolChangedNotification",
+	CmdValidateTransactionRequestMessage:                          "ValidateTransactionRequest",
+	CmdValidateTransactionResponseMessage:                         "ValidateTransactionResponse",
+	CmdSubmitTransactionPackageRequestMessage:                     "SubmitTransactionPackageRequest",
+	CmdSubmitTransactionPackageResponseMessage:                    "SubmitTransactionPackageResponse",
 }
 
 // Message is an interface that describes a zua message. A type that
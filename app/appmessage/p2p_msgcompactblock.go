@@ -0,0 +1,52 @@
+package appmessage
+
+// MaxShortTransactionID is the largest valid short transaction ID. Short
+// transaction IDs are 6 bytes long.
+const MaxShortTransactionID = 1<<48 - 1
+
+// PrefilledTransaction is a transaction that is sent in full inside a compact
+// block, along with its index in the block
+type PrefilledTransaction struct {
+	Index uint32
+	Tx    *MsgTx
+}
+
+// MsgCompactBlock implements the Message interface and represents a zua
+// CompactBlock message. It is used to relay a block in which every transaction,
+// except for the prefilled ones, is replaced by its short transaction ID, so
+// that the receiver can rebuild the block from the transactions in its mempool.
+//
+// The transactions of the block are the prefilled transactions, placed at their
+// indexes, with the gaps filled by the transactions of ShortIDs in order. The
+// coinbase transaction is always prefilled.
+type MsgCompactBlock struct {
+	baseMessage
+	Header                MsgBlockHeader
+	ShortIDSalt           uint64
+	ShortIDs              []uint64
+	PrefilledTransactions []*PrefilledTransaction
+}
+
+// Command returns the protocol command string for the message. This is part
+// of the Message interface implementation.
+func (msg *MsgCompactBlock) Command() MessageCommand {
+	return CmdCompactBlock
+}
+
+// TransactionCount returns the number of transactions in the block
+func (msg *MsgCompactBlock) TransactionCount() int {
+	return len(msg.ShortIDs) + len(msg.PrefilledTransactions)
+}
+
+// NewMsgCompactBlock returns a new zua CompactBlock message that conforms to
+// the Message interface. See MsgCompactBlock for details.
+func NewMsgCompactBlock(header *MsgBlockHeader, shortIDSalt uint64, shortIDs []uint64,
+	prefilledTransactions []*PrefilledTransaction) *MsgCompactBlock {
+
+	return &MsgCompactBlock{
+		Header:                *header,
+		ShortIDSalt:           shortIDSalt,
+		ShortIDs:              shortIDs,
+		PrefilledTransactions: prefilledTransactions,
+	}
+}
@@ -0,0 +1,29 @@
+package appmessage
+
+import (
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+)
+
+// MsgInvTransactionPackage implements the Message interface and represents a zua
+// InvTransactionPackage message. It is used to notify the network about a new
+// package of dependent transactions by sending the IDs of its transactions, sorted
+// so that parents come before their children, and let the receiving node decide
+// if it needs it.
+type MsgInvTransactionPackage struct {
+	baseMessage
+	TxIDs []*externalapi.DomainTransactionID
+}
+
+// Command returns the protocol command string for the message. This is part
+// of the Message interface implementation.
+func (msg *MsgInvTransactionPackage) Command() MessageCommand {
+	return CmdInvTransactionPackage
+}
+
+// NewMsgInvTransactionPackage returns a new zua InvTransactionPackage message that conforms to
+// the Message interface. See MsgInvTransactionPackage for details.
+func NewMsgInvTransactionPackage(ids []*externalapi.DomainTransactionID) *MsgInvTransactionPackage {
+	return &MsgInvTransactionPackage{
+		TxIDs: ids,
+	}
+}
@@ -2,10 +2,13 @@ package appmessage
 
 import "github.com/zuanet/zuad/domain/consensus/model/externalapi"
 
-// MsgPruningPointUTXOSetChunk represents a zua PruningPointUTXOSetChunk message
+// MsgPruningPointUTXOSetChunk represents a zua PruningPointUTXOSetChunk message.
+// The vprog state of the pruning point is committed to along with its UTXO set,
+// so its entries are sent in the chunks that follow the UTXOs.
 type MsgPruningPointUTXOSetChunk struct {
 	baseMessage
 	OutpointAndUTXOEntryPairs []*OutpointAndUTXOEntryPair
+	VProgStateEntries         []*externalapi.VProgStateEntry
 }
 
 // Command returns the protocol command string for the message
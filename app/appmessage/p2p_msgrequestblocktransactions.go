@@ -0,0 +1,29 @@
+package appmessage
+
+import (
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+)
+
+// MsgRequestBlockTransactions implements the Message interface and represents a zua
+// RequestBlockTransactions message. It is used to request the transactions of a
+// compact block that the requesting peer couldn't find in its mempool.
+type MsgRequestBlockTransactions struct {
+	baseMessage
+	BlockHash *externalapi.DomainHash
+	Indexes   []uint32
+}
+
+// Command returns the protocol command string for the message. This is part
+// of the Message interface implementation.
+func (msg *MsgRequestBlockTransactions) Command() MessageCommand {
+	return CmdRequestBlockTransactions
+}
+
+// NewMsgRequestBlockTransactions returns a new zua RequestBlockTransactions message that conforms to
+// the Message interface. See MsgRequestBlockTransactions for details.
+func NewMsgRequestBlockTransactions(blockHash *externalapi.DomainHash, indexes []uint32) *MsgRequestBlockTransactions {
+	return &MsgRequestBlockTransactions{
+		BlockHash: blockHash,
+		Indexes:   indexes,
+	}
+}
@@ -0,0 +1,27 @@
+package appmessage
+
+import (
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+)
+
+// MsgRequestCompactBlock implements the Message interface and represents a zua
+// RequestCompactBlock message. It is used to request a relayed block as a
+// compact block, as part of the compact block relay protocol.
+type MsgRequestCompactBlock struct {
+	baseMessage
+	Hash *externalapi.DomainHash
+}
+
+// Command returns the protocol command string for the message. This is part
+// of the Message interface implementation.
+func (msg *MsgRequestCompactBlock) Command() MessageCommand {
+	return CmdRequestCompactBlock
+}
+
+// NewMsgRequestCompactBlock returns a new zua RequestCompactBlock message that conforms to
+// the Message interface. See MsgRequestCompactBlock for details.
+func NewMsgRequestCompactBlock(hash *externalapi.DomainHash) *MsgRequestCompactBlock {
+	return &MsgRequestCompactBlock{
+		Hash: hash,
+	}
+}
@@ -0,0 +1,27 @@
+package appmessage
+
+import (
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+)
+
+// MsgRequestTransactionPackage implements the Message interface and represents a zua
+// RequestTransactionPackage message. It is used to request a package of dependent
+// transactions that was announced by a MsgInvTransactionPackage.
+type MsgRequestTransactionPackage struct {
+	baseMessage
+	TxIDs []*externalapi.DomainTransactionID
+}
+
+// Command returns the protocol command string for the message. This is part
+// of the Message interface implementation.
+func (msg *MsgRequestTransactionPackage) Command() MessageCommand {
+	return CmdRequestTransactionPackage
+}
+
+// NewMsgRequestTransactionPackage returns a new zua RequestTransactionPackage message that conforms to
+// the Message interface. See MsgRequestTransactionPackage for details.
+func NewMsgRequestTransactionPackage(ids []*externalapi.DomainTransactionID) *MsgRequestTransactionPackage {
+	return &MsgRequestTransactionPackage{
+		TxIDs: ids,
+	}
+}
@@ -0,0 +1,29 @@
+package appmessage
+
+// MaxTransactionsPerTransactionPackageMsg is the maximum number of transactions
+// that can be in a single TransactionPackage message.
+const MaxTransactionsPerTransactionPackageMsg = 25
+
+// MsgTransactionPackage implements the Message interface and represents a zua
+// TransactionPackage message. It is used to relay a set of dependent transactions,
+// sorted so that parents come before their children, that should be admitted to
+// the mempool together. It's sent in response to a MsgRequestTransactionPackage,
+// and carries no transactions if the requested package is not available.
+type MsgTransactionPackage struct {
+	baseMessage
+	Transactions []*MsgTx
+}
+
+// Command returns the protocol command string for the message. This is part
+// of the Message interface implementation.
+func (msg *MsgTransactionPackage) Command() MessageCommand {
+	return CmdTransactionPackage
+}
+
+// NewMsgTransactionPackage returns a new zua TransactionPackage message that conforms to
+// the Message interface. See MsgTransactionPackage for details.
+func NewMsgTransactionPackage(transactions []*MsgTx) *MsgTransactionPackage {
+	return &MsgTransactionPackage{
+		Transactions: transactions,
+	}
+}
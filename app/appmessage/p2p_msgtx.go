@@ -135,6 +135,11 @@ type MsgTx struct {
 	SubnetworkID externalapi.DomainSubnetworkID
 	Gas          uint64
 	Payload      []byte
+
+	VProgVersion  byte
+	VProgCode     []byte
+	VProgData     []byte
+	VProgGasLimit uint64
 }
 
 // AddTxIn adds a transaction input to the message.
@@ -179,6 +184,9 @@ func (msg *MsgTx) Copy() *MsgTx {
 		LockTime:     msg.LockTime,
 		SubnetworkID: msg.SubnetworkID,
 		Gas:          msg.Gas,
+
+		VProgVersion:  msg.VProgVersion,
+		VProgGasLimit: msg.VProgGasLimit,
 	}
 
 	if msg.Payload != nil {
@@ -186,6 +194,16 @@ func (msg *MsgTx) Copy() *MsgTx {
 		copy(newTx.Payload, msg.Payload)
 	}
 
+	if msg.VProgCode != nil {
+		newTx.VProgCode = make([]byte, len(msg.VProgCode))
+		copy(newTx.VProgCode, msg.VProgCode)
+	}
+
+	if msg.VProgData != nil {
+		newTx.VProgData = make([]byte, len(msg.VProgData))
+		copy(newTx.VProgData, msg.VProgData)
+	}
+
 	// Deep copy the old TxIn data.
 	for _, oldTxIn := range msg.TxIn {
 		// Deep copy the old previous outpoint.
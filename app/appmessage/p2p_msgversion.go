@@ -58,6 +58,23 @@ type MsgVersion struct {
 
 	// The subnetwork of the generator of the version message. Should be nil in full nodes
 	SubnetworkID *externalapi.DomainSubnetworkID
+
+	// The keys the peer offers for encrypting the connection. Should be nil
+	// in peers that don't support or don't want encrypted connections
+	EncryptionOffer *EncryptionOffer
+}
+
+// EncryptionOffer is the key material a peer sends in its version message in
+// order to negotiate an encrypted connection
+type EncryptionOffer struct {
+	// The long-term public identity key of the peer
+	IdentityPublicKey []byte
+
+	// The public key the peer generated for this connection only
+	EphemeralPublicKey []byte
+
+	// The signature of the ephemeral key by the identity key
+	Signature []byte
 }
 
 // HasService returns whether the specified service is supported by the peer
@@ -6,6 +6,7 @@ package appmessage
 
 import (
 	"net"
+	"strconv"
 
 	"github.com/zuanet/zuad/util/mstime"
 )
@@ -16,15 +17,24 @@ type NetAddress struct {
 	// Last time the address was seen.
 	Timestamp mstime.Time
 
-	// IP address of the peer.
+	// Network is the network the peer is reachable through.
+	Network NetworkID
+
+	// IP address of the peer. Only set if Network is NetworkIP.
 	IP net.IP
 
+	// Host is the address of the peer within its network, for networks
+	// other than NetworkIP. See NetworkID for its format in every network.
+	Host []byte
+
 	// Port the peer is using. This is encoded in big endian on the appmessage
 	// which differs from most everything else.
 	Port uint16
 }
 
 // TCPAddress converts the NetAddress to *net.TCPAddr
+// Note that the IP of the returned address is nil if the NetAddress
+// isn't an IP address
 func (na *NetAddress) TCPAddress() *net.TCPAddr {
 	return &net.TCPAddr{
 		IP:   na.IP,
@@ -59,6 +69,29 @@ func NewNetAddress(addr *net.TCPAddr) *NetAddress {
 	return NewNetAddressIPPort(addr.IP, uint16(addr.Port))
 }
 
+// IsIP returns whether the NetAddress is an IP address
+func (na *NetAddress) IsIP() bool {
+	return na.Network == NetworkIP
+}
+
+// HostName returns the host part of the address: the IP address for IP
+// addresses, and the .onion or .b32.i2p name for Tor and I2P addresses
+func (na *NetAddress) HostName() string {
+	switch na.Network {
+	case NetworkIP:
+		return na.IP.String()
+	case NetworkTorV3:
+		return torV3HostName(na.Host)
+	case NetworkI2P:
+		return i2pHostName(na.Host)
+	default:
+		return "unknown"
+	}
+}
+
 func (na NetAddress) String() string {
-	return na.TCPAddress().String()
+	if na.IsIP() {
+		return na.TCPAddress().String()
+	}
+	return net.JoinHostPort(na.HostName(), strconv.Itoa(int(na.Port)))
 }
@@ -0,0 +1,177 @@
+package appmessage
+
+import (
+	"encoding/base32"
+	"net"
+	"strings"
+
+	"github.com/zuanet/zuad/util/mstime"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/sha3"
+)
+
+// NetworkID identifies the network a NetAddress is reachable through
+type NetworkID uint8
+
+const (
+	// NetworkIP is the network of IPv4 and IPv6 addresses. Addresses in
+	// this network are stored in NetAddress.IP
+	NetworkIP NetworkID = 0
+
+	// NetworkTorV3 is the network of Tor v3 onion services. The host of
+	// addresses in this network is the ed25519 public key of the service
+	NetworkTorV3 NetworkID = 1
+
+	// NetworkI2P is the network of I2P destinations. The host of addresses
+	// in this network is the SHA256 hash of the destination
+	NetworkI2P NetworkID = 2
+)
+
+func (id NetworkID) String() string {
+	switch id {
+	case NetworkIP:
+		return "ip"
+	case NetworkTorV3:
+		return "torv3"
+	case NetworkI2P:
+		return "i2p"
+	default:
+		return "unknown"
+	}
+}
+
+// HostSize returns the size of the host of addresses in the network, or
+// false if the network is unknown or its addresses don't have a host
+func (id NetworkID) HostSize() (int, bool) {
+	switch id {
+	case NetworkTorV3:
+		return TorV3PublicKeySize, true
+	case NetworkI2P:
+		return I2PHashSize, true
+	default:
+		return 0, false
+	}
+}
+
+const (
+	// TorV3PublicKeySize is the size of the public key of a Tor v3 onion service
+	TorV3PublicKeySize = 32
+
+	// I2PHashSize is the size of the hash of an I2P destination
+	I2PHashSize = 32
+
+	torV3Version       = 3
+	torV3ChecksumSize  = 2
+	torV3ChecksumLabel = ".onion checksum"
+	torV3Suffix        = ".onion"
+	i2pSuffix          = ".b32.i2p"
+)
+
+var hostNameEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewNetAddressTorV3 returns a new NetAddress of the Tor v3 onion service
+// with the given public key and port
+func NewNetAddressTorV3(publicKey []byte, port uint16) *NetAddress {
+	return NewNetAddressHost(mstime.Now(), NetworkTorV3, publicKey, port)
+}
+
+// NewNetAddressI2P returns a new NetAddress of the I2P destination with the
+// given hash and port
+func NewNetAddressI2P(destinationHash []byte, port uint16) *NetAddress {
+	return NewNetAddressHost(mstime.Now(), NetworkI2P, destinationHash, port)
+}
+
+// NewNetAddressHost returns a new NetAddress in the given network, which
+// must not be NetworkIP
+func NewNetAddressHost(timestamp mstime.Time, network NetworkID, host []byte, port uint16) *NetAddress {
+	return &NetAddress{
+		Timestamp: timestamp,
+		Network:   network,
+		Host:      host,
+		Port:      port,
+	}
+}
+
+// ParseNetAddress returns a NetAddress out of the given host name and port.
+// The host name is either an IP address, a Tor v3 .onion name or an I2P
+// .b32.i2p name
+func ParseNetAddress(hostName string, port uint16) (*NetAddress, error) {
+	lowerCaseHostName := strings.ToLower(hostName)
+	switch {
+	case strings.HasSuffix(lowerCaseHostName, torV3Suffix):
+		publicKey, err := parseTorV3HostName(lowerCaseHostName)
+		if err != nil {
+			return nil, err
+		}
+		return NewNetAddressTorV3(publicKey, port), nil
+	case strings.HasSuffix(lowerCaseHostName, i2pSuffix):
+		destinationHash, err := parseI2PHostName(lowerCaseHostName)
+		if err != nil {
+			return nil, err
+		}
+		return NewNetAddressI2P(destinationHash, port), nil
+	default:
+		ip := net.ParseIP(hostName)
+		if ip == nil {
+			return nil, errors.Errorf("%s is not an IP address, an onion address or an I2P address", hostName)
+		}
+		return NewNetAddressIPPort(ip, port), nil
+	}
+}
+
+// torV3Checksum returns the checksum of a v3 onion address as defined
+// in rend-spec-v3: SHA3-256(".onion checksum" | PUBKEY | VERSION)[:2]
+func torV3Checksum(publicKey []byte) []byte {
+	hasher := sha3.New256()
+	hasher.Write([]byte(torV3ChecksumLabel))
+	hasher.Write(publicKey)
+	hasher.Write([]byte{torV3Version})
+	return hasher.Sum(nil)[:torV3ChecksumSize]
+}
+
+// torV3HostName returns the .onion name of the given public key, which is
+// base32(PUBKEY | CHECKSUM | VERSION)
+func torV3HostName(publicKey []byte) string {
+	encoded := make([]byte, 0, len(publicKey)+torV3ChecksumSize+1)
+	encoded = append(encoded, publicKey...)
+	encoded = append(encoded, torV3Checksum(publicKey)...)
+	encoded = append(encoded, torV3Version)
+	return strings.ToLower(hostNameEncoding.EncodeToString(encoded)) + torV3Suffix
+}
+
+func parseTorV3HostName(hostName string) ([]byte, error) {
+	decoded, err := hostNameEncoding.DecodeString(strings.ToUpper(strings.TrimSuffix(hostName, torV3Suffix)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s is not a valid onion address", hostName)
+	}
+	if len(decoded) != TorV3PublicKeySize+torV3ChecksumSize+1 {
+		return nil, errors.Errorf("%s is not a v3 onion address", hostName)
+	}
+	publicKey := decoded[:TorV3PublicKeySize]
+	checksum := decoded[TorV3PublicKeySize : TorV3PublicKeySize+torV3ChecksumSize]
+	version := decoded[TorV3PublicKeySize+torV3ChecksumSize]
+	if version != torV3Version {
+		return nil, errors.Errorf("%s has unsupported onion address version %d", hostName, version)
+	}
+	expectedChecksum := torV3Checksum(publicKey)
+	if checksum[0] != expectedChecksum[0] || checksum[1] != expectedChecksum[1] {
+		return nil, errors.Errorf("%s has an invalid checksum", hostName)
+	}
+	return publicKey, nil
+}
+
+// i2pHostName returns the .b32.i2p name of the given destination hash
+func i2pHostName(destinationHash []byte) string {
+	return strings.ToLower(hostNameEncoding.EncodeToString(destinationHash)) + i2pSuffix
+}
+
+func parseI2PHostName(hostName string) ([]byte, error) {
+	decoded, err := hostNameEncoding.DecodeString(strings.ToUpper(strings.TrimSuffix(hostName, i2pSuffix)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s is not a valid I2P address", hostName)
+	}
+	if len(decoded) != I2PHashSize {
+		return nil, errors.Errorf("%s is not a b32 I2P address", hostName)
+	}
+	return decoded, nil
+}
@@ -0,0 +1,101 @@
+package appmessage
+
+import (
+	"bytes"
+	"net"
+	"testing"
+)
+
+func TestParseNetAddress(t *testing.T) {
+	tests := []struct {
+		hostName        string
+		expectedNetwork NetworkID
+		expectedString  string
+	}{
+		{
+			hostName:        "127.0.0.1",
+			expectedNetwork: NetworkIP,
+			expectedString:  "127.0.0.1:16111",
+		},
+		{
+			hostName:        "::1",
+			expectedNetwork: NetworkIP,
+			expectedString:  "[::1]:16111",
+		},
+		{
+			// The onion service of torproject.org
+			hostName:        "2gzyxa5ihm7nsggfxnu52rck2vv4rvmdlkiu3zzui5du4xyclen53wid.onion",
+			expectedNetwork: NetworkTorV3,
+			expectedString:  "2gzyxa5ihm7nsggfxnu52rck2vv4rvmdlkiu3zzui5du4xyclen53wid.onion:16111",
+		},
+		{
+			hostName:        "2GZYXA5IHM7NSGGFXNU52RCK2VV4RVMDLKIU3ZZUI5DU4XYCLEN53WID.ONION",
+			expectedNetwork: NetworkTorV3,
+			expectedString:  "2gzyxa5ihm7nsggfxnu52rck2vv4rvmdlkiu3zzui5du4xyclen53wid.onion:16111",
+		},
+		{
+			hostName:        "ukeu3k5oycgaauneqgtnvselmt4yemvoilkln7jpvamvfx7dnkdq.b32.i2p",
+			expectedNetwork: NetworkI2P,
+			expectedString:  "ukeu3k5oycgaauneqgtnvselmt4yemvoilkln7jpvamvfx7dnkdq.b32.i2p:16111",
+		},
+	}
+	for _, test := range tests {
+		netAddress, err := ParseNetAddress(test.hostName, 16111)
+		if err != nil {
+			t.Fatalf("ParseNetAddress(%s): %+v", test.hostName, err)
+		}
+		if netAddress.Network != test.expectedNetwork {
+			t.Errorf("%s: expected network %s but got %s", test.hostName, test.expectedNetwork, netAddress.Network)
+		}
+		if netAddress.String() != test.expectedString {
+			t.Errorf("%s: expected %s but got %s", test.hostName, test.expectedString, netAddress.String())
+		}
+		if hostSize, ok := netAddress.Network.HostSize(); ok && len(netAddress.Host) != hostSize {
+			t.Errorf("%s: expected a host of %d bytes but got %d", test.hostName, hostSize, len(netAddress.Host))
+		}
+	}
+}
+
+func TestParseInvalidNetAddress(t *testing.T) {
+	tests := []string{
+		"not an address",
+		// The last character of torproject.org's onion name is changed
+		"2gzyxa5ihm7nsggfxnu52rck2vv4rvmdlkiu3zzui5du4xyclen53wia.onion",
+		// A v2 onion name
+		"expyuzz4wqqyqhjn.onion",
+		"ukeu3k5oycgaauneqgtnvselmt4yemvoilkln7jpvamvfx7dnk.b32.i2p",
+		"ukeu3k5oycgaauneqgtnvselmt4yemvoilkln7jpvamvfx7dnk!!.b32.i2p",
+	}
+	for _, hostName := range tests {
+		_, err := ParseNetAddress(hostName, 16111)
+		if err == nil {
+			t.Errorf("ParseNetAddress(%s) unexpectedly succeeded", hostName)
+		}
+	}
+}
+
+func TestTorV3HostNameRoundTrip(t *testing.T) {
+	publicKey := bytes.Repeat([]byte{0xab}, TorV3PublicKeySize)
+	netAddress := NewNetAddressTorV3(publicKey, 16111)
+	if netAddress.IsIP() {
+		t.Fatalf("an onion address is an IP address")
+	}
+	if netAddress.TCPAddress().IP != nil {
+		t.Fatalf("the TCP address of an onion address has IP %s", netAddress.TCPAddress().IP)
+	}
+
+	host, port, err := net.SplitHostPort(netAddress.String())
+	if err != nil {
+		t.Fatalf("SplitHostPort: %+v", err)
+	}
+	if port != "16111" {
+		t.Fatalf("expected port 16111 but got %s", port)
+	}
+	parsedAddress, err := ParseNetAddress(host, 16111)
+	if err != nil {
+		t.Fatalf("ParseNetAddress: %+v", err)
+	}
+	if !bytes.Equal(parsedAddress.Host, publicKey) {
+		t.Fatalf("parsed public key %x instead of %x", parsedAddress.Host, publicKey)
+	}
+}
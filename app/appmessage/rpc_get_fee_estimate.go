@@ -0,0 +1,52 @@
+package appmessage
+
+// GetFeeEstimateRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetFeeEstimateRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetFeeEstimateRequestMessage) Command() MessageCommand {
+	return CmdGetFeeEstimateRequestMessage
+}
+
+// NewGetFeeEstimateRequestMessage returns a instance of the message
+func NewGetFeeEstimateRequestMessage() *GetFeeEstimateRequestMessage {
+	return &GetFeeEstimateRequestMessage{}
+}
+
+// RPCFeeRateBucket is a fee rate, in sompi per gram, along with the
+// estimated time it takes a transaction that pays it to be included
+type RPCFeeRateBucket struct {
+	FeeRate          float64
+	EstimatedSeconds float64
+}
+
+// RPCFeeEstimate holds the recommended fee rates for three levels of urgency
+type RPCFeeEstimate struct {
+	PriorityBucket *RPCFeeRateBucket
+	NormalBucket   *RPCFeeRateBucket
+	LowBucket      *RPCFeeRateBucket
+}
+
+// GetFeeEstimateResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetFeeEstimateResponseMessage struct {
+	baseMessage
+	Estimate *RPCFeeEstimate
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetFeeEstimateResponseMessage) Command() MessageCommand {
+	return CmdGetFeeEstimateResponseMessage
+}
+
+// NewGetFeeEstimateResponseMessage returns a instance of the message
+func NewGetFeeEstimateResponseMessage(estimate *RPCFeeEstimate) *GetFeeEstimateResponseMessage {
+	return &GetFeeEstimateResponseMessage{
+		Estimate: estimate,
+	}
+}
@@ -20,11 +20,12 @@ func NewGetInfoRequestMessage() *GetInfoRequestMessage {
 // its respective RPC message
 type GetInfoResponseMessage struct {
 	baseMessage
-	P2PID         string
-	MempoolSize   uint64
-	ServerVersion string
-	IsUtxoIndexed bool
-	IsSynced      bool
+	P2PID           string
+	MempoolSize     uint64
+	ServerVersion   string
+	IsUtxoIndexed   bool
+	IsSynced        bool
+	MinimumRelayFee uint64
 
 	Error *RPCError
 }
@@ -35,12 +36,14 @@ func (msg *GetInfoResponseMessage) Command() MessageCommand {
 }
 
 // NewGetInfoResponseMessage returns a instance of the message
-func NewGetInfoResponseMessage(p2pID string, mempoolSize uint64, serverVersion string, isUtxoIndexed bool, isSynced bool) *GetInfoResponseMessage {
+func NewGetInfoResponseMessage(p2pID string, mempoolSize uint64, serverVersion string, isUtxoIndexed bool, isSynced bool,
+	minimumRelayFee uint64) *GetInfoResponseMessage {
 	return &GetInfoResponseMessage{
-		P2PID:         p2pID,
-		MempoolSize:   mempoolSize,
-		ServerVersion: serverVersion,
-		IsUtxoIndexed: isUtxoIndexed,
-		IsSynced:      isSynced,
+		P2PID:           p2pID,
+		MempoolSize:     mempoolSize,
+		ServerVersion:   serverVersion,
+		IsUtxoIndexed:   isUtxoIndexed,
+		IsSynced:        isSynced,
+		MinimumRelayFee: minimumRelayFee,
 	}
 }
@@ -0,0 +1,47 @@
+package appmessage
+
+// GetTransactionRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetTransactionRequestMessage struct {
+	baseMessage
+	TransactionID string
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetTransactionRequestMessage) Command() MessageCommand {
+	return CmdGetTransactionRequestMessage
+}
+
+// NewGetTransactionRequestMessage returns a instance of the message
+func NewGetTransactionRequestMessage(transactionID string) *GetTransactionRequestMessage {
+	return &GetTransactionRequestMessage{
+		TransactionID: transactionID,
+	}
+}
+
+// GetTransactionResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetTransactionResponseMessage struct {
+	baseMessage
+	Transaction        *RPCTransaction
+	IncludingBlockHash string
+	AcceptingBlockHash string
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetTransactionResponseMessage) Command() MessageCommand {
+	return CmdGetTransactionResponseMessage
+}
+
+// NewGetTransactionResponseMessage returns a instance of the message
+func NewGetTransactionResponseMessage(transaction *RPCTransaction,
+	includingBlockHash string, acceptingBlockHash string) *GetTransactionResponseMessage {
+
+	return &GetTransactionResponseMessage{
+		Transaction:        transaction,
+		IncludingBlockHash: includingBlockHash,
+		AcceptingBlockHash: acceptingBlockHash,
+	}
+}
@@ -0,0 +1,52 @@
+package appmessage
+
+// GetTransactionAcceptanceRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetTransactionAcceptanceRequestMessage struct {
+	baseMessage
+	TransactionIDs []string
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetTransactionAcceptanceRequestMessage) Command() MessageCommand {
+	return CmdGetTransactionAcceptanceRequestMessage
+}
+
+// NewGetTransactionAcceptanceRequestMessage returns a instance of the message
+func NewGetTransactionAcceptanceRequestMessage(transactionIDs []string) *GetTransactionAcceptanceRequestMessage {
+	return &GetTransactionAcceptanceRequestMessage{
+		TransactionIDs: transactionIDs,
+	}
+}
+
+// GetTransactionAcceptanceResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetTransactionAcceptanceResponseMessage struct {
+	baseMessage
+	TransactionAcceptances []*RPCTransactionAcceptance
+
+	Error *RPCError
+}
+
+// RPCTransactionAcceptance states whether a transaction was accepted by the
+// virtual selected parent chain, and if so by which blocks
+type RPCTransactionAcceptance struct {
+	TransactionID      string
+	IsAccepted         bool
+	IncludingBlockHash string
+	AcceptingBlockHash string
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetTransactionAcceptanceResponseMessage) Command() MessageCommand {
+	return CmdGetTransactionAcceptanceResponseMessage
+}
+
+// NewGetTransactionAcceptanceResponseMessage returns a instance of the message
+func NewGetTransactionAcceptanceResponseMessage(
+	transactionAcceptances []*RPCTransactionAcceptance) *GetTransactionAcceptanceResponseMessage {
+
+	return &GetTransactionAcceptanceResponseMessage{
+		TransactionAcceptances: transactionAcceptances,
+	}
+}
@@ -0,0 +1,65 @@
+package appmessage
+
+// GetTransactionsByAddressesRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetTransactionsByAddressesRequestMessage struct {
+	baseMessage
+	Addresses    []string
+	FromDAAScore uint64
+	Limit        uint32
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetTransactionsByAddressesRequestMessage) Command() MessageCommand {
+	return CmdGetTransactionsByAddressesRequestMessage
+}
+
+// NewGetTransactionsByAddressesRequestMessage returns a instance of the message
+func NewGetTransactionsByAddressesRequestMessage(addresses []string, fromDAAScore uint64,
+	limit uint32) *GetTransactionsByAddressesRequestMessage {
+
+	return &GetTransactionsByAddressesRequestMessage{
+		Addresses:    addresses,
+		FromDAAScore: fromDAAScore,
+		Limit:        limit,
+	}
+}
+
+// GetTransactionsByAddressesResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetTransactionsByAddressesResponseMessage struct {
+	baseMessage
+	Entries []*TransactionsByAddressEntry
+
+	Error *RPCError
+}
+
+// TransactionsByAddressEntry represents a page of the history of an address
+type TransactionsByAddressEntry struct {
+	Address      string
+	Transactions []*RPCAddressTransaction
+	NextDAAScore uint64
+}
+
+// RPCAddressTransaction is an accepted transaction in the history of an address
+type RPCAddressTransaction struct {
+	TransactionID      string
+	AcceptingBlockHash string
+	DAAScore           uint64
+	ReceivedAmount     uint64
+	SpentAmount        uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetTransactionsByAddressesResponseMessage) Command() MessageCommand {
+	return CmdGetTransactionsByAddressesResponseMessage
+}
+
+// NewGetTransactionsByAddressesResponseMessage returns a instance of the message
+func NewGetTransactionsByAddressesResponseMessage(
+	entries []*TransactionsByAddressEntry) *GetTransactionsByAddressesResponseMessage {
+
+	return &GetTransactionsByAddressesResponseMessage{
+		Entries: entries,
+	}
+}
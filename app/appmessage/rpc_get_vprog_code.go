@@ -0,0 +1,41 @@
+package appmessage
+
+// GetVProgCodeRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetVProgCodeRequestMessage struct {
+	baseMessage
+	ProgramID string
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetVProgCodeRequestMessage) Command() MessageCommand {
+	return CmdGetVProgCodeRequestMessage
+}
+
+// NewGetVProgCodeRequestMessage returns a instance of the message
+func NewGetVProgCodeRequestMessage(programID string) *GetVProgCodeRequestMessage {
+	return &GetVProgCodeRequestMessage{
+		ProgramID: programID,
+	}
+}
+
+// GetVProgCodeResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetVProgCodeResponseMessage struct {
+	baseMessage
+	Code string
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetVProgCodeResponseMessage) Command() MessageCommand {
+	return CmdGetVProgCodeResponseMessage
+}
+
+// NewGetVProgCodeResponseMessage returns a instance of the message
+func NewGetVProgCodeResponseMessage(code string) *GetVProgCodeResponseMessage {
+	return &GetVProgCodeResponseMessage{
+		Code: code,
+	}
+}
@@ -0,0 +1,43 @@
+package appmessage
+
+// GetVProgStorageRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetVProgStorageRequestMessage struct {
+	baseMessage
+	ProgramID string
+	Keys      []string
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetVProgStorageRequestMessage) Command() MessageCommand {
+	return CmdGetVProgStorageRequestMessage
+}
+
+// NewGetVProgStorageRequestMessage returns a instance of the message
+func NewGetVProgStorageRequestMessage(programID string, keys []string) *GetVProgStorageRequestMessage {
+	return &GetVProgStorageRequestMessage{
+		ProgramID: programID,
+		Keys:      keys,
+	}
+}
+
+// GetVProgStorageResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetVProgStorageResponseMessage struct {
+	baseMessage
+	Entries []*RPCVProgStorageEntry
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetVProgStorageResponseMessage) Command() MessageCommand {
+	return CmdGetVProgStorageResponseMessage
+}
+
+// NewGetVProgStorageResponseMessage returns a instance of the message
+func NewGetVProgStorageResponseMessage(entries []*RPCVProgStorageEntry) *GetVProgStorageResponseMessage {
+	return &GetVProgStorageResponseMessage{
+		Entries: entries,
+	}
+}
@@ -0,0 +1,71 @@
+package appmessage
+
+// The events of MempoolChangedNotificationMessage
+const (
+	MempoolChangedEventAdded   = "added"
+	MempoolChangedEventRemoved = "removed"
+	MempoolChangedEventEvicted = "evicted"
+)
+
+// NotifyMempoolChangedRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type NotifyMempoolChangedRequestMessage struct {
+	baseMessage
+	IncludeTransactions bool
+}
+
+// Command returns the protocol command string for the message
+func (msg *NotifyMempoolChangedRequestMessage) Command() MessageCommand {
+	return CmdNotifyMempoolChangedRequestMessage
+}
+
+// NewNotifyMempoolChangedRequestMessage returns a instance of the message
+func NewNotifyMempoolChangedRequestMessage(includeTransactions bool) *NotifyMempoolChangedRequestMessage {
+	return &NotifyMempoolChangedRequestMessage{
+		IncludeTransactions: includeTransactions,
+	}
+}
+
+// NotifyMempoolChangedResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type NotifyMempoolChangedResponseMessage struct {
+	baseMessage
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *NotifyMempoolChangedResponseMessage) Command() MessageCommand {
+	return CmdNotifyMempoolChangedResponseMessage
+}
+
+// NewNotifyMempoolChangedResponseMessage returns a instance of the message
+func NewNotifyMempoolChangedResponseMessage() *NotifyMempoolChangedResponseMessage {
+	return &NotifyMempoolChangedResponseMessage{}
+}
+
+// MempoolChangedNotificationMessage is an appmessage corresponding to
+// its respective RPC message
+type MempoolChangedNotificationMessage struct {
+	baseMessage
+	Event         string
+	TransactionID string
+	RemovalReason string
+	Transaction   *RPCTransaction
+}
+
+// Command returns the protocol command string for the message
+func (msg *MempoolChangedNotificationMessage) Command() MessageCommand {
+	return CmdMempoolChangedNotificationMessage
+}
+
+// NewMempoolChangedNotificationMessage returns a instance of the message
+func NewMempoolChangedNotificationMessage(event string, transactionID string, removalReason string,
+	transaction *RPCTransaction) *MempoolChangedNotificationMessage {
+
+	return &MempoolChangedNotificationMessage{
+		Event:         event,
+		TransactionID: transactionID,
+		RemovalReason: removalReason,
+		Transaction:   transaction,
+	}
+}
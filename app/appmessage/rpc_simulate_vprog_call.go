@@ -0,0 +1,64 @@
+package appmessage
+
+// SimulateVProgCallRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type SimulateVProgCallRequestMessage struct {
+	baseMessage
+	Code     string
+	Data     string
+	GasLimit uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *SimulateVProgCallRequestMessage) Command() MessageCommand {
+	return CmdSimulateVProgCallRequestMessage
+}
+
+// NewSimulateVProgCallRequestMessage returns a instance of the message
+func NewSimulateVProgCallRequestMessage(code string, data string, gasLimit uint64) *SimulateVProgCallRequestMessage {
+	return &SimulateVProgCallRequestMessage{
+		Code:     code,
+		Data:     data,
+		GasLimit: gasLimit,
+	}
+}
+
+// SimulateVProgCallResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type SimulateVProgCallResponseMessage struct {
+	baseMessage
+	ProgramID      string
+	Success        bool
+	GasUsed        uint64
+	ReturnData     string
+	StateChanges   []*RPCVProgStorageEntry
+	ExecutionError string
+
+	Error *RPCError
+}
+
+// RPCVProgStorageEntry is a single key/value entry in the storage of a vprog.
+// An empty value in a state change means that the entry was deleted.
+type RPCVProgStorageEntry struct {
+	Key   string
+	Value string
+}
+
+// Command returns the protocol command string for the message
+func (msg *SimulateVProgCallResponseMessage) Command() MessageCommand {
+	return CmdSimulateVProgCallResponseMessage
+}
+
+// NewSimulateVProgCallResponseMessage returns a instance of the message
+func NewSimulateVProgCallResponseMessage(programID string, success bool, gasUsed uint64, returnData string,
+	stateChanges []*RPCVProgStorageEntry, executionError string) *SimulateVProgCallResponseMessage {
+
+	return &SimulateVProgCallResponseMessage{
+		ProgramID:      programID,
+		Success:        success,
+		GasUsed:        gasUsed,
+		ReturnData:     returnData,
+		StateChanges:   stateChanges,
+		ExecutionError: executionError,
+	}
+}
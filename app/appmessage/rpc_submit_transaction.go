@@ -6,21 +6,6 @@ type SubmitTransactionRequestMessage struct {
 	baseMessage
 	Transaction *RPCTransaction
 	AllowOrphan bool
-	VProgCode     string `json:"vprogCode,omitempty"`    
-    VProgData     string `json:"vprogData,omitempty"`    
-    VProgGasLimit uint64 `json:"vprogGasLimit,omitempty"`
-}
-
-type SubmitTransactionResponseMessage struct {
-    TransactionID string
-    VProgResult   *RPCVProgResult `json:"vprogResult,omitempty"`
-}
-
-type RPCVProgResult struct {
-    Success    bool   `json:"success"`
-    GasUsed    uint64 `json:"gasUsed"`
-    ReturnData string `json:"returnData"` 
-    Error      string `json:"error,omitempty"`
 }
 
 // Command returns the protocol command string for the message
@@ -68,6 +53,11 @@ type RPCTransaction struct {
 	Gas          uint64
 	Payload      string
 	VerboseData  *RPCTransactionVerboseData
+
+	VProgVersion  byte
+	VProgCode     string
+	VProgData     string
+	VProgGasLimit uint64
 }
 
 // RPCTransactionInput is a zuad transaction input representation
@@ -0,0 +1,41 @@
+package appmessage
+
+// SubmitTransactionPackageRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type SubmitTransactionPackageRequestMessage struct {
+	baseMessage
+	Transactions []*RPCTransaction
+}
+
+// Command returns the protocol command string for the message
+func (msg *SubmitTransactionPackageRequestMessage) Command() MessageCommand {
+	return CmdSubmitTransactionPackageRequestMessage
+}
+
+// NewSubmitTransactionPackageRequestMessage returns a instance of the message
+func NewSubmitTransactionPackageRequestMessage(transactions []*RPCTransaction) *SubmitTransactionPackageRequestMessage {
+	return &SubmitTransactionPackageRequestMessage{
+		Transactions: transactions,
+	}
+}
+
+// SubmitTransactionPackageResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type SubmitTransactionPackageResponseMessage struct {
+	baseMessage
+	TransactionIDs []string
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *SubmitTransactionPackageResponseMessage) Command() MessageCommand {
+	return CmdSubmitTransactionPackageResponseMessage
+}
+
+// NewSubmitTransactionPackageResponseMessage returns a instance of the message
+func NewSubmitTransactionPackageResponseMessage(transactionIDs []string) *SubmitTransactionPackageResponseMessage {
+	return &SubmitTransactionPackageResponseMessage{
+		TransactionIDs: transactionIDs,
+	}
+}
@@ -0,0 +1,62 @@
+package appmessage
+
+// ValidateTransactionRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type ValidateTransactionRequestMessage struct {
+	baseMessage
+	Transaction *RPCTransaction
+}
+
+// Command returns the protocol command string for the message
+func (msg *ValidateTransactionRequestMessage) Command() MessageCommand {
+	return CmdValidateTransactionRequestMessage
+}
+
+// NewValidateTransactionRequestMessage returns a instance of the message
+func NewValidateTransactionRequestMessage(transaction *RPCTransaction) *ValidateTransactionRequestMessage {
+	return &ValidateTransactionRequestMessage{
+		Transaction: transaction,
+	}
+}
+
+// ValidateTransactionResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type ValidateTransactionResponseMessage struct {
+	baseMessage
+	TransactionID string
+	Mass          uint64
+	Fee           uint64
+	FeeRate       float64
+	HasFee        bool
+	IsStandard    bool
+	IsOrphan      bool
+	IsAccepted    bool
+	RejectCode    string
+	RejectReason  string
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *ValidateTransactionResponseMessage) Command() MessageCommand {
+	return CmdValidateTransactionResponseMessage
+}
+
+// NewValidateTransactionResponseMessage returns a instance of the message
+func NewValidateTransactionResponseMessage(transactionID string, mass uint64, fee uint64, feeRate float64,
+	hasFee bool, isStandard bool, isOrphan bool, isAccepted bool, rejectCode string,
+	rejectReason string) *ValidateTransactionResponseMessage {
+
+	return &ValidateTransactionResponseMessage{
+		TransactionID: transactionID,
+		Mass:          mass,
+		Fee:           fee,
+		FeeRate:       feeRate,
+		HasFee:        hasFee,
+		IsStandard:    isStandard,
+		IsOrphan:      isOrphan,
+		IsAccepted:    isAccepted,
+		RejectCode:    rejectCode,
+		RejectReason:  rejectReason,
+	}
+}
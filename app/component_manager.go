@@ -2,16 +2,22 @@ package app
 
 import (
 	"fmt"
+	"net"
+	"path/filepath"
+	"strconv"
 	"sync/atomic"
 
 	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
 
 	"github.com/zuanet/zuad/domain/miningmanager/mempool"
+	miningmanagermodel "github.com/zuanet/zuad/domain/miningmanager/model"
 
 	"github.com/zuanet/zuad/app/protocol"
 	"github.com/zuanet/zuad/app/rpc"
 	"github.com/zuanet/zuad/domain"
 	"github.com/zuanet/zuad/domain/consensus"
+	"github.com/zuanet/zuad/domain/mempoolsnapshot"
+	"github.com/zuanet/zuad/domain/txindex"
 	"github.com/zuanet/zuad/domain/utxoindex"
 	"github.com/zuanet/zuad/infrastructure/config"
 	infrastructuredatabase "github.com/zuanet/zuad/infrastructure/db/database"
@@ -19,6 +25,8 @@ import (
 	"github.com/zuanet/zuad/infrastructure/network/connmanager"
 	"github.com/zuanet/zuad/infrastructure/network/netadapter"
 	"github.com/zuanet/zuad/infrastructure/network/netadapter/id"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/p2pencryption"
+	"github.com/zuanet/zuad/infrastructure/network/torcontrol"
 	"github.com/zuanet/zuad/util/panics"
 )
 
@@ -30,6 +38,8 @@ type ComponentManager struct {
 	rpcManager        *rpc.Manager
 	connectionManager *connmanager.ConnectionManager
 	netAdapter        *netadapter.NetAdapter
+	mempoolSnapshot   *mempoolsnapshot.MempoolSnapshot
+	onionService      *torcontrol.OnionService
 
 	started, shutdown int32
 }
@@ -49,6 +59,10 @@ func (a *ComponentManager) Start() {
 	}
 
 	a.connectionManager.Start()
+
+	if a.mempoolSnapshot != nil {
+		a.mempoolSnapshot.Start()
+	}
 }
 
 // Stop gracefully shuts down all the zuad services.
@@ -68,8 +82,23 @@ func (a *ComponentManager) Stop() {
 		log.Errorf("Error stopping the net adapter: %+v", err)
 	}
 
+	if a.onionService != nil {
+		err = a.onionService.Close()
+		if err != nil {
+			log.Errorf("Error removing the onion service: %+v", err)
+		}
+	}
+
+	if a.mempoolSnapshot != nil {
+		err = a.mempoolSnapshot.Stop()
+		if err != nil {
+			log.Errorf("Error saving the mempool snapshot: %+v", err)
+		}
+	}
+
 	a.protocolManager.Close()
 	close(a.protocolManager.Context().Domain().ConsensusEventsChannel())
+	a.protocolManager.Context().Domain().MiningManager().CloseMempoolEventsChannel()
 
 	return
 }
@@ -87,6 +116,7 @@ func NewComponentManager(cfg *config.Config, db infrastructuredatabase.Database,
 	mempoolConfig := mempool.DefaultConfig(&consensusConfig.Params)
 	mempoolConfig.MaximumOrphanTransactionCount = cfg.MaxOrphanTxs
 	mempoolConfig.MinimumRelayTransactionFee = cfg.MinRelayTxFee
+	mempoolConfig.EnableReplaceByFee = !cfg.DisableReplaceByFee
 
 	domain, err := domain.New(&consensusConfig, mempoolConfig, db)
 	if err != nil {
@@ -97,15 +127,37 @@ func NewComponentManager(cfg *config.Config, db infrastructuredatabase.Database,
 	if err != nil {
 		return nil, err
 	}
+	if !cfg.NoP2PEncryption {
+		p2pIdentity, err := p2pencryption.LoadOrCreateIdentity(filepath.Join(cfg.AppDir, p2pencryption.IdentityFileName))
+		if err != nil {
+			return nil, err
+		}
+		netAdapter.SetP2PIdentity(p2pIdentity)
+		log.Infof("P2P identity key: %x", p2pIdentity.PublicKey())
+	}
 
 	addressManager, err := addressmanager.New(addressmanager.NewConfig(cfg), db)
 	if err != nil {
 		return nil, err
 	}
+	for _, pinnedIdentityKey := range cfg.PinnedP2PIdentityKeys {
+		err := addressManager.SetIdentityKey(pinnedIdentityKey.Address, pinnedIdentityKey.IdentityKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var onionService *torcontrol.OnionService
+	if cfg.TorControl != "" {
+		onionService, err = createOnionService(cfg, addressManager)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	var utxoIndex *utxoindex.UTXOIndex
 	if cfg.UTXOIndex {
-		utxoIndex, err = utxoindex.New(domain, db)
+		utxoIndex, err = utxoindex.New(domain, db, cfg.AddressHistoryIndex)
 		if err != nil {
 			return nil, err
 		}
@@ -113,6 +165,25 @@ func NewComponentManager(cfg *config.Config, db infrastructuredatabase.Database,
 		log.Infof("UTXO index started")
 	}
 
+	var txIndex *txindex.TXIndex
+	if cfg.TXIndex {
+		txIndex, err = txindex.New(domain, db)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Infof("TX index started")
+	}
+
+	var mempoolSnapshot *mempoolsnapshot.MempoolSnapshot
+	if cfg.PersistMempool {
+		mempoolSnapshot = mempoolsnapshot.New(domain, db)
+		err = mempoolSnapshot.Restore()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	connectionManager, err := connmanager.New(cfg, netAdapter, addressManager)
 	if err != nil {
 		return nil, err
@@ -121,7 +192,8 @@ func NewComponentManager(cfg *config.Config, db infrastructuredatabase.Database,
 	if err != nil {
 		return nil, err
 	}
-	rpcManager := setupRPC(cfg, domain, netAdapter, protocolManager, connectionManager, addressManager, utxoIndex, domain.ConsensusEventsChannel(), interrupt)
+	rpcManager := setupRPC(cfg, domain, netAdapter, protocolManager, connectionManager, addressManager, utxoIndex,
+		txIndex, domain.ConsensusEventsChannel(), domain.MempoolEventsChannel(), interrupt)
 
 	return &ComponentManager{
 		cfg:               cfg,
@@ -130,10 +202,42 @@ func NewComponentManager(cfg *config.Config, db infrastructuredatabase.Database,
 		connectionManager: connectionManager,
 		netAdapter:        netAdapter,
 		addressManager:    addressManager,
+		mempoolSnapshot:   mempoolSnapshot,
+		onionService:      onionService,
 	}, nil
 
 }
 
+// createOnionService creates an onion service that forwards incoming
+// connections to the P2P listener, and advertises its address to peers
+func createOnionService(cfg *config.Config, addressManager *addressmanager.AddressManager) (
+	*torcontrol.OnionService, error) {
+
+	host, portString, err := net.SplitHostPort(cfg.Listeners[0])
+	if err != nil {
+		return nil, err
+	}
+	port, err := strconv.ParseUint(portString, 10, 16)
+	if err != nil {
+		return nil, err
+	}
+	if host == "" || net.ParseIP(host).IsUnspecified() {
+		host = "127.0.0.1"
+	}
+
+	onionService, err := torcontrol.CreateOnionService(cfg.TorControl, cfg.TorPassword,
+		filepath.Join(cfg.AppDir, torcontrol.OnionServiceKeyFileName), uint16(port), net.JoinHostPort(host, portString))
+	if err != nil {
+		return nil, err
+	}
+	err = addressManager.AddLocalAddress(onionService.NetAddress(), addressmanager.ManualPrio)
+	if err != nil {
+		onionService.Close()
+		return nil, err
+	}
+	return onionService, nil
+}
+
 func setupRPC(
 	cfg *config.Config,
 	domain domain.Domain,
@@ -142,7 +246,9 @@ func setupRPC(
 	connectionManager *connmanager.ConnectionManager,
 	addressManager *addressmanager.AddressManager,
 	utxoIndex *utxoindex.UTXOIndex,
+	txIndex *txindex.TXIndex,
 	consensusEventsChan chan externalapi.ConsensusEvent,
+	mempoolEventsChan chan miningmanagermodel.MempoolEvent,
 	shutDownChan chan<- struct{},
 ) *rpc.Manager {
 
@@ -154,7 +260,9 @@ func setupRPC(
 		connectionManager,
 		addressManager,
 		utxoIndex,
+		txIndex,
 		consensusEventsChan,
+		mempoolEventsChan,
 		shutDownChan,
 	)
 	protocolManager.SetOnNewBlockTemplateHandler(rpcManager.NotifyNewBlockTemplate)
@@ -12,7 +12,7 @@ import (
 
 var (
 	// ErrPingTimeout signifies that a ping operation timed out.
-	ErrPingTimeout = protocolerrors.New(false, "timeout expired on ping")
+	ErrPingTimeout = protocolerrors.OffenseErrorf(protocolerrors.OffenseSlowResponse, "timeout expired on ping")
 )
 
 // HandleError handles an error from a flow,
@@ -0,0 +1,33 @@
+package flowcontext
+
+import (
+	"github.com/zuanet/zuad/app/protocol/protocolerrors"
+	"github.com/zuanet/zuad/infrastructure/network/connmanager"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter"
+	"github.com/pkg/errors"
+)
+
+// AddOffense adds the weight of the given offense to the misbehavior score of
+// netConnection, bans it if its new score reaches the ban threshold, and
+// returns the response that the new score calls for.
+//
+// Disconnecting is left to the caller, so that it may notify the peer first.
+func (f *FlowContext) AddOffense(netConnection *netadapter.NetConnection,
+	offense protocolerrors.Offense, reason string) connmanager.MisbehaviorResponse {
+
+	score, response := f.connectionManager.AddMisbehavior(netConnection, offense.Weight())
+	log.Debugf("Misbehavior score of %s increased to %d due to %s (reason: %s)",
+		netConnection, score, offense, reason)
+
+	switch response {
+	case connmanager.MisbehaviorResponseBan:
+		log.Warnf("Banning %s with misbehavior score %d (reason: %s)", netConnection, score, reason)
+		err := f.connectionManager.Ban(netConnection)
+		if err != nil && !errors.Is(err, connmanager.ErrCannotBanPermanent) {
+			panic(err)
+		}
+	case connmanager.MisbehaviorResponseDeprioritize:
+		log.Infof("Deprioritizing %s with misbehavior score %d (reason: %s)", netConnection, score, reason)
+	}
+	return response
+}
@@ -59,6 +59,21 @@ func (f *FlowContext) Broadcast(message appmessage.Message) error {
 	return f.netAdapter.P2PBroadcast(f.readyPeerConnections(), message)
 }
 
+// broadcastFromProtocolVersion broadcasts the given message to all the ready peers
+// whose protocol version is at least the given one.
+func (f *FlowContext) broadcastFromProtocolVersion(protocolVersion uint32, message appmessage.Message) error {
+	f.peersMutex.RLock()
+	peerConnections := make([]*netadapter.NetConnection, 0, len(f.peers))
+	for _, peer := range f.peers {
+		if peer.ProtocolVersion() >= protocolVersion {
+			peerConnections = append(peerConnections, peer.Connection())
+		}
+	}
+	f.peersMutex.RUnlock()
+
+	return f.netAdapter.P2PBroadcast(peerConnections, message)
+}
+
 // Peers returns the currently active peers
 func (f *FlowContext) Peers() []*peerpkg.Peer {
 	f.peersMutex.RLock()
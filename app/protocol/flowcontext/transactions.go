@@ -11,6 +11,10 @@ import (
 // TransactionIDPropagationInterval is the interval between transaction IDs propagations
 const TransactionIDPropagationInterval = 500 * time.Millisecond
 
+// transactionPackageRelayProtocolVersion is the lowest protocol version that relays
+// packages of dependent transactions
+const transactionPackageRelayProtocolVersion = 6
+
 // AddTransaction adds transaction to the mempool and propagates it.
 func (f *FlowContext) AddTransaction(tx *externalapi.DomainTransaction, allowOrphan bool) error {
 	acceptedTransactions, err := f.Domain().MiningManager().ValidateAndInsertTransaction(tx, true, allowOrphan)
@@ -22,6 +26,59 @@ func (f *FlowContext) AddTransaction(tx *externalapi.DomainTransaction, allowOrp
 	return f.EnqueueTransactionIDsForPropagation(acceptedTransactionIDs)
 }
 
+// ReplaceTransaction adds transaction to the mempool in place of the transactions
+// it double spends, and propagates it. It returns the transactions that were
+// evicted from the mempool as a result.
+func (f *FlowContext) ReplaceTransaction(tx *externalapi.DomainTransaction) (
+	replacedTransactions []*externalapi.DomainTransaction, err error) {
+
+	acceptedTransactions, replacedTransactions, err := f.Domain().MiningManager().ValidateAndReplaceTransaction(tx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	acceptedTransactionIDs := consensushashing.TransactionIDs(acceptedTransactions)
+	err = f.EnqueueTransactionIDsForPropagation(acceptedTransactionIDs)
+	if err != nil {
+		return nil, err
+	}
+	return replacedTransactions, nil
+}
+
+// AddTransactionPackage adds the given package of dependent transactions to the mempool
+// together, and propagates it. It returns the transactions that were accepted to the
+// mempool as a result.
+func (f *FlowContext) AddTransactionPackage(transactions []*externalapi.DomainTransaction) (
+	acceptedTransactions []*externalapi.DomainTransaction, err error) {
+
+	acceptedTransactions, err = f.Domain().MiningManager().ValidateAndInsertTransactionPackage(transactions, true)
+	if err != nil {
+		return nil, err
+	}
+
+	err = f.PropagateTransactionPackage(transactions, acceptedTransactions)
+	if err != nil {
+		return nil, err
+	}
+	return acceptedTransactions, nil
+}
+
+// PropagateTransactionPackage announces the given package of dependent transactions to all
+// peers that relay packages, so that they too can request it and admit it together. The IDs
+// of the given accepted transactions are propagated to all peers as usual.
+func (f *FlowContext) PropagateTransactionPackage(transactions []*externalapi.DomainTransaction,
+	acceptedTransactions []*externalapi.DomainTransaction) error {
+
+	inv := appmessage.NewMsgInvTransactionPackage(consensushashing.TransactionIDs(transactions))
+	err := f.broadcastFromProtocolVersion(transactionPackageRelayProtocolVersion, inv)
+	if err != nil {
+		return err
+	}
+
+	acceptedTransactionIDs := consensushashing.TransactionIDs(acceptedTransactions)
+	return f.EnqueueTransactionIDsForPropagation(acceptedTransactionIDs)
+}
+
 func (f *FlowContext) shouldRebroadcastTransactions() bool {
 	const rebroadcastInterval = 30 * time.Second
 	return time.Since(f.lastRebroadcastTime) > rebroadcastInterval
@@ -11,6 +11,7 @@ import (
 
 	"github.com/zuanet/zuad/infrastructure/config"
 	"github.com/zuanet/zuad/infrastructure/network/netadapter"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/p2pencryption"
 
 	"github.com/zuanet/zuad/app/appmessage"
 	peerpkg "github.com/zuanet/zuad/app/protocol/peer"
@@ -47,9 +48,28 @@ func HandleHandshake(context HandleHandshakeContext, netConnection *netadapter.N
 
 	peer := peerpkg.New(netConnection)
 
+	// Encryption is offered only if the node has an identity, i.e. unless
+	// it was disabled with --nop2pencryption
+	var encryptionHandshake *p2pencryption.Handshake
+	if identity := context.NetAdapter().P2PIdentity(); identity != nil {
+		var err error
+		encryptionHandshake, err = p2pencryption.NewHandshake(identity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The local version is created up front, since the encryption keys are
+	// bound to it as well as to the version of the peer
+	localVersion, err := newLocalVersion(context, peer, encryptionHandshake)
+	if err != nil {
+		return nil, err
+	}
+
 	var peerAddress *appmessage.NetAddress
 	spawn("HandleHandshake-ReceiveVersion", func() {
-		address, err := ReceiveVersion(context, receiveVersionRoute, outgoingRoute, peer)
+		address, err := ReceiveVersion(context, receiveVersionRoute, outgoingRoute, peer, encryptionHandshake,
+			localVersion)
 		if err != nil {
 			handleError(err, "ReceiveVersion", &isStopping, errChan)
 			return
@@ -61,7 +81,7 @@ func HandleHandshake(context HandleHandshakeContext, netConnection *netadapter.N
 	})
 
 	spawn("HandleHandshake-SendVersion", func() {
-		err := SendVersion(context, sendVersionRoute, outgoingRoute, peer)
+		err := SendVersion(context, sendVersionRoute, outgoingRoute, peer, localVersion)
 		if err != nil {
 			handleError(err, "SendVersion", &isStopping, errChan)
 			return
@@ -80,7 +100,7 @@ func HandleHandshake(context HandleHandshakeContext, netConnection *netadapter.N
 	case <-doneChan:
 	}
 
-	err := context.AddToPeers(peer)
+	err = context.AddToPeers(peer)
 	if err != nil {
 		if errors.Is(err, common.ErrPeerWithSameIDExists) {
 			return nil, protocolerrors.Wrap(false, err, "peer already exists")
@@ -94,6 +114,14 @@ func HandleHandshake(context HandleHandshakeContext, netConnection *netadapter.N
 			return nil, err
 		}
 	}
+
+	// An outgoing address is only moved to the tried table once the peer
+	// completed the handshake, so that anything that merely accepts TCP
+	// connections can't get there. The error is ignored since peers that
+	// were added manually are not necessarily known to the address manager.
+	if peer.IsOutbound() {
+		_ = context.AddressManager().MarkConnectionSuccess(netConnection.NetAddress())
+	}
 	return peer, nil
 }
 
@@ -1,11 +1,15 @@
 package handshake
 
 import (
+	"bytes"
+	"encoding/binary"
+
 	"github.com/zuanet/zuad/app/appmessage"
 	"github.com/zuanet/zuad/app/protocol/common"
 	peerpkg "github.com/zuanet/zuad/app/protocol/peer"
 	"github.com/zuanet/zuad/app/protocol/protocolerrors"
 	"github.com/zuanet/zuad/infrastructure/logger"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/p2pencryption"
 	"github.com/zuanet/zuad/infrastructure/network/netadapter/router"
 	"github.com/pkg/errors"
 )
@@ -20,25 +24,34 @@ var (
 	// connected peer may support.
 	minAcceptableProtocolVersion = uint32(5)
 
-	maxAcceptableProtocolVersion = uint32(5)
+	maxAcceptableProtocolVersion = uint32(6)
 )
 
 type receiveVersionFlow struct {
 	HandleHandshakeContext
 	incomingRoute, outgoingRoute *router.Route
 	peer                         *peerpkg.Peer
+	encryptionHandshake          *p2pencryption.Handshake
+	localVersion                 *appmessage.MsgVersion
 }
 
 // ReceiveVersion waits for the peer to send a version message, sends a
 // verack in response, and updates its info accordingly.
+// If both encryptionHandshake and the offer in the peer's version are
+// present, the connection is encrypted before the verack is sent.
+// localVersion is the version sent to the peer, which the encryption keys
+// are bound to.
 func ReceiveVersion(context HandleHandshakeContext, incomingRoute *router.Route, outgoingRoute *router.Route,
-	peer *peerpkg.Peer) (*appmessage.NetAddress, error) {
+	peer *peerpkg.Peer, encryptionHandshake *p2pencryption.Handshake,
+	localVersion *appmessage.MsgVersion) (*appmessage.NetAddress, error) {
 
 	flow := &receiveVersionFlow{
 		HandleHandshakeContext: context,
 		incomingRoute:          incomingRoute,
 		outgoingRoute:          outgoingRoute,
 		peer:                   peer,
+		encryptionHandshake:    encryptionHandshake,
+		localVersion:           localVersion,
 	}
 
 	return flow.start()
@@ -104,6 +117,11 @@ func (flow *receiveVersionFlow) start() (*appmessage.NetAddress, error) {
 		return nil, errors.Errorf("%d is a non existing protocol version", flow.Config().ProtocolVersion)
 	}
 
+	err = flow.setUpEncryption(msgVersion)
+	if err != nil {
+		return nil, err
+	}
+
 	maxProtocolVersion := flow.Config().ProtocolVersion
 	flow.peer.UpdateFieldsFromMsgVersion(msgVersion, maxProtocolVersion)
 	err = flow.outgoingRoute.Enqueue(appmessage.NewMsgVerAck())
@@ -115,3 +133,120 @@ func (flow *receiveVersionFlow) start() (*appmessage.NetAddress, error) {
 
 	return msgVersion.Address, nil
 }
+
+// setUpEncryption encrypts the connection if both sides offered it. Peers
+// that don't offer encryption - such as older nodes - stay unencrypted,
+// unless --requirep2pencryption is set or their identity key is known.
+//
+// The identity keys of outbound peers are pinned: the first time a peer at
+// some address proves it holds an identity key, the key is stored in the
+// address manager, and from then on the peer at that address must prove it
+// holds the same key. Keys can also be pinned in advance with --addpeer and
+// --connect. Inbound peers connect from arbitrary ports, so their identity
+// keys are not checked.
+func (flow *receiveVersionFlow) setUpEncryption(msgVersion *appmessage.MsgVersion) error {
+	isOutbound := flow.peer.Connection().IsOutbound()
+	peerAddress := flow.peer.Connection().NetAddress()
+	var expectedIdentityKey []byte
+	if isOutbound && flow.encryptionHandshake != nil {
+		expectedIdentityKey = flow.AddressManager().IdentityKey(peerAddress)
+	}
+
+	if flow.encryptionHandshake == nil || msgVersion.EncryptionOffer == nil {
+		if expectedIdentityKey != nil {
+			return protocolerrors.Errorf(false, "peer did not offer encryption, but is expected to "+
+				"prove it holds identity key %x", expectedIdentityKey)
+		}
+		if flow.Config().RequireP2PEncryption {
+			return protocolerrors.New(false, "peer does not support encrypted connections")
+		}
+		log.Debugf("The connection to %s is not encrypted", flow.peer)
+		return nil
+	}
+
+	remoteOffer := &p2pencryption.Offer{
+		IdentityPublicKey:  msgVersion.EncryptionOffer.IdentityPublicKey,
+		EphemeralPublicKey: msgVersion.EncryptionOffer.EphemeralPublicKey,
+		Signature:          msgVersion.EncryptionOffer.Signature,
+	}
+	if expectedIdentityKey != nil && !bytes.Equal(remoteOffer.IdentityPublicKey, expectedIdentityKey) {
+		return protocolerrors.Errorf(false, "peer offered identity key %x, but is expected to "+
+			"prove it holds identity key %x", remoteOffer.IdentityPublicKey, expectedIdentityKey)
+	}
+
+	localVersion, err := serializeVersion(flow.localVersion)
+	if err != nil {
+		return err
+	}
+	remoteVersion, err := serializeVersion(msgVersion)
+	if err != nil {
+		return err
+	}
+	session, err := flow.encryptionHandshake.Complete(remoteOffer, isOutbound, localVersion, remoteVersion)
+	if err != nil {
+		return protocolerrors.Wrap(true, err, "invalid encryption offer")
+	}
+	err = flow.peer.Connection().EnableEncryption(session)
+	if err != nil {
+		return err
+	}
+	log.Debugf("Encrypted the connection to %s, whose identity key is %x",
+		flow.peer, session.RemoteIdentityKey())
+
+	if isOutbound && expectedIdentityKey == nil {
+		err = flow.AddressManager().SetIdentityKey(peerAddress, session.RemoteIdentityKey())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// serializeVersion serializes a version message for binding it into the
+// encryption keys. Every field that is sent over the wire is serialized,
+// variable length fields are prefixed with their length, and optional fields
+// with whether they are present, so that no two messages serialize the same.
+func serializeVersion(msgVersion *appmessage.MsgVersion) ([]byte, error) {
+	serializedID, err := msgVersion.ID.SerializeToBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	// Writing to a bytes.Buffer never fails
+	serialized := &bytes.Buffer{}
+	writeVarBytes := func(data []byte) {
+		_ = binary.Write(serialized, binary.LittleEndian, uint32(len(data)))
+		serialized.Write(data)
+	}
+	fields := []interface{}{
+		msgVersion.ProtocolVersion,
+		msgVersion.Services,
+		msgVersion.Timestamp.UnixMilliseconds(),
+		msgVersion.DisableRelayTx,
+		msgVersion.Address != nil,
+		msgVersion.SubnetworkID != nil,
+		msgVersion.EncryptionOffer != nil,
+	}
+	for _, field := range fields {
+		_ = binary.Write(serialized, binary.LittleEndian, field)
+	}
+	writeVarBytes([]byte(msgVersion.Network))
+	writeVarBytes(serializedID)
+	writeVarBytes([]byte(msgVersion.UserAgent))
+	if msgVersion.Address != nil {
+		_ = binary.Write(serialized, binary.LittleEndian, msgVersion.Address.Timestamp.UnixMilliseconds())
+		_ = binary.Write(serialized, binary.LittleEndian, msgVersion.Address.Network)
+		_ = binary.Write(serialized, binary.LittleEndian, msgVersion.Address.Port)
+		writeVarBytes(msgVersion.Address.IP)
+		writeVarBytes(msgVersion.Address.Host)
+	}
+	if msgVersion.SubnetworkID != nil {
+		serialized.Write(msgVersion.SubnetworkID[:])
+	}
+	if msgVersion.EncryptionOffer != nil {
+		writeVarBytes(msgVersion.EncryptionOffer.IdentityPublicKey)
+		writeVarBytes(msgVersion.EncryptionOffer.EphemeralPublicKey)
+		writeVarBytes(msgVersion.EncryptionOffer.Signature)
+	}
+	return serialized.Bytes(), nil
+}
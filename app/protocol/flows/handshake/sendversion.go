@@ -5,6 +5,7 @@ import (
 	"github.com/zuanet/zuad/app/protocol/common"
 	peerpkg "github.com/zuanet/zuad/app/protocol/peer"
 	"github.com/zuanet/zuad/infrastructure/logger"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/p2pencryption"
 	"github.com/zuanet/zuad/infrastructure/network/netadapter/router"
 	"github.com/zuanet/zuad/version"
 	"github.com/pkg/errors"
@@ -34,47 +35,65 @@ type sendVersionFlow struct {
 
 	incomingRoute, outgoingRoute *router.Route
 	peer                         *peerpkg.Peer
+	msgVersion                   *appmessage.MsgVersion
 }
 
-// SendVersion sends a version to a peer and waits for verack.
+// SendVersion sends the given version to a peer and waits for verack.
 func SendVersion(context HandleHandshakeContext, incomingRoute *router.Route,
-	outgoingRoute *router.Route, peer *peerpkg.Peer) error {
+	outgoingRoute *router.Route, peer *peerpkg.Peer, msgVersion *appmessage.MsgVersion) error {
 
 	flow := &sendVersionFlow{
 		HandleHandshakeContext: context,
 		incomingRoute:          incomingRoute,
 		outgoingRoute:          outgoingRoute,
 		peer:                   peer,
+		msgVersion:             msgVersion,
 	}
 	return flow.start()
 }
 
-func (flow *sendVersionFlow) start() error {
-	onEnd := logger.LogAndMeasureExecutionTime(log, "sendVersionFlow.start")
-	defer onEnd()
+// newLocalVersion creates the version message to send to the given peer.
+// If encryptionHandshake is not nil, its offer is advertised in the version.
+func newLocalVersion(context HandleHandshakeContext, peer *peerpkg.Peer,
+	encryptionHandshake *p2pencryption.Handshake) (*appmessage.MsgVersion, error) {
 
-	log.Debugf("Starting sendVersionFlow with %s", flow.peer.Address())
-
-	// Version message.
-	localAddress := flow.AddressManager().BestLocalAddress(flow.peer.Connection().NetAddress())
-	subnetworkID := flow.Config().SubnetworkID
-	if flow.Config().ProtocolVersion < minAcceptableProtocolVersion {
-		return errors.Errorf("configured protocol version %d is obsolete", flow.Config().ProtocolVersion)
+	localAddress := context.AddressManager().BestLocalAddress(peer.Connection().NetAddress())
+	subnetworkID := context.Config().SubnetworkID
+	if context.Config().ProtocolVersion < minAcceptableProtocolVersion {
+		return nil, errors.Errorf("configured protocol version %d is obsolete", context.Config().ProtocolVersion)
 	}
-	msg := appmessage.NewMsgVersion(localAddress, flow.NetAdapter().ID(),
-		flow.Config().ActiveNetParams.Name, subnetworkID, flow.Config().ProtocolVersion)
-	msg.AddUserAgent(userAgentName, userAgentVersion, flow.Config().UserAgentComments...)
+	msg := appmessage.NewMsgVersion(localAddress, context.NetAdapter().ID(),
+		context.Config().ActiveNetParams.Name, subnetworkID, context.Config().ProtocolVersion)
+	msg.AddUserAgent(userAgentName, userAgentVersion, context.Config().UserAgentComments...)
 
 	// Advertise the services flag
 	msg.Services = defaultServices
 
 	// Advertise our max supported protocol version.
-	msg.ProtocolVersion = flow.Config().ProtocolVersion
+	msg.ProtocolVersion = context.Config().ProtocolVersion
 
 	// Advertise if inv messages for transactions are desired.
-	msg.DisableRelayTx = flow.Config().BlocksOnly
+	msg.DisableRelayTx = context.Config().BlocksOnly
+
+	// Offer to encrypt the connection
+	if encryptionHandshake != nil {
+		offer := encryptionHandshake.Offer()
+		msg.EncryptionOffer = &appmessage.EncryptionOffer{
+			IdentityPublicKey:  offer.IdentityPublicKey,
+			EphemeralPublicKey: offer.EphemeralPublicKey,
+			Signature:          offer.Signature,
+		}
+	}
+	return msg, nil
+}
+
+func (flow *sendVersionFlow) start() error {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "sendVersionFlow.start")
+	defer onEnd()
+
+	log.Debugf("Starting sendVersionFlow with %s", flow.peer.Address())
 
-	err := flow.outgoingRoute.Enqueue(msg)
+	err := flow.outgoingRoute.Enqueue(flow.msgVersion)
 	if err != nil {
 		return err
 	}
@@ -35,5 +35,6 @@ func ReceiveAddresses(context ReceiveAddressesContext, incomingRoute *router.Rou
 		return protocolerrors.Errorf(true, "address count exceeded %d", addressmanager.GetAddressesMax)
 	}
 
-	return context.AddressManager().AddAddresses(msgAddresses.AddressList...)
+	return context.AddressManager().AddAddressesFromSource(peer.Connection().NetAddress(),
+		msgAddresses.AddressList...)
 }
@@ -83,27 +83,62 @@ func (flow *handleRequestPruningPointUTXOSetFlow) sendPruningPointUTXOSet(
 	// Send the UTXO set in `step`-sized chunks
 	const step = 1000
 	var fromOutpoint *externalapi.DomainOutpoint
+	var fromVProgStateEntry *externalapi.VProgStateEntry
+	sentAllUTXOs := false
 	chunksSent := 0
 	for {
-		pruningPointUTXOs, err := flow.Domain().Consensus().GetPruningPointUTXOs(
-			msgRequestPruningPointUTXOSet.PruningPointHash, fromOutpoint, step)
-		if err != nil {
-			if errors.Is(err, ruleerrors.ErrWrongPruningPointHash) {
-				return flow.outgoingRoute.Enqueue(appmessage.NewMsgUnexpectedPruningPoint())
+		var pruningPointUTXOs []*externalapi.OutpointAndUTXOEntryPair
+		if !sentAllUTXOs {
+			var err error
+			pruningPointUTXOs, err = flow.Domain().Consensus().GetPruningPointUTXOs(
+				msgRequestPruningPointUTXOSet.PruningPointHash, fromOutpoint, step)
+			if err != nil {
+				if errors.Is(err, ruleerrors.ErrWrongPruningPointHash) {
+					return flow.outgoingRoute.Enqueue(appmessage.NewMsgUnexpectedPruningPoint())
+				}
 			}
+
+			log.Debugf("Retrieved %d UTXOs for pruning block %s",
+				len(pruningPointUTXOs), msgRequestPruningPointUTXOSet.PruningPointHash)
+
+			if len(pruningPointUTXOs) > 0 {
+				fromOutpoint = pruningPointUTXOs[len(pruningPointUTXOs)-1].Outpoint
+			}
+			sentAllUTXOs = len(pruningPointUTXOs) < step
 		}
 
-		log.Debugf("Retrieved %d UTXOs for pruning block %s",
-			len(pruningPointUTXOs), msgRequestPruningPointUTXOSet.PruningPointHash)
+		// The vprog state of the pruning point is committed to along with its
+		// UTXO set, so it fills the chunks that follow the last UTXO
+		var vprogStateEntries []*externalapi.VProgStateEntry
+		if sentAllUTXOs {
+			var err error
+			vprogStateEntries, err = flow.Domain().Consensus().GetPruningPointVProgState(
+				msgRequestPruningPointUTXOSet.PruningPointHash, fromVProgStateEntry, step-len(pruningPointUTXOs))
+			if err != nil {
+				if errors.Is(err, ruleerrors.ErrWrongPruningPointHash) {
+					return flow.outgoingRoute.Enqueue(appmessage.NewMsgUnexpectedPruningPoint())
+				}
+				return err
+			}
+
+			log.Debugf("Retrieved %d vprog state entries for pruning block %s",
+				len(vprogStateEntries), msgRequestPruningPointUTXOSet.PruningPointHash)
+
+			if len(vprogStateEntries) > 0 {
+				fromVProgStateEntry = vprogStateEntries[len(vprogStateEntries)-1]
+			}
+		}
 
 		outpointAndUTXOEntryPairs :=
 			appmessage.DomainOutpointAndUTXOEntryPairsToOutpointAndUTXOEntryPairs(pruningPointUTXOs)
-		err = flow.outgoingRoute.Enqueue(appmessage.NewMsgPruningPointUTXOSetChunk(outpointAndUTXOEntryPairs))
+		chunk := appmessage.NewMsgPruningPointUTXOSetChunk(outpointAndUTXOEntryPairs)
+		chunk.VProgStateEntries = vprogStateEntries
+		err := flow.outgoingRoute.Enqueue(chunk)
 		if err != nil {
 			return err
 		}
 
-		finished := len(pruningPointUTXOs) < step
+		finished := len(pruningPointUTXOs)+len(vprogStateEntries) < step
 		if finished && chunksSent%ibdBatchSize != 0 {
 			log.Debugf("Finished sending UTXOs for pruning block %s",
 				msgRequestPruningPointUTXOSet.PruningPointHash)
@@ -111,9 +146,6 @@ func (flow *handleRequestPruningPointUTXOSetFlow) sendPruningPointUTXOSet(
 			return flow.outgoingRoute.Enqueue(appmessage.NewMsgDonePruningPointUTXOSetChunks())
 		}
 
-		if len(pruningPointUTXOs) > 0 {
-			fromOutpoint = pruningPointUTXOs[len(pruningPointUTXOs)-1].Outpoint
-		}
 		chunksSent++
 
 		// Wait for the peer to request more chunks every `ibdBatchSize` chunks
@@ -189,7 +189,8 @@ func (flow *handleIBDFlow) negotiateMissingSyncerChainSegment() (*externalapi.Do
 			}
 			if info.Exists {
 				if info.BlockStatus == externalapi.StatusInvalid {
-					return nil, nil, protocolerrors.Errorf(true, "Sent invalid chain block %s", syncerChainHash)
+					return nil, nil, protocolerrors.OffenseErrorf(protocolerrors.OffenseInvalidBlock,
+						"Sent invalid chain block %s", syncerChainHash)
 				}
 
 				isPruningPointOnSyncerChain, err := flow.Domain().Consensus().IsInSelectedParentChainOf(pruningPoint, syncerChainHash)
@@ -526,7 +527,8 @@ func (flow *handleIBDFlow) processHeader(consensus externalapi.Consensus, msgBlo
 			log.Debugf("Skipping block header %s as it is a duplicate", blockHash)
 		} else {
 			log.Infof("Rejected block header %s from %s during IBD: %s", blockHash, flow.peer, err)
-			return protocolerrors.Wrapf(true, err, "got invalid block header %s during IBD", blockHash)
+			return protocolerrors.OffenseWrapf(protocolerrors.OffenseInvalidBlock, err,
+				"got invalid block header %s during IBD", blockHash)
 		}
 	}
 
@@ -575,6 +577,7 @@ func (flow *handleIBDFlow) receiveAndInsertPruningPointUTXOSet(
 
 	receivedChunkCount := 0
 	receivedUTXOCount := 0
+	receivedVProgStateEntryCount := 0
 	for {
 		message, err := flow.incomingRoute.DequeueWithTimeout(common.DefaultTimeout)
 		if err != nil {
@@ -592,6 +595,20 @@ func (flow *handleIBDFlow) receiveAndInsertPruningPointUTXOSet(
 				return false, err
 			}
 
+			if len(message.VProgStateEntries) > 0 {
+				for _, vprogStateEntry := range message.VProgStateEntries {
+					// Deleted entries are not part of the state
+					if len(vprogStateEntry.Value) == 0 {
+						return false, protocolerrors.Errorf(true, "received an empty vprog state entry")
+					}
+				}
+				receivedVProgStateEntryCount += len(message.VProgStateEntries)
+				err := consensus.AppendImportedPruningPointVProgState(message.VProgStateEntries)
+				if err != nil {
+					return false, err
+				}
+			}
+
 			receivedChunkCount++
 			if receivedChunkCount%ibdBatchSize == 0 {
 				log.Infof("Received %d UTXO set chunks so far, totaling in %d UTXOs",
@@ -605,7 +622,8 @@ func (flow *handleIBDFlow) receiveAndInsertPruningPointUTXOSet(
 			}
 
 		case *appmessage.MsgDonePruningPointUTXOSetChunks:
-			log.Infof("Finished receiving the UTXO set. Total UTXOs: %d", receivedUTXOCount)
+			log.Infof("Finished receiving the UTXO set. Total UTXOs: %d, total vprog state entries: %d",
+				receivedUTXOCount, receivedVProgStateEntryCount)
 			return true, nil
 
 		case *appmessage.MsgUnexpectedPruningPoint:
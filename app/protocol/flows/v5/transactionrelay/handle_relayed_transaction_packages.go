@@ -0,0 +1,178 @@
+package transactionrelay
+
+import (
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/app/protocol/common"
+	peerpkg "github.com/zuanet/zuad/app/protocol/peer"
+	"github.com/zuanet/zuad/app/protocol/protocolerrors"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/utils/consensushashing"
+	"github.com/zuanet/zuad/domain/miningmanager/mempool"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/router"
+	"github.com/pkg/errors"
+)
+
+type handleRelayedTransactionPackagesFlow struct {
+	TransactionsRelayContext
+	peer                         *peerpkg.Peer
+	incomingRoute, outgoingRoute *router.Route
+	invsQueue                    []*appmessage.MsgInvTransactionPackage
+}
+
+// HandleRelayedTransactionPackages listens to appmessage.MsgInvTransactionPackage messages, requests
+// the packages they announce if any of their transactions are missing, adds the transactions to the
+// mempool together and propagates them to the rest of the network.
+// Transaction packages are relayed from protocol version 6 and above.
+func HandleRelayedTransactionPackages(context TransactionsRelayContext, peer *peerpkg.Peer,
+	incomingRoute *router.Route, outgoingRoute *router.Route) error {
+
+	flow := &handleRelayedTransactionPackagesFlow{
+		TransactionsRelayContext: context,
+		peer:                     peer,
+		incomingRoute:            incomingRoute,
+		outgoingRoute:            outgoingRoute,
+		invsQueue:                make([]*appmessage.MsgInvTransactionPackage, 0),
+	}
+	return flow.start()
+}
+
+func (flow *handleRelayedTransactionPackagesFlow) start() error {
+	for {
+		inv, err := flow.readInv()
+		if err != nil {
+			return err
+		}
+
+		isNearlySynced, err := flow.IsNearlySynced()
+		if err != nil {
+			return err
+		}
+		// Transaction relay is disabled if the node is out of sync and thus not mining
+		if !isNearlySynced {
+			continue
+		}
+
+		err = flow.handleInv(inv)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (flow *handleRelayedTransactionPackagesFlow) readInv() (*appmessage.MsgInvTransactionPackage, error) {
+	if len(flow.invsQueue) > 0 {
+		var inv *appmessage.MsgInvTransactionPackage
+		inv, flow.invsQueue = flow.invsQueue[0], flow.invsQueue[1:]
+		return inv, nil
+	}
+
+	message, err := flow.incomingRoute.Dequeue()
+	if err != nil {
+		return nil, err
+	}
+	inv, ok := message.(*appmessage.MsgInvTransactionPackage)
+	if !ok {
+		return nil, protocolerrors.Errorf(true, "unexpected %s message while waiting for "+
+			"a transaction package announcement", message.Command())
+	}
+	return inv, nil
+}
+
+func (flow *handleRelayedTransactionPackagesFlow) handleInv(inv *appmessage.MsgInvTransactionPackage) error {
+	if len(inv.TxIDs) == 0 {
+		return protocolerrors.Errorf(true, "received an empty transaction package announcement")
+	}
+	// The maximum package size is a local policy, so larger packages are ignored
+	// rather than treated as misbehavior
+	if uint64(len(inv.TxIDs)) > flow.Domain().MiningManager().MaximumTransactionPackageSize() {
+		return nil
+	}
+	if flow.hasAllTransactions(inv.TxIDs) {
+		return nil
+	}
+
+	err := flow.outgoingRoute.Enqueue(appmessage.NewMsgRequestTransactionPackage(inv.TxIDs))
+	if err != nil {
+		return err
+	}
+	msgTransactionPackage, err := flow.readTransactionPackage()
+	if err != nil {
+		return err
+	}
+	// The peer no longer has the package, possibly because some of its transactions were
+	// included in a block in the meantime
+	if len(msgTransactionPackage.Transactions) == 0 {
+		return nil
+	}
+
+	if len(msgTransactionPackage.Transactions) != len(inv.TxIDs) {
+		return protocolerrors.Errorf(true, "requested a transaction package of %d transactions "+
+			"but got %d", len(inv.TxIDs), len(msgTransactionPackage.Transactions))
+	}
+	transactions := make([]*externalapi.DomainTransaction, len(msgTransactionPackage.Transactions))
+	for i, msgTx := range msgTransactionPackage.Transactions {
+		transactions[i] = appmessage.MsgTxToDomainTransaction(msgTx)
+		transactionID := consensushashing.TransactionID(transactions[i])
+		if !transactionID.Equal(inv.TxIDs[i]) {
+			return protocolerrors.Errorf(true, "requested transaction %s at index %d of a transaction "+
+				"package but got %s", inv.TxIDs[i], i, transactionID)
+		}
+	}
+
+	return flow.handleTransactionPackage(transactions)
+}
+
+// hasAllTransactions returns whether all the given transactions are already in the transaction pool
+func (flow *handleRelayedTransactionPackagesFlow) hasAllTransactions(
+	transactionIDs []*externalapi.DomainTransactionID) bool {
+
+	for _, transactionID := range transactionIDs {
+		_, _, ok := flow.Domain().MiningManager().GetTransaction(transactionID, true, false)
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// readTransactionPackage waits for the response to a package request. Announcements that
+// arrive in the meantime are queued to be handled later.
+func (flow *handleRelayedTransactionPackagesFlow) readTransactionPackage() (*appmessage.MsgTransactionPackage, error) {
+	for {
+		message, err := flow.incomingRoute.DequeueWithTimeout(common.DefaultTimeout)
+		if err != nil {
+			return nil, err
+		}
+
+		switch message := message.(type) {
+		case *appmessage.MsgInvTransactionPackage:
+			flow.invsQueue = append(flow.invsQueue, message)
+		case *appmessage.MsgTransactionPackage:
+			return message, nil
+		default:
+			return nil, errors.Errorf("unexpected message %s", message.Command())
+		}
+	}
+}
+
+func (flow *handleRelayedTransactionPackagesFlow) handleTransactionPackage(
+	transactions []*externalapi.DomainTransaction) error {
+
+	acceptedTransactions, err :=
+		flow.Domain().MiningManager().ValidateAndInsertTransactionPackage(transactions, false)
+	if err != nil {
+		ruleErr := &mempool.RuleError{}
+		if !errors.As(err, ruleErr) {
+			return errors.Wrapf(err, "failed to process transaction package")
+		}
+
+		return addOffenseIfMisbehaving(flow, flow.peer, ruleErr)
+	}
+
+	err = flow.PropagateTransactionPackage(transactions, acceptedTransactions)
+	if err != nil {
+		return err
+	}
+	flow.OnTransactionAddedToMempool()
+	return nil
+}
@@ -0,0 +1,188 @@
+package transactionrelay_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/app/protocol/flowcontext"
+	"github.com/zuanet/zuad/app/protocol/flows/v5/transactionrelay"
+	peerpkg "github.com/zuanet/zuad/app/protocol/peer"
+	"github.com/zuanet/zuad/app/protocol/protocolerrors"
+	"github.com/zuanet/zuad/domain"
+	"github.com/zuanet/zuad/domain/consensus"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/utils/consensushashing"
+	"github.com/zuanet/zuad/domain/consensus/utils/testutils"
+	"github.com/zuanet/zuad/domain/miningmanager/mempool"
+	"github.com/zuanet/zuad/infrastructure/config"
+	"github.com/zuanet/zuad/infrastructure/network/connmanager"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/router"
+)
+
+// transactionPackageTestContext creates a relay context for the package relay tests, along
+// with a funded parent transaction and a child that spends it
+func transactionPackageTestContext(t *testing.T, consensusConfig *consensus.Config, testName string) (
+	context *mocTransactionsRelayContext, parentTransaction, childTransaction *externalapi.DomainTransaction,
+	teardown func()) {
+
+	factory := consensus.NewFactory()
+	tc, teardownFunc, err := factory.NewTestConsensus(consensusConfig, testName)
+	if err != nil {
+		t.Fatalf("Error setting up test consensus: %+v", err)
+	}
+
+	adapter, err := netadapter.NewNetAdapter(config.DefaultConfig())
+	if err != nil {
+		t.Fatalf("Failed to create a NetAdapter: %v", err)
+	}
+	domainInstance, err := domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), tc.Database())
+	if err != nil {
+		t.Fatalf("Failed to set up a domain instance: %v", err)
+	}
+	context = &mocTransactionsRelayContext{
+		netAdapter:                  adapter,
+		domain:                      domainInstance,
+		sharedRequestedTransactions: flowcontext.NewSharedRequestedTransactions(),
+	}
+
+	scriptPublicKey, _ := testutils.OpTrueScript()
+	fundingTransaction := &externalapi.DomainTransaction{
+		Outputs: []*externalapi.DomainTransactionOutput{{Value: 100000000, ScriptPublicKey: scriptPublicKey}},
+	}
+	parentTransaction, err = testutils.CreateTransaction(fundingTransaction, 1000)
+	if err != nil {
+		t.Fatalf("Error creating transaction: %+v", err)
+	}
+	childTransaction, err = testutils.CreateTransaction(parentTransaction, 1000)
+	if err != nil {
+		t.Fatalf("Error creating transaction: %+v", err)
+	}
+	return context, parentTransaction, childTransaction, func() { teardownFunc(false) }
+}
+
+// TestHandleRelayedTransactionPackagesUnsorted verifies that a peer that relays a package
+// in which a child comes before its parent is charged with an invalid transaction offense.
+func TestHandleRelayedTransactionPackagesUnsorted(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		context, parentTransaction, childTransaction, teardown :=
+			transactionPackageTestContext(t, consensusConfig, "TestHandleRelayedTransactionPackagesUnsorted")
+		defer teardown()
+		context.misbehaviorResponse = connmanager.MisbehaviorResponseBan
+
+		incomingRoute := router.NewRoute("incoming")
+		defer incomingRoute.Close()
+		outgoingRoute := router.NewRoute("outgoing")
+		defer outgoingRoute.Close()
+
+		unsortedPackage := []*externalapi.DomainTransaction{childTransaction, parentTransaction}
+		err := incomingRoute.Enqueue(appmessage.NewMsgInvTransactionPackage(consensushashing.TransactionIDs(unsortedPackage)))
+		if err != nil {
+			t.Fatalf("Unexpected error from incomingRoute.Enqueue: %v", err)
+		}
+		err = incomingRoute.Enqueue(appmessage.NewMsgTransactionPackage([]*appmessage.MsgTx{
+			appmessage.DomainTransactionToMsgTx(childTransaction),
+			appmessage.DomainTransactionToMsgTx(parentTransaction),
+		}))
+		if err != nil {
+			t.Fatalf("Unexpected error from incomingRoute.Enqueue: %v", err)
+		}
+
+		err = transactionrelay.HandleRelayedTransactionPackages(context, peerpkg.New(nil), incomingRoute, outgoingRoute)
+		if protocolErr := (protocolerrors.ProtocolError{}); err == nil || !errors.As(err, &protocolErr) {
+			t.Fatalf("Expected a protocol error, but got: %v", err)
+		} else {
+			// The offense was already added, so handling the error must not add to the misbehavior score again
+			if protocolErr.ShouldBan {
+				t.Fatalf("Expected shouldBan false, but got true.")
+			}
+			if !strings.Contains(err.Error(), "appears in the package before its parent") {
+				t.Fatalf("Unexpected error: expected an error due to the package being unsorted, but got: %v", err)
+			}
+		}
+		if len(context.offenses) != 1 || context.offenses[0] != protocolerrors.OffenseInvalidTransaction {
+			t.Fatalf("Expected a single invalid transaction offense, but got %v", context.offenses)
+		}
+
+		message, err := outgoingRoute.DequeueWithTimeout(time.Second)
+		if err != nil {
+			t.Fatalf("Expected the announced package to be requested: %v", err)
+		}
+		if _, ok := message.(*appmessage.MsgRequestTransactionPackage); !ok {
+			t.Fatalf("Unexpected message %s", message.Command())
+		}
+	})
+}
+
+// TestHandleRelayedTransactionPackagesMismatch verifies that a peer that responds to a package
+// request with transactions other than the announced ones is banned.
+func TestHandleRelayedTransactionPackagesMismatch(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		context, parentTransaction, childTransaction, teardown :=
+			transactionPackageTestContext(t, consensusConfig, "TestHandleRelayedTransactionPackagesMismatch")
+		defer teardown()
+
+		incomingRoute := router.NewRoute("incoming")
+		defer incomingRoute.Close()
+		outgoingRoute := router.NewRoute("outgoing")
+		defer outgoingRoute.Close()
+
+		err := incomingRoute.Enqueue(appmessage.NewMsgInvTransactionPackage([]*externalapi.DomainTransactionID{
+			consensushashing.TransactionID(parentTransaction),
+			consensushashing.TransactionID(parentTransaction),
+		}))
+		if err != nil {
+			t.Fatalf("Unexpected error from incomingRoute.Enqueue: %v", err)
+		}
+		err = incomingRoute.Enqueue(appmessage.NewMsgTransactionPackage([]*appmessage.MsgTx{
+			appmessage.DomainTransactionToMsgTx(parentTransaction),
+			appmessage.DomainTransactionToMsgTx(childTransaction),
+		}))
+		if err != nil {
+			t.Fatalf("Unexpected error from incomingRoute.Enqueue: %v", err)
+		}
+
+		err = transactionrelay.HandleRelayedTransactionPackages(context, peerpkg.New(nil), incomingRoute, outgoingRoute)
+		if protocolErr := (protocolerrors.ProtocolError{}); err == nil || !errors.As(err, &protocolErr) {
+			t.Fatalf("Expected a protocol error, but got: %v", err)
+		} else if !protocolErr.ShouldBan {
+			t.Fatalf("Expected shouldBan true, but got false.")
+		}
+	})
+}
+
+// TestHandleRelayedTransactionPackagesAboveLocalLimit verifies that packages that are larger than
+// the local maximum package size are neither requested nor treated as misbehavior.
+func TestHandleRelayedTransactionPackagesAboveLocalLimit(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		context, parentTransaction, _, teardown :=
+			transactionPackageTestContext(t, consensusConfig, "TestHandleRelayedTransactionPackagesAboveLocalLimit")
+		defer teardown()
+
+		incomingRoute := router.NewRoute("incoming")
+		outgoingRoute := router.NewRoute("outgoing")
+		defer outgoingRoute.Close()
+
+		maximumPackageSize := context.Domain().MiningManager().MaximumTransactionPackageSize()
+		transactionIDs := make([]*externalapi.DomainTransactionID, maximumPackageSize+1)
+		for i := range transactionIDs {
+			transactionIDs[i] = consensushashing.TransactionID(parentTransaction)
+		}
+		err := incomingRoute.Enqueue(appmessage.NewMsgInvTransactionPackage(transactionIDs))
+		if err != nil {
+			t.Fatalf("Unexpected error from incomingRoute.Enqueue: %v", err)
+		}
+		incomingRoute.Close()
+
+		err = transactionrelay.HandleRelayedTransactionPackages(context, peerpkg.New(nil), incomingRoute, outgoingRoute)
+		if !errors.Is(err, router.ErrRouteClosed) {
+			t.Fatalf("Unexpected error: expected: %v, got : %v", router.ErrRouteClosed, err)
+		}
+		if _, err := outgoingRoute.DequeueWithTimeout(100 * time.Millisecond); err == nil {
+			t.Fatalf("Expected a package above the local limit not to be requested")
+		}
+	})
+}
@@ -4,11 +4,14 @@ import (
 	"github.com/zuanet/zuad/app/appmessage"
 	"github.com/zuanet/zuad/app/protocol/common"
 	"github.com/zuanet/zuad/app/protocol/flowcontext"
+	peerpkg "github.com/zuanet/zuad/app/protocol/peer"
 	"github.com/zuanet/zuad/app/protocol/protocolerrors"
 	"github.com/zuanet/zuad/domain"
 	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/ruleerrors"
 	"github.com/zuanet/zuad/domain/consensus/utils/consensushashing"
 	"github.com/zuanet/zuad/domain/miningmanager/mempool"
+	"github.com/zuanet/zuad/infrastructure/network/connmanager"
 	"github.com/zuanet/zuad/infrastructure/network/netadapter"
 	"github.com/zuanet/zuad/infrastructure/network/netadapter/router"
 	"github.com/pkg/errors"
@@ -22,20 +25,28 @@ type TransactionsRelayContext interface {
 	SharedRequestedTransactions() *flowcontext.SharedRequestedTransactions
 	OnTransactionAddedToMempool()
 	EnqueueTransactionIDsForPropagation(transactionIDs []*externalapi.DomainTransactionID) error
+	PropagateTransactionPackage(transactions []*externalapi.DomainTransaction,
+		acceptedTransactions []*externalapi.DomainTransaction) error
 	IsNearlySynced() (bool, error)
+	AddOffense(netConnection *netadapter.NetConnection, offense protocolerrors.Offense,
+		reason string) connmanager.MisbehaviorResponse
 }
 
 type handleRelayedTransactionsFlow struct {
 	TransactionsRelayContext
+	peer                         *peerpkg.Peer
 	incomingRoute, outgoingRoute *router.Route
 	invsQueue                    []*appmessage.MsgInvTransaction
 }
 
 // HandleRelayedTransactions listens to appmessage.MsgInvTransaction messages, requests their corresponding transactions if they
 // are missing, adds them to the mempool and propagates them to the rest of the network.
-func HandleRelayedTransactions(context TransactionsRelayContext, incomingRoute *router.Route, outgoingRoute *router.Route) error {
+func HandleRelayedTransactions(context TransactionsRelayContext, peer *peerpkg.Peer,
+	incomingRoute *router.Route, outgoingRoute *router.Route) error {
+
 	flow := &handleRelayedTransactionsFlow{
 		TransactionsRelayContext: context,
+		peer:                     peer,
 		incomingRoute:            incomingRoute,
 		outgoingRoute:            outgoingRoute,
 		invsQueue:                make([]*appmessage.MsgInvTransaction, 0),
@@ -183,26 +194,20 @@ func (flow *handleRelayedTransactionsFlow) receiveTransactions(requestedTransact
 				expectedID, txID)
 		}
 
-		acceptedTransactions, err :=
-			flow.Domain().MiningManager().ValidateAndInsertTransaction(tx, false, true)
+		// Transactions that double spend mempool transactions are relayed as replacements
+		acceptedTransactions, _, err :=
+			flow.Domain().MiningManager().ValidateAndInsertOrReplaceTransaction(tx, false, true)
 		if err != nil {
 			ruleErr := &mempool.RuleError{}
 			if !errors.As(err, ruleErr) {
 				return errors.Wrapf(err, "failed to process transaction %s", txID)
 			}
 
-			shouldBan := false
-			if txRuleErr := (&mempool.TxRuleError{}); errors.As(ruleErr.Err, txRuleErr) {
-				if txRuleErr.RejectCode == mempool.RejectInvalid {
-					shouldBan = true
-				}
-			}
-
-			if !shouldBan {
-				continue
+			err := addOffenseIfMisbehaving(flow, flow.peer, ruleErr)
+			if err != nil {
+				return err
 			}
-
-			return protocolerrors.Errorf(true, "rejected transaction %s: %s", txID, ruleErr)
+			continue
 		}
 		err = flow.broadcastAcceptedTransactions(consensushashing.TransactionIDs(acceptedTransactions))
 		if err != nil {
@@ -212,3 +217,80 @@ func (flow *handleRelayedTransactionsFlow) receiveTransactions(requestedTransact
 	}
 	return nil
 }
+
+// spamRejectCodes are the reject codes of transactions that are valid, but that
+// the mempool policy doesn't accept
+var spamRejectCodes = map[mempool.RejectCode]struct{}{
+	mempool.RejectNonstandard:     {},
+	mempool.RejectDust:            {},
+	mempool.RejectInsufficientFee: {},
+}
+
+// chainStateIndependentRuleErrors are the consensus rule errors that make a transaction
+// invalid no matter which chain state it's validated against. Other rule errors, such as
+// spending an output that isn't final yet, or a transaction version that this node doesn't
+// know, may only mean that the peer sees a different chain or runs a different version.
+var chainStateIndependentRuleErrors = []error{
+	ruleerrors.ErrNoTxInputs,
+	ruleerrors.ErrTxOutValueZero,
+	ruleerrors.ErrBadTxOutValue,
+	ruleerrors.ErrDuplicateTxInputs,
+	ruleerrors.ErrBadCoinbasePayloadLen,
+	ruleerrors.ErrCoinbaseWithInputs,
+	ruleerrors.ErrCoinbaseTooManyOutputs,
+	ruleerrors.ErrCoinbaseTooLongScriptPublicKey,
+	ruleerrors.ErrCoinbaseWithVProg,
+	ruleerrors.ErrInvalidGas,
+	ruleerrors.ErrInvalidPayload,
+	ruleerrors.ErrInvalidVProgFields,
+	ruleerrors.ErrSpendTooHigh,
+	ruleerrors.ErrScriptMalformed,
+	ruleerrors.ErrScriptValidation,
+	ruleerrors.ErrWrongSigOpCount,
+}
+
+// addOffenseIfMisbehaving adds an offense to the peer if the given mempool rule
+// error shows that relaying the rejected transaction was misbehavior. It returns
+// an error if the peer has to be disconnected as a result.
+//
+// Invalid transactions add an invalid transaction offense, and transactions that
+// are only rejected by the mempool policy add a much lighter spam transactions
+// offense, since the policy differs between nodes and the fee floor rises and
+// falls with the load on the mempool. Neither is heavy enough to ban a peer for a
+// single transaction.
+func addOffenseIfMisbehaving(context TransactionsRelayContext, peer *peerpkg.Peer, ruleErr *mempool.RuleError) error {
+	offense, ok := transactionOffense(ruleErr)
+	if !ok {
+		return nil
+	}
+
+	response := context.AddOffense(peer.Connection(), offense, ruleErr.Error())
+	if response >= connmanager.MisbehaviorResponseDisconnect {
+		// The offense is already accounted for, so the error must not add to the misbehavior score
+		return protocolerrors.Errorf(false, "relayed too many rejected transactions, the last one "+
+			"for %s: %s", offense, ruleErr)
+	}
+	return nil
+}
+
+// transactionOffense returns the offense of relaying a transaction that the mempool
+// rejected with the given rule error, if relaying it is an offense at all
+func transactionOffense(ruleErr *mempool.RuleError) (protocolerrors.Offense, bool) {
+	txRuleErr := &mempool.TxRuleError{}
+	if errors.As(ruleErr.Err, txRuleErr) {
+		if txRuleErr.RejectCode == mempool.RejectInvalid {
+			return protocolerrors.OffenseInvalidTransaction, true
+		}
+		if _, ok := spamRejectCodes[txRuleErr.RejectCode]; ok {
+			return protocolerrors.OffenseSpamTransactions, true
+		}
+		return 0, false
+	}
+
+	for _, chainStateIndependentRuleError := range chainStateIndependentRuleErrors {
+		if errors.Is(ruleErr.Err, chainStateIndependentRuleError) {
+			return protocolerrors.OffenseInvalidTransaction, true
+		}
+	}
+	return 0, false
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/zuanet/zuad/app/protocol/flowcontext"
 	"github.com/zuanet/zuad/app/protocol/flows/v5/transactionrelay"
+	peerpkg "github.com/zuanet/zuad/app/protocol/peer"
 	"strings"
 	"testing"
 
@@ -11,13 +12,17 @@ import (
 	"github.com/zuanet/zuad/domain"
 	"github.com/zuanet/zuad/domain/consensus"
 	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/utils/consensushashing"
+	"github.com/zuanet/zuad/domain/consensus/utils/constants"
 	"github.com/zuanet/zuad/domain/consensus/utils/testutils"
+	"github.com/zuanet/zuad/domain/consensus/utils/transactionhelper"
 	"github.com/zuanet/zuad/domain/miningmanager/mempool"
 	"github.com/zuanet/zuad/infrastructure/logger"
 	"github.com/zuanet/zuad/util/panics"
 
 	"github.com/zuanet/zuad/app/appmessage"
 	"github.com/zuanet/zuad/infrastructure/config"
+	"github.com/zuanet/zuad/infrastructure/network/connmanager"
 	"github.com/zuanet/zuad/infrastructure/network/netadapter"
 	"github.com/zuanet/zuad/infrastructure/network/netadapter/router"
 )
@@ -26,6 +31,8 @@ type mocTransactionsRelayContext struct {
 	netAdapter                  *netadapter.NetAdapter
 	domain                      domain.Domain
 	sharedRequestedTransactions *flowcontext.SharedRequestedTransactions
+	misbehaviorResponse         connmanager.MisbehaviorResponse
+	offenses                    []protocolerrors.Offense
 }
 
 func (m *mocTransactionsRelayContext) NetAdapter() *netadapter.NetAdapter {
@@ -44,6 +51,11 @@ func (m *mocTransactionsRelayContext) EnqueueTransactionIDsForPropagation(transa
 	return nil
 }
 
+func (m *mocTransactionsRelayContext) PropagateTransactionPackage(transactions []*externalapi.DomainTransaction,
+	acceptedTransactions []*externalapi.DomainTransaction) error {
+	return nil
+}
+
 func (m *mocTransactionsRelayContext) OnTransactionAddedToMempool() {
 }
 
@@ -51,6 +63,12 @@ func (m *mocTransactionsRelayContext) IsNearlySynced() (bool, error) {
 	return true, nil
 }
 
+func (m *mocTransactionsRelayContext) AddOffense(netConnection *netadapter.NetConnection,
+	offense protocolerrors.Offense, reason string) connmanager.MisbehaviorResponse {
+	m.offenses = append(m.offenses, offense)
+	return m.misbehaviorResponse
+}
+
 // TestHandleRelayedTransactionsNotFound tests the flow of  HandleRelayedTransactions when the peer doesn't
 // have the requested transactions in the mempool.
 func TestHandleRelayedTransactionsNotFound(t *testing.T) {
@@ -128,7 +146,7 @@ func TestHandleRelayedTransactionsNotFound(t *testing.T) {
 			}
 		})
 
-		err = transactionrelay.HandleRelayedTransactions(context, incomingRoute, peerIncomingRoute)
+		err = transactionrelay.HandleRelayedTransactions(context, peerpkg.New(nil), incomingRoute, peerIncomingRoute)
 		// Since we inserted an unexpected message type to stop the infinity loop,
 		// we expect the error will be infected from this specific message and also the
 		// error will count as a protocol message.
@@ -188,9 +206,268 @@ func TestOnClosedIncomingRoute(t *testing.T) {
 			t.Fatalf("Unexpected error from incomingRoute.Enqueue: %v", err)
 		}
 		incomingRoute.Close()
-		err = transactionrelay.HandleRelayedTransactions(context, incomingRoute, outgoingRoute)
+		err = transactionrelay.HandleRelayedTransactions(context, peerpkg.New(nil), incomingRoute, outgoingRoute)
 		if err == nil || !errors.Is(err, router.ErrRouteClosed) {
 			t.Fatalf("Unexpected error: expected: %v, got : %v", router.ErrRouteClosed, err)
 		}
 	})
 }
+
+// TestHandleRelayedTransactionsReplacement verifies that a relayed transaction that double spends
+// a mempool transaction replaces it if replace-by-fee is enabled, and is rejected without
+// banning the peer otherwise.
+func TestHandleRelayedTransactionsReplacement(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+
+		for _, enableReplaceByFee := range []bool{true, false} {
+			factory := consensus.NewFactory()
+			tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestHandleRelayedTransactionsReplacement")
+			if err != nil {
+				t.Fatalf("Error setting up test consensus: %+v", err)
+			}
+
+			adapter, err := netadapter.NewNetAdapter(config.DefaultConfig())
+			if err != nil {
+				t.Fatalf("Failed to create a NetAdapter: %v", err)
+			}
+			mempoolConfig := mempool.DefaultConfig(&consensusConfig.Params)
+			mempoolConfig.EnableReplaceByFee = enableReplaceByFee
+			domainInstance, err := domain.New(consensusConfig, mempoolConfig, tc.Database())
+			if err != nil {
+				t.Fatalf("Failed to set up a domain instance: %v", err)
+			}
+			context := &mocTransactionsRelayContext{
+				netAdapter:                  adapter,
+				domain:                      domainInstance,
+				sharedRequestedTransactions: flowcontext.NewSharedRequestedTransactions(),
+			}
+
+			fundingTransaction := mineFundingTransaction(t, domainInstance)
+
+			originalTransaction, err := testutils.CreateTransaction(fundingTransaction, 1000)
+			if err != nil {
+				t.Fatalf("Error creating transaction: %+v", err)
+			}
+			_, err = domainInstance.MiningManager().ValidateAndInsertTransaction(originalTransaction, false, true)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertTransaction: %+v", err)
+			}
+			// The replacement pays a higher fee by paying less to its output
+			replacement := originalTransaction.Clone()
+			replacement.Outputs[0].Value -= 5000
+			replacement.ID = nil
+			replacementID := consensushashing.TransactionID(replacement)
+
+			incomingRoute := router.NewRoute("incoming")
+			outgoingRoute := router.NewRoute("outgoing")
+			err = incomingRoute.Enqueue(appmessage.NewMsgInvTransaction([]*externalapi.DomainTransactionID{replacementID}))
+			if err != nil {
+				t.Fatalf("Unexpected error from incomingRoute.Enqueue: %v", err)
+			}
+			err = incomingRoute.Enqueue(appmessage.DomainTransactionToMsgTx(replacement))
+			if err != nil {
+				t.Fatalf("Unexpected error from incomingRoute.Enqueue: %v", err)
+			}
+			incomingRoute.Close()
+
+			err = transactionrelay.HandleRelayedTransactions(context, peerpkg.New(nil), incomingRoute, outgoingRoute)
+			if !errors.Is(err, router.ErrRouteClosed) {
+				t.Fatalf("Unexpected error: expected: %v, got : %v", router.ErrRouteClosed, err)
+			}
+			outgoingRoute.Close()
+
+			_, _, isReplacementInMempool := domainInstance.MiningManager().GetTransaction(replacementID, true, true)
+			_, _, isOriginalInMempool := domainInstance.MiningManager().GetTransaction(
+				consensushashing.TransactionID(originalTransaction), true, true)
+			if isReplacementInMempool != enableReplaceByFee || isOriginalInMempool == enableReplaceByFee {
+				t.Fatalf("With replace-by-fee enabled set to %t, expected the replacement to be in the "+
+					"mempool: %t and the original transaction to be in the mempool: %t, but got %t and %t",
+					enableReplaceByFee, enableReplaceByFee, !enableReplaceByFee,
+					isReplacementInMempool, isOriginalInMempool)
+			}
+
+			teardown(false)
+		}
+	})
+}
+
+// TestHandleRelayedTransactionsRejected verifies that a relayed transaction that the mempool rejects
+// adds an offense to the peer, disconnecting it if its misbehavior score calls for it. Invalid
+// transactions add an invalid transaction offense, transactions that are only rejected by the mempool
+// policy add a spam transactions offense, and transactions that are only rejected due to the current
+// chain state are not counted as misbehavior.
+func TestHandleRelayedTransactionsRejected(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+
+		tests := []struct {
+			name                string
+			createTransaction   func(fundingTransaction *externalapi.DomainTransaction) *externalapi.DomainTransaction
+			misbehaviorResponse connmanager.MisbehaviorResponse
+			expectedOffenses    []protocolerrors.Offense
+			expectedDisconnect  bool
+		}{
+			{
+				name: "insufficient fee",
+				createTransaction: func(fundingTransaction *externalapi.DomainTransaction) *externalapi.DomainTransaction {
+					transaction, err := testutils.CreateTransaction(fundingTransaction, 0)
+					if err != nil {
+						t.Fatalf("Error creating transaction: %+v", err)
+					}
+					return transaction
+				},
+				misbehaviorResponse: connmanager.MisbehaviorResponseNone,
+				expectedOffenses:    []protocolerrors.Offense{protocolerrors.OffenseSpamTransactions},
+			},
+			{
+				name: "non-standard",
+				createTransaction: func(fundingTransaction *externalapi.DomainTransaction) *externalapi.DomainTransaction {
+					transaction, err := testutils.CreateTransaction(fundingTransaction, 1000)
+					if err != nil {
+						t.Fatalf("Error creating transaction: %+v", err)
+					}
+					transaction.Outputs[0].ScriptPublicKey = &externalapi.ScriptPublicKey{Script: []byte{0x51}}
+					transaction.ID = nil
+					return transaction
+				},
+				misbehaviorResponse: connmanager.MisbehaviorResponseNone,
+				expectedOffenses:    []protocolerrors.Offense{protocolerrors.OffenseSpamTransactions},
+			},
+			{
+				name: "invalid",
+				createTransaction: func(fundingTransaction *externalapi.DomainTransaction) *externalapi.DomainTransaction {
+					transaction, err := testutils.CreateTransaction(fundingTransaction, 1000)
+					if err != nil {
+						t.Fatalf("Error creating transaction: %+v", err)
+					}
+					// The transaction spends more than its input
+					transaction.Outputs[0].Value = fundingTransaction.Outputs[0].Value + 1
+					transaction.ID = nil
+					return transaction
+				},
+				misbehaviorResponse: connmanager.MisbehaviorResponseNone,
+				expectedOffenses:    []protocolerrors.Offense{protocolerrors.OffenseInvalidTransaction},
+			},
+			{
+				name: "not final",
+				createTransaction: func(fundingTransaction *externalapi.DomainTransaction) *externalapi.DomainTransaction {
+					transaction, err := testutils.CreateTransaction(fundingTransaction, 1000)
+					if err != nil {
+						t.Fatalf("Error creating transaction: %+v", err)
+					}
+					// The transaction may only be included once the DAA score reaches its lock time
+					transaction.LockTime = constants.LockTimeThreshold - 1
+					transaction.Inputs[0].Sequence = 0
+					transaction.ID = nil
+					return transaction
+				},
+				misbehaviorResponse: connmanager.MisbehaviorResponseBan,
+				expectedOffenses:    nil,
+			},
+			{
+				name: "invalid with a banning score",
+				createTransaction: func(fundingTransaction *externalapi.DomainTransaction) *externalapi.DomainTransaction {
+					transaction, err := testutils.CreateTransaction(fundingTransaction, 1000)
+					if err != nil {
+						t.Fatalf("Error creating transaction: %+v", err)
+					}
+					transaction.Outputs[0].Value = fundingTransaction.Outputs[0].Value + 1
+					transaction.ID = nil
+					return transaction
+				},
+				misbehaviorResponse: connmanager.MisbehaviorResponseBan,
+				expectedOffenses:    []protocolerrors.Offense{protocolerrors.OffenseInvalidTransaction},
+				expectedDisconnect:  true,
+			},
+		}
+
+		for _, test := range tests {
+			factory := consensus.NewFactory()
+			tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestHandleRelayedTransactionsRejected")
+			if err != nil {
+				t.Fatalf("Error setting up test consensus: %+v", err)
+			}
+
+			adapter, err := netadapter.NewNetAdapter(config.DefaultConfig())
+			if err != nil {
+				t.Fatalf("Failed to create a NetAdapter: %v", err)
+			}
+			domainInstance, err := domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), tc.Database())
+			if err != nil {
+				t.Fatalf("Failed to set up a domain instance: %v", err)
+			}
+			context := &mocTransactionsRelayContext{
+				netAdapter:                  adapter,
+				domain:                      domainInstance,
+				sharedRequestedTransactions: flowcontext.NewSharedRequestedTransactions(),
+				misbehaviorResponse:         test.misbehaviorResponse,
+			}
+
+			transaction := test.createTransaction(mineFundingTransaction(t, domainInstance))
+			transactionID := consensushashing.TransactionID(transaction)
+
+			incomingRoute := router.NewRoute("incoming")
+			outgoingRoute := router.NewRoute("outgoing")
+			err = incomingRoute.Enqueue(appmessage.NewMsgInvTransaction([]*externalapi.DomainTransactionID{transactionID}))
+			if err != nil {
+				t.Fatalf("Unexpected error from incomingRoute.Enqueue: %v", err)
+			}
+			err = incomingRoute.Enqueue(appmessage.DomainTransactionToMsgTx(transaction))
+			if err != nil {
+				t.Fatalf("Unexpected error from incomingRoute.Enqueue: %v", err)
+			}
+			incomingRoute.Close()
+
+			err = transactionrelay.HandleRelayedTransactions(context, peerpkg.New(nil), incomingRoute, outgoingRoute)
+			outgoingRoute.Close()
+			if test.expectedDisconnect {
+				protocolErr := protocolerrors.ProtocolError{}
+				if !errors.As(err, &protocolErr) {
+					t.Fatalf("%s: expected a protocol error, got: %v", test.name, err)
+				}
+				// The offense was already added, so handling the error must not add to the misbehavior score again
+				if protocolErr.ShouldBan {
+					t.Fatalf("%s: expected a protocol error that doesn't ban the peer", test.name)
+				}
+			} else if !errors.Is(err, router.ErrRouteClosed) {
+				t.Fatalf("%s: unexpected error: expected: %v, got : %v", test.name, router.ErrRouteClosed, err)
+			}
+
+			if len(context.offenses) != len(test.expectedOffenses) {
+				t.Fatalf("%s: expected offenses %v, got %v", test.name, test.expectedOffenses, context.offenses)
+			}
+			for i, offense := range context.offenses {
+				if offense != test.expectedOffenses[i] {
+					t.Fatalf("%s: expected offenses %v, got %v", test.name, test.expectedOffenses, context.offenses)
+				}
+			}
+			_, _, isInMempool := domainInstance.MiningManager().GetTransaction(transactionID, true, true)
+			if isInMempool {
+				t.Fatalf("%s: expected the transaction to be rejected", test.name)
+			}
+
+			teardown(false)
+		}
+	})
+}
+
+// mineFundingTransaction mines a few blocks, so that the coinbase of the last one can be spent,
+// and returns that coinbase transaction
+func mineFundingTransaction(t *testing.T, domainInstance domain.Domain) *externalapi.DomainTransaction {
+	scriptPublicKey, _ := testutils.OpTrueScript()
+	coinbaseData := &externalapi.DomainCoinbaseData{ScriptPublicKey: scriptPublicKey, ExtraData: []byte{}}
+	var fundingBlock *externalapi.DomainBlock
+	for i := 0; i < 3; i++ {
+		var err error
+		fundingBlock, err = domainInstance.Consensus().BuildBlock(coinbaseData, nil)
+		if err != nil {
+			t.Fatalf("BuildBlock: %+v", err)
+		}
+		err = domainInstance.Consensus().ValidateAndInsertBlock(fundingBlock, true)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertBlock: %+v", err)
+		}
+	}
+	return fundingBlock.Transactions[transactionhelper.CoinbaseTransactionIndex]
+}
@@ -0,0 +1,50 @@
+package transactionrelay
+
+import (
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/router"
+)
+
+type handleRequestedTransactionPackagesFlow struct {
+	TransactionsRelayContext
+	incomingRoute, outgoingRoute *router.Route
+}
+
+// HandleRequestedTransactionPackages listens to appmessage.MsgRequestTransactionPackage messages,
+// responding with the requested package if all of its transactions are in the mempool, or with
+// an empty package otherwise.
+func HandleRequestedTransactionPackages(context TransactionsRelayContext, incomingRoute *router.Route,
+	outgoingRoute *router.Route) error {
+
+	flow := &handleRequestedTransactionPackagesFlow{
+		TransactionsRelayContext: context,
+		incomingRoute:            incomingRoute,
+		outgoingRoute:            outgoingRoute,
+	}
+	return flow.start()
+}
+
+func (flow *handleRequestedTransactionPackagesFlow) start() error {
+	for {
+		message, err := flow.incomingRoute.Dequeue()
+		if err != nil {
+			return err
+		}
+		msgRequestTransactionPackage := message.(*appmessage.MsgRequestTransactionPackage)
+
+		msgTxs := make([]*appmessage.MsgTx, 0, len(msgRequestTransactionPackage.TxIDs))
+		for _, transactionID := range msgRequestTransactionPackage.TxIDs {
+			tx, _, ok := flow.Domain().MiningManager().GetTransaction(transactionID, true, false)
+			if !ok {
+				msgTxs = nil
+				break
+			}
+			msgTxs = append(msgTxs, appmessage.DomainTransactionToMsgTx(tx))
+		}
+
+		err = flow.outgoingRoute.Enqueue(appmessage.NewMsgTransactionPackage(msgTxs))
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package blockrelay
+
+import (
+	"encoding/binary"
+
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/utils/consensushashing"
+	"github.com/zuanet/zuad/domain/consensus/utils/merkle"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+)
+
+// shortTransactionIDSize is the size in bytes of a short transaction ID
+const shortTransactionIDSize = 6
+
+// shortTransactionIDHasher computes the short transaction IDs of a single
+// compact block.
+//
+// Short IDs are keyed by the block hash and a salt chosen by the sender, so
+// that an attacker can't craft transactions whose short IDs collide in every
+// compact block.
+type shortTransactionIDHasher struct {
+	key []byte
+}
+
+func newShortTransactionIDHasher(blockHash *externalapi.DomainHash, salt uint64) *shortTransactionIDHasher {
+	key := make([]byte, externalapi.DomainHashSize+8)
+	copy(key, blockHash.ByteSlice())
+	binary.LittleEndian.PutUint64(key[externalapi.DomainHashSize:], salt)
+	return &shortTransactionIDHasher{key: key}
+}
+
+func (h *shortTransactionIDHasher) shortID(transactionID *externalapi.DomainTransactionID) uint64 {
+	hasher, err := blake2b.New(shortTransactionIDSize, h.key)
+	if err != nil {
+		panic(errors.Wrapf(err, "this should never happen. the short ID key is less than 64 bytes"))
+	}
+	hasher.Write(transactionID.ByteSlice())
+
+	var shortID [8]byte
+	copy(shortID[:], hasher.Sum(nil))
+	return binary.LittleEndian.Uint64(shortID[:])
+}
+
+// domainBlockToMsgCompactBlock converts the given block to a compact block in
+// which only the coinbase transaction is prefilled
+func domainBlockToMsgCompactBlock(block *externalapi.DomainBlock, salt uint64) *appmessage.MsgCompactBlock {
+	hasher := newShortTransactionIDHasher(consensushashing.BlockHash(block), salt)
+
+	shortIDs := make([]uint64, 0, len(block.Transactions)-1)
+	for _, transaction := range block.Transactions[1:] {
+		shortIDs = append(shortIDs, hasher.shortID(consensushashing.TransactionID(transaction)))
+	}
+	prefilledTransactions := []*appmessage.PrefilledTransaction{
+		{Index: 0, Tx: appmessage.DomainTransactionToMsgTx(block.Transactions[0])},
+	}
+	return appmessage.NewMsgCompactBlock(appmessage.DomainBlockHeaderToBlockHeader(block.Header),
+		salt, shortIDs, prefilledTransactions)
+}
+
+// compactBlockReconstruction rebuilds a block out of a compact block and the
+// transactions in the mempool
+type compactBlockReconstruction struct {
+	header         externalapi.BlockHeader
+	transactions   []*externalapi.DomainTransaction
+	missingIndexes []uint32
+}
+
+// newCompactBlockReconstruction places the prefilled transactions of the
+// compact block, and fills the rest from the given mempool transactions. The
+// transactions that aren't found - or whose short ID matches more than one
+// mempool transaction - are listed in missingIndexes.
+func newCompactBlockReconstruction(compactBlock *appmessage.MsgCompactBlock,
+	mempoolTransactions []*externalapi.DomainTransaction) (*compactBlockReconstruction, error) {
+
+	transactionCount := compactBlock.TransactionCount()
+	if len(compactBlock.PrefilledTransactions) == 0 || compactBlock.PrefilledTransactions[0].Index != 0 {
+		return nil, errors.New("the coinbase transaction is not prefilled")
+	}
+
+	transactions := make([]*externalapi.DomainTransaction, transactionCount)
+	for i, prefilledTransaction := range compactBlock.PrefilledTransactions {
+		if i > 0 && prefilledTransaction.Index <= compactBlock.PrefilledTransactions[i-1].Index {
+			return nil, errors.New("the prefilled transactions are not sorted by index")
+		}
+		if int(prefilledTransaction.Index) >= transactionCount {
+			return nil, errors.Errorf("prefilled transaction index %d is out of range for a block with "+
+				"%d transactions", prefilledTransaction.Index, transactionCount)
+		}
+		transactions[prefilledTransaction.Index] = appmessage.MsgTxToDomainTransaction(prefilledTransaction.Tx)
+	}
+
+	header := appmessage.BlockHeaderToDomainBlockHeader(&compactBlock.Header)
+	hasher := newShortTransactionIDHasher(consensushashing.HeaderHash(header), compactBlock.ShortIDSalt)
+
+	// A nil value marks a short ID that matches more than one transaction
+	mempoolTransactionsByShortID := make(map[uint64]*externalapi.DomainTransaction, len(mempoolTransactions))
+	for _, transaction := range mempoolTransactions {
+		shortID := hasher.shortID(consensushashing.TransactionID(transaction))
+		if _, ok := mempoolTransactionsByShortID[shortID]; ok {
+			mempoolTransactionsByShortID[shortID] = nil
+			continue
+		}
+		mempoolTransactionsByShortID[shortID] = transaction
+	}
+
+	var missingIndexes []uint32
+	shortIDs := compactBlock.ShortIDs
+	for i := range transactions {
+		if transactions[i] != nil {
+			continue
+		}
+		var shortID uint64
+		shortID, shortIDs = shortIDs[0], shortIDs[1:]
+
+		transaction := mempoolTransactionsByShortID[shortID]
+		if transaction == nil {
+			missingIndexes = append(missingIndexes, uint32(i))
+			continue
+		}
+		// The consensus caches data on the transactions it validates, so
+		// the mempool's copy must not be shared with it. The UTXO entries
+		// the mempool filled in must be cleared as well, since blocks may
+		// not carry them.
+		transactions[i] = transaction.Clone()
+		for _, input := range transactions[i].Inputs {
+			input.UTXOEntry = nil
+		}
+	}
+
+	return &compactBlockReconstruction{
+		header:         header,
+		transactions:   transactions,
+		missingIndexes: missingIndexes,
+	}, nil
+}
+
+// fillMissingTransactions places the given transactions at missingIndexes
+func (r *compactBlockReconstruction) fillMissingTransactions(transactions []*externalapi.DomainTransaction) error {
+	if len(transactions) != len(r.missingIndexes) {
+		return errors.Errorf("expected %d missing transactions but got %d",
+			len(r.missingIndexes), len(transactions))
+	}
+	for i, index := range r.missingIndexes {
+		r.transactions[index] = transactions[i]
+	}
+	r.missingIndexes = nil
+	return nil
+}
+
+// block returns the reconstructed block, or false if its transactions don't
+// match the merkle root in its header. This happens if a short ID matched a
+// wrong mempool transaction, in which case the full block should be requested.
+func (r *compactBlockReconstruction) block() (*externalapi.DomainBlock, bool) {
+	if len(r.missingIndexes) > 0 {
+		return nil, false
+	}
+	if !merkle.CalculateHashMerkleRoot(r.transactions).Equal(r.header.HashMerkleRoot()) {
+		return nil, false
+	}
+	return &externalapi.DomainBlock{
+		Header:       r.header,
+		Transactions: r.transactions,
+	}, true
+}
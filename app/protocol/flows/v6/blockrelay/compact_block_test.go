@@ -0,0 +1,234 @@
+package blockrelay
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/domain/consensus"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/utils/blockheader"
+	"github.com/zuanet/zuad/domain/consensus/utils/consensushashing"
+	"github.com/zuanet/zuad/domain/consensus/utils/merkle"
+	"github.com/zuanet/zuad/domain/consensus/utils/subnetworks"
+	"github.com/zuanet/zuad/domain/consensus/utils/testutils"
+	"github.com/zuanet/zuad/domain/consensus/utils/transactionhelper"
+	"github.com/zuanet/zuad/domain/consensusreference"
+	"github.com/zuanet/zuad/domain/miningmanager"
+	"github.com/zuanet/zuad/domain/miningmanager/mempool"
+)
+
+func transactionForTest(seed byte) *externalapi.DomainTransaction {
+	return &externalapi.DomainTransaction{
+		Inputs: []*externalapi.DomainTransactionInput{{
+			PreviousOutpoint: externalapi.DomainOutpoint{
+				TransactionID: *externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{seed}),
+			},
+			SignatureScript: []byte{seed},
+		}},
+		Outputs: []*externalapi.DomainTransactionOutput{{
+			Value:           uint64(seed) * 1000,
+			ScriptPublicKey: &externalapi.ScriptPublicKey{Script: []byte{seed}},
+		}},
+		SubnetworkID: subnetworks.SubnetworkIDNative,
+		Payload:      []byte{},
+	}
+}
+
+func blockForTest(transactionCount int) *externalapi.DomainBlock {
+	coinbase := transactionForTest(0)
+	coinbase.Inputs = []*externalapi.DomainTransactionInput{}
+	coinbase.SubnetworkID = subnetworks.SubnetworkIDCoinbase
+	coinbase.Payload = []byte{1, 2, 3}
+
+	transactions := []*externalapi.DomainTransaction{coinbase}
+	for i := 1; i < transactionCount; i++ {
+		transactions = append(transactions, transactionForTest(byte(i)))
+	}
+	header := blockheader.NewImmutableBlockHeader(0, nil, merkle.CalculateHashMerkleRoot(transactions),
+		&externalapi.DomainHash{}, &externalapi.DomainHash{}, 0, 0, 0, 0, 0, big.NewInt(0), &externalapi.DomainHash{})
+	return &externalapi.DomainBlock{Header: header, Transactions: transactions}
+}
+
+func TestCompactBlockReconstruction(t *testing.T) {
+	block := blockForTest(10)
+	blockHash := consensushashing.BlockHash(block)
+	compactBlock := domainBlockToMsgCompactBlock(block, 7)
+
+	if len(compactBlock.PrefilledTransactions) != 1 || compactBlock.PrefilledTransactions[0].Index != 0 {
+		t.Fatalf("expected only the coinbase to be prefilled")
+	}
+	if compactBlock.TransactionCount() != len(block.Transactions) {
+		t.Fatalf("expected %d transactions in the compact block, got %d",
+			len(block.Transactions), compactBlock.TransactionCount())
+	}
+
+	// The mempool misses transactions 3 and 7, and has a transaction that isn't in the block
+	var mempoolTransactions []*externalapi.DomainTransaction
+	for i, transaction := range block.Transactions[1:] {
+		if i+1 == 3 || i+1 == 7 {
+			continue
+		}
+		mempoolTransactions = append(mempoolTransactions, transaction)
+	}
+	mempoolTransactions = append(mempoolTransactions, transactionForTest(100))
+
+	reconstruction, err := newCompactBlockReconstruction(compactBlock, mempoolTransactions)
+	if err != nil {
+		t.Fatalf("newCompactBlockReconstruction: %+v", err)
+	}
+	if len(reconstruction.missingIndexes) != 2 ||
+		reconstruction.missingIndexes[0] != 3 || reconstruction.missingIndexes[1] != 7 {
+		t.Fatalf("expected transactions 3 and 7 to be missing, got %v", reconstruction.missingIndexes)
+	}
+	if _, ok := reconstruction.block(); ok {
+		t.Fatalf("a block with missing transactions was reconstructed")
+	}
+
+	err = reconstruction.fillMissingTransactions([]*externalapi.DomainTransaction{block.Transactions[3]})
+	if err == nil {
+		t.Fatalf("filling the wrong number of missing transactions unexpectedly succeeded")
+	}
+	err = reconstruction.fillMissingTransactions(
+		[]*externalapi.DomainTransaction{block.Transactions[3], block.Transactions[7]})
+	if err != nil {
+		t.Fatalf("fillMissingTransactions: %+v", err)
+	}
+	reconstructedBlock, ok := reconstruction.block()
+	if !ok {
+		t.Fatalf("the reconstructed block doesn't match its header")
+	}
+	if reconstructedHash := consensushashing.BlockHash(reconstructedBlock); !reconstructedHash.Equal(blockHash) {
+		t.Fatalf("reconstructed block %s instead of %s", reconstructedHash, blockHash)
+	}
+	for i, transaction := range reconstructedBlock.Transactions {
+		if !transaction.Equal(block.Transactions[i]) {
+			t.Fatalf("transaction %d of the reconstructed block is different from the original", i)
+		}
+	}
+}
+
+// TestCompactBlockReconstructionFromMempool makes sure that blocks that are
+// reconstructed out of mempool transactions, whose inputs have their UTXO
+// entries filled in, are accepted by the consensus
+func TestCompactBlockReconstructionFromMempool(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestCompactBlockReconstructionFromMempool")
+		if err != nil {
+			t.Fatalf("Error setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+		miningManager := miningmanager.NewFactory().NewMiningManager(consensusReference, &consensusConfig.Params,
+			mempool.DefaultConfig(&consensusConfig.Params), nil)
+
+		firstBlockHash, _, err := tc.AddBlock([]*externalapi.DomainHash{consensusConfig.GenesisHash}, nil, nil)
+		if err != nil {
+			t.Fatalf("AddBlock: %+v", err)
+		}
+		parentBlockHash, _, err := tc.AddBlock([]*externalapi.DomainHash{firstBlockHash}, nil, nil)
+		if err != nil {
+			t.Fatalf("AddBlock: %+v", err)
+		}
+		parentBlock, _, err := tc.GetBlock(parentBlockHash)
+		if err != nil {
+			t.Fatalf("GetBlock: %+v", err)
+		}
+		transaction, err := testutils.CreateTransaction(
+			parentBlock.Transactions[transactionhelper.CoinbaseTransactionIndex], 1000)
+		if err != nil {
+			t.Fatalf("CreateTransaction: %+v", err)
+		}
+		_, err = miningManager.ValidateAndInsertTransaction(transaction.Clone(), false, true)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertTransaction: %+v", err)
+		}
+		mempoolTransactions, _ := miningManager.AllTransactions(true, false)
+		if len(mempoolTransactions) != 1 || mempoolTransactions[0].Inputs[0].UTXOEntry == nil {
+			t.Fatalf("expected the mempool to have one transaction with its UTXO entry filled in")
+		}
+
+		block, _, err := tc.BuildBlockWithParents([]*externalapi.DomainHash{parentBlockHash}, nil,
+			[]*externalapi.DomainTransaction{transaction})
+		if err != nil {
+			t.Fatalf("BuildBlockWithParents: %+v", err)
+		}
+		reconstruction, err := newCompactBlockReconstruction(domainBlockToMsgCompactBlock(block, 0),
+			mempoolTransactions)
+		if err != nil {
+			t.Fatalf("newCompactBlockReconstruction: %+v", err)
+		}
+		reconstructedBlock, ok := reconstruction.block()
+		if !ok {
+			t.Fatalf("the block could not be reconstructed out of the mempool")
+		}
+		err = tc.ValidateAndInsertBlock(reconstructedBlock, true)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertBlock: %+v", err)
+		}
+		if mempoolTransactions[0].Inputs[0].UTXOEntry == nil {
+			t.Fatalf("the reconstruction modified the transaction of the mempool")
+		}
+	})
+}
+
+func TestCompactBlockReconstructionWithWrongTransaction(t *testing.T) {
+	block := blockForTest(3)
+	compactBlock := domainBlockToMsgCompactBlock(block, 0)
+
+	reconstruction, err := newCompactBlockReconstruction(compactBlock, block.Transactions[1:])
+	if err != nil {
+		t.Fatalf("newCompactBlockReconstruction: %+v", err)
+	}
+	if len(reconstruction.missingIndexes) != 0 {
+		t.Fatalf("expected no missing transactions, got %v", reconstruction.missingIndexes)
+	}
+	// Simulate a short ID collision with a transaction that isn't in the block
+	reconstruction.transactions[2] = transactionForTest(100)
+	if _, ok := reconstruction.block(); ok {
+		t.Fatalf("a block that doesn't match its merkle root was reconstructed")
+	}
+}
+
+func TestCompactBlockShortIDs(t *testing.T) {
+	block := blockForTest(2)
+	blockHash := consensushashing.BlockHash(block)
+	transactionID := consensushashing.TransactionID(block.Transactions[1])
+
+	shortID := newShortTransactionIDHasher(blockHash, 0).shortID(transactionID)
+	if shortID>>(shortTransactionIDSize*8) != 0 {
+		t.Fatalf("short ID %x is longer than %d bytes", shortID, shortTransactionIDSize)
+	}
+	if shortID == newShortTransactionIDHasher(blockHash, 1).shortID(transactionID) {
+		t.Fatalf("the short ID doesn't depend on the salt")
+	}
+	if shortID == newShortTransactionIDHasher(&externalapi.DomainHash{}, 0).shortID(transactionID) {
+		t.Fatalf("the short ID doesn't depend on the block hash")
+	}
+}
+
+func TestInvalidCompactBlocks(t *testing.T) {
+	block := blockForTest(3)
+
+	withoutCoinbase := domainBlockToMsgCompactBlock(block, 0)
+	withoutCoinbase.PrefilledTransactions[0].Index = 1
+	_, err := newCompactBlockReconstruction(withoutCoinbase, nil)
+	if err == nil {
+		t.Fatalf("a compact block without a prefilled coinbase was accepted")
+	}
+
+	outOfRange := domainBlockToMsgCompactBlock(block, 0)
+	outOfRange.PrefilledTransactions = append(outOfRange.PrefilledTransactions, &appmessage.PrefilledTransaction{
+		Index: 5,
+		Tx:    outOfRange.PrefilledTransactions[0].Tx,
+	})
+	_, err = newCompactBlockReconstruction(outOfRange, nil)
+	if err == nil {
+		t.Fatalf("a compact block with an out of range prefilled transaction was accepted")
+	}
+}
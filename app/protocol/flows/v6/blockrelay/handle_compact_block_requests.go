@@ -0,0 +1,112 @@
+package blockrelay
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+
+	"github.com/zuanet/zuad/app/appmessage"
+	peerpkg "github.com/zuanet/zuad/app/protocol/peer"
+	"github.com/zuanet/zuad/app/protocol/protocolerrors"
+	"github.com/zuanet/zuad/domain"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/router"
+	"github.com/pkg/errors"
+)
+
+// CompactBlockRequestsContext is the interface for the context needed for the HandleCompactBlockRequests flow.
+type CompactBlockRequestsContext interface {
+	Domain() domain.Domain
+}
+
+type handleCompactBlockRequestsFlow struct {
+	CompactBlockRequestsContext
+	incomingRoute, outgoingRoute *router.Route
+	peer                         *peerpkg.Peer
+}
+
+// HandleCompactBlockRequests listens to appmessage.MsgRequestCompactBlock messages and sends
+// the corresponding compact blocks to the requesting peer. It then serves the
+// appmessage.MsgRequestBlockTransactions messages the peer sends for the transactions it's missing.
+func HandleCompactBlockRequests(context CompactBlockRequestsContext, incomingRoute *router.Route,
+	outgoingRoute *router.Route, peer *peerpkg.Peer) error {
+
+	flow := &handleCompactBlockRequestsFlow{
+		CompactBlockRequestsContext: context,
+		incomingRoute:               incomingRoute,
+		outgoingRoute:               outgoingRoute,
+		peer:                        peer,
+	}
+	return flow.start()
+}
+
+func (flow *handleCompactBlockRequestsFlow) start() error {
+	for {
+		message, err := flow.incomingRoute.Dequeue()
+		if err != nil {
+			return err
+		}
+
+		switch message := message.(type) {
+		case *appmessage.MsgRequestCompactBlock:
+			err = flow.sendCompactBlock(message.Hash)
+		case *appmessage.MsgRequestBlockTransactions:
+			err = flow.sendBlockTransactions(message.BlockHash, message.Indexes)
+		default:
+			return protocolerrors.Errorf(true, "unexpected %s message in the HandleCompactBlockRequests flow",
+				message.Command())
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (flow *handleCompactBlockRequestsFlow) getBlock(hash *externalapi.DomainHash) (*externalapi.DomainBlock, error) {
+	block, found, err := flow.Domain().Consensus().GetBlock(hash)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to fetch requested block hash %s", hash)
+	}
+	if !found {
+		return nil, protocolerrors.Errorf(false, "Relay block %s not found", hash)
+	}
+	return block, nil
+}
+
+func (flow *handleCompactBlockRequestsFlow) sendCompactBlock(hash *externalapi.DomainHash) error {
+	log.Debugf("Got request for compact block %s", hash)
+	block, err := flow.getBlock(hash)
+	if err != nil {
+		return err
+	}
+
+	var salt [8]byte
+	_, err = rand.Read(salt[:])
+	if err != nil {
+		return err
+	}
+
+	err = flow.outgoingRoute.Enqueue(domainBlockToMsgCompactBlock(block, binary.LittleEndian.Uint64(salt[:])))
+	if err != nil {
+		return err
+	}
+	log.Debugf("Relayed compact block %s", hash)
+	return nil
+}
+
+func (flow *handleCompactBlockRequestsFlow) sendBlockTransactions(blockHash *externalapi.DomainHash, indexes []uint32) error {
+	log.Debugf("Got request for %d transactions of block %s", len(indexes), blockHash)
+	block, err := flow.getBlock(blockHash)
+	if err != nil {
+		return err
+	}
+
+	transactions := make([]*appmessage.MsgTx, len(indexes))
+	for i, index := range indexes {
+		if int(index) >= len(block.Transactions) {
+			return protocolerrors.Errorf(true, "requested transaction %d of block %s, which has only "+
+				"%d transactions", index, blockHash, len(block.Transactions))
+		}
+		transactions[i] = appmessage.DomainTransactionToMsgTx(block.Transactions[index])
+	}
+	return flow.outgoingRoute.Enqueue(appmessage.NewMsgBlockTransactions(blockHash, transactions))
+}
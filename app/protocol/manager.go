@@ -68,6 +68,22 @@ func (m *Manager) AddTransaction(tx *externalapi.DomainTransaction, allowOrphan
 	return m.context.AddTransaction(tx, allowOrphan)
 }
 
+// ReplaceTransaction adds transaction to the mempool in place of the transactions
+// it double spends, and propagates it.
+func (m *Manager) ReplaceTransaction(tx *externalapi.DomainTransaction) (
+	replacedTransactions []*externalapi.DomainTransaction, err error) {
+
+	return m.context.ReplaceTransaction(tx)
+}
+
+// AddTransactionPackage adds the given package of dependent transactions to the
+// mempool together, and propagates it.
+func (m *Manager) AddTransactionPackage(transactions []*externalapi.DomainTransaction) (
+	acceptedTransactions []*externalapi.DomainTransaction, err error) {
+
+	return m.context.AddTransactionPackage(transactions)
+}
+
 // AddBlock adds the given block to the DAG and propagates it.
 func (m *Manager) AddBlock(block *externalapi.DomainBlock) error {
 	return m.context.AddBlock(block)
@@ -4,6 +4,7 @@ import (
 	"github.com/zuanet/zuad/app/protocol/common"
 	"github.com/zuanet/zuad/app/protocol/flows/ready"
 	"github.com/zuanet/zuad/app/protocol/flows/v5"
+	"github.com/zuanet/zuad/app/protocol/flows/v6"
 	"sync"
 	"sync/atomic"
 
@@ -78,6 +79,8 @@ func (m *Manager) routerInitializer(router *routerpkg.Router, netConnection *net
 		switch peer.ProtocolVersion() {
 		case 5:
 			flows = v5.Register(m, router, errChan, &isStopping)
+		case 6:
+			flows = v6.Register(m, router, errChan, &isStopping)
 		default:
 			panic(errors.Errorf("no way to handle protocol version %d", peer.ProtocolVersion()))
 		}
@@ -106,17 +109,13 @@ func (m *Manager) routerInitializer(router *routerpkg.Router, netConnection *net
 
 func (m *Manager) handleError(err error, netConnection *netadapter.NetConnection, outgoingRoute *routerpkg.Route) {
 	if protocolErr := (protocolerrors.ProtocolError{}); errors.As(err, &protocolErr) {
-		if m.context.Config().EnableBanning && protocolErr.ShouldBan {
-			log.Warnf("Banning %s (reason: %s)", netConnection, protocolErr.Cause)
-
-			err := m.context.ConnectionManager().Ban(netConnection)
-			if err != nil && !errors.Is(err, connmanager.ErrCannotBanPermanent) {
-				panic(err)
-			}
-
-			err = outgoingRoute.Enqueue(appmessage.NewMsgReject(protocolErr.Error()))
-			if err != nil && !errors.Is(err, routerpkg.ErrRouteClosed) {
-				panic(err)
+		if protocolErr.ShouldBan {
+			response := m.context.AddOffense(netConnection, protocolErr.Offense, protocolErr.Cause.Error())
+			if response == connmanager.MisbehaviorResponseBan {
+				err := outgoingRoute.Enqueue(appmessage.NewMsgReject(protocolErr.Error()))
+				if err != nil && !errors.Is(err, routerpkg.ErrRouteClosed) {
+					panic(err)
+				}
 			}
 		}
 		log.Infof("Disconnecting from %s (reason: %s)", netConnection, protocolErr.Cause)
@@ -125,6 +124,7 @@ func (m *Manager) handleError(err error, netConnection *netadapter.NetConnection
 	}
 	if errors.Is(err, routerpkg.ErrTimeout) {
 		log.Warnf("Got timeout from %s. Disconnecting...", netConnection)
+		m.context.AddOffense(netConnection, protocolerrors.OffenseSlowResponse, err.Error())
 		netConnection.Disconnect()
 		return
 	}
@@ -0,0 +1,62 @@
+package protocolerrors
+
+import "fmt"
+
+// Offense is a kind of peer misbehavior. Every offense adds its weight to
+// the misbehavior score of the offending peer.
+type Offense uint8
+
+// These constants define the kinds of peer misbehavior
+const (
+	// OffenseProtocolViolation is a violation of the protocol that has no more
+	// specific offense
+	OffenseProtocolViolation Offense = iota
+
+	// OffenseInvalidBlock is sending a block that violates the consensus rules
+	OffenseInvalidBlock
+
+	// OffenseUnrequestedMessage is sending a response to a request that was
+	// never made
+	OffenseUnrequestedMessage
+
+	// OffenseSlowResponse is not responding to a request in time
+	OffenseSlowResponse
+
+	// OffenseInvalidTransaction is relaying a transaction that the mempool
+	// rejects as invalid
+	OffenseInvalidTransaction
+
+	// OffenseSpamTransactions is relaying transactions that are valid, but
+	// that the mempool rejects as non-standard, dust or too cheap
+	OffenseSpamTransactions
+)
+
+var offenseWeights = map[Offense]uint32{
+	OffenseProtocolViolation:  100,
+	OffenseInvalidBlock:       100,
+	OffenseUnrequestedMessage: 20,
+	OffenseSlowResponse:       10,
+	OffenseInvalidTransaction: 20,
+	OffenseSpamTransactions:   2,
+}
+
+var offenseStrings = map[Offense]string{
+	OffenseProtocolViolation:  "protocol violation",
+	OffenseInvalidBlock:       "invalid block",
+	OffenseUnrequestedMessage: "unrequested message",
+	OffenseSlowResponse:       "slow response",
+	OffenseInvalidTransaction: "invalid transaction",
+	OffenseSpamTransactions:   "spam transactions",
+}
+
+// Weight returns how much the offense adds to the misbehavior score of a peer
+func (offense Offense) Weight() uint32 {
+	return offenseWeights[offense]
+}
+
+func (offense Offense) String() string {
+	if offenseString, ok := offenseStrings[offense]; ok {
+		return offenseString
+	}
+	return fmt.Sprintf("unknown offense %d", offense)
+}
@@ -7,8 +7,13 @@ import (
 
 // ProtocolError is an error that signifies a violation
 // of the peer-to-peer protocol
+//
+// If ShouldBan is set, the weight of Offense is added to the misbehavior
+// score of the peer, which gets banned once its score reaches the ban
+// threshold.
 type ProtocolError struct {
 	ShouldBan bool
+	Offense   Offense
 	Cause     error
 }
 
@@ -55,6 +60,28 @@ func Wrapf(shouldBan bool, err error, format string, args ...interface{}) error
 	}
 }
 
+// OffenseErrorf formats according to a format specifier and returns the
+// string as a ProtocolError that adds the given offense to the misbehavior
+// score of the peer.
+func OffenseErrorf(offense Offense, format string, args ...interface{}) error {
+	return ProtocolError{
+		ShouldBan: true,
+		Offense:   offense,
+		Cause:     errors.Errorf(format, args...),
+	}
+}
+
+// OffenseWrapf wraps the given error with the given format and returns it as
+// a ProtocolError that adds the given offense to the misbehavior score of the
+// peer.
+func OffenseWrapf(offense Offense, err error, format string, args ...interface{}) error {
+	return ProtocolError{
+		ShouldBan: true,
+		Offense:   offense,
+		Cause:     errors.Wrapf(err, format, args...),
+	}
+}
+
 // ConvertToBanningProtocolErrorIfRuleError converts the given error to
 // a banning protocol error if it's a rule error, and otherwise keep it
 // as is.
@@ -6,6 +6,9 @@ import (
 	"github.com/zuanet/zuad/app/rpc/rpccontext"
 	"github.com/zuanet/zuad/domain"
 	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/utils/consensushashing"
+	miningmanagermodel "github.com/zuanet/zuad/domain/miningmanager/model"
+	"github.com/zuanet/zuad/domain/txindex"
 	"github.com/zuanet/zuad/domain/utxoindex"
 	"github.com/zuanet/zuad/infrastructure/config"
 	"github.com/zuanet/zuad/infrastructure/logger"
@@ -29,7 +32,9 @@ func NewManager(
 	connectionManager *connmanager.ConnectionManager,
 	addressManager *addressmanager.AddressManager,
 	utxoIndex *utxoindex.UTXOIndex,
+	txIndex *txindex.TXIndex,
 	consensusEventsChan chan externalapi.ConsensusEvent,
+	mempoolEventsChan chan miningmanagermodel.MempoolEvent,
 	shutDownChan chan<- struct{}) *Manager {
 
 	manager := Manager{
@@ -41,12 +46,18 @@ func NewManager(
 			connectionManager,
 			addressManager,
 			utxoIndex,
+			txIndex,
 			shutDownChan,
 		),
 	}
 	netAdapter.SetRPCRouterInitializer(manager.routerInitializer)
 
 	manager.initConsensusEventsHandler(consensusEventsChan)
+	manager.initMempoolEventsHandler(mempoolEventsChan)
+	domain.MiningManager().SetHasMempoolEventListeners(func() bool {
+		hasListeners, _ := manager.context.NotificationManager.HasListenersThatPropagateMempoolChanged()
+		return hasListeners
+	})
 
 	return &manager
 }
@@ -76,6 +87,58 @@ func (m *Manager) initConsensusEventsHandler(consensusEventsChan chan externalap
 	})
 }
 
+func (m *Manager) initMempoolEventsHandler(mempoolEventsChan chan miningmanagermodel.MempoolEvent) {
+	spawn("mempoolEventsHandler", func() {
+		for {
+			mempoolEvent, ok := <-mempoolEventsChan
+			if !ok {
+				return
+			}
+			err := m.notifyMempoolChanged(mempoolEvent)
+			if err != nil {
+				panic(err)
+			}
+		}
+	})
+}
+
+// notifyMempoolChanged notifies the manager that a transaction was added to or removed from the mempool
+func (m *Manager) notifyMempoolChanged(mempoolEvent miningmanagermodel.MempoolEvent) error {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "RPCManager.notifyMempoolChanged")
+	defer onEnd()
+
+	hasListeners, includeTransactions := m.context.NotificationManager.HasListenersThatPropagateMempoolChanged()
+	if !hasListeners {
+		return nil
+	}
+
+	var notification *appmessage.MempoolChangedNotificationMessage
+	switch event := mempoolEvent.(type) {
+	case *miningmanagermodel.TransactionAddedToMempool:
+		var rpcTransaction *appmessage.RPCTransaction
+		if includeTransactions {
+			rpcTransaction = appmessage.DomainTransactionToRPCTransaction(event.Transaction)
+			err := m.context.PopulateTransactionWithVerboseData(rpcTransaction, nil)
+			if err != nil {
+				return err
+			}
+		}
+		notification = appmessage.NewMempoolChangedNotificationMessage(appmessage.MempoolChangedEventAdded,
+			consensushashing.TransactionID(event.Transaction).String(), "", rpcTransaction)
+	case *miningmanagermodel.TransactionRemovedFromMempool:
+		eventName := appmessage.MempoolChangedEventRemoved
+		if event.Reason == miningmanagermodel.MempoolRemovalReasonEvicted {
+			eventName = appmessage.MempoolChangedEventEvicted
+		}
+		notification = appmessage.NewMempoolChangedNotificationMessage(eventName,
+			event.TransactionID.String(), event.Reason.String(), nil)
+	default:
+		return errors.Errorf("Got event of unsupported type %T", mempoolEvent)
+	}
+
+	return m.context.NotificationManager.NotifyMempoolChanged(notification)
+}
+
 // notifyBlockAddedToDAG notifies the manager that a block has been added to the DAG
 func (m *Manager) notifyBlockAddedToDAG(block *externalapi.DomainBlock) error {
 	onEnd := logger.LogAndMeasureExecutionTime(log, "RPCManager.notifyBlockAddedToDAG")
@@ -113,6 +176,13 @@ func (m *Manager) notifyVirtualChange(virtualChangeSet *externalapi.VirtualChang
 		}
 	}
 
+	if m.context.Config.TXIndex {
+		err := m.updateTXIndex(virtualChangeSet)
+		if err != nil {
+			return err
+		}
+	}
+
 	err := m.notifyVirtualSelectedParentBlueScoreChanged(virtualChangeSet.VirtualSelectedParentBlueScore)
 	if err != nil {
 		return err
@@ -158,6 +228,13 @@ func (m *Manager) NotifyPruningPointUTXOSetOverride() error {
 		}
 	}
 
+	if m.context.Config.TXIndex {
+		err := m.context.TXIndex.Reset()
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -191,6 +268,13 @@ func (m *Manager) notifyUTXOsChanged(virtualChangeSet *externalapi.VirtualChange
 	return m.context.NotificationManager.NotifyUTXOsChanged(utxoIndexChanges)
 }
 
+func (m *Manager) updateTXIndex(virtualChangeSet *externalapi.VirtualChangeSet) error {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "RPCManager.updateTXIndex")
+	defer onEnd()
+
+	return m.context.TXIndex.Update(virtualChangeSet)
+}
+
 func (m *Manager) notifyPruningPointUTXOSetOverride() error {
 	onEnd := logger.LogAndMeasureExecutionTime(log, "RPCManager.notifyPruningPointUTXOSetOverride")
 	defer onEnd()
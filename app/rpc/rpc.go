@@ -51,6 +51,17 @@ var handlers = map[appmessage.MessageCommand]handler{
 	appmessage.CmdNotifyNewBlockTemplateRequestMessage:                      rpchandlers.HandleNotifyNewBlockTemplate,
 	appmessage.CmdGetCoinSupplyRequestMessage:                               rpchandlers.HandleGetCoinSupply,
 	appmessage.CmdGetMempoolEntriesByAddressesRequestMessage:                rpchandlers.HandleGetMempoolEntriesByAddresses,
+	appmessage.CmdSimulateVProgCallRequestMessage:                           rpchandlers.HandleSimulateVProgCall,
+	appmessage.CmdGetVProgCodeRequestMessage:                                rpchandlers.HandleGetVProgCode,
+	appmessage.CmdGetVProgStorageRequestMessage:                             rpchandlers.HandleGetVProgStorage,
+	appmessage.CmdGetTransactionRequestMessage:                              rpchandlers.HandleGetTransaction,
+	appmessage.CmdGetTransactionAcceptanceRequestMessage:                    rpchandlers.HandleGetTransactionAcceptance,
+	appmessage.CmdGetTransactionsByAddressesRequestMessage:                  rpchandlers.HandleGetTransactionsByAddresses,
+	appmessage.CmdGetFeeEstimateRequestMessage:                              rpchandlers.HandleGetFeeEstimate,
+	appmessage.CmdSubmitTransactionReplacementRequestMessage:                rpchandlers.HandleSubmitTransactionReplacement,
+	appmessage.CmdNotifyMempoolChangedRequestMessage:                        rpchandlers.HandleNotifyMempoolChanged,
+	appmessage.CmdValidateTransactionRequestMessage:                         rpchandlers.HandleValidateTransaction,
+	appmessage.CmdSubmitTransactionPackageRequestMessage:                    rpchandlers.HandleSubmitTransactionPackage,
 }
 
 func (m *Manager) routerInitializer(router *router.Router, netConnection *netadapter.NetConnection) {
@@ -3,6 +3,7 @@ package rpccontext
 import (
 	"github.com/zuanet/zuad/app/protocol"
 	"github.com/zuanet/zuad/domain"
+	"github.com/zuanet/zuad/domain/txindex"
 	"github.com/zuanet/zuad/domain/utxoindex"
 	"github.com/zuanet/zuad/infrastructure/config"
 	"github.com/zuanet/zuad/infrastructure/network/addressmanager"
@@ -19,6 +20,7 @@ type Context struct {
 	ConnectionManager *connmanager.ConnectionManager
 	AddressManager    *addressmanager.AddressManager
 	UTXOIndex         *utxoindex.UTXOIndex
+	TXIndex           *txindex.TXIndex
 	ShutDownChan      chan<- struct{}
 
 	NotificationManager *NotificationManager
@@ -32,6 +34,7 @@ func NewContext(cfg *config.Config,
 	connectionManager *connmanager.ConnectionManager,
 	addressManager *addressmanager.AddressManager,
 	utxoIndex *utxoindex.UTXOIndex,
+	txIndex *txindex.TXIndex,
 	shutDownChan chan<- struct{}) *Context {
 
 	context := &Context{
@@ -42,6 +45,7 @@ func NewContext(cfg *config.Config,
 		ConnectionManager: connectionManager,
 		AddressManager:    addressManager,
 		UTXOIndex:         utxoIndex,
+		TXIndex:           txIndex,
 		ShutDownChan:      shutDownChan,
 	}
 	context.NotificationManager = NewNotificationManager(cfg.ActiveNetParams)
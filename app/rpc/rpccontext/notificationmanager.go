@@ -41,9 +41,11 @@ type NotificationListener struct {
 	propagateVirtualDaaScoreChangedNotifications                bool
 	propagatePruningPointUTXOSetOverrideNotifications           bool
 	propagateNewBlockTemplateNotifications                      bool
+	propagateMempoolChangedNotifications                        bool
 
 	propagateUTXOsChangedNotificationAddresses                                    map[utxoindex.ScriptPublicKeyString]*UTXOsChangedNotificationAddress
 	includeAcceptedTransactionIDsInVirtualSelectedParentChainChangedNotifications bool
+	includeTransactionsInMempoolChangedNotifications                              bool
 }
 
 // NewNotificationManager creates a new NotificationManager
@@ -284,6 +286,57 @@ func (nm *NotificationManager) NotifyNewBlockTemplate(
 	return nil
 }
 
+// HasListenersThatPropagateMempoolChanged returns whether there's any listener that is subscribed
+// to MempoolChanged notifications, as well as whether any such listener requested to include
+// the added transactions.
+func (nm *NotificationManager) HasListenersThatPropagateMempoolChanged() (hasListeners, hasListenersThatRequireTransactions bool) {
+	nm.RLock()
+	defer nm.RUnlock()
+
+	for _, listener := range nm.listeners {
+		if listener.propagateMempoolChangedNotifications {
+			hasListeners = true
+			// Populating the transaction's verbose data is relatively heavy, so we check if it's needed by any listener.
+			if listener.includeTransactionsInMempoolChangedNotifications {
+				hasListenersThatRequireTransactions = true
+				break
+			}
+		}
+	}
+
+	return hasListeners, hasListenersThatRequireTransactions
+}
+
+// NotifyMempoolChanged notifies the notification manager that a transaction was added to
+// or removed from the mempool
+func (nm *NotificationManager) NotifyMempoolChanged(notification *appmessage.MempoolChangedNotificationMessage) error {
+	nm.RLock()
+	defer nm.RUnlock()
+
+	notificationWithoutTransaction := &appmessage.MempoolChangedNotificationMessage{
+		Event:         notification.Event,
+		TransactionID: notification.TransactionID,
+		RemovalReason: notification.RemovalReason,
+	}
+
+	for router, listener := range nm.listeners {
+		if listener.propagateMempoolChangedNotifications {
+			var err error
+
+			if listener.includeTransactionsInMempoolChangedNotifications {
+				err = router.OutgoingRoute().MaybeEnqueue(notification)
+			} else {
+				err = router.OutgoingRoute().MaybeEnqueue(notificationWithoutTransaction)
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // NotifyPruningPointUTXOSetOverride notifies the notification manager that the UTXO index
 // reset due to pruning point change via IBD.
 func (nm *NotificationManager) NotifyPruningPointUTXOSetOverride() error {
@@ -313,6 +366,7 @@ func newNotificationListener(params *dagconfig.Params) *NotificationListener {
 		propagateVirtualSelectedParentBlueScoreChangedNotifications: false,
 		propagateNewBlockTemplateNotifications:                      false,
 		propagatePruningPointUTXOSetOverrideNotifications:           false,
+		propagateMempoolChangedNotifications:                        false,
 	}
 }
 
@@ -489,3 +543,10 @@ func (nl *NotificationListener) PropagatePruningPointUTXOSetOverrideNotification
 func (nl *NotificationListener) StopPropagatingPruningPointUTXOSetOverrideNotifications() {
 	nl.propagatePruningPointUTXOSetOverrideNotifications = false
 }
+
+// PropagateMempoolChangedNotifications instructs the listener to send mempool changed notifications
+// to the remote listener
+func (nl *NotificationListener) PropagateMempoolChangedNotifications(includeTransactions bool) {
+	nl.propagateMempoolChangedNotifications = true
+	nl.includeTransactionsInMempoolChangedNotifications = includeTransactions
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/zuanet/zuad/domain/consensus/utils/hashes"
 	"github.com/zuanet/zuad/domain/consensus/utils/testutils"
 	"github.com/zuanet/zuad/domain/miningmanager"
+	miningmanagermodel "github.com/zuanet/zuad/domain/miningmanager/model"
 	"github.com/zuanet/zuad/infrastructure/config"
 )
 
@@ -27,6 +28,10 @@ func (d fakeDomain) ConsensusEventsChannel() chan externalapi.ConsensusEvent {
 	panic("implement me")
 }
 
+func (d fakeDomain) MempoolEventsChannel() chan miningmanagermodel.MempoolEvent {
+	panic("implement me")
+}
+
 func (d fakeDomain) DeleteStagingConsensus() error {
 	panic("implement me")
 }
@@ -1,6 +1,8 @@
 package rpchandlers
 
 import (
+	"encoding/hex"
+
 	"github.com/zuanet/zuad/app/appmessage"
 	"github.com/zuanet/zuad/app/rpc/rpccontext"
 	"github.com/zuanet/zuad/infrastructure/network/netadapter/router"
@@ -22,6 +24,9 @@ func HandleGetConnectedPeerInfo(context *rpccontext.Context, _ *router.Router, _
 			AdvertisedProtocolVersion: peer.AdvertisedProtocolVersion(),
 			TimeConnected:             peer.TimeConnected().Milliseconds(),
 			IsIBDPeer:                 peer == ibdPeer,
+			IsEncrypted:               peer.Connection().IsEncrypted(),
+			IdentityKey:               hex.EncodeToString(peer.Connection().RemoteIdentityKey()),
+			MisbehaviorScore:          context.ConnectionManager.MisbehaviorScore(peer.Connection()),
 		}
 		infos = append(infos, info)
 	}
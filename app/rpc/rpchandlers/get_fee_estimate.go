@@ -0,0 +1,30 @@
+package rpchandlers
+
+import (
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/app/rpc/rpccontext"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetFeeEstimate handles the respectively named RPC command
+func HandleGetFeeEstimate(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	feeEstimate, err := context.Domain.MiningManager().GetFeeEstimate()
+	if err != nil {
+		return nil, err
+	}
+
+	return appmessage.NewGetFeeEstimateResponseMessage(&appmessage.RPCFeeEstimate{
+		PriorityBucket: &appmessage.RPCFeeRateBucket{
+			FeeRate:          feeEstimate.PriorityBucket.FeeRate,
+			EstimatedSeconds: feeEstimate.PriorityBucket.EstimatedSeconds,
+		},
+		NormalBucket: &appmessage.RPCFeeRateBucket{
+			FeeRate:          feeEstimate.NormalBucket.FeeRate,
+			EstimatedSeconds: feeEstimate.NormalBucket.EstimatedSeconds,
+		},
+		LowBucket: &appmessage.RPCFeeRateBucket{
+			FeeRate:          feeEstimate.LowBucket.FeeRate,
+			EstimatedSeconds: feeEstimate.LowBucket.EstimatedSeconds,
+		},
+	}), nil
+}
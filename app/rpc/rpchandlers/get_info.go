@@ -20,6 +20,7 @@ func HandleGetInfo(context *rpccontext.Context, _ *router.Router, _ appmessage.M
 		version.Version(),
 		context.Config.UTXOIndex,
 		context.ProtocolManager.Context().HasPeers() && isNearlySynced,
+		uint64(context.Domain.MiningManager().MinimumRelayTransactionFee()),
 	)
 
 	return response, nil
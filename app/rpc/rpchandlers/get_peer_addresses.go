@@ -14,14 +14,14 @@ func HandleGetPeerAddresses(context *rpccontext.Context, _ *router.Router, _ app
 	netAddresses := context.AddressManager.Addresses()
 	addressMessages := make([]*appmessage.GetPeerAddressesKnownAddressMessage, len(netAddresses))
 	for i, netAddress := range netAddresses {
-		addressWithPort := net.JoinHostPort(netAddress.IP.String(), strconv.FormatUint(uint64(netAddress.Port), 10))
+		addressWithPort := net.JoinHostPort(netAddress.HostName(), strconv.FormatUint(uint64(netAddress.Port), 10))
 		addressMessages[i] = &appmessage.GetPeerAddressesKnownAddressMessage{Addr: addressWithPort}
 	}
 
 	bannedAddresses := context.AddressManager.BannedAddresses()
 	bannedAddressMessages := make([]*appmessage.GetPeerAddressesKnownAddressMessage, len(bannedAddresses))
 	for i, netAddress := range bannedAddresses {
-		addressWithPort := net.JoinHostPort(netAddress.IP.String(), strconv.FormatUint(uint64(netAddress.Port), 10))
+		addressWithPort := net.JoinHostPort(netAddress.HostName(), strconv.FormatUint(uint64(netAddress.Port), 10))
 		bannedAddressMessages[i] = &appmessage.GetPeerAddressesKnownAddressMessage{Addr: addressWithPort}
 	}
 
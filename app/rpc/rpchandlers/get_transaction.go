@@ -0,0 +1,66 @@
+package rpchandlers
+
+import (
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/app/rpc/rpccontext"
+	"github.com/zuanet/zuad/domain/consensus/utils/consensushashing"
+	"github.com/zuanet/zuad/domain/consensus/utils/transactionid"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/router"
+	"github.com/pkg/errors"
+)
+
+// HandleGetTransaction handles the respectively named RPC command
+func HandleGetTransaction(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if !context.Config.TXIndex {
+		errorMessage := &appmessage.GetTransactionResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Method unavailable when zuad is run without --txindex")
+		return errorMessage, nil
+	}
+
+	getTransactionRequest := request.(*appmessage.GetTransactionRequestMessage)
+
+	transactionID, err := transactionid.FromString(getTransactionRequest.TransactionID)
+	if err != nil {
+		errorMessage := &appmessage.GetTransactionResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Transaction ID could not be parsed: %s", err)
+		return errorMessage, nil
+	}
+
+	txAcceptanceData, found, err := context.TXIndex.TXAcceptanceData(transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		errorMessage := &appmessage.GetTransactionResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Transaction %s was not found", transactionID)
+		return errorMessage, nil
+	}
+
+	block, found, err := context.Domain.Consensus().GetBlock(txAcceptanceData.IncludingBlockHash)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		errorMessage := &appmessage.GetTransactionResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Block %s, which includes transaction %s, was pruned",
+			txAcceptanceData.IncludingBlockHash, transactionID)
+		return errorMessage, nil
+	}
+
+	for _, transaction := range block.Transactions {
+		if !consensushashing.TransactionID(transaction).Equal(transactionID) {
+			continue
+		}
+
+		rpcTransaction := appmessage.DomainTransactionToRPCTransaction(transaction)
+		err = context.PopulateTransactionWithVerboseData(rpcTransaction, block.Header)
+		if err != nil {
+			return nil, err
+		}
+		return appmessage.NewGetTransactionResponseMessage(rpcTransaction,
+			txAcceptanceData.IncludingBlockHash.String(), txAcceptanceData.AcceptingBlockHash.String()), nil
+	}
+
+	return nil, errors.Errorf("transaction %s is missing from its including block %s",
+		transactionID, txAcceptanceData.IncludingBlockHash)
+}
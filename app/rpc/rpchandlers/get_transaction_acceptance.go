@@ -0,0 +1,45 @@
+package rpchandlers
+
+import (
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/app/rpc/rpccontext"
+	"github.com/zuanet/zuad/domain/consensus/utils/transactionid"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetTransactionAcceptance handles the respectively named RPC command
+func HandleGetTransactionAcceptance(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if !context.Config.TXIndex {
+		errorMessage := &appmessage.GetTransactionAcceptanceResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Method unavailable when zuad is run without --txindex")
+		return errorMessage, nil
+	}
+
+	getTransactionAcceptanceRequest := request.(*appmessage.GetTransactionAcceptanceRequestMessage)
+
+	transactionAcceptances := make([]*appmessage.RPCTransactionAcceptance, len(getTransactionAcceptanceRequest.TransactionIDs))
+	for i, transactionIDString := range getTransactionAcceptanceRequest.TransactionIDs {
+		transactionID, err := transactionid.FromString(transactionIDString)
+		if err != nil {
+			errorMessage := &appmessage.GetTransactionAcceptanceResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf("Transaction ID %s could not be parsed: %s", transactionIDString, err)
+			return errorMessage, nil
+		}
+
+		txAcceptanceData, found, err := context.TXIndex.TXAcceptanceData(transactionID)
+		if err != nil {
+			return nil, err
+		}
+
+		transactionAcceptances[i] = &appmessage.RPCTransactionAcceptance{
+			TransactionID: transactionID.String(),
+			IsAccepted:    found,
+		}
+		if found {
+			transactionAcceptances[i].IncludingBlockHash = txAcceptanceData.IncludingBlockHash.String()
+			transactionAcceptances[i].AcceptingBlockHash = txAcceptanceData.AcceptingBlockHash.String()
+		}
+	}
+
+	return appmessage.NewGetTransactionAcceptanceResponseMessage(transactionAcceptances), nil
+}
@@ -0,0 +1,67 @@
+package rpchandlers
+
+import (
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/app/rpc/rpccontext"
+	"github.com/zuanet/zuad/domain/consensus/utils/txscript"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/router"
+	"github.com/zuanet/zuad/util"
+)
+
+const defaultAddressHistoryPageSize = 1000
+
+// HandleGetTransactionsByAddresses handles the respectively named RPC command
+func HandleGetTransactionsByAddresses(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if !context.Config.UTXOIndex || !context.Config.AddressHistoryIndex {
+		errorMessage := &appmessage.GetTransactionsByAddressesResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Method unavailable when zuad is run without --utxoindex and --addresshistoryindex")
+		return errorMessage, nil
+	}
+
+	getTransactionsByAddressesRequest := request.(*appmessage.GetTransactionsByAddressesRequestMessage)
+
+	limit := int(getTransactionsByAddressesRequest.Limit)
+	if limit == 0 {
+		limit = defaultAddressHistoryPageSize
+	}
+
+	entries := make([]*appmessage.TransactionsByAddressEntry, len(getTransactionsByAddressesRequest.Addresses))
+	for i, addressString := range getTransactionsByAddressesRequest.Addresses {
+		address, err := util.DecodeAddress(addressString, context.Config.ActiveNetParams.Prefix)
+		if err != nil {
+			errorMessage := &appmessage.GetTransactionsByAddressesResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf("Could not decode address '%s': %s", addressString, err)
+			return errorMessage, nil
+		}
+		scriptPublicKey, err := txscript.PayToAddrScript(address)
+		if err != nil {
+			errorMessage := &appmessage.GetTransactionsByAddressesResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf("Could not create a scriptPublicKey for address '%s': %s", addressString, err)
+			return errorMessage, nil
+		}
+
+		addressHistory, nextDAAScore, err := context.UTXOIndex.AddressHistory(scriptPublicKey,
+			getTransactionsByAddressesRequest.FromDAAScore, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		transactions := make([]*appmessage.RPCAddressTransaction, len(addressHistory))
+		for j, addressHistoryEntry := range addressHistory {
+			transactions[j] = &appmessage.RPCAddressTransaction{
+				TransactionID:      addressHistoryEntry.TransactionID.String(),
+				AcceptingBlockHash: addressHistoryEntry.AcceptingBlockHash.String(),
+				DAAScore:           addressHistoryEntry.DAAScore,
+				ReceivedAmount:     addressHistoryEntry.ReceivedAmount,
+				SpentAmount:        addressHistoryEntry.SpentAmount,
+			}
+		}
+		entries[i] = &appmessage.TransactionsByAddressEntry{
+			Address:      addressString,
+			Transactions: transactions,
+			NextDAAScore: nextDAAScore,
+		}
+	}
+
+	return appmessage.NewGetTransactionsByAddressesResponseMessage(entries), nil
+}
@@ -0,0 +1,34 @@
+package rpchandlers
+
+import (
+	"encoding/hex"
+
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/app/rpc/rpccontext"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetVProgCode handles the respectively named RPC command
+func HandleGetVProgCode(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	getVProgCodeRequest := request.(*appmessage.GetVProgCodeRequestMessage)
+
+	programID, err := externalapi.NewDomainHashFromString(getVProgCodeRequest.ProgramID)
+	if err != nil {
+		errorMessage := &appmessage.GetVProgCodeResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Program ID could not be parsed: %s", err)
+		return errorMessage, nil
+	}
+
+	code, found, err := context.Domain.Consensus().GetVProgCode(programID)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		errorMessage := &appmessage.GetVProgCodeResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Program %s not found", programID)
+		return errorMessage, nil
+	}
+
+	return appmessage.NewGetVProgCodeResponseMessage(hex.EncodeToString(code)), nil
+}
@@ -0,0 +1,50 @@
+package rpchandlers
+
+import (
+	"encoding/hex"
+
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/app/rpc/rpccontext"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetVProgStorage handles the respectively named RPC command
+func HandleGetVProgStorage(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	getVProgStorageRequest := request.(*appmessage.GetVProgStorageRequestMessage)
+
+	programID, err := externalapi.NewDomainHashFromString(getVProgStorageRequest.ProgramID)
+	if err != nil {
+		errorMessage := &appmessage.GetVProgStorageResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Program ID could not be parsed: %s", err)
+		return errorMessage, nil
+	}
+
+	keys := make([]string, len(getVProgStorageRequest.Keys))
+	for i, hexKey := range getVProgStorageRequest.Keys {
+		key, err := hex.DecodeString(hexKey)
+		if err != nil {
+			errorMessage := &appmessage.GetVProgStorageResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf("Key %s could not be parsed: %s", hexKey, err)
+			return errorMessage, nil
+		}
+		keys[i] = string(key)
+	}
+
+	programStorage, err := context.Domain.Consensus().GetVProgStorage(programID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) > 0 {
+		requestedStorage := make(map[string][]byte, len(keys))
+		for _, key := range keys {
+			if value, ok := programStorage[key]; ok {
+				requestedStorage[key] = value
+			}
+		}
+		programStorage = requestedStorage
+	}
+
+	return appmessage.NewGetVProgStorageResponseMessage(vprogStorageEntries(programStorage)), nil
+}
@@ -0,0 +1,21 @@
+package rpchandlers
+
+import (
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/app/rpc/rpccontext"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/router"
+)
+
+// HandleNotifyMempoolChanged handles the respectively named RPC command
+func HandleNotifyMempoolChanged(context *rpccontext.Context, router *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	notifyMempoolChangedRequest := request.(*appmessage.NotifyMempoolChangedRequestMessage)
+
+	listener, err := context.NotificationManager.Listener(router)
+	if err != nil {
+		return nil, err
+	}
+	listener.PropagateMempoolChangedNotifications(notifyMempoolChangedRequest.IncludeTransactions)
+
+	response := appmessage.NewNotifyMempoolChangedResponseMessage()
+	return response, nil
+}
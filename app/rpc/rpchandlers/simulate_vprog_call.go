@@ -0,0 +1,84 @@
+package rpchandlers
+
+import (
+	"encoding/hex"
+	"sort"
+
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/app/rpc/rpccontext"
+	"github.com/zuanet/zuad/domain/consensus/vprog"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/router"
+)
+
+// HandleSimulateVProgCall handles the respectively named RPC command
+func HandleSimulateVProgCall(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	simulateVProgCallRequest := request.(*appmessage.SimulateVProgCallRequestMessage)
+
+	params := context.Config.NetParams()
+	if !params.EnableVProgs {
+		errorMessage := &appmessage.SimulateVProgCallResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Method unavailable when vprogs are disabled")
+		return errorMessage, nil
+	}
+
+	code, err := hex.DecodeString(simulateVProgCallRequest.Code)
+	if err != nil {
+		errorMessage := &appmessage.SimulateVProgCallResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Code could not be parsed: %s", err)
+		return errorMessage, nil
+	}
+	if len(code) == 0 {
+		errorMessage := &appmessage.SimulateVProgCallResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Code must not be empty")
+		return errorMessage, nil
+	}
+
+	data, err := hex.DecodeString(simulateVProgCallRequest.Data)
+	if err != nil {
+		errorMessage := &appmessage.SimulateVProgCallResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Data could not be parsed: %s", err)
+		return errorMessage, nil
+	}
+
+	gasLimit := simulateVProgCallRequest.GasLimit
+	if gasLimit == 0 {
+		gasLimit = params.MaxVProgGasLimit
+	}
+	if gasLimit > params.MaxVProgGasLimit {
+		errorMessage := &appmessage.SimulateVProgCallResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Gas limit %d exceeds the maximum of %d",
+			gasLimit, params.MaxVProgGasLimit)
+		return errorMessage, nil
+	}
+
+	result, err := context.Domain.Consensus().SimulateVProgCall(code, data, gasLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	executionError := ""
+	if !result.Success {
+		executionError = vprog.ErrorCode(result.ErrorCode).String()
+	}
+
+	return appmessage.NewSimulateVProgCallResponseMessage(result.ProgramID.String(), result.Success, result.GasUsed,
+		hex.EncodeToString(result.ReturnData), vprogStorageEntries(result.StateChanges), executionError), nil
+}
+
+// vprogStorageEntries converts the given vprog storage entries to RPC entries, sorted by key
+func vprogStorageEntries(entries map[string][]byte) []*appmessage.RPCVProgStorageEntry {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	rpcEntries := make([]*appmessage.RPCVProgStorageEntry, len(keys))
+	for i, key := range keys {
+		rpcEntries[i] = &appmessage.RPCVProgStorageEntry{
+			Key:   hex.EncodeToString([]byte(key)),
+			Value: hex.EncodeToString(entries[key]),
+		}
+	}
+	return rpcEntries
+}
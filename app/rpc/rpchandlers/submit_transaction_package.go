@@ -0,0 +1,47 @@
+package rpchandlers
+
+import (
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/app/rpc/rpccontext"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/utils/consensushashing"
+	"github.com/zuanet/zuad/domain/miningmanager/mempool"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/router"
+	"github.com/pkg/errors"
+)
+
+// HandleSubmitTransactionPackage handles the respectively named RPC command
+func HandleSubmitTransactionPackage(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	submitTransactionPackageRequest := request.(*appmessage.SubmitTransactionPackageRequestMessage)
+
+	domainTransactions := make([]*externalapi.DomainTransaction, len(submitTransactionPackageRequest.Transactions))
+	for i, transaction := range submitTransactionPackageRequest.Transactions {
+		domainTransaction, err := appmessage.RPCTransactionToDomainTransaction(transaction)
+		if err != nil {
+			errorMessage := &appmessage.SubmitTransactionPackageResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf("Could not parse transaction #%d: %s", i, err)
+			return errorMessage, nil
+		}
+		domainTransactions[i] = domainTransaction
+	}
+
+	acceptedTransactions, err := context.ProtocolManager.AddTransactionPackage(domainTransactions)
+	if err != nil {
+		if !errors.As(err, &mempool.RuleError{}) {
+			return nil, err
+		}
+
+		log.Debugf("Rejected transaction package: %s", err)
+		errorMessage := &appmessage.SubmitTransactionPackageResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Rejected transaction package: %s", err)
+		return errorMessage, nil
+	}
+
+	acceptedTransactionIDs := make([]string, len(acceptedTransactions))
+	for i, acceptedTransaction := range acceptedTransactions {
+		acceptedTransactionIDs[i] = consensushashing.TransactionID(acceptedTransaction).String()
+	}
+
+	response := appmessage.NewSubmitTransactionPackageResponseMessage(acceptedTransactionIDs)
+	return response, nil
+}
@@ -0,0 +1,43 @@
+package rpchandlers
+
+import (
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/app/rpc/rpccontext"
+	"github.com/zuanet/zuad/domain/consensus/utils/consensushashing"
+	"github.com/zuanet/zuad/domain/miningmanager/mempool"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/router"
+	"github.com/pkg/errors"
+)
+
+// HandleSubmitTransactionReplacement handles the respectively named RPC command
+func HandleSubmitTransactionReplacement(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	submitTransactionReplacementRequest := request.(*appmessage.SubmitTransactionReplacementRequestMessage)
+
+	domainTransaction, err := appmessage.RPCTransactionToDomainTransaction(submitTransactionReplacementRequest.Transaction)
+	if err != nil {
+		errorMessage := &appmessage.SubmitTransactionReplacementResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Could not parse transaction: %s", err)
+		return errorMessage, nil
+	}
+
+	transactionID := consensushashing.TransactionID(domainTransaction)
+	replacedTransactions, err := context.ProtocolManager.ReplaceTransaction(domainTransaction)
+	if err != nil {
+		if !errors.As(err, &mempool.RuleError{}) {
+			return nil, err
+		}
+
+		log.Debugf("Rejected replacement transaction %s: %s", transactionID, err)
+		errorMessage := &appmessage.SubmitTransactionReplacementResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Rejected replacement transaction %s: %s", transactionID, err)
+		return errorMessage, nil
+	}
+
+	replacedTransactionIDs := make([]string, len(replacedTransactions))
+	for i, replacedTransaction := range replacedTransactions {
+		replacedTransactionIDs[i] = consensushashing.TransactionID(replacedTransaction).String()
+	}
+
+	response := appmessage.NewSubmitTransactionReplacementResponseMessage(transactionID.String(), replacedTransactionIDs)
+	return response, nil
+}
@@ -0,0 +1,44 @@
+package rpchandlers
+
+import (
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/app/rpc/rpccontext"
+	"github.com/zuanet/zuad/domain/consensus/utils/consensushashing"
+	"github.com/zuanet/zuad/domain/miningmanager/mempool"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/router"
+)
+
+// HandleValidateTransaction handles the respectively named RPC command
+func HandleValidateTransaction(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	validateTransactionRequest := request.(*appmessage.ValidateTransactionRequestMessage)
+
+	domainTransaction, err := appmessage.RPCTransactionToDomainTransaction(validateTransactionRequest.Transaction)
+	if err != nil {
+		errorMessage := &appmessage.ValidateTransactionResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Could not parse transaction: %s", err)
+		return errorMessage, nil
+	}
+
+	transactionID := consensushashing.TransactionID(domainTransaction)
+	result, err := context.Domain.MiningManager().ValidateTransaction(domainTransaction)
+	if err != nil {
+		return nil, err
+	}
+
+	feeRate := float64(0)
+	if result.HasFee && result.Mass > 0 {
+		feeRate = float64(result.Fee) / float64(result.Mass)
+	}
+
+	rejectCode := ""
+	rejectReason := ""
+	if result.RuleError != nil {
+		code, _ := mempool.ExtractRejectCode(result.RuleError)
+		rejectCode = code.String()
+		rejectReason = result.RuleError.Error()
+	}
+
+	response := appmessage.NewValidateTransactionResponseMessage(transactionID.String(), result.Mass, result.Fee, feeRate,
+		result.HasFee, result.IsStandard, result.IsOrphan, result.RuleError == nil, rejectCode, rejectReason)
+	return response, nil
+}
@@ -32,12 +32,23 @@ var commandTypes = []reflect.Type{
 	reflect.TypeOf(protowire.ZuadMessage_GetMempoolEntryRequest{}),
 	reflect.TypeOf(protowire.ZuadMessage_GetMempoolEntriesRequest{}),
 	reflect.TypeOf(protowire.ZuadMessage_GetMempoolEntriesByAddressesRequest{}),
+	reflect.TypeOf(protowire.ZuadMessage_GetFeeEstimateRequest{}),
 
 	reflect.TypeOf(protowire.ZuadMessage_SubmitTransactionRequest{}),
+	reflect.TypeOf(protowire.ZuadMessage_SubmitTransactionReplacementRequest{}),
+	reflect.TypeOf(protowire.ZuadMessage_SubmitTransactionPackageRequest{}),
+	reflect.TypeOf(protowire.ZuadMessage_ValidateTransactionRequest{}),
+	reflect.TypeOf(protowire.ZuadMessage_GetTransactionRequest{}),
+	reflect.TypeOf(protowire.ZuadMessage_GetTransactionAcceptanceRequest{}),
 
 	reflect.TypeOf(protowire.ZuadMessage_GetUtxosByAddressesRequest{}),
 	reflect.TypeOf(protowire.ZuadMessage_GetBalanceByAddressRequest{}),
 	reflect.TypeOf(protowire.ZuadMessage_GetCoinSupplyRequest{}),
+	reflect.TypeOf(protowire.ZuadMessage_GetTransactionsByAddressesRequest{}),
+
+	reflect.TypeOf(protowire.ZuadMessage_SimulateVProgCallRequest{}),
+	reflect.TypeOf(protowire.ZuadMessage_GetVProgCodeRequest{}),
+	reflect.TypeOf(protowire.ZuadMessage_GetVProgStorageRequest{}),
 
 	reflect.TypeOf(protowire.ZuadMessage_BanRequest{}),
 	reflect.TypeOf(protowire.ZuadMessage_UnbanRequest{}),
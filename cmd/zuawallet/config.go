@@ -17,11 +17,16 @@ const (
 	createUnsignedTransactionSubCmd = "create-unsigned-transaction"
 	signSubCmd                      = "sign"
 	broadcastSubCmd                 = "broadcast"
+	validateTransactionSubCmd       = "validate-transaction"
 	parseSubCmd                     = "parse"
 	showAddressesSubCmd             = "show-addresses"
+	historySubCmd                   = "history"
+	labelTransactionSubCmd          = "label-transaction"
 	newAddressSubCmd                = "new-address"
 	dumpUnencryptedDataSubCmd       = "dump-unencrypted-data"
 	startDaemonSubCmd               = "start-daemon"
+	deployVProgSubCmd               = "deploy-vprog"
+	callVProgSubCmd                 = "call-vprog"
 )
 
 const (
@@ -34,14 +39,16 @@ type configFlags struct {
 }
 
 type createConfig struct {
-	KeysFile          string `long:"keys-file" short:"f" description:"Keys file location (default: ~/.zuawallet/keys.json (*nix), %USERPROFILE%\\AppData\\Local\\Zuawallet\\key.json (Windows))"`
-	Password          string `long:"password" short:"p" description:"Wallet password"`
-	Yes               bool   `long:"yes" short:"y" description:"Assume \"yes\" to all questions"`
-	MinimumSignatures uint32 `long:"min-signatures" short:"m" description:"Minimum required signatures" default:"1"`
-	NumPrivateKeys    uint32 `long:"num-private-keys" short:"k" description:"Number of private keys" default:"1"`
-	NumPublicKeys     uint32 `long:"num-public-keys" short:"n" description:"Total number of keys" default:"1"`
-	ECDSA             bool   `long:"ecdsa" description:"Create an ECDSA wallet"`
-	Import            bool   `long:"import" short:"i" description:"Import private keys (as opposed to generating them)"`
+	KeysFile           string   `long:"keys-file" short:"f" description:"Keys file location (default: ~/.zuawallet/keys.json (*nix), %USERPROFILE%\\AppData\\Local\\Zuawallet\\key.json (Windows))"`
+	Password           string   `long:"password" short:"p" description:"Wallet password"`
+	Yes                bool     `long:"yes" short:"y" description:"Assume \"yes\" to all questions"`
+	MinimumSignatures  uint32   `long:"min-signatures" short:"m" description:"Minimum required signatures" default:"1"`
+	NumPrivateKeys     uint32   `long:"num-private-keys" short:"k" description:"Number of private keys" default:"1"`
+	NumPublicKeys      uint32   `long:"num-public-keys" short:"n" description:"Total number of keys" default:"1"`
+	ECDSA              bool     `long:"ecdsa" description:"Create an ECDSA wallet"`
+	Import             bool     `long:"import" short:"i" description:"Import private keys (as opposed to generating them)"`
+	WatchOnly          bool     `long:"watch-only" description:"Create a watch-only wallet from extended public keys, without any private keys"`
+	ExtendedPublicKeys []string `long:"xpub" description:"Extended public key of a watch-only wallet (may be repeated; prompted for if omitted)"`
 	config.NetworkFlags
 }
 
@@ -60,6 +67,10 @@ type sendConfig struct {
 	SendAmount               float64  `long:"send-amount" short:"v" description:"An amount to send in Zua (e.g. 1234.12345678)"`
 	IsSendAll                bool     `long:"send-all" description:"Send all the Zua in the wallet (mutually exclusive with --send-amount)"`
 	UseExistingChangeAddress bool     `long:"use-existing-change-address" short:"u" description:"Will use an existing change address (in case no change address was ever used, it will use a new one)"`
+	FeePriority              string   `long:"fee-priority" description:"Pay the fee rate zuad estimates for the given priority: priority, normal or low (default: a fixed fee per input)"`
+	CoinSelection            string   `long:"coin-selection" description:"The strategy for choosing the UTXOs to spend: largest-first, privacy, branch-and-bound or consolidate (default: largest-first)"`
+	IncludeUTXOs             []string `long:"include-utxo" description:"A UTXO, given as <transaction ID>:<index>, that must be spent. Use multiple times to include several UTXOs"`
+	ExcludeUTXOs             []string `long:"exclude-utxo" description:"A UTXO, given as <transaction ID>:<index>, that must not be spent. Use multiple times to exclude several UTXOs"`
 	Verbose                  bool     `long:"show-serialized" short:"s" description:"Show a list of hex encoded sent transactions"`
 	config.NetworkFlags
 }
@@ -77,6 +88,10 @@ type createUnsignedTransactionConfig struct {
 	SendAmount               float64  `long:"send-amount" short:"v" description:"An amount to send in Zua (e.g. 1234.12345678)"`
 	IsSendAll                bool     `long:"send-all" description:"Send all the Zua in the wallet (mutually exclusive with --send-amount)"`
 	UseExistingChangeAddress bool     `long:"use-existing-change-address" short:"u" description:"Will use an existing change address (in case no change address was ever used, it will use a new one)"`
+	FeePriority              string   `long:"fee-priority" description:"Pay the fee rate zuad estimates for the given priority: priority, normal or low (default: a fixed fee per input)"`
+	CoinSelection            string   `long:"coin-selection" description:"The strategy for choosing the UTXOs to spend: largest-first, privacy, branch-and-bound or consolidate (default: largest-first)"`
+	IncludeUTXOs             []string `long:"include-utxo" description:"A UTXO, given as <transaction ID>:<index>, that must be spent. Use multiple times to include several UTXOs"`
+	ExcludeUTXOs             []string `long:"exclude-utxo" description:"A UTXO, given as <transaction ID>:<index>, that must not be spent. Use multiple times to exclude several UTXOs"`
 	config.NetworkFlags
 }
 
@@ -85,6 +100,7 @@ type signConfig struct {
 	Password        string `long:"password" short:"p" description:"Wallet password"`
 	Transaction     string `long:"transaction" short:"t" description:"The unsigned transaction(s) to sign on (encoded in hex)"`
 	TransactionFile string `long:"transaction-file" short:"F" description:"The file containing the unsigned transaction(s) to sign on (encoded in hex)"`
+	ExternalSigner  string `long:"external-signer" description:"A command that signs the transaction(s) out of process, e.g. on a hardware wallet. The keys file is then only used to tell the signature type"`
 	config.NetworkFlags
 }
 
@@ -95,6 +111,13 @@ type broadcastConfig struct {
 	config.NetworkFlags
 }
 
+type validateTransactionConfig struct {
+	DaemonAddress    string `long:"daemonaddress" short:"d" description:"Wallet daemon server to connect to"`
+	Transactions     string `long:"transaction" short:"t" description:"The signed transaction to validate (encoded in hex)"`
+	TransactionsFile string `long:"transaction-file" short:"F" description:"The file containing the signed transaction to validate (encoded in hex)"`
+	config.NetworkFlags
+}
+
 type parseConfig struct {
 	Transaction     string `long:"transaction" short:"t" description:"The transaction to parse (encoded in hex)"`
 	TransactionFile string `long:"transaction-file" short:"F" description:"The file containing the transaction to parse (encoded in hex)"`
@@ -107,6 +130,19 @@ type showAddressesConfig struct {
 	config.NetworkFlags
 }
 
+type historyConfig struct {
+	DaemonAddress string `long:"daemonaddress" short:"d" description:"Wallet daemon server to connect to"`
+	Limit         uint32 `long:"limit" short:"n" description:"Show only the given number of most recent transactions (default: all)"`
+	config.NetworkFlags
+}
+
+type labelTransactionConfig struct {
+	DaemonAddress string `long:"daemonaddress" short:"d" description:"Wallet daemon server to connect to"`
+	TransactionID string `long:"txid" short:"t" description:"The ID of the transaction to label" required:"true"`
+	Label         string `long:"label" short:"l" description:"The label to set (an empty label removes the existing one)"`
+	config.NetworkFlags
+}
+
 type newAddressConfig struct {
 	DaemonAddress string `long:"daemonaddress" short:"d" description:"Wallet daemon server to connect to"`
 	config.NetworkFlags
@@ -129,6 +165,29 @@ type dumpUnencryptedDataConfig struct {
 	config.NetworkFlags
 }
 
+type vprogTransactionConfig struct {
+	KeysFile                 string   `long:"keys-file" short:"f" description:"Keys file location (default: ~/.zuawallet/keys.json (*nix), %USERPROFILE%\\AppData\\Local\\Zuawallet\\key.json (Windows))"`
+	Password                 string   `long:"password" short:"p" description:"Wallet password"`
+	DaemonAddress            string   `long:"daemonaddress" short:"d" description:"Wallet daemon server to connect to"`
+	Data                     string   `long:"data" description:"The input data of the vprog call (encoded in hex)"`
+	GasLimit                 uint64   `long:"gas-limit" short:"g" description:"The maximum amount of gas the vprog call may use (default: the maximum allowed by the network)"`
+	FromAddresses            []string `long:"from-address" short:"a" description:"Specific public address to pay the fee from. Use multiple times to accept several addresses" required:"false"`
+	UseExistingChangeAddress bool     `long:"use-existing-change-address" short:"u" description:"Will use an existing change address (in case no change address was ever used, it will use a new one)"`
+	Verbose                  bool     `long:"show-serialized" short:"s" description:"Show the hex encoded sent transaction"`
+	config.NetworkFlags
+}
+
+type deployVProgConfig struct {
+	Code     string `long:"code" short:"c" description:"The code of the vprog to deploy (encoded in hex)"`
+	CodeFile string `long:"code-file" short:"C" description:"The file containing the code of the vprog to deploy (encoded in hex)"`
+	vprogTransactionConfig
+}
+
+type callVProgConfig struct {
+	ProgramID string `long:"program-id" short:"i" description:"The ID of the deployed vprog to call" required:"true"`
+	vprogTransactionConfig
+}
+
 func parseCommandLine() (subCommand string, config interface{}) {
 	cfg := &configFlags{}
 	parser := flags.NewParser(cfg, flags.PrintErrors|flags.HelpFlag)
@@ -163,6 +222,11 @@ func parseCommandLine() (subCommand string, config interface{}) {
 	parser.AddCommand(broadcastSubCmd, "Broadcast the given transaction",
 		"Broadcast the given transaction", broadcastConf)
 
+	validateTransactionConf := &validateTransactionConfig{DaemonAddress: defaultListen}
+	parser.AddCommand(validateTransactionSubCmd, "Check whether the node would accept the given transaction",
+		"Run the node's mempool checks on the given transaction without broadcasting it, "+
+			"and print its mass, fee and the exact reason it would be rejected", validateTransactionConf)
+
 	parseConf := &parseConfig{}
 	parser.AddCommand(parseSubCmd, "Parse the given transaction and print its contents",
 		"Parse the given transaction and print its contents", parseConf)
@@ -171,6 +235,15 @@ func parseCommandLine() (subCommand string, config interface{}) {
 	parser.AddCommand(showAddressesSubCmd, "Shows all generated public addresses of the current wallet",
 		"Shows all generated public addresses of the current wallet", showAddressesConf)
 
+	historyConf := &historyConfig{DaemonAddress: defaultListen}
+	parser.AddCommand(historySubCmd, "Shows the transaction history of the current wallet",
+		"Shows the incoming and outgoing transactions of the current wallet, along with their "+
+			"confirmations, fees and labels", historyConf)
+
+	labelTransactionConf := &labelTransactionConfig{DaemonAddress: defaultListen}
+	parser.AddCommand(labelTransactionSubCmd, "Sets the label of a transaction in the wallet history",
+		"Sets the label of a transaction in the wallet history", labelTransactionConf)
+
 	newAddressConf := &newAddressConfig{DaemonAddress: defaultListen}
 	parser.AddCommand(newAddressSubCmd, "Generates new public address of the current wallet and shows it",
 		"Generates new public address of the current wallet and shows it", newAddressConf)
@@ -180,6 +253,15 @@ func parseCommandLine() (subCommand string, config interface{}) {
 		"Prints the unencrypted wallet data including its private keys. Anyone that sees it can access "+
 			"the funds. Use only on safe environment.", dumpUnencryptedDataConf)
 
+	deployVProgConf := &deployVProgConfig{vprogTransactionConfig: vprogTransactionConfig{DaemonAddress: defaultListen}}
+	parser.AddCommand(deployVProgSubCmd, "Deploys a vprog",
+		"Deploys a vprog by sending a transaction that calls its code. The program is deployed once the call succeeds.",
+		deployVProgConf)
+
+	callVProgConf := &callVProgConfig{vprogTransactionConfig: vprogTransactionConfig{DaemonAddress: defaultListen}}
+	parser.AddCommand(callVProgSubCmd, "Calls a deployed vprog",
+		"Calls a deployed vprog by sending a transaction with the given input data", callVProgConf)
+
 	startDaemonConf := &startDaemonConfig{
 		RPCServer: defaultRPCServer,
 		Listen:    defaultListen,
@@ -204,6 +286,10 @@ func parseCommandLine() (subCommand string, config interface{}) {
 		if err != nil {
 			printErrorAndExit(err)
 		}
+		err = validateCreateConfig(createConf)
+		if err != nil {
+			printErrorAndExit(err)
+		}
 		config = createConf
 	case balanceSubCmd:
 		combineNetworkFlags(&balanceConf.NetworkFlags, &cfg.NetworkFlags)
@@ -255,6 +341,13 @@ func parseCommandLine() (subCommand string, config interface{}) {
 			printErrorAndExit(err)
 		}
 		config = broadcastConf
+	case validateTransactionSubCmd:
+		combineNetworkFlags(&validateTransactionConf.NetworkFlags, &cfg.NetworkFlags)
+		err := validateTransactionConf.ResolveNetwork(parser)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		config = validateTransactionConf
 	case parseSubCmd:
 		combineNetworkFlags(&parseConf.NetworkFlags, &cfg.NetworkFlags)
 		err := parseConf.ResolveNetwork(parser)
@@ -269,6 +362,20 @@ func parseCommandLine() (subCommand string, config interface{}) {
 			printErrorAndExit(err)
 		}
 		config = showAddressesConf
+	case historySubCmd:
+		combineNetworkFlags(&historyConf.NetworkFlags, &cfg.NetworkFlags)
+		err := historyConf.ResolveNetwork(parser)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		config = historyConf
+	case labelTransactionSubCmd:
+		combineNetworkFlags(&labelTransactionConf.NetworkFlags, &cfg.NetworkFlags)
+		err := labelTransactionConf.ResolveNetwork(parser)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		config = labelTransactionConf
 	case newAddressSubCmd:
 		combineNetworkFlags(&newAddressConf.NetworkFlags, &cfg.NetworkFlags)
 		err := newAddressConf.ResolveNetwork(parser)
@@ -290,6 +397,24 @@ func parseCommandLine() (subCommand string, config interface{}) {
 			printErrorAndExit(err)
 		}
 		config = startDaemonConf
+	case deployVProgSubCmd:
+		combineNetworkFlags(&deployVProgConf.NetworkFlags, &cfg.NetworkFlags)
+		err := deployVProgConf.ResolveNetwork(parser)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		err = validateDeployVProgConfig(deployVProgConf)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		config = deployVProgConf
+	case callVProgSubCmd:
+		combineNetworkFlags(&callVProgConf.NetworkFlags, &cfg.NetworkFlags)
+		err := callVProgConf.ResolveNetwork(parser)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		config = callVProgConf
 	}
 
 	return parser.Command.Active.Name, config
@@ -304,6 +429,27 @@ func validateCreateUnsignedTransactionConf(conf *createUnsignedTransactionConfig
 	return nil
 }
 
+func validateCreateConfig(conf *createConfig) error {
+	if !conf.WatchOnly {
+		if len(conf.ExtendedPublicKeys) > 0 {
+			return errors.New("'--xpub' can only be used together with '--watch-only'")
+		}
+		return nil
+	}
+
+	if conf.Import {
+		return errors.New("'--import' cannot be used together with '--watch-only'")
+	}
+	if len(conf.ExtendedPublicKeys) > 0 {
+		conf.NumPublicKeys = uint32(len(conf.ExtendedPublicKeys))
+	}
+	if conf.MinimumSignatures > conf.NumPublicKeys {
+		return errors.Errorf("the minimum number of signatures (%d) cannot exceed the number of public keys (%d)",
+			conf.MinimumSignatures, conf.NumPublicKeys)
+	}
+	return nil
+}
+
 func validateSendConfig(conf *sendConfig) error {
 	if (!conf.IsSendAll && conf.SendAmount == 0) ||
 		(conf.IsSendAll && conf.SendAmount > 0) {
@@ -313,6 +459,13 @@ func validateSendConfig(conf *sendConfig) error {
 	return nil
 }
 
+func validateDeployVProgConfig(conf *deployVProgConfig) error {
+	if (conf.Code == "") == (conf.CodeFile == "") {
+		return errors.New("exactly one of '--code' or '--code-file' must be specified")
+	}
+	return nil
+}
+
 func combineNetworkFlags(dst, src *config.NetworkFlags) {
 	dst.Testnet = dst.Testnet || src.Testnet
 	dst.Simnet = dst.Simnet || src.Simnet
@@ -14,6 +14,10 @@ import (
 )
 
 func create(conf *createConfig) error {
+	if conf.WatchOnly {
+		return createWatchOnly(conf)
+	}
+
 	var encryptedMnemonics []*keys.EncryptedMnemonic
 	var signerExtendedPublicKeys []string
 	var err error
@@ -64,7 +68,7 @@ func create(conf *createConfig) error {
 		}
 	}
 
-	file := keys.File{
+	file := &keys.File{
 		Version:            keys.LastVersion,
 		EncryptedMnemonics: encryptedMnemonics,
 		ExtendedPublicKeys: extendedPublicKeys,
@@ -73,7 +77,51 @@ func create(conf *createConfig) error {
 		ECDSA:              conf.ECDSA,
 	}
 
-	err = file.SetPath(conf.NetParams(), conf.KeysFile, conf.Yes)
+	return saveKeysFile(conf, file)
+}
+
+// createWatchOnly creates a keys file that contains only extended public keys.
+// Such a wallet can sync balances, derive addresses and create unsigned
+// transactions, which then have to be signed elsewhere.
+func createWatchOnly(conf *createConfig) error {
+	extendedPublicKeys := conf.ExtendedPublicKeys
+	if len(extendedPublicKeys) == 0 {
+		extendedPublicKeys = make([]string, 0, conf.NumPublicKeys)
+		reader := bufio.NewReader(os.Stdin)
+		for i := uint32(0); i < conf.NumPublicKeys; i++ {
+			fmt.Printf("Enter public key #%d here:\n", i+1)
+			extendedPublicKey, err := utils.ReadLine(reader)
+			if err != nil {
+				return err
+			}
+			fmt.Println()
+
+			extendedPublicKeys = append(extendedPublicKeys, string(extendedPublicKey))
+		}
+	}
+
+	for _, extendedPublicKey := range extendedPublicKeys {
+		err := libzuawallet.ValidateExtendedPublicKey(conf.NetParams(), extendedPublicKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	// For a read only wallet the cosigner index is 0
+	file := &keys.File{
+		Version:            keys.LastVersion,
+		EncryptedMnemonics: []*keys.EncryptedMnemonic{},
+		ExtendedPublicKeys: extendedPublicKeys,
+		MinimumSignatures:  conf.MinimumSignatures,
+		CosignerIndex:      0,
+		ECDSA:              conf.ECDSA,
+	}
+
+	return saveKeysFile(conf, file)
+}
+
+func saveKeysFile(conf *createConfig, file *keys.File) error {
+	err := file.SetPath(conf.NetParams(), conf.KeysFile, conf.Yes)
 	if err != nil {
 		return err
 	}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/zuanet/zuad/cmd/zuawallet/daemon/client"
 	"github.com/zuanet/zuad/cmd/zuawallet/daemon/pb"
 	"github.com/zuanet/zuad/domain/consensus/utils/constants"
+	"github.com/pkg/errors"
 )
 
 func createUnsignedTransaction(conf *createUnsignedTransactionConfig) error {
@@ -20,6 +23,15 @@ func createUnsignedTransaction(conf *createUnsignedTransactionConfig) error {
 	ctx, cancel := context.WithTimeout(context.Background(), daemonTimeout)
 	defer cancel()
 
+	includeOutpoints, err := parseOutpoints(conf.IncludeUTXOs)
+	if err != nil {
+		return err
+	}
+	excludeOutpoints, err := parseOutpoints(conf.ExcludeUTXOs)
+	if err != nil {
+		return err
+	}
+
 	sendAmountSompi := uint64(conf.SendAmount * constants.SompiPerZua)
 	response, err := daemonClient.CreateUnsignedTransactions(ctx, &pb.CreateUnsignedTransactionsRequest{
 		From:                     conf.FromAddresses,
@@ -27,6 +39,10 @@ func createUnsignedTransaction(conf *createUnsignedTransactionConfig) error {
 		Amount:                   sendAmountSompi,
 		IsSendAll:                conf.IsSendAll,
 		UseExistingChangeAddress: conf.UseExistingChangeAddress,
+		FeePriority:              conf.FeePriority,
+		CoinSelection:            conf.CoinSelection,
+		IncludeOutpoints:         includeOutpoints,
+		ExcludeOutpoints:         excludeOutpoints,
 	})
 	if err != nil {
 		return err
@@ -37,3 +53,25 @@ func createUnsignedTransaction(conf *createUnsignedTransactionConfig) error {
 
 	return nil
 }
+
+// parseOutpoints parses outpoints given as <transaction ID>:<index>
+func parseOutpoints(outpointStrings []string) ([]*pb.Outpoint, error) {
+	outpoints := make([]*pb.Outpoint, len(outpointStrings))
+	for i, outpointString := range outpointStrings {
+		separatorIndex := strings.LastIndex(outpointString, ":")
+		if separatorIndex == -1 {
+			return nil, errors.Errorf("outpoint %s is not of the form <transaction ID>:<index>", outpointString)
+		}
+
+		index, err := strconv.ParseUint(outpointString[separatorIndex+1:], 10, 32)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid index in outpoint %s", outpointString)
+		}
+
+		outpoints[i] = &pb.Outpoint{
+			TransactionId: outpointString[:separatorIndex],
+			Index:         uint32(index),
+		}
+	}
+	return outpoints, nil
+}
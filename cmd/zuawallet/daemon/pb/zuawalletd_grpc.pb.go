@@ -21,10 +21,14 @@ type ZuawalletdClient interface {
 	GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error)
 	GetExternalSpendableUTXOs(ctx context.Context, in *GetExternalSpendableUTXOsRequest, opts ...grpc.CallOption) (*GetExternalSpendableUTXOsResponse, error)
 	CreateUnsignedTransactions(ctx context.Context, in *CreateUnsignedTransactionsRequest, opts ...grpc.CallOption) (*CreateUnsignedTransactionsResponse, error)
+	CreateUnsignedVProgTransaction(ctx context.Context, in *CreateUnsignedVProgTransactionRequest, opts ...grpc.CallOption) (*CreateUnsignedVProgTransactionResponse, error)
 	ShowAddresses(ctx context.Context, in *ShowAddressesRequest, opts ...grpc.CallOption) (*ShowAddressesResponse, error)
 	NewAddress(ctx context.Context, in *NewAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error)
 	Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error)
 	Broadcast(ctx context.Context, in *BroadcastRequest, opts ...grpc.CallOption) (*BroadcastResponse, error)
+	ValidateTransactions(ctx context.Context, in *ValidateTransactionsRequest, opts ...grpc.CallOption) (*ValidateTransactionsResponse, error)
+	GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*GetTransactionsResponse, error)
+	LabelTransaction(ctx context.Context, in *LabelTransactionRequest, opts ...grpc.CallOption) (*LabelTransactionResponse, error)
 	// Since SendRequest contains a password - this command should only be used on a trusted or secure connection
 	Send(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error)
 	// Since SignRequest contains a password - this command should only be used on a trusted or secure connection
@@ -66,6 +70,15 @@ func (c *zuawalletdClient) CreateUnsignedTransactions(ctx context.Context, in *C
 	return out, nil
 }
 
+func (c *zuawalletdClient) CreateUnsignedVProgTransaction(ctx context.Context, in *CreateUnsignedVProgTransactionRequest, opts ...grpc.CallOption) (*CreateUnsignedVProgTransactionResponse, error) {
+	out := new(CreateUnsignedVProgTransactionResponse)
+	err := c.cc.Invoke(ctx, "/zuawalletd.zuawalletd/CreateUnsignedVProgTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *zuawalletdClient) ShowAddresses(ctx context.Context, in *ShowAddressesRequest, opts ...grpc.CallOption) (*ShowAddressesResponse, error) {
 	out := new(ShowAddressesResponse)
 	err := c.cc.Invoke(ctx, "/zuawalletd.zuawalletd/ShowAddresses", in, out, opts...)
@@ -102,6 +115,33 @@ func (c *zuawalletdClient) Broadcast(ctx context.Context, in *BroadcastRequest,
 	return out, nil
 }
 
+func (c *zuawalletdClient) ValidateTransactions(ctx context.Context, in *ValidateTransactionsRequest, opts ...grpc.CallOption) (*ValidateTransactionsResponse, error) {
+	out := new(ValidateTransactionsResponse)
+	err := c.cc.Invoke(ctx, "/zuawalletd.zuawalletd/ValidateTransactions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *zuawalletdClient) GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*GetTransactionsResponse, error) {
+	out := new(GetTransactionsResponse)
+	err := c.cc.Invoke(ctx, "/zuawalletd.zuawalletd/GetTransactions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *zuawalletdClient) LabelTransaction(ctx context.Context, in *LabelTransactionRequest, opts ...grpc.CallOption) (*LabelTransactionResponse, error) {
+	out := new(LabelTransactionResponse)
+	err := c.cc.Invoke(ctx, "/zuawalletd.zuawalletd/LabelTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *zuawalletdClient) Send(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error) {
 	out := new(SendResponse)
 	err := c.cc.Invoke(ctx, "/zuawalletd.zuawalletd/Send", in, out, opts...)
@@ -127,10 +167,14 @@ type ZuawalletdServer interface {
 	GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error)
 	GetExternalSpendableUTXOs(context.Context, *GetExternalSpendableUTXOsRequest) (*GetExternalSpendableUTXOsResponse, error)
 	CreateUnsignedTransactions(context.Context, *CreateUnsignedTransactionsRequest) (*CreateUnsignedTransactionsResponse, error)
+	CreateUnsignedVProgTransaction(context.Context, *CreateUnsignedVProgTransactionRequest) (*CreateUnsignedVProgTransactionResponse, error)
 	ShowAddresses(context.Context, *ShowAddressesRequest) (*ShowAddressesResponse, error)
 	NewAddress(context.Context, *NewAddressRequest) (*NewAddressResponse, error)
 	Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error)
 	Broadcast(context.Context, *BroadcastRequest) (*BroadcastResponse, error)
+	ValidateTransactions(context.Context, *ValidateTransactionsRequest) (*ValidateTransactionsResponse, error)
+	GetTransactions(context.Context, *GetTransactionsRequest) (*GetTransactionsResponse, error)
+	LabelTransaction(context.Context, *LabelTransactionRequest) (*LabelTransactionResponse, error)
 	// Since SendRequest contains a password - this command should only be used on a trusted or secure connection
 	Send(context.Context, *SendRequest) (*SendResponse, error)
 	// Since SignRequest contains a password - this command should only be used on a trusted or secure connection
@@ -151,6 +195,9 @@ func (UnimplementedZuawalletdServer) GetExternalSpendableUTXOs(context.Context,
 func (UnimplementedZuawalletdServer) CreateUnsignedTransactions(context.Context, *CreateUnsignedTransactionsRequest) (*CreateUnsignedTransactionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateUnsignedTransactions not implemented")
 }
+func (UnimplementedZuawalletdServer) CreateUnsignedVProgTransaction(context.Context, *CreateUnsignedVProgTransactionRequest) (*CreateUnsignedVProgTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUnsignedVProgTransaction not implemented")
+}
 func (UnimplementedZuawalletdServer) ShowAddresses(context.Context, *ShowAddressesRequest) (*ShowAddressesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ShowAddresses not implemented")
 }
@@ -163,6 +210,15 @@ func (UnimplementedZuawalletdServer) Shutdown(context.Context, *ShutdownRequest)
 func (UnimplementedZuawalletdServer) Broadcast(context.Context, *BroadcastRequest) (*BroadcastResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Broadcast not implemented")
 }
+func (UnimplementedZuawalletdServer) ValidateTransactions(context.Context, *ValidateTransactionsRequest) (*ValidateTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateTransactions not implemented")
+}
+func (UnimplementedZuawalletdServer) GetTransactions(context.Context, *GetTransactionsRequest) (*GetTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransactions not implemented")
+}
+func (UnimplementedZuawalletdServer) LabelTransaction(context.Context, *LabelTransactionRequest) (*LabelTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LabelTransaction not implemented")
+}
 func (UnimplementedZuawalletdServer) Send(context.Context, *SendRequest) (*SendResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Send not implemented")
 }
@@ -236,6 +292,24 @@ func _Zuawalletd_CreateUnsignedTransactions_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Zuawalletd_CreateUnsignedVProgTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUnsignedVProgTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZuawalletdServer).CreateUnsignedVProgTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/zuawalletd.zuawalletd/CreateUnsignedVProgTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZuawalletdServer).CreateUnsignedVProgTransaction(ctx, req.(*CreateUnsignedVProgTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Zuawalletd_ShowAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ShowAddressesRequest)
 	if err := dec(in); err != nil {
@@ -308,6 +382,60 @@ func _Zuawalletd_Broadcast_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Zuawalletd_ValidateTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZuawalletdServer).ValidateTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/zuawalletd.zuawalletd/ValidateTransactions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZuawalletdServer).ValidateTransactions(ctx, req.(*ValidateTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Zuawalletd_GetTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZuawalletdServer).GetTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/zuawalletd.zuawalletd/GetTransactions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZuawalletdServer).GetTransactions(ctx, req.(*GetTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Zuawalletd_LabelTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabelTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZuawalletdServer).LabelTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/zuawalletd.zuawalletd/LabelTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZuawalletdServer).LabelTransaction(ctx, req.(*LabelTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Zuawalletd_Send_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SendRequest)
 	if err := dec(in); err != nil {
@@ -363,6 +491,10 @@ var Zuawalletd_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateUnsignedTransactions",
 			Handler:    _Zuawalletd_CreateUnsignedTransactions_Handler,
 		},
+		{
+			MethodName: "CreateUnsignedVProgTransaction",
+			Handler:    _Zuawalletd_CreateUnsignedVProgTransaction_Handler,
+		},
 		{
 			MethodName: "ShowAddresses",
 			Handler:    _Zuawalletd_ShowAddresses_Handler,
@@ -379,6 +511,18 @@ var Zuawalletd_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Broadcast",
 			Handler:    _Zuawalletd_Broadcast_Handler,
 		},
+		{
+			MethodName: "ValidateTransactions",
+			Handler:    _Zuawalletd_ValidateTransactions_Handler,
+		},
+		{
+			MethodName: "GetTransactions",
+			Handler:    _Zuawalletd_GetTransactions_Handler,
+		},
+		{
+			MethodName: "LabelTransaction",
+			Handler:    _Zuawalletd_LabelTransaction_Handler,
+		},
 		{
 			MethodName: "Send",
 			Handler:    _Zuawalletd_Send_Handler,
@@ -9,7 +9,6 @@ import (
 	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
 	"github.com/zuanet/zuad/infrastructure/network/rpcclient"
 	"github.com/pkg/errors"
-	"time"
 )
 
 func (s *server) Broadcast(_ context.Context, request *pb.BroadcastRequest) (*pb.BroadcastResponse, error) {
@@ -49,16 +48,13 @@ func (s *server) broadcast(transactions [][]byte, isDomain bool) ([]string, erro
 			return nil, err
 		}
 
+		s.recordSentTransaction(txIDs[i], tx)
+
 		for _, input := range tx.Inputs {
-			s.usedOutpoints[input.PreviousOutpoint] = time.Now()
+			s.usedOutpoints[input.PreviousOutpoint] = txIDs[i]
 		}
 	}
 
-	err = s.refreshUTXOs()
-	if err != nil {
-		return nil, err
-	}
-
 	return txIDs, nil
 }
 
@@ -0,0 +1,320 @@
+package server
+
+import (
+	"sort"
+
+	"github.com/zuanet/zuad/cmd/zuawallet/daemon/pb"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/utils/transactionid"
+	"github.com/pkg/errors"
+)
+
+const (
+	// coinSelectionLargestFirst spends the largest UTXOs first, which keeps
+	// the number of inputs low. This is the default strategy.
+	coinSelectionLargestFirst = "largest-first"
+
+	// coinSelectionPrivacy prefers spending from as few addresses as possible,
+	// and spends all the UTXOs of every address it uses, so that addresses
+	// that weren't linked before aren't linked by the transaction.
+	coinSelectionPrivacy = "privacy"
+
+	// coinSelectionBranchAndBound looks for a set of UTXOs that pays the
+	// amount and fee exactly enough that no change output is needed,
+	// and falls back to largest-first if there is none.
+	coinSelectionBranchAndBound = "branch-and-bound"
+
+	// coinSelectionConsolidate spends the smallest UTXOs of the wallet along
+	// with the payment while fees are low, to save on fees later.
+	coinSelectionConsolidate = "consolidate"
+)
+
+// branchAndBoundMaxTries is the maximal number of nodes the branch-and-bound
+// search visits before giving up.
+const branchAndBoundMaxTries = 100_000
+
+// consolidationMaxFeeRate is the highest normal-priority fee rate, in sompi
+// per gram, at which fees are considered low enough for consolidation.
+const consolidationMaxFeeRate = 1.0
+
+// consolidationMaxInputs is the maximal number of inputs the consolidate
+// strategy adds to a transaction. Transactions that exceed the mass limit
+// are split by maybeAutoCompoundTransaction.
+const consolidationMaxInputs = 100
+
+// coinSelectionOptions controls which UTXOs are spent by a transaction.
+type coinSelectionOptions struct {
+	strategy string
+
+	// includedOutpoints must be spent by the transaction, and
+	// excludedOutpoints must not.
+	includedOutpoints map[externalapi.DomainOutpoint]struct{}
+	excludedOutpoints map[externalapi.DomainOutpoint]struct{}
+}
+
+func defaultCoinSelectionOptions() *coinSelectionOptions {
+	return &coinSelectionOptions{
+		strategy:          coinSelectionLargestFirst,
+		includedOutpoints: map[externalapi.DomainOutpoint]struct{}{},
+		excludedOutpoints: map[externalapi.DomainOutpoint]struct{}{},
+	}
+}
+
+func newCoinSelectionOptions(strategy string, includedOutpoints, excludedOutpoints []*pb.Outpoint) (
+	*coinSelectionOptions, error) {
+
+	options := defaultCoinSelectionOptions()
+	switch strategy {
+	case "":
+	case coinSelectionLargestFirst, coinSelectionPrivacy, coinSelectionBranchAndBound, coinSelectionConsolidate:
+		options.strategy = strategy
+	default:
+		return nil, errors.Errorf("unknown coin selection strategy %s, expected one of: %s, %s, %s, %s", strategy,
+			coinSelectionLargestFirst, coinSelectionPrivacy, coinSelectionBranchAndBound, coinSelectionConsolidate)
+	}
+
+	for _, outpoint := range includedOutpoints {
+		domainOutpoint, err := protoOutpointToDomain(outpoint)
+		if err != nil {
+			return nil, err
+		}
+		options.includedOutpoints[*domainOutpoint] = struct{}{}
+	}
+	for _, outpoint := range excludedOutpoints {
+		domainOutpoint, err := protoOutpointToDomain(outpoint)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := options.includedOutpoints[*domainOutpoint]; ok {
+			return nil, errors.Errorf("outpoint %s:%d is both included and excluded",
+				outpoint.TransactionId, outpoint.Index)
+		}
+		options.excludedOutpoints[*domainOutpoint] = struct{}{}
+	}
+
+	return options, nil
+}
+
+func protoOutpointToDomain(outpoint *pb.Outpoint) (*externalapi.DomainOutpoint, error) {
+	transactionID, err := transactionid.FromString(outpoint.TransactionId)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid outpoint transaction ID %s", outpoint.TransactionId)
+	}
+	return &externalapi.DomainOutpoint{
+		TransactionID: *transactionID,
+		Index:         outpoint.Index,
+	}, nil
+}
+
+// selectCoins picks the UTXOs to spend out of candidates, which are sorted by
+// amount in descending order, so that they pay spendAmount along with a fee of
+// feePerInput for every input. The included UTXOs are always spent.
+// It also returns whether the selection is meant to be spent without change,
+// in which case any excess goes to the fee.
+func selectCoins(strategy string, candidates, included []*walletUTXO, spendAmount, feePerInput uint64,
+	areFeesLow bool) (selected []*walletUTXO, isChangeless bool) {
+
+	switch strategy {
+	case coinSelectionPrivacy:
+		return selectCoinsPrivacy(candidates, included, spendAmount, feePerInput), false
+	case coinSelectionBranchAndBound:
+		selected, found := selectCoinsBranchAndBound(candidates, included, spendAmount, feePerInput)
+		if found {
+			return selected, true
+		}
+	case coinSelectionConsolidate:
+		if areFeesLow {
+			return selectCoinsConsolidate(candidates, included, spendAmount, feePerInput), false
+		}
+	}
+
+	return selectCoinsLargestFirst(candidates, included, spendAmount, feePerInput), false
+}
+
+func totalAmount(utxos []*walletUTXO) uint64 {
+	total := uint64(0)
+	for _, utxo := range utxos {
+		total += utxo.UTXOEntry.Amount()
+	}
+	return total
+}
+
+func isEnough(utxos []*walletUTXO, spendAmount, feePerInput uint64) bool {
+	return totalAmount(utxos) >= spendAmount+feePerInput*uint64(len(utxos))
+}
+
+func selectCoinsLargestFirst(candidates, included []*walletUTXO, spendAmount, feePerInput uint64) []*walletUTXO {
+	selected := append([]*walletUTXO{}, included...)
+	for _, utxo := range candidates {
+		if len(selected) > 0 && isEnough(selected, spendAmount, feePerInput) {
+			break
+		}
+		selected = append(selected, utxo)
+	}
+	return selected
+}
+
+// selectCoinsPrivacy groups the candidates by address and spends whole
+// groups. The addresses of the included UTXOs are always used. If more
+// funds are needed, it prefers the single address that covers the rest
+// with the smallest balance, and otherwise adds the addresses with the
+// largest balances until the payment is covered.
+func selectCoinsPrivacy(candidates, included []*walletUTXO, spendAmount, feePerInput uint64) []*walletUTXO {
+	clusters := make(map[*walletAddress][]*walletUTXO)
+	for _, utxo := range candidates {
+		clusters[utxo.address] = append(clusters[utxo.address], utxo)
+	}
+
+	selected := append([]*walletUTXO{}, included...)
+	for _, utxo := range included {
+		selected = append(selected, clusters[utxo.address]...)
+		delete(clusters, utxo.address)
+	}
+
+	remainingClusters := make([][]*walletUTXO, 0, len(clusters))
+	for _, cluster := range clusters {
+		remainingClusters = append(remainingClusters, cluster)
+	}
+	sort.Slice(remainingClusters, func(i, j int) bool {
+		iTotal, jTotal := totalAmount(remainingClusters[i]), totalAmount(remainingClusters[j])
+		if iTotal != jTotal {
+			return iTotal < jTotal
+		}
+		return remainingClusters[i][0].Outpoint.String() < remainingClusters[j][0].Outpoint.String()
+	})
+
+	if len(selected) > 0 && isEnough(selected, spendAmount, feePerInput) {
+		return selected
+	}
+
+	// remainingClusters is sorted by ascending balance, so the first
+	// cluster that covers the payment on its own is the smallest one.
+	for _, cluster := range remainingClusters {
+		if isEnough(append(append([]*walletUTXO{}, selected...), cluster...), spendAmount, feePerInput) {
+			return append(selected, cluster...)
+		}
+	}
+
+	for i := len(remainingClusters) - 1; i >= 0; i-- {
+		selected = append(selected, remainingClusters[i]...)
+		if isEnough(selected, spendAmount, feePerInput) {
+			break
+		}
+	}
+	return selected
+}
+
+// selectCoinsBranchAndBound searches for a set of candidates that, along with
+// the included UTXOs, pays spendAmount and the fee with an excess no larger
+// than the cost of a change output, which is assumed to be the fee of
+// spending it later. Among the sets it finds it picks the one with the
+// smallest excess.
+func selectCoinsBranchAndBound(candidates, included []*walletUTXO, spendAmount, feePerInput uint64) (
+	[]*walletUTXO, bool) {
+
+	costOfChange := feePerInput
+
+	includedEffectiveValue := int64(0)
+	for _, utxo := range included {
+		includedEffectiveValue += int64(utxo.UTXOEntry.Amount()) - int64(feePerInput)
+	}
+	target := int64(spendAmount) - includedEffectiveValue
+	if target <= 0 {
+		return included, -target <= int64(costOfChange)
+	}
+
+	// UTXOs that cost more to spend than they're worth can't be
+	// part of a solution.
+	var effectiveCandidates []*walletUTXO
+	var effectiveValues []int64
+	for _, utxo := range candidates {
+		if utxo.UTXOEntry.Amount() <= feePerInput {
+			continue
+		}
+		effectiveCandidates = append(effectiveCandidates, utxo)
+		effectiveValues = append(effectiveValues, int64(utxo.UTXOEntry.Amount()-feePerInput))
+	}
+
+	remaining := int64(0)
+	for _, value := range effectiveValues {
+		remaining += value
+	}
+	if remaining < target {
+		return nil, false
+	}
+
+	var bestSelection []bool
+	bestExcess := int64(costOfChange) + 1
+	selection := make([]bool, len(effectiveValues))
+	tries := 0
+
+	var search func(index int, value, remaining int64)
+	search = func(index int, value, remaining int64) {
+		tries++
+		if tries > branchAndBoundMaxTries || value+remaining < target || value > target+int64(costOfChange) {
+			return
+		}
+		if value >= target {
+			if excess := value - target; excess < bestExcess {
+				bestExcess = excess
+				bestSelection = append([]bool{}, selection...)
+			}
+			return
+		}
+		if index == len(effectiveValues) {
+			return
+		}
+
+		selection[index] = true
+		search(index+1, value+effectiveValues[index], remaining-effectiveValues[index])
+		selection[index] = false
+		search(index+1, value, remaining-effectiveValues[index])
+	}
+	search(0, 0, remaining)
+
+	if bestSelection == nil {
+		return nil, false
+	}
+
+	selected := append([]*walletUTXO{}, included...)
+	for i, isSelected := range bestSelection {
+		if isSelected {
+			selected = append(selected, effectiveCandidates[i])
+		}
+	}
+	return selected, true
+}
+
+// selectCoinsConsolidate pays with the largest UTXOs first, and then adds the
+// smallest remaining UTXOs, as long as each of them is worth more than the fee
+// of spending it.
+func selectCoinsConsolidate(candidates, included []*walletUTXO, spendAmount, feePerInput uint64) []*walletUTXO {
+	selected := selectCoinsLargestFirst(candidates, included, spendAmount, feePerInput)
+
+	isSelected := make(map[*walletUTXO]struct{}, len(selected))
+	for _, utxo := range selected {
+		isSelected[utxo] = struct{}{}
+	}
+
+	for i := len(candidates) - 1; i >= 0 && len(selected) < consolidationMaxInputs; i-- {
+		utxo := candidates[i]
+		if _, ok := isSelected[utxo]; ok {
+			continue
+		}
+		if utxo.UTXOEntry.Amount() <= feePerInput {
+			continue
+		}
+		selected = append(selected, utxo)
+	}
+	return selected
+}
+
+// areFeesLow returns whether the fee rate zuad estimates for normal priority
+// is low enough to consolidate UTXOs.
+func (s *server) areFeesLow() (bool, error) {
+	response, err := s.rpcClient.GetFeeEstimate()
+	if err != nil {
+		return false, err
+	}
+	return response.Estimate.NormalBucket.FeeRate <= consolidationMaxFeeRate, nil
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/utils/utxo"
+)
+
+func newTestWalletUTXO(index uint32, amount uint64, address *walletAddress) *walletUTXO {
+	return &walletUTXO{
+		Outpoint:  &externalapi.DomainOutpoint{TransactionID: externalapi.DomainTransactionID{}, Index: index},
+		UTXOEntry: utxo.NewUTXOEntry(amount, &externalapi.ScriptPublicKey{}, false, 0),
+		address:   address,
+	}
+}
+
+func TestSelectCoins(t *testing.T) {
+	addressA := &walletAddress{index: 1}
+	addressB := &walletAddress{index: 2}
+	addressC := &walletAddress{index: 3}
+
+	// Sorted by amount in descending order, like utxosSortedByAmount
+	candidates := []*walletUTXO{
+		newTestWalletUTXO(0, 1000, addressA),
+		newTestWalletUTXO(1, 600, addressB),
+		newTestWalletUTXO(2, 500, addressC),
+		newTestWalletUTXO(3, 400, addressB),
+		newTestWalletUTXO(4, 15, addressA),
+		newTestWalletUTXO(5, 5, addressC),
+	}
+	const feePerInput = 10
+
+	tests := []struct {
+		name                 string
+		strategy             string
+		included             []*walletUTXO
+		spendAmount          uint64
+		areFeesLow           bool
+		expectedIndexes      []uint32
+		expectedIsChangeless bool
+	}{
+		{
+			name:            "largest-first",
+			strategy:        coinSelectionLargestFirst,
+			spendAmount:     1200,
+			expectedIndexes: []uint32{0, 1},
+		},
+		{
+			name:            "largest-first with an included UTXO",
+			strategy:        coinSelectionLargestFirst,
+			included:        []*walletUTXO{newTestWalletUTXO(6, 300, addressC)},
+			spendAmount:     1200,
+			expectedIndexes: []uint32{6, 0},
+		},
+		{
+			// addressB holds 1000, which covers the payment on its own
+			name:            "privacy",
+			strategy:        coinSelectionPrivacy,
+			spendAmount:     900,
+			expectedIndexes: []uint32{1, 3},
+		},
+		{
+			// 600 + 500 = 1100, which pays 1080 and a fee of 20 exactly
+			name:                 "branch-and-bound",
+			strategy:             coinSelectionBranchAndBound,
+			spendAmount:          1080,
+			expectedIndexes:      []uint32{1, 2},
+			expectedIsChangeless: true,
+		},
+		{
+			name:            "branch-and-bound without an exact match",
+			strategy:        coinSelectionBranchAndBound,
+			spendAmount:     2400,
+			expectedIndexes: []uint32{0, 1, 2, 3},
+		},
+		{
+			// The UTXO of 5 is worth less than the fee of spending it
+			name:            "consolidate",
+			strategy:        coinSelectionConsolidate,
+			spendAmount:     900,
+			areFeesLow:      true,
+			expectedIndexes: []uint32{0, 4, 3, 2, 1},
+		},
+		{
+			name:            "consolidate with high fees",
+			strategy:        coinSelectionConsolidate,
+			spendAmount:     900,
+			expectedIndexes: []uint32{0},
+		},
+	}
+
+	for _, test := range tests {
+		selected, isChangeless := selectCoins(test.strategy, candidates, test.included, test.spendAmount,
+			feePerInput, test.areFeesLow)
+
+		if isChangeless != test.expectedIsChangeless {
+			t.Errorf("%s: expected isChangeless to be %t, got %t", test.name, test.expectedIsChangeless, isChangeless)
+		}
+		if len(selected) != len(test.expectedIndexes) {
+			t.Errorf("%s: expected %d UTXOs to be selected, got %d", test.name, len(test.expectedIndexes), len(selected))
+			continue
+		}
+		for i, expectedIndex := range test.expectedIndexes {
+			if selected[i].Outpoint.Index != expectedIndex {
+				t.Errorf("%s: expected UTXO #%d to be %d, got %d", test.name, i, expectedIndex, selected[i].Outpoint.Index)
+			}
+		}
+	}
+}
@@ -3,7 +3,6 @@ package server
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/zuanet/zuad/cmd/zuawallet/daemon/pb"
 	"github.com/zuanet/zuad/cmd/zuawallet/libzuawallet"
@@ -13,7 +12,7 @@ import (
 	"golang.org/x/exp/slices"
 )
 
-// TODO: Implement a better fee estimation mechanism
+// feePerInput is the fee paid for every input when no fee priority is requested
 const feePerInput = 10000
 
 func (s *server) CreateUnsignedTransactions(_ context.Context, request *pb.CreateUnsignedTransactionsRequest) (
@@ -22,8 +21,13 @@ func (s *server) CreateUnsignedTransactions(_ context.Context, request *pb.Creat
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
+	coinSelection, err := newCoinSelectionOptions(request.CoinSelection, request.IncludeOutpoints, request.ExcludeOutpoints)
+	if err != nil {
+		return nil, err
+	}
+
 	unsignedTransactions, err := s.createUnsignedTransactions(request.Address, request.Amount, request.IsSendAll,
-		request.From, request.UseExistingChangeAddress)
+		request.From, request.UseExistingChangeAddress, request.FeePriority, coinSelection)
 	if err != nil {
 		return nil, err
 	}
@@ -31,23 +35,17 @@ func (s *server) CreateUnsignedTransactions(_ context.Context, request *pb.Creat
 	return &pb.CreateUnsignedTransactionsResponse{UnsignedTransactions: unsignedTransactions}, nil
 }
 
-func (s *server) createUnsignedTransactions(address string, amount uint64, isSendAll bool, fromAddressesString []string, useExistingChangeAddress bool) ([][]byte, error) {
+func (s *server) createUnsignedTransactions(address string, amount uint64, isSendAll bool, fromAddressesString []string, useExistingChangeAddress bool, feePriority string,
+	coinSelection *coinSelectionOptions) ([][]byte, error) {
 	if !s.isSynced() {
 		return nil, errors.Errorf("wallet daemon is not synced yet, %s", s.formatSyncStateReport())
 	}
 
-	// make sure address string is correct before proceeding to a
-	// potentially long UTXO refreshment operation
 	toAddress, err := util.DecodeAddress(address, s.params.Prefix)
 	if err != nil {
 		return nil, err
 	}
 
-	err = s.refreshUTXOs()
-	if err != nil {
-		return nil, err
-	}
-
 	var fromAddresses []*walletAddress
 	for _, from := range fromAddressesString {
 		fromAddress, exists := s.addressSet[from]
@@ -57,7 +55,12 @@ func (s *server) createUnsignedTransactions(address string, amount uint64, isSen
 		fromAddresses = append(fromAddresses, fromAddress)
 	}
 
-	selectedUTXOs, spendValue, changeSompi, err := s.selectUTXOs(amount, isSendAll, feePerInput, fromAddresses)
+	feePerInput, err := s.feePerInputForPriority(feePriority, toAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	selectedUTXOs, spendValue, changeSompi, err := s.selectUTXOs(amount, isSendAll, feePerInput, fromAddresses, coinSelection)
 	if err != nil {
 		return nil, err
 	}
@@ -95,44 +98,78 @@ func (s *server) createUnsignedTransactions(address string, amount uint64, isSen
 	return unsignedTransactions, nil
 }
 
-func (s *server) selectUTXOs(spendAmount uint64, isSendAll bool, feePerInput uint64, fromAddresses []*walletAddress) (
+func (s *server) selectUTXOs(spendAmount uint64, isSendAll bool, feePerInput uint64, fromAddresses []*walletAddress,
+	coinSelection *coinSelectionOptions) (
 	selectedUTXOs []*libzuawallet.UTXO, totalReceived uint64, changeSompi uint64, err error) {
 
-	selectedUTXOs = []*libzuawallet.UTXO{}
-	totalValue := uint64(0)
-
 	dagInfo, err := s.rpcClient.GetBlockDAGInfo()
 	if err != nil {
 		return nil, 0, 0, err
 	}
+	mempoolSpentOutpoints, err := s.mempoolSpentOutpoints()
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	err = s.releaseOutpointsOfDroppedTransactions()
+	if err != nil {
+		return nil, 0, 0, err
+	}
 
+	var candidates, included []*walletUTXO
 	for _, utxo := range s.utxosSortedByAmount {
+		if _, ok := coinSelection.excludedOutpoints[*utxo.Outpoint]; ok {
+			continue
+		}
+		_, isIncluded := coinSelection.includedOutpoints[*utxo.Outpoint]
+
 		if (fromAddresses != nil && !slices.Contains(fromAddresses, utxo.address)) ||
 			!isUTXOSpendable(utxo, dagInfo.VirtualDAAScore, s.params.BlockCoinbaseMaturity) {
 			continue
 		}
 
-		if broadcastTime, ok := s.usedOutpoints[*utxo.Outpoint]; ok {
-			if time.Since(broadcastTime) > time.Minute {
-				delete(s.usedOutpoints, *utxo.Outpoint)
-			} else {
-				continue
+		if _, ok := mempoolSpentOutpoints[*utxo.Outpoint]; ok {
+			continue
+		}
+		if _, ok := s.usedOutpoints[*utxo.Outpoint]; ok {
+			continue
+		}
+
+		if isIncluded {
+			included = append(included, utxo)
+			continue
+		}
+		candidates = append(candidates, utxo)
+	}
+
+	if len(included) < len(coinSelection.includedOutpoints) {
+		return nil, 0, 0, errors.Errorf("some of the included outpoints are not spendable UTXOs of the wallet " +
+			"(or of the given from addresses)")
+	}
+
+	var selected []*walletUTXO
+	isChangeless := false
+	if isSendAll {
+		selected = append(included, candidates...)
+	} else {
+		areFeesLow := false
+		if coinSelection.strategy == coinSelectionConsolidate {
+			areFeesLow, err = s.areFeesLow()
+			if err != nil {
+				return nil, 0, 0, err
 			}
 		}
+		selected, isChangeless = selectCoins(coinSelection.strategy, candidates, included, spendAmount, feePerInput, areFeesLow)
+	}
 
-		selectedUTXOs = append(selectedUTXOs, &libzuawallet.UTXO{
+	selectedUTXOs = make([]*libzuawallet.UTXO, len(selected))
+	totalValue := uint64(0)
+	for i, utxo := range selected {
+		selectedUTXOs[i] = &libzuawallet.UTXO{
 			Outpoint:       utxo.Outpoint,
 			UTXOEntry:      utxo.UTXOEntry,
 			DerivationPath: s.walletAddressPath(utxo.address),
-		})
-
-		totalValue += utxo.UTXOEntry.Amount()
-
-		fee := feePerInput * uint64(len(selectedUTXOs))
-		totalSpend := spendAmount + fee
-		if !isSendAll && totalValue >= totalSpend {
-			break
 		}
+		totalValue += utxo.UTXOEntry.Amount()
 	}
 
 	fee := feePerInput * uint64(len(selectedUTXOs))
@@ -149,5 +186,9 @@ func (s *server) selectUTXOs(spendAmount uint64, isSendAll bool, feePerInput uin
 			float64(totalSpend)/constants.SompiPerZua, float64(totalValue)/constants.SompiPerZua)
 	}
 
+	// A changeless selection leaves its small excess to the fee
+	if isChangeless {
+		return selectedUTXOs, totalReceived, 0, nil
+	}
 	return selectedUTXOs, totalReceived, totalValue - totalSpend, nil
 }
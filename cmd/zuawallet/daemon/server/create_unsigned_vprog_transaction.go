@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/zuanet/zuad/cmd/zuawallet/daemon/pb"
+	"github.com/zuanet/zuad/cmd/zuawallet/libzuawallet"
+	"github.com/zuanet/zuad/cmd/zuawallet/libzuawallet/serialization"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/utils/constants"
+	"github.com/zuanet/zuad/domain/consensus/vprog"
+	"github.com/zuanet/zuad/domain/miningmanager/mempool"
+	"github.com/pkg/errors"
+)
+
+func (s *server) CreateUnsignedVProgTransaction(_ context.Context, request *pb.CreateUnsignedVProgTransactionRequest) (
+	*pb.CreateUnsignedVProgTransactionResponse, error,
+) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	unsignedTransaction, programID, err := s.createUnsignedVProgTransaction(request.Code, request.ProgramId,
+		request.Data, request.GasLimit, request.From, request.UseExistingChangeAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CreateUnsignedVProgTransactionResponse{
+		UnsignedTransaction: unsignedTransaction,
+		ProgramId:           programID.String(),
+	}, nil
+}
+
+func (s *server) createUnsignedVProgTransaction(code []byte, programIDString string, data []byte, gasLimit uint64,
+	fromAddressesString []string, useExistingChangeAddress bool) ([]byte, *externalapi.DomainHash, error) {
+
+	if !s.isSynced() {
+		return nil, nil, errors.Errorf("wallet daemon is not synced yet, %s", s.formatSyncStateReport())
+	}
+
+	if (len(code) == 0) == (programIDString == "") {
+		return nil, nil, errors.New("exactly one of the code or the program ID must be specified")
+	}
+	if gasLimit == 0 || gasLimit > s.params.MaxVProgGasLimit {
+		return nil, nil, errors.Errorf("gas limit must be between 1 and %d", s.params.MaxVProgGasLimit)
+	}
+
+	if programIDString != "" {
+		var err error
+		code, err = s.deployedVProgCode(programIDString)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	vprogCall := &libzuawallet.VProgCall{
+		Code:     code,
+		Data:     data,
+		GasLimit: gasLimit,
+	}
+
+	var fromAddresses []*walletAddress
+	for _, from := range fromAddressesString {
+		fromAddress, exists := s.addressSet[from]
+		if !exists {
+			return nil, nil, fmt.Errorf("Specified from address %s does not exists", from)
+		}
+		fromAddresses = append(fromAddresses, fromAddress)
+	}
+
+	// The fee of the vprog is selected as if it was a payment, and the transaction only
+	// pays the change back to the wallet, so it's left for the miner
+	selectedUTXOs, _, changeSompi, err := s.selectUTXOs(s.vprogFee(vprogCall), false, feePerInput, fromAddresses,
+		defaultCoinSelectionOptions())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(selectedUTXOs) == 0 {
+		return nil, nil, errors.Errorf("couldn't find funds to spend")
+	}
+
+	changeAddress, _, err := s.changeAddress(useExistingChangeAddress, fromAddresses)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var payments []*libzuawallet.Payment
+	if changeSompi > 0 {
+		payments = append(payments, &libzuawallet.Payment{
+			Address: changeAddress,
+			Amount:  changeSompi,
+		})
+	}
+	unsignedTransaction, err := libzuawallet.CreateUnsignedVProgTransaction(s.keysFile.ExtendedPublicKeys,
+		s.keysFile.MinimumSignatures, payments, selectedUTXOs, vprogCall)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = s.checkVProgTransactionMass(unsignedTransaction)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return unsignedTransaction, vprog.ProgramID(code), nil
+}
+
+// deployedVProgCode fetches the code of the given deployed vprog from the node
+func (s *server) deployedVProgCode(programIDString string) ([]byte, error) {
+	getVProgCodeResponse, err := s.rpcClient.GetVProgCode(programIDString)
+	if err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(getVProgCodeResponse.Code)
+}
+
+// vprogFee returns the fee that covers the mass the given vprog call adds to a transaction,
+// at the default minimum relay fee of one sompi per gram
+func (s *server) vprogFee(vprogCall *libzuawallet.VProgCall) uint64 {
+	transactionWithVProg := &externalapi.DomainTransaction{
+		VProgVersion:  constants.VProgVersion,
+		VProgCode:     vprogCall.Code,
+		VProgData:     vprogCall.Data,
+		VProgGasLimit: vprogCall.GasLimit,
+	}
+	return s.txMassCalculator.CalculateTransactionMass(transactionWithVProg) -
+		s.txMassCalculator.CalculateTransactionMass(&externalapi.DomainTransaction{})
+}
+
+// checkVProgTransactionMass makes sure that the given vprog transaction is standard. Unlike
+// payments, vprog transactions can't be split, so the caller is expected to compound their
+// UTXOs first if too many of them are needed.
+func (s *server) checkVProgTransactionMass(unsignedTransactionBytes []byte) error {
+	unsignedTransaction, err := serialization.DeserializePartiallySignedTransaction(unsignedTransactionBytes)
+	if err != nil {
+		return err
+	}
+
+	transactionMass, err := s.estimateMassAfterSignatures(unsignedTransaction)
+	if err != nil {
+		return err
+	}
+	if transactionMass >= mempool.MaximumStandardTransactionMass {
+		return errors.Errorf("vprog transaction mass %d exceeds the maximum of %d. Consider sending "+
+			"some funds to yourself first in order to compound your UTXOs", transactionMass,
+			mempool.MaximumStandardTransactionMass)
+	}
+	return nil
+}
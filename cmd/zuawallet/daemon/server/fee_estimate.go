@@ -0,0 +1,85 @@
+package server
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+
+	"github.com/zuanet/zuad/cmd/zuawallet/libzuawallet"
+	"github.com/zuanet/zuad/cmd/zuawallet/libzuawallet/serialization"
+	"github.com/zuanet/zuad/util"
+)
+
+const (
+	feePriorityPriority = "priority"
+	feePriorityNormal   = "normal"
+	feePriorityLow      = "low"
+)
+
+// feePerInputForPriority returns the fee to pay for every input of a transaction
+// sending to toAddress, so that it pays the fee rate that zuad currently estimates
+// for the given priority.
+// An empty priority returns the fixed feePerInput.
+func (s *server) feePerInputForPriority(feePriority string, toAddress util.Address) (uint64, error) {
+	if feePriority == "" {
+		return feePerInput, nil
+	}
+
+	response, err := s.rpcClient.GetFeeEstimate()
+	if err != nil {
+		return 0, err
+	}
+
+	var feeRate float64
+	switch feePriority {
+	case feePriorityPriority:
+		feeRate = response.Estimate.PriorityBucket.FeeRate
+	case feePriorityNormal:
+		feeRate = response.Estimate.NormalBucket.FeeRate
+	case feePriorityLow:
+		feeRate = response.Estimate.LowBucket.FeeRate
+	default:
+		return 0, errors.Errorf("unknown fee priority %s, expected one of: %s, %s, %s",
+			feePriority, feePriorityPriority, feePriorityNormal, feePriorityLow)
+	}
+
+	massPerInput, err := s.estimateMassPerInput(toAddress)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(math.Ceil(feeRate * float64(massPerInput))), nil
+}
+
+// estimateMassPerInput returns the mass of a signed transaction that spends a single
+// input into a payment and a change output.
+// Charging this mass for every input overestimates the mass of transactions with
+// many inputs, since their outputs are counted once per input, which errs on the
+// side of paying slightly more than the requested fee rate.
+func (s *server) estimateMassPerInput(toAddress util.Address) (uint64, error) {
+	if len(s.utxosSortedByAmount) == 0 {
+		return 0, errors.Errorf("couldn't find funds to spend")
+	}
+	utxo := s.utxosSortedByAmount[0]
+
+	payments := []*libzuawallet.Payment{
+		{Address: toAddress, Amount: 1},
+		{Address: toAddress, Amount: 1},
+	}
+	selectedUTXOs := []*libzuawallet.UTXO{{
+		Outpoint:       utxo.Outpoint,
+		UTXOEntry:      utxo.UTXOEntry,
+		DerivationPath: s.walletAddressPath(utxo.address),
+	}}
+	transactionBytes, err := libzuawallet.CreateUnsignedTransaction(s.keysFile.ExtendedPublicKeys,
+		s.keysFile.MinimumSignatures, payments, selectedUTXOs)
+	if err != nil {
+		return 0, err
+	}
+	transaction, err := serialization.DeserializePartiallySignedTransaction(transactionBytes)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.estimateMassAfterSignatures(transaction)
+}
@@ -0,0 +1,447 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/utils/txscript"
+	"github.com/zuanet/zuad/infrastructure/network/rpcclient"
+	"github.com/zuanet/zuad/util/mstime"
+	"github.com/pkg/errors"
+)
+
+const historyFileVersion = 1
+
+// historyResyncDAAScoreDepth is the number of recent DAA scores whose history
+// is fetched again on every sync, so that transactions that were accepted and
+// then un-accepted by a reorg are corrected.
+const historyResyncDAAScoreDepth = 1000
+
+// walletHistory is the local record of the transactions that paid to or spent
+// from the wallet. It's kept in a file next to the keys file.
+type walletHistory struct {
+	path string
+
+	transactions map[string]*historyTransaction
+
+	// syncedDAAScores holds, per address, the DAA score from which its
+	// history should be fetched on the next sync.
+	syncedDAAScores map[string]uint64
+
+	lastSaved         []byte
+	isSyncErrorLogged bool
+}
+
+// historyTransaction is a single entry in the wallet history.
+type historyTransaction struct {
+	TransactionID      string `json:"transactionId"`
+	AcceptingBlockHash string `json:"acceptingBlockHash,omitempty"`
+	DAAScore           uint64 `json:"daaScore,omitempty"`
+	Fee                uint64 `json:"fee,omitempty"`
+	HasFee             bool   `json:"hasFee,omitempty"`
+	Label              string `json:"label,omitempty"`
+	Timestamp          int64  `json:"timestamp"`
+
+	// IsSentByWallet is set for transactions that were broadcast by this
+	// daemon. PendingReceived and PendingSpent are the amounts computed at
+	// broadcast time, and are used until the transaction is accepted. A
+	// transaction that leaves the mempool without being accepted is removed.
+	IsSentByWallet  bool   `json:"isSentByWallet,omitempty"`
+	PendingReceived uint64 `json:"pendingReceived,omitempty"`
+	PendingSpent    uint64 `json:"pendingSpent,omitempty"`
+
+	AddressAmounts map[string]*historyAddressAmount `json:"addressAmounts,omitempty"`
+}
+
+// historyAddressAmount is the amount a transaction paid to and spent from
+// a single wallet address, as reported by the node.
+type historyAddressAmount struct {
+	Received uint64 `json:"received"`
+	Spent    uint64 `json:"spent"`
+}
+
+type historyFileJSON struct {
+	Version         uint32                `json:"version"`
+	Transactions    []*historyTransaction `json:"transactions"`
+	SyncedDAAScores map[string]uint64     `json:"syncedDaaScores"`
+}
+
+func (tx *historyTransaction) isAccepted() bool {
+	return tx.AcceptingBlockHash != ""
+}
+
+// amounts returns the total amount the transaction paid to and spent
+// from the wallet.
+func (tx *historyTransaction) amounts() (received uint64, spent uint64) {
+	if !tx.isAccepted() {
+		return tx.PendingReceived, tx.PendingSpent
+	}
+
+	for _, addressAmount := range tx.AddressAmounts {
+		received += addressAmount.Received
+		spent += addressAmount.Spent
+	}
+	return received, spent
+}
+
+// historyFilePath returns the path of the history file that belongs to
+// the given keys file: keys.json is accompanied by keys.history.json.
+func historyFilePath(keysFilePath string) string {
+	extension := filepath.Ext(keysFilePath)
+	return strings.TrimSuffix(keysFilePath, extension) + ".history.json"
+}
+
+// loadHistory reads the wallet history from the given path. A missing
+// file results in an empty history.
+func loadHistory(path string) (*walletHistory, error) {
+	history := &walletHistory{
+		path:            path,
+		transactions:    make(map[string]*historyTransaction),
+		syncedDAAScores: make(map[string]uint64),
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return history, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	decodedFile := &historyFileJSON{}
+	err = json.NewDecoder(file).Decode(decodedFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error decoding the history file %s", path)
+	}
+	if decodedFile.Version != historyFileVersion {
+		return nil, errors.Errorf("unknown history file version %d", decodedFile.Version)
+	}
+
+	for _, transaction := range decodedFile.Transactions {
+		history.transactions[transaction.TransactionID] = transaction
+	}
+	for address, daaScore := range decodedFile.SyncedDAAScores {
+		history.syncedDAAScores[address] = daaScore
+	}
+
+	return history, nil
+}
+
+// save writes the wallet history to its file, unless it hasn't changed
+// since it was last saved.
+func (h *walletHistory) save() error {
+	fileJSON := &historyFileJSON{
+		Version:         historyFileVersion,
+		Transactions:    h.sortedTransactions(),
+		SyncedDAAScores: h.syncedDAAScores,
+	}
+	serializedHistory, err := json.Marshal(fileJSON)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(serializedHistory, h.lastSaved) {
+		return nil
+	}
+
+	err = os.MkdirAll(filepath.Dir(h.path), 0700)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first, so that a crash in the middle
+	// doesn't leave a corrupted history file behind.
+	tempPath := h.path + ".tmp"
+	err = ioutil.WriteFile(tempPath, serializedHistory, 0600)
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(tempPath, h.path)
+	if err != nil {
+		return err
+	}
+
+	h.lastSaved = serializedHistory
+	return nil
+}
+
+// sortedTransactions returns the history with the newest transactions
+// first. Transactions that aren't accepted yet come before all others.
+func (h *walletHistory) sortedTransactions() []*historyTransaction {
+	transactions := make([]*historyTransaction, 0, len(h.transactions))
+	for _, transaction := range h.transactions {
+		transactions = append(transactions, transaction)
+	}
+
+	sort.Slice(transactions, func(i, j int) bool {
+		if transactions[i].isAccepted() != transactions[j].isAccepted() {
+			return !transactions[i].isAccepted()
+		}
+		if transactions[i].DAAScore != transactions[j].DAAScore {
+			return transactions[i].DAAScore > transactions[j].DAAScore
+		}
+		if transactions[i].Timestamp != transactions[j].Timestamp {
+			return transactions[i].Timestamp > transactions[j].Timestamp
+		}
+		return transactions[i].TransactionID < transactions[j].TransactionID
+	})
+
+	return transactions
+}
+
+func (h *walletHistory) transaction(transactionID string) *historyTransaction {
+	transaction, ok := h.transactions[transactionID]
+	if !ok {
+		transaction = &historyTransaction{
+			TransactionID: transactionID,
+			Timestamp:     mstime.Now().UnixMilliseconds(),
+		}
+		h.transactions[transactionID] = transaction
+	}
+	return transaction
+}
+
+// resetAddress removes the amounts of the given address from all the
+// transactions that were accepted at fromDAAScore or later, before they
+// are fetched again from the node.
+func (h *walletHistory) resetAddress(address string, fromDAAScore uint64) {
+	for transactionID, transaction := range h.transactions {
+		if !transaction.isAccepted() || transaction.DAAScore < fromDAAScore {
+			continue
+		}
+		if _, ok := transaction.AddressAmounts[address]; !ok {
+			continue
+		}
+
+		delete(transaction.AddressAmounts, address)
+		if len(transaction.AddressAmounts) > 0 {
+			continue
+		}
+
+		// None of the wallet addresses are in this transaction's
+		// history anymore, so it's no longer accepted. Transactions
+		// sent by the wallet or labeled by the user are kept as pending.
+		if !transaction.IsSentByWallet && transaction.Label == "" {
+			delete(h.transactions, transactionID)
+			continue
+		}
+		transaction.AcceptingBlockHash = ""
+		transaction.DAAScore = 0
+	}
+}
+
+// removeDroppedTransactions removes the given transactions from the history,
+// unless they were accepted since they were found missing from the mempool.
+// A pending transaction that's neither accepted nor in the mempool was
+// replaced or double spent, or otherwise dropped by the node, so it will
+// never be accepted.
+func (h *walletHistory) removeDroppedTransactions(droppedTransactionIDs []string) {
+	for _, transactionID := range droppedTransactionIDs {
+		transaction, ok := h.transactions[transactionID]
+		if !ok || transaction.isAccepted() {
+			continue
+		}
+		delete(h.transactions, transactionID)
+	}
+}
+
+// recordSentTransaction adds a transaction that was broadcast by this
+// daemon to the history. It must be called before the UTXOs it spends
+// are removed from the UTXO set.
+func (s *server) recordSentTransaction(transactionID string, tx *externalapi.DomainTransaction) {
+	walletUTXOs := make(map[externalapi.DomainOutpoint]*walletUTXO, len(s.utxosSortedByAmount))
+	for _, utxo := range s.utxosSortedByAmount {
+		walletUTXOs[*utxo.Outpoint] = utxo
+	}
+
+	var spent uint64
+	areAllInputsKnown := true
+	for _, input := range tx.Inputs {
+		utxo, ok := walletUTXOs[input.PreviousOutpoint]
+		if !ok {
+			areAllInputsKnown = false
+			continue
+		}
+		spent += utxo.UTXOEntry.Amount()
+	}
+
+	walletAddresses, err := s.historyAddresses()
+	if err != nil {
+		log.Warnf("Couldn't derive the wallet addresses for the history of transaction %s: %s", transactionID, err)
+		walletAddresses = s.addressSet
+	}
+
+	var received, totalOutputs uint64
+	for _, output := range tx.Outputs {
+		totalOutputs += output.Value
+		_, address, err := txscript.ExtractScriptPubKeyAddress(output.ScriptPublicKey, s.params)
+		if err != nil || address == nil {
+			continue
+		}
+		if _, ok := walletAddresses[address.String()]; ok {
+			received += output.Value
+		}
+	}
+
+	transaction := s.history.transaction(transactionID)
+	transaction.IsSentByWallet = true
+	transaction.PendingReceived = received
+	transaction.PendingSpent = spent
+	if areAllInputsKnown && spent >= totalOutputs {
+		transaction.Fee = spent - totalOutputs
+		transaction.HasFee = true
+	}
+
+	err = s.history.save()
+	if err != nil {
+		log.Warnf("Couldn't save the wallet history: %s", err)
+	}
+}
+
+// historyAddresses returns all the addresses of the wallet that might
+// appear in its history: every address up to the last used index, along
+// with the addresses that were found with a balance while syncing.
+func (s *server) historyAddresses() (walletAddressSet, error) {
+	addresses, err := s.addressesToQuery(0, s.maxUsedIndex()+1)
+	if err != nil {
+		return nil, err
+	}
+
+	for address, walletAddress := range s.addressSet {
+		addresses[address] = walletAddress
+	}
+	return addresses, nil
+}
+
+func (s *server) syncHistoryWithLock() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	err := s.syncHistory()
+	if err != nil {
+		// The history is only available when the node keeps an address
+		// history index, so failing to sync it must not stop the daemon.
+		if !s.history.isSyncErrorLogged {
+			log.Warnf("Couldn't sync the wallet transaction history. Make sure the node runs with "+
+				"--utxoindex and --addresshistoryindex: %s", err)
+			s.history.isSyncErrorLogged = true
+		}
+		return
+	}
+	s.history.isSyncErrorLogged = false
+}
+
+// syncHistory fetches the history of the wallet addresses from the node,
+// starting, for every address, from the DAA score where the previous
+// sync stopped.
+func (s *server) syncHistory() error {
+	dagInfo, err := s.rpcClient.GetBlockDAGInfo()
+	if err != nil {
+		return err
+	}
+	nextSyncedDAAScore := uint64(0)
+	if dagInfo.VirtualDAAScore > historyResyncDAAScoreDepth {
+		nextSyncedDAAScore = dagInfo.VirtualDAAScore - historyResyncDAAScoreDepth
+	}
+
+	addresses, err := s.historyAddresses()
+	if err != nil {
+		return err
+	}
+
+	// The mempool is checked before the history is fetched: a pending transaction
+	// that's accepted in between is still in the mempool at the time of the check,
+	// or is found accepted in the fetched history.
+	droppedTransactionIDs, err := s.pendingTransactionsNotInMempool()
+	if err != nil {
+		return err
+	}
+
+	addressesByDAAScore := make(map[uint64][]string)
+	for address := range addresses {
+		fromDAAScore := s.history.syncedDAAScores[address]
+		addressesByDAAScore[fromDAAScore] = append(addressesByDAAScore[fromDAAScore], address)
+	}
+
+	for fromDAAScore, addressesToSync := range addressesByDAAScore {
+		for _, address := range addressesToSync {
+			s.history.resetAddress(address, fromDAAScore)
+		}
+
+		err := s.syncAddressesHistory(addressesToSync, fromDAAScore)
+		if err != nil {
+			return err
+		}
+
+		for _, address := range addressesToSync {
+			if nextSyncedDAAScore > fromDAAScore {
+				s.history.syncedDAAScores[address] = nextSyncedDAAScore
+			}
+		}
+	}
+
+	s.history.removeDroppedTransactions(droppedTransactionIDs)
+
+	return s.history.save()
+}
+
+// pendingTransactionsNotInMempool returns the IDs of the pending transactions
+// in the history that aren't in the node's mempool
+func (s *server) pendingTransactionsNotInMempool() ([]string, error) {
+	var transactionIDs []string
+	for transactionID, transaction := range s.history.transactions {
+		if transaction.isAccepted() {
+			continue
+		}
+
+		_, err := s.rpcClient.GetMempoolEntry(transactionID, true, false)
+		if err != nil {
+			// The node responds with an error when the transaction isn't found
+			if !errors.Is(err, rpcclient.ErrRPC) {
+				return nil, err
+			}
+			transactionIDs = append(transactionIDs, transactionID)
+		}
+	}
+	return transactionIDs, nil
+}
+
+func (s *server) syncAddressesHistory(addresses []string, fromDAAScore uint64) error {
+	response, err := s.rpcClient.GetTransactionsByAddresses(addresses, fromDAAScore, 0)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range response.Entries {
+		for _, addressTransaction := range entry.Transactions {
+			transaction := s.history.transaction(addressTransaction.TransactionID)
+			transaction.AcceptingBlockHash = addressTransaction.AcceptingBlockHash
+			transaction.DAAScore = addressTransaction.DAAScore
+			if transaction.AddressAmounts == nil {
+				transaction.AddressAmounts = make(map[string]*historyAddressAmount)
+			}
+			transaction.AddressAmounts[entry.Address] = &historyAddressAmount{
+				Received: addressTransaction.ReceivedAmount,
+				Spent:    addressTransaction.SpentAmount,
+			}
+		}
+
+		// Pages are requested per address, since each address may
+		// continue from a different DAA score.
+		if entry.NextDAAScore != 0 {
+			err := s.syncAddressesHistory([]string{entry.Address}, entry.NextDAAScore)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWalletHistory(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "TestWalletHistory")
+	if err != nil {
+		t.Fatalf("MkdirTemp: %s", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	path := historyFilePath(filepath.Join(tempDir, "keys.json"))
+	if filepath.Base(path) != "keys.history.json" {
+		t.Fatalf("unexpected history file name %s", filepath.Base(path))
+	}
+
+	history, err := loadHistory(path)
+	if err != nil {
+		t.Fatalf("loadHistory: %s", err)
+	}
+
+	const address = "zua:address"
+	incoming := history.transaction("incoming")
+	incoming.AcceptingBlockHash = "block1"
+	incoming.DAAScore = 10
+	incoming.AddressAmounts = map[string]*historyAddressAmount{address: {Received: 100}}
+
+	reorged := history.transaction("reorged")
+	reorged.AcceptingBlockHash = "block2"
+	reorged.DAAScore = 20
+	reorged.AddressAmounts = map[string]*historyAddressAmount{address: {Received: 50}}
+
+	sent := history.transaction("sent")
+	sent.IsSentByWallet = true
+	sent.PendingSpent = 70
+	sent.PendingReceived = 20
+	sent.Fee = 10
+	sent.HasFee = true
+	sent.Label = "rent"
+
+	err = history.save()
+	if err != nil {
+		t.Fatalf("save: %s", err)
+	}
+
+	history, err = loadHistory(path)
+	if err != nil {
+		t.Fatalf("loadHistory: %s", err)
+	}
+
+	transactions := history.sortedTransactions()
+	expectedOrder := []string{"sent", "reorged", "incoming"}
+	if len(transactions) != len(expectedOrder) {
+		t.Fatalf("expected %d transactions, got %d", len(expectedOrder), len(transactions))
+	}
+	for i, transactionID := range expectedOrder {
+		if transactions[i].TransactionID != transactionID {
+			t.Fatalf("expected transaction #%d to be %s, got %s", i, transactionID, transactions[i].TransactionID)
+		}
+	}
+
+	received, spent := history.transactions["sent"].amounts()
+	if received != 20 || spent != 70 || history.transactions["sent"].Label != "rent" {
+		t.Fatalf("the sent transaction wasn't restored correctly: %+v", history.transactions["sent"])
+	}
+
+	// Resetting the address from DAA score 15 should drop the transaction
+	// accepted at DAA score 20, and keep the one accepted at DAA score 10.
+	history.resetAddress(address, 15)
+	if _, ok := history.transactions["reorged"]; ok {
+		t.Fatalf("the transaction accepted after the reset DAA score wasn't removed")
+	}
+	received, _ = history.transactions["incoming"].amounts()
+	if received != 100 {
+		t.Fatalf("expected the incoming transaction to keep its amount, got %d", received)
+	}
+
+	// A sent transaction that's no longer in the mempool is removed, unless it
+	// was accepted in the meantime
+	replaced := history.transaction("replaced")
+	replaced.IsSentByWallet = true
+	replaced.PendingSpent = 30
+	history.removeDroppedTransactions([]string{"replaced", "incoming"})
+	if _, ok := history.transactions["replaced"]; ok {
+		t.Fatalf("the sent transaction that was dropped from the mempool wasn't removed")
+	}
+	if _, ok := history.transactions["incoming"]; !ok {
+		t.Fatalf("the accepted transaction was removed")
+	}
+	if _, ok := history.transactions["sent"]; !ok {
+		t.Fatalf("the pending transaction that's still in the mempool was removed")
+	}
+}
@@ -4,14 +4,25 @@ import (
 	"context"
 
 	"github.com/zuanet/zuad/cmd/zuawallet/daemon/pb"
+	"github.com/pkg/errors"
 )
 
 func (s *server) Send(_ context.Context, request *pb.SendRequest) (*pb.SendResponse, error) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
+	if s.keysFile.IsWatchOnly() {
+		return nil, errors.New("cannot send from a watch-only wallet. " +
+			"Create an unsigned transaction and sign it offline with 'zuawallet sign'")
+	}
+
+	coinSelection, err := newCoinSelectionOptions(request.CoinSelection, request.IncludeOutpoints, request.ExcludeOutpoints)
+	if err != nil {
+		return nil, err
+	}
+
 	unsignedTransactions, err := s.createUnsignedTransactions(request.ToAddress, request.Amount, request.IsSendAll,
-		request.From, request.UseExistingChangeAddress)
+		request.From, request.UseExistingChangeAddress, request.FeePriority, coinSelection)
 
 	if err != nil {
 		return nil, err
@@ -32,12 +32,15 @@ type server struct {
 
 	lock                sync.RWMutex
 	utxosSortedByAmount []*walletUTXO
+	utxosByOutpoint     map[externalapi.DomainOutpoint]*walletUTXO
+	subscribedAddresses map[string]struct{}
 	nextSyncStartIndex  uint32
 	keysFile            *keys.File
 	shutdown            chan struct{}
 	addressSet          walletAddressSet
 	txMassCalculator    *txmass.Calculator
-	usedOutpoints       map[externalapi.DomainOutpoint]time.Time
+	usedOutpoints       map[externalapi.DomainOutpoint]string
+	history             *walletHistory
 
 	isLogFinalProgressLineShown bool
 	maxUsedAddressesForLog      uint32
@@ -82,21 +85,31 @@ func Start(params *dagconfig.Params, listen, rpcServer string, keysFilePath stri
 		return err
 	}
 
+	history, err := loadHistory(historyFilePath(keysFile.Path()))
+	if err != nil {
+		return err
+	}
+
 	serverInstance := &server{
 		rpcClient:                   rpcClient,
 		params:                      params,
 		utxosSortedByAmount:         []*walletUTXO{},
+		utxosByOutpoint:             map[externalapi.DomainOutpoint]*walletUTXO{},
+		subscribedAddresses:         map[string]struct{}{},
 		nextSyncStartIndex:          0,
 		keysFile:                    keysFile,
 		shutdown:                    make(chan struct{}),
 		addressSet:                  make(walletAddressSet),
-		txMassCalculator:            txmass.NewCalculator(params.MassPerTxByte, params.MassPerScriptPubKeyByte, params.MassPerSigOp),
-		usedOutpoints:               map[externalapi.DomainOutpoint]time.Time{},
+		txMassCalculator:            txmass.NewCalculator(params.MassPerTxByte, params.MassPerScriptPubKeyByte, params.MassPerSigOp, params.VProgGasPerMass),
+		usedOutpoints:               map[externalapi.DomainOutpoint]string{},
+		history:                     history,
 		isLogFinalProgressLineShown: false,
 		maxUsedAddressesForLog:      0,
 		maxProcessedAddressesForLog: 0,
 	}
 
+	rpcClient.SetOnReconnectedHandler(serverInstance.onReconnected)
+
 	log.Infof("Read, syncing the wallet...")
 	spawn("serverInstance.sync", func() {
 		err := serverInstance.sync()
@@ -6,6 +6,7 @@ import (
 	"github.com/zuanet/zuad/cmd/zuawallet/libzuawallet"
 
 	"github.com/zuanet/zuad/cmd/zuawallet/daemon/pb"
+	"github.com/pkg/errors"
 )
 
 func (s *server) Sign(_ context.Context, request *pb.SignRequest) (*pb.SignResponse, error) {
@@ -20,6 +21,11 @@ func (s *server) Sign(_ context.Context, request *pb.SignRequest) (*pb.SignRespo
 }
 
 func (s *server) signTransactions(unsignedTransactions [][]byte, password string) ([][]byte, error) {
+	if s.keysFile.IsWatchOnly() {
+		return nil, errors.New("the wallet is watch-only and holds no private keys. " +
+			"Create an unsigned transaction and sign it offline with 'zuawallet sign'")
+	}
+
 	mnemonics, err := s.keysFile.DecryptMnemonics(password)
 	if err != nil {
 		return nil, err
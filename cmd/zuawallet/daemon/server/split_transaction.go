@@ -255,6 +255,10 @@ func (s *server) moreUTXOsForMergeTransaction(alreadySelectedUTXOs []*libzuawall
 	if err != nil {
 		return nil, 0, err
 	}
+	mempoolSpentOutpoints, err := s.mempoolSpentOutpoints()
+	if err != nil {
+		return nil, 0, err
+	}
 	alreadySelectedUTXOsMap := make(map[externalapi.DomainOutpoint]struct{}, len(alreadySelectedUTXOs))
 	for _, alreadySelectedUTXO := range alreadySelectedUTXOs {
 		alreadySelectedUTXOsMap[*alreadySelectedUTXO.Outpoint] = struct{}{}
@@ -264,6 +268,9 @@ func (s *server) moreUTXOsForMergeTransaction(alreadySelectedUTXOs []*libzuawall
 		if _, ok := alreadySelectedUTXOsMap[*utxo.Outpoint]; ok {
 			continue
 		}
+		if _, ok := mempoolSpentOutpoints[*utxo.Outpoint]; ok {
+			continue
+		}
 		if !isUTXOSpendable(utxo, dagInfo.VirtualDAAScore, s.params.BlockCoinbaseMaturity) {
 			continue
 		}
@@ -8,6 +8,7 @@ import (
 	"github.com/zuanet/zuad/cmd/zuawallet/libzuawallet"
 
 	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
 	"github.com/pkg/errors"
 )
 
@@ -32,11 +33,16 @@ func (s *server) sync() error {
 		return err
 	}
 
-	err = s.refreshExistingUTXOsWithLock()
+	// From here on the UTXO set is kept up to date by UTXOsChanged
+	// notifications, so it's fully refreshed only now, on reconnection
+	// and when the pruning point UTXO set is overridden.
+	err = s.registerForNotificationsWithLock()
 	if err != nil {
 		return err
 	}
 
+	s.syncHistoryWithLock()
+
 	for range ticker.C {
 		err = s.collectFarAddresses()
 		if err != nil {
@@ -48,10 +54,12 @@ func (s *server) sync() error {
 			return err
 		}
 
-		err = s.refreshExistingUTXOsWithLock()
+		err = s.subscribeToNewAddressesWithLock()
 		if err != nil {
 			return err
 		}
+
+		s.syncHistoryWithLock()
 	}
 
 	return nil
@@ -215,9 +223,19 @@ func (s *server) refreshExistingUTXOsWithLock() error {
 	return s.refreshUTXOs()
 }
 
-// updateUTXOSet clears the current UTXO set, and re-fills it with the given entries
-func (s *server) updateUTXOSet(entries []*appmessage.UTXOsByAddressesEntry, mempoolEntries []*appmessage.MempoolEntryByAddress) error {
-	utxos := make([]*walletUTXO, 0, len(entries))
+// updateUTXOSet replaces the UTXOs of the given addresses with the given entries
+func (s *server) updateUTXOSet(addresses []string, entries []*appmessage.UTXOsByAddressesEntry,
+	mempoolEntries []*appmessage.MempoolEntryByAddress) error {
+
+	replacedAddresses := make(map[*walletAddress]struct{}, len(addresses))
+	for _, address := range addresses {
+		replacedAddresses[s.addressSet[address]] = struct{}{}
+	}
+	for outpoint, utxo := range s.utxosByOutpoint {
+		if _, ok := replacedAddresses[utxo.address]; ok {
+			delete(s.utxosByOutpoint, outpoint)
+		}
+	}
 
 	exclude := make(map[appmessage.RPCOutpoint]struct{})
 	for _, entriesByAddress := range mempoolEntries {
@@ -233,51 +251,104 @@ func (s *server) updateUTXOSet(entries []*appmessage.UTXOsByAddressesEntry, memp
 			continue
 		}
 
-		outpoint, err := appmessage.RPCOutpointToDomainOutpoint(entry.Outpoint)
+		err := s.addUTXO(entry)
 		if err != nil {
 			return err
 		}
+	}
 
-		utxoEntry, err := appmessage.RPCUTXOEntryToUTXOEntry(entry.UTXOEntry)
-		if err != nil {
-			return err
-		}
+	s.updateUTXOsSortedByAmount()
 
-		address, ok := s.addressSet[entry.Address]
-		if !ok {
-			return errors.Errorf("Got result from address %s even though it wasn't requested", entry.Address)
-		}
-		utxos = append(utxos, &walletUTXO{
-			Outpoint:  outpoint,
-			UTXOEntry: utxoEntry,
-			address:   address,
-		})
+	return nil
+}
+
+func (s *server) addUTXO(entry *appmessage.UTXOsByAddressesEntry) error {
+	outpoint, err := appmessage.RPCOutpointToDomainOutpoint(entry.Outpoint)
+	if err != nil {
+		return err
 	}
 
-	sort.Slice(utxos, func(i, j int) bool { return utxos[i].UTXOEntry.Amount() > utxos[j].UTXOEntry.Amount() })
+	utxoEntry, err := appmessage.RPCUTXOEntryToUTXOEntry(entry.UTXOEntry)
+	if err != nil {
+		return err
+	}
 
-	s.utxosSortedByAmount = utxos
+	address, ok := s.addressSet[entry.Address]
+	if !ok {
+		return errors.Errorf("Got result from address %s even though it wasn't requested", entry.Address)
+	}
+	s.utxosByOutpoint[*outpoint] = &walletUTXO{
+		Outpoint:  outpoint,
+		UTXOEntry: utxoEntry,
+		address:   address,
+	}
 
 	return nil
 }
 
+func (s *server) updateUTXOsSortedByAmount() {
+	utxos := make([]*walletUTXO, 0, len(s.utxosByOutpoint))
+	for _, utxo := range s.utxosByOutpoint {
+		utxos = append(utxos, utxo)
+	}
+
+	sort.Slice(utxos, func(i, j int) bool {
+		if utxos[i].UTXOEntry.Amount() != utxos[j].UTXOEntry.Amount() {
+			return utxos[i].UTXOEntry.Amount() > utxos[j].UTXOEntry.Amount()
+		}
+		return utxos[i].Outpoint.String() < utxos[j].Outpoint.String()
+	})
+
+	s.utxosSortedByAmount = utxos
+}
+
+// refreshUTXOs re-fetches the UTXOs of all the wallet addresses
 func (s *server) refreshUTXOs() error {
+	return s.refreshUTXOsOfAddresses(s.addressSet.strings())
+}
+
+func (s *server) refreshUTXOsOfAddresses(addresses []string) error {
 	// It's important to check the mempool before calling `GetUTXOsByAddresses`:
 	// If we would do it the other way around an output can be spent in the mempool
 	// and not in consensus, and between the calls its spending transaction will be
 	// added to consensus and removed from the mempool, so `getUTXOsByAddressesResponse`
 	// will include an obsolete output.
-	mempoolEntriesByAddresses, err := s.rpcClient.GetMempoolEntriesByAddresses(s.addressSet.strings(), true, true)
+	mempoolEntriesByAddresses, err := s.rpcClient.GetMempoolEntriesByAddresses(addresses, true, true)
 	if err != nil {
 		return err
 	}
 
-	getUTXOsByAddressesResponse, err := s.rpcClient.GetUTXOsByAddresses(s.addressSet.strings())
+	getUTXOsByAddressesResponse, err := s.rpcClient.GetUTXOsByAddresses(addresses)
 	if err != nil {
 		return err
 	}
 
-	return s.updateUTXOSet(getUTXOsByAddressesResponse.Entries, mempoolEntriesByAddresses.Entries)
+	return s.updateUTXOSet(addresses, getUTXOsByAddressesResponse.Entries, mempoolEntriesByAddresses.Entries)
+}
+
+// mempoolSpentOutpoints returns the outpoints of the wallet UTXOs that are spent by
+// transactions in the node's mempool. The UTXOs changed notifications follow the
+// virtual UTXO set, so these UTXOs stay in the wallet UTXO set until a block accepts
+// the transactions that spend them.
+func (s *server) mempoolSpentOutpoints() (map[externalapi.DomainOutpoint]struct{}, error) {
+	mempoolEntriesByAddresses, err := s.rpcClient.GetMempoolEntriesByAddresses(s.addressSet.strings(), true, true)
+	if err != nil {
+		return nil, err
+	}
+
+	spentOutpoints := make(map[externalapi.DomainOutpoint]struct{})
+	for _, entriesByAddress := range mempoolEntriesByAddresses.Entries {
+		for _, entry := range entriesByAddress.Sending {
+			for _, input := range entry.Transaction.Inputs {
+				outpoint, err := appmessage.RPCOutpointToDomainOutpoint(input.PreviousOutpoint)
+				if err != nil {
+					return nil, err
+				}
+				spentOutpoints[*outpoint] = struct{}{}
+			}
+		}
+	}
+	return spentOutpoints, nil
 }
 
 func (s *server) isSynced() bool {
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"context"
+
+	"github.com/zuanet/zuad/cmd/zuawallet/daemon/pb"
+	"github.com/pkg/errors"
+)
+
+func (s *server) GetTransactions(_ context.Context, request *pb.GetTransactionsRequest) (*pb.GetTransactionsResponse, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	dagInfo, err := s.rpcClient.GetBlockDAGInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := s.history.sortedTransactions()
+	if request.Limit > 0 && int(request.Limit) < len(transactions) {
+		transactions = transactions[:request.Limit]
+	}
+
+	walletTransactions := make([]*pb.WalletTransaction, len(transactions))
+	for i, transaction := range transactions {
+		received, spent := transaction.amounts()
+
+		var confirmations uint64
+		if transaction.isAccepted() && dagInfo.VirtualDAAScore >= transaction.DAAScore {
+			confirmations = dagInfo.VirtualDAAScore - transaction.DAAScore + 1
+		}
+
+		walletTransactions[i] = &pb.WalletTransaction{
+			TxID:               transaction.TransactionID,
+			ReceivedAmount:     received,
+			SpentAmount:        spent,
+			Fee:                transaction.Fee,
+			HasFee:             transaction.HasFee,
+			IsAccepted:         transaction.isAccepted(),
+			AcceptingBlockHash: transaction.AcceptingBlockHash,
+			DaaScore:           transaction.DAAScore,
+			Confirmations:      confirmations,
+			Label:              transaction.Label,
+			Timestamp:          transaction.Timestamp,
+			IsSentByWallet:     transaction.IsSentByWallet,
+		}
+	}
+
+	return &pb.GetTransactionsResponse{Transactions: walletTransactions}, nil
+}
+
+func (s *server) LabelTransaction(_ context.Context, request *pb.LabelTransactionRequest) (*pb.LabelTransactionResponse, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	transaction, ok := s.history.transactions[request.TxID]
+	if !ok {
+		return nil, errors.Errorf("transaction %s is not in the wallet history", request.TxID)
+	}
+
+	transaction.Label = request.Label
+	err := s.history.save()
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.LabelTransactionResponse{}, nil
+}
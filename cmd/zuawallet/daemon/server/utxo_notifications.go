@@ -0,0 +1,143 @@
+package server
+
+import (
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/infrastructure/network/rpcclient"
+	"github.com/pkg/errors"
+)
+
+func (s *server) registerForNotificationsWithLock() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.registerForNotifications()
+}
+
+// registerForNotifications registers for the notifications that keep the
+// UTXO set up to date, and fully refreshes it. It's called on startup and
+// whenever the RPC client reconnects, since registrations don't survive
+// a reconnection.
+func (s *server) registerForNotifications() error {
+	err := s.rpcClient.RegisterPruningPointUTXOSetNotifications(s.onPruningPointUTXOSetOverride)
+	if err != nil {
+		return errors.Wrap(err, "error registering for pruning point UTXO set override notifications")
+	}
+
+	s.subscribedAddresses = make(map[string]struct{})
+	return s.subscribeToNewAddresses()
+}
+
+func (s *server) subscribeToNewAddressesWithLock() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.subscribeToNewAddresses()
+}
+
+// subscribeToNewAddresses registers for UTXOsChanged notifications of the
+// addresses that were found since the last call, and fetches their UTXOs.
+// Registering before fetching makes sure no change is missed in between.
+func (s *server) subscribeToNewAddresses() error {
+	var newAddresses []string
+	for address := range s.addressSet {
+		if _, ok := s.subscribedAddresses[address]; !ok {
+			newAddresses = append(newAddresses, address)
+		}
+	}
+	if len(newAddresses) == 0 {
+		return nil
+	}
+
+	var err error
+	if len(s.subscribedAddresses) == 0 {
+		err = s.rpcClient.RegisterForUTXOsChangedNotifications(newAddresses, s.onUTXOsChanged)
+	} else {
+		err = s.rpcClient.AddUTXOsChangedNotificationAddresses(newAddresses)
+	}
+	if err != nil {
+		return errors.Wrap(err, "error registering for UTXOs changed notifications")
+	}
+
+	for _, address := range newAddresses {
+		s.subscribedAddresses[address] = struct{}{}
+	}
+
+	return s.refreshUTXOsOfAddresses(newAddresses)
+}
+
+func (s *server) onUTXOsChanged(notification *appmessage.UTXOsChangedNotificationMessage) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	err := s.applyUTXOsChanged(notification.Added, notification.Removed)
+	if err != nil {
+		// A notification that can't be applied leaves the UTXO set in an
+		// unknown state, so it's fetched again from scratch
+		log.Warnf("Couldn't apply a UTXOs changed notification, refreshing all UTXOs: %s", err)
+		err = s.refreshUTXOs()
+		if err != nil {
+			printErrorAndExit(errors.Wrap(err, "error refreshing the wallet UTXOs"))
+		}
+	}
+}
+
+func (s *server) applyUTXOsChanged(added, removed []*appmessage.UTXOsByAddressesEntry) error {
+	for _, entry := range removed {
+		outpoint, err := appmessage.RPCOutpointToDomainOutpoint(entry.Outpoint)
+		if err != nil {
+			return err
+		}
+		delete(s.utxosByOutpoint, *outpoint)
+		delete(s.usedOutpoints, *outpoint)
+	}
+
+	for _, entry := range added {
+		err := s.addUTXO(entry)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.updateUTXOsSortedByAmount()
+	return nil
+}
+
+// releaseOutpointsOfDroppedTransactions makes the outpoints spent by transactions
+// that this daemon broadcast available again, if those transactions were dropped
+// from the node's mempool without being accepted. Outpoints spent by accepted
+// transactions are released by the UTXOs changed notification that removes them.
+func (s *server) releaseOutpointsOfDroppedTransactions() error {
+	isDroppedByTransactionID := make(map[string]bool)
+	for outpoint, transactionID := range s.usedOutpoints {
+		isDropped, ok := isDroppedByTransactionID[transactionID]
+		if !ok {
+			_, err := s.rpcClient.GetMempoolEntry(transactionID, true, false)
+			if err != nil && !errors.Is(err, rpcclient.ErrRPC) {
+				return err
+			}
+			// The node responds with an error when the transaction isn't found
+			isDropped = err != nil
+			isDroppedByTransactionID[transactionID] = isDropped
+		}
+		if isDropped {
+			delete(s.usedOutpoints, outpoint)
+		}
+	}
+	return nil
+}
+
+func (s *server) onPruningPointUTXOSetOverride() {
+	log.Infof("The pruning point UTXO set was overridden, refreshing all UTXOs")
+	err := s.refreshExistingUTXOsWithLock()
+	if err != nil {
+		printErrorAndExit(errors.Wrap(err, "error refreshing the wallet UTXOs"))
+	}
+}
+
+func (s *server) onReconnected() {
+	log.Infof("Reconnected to the node, registering for notifications and refreshing all UTXOs")
+	err := s.registerForNotificationsWithLock()
+	if err != nil {
+		printErrorAndExit(errors.Wrap(err, "error resyncing the wallet after reconnecting"))
+	}
+}
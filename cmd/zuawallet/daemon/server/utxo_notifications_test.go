@@ -0,0 +1,72 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+)
+
+func TestApplyUTXOsChanged(t *testing.T) {
+	const address = "zuatest:address"
+	const transactionID = "0000000000000000000000000000000000000000000000000000000000000001"
+
+	serverInstance := &server{
+		addressSet:      walletAddressSet{address: &walletAddress{index: 1}},
+		utxosByOutpoint: map[externalapi.DomainOutpoint]*walletUTXO{},
+		usedOutpoints:   map[externalapi.DomainOutpoint]string{},
+	}
+
+	entry := func(index uint32, amount uint64) *appmessage.UTXOsByAddressesEntry {
+		return &appmessage.UTXOsByAddressesEntry{
+			Address:  address,
+			Outpoint: &appmessage.RPCOutpoint{TransactionID: transactionID, Index: index},
+			UTXOEntry: &appmessage.RPCUTXOEntry{
+				Amount:          amount,
+				ScriptPublicKey: &appmessage.RPCScriptPublicKey{Script: "", Version: 0},
+			},
+		}
+	}
+
+	err := serverInstance.updateUTXOSet([]string{address},
+		[]*appmessage.UTXOsByAddressesEntry{entry(0, 100), entry(1, 300)}, nil)
+	if err != nil {
+		t.Fatalf("updateUTXOSet: %+v", err)
+	}
+
+	spentOutpoint, err := appmessage.RPCOutpointToDomainOutpoint(entry(1, 300).Outpoint)
+	if err != nil {
+		t.Fatalf("RPCOutpointToDomainOutpoint: %+v", err)
+	}
+	serverInstance.usedOutpoints[*spentOutpoint] = transactionID
+
+	err = serverInstance.applyUTXOsChanged(
+		[]*appmessage.UTXOsByAddressesEntry{entry(2, 200)},
+		[]*appmessage.UTXOsByAddressesEntry{entry(1, 300)})
+	if err != nil {
+		t.Fatalf("applyUTXOsChanged: %+v", err)
+	}
+
+	expectedAmounts := []uint64{200, 100}
+	if len(serverInstance.utxosSortedByAmount) != len(expectedAmounts) {
+		t.Fatalf("expected %d UTXOs, got %d", len(expectedAmounts), len(serverInstance.utxosSortedByAmount))
+	}
+	for i, expectedAmount := range expectedAmounts {
+		amount := serverInstance.utxosSortedByAmount[i].UTXOEntry.Amount()
+		if amount != expectedAmount {
+			t.Fatalf("expected UTXO #%d to have amount %d, got %d", i, expectedAmount, amount)
+		}
+	}
+	if _, ok := serverInstance.usedOutpoints[*spentOutpoint]; ok {
+		t.Fatalf("the removed UTXO is still marked as used")
+	}
+
+	err = serverInstance.applyUTXOsChanged([]*appmessage.UTXOsByAddressesEntry{{
+		Address:   "zuatest:unknown",
+		Outpoint:  entry(3, 0).Outpoint,
+		UTXOEntry: entry(3, 50).UTXOEntry,
+	}}, nil)
+	if err == nil {
+		t.Fatalf("expected an error for a UTXO of an address that isn't in the wallet")
+	}
+}
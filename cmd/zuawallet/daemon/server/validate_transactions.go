@@ -0,0 +1,53 @@
+package server
+
+import (
+	"context"
+
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/cmd/zuawallet/daemon/pb"
+	"github.com/zuanet/zuad/cmd/zuawallet/libzuawallet"
+	"github.com/zuanet/zuad/cmd/zuawallet/libzuawallet/serialization"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
+)
+
+func (s *server) ValidateTransactions(_ context.Context, request *pb.ValidateTransactionsRequest) (
+	*pb.ValidateTransactionsResponse, error) {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	results := make([]*pb.TransactionValidationResult, len(request.Transactions))
+	for i, transaction := range request.Transactions {
+		var tx *externalapi.DomainTransaction
+		var err error
+		if request.IsDomain {
+			tx, err = serialization.DeserializeDomainTransaction(transaction)
+		} else {
+			tx, err = libzuawallet.ExtractTransaction(transaction, s.keysFile.ECDSA)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := s.rpcClient.ValidateTransaction(appmessage.DomainTransactionToRPCTransaction(tx))
+		if err != nil {
+			return nil, errors.Wrapf(err, "error validating transaction")
+		}
+
+		results[i] = &pb.TransactionValidationResult{
+			TxID:         response.TransactionID,
+			Mass:         response.Mass,
+			Fee:          response.Fee,
+			FeeRate:      response.FeeRate,
+			HasFee:       response.HasFee,
+			IsStandard:   response.IsStandard,
+			IsOrphan:     response.IsOrphan,
+			IsAccepted:   response.IsAccepted,
+			RejectCode:   response.RejectCode,
+			RejectReason: response.RejectReason,
+		}
+	}
+
+	return &pb.ValidateTransactionsResponse{Results: results}, nil
+}
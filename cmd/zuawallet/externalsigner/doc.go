@@ -0,0 +1,40 @@
+/*
+Package externalsigner lets zuawallet hand partially signed transactions to a
+signer that runs out of process, such as a driver for a hardware wallet, an
+HSM or a bridge to an air-gapped machine.
+
+# Protocol
+
+zuawallet starts the signer command and writes a single JSON request to its
+stdin:
+
+	{
+	  "protocolVersion": 1,
+	  "method": "signTransactions",
+	  "network": "zuad-mainnet",
+	  "ecdsa": false,
+	  "transactions": ["7a707374ff0a..."]
+	}
+
+Every transaction is a hex-encoded PST (see the README of
+libzuawallet/serialization for the file format). The signer adds the
+signatures of the keys it holds and writes a single JSON response to its
+stdout before exiting:
+
+	{
+	  "protocolVersion": 1,
+	  "transactions": ["7a707374ff0a..."]
+	}
+
+The response must hold exactly one transaction per requested transaction, in
+the same order. A signer that fails writes a response with an "error" field
+instead, or exits with a non-zero status. Anything the signer writes to
+stderr, such as a prompt to confirm the transaction on a device, is passed
+through to the user.
+
+zuawallet rejects responses in which anything other than signatures was
+changed.
+
+Signers written in Go can use Serve to implement their side of the protocol.
+*/
+package externalsigner
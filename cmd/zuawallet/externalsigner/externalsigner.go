@@ -0,0 +1,128 @@
+package externalsigner
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+
+	"github.com/zuanet/zuad/cmd/zuawallet/libzuawallet/serialization"
+	"github.com/zuanet/zuad/domain/consensus/utils/consensushashing"
+	"github.com/zuanet/zuad/domain/dagconfig"
+	"github.com/pkg/errors"
+)
+
+// ExternalSigner runs a signer command for every batch of transactions to sign
+type ExternalSigner struct {
+	command string
+	args    []string
+}
+
+// New returns an ExternalSigner that runs the given command line. The command
+// line is split on whitespace into the command and its arguments.
+func New(commandLine string) (*ExternalSigner, error) {
+	fields := strings.Fields(commandLine)
+	if len(fields) == 0 {
+		return nil, errors.New("the external signer command is empty")
+	}
+	return &ExternalSigner{
+		command: fields[0],
+		args:    fields[1:],
+	}, nil
+}
+
+// Sign hands the given partially signed transactions to the signer and returns
+// them with the signatures it added
+func (es *ExternalSigner) Sign(params *dagconfig.Params, ecdsa bool, partiallySignedTransactions [][]byte) ([][]byte, error) {
+	request := &Request{
+		ProtocolVersion: ProtocolVersion,
+		Method:          MethodSignTransactions,
+		Network:         params.Name,
+		ECDSA:           ecdsa,
+		Transactions:    encodeTransactions(partiallySignedTransactions),
+	}
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	stdout := &bytes.Buffer{}
+	cmd := exec.Command(es.command, es.args...)
+	cmd.Stdin = bytes.NewReader(requestBytes)
+	cmd.Stdout = stdout
+	cmd.Stderr = os.Stderr
+	err = cmd.Run()
+	if err != nil {
+		return nil, errors.Wrapf(err, "external signer %s failed", es.command)
+	}
+
+	response := &Response{}
+	err = json.Unmarshal(stdout.Bytes(), response)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not decode the response of external signer %s", es.command)
+	}
+	if response.Error != "" {
+		return nil, errors.Errorf("external signer %s returned an error: %s", es.command, response.Error)
+	}
+	if response.ProtocolVersion != ProtocolVersion {
+		return nil, errors.Errorf("external signer %s responded with protocol version %d, but version %d is required",
+			es.command, response.ProtocolVersion, ProtocolVersion)
+	}
+	if len(response.Transactions) != len(partiallySignedTransactions) {
+		return nil, errors.Errorf("external signer %s returned %d transactions, but %d were sent",
+			es.command, len(response.Transactions), len(partiallySignedTransactions))
+	}
+
+	signedTransactions, err := decodeTransactions(response.Transactions)
+	if err != nil {
+		return nil, err
+	}
+	for i := range signedTransactions {
+		err := checkOnlySignaturesChanged(partiallySignedTransactions[i], signedTransactions[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "external signer %s returned an invalid transaction #%d", es.command, i+1)
+		}
+	}
+	return signedTransactions, nil
+}
+
+// checkOnlySignaturesChanged makes sure the signer didn't tamper with anything
+// but the signatures of the transaction it was asked to sign
+func checkOnlySignaturesChanged(originalBytes []byte, signedBytes []byte) error {
+	original, err := serialization.DeserializePartiallySignedTransaction(originalBytes)
+	if err != nil {
+		return err
+	}
+	signed, err := serialization.DeserializePartiallySignedTransaction(signedBytes)
+	if err != nil {
+		return err
+	}
+
+	if !consensushashing.TransactionID(original.Tx).Equal(consensushashing.TransactionID(signed.Tx)) {
+		return errors.New("the transaction was modified")
+	}
+	if len(original.PartiallySignedInputs) != len(signed.PartiallySignedInputs) {
+		return errors.New("the number of inputs was modified")
+	}
+	for i, originalInput := range original.PartiallySignedInputs {
+		signedInput := signed.PartiallySignedInputs[i]
+		if !originalInput.PrevOutput.Equal(signedInput.PrevOutput) ||
+			originalInput.MinimumSignatures != signedInput.MinimumSignatures ||
+			originalInput.DerivationPath != signedInput.DerivationPath ||
+			len(originalInput.PubKeySignaturePairs) != len(signedInput.PubKeySignaturePairs) {
+
+			return errors.Errorf("input #%d was modified", i)
+		}
+		for j, originalPair := range originalInput.PubKeySignaturePairs {
+			signedPair := signedInput.PubKeySignaturePairs[j]
+			if originalPair.ExtendedPublicKey != signedPair.ExtendedPublicKey ||
+				!reflect.DeepEqual(originalPair.KeyOrigin, signedPair.KeyOrigin) {
+
+				return errors.Errorf("the public keys of input #%d were modified", i)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package externalsigner_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/zuanet/zuad/cmd/zuawallet/externalsigner"
+	"github.com/zuanet/zuad/cmd/zuawallet/libzuawallet"
+	"github.com/zuanet/zuad/cmd/zuawallet/libzuawallet/bip32"
+	"github.com/zuanet/zuad/cmd/zuawallet/libzuawallet/serialization"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/utils/txscript"
+	"github.com/zuanet/zuad/domain/consensus/utils/utxo"
+	"github.com/zuanet/zuad/domain/dagconfig"
+)
+
+const (
+	mockSignerMnemonicEnv = "ZUAWALLET_MOCK_SIGNER_MNEMONIC"
+	mockSignerTamperEnv   = "ZUAWALLET_MOCK_SIGNER_TAMPER"
+)
+
+// TestMain turns the test binary into a mock external signer when it's run
+// by the tests below with a mnemonic in its environment
+func TestMain(m *testing.M) {
+	mnemonic := os.Getenv(mockSignerMnemonicEnv)
+	if mnemonic == "" {
+		os.Exit(m.Run())
+	}
+
+	err := externalsigner.Serve(os.Stdin, os.Stdout, func(request *externalsigner.Request,
+		partiallySignedTransactions [][]byte) ([][]byte, error) {
+
+		return mockSign(mnemonic, request, partiallySignedTransactions)
+	})
+	if err != nil {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func mockSign(mnemonic string, request *externalsigner.Request, partiallySignedTransactions [][]byte) ([][]byte, error) {
+	params := &dagconfig.SimnetParams
+	signedTransactions := make([][]byte, len(partiallySignedTransactions))
+	for i, partiallySignedTransaction := range partiallySignedTransactions {
+		signedTransaction, err := libzuawallet.Sign(params, []string{mnemonic}, partiallySignedTransaction, request.ECDSA)
+		if err != nil {
+			return nil, err
+		}
+		if os.Getenv(mockSignerTamperEnv) != "" {
+			deserialized, err := serialization.DeserializePartiallySignedTransaction(signedTransaction)
+			if err != nil {
+				return nil, err
+			}
+			deserialized.Tx.Outputs[0].Value++
+			signedTransaction, err = serialization.SerializePartiallySignedTransaction(deserialized)
+			if err != nil {
+				return nil, err
+			}
+		}
+		signedTransactions[i] = signedTransaction
+	}
+	return signedTransactions, nil
+}
+
+func createUnsignedTransaction(t *testing.T, params *dagconfig.Params, publicKey string) []byte {
+	const path = "m/0/3"
+	address, err := libzuawallet.Address(params, []string{publicKey}, 1, path, false)
+	if err != nil {
+		t.Fatalf("Address: %+v", err)
+	}
+	scriptPublicKey, err := txscript.PayToAddrScript(address)
+	if err != nil {
+		t.Fatalf("PayToAddrScript: %+v", err)
+	}
+
+	selectedUTXOs := []*libzuawallet.UTXO{
+		{
+			Outpoint:       &externalapi.DomainOutpoint{Index: 1},
+			UTXOEntry:      utxo.NewUTXOEntry(100000, scriptPublicKey, false, 0),
+			DerivationPath: path,
+		},
+	}
+	unsignedTransaction, err := libzuawallet.CreateUnsignedTransaction([]string{publicKey}, 1,
+		[]*libzuawallet.Payment{{Address: address, Amount: 1000}}, selectedUTXOs)
+	if err != nil {
+		t.Fatalf("CreateUnsignedTransaction: %+v", err)
+	}
+	return unsignedTransaction
+}
+
+func TestExternalSigner(t *testing.T) {
+	params := &dagconfig.SimnetParams
+	mnemonic, err := libzuawallet.CreateMnemonic()
+	if err != nil {
+		t.Fatalf("CreateMnemonic: %+v", err)
+	}
+	publicKey, err := libzuawallet.MasterPublicKeyFromMnemonic(params, mnemonic, false)
+	if err != nil {
+		t.Fatalf("MasterPublicKeyFromMnemonic: %+v", err)
+	}
+	unsignedTransaction := createUnsignedTransaction(t, params, publicKey)
+
+	deserialized, err := serialization.DeserializePartiallySignedTransaction(unsignedTransaction)
+	if err != nil {
+		t.Fatalf("DeserializePartiallySignedTransaction: %+v", err)
+	}
+	extendedPublicKey, err := bip32.DeserializeExtendedKey(publicKey)
+	if err != nil {
+		t.Fatalf("DeserializeExtendedKey: %+v", err)
+	}
+	expectedFingerprint, err := extendedPublicKey.Fingerprint()
+	if err != nil {
+		t.Fatalf("Fingerprint: %+v", err)
+	}
+	keyOrigin := deserialized.PartiallySignedInputs[0].PubKeySignaturePairs[0].KeyOrigin
+	if keyOrigin == nil || keyOrigin.Fingerprint != expectedFingerprint || keyOrigin.DerivationPath != "m/0/3" {
+		t.Fatalf("unexpected key origin %+v", keyOrigin)
+	}
+
+	signer, err := externalsigner.New(os.Args[0])
+	if err != nil {
+		t.Fatalf("New: %+v", err)
+	}
+
+	t.Run("sign", func(t *testing.T) {
+		t.Setenv(mockSignerMnemonicEnv, mnemonic)
+		signedTransactions, err := signer.Sign(params, false, [][]byte{unsignedTransaction})
+		if err != nil {
+			t.Fatalf("Sign: %+v", err)
+		}
+		isFullySigned, err := libzuawallet.IsTransactionFullySigned(signedTransactions[0])
+		if err != nil {
+			t.Fatalf("IsTransactionFullySigned: %+v", err)
+		}
+		if !isFullySigned {
+			t.Fatalf("The transaction is expected to be fully signed")
+		}
+	})
+
+	t.Run("foreign key", func(t *testing.T) {
+		otherMnemonic, err := libzuawallet.CreateMnemonic()
+		if err != nil {
+			t.Fatalf("CreateMnemonic: %+v", err)
+		}
+		t.Setenv(mockSignerMnemonicEnv, otherMnemonic)
+		_, err = signer.Sign(params, false, [][]byte{unsignedTransaction})
+		if err == nil || !strings.Contains(err.Error(), "doesn't match any of the transaction public keys") {
+			t.Fatalf("Expected the error of the signer to be returned, got %+v", err)
+		}
+	})
+
+	t.Run("tampering signer", func(t *testing.T) {
+		t.Setenv(mockSignerMnemonicEnv, mnemonic)
+		t.Setenv(mockSignerTamperEnv, "1")
+		_, err := signer.Sign(params, false, [][]byte{unsignedTransaction})
+		if err == nil || !strings.Contains(err.Error(), "the transaction was modified") {
+			t.Fatalf("Expected a modified transaction to be rejected, got %+v", err)
+		}
+	})
+}
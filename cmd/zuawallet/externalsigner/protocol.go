@@ -0,0 +1,91 @@
+package externalsigner
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// ProtocolVersion is the version of the external signer protocol implemented
+// by this package
+const ProtocolVersion = 1
+
+// MethodSignTransactions asks the signer to sign the given transactions
+const MethodSignTransactions = "signTransactions"
+
+// Request is the message zuawallet writes to the stdin of the signer
+type Request struct {
+	ProtocolVersion uint32   `json:"protocolVersion"`
+	Method          string   `json:"method"`
+	Network         string   `json:"network"`
+	ECDSA           bool     `json:"ecdsa"`
+	Transactions    []string `json:"transactions"`
+}
+
+// Response is the message the signer writes to its stdout
+type Response struct {
+	ProtocolVersion uint32   `json:"protocolVersion"`
+	Transactions    []string `json:"transactions,omitempty"`
+	Error           string   `json:"error,omitempty"`
+}
+
+// SignFunc signs the given partially signed transactions on behalf of a signer
+type SignFunc func(request *Request, partiallySignedTransactions [][]byte) ([][]byte, error)
+
+// Serve implements the signer's side of the protocol: it reads a single request
+// from reader, signs its transactions with signFunc and writes the response to
+// writer. Errors returned by signFunc are reported to zuawallet in the response.
+func Serve(reader io.Reader, writer io.Writer, signFunc SignFunc) error {
+	request := &Request{}
+	err := json.NewDecoder(reader).Decode(request)
+	if err != nil {
+		return errors.Wrap(err, "could not decode the external signer request")
+	}
+
+	response := &Response{ProtocolVersion: ProtocolVersion}
+	signedTransactions, err := handleRequest(request, signFunc)
+	if err != nil {
+		response.Error = err.Error()
+	} else {
+		response.Transactions = encodeTransactions(signedTransactions)
+	}
+
+	return json.NewEncoder(writer).Encode(response)
+}
+
+func handleRequest(request *Request, signFunc SignFunc) ([][]byte, error) {
+	if request.ProtocolVersion != ProtocolVersion {
+		return nil, errors.Errorf("unsupported protocol version %d", request.ProtocolVersion)
+	}
+	if request.Method != MethodSignTransactions {
+		return nil, errors.Errorf("unknown method %s", request.Method)
+	}
+
+	partiallySignedTransactions, err := decodeTransactions(request.Transactions)
+	if err != nil {
+		return nil, err
+	}
+	return signFunc(request, partiallySignedTransactions)
+}
+
+func encodeTransactions(transactions [][]byte) []string {
+	encoded := make([]string, len(transactions))
+	for i, transaction := range transactions {
+		encoded[i] = hex.EncodeToString(transaction)
+	}
+	return encoded
+}
+
+func decodeTransactions(encoded []string) ([][]byte, error) {
+	transactions := make([][]byte, len(encoded))
+	for i, transactionHex := range encoded {
+		var err error
+		transactions[i], err = hex.DecodeString(transactionHex)
+		if err != nil {
+			return nil, errors.Wrapf(err, "transaction #%d is not valid hex", i+1)
+		}
+	}
+	return transactions, nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zuanet/zuad/cmd/zuawallet/daemon/client"
+	"github.com/zuanet/zuad/cmd/zuawallet/daemon/pb"
+	"github.com/zuanet/zuad/domain/consensus/utils/constants"
+	"github.com/zuanet/zuad/util/mstime"
+)
+
+func history(conf *historyConfig) error {
+	daemonClient, tearDown, err := client.Connect(conf.DaemonAddress)
+	if err != nil {
+		return err
+	}
+	defer tearDown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), daemonTimeout)
+	defer cancel()
+
+	response, err := daemonClient.GetTransactions(ctx, &pb.GetTransactionsRequest{Limit: conf.Limit})
+	if err != nil {
+		return err
+	}
+
+	if len(response.Transactions) == 0 {
+		fmt.Println("No transactions were found. Note that the history is only available " +
+			"when the node runs with --utxoindex and --addresshistoryindex")
+		return nil
+	}
+
+	for _, transaction := range response.Transactions {
+		fmt.Printf("Transaction %s\n", transaction.TxID)
+		fmt.Printf("\tAmount:\t\t%s ZUA\n", formatBalanceChange(transaction.ReceivedAmount, transaction.SpentAmount))
+		if transaction.HasFee {
+			fmt.Printf("\tFee:\t\t%s ZUA\n", formatSompi(transaction.Fee))
+		}
+		if transaction.IsAccepted {
+			fmt.Printf("\tStatus:\t\taccepted at DAA score %d (%d confirmations)\n",
+				transaction.DaaScore, transaction.Confirmations)
+		} else {
+			fmt.Println("\tStatus:\t\tpending")
+		}
+		fmt.Printf("\tFirst seen:\t%s\n", mstime.UnixMilliseconds(transaction.Timestamp).ToNativeTime().Format(time.RFC3339))
+		if transaction.Label != "" {
+			fmt.Printf("\tLabel:\t\t%s\n", transaction.Label)
+		}
+	}
+
+	return nil
+}
+
+func labelTransaction(conf *labelTransactionConfig) error {
+	daemonClient, tearDown, err := client.Connect(conf.DaemonAddress)
+	if err != nil {
+		return err
+	}
+	defer tearDown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), daemonTimeout)
+	defer cancel()
+
+	_, err = daemonClient.LabelTransaction(ctx, &pb.LabelTransactionRequest{
+		TxID:  conf.TransactionID,
+		Label: conf.Label,
+	})
+	if err != nil {
+		return err
+	}
+
+	if conf.Label == "" {
+		fmt.Printf("Removed the label of transaction %s\n", conf.TransactionID)
+	} else {
+		fmt.Printf("Labeled transaction %s\n", conf.TransactionID)
+	}
+	return nil
+}
+
+// formatBalanceChange formats the change a transaction made to the wallet
+// balance as a signed amount of ZUA.
+func formatBalanceChange(received, spent uint64) string {
+	if spent > received {
+		return "-" + formatSompi(spent-received)
+	}
+	return "+" + formatSompi(received-spent)
+}
+
+func formatSompi(amount uint64) string {
+	return fmt.Sprintf("%.8f", float64(amount)/constants.SompiPerZua)
+}
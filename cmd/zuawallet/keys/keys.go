@@ -205,6 +205,12 @@ func (d *File) LastUsedInternalIndex() uint32 {
 	return d.lastUsedInternalIndex
 }
 
+// IsWatchOnly returns whether the keys file holds only extended public keys,
+// in which case it can't be used to sign transactions.
+func (d *File) IsWatchOnly() bool {
+	return len(d.EncryptedMnemonics) == 0
+}
+
 // DecryptMnemonics asks the user to enter the password for the private keys and
 // returns the decrypted private keys.
 func (d *File) DecryptMnemonics(password string) ([]string, error) {
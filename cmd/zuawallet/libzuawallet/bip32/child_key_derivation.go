@@ -81,6 +81,12 @@ func (extKey *ExtendedKey) Child(i uint32) (*ExtendedKey, error) {
 	return childExt, nil
 }
 
+// Fingerprint returns the BIP32 fingerprint of the extended key: the first
+// 4 bytes of the hash160 of its serialized public key
+func (extKey *ExtendedKey) Fingerprint() ([4]byte, error) {
+	return extKey.calcFingerprint()
+}
+
 func (extKey *ExtendedKey) calcFingerprint() ([4]byte, error) {
 	publicKey, err := extKey.PublicKey()
 	if err != nil {
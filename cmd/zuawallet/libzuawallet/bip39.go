@@ -80,3 +80,42 @@ func versionFromParams(params *dagconfig.Params) ([4]byte, error) {
 
 	return [4]byte{}, errors.Errorf("unknown network %s", params.Name)
 }
+
+// ValidateExtendedPublicKey checks that the given string is a serialized
+// extended public key that belongs to the network defined by params.
+func ValidateExtendedPublicKey(params *dagconfig.Params, extendedPublicKey string) error {
+	extendedKey, err := bip32.DeserializeExtendedKey(extendedPublicKey)
+	if err != nil {
+		return errors.Wrapf(err, "%s is invalid extended public key", extendedPublicKey)
+	}
+
+	if extendedKey.IsPrivate() {
+		return errors.Errorf("%s is an extended private key, while an extended public key is expected", extendedPublicKey)
+	}
+
+	version, err := publicVersionFromParams(params)
+	if err != nil {
+		return err
+	}
+
+	if extendedKey.Version != version {
+		return errors.Errorf("%s is not an extended public key of network %s", extendedPublicKey, params.Name)
+	}
+
+	return nil
+}
+
+func publicVersionFromParams(params *dagconfig.Params) ([4]byte, error) {
+	switch params.Name {
+	case dagconfig.MainnetParams.Name:
+		return bip32.ZuaMainnetPublic, nil
+	case dagconfig.TestnetParams.Name:
+		return bip32.ZuaTestnetPublic, nil
+	case dagconfig.DevnetParams.Name:
+		return bip32.ZuaDevnetPublic, nil
+	case dagconfig.SimnetParams.Name:
+		return bip32.ZuaSimnetPublic, nil
+	}
+
+	return [4]byte{}, errors.Errorf("unknown network %s", params.Name)
+}
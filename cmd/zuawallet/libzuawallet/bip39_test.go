@@ -0,0 +1,35 @@
+package libzuawallet_test
+
+import (
+	"testing"
+
+	"github.com/zuanet/zuad/cmd/zuawallet/libzuawallet"
+	"github.com/zuanet/zuad/domain/dagconfig"
+)
+
+func TestValidateExtendedPublicKey(t *testing.T) {
+	mnemonic, err := libzuawallet.CreateMnemonic()
+	if err != nil {
+		t.Fatalf("CreateMnemonic: %+v", err)
+	}
+
+	mainnetPublicKey, err := libzuawallet.MasterPublicKeyFromMnemonic(&dagconfig.MainnetParams, mnemonic, false)
+	if err != nil {
+		t.Fatalf("MasterPublicKeyFromMnemonic: %+v", err)
+	}
+
+	err = libzuawallet.ValidateExtendedPublicKey(&dagconfig.MainnetParams, mainnetPublicKey)
+	if err != nil {
+		t.Fatalf("ValidateExtendedPublicKey: unexpected error for a mainnet public key: %+v", err)
+	}
+
+	err = libzuawallet.ValidateExtendedPublicKey(&dagconfig.TestnetParams, mainnetPublicKey)
+	if err == nil {
+		t.Fatalf("ValidateExtendedPublicKey: expected an error for a mainnet public key on testnet")
+	}
+
+	err = libzuawallet.ValidateExtendedPublicKey(&dagconfig.MainnetParams, "not an extended key")
+	if err == nil {
+		t.Fatalf("ValidateExtendedPublicKey: expected an error for a malformed key")
+	}
+}
@@ -0,0 +1,20 @@
+package serialization
+
+import "bytes"
+
+// PSTMagic is the prefix of every partially signed transaction in the PST
+// file format. The 0xff separator can never start a serialized
+// PartiallySignedTransaction message, which tells the file format apart from
+// the bare protobuf encoding used by older wallets.
+const PSTMagic = "zpst\xff"
+
+// PSTVersion is the version of the PST file format written by this package.
+// Partially signed transactions of higher versions are rejected.
+//
+// Version 0 is the bare protobuf encoding without key origins.
+// Version 1 adds PSTMagic and key origins for every public key.
+const PSTVersion = 1
+
+func trimPSTMagic(serialized []byte) []byte {
+	return bytes.TrimPrefix(serialized, []byte(PSTMagic))
+}
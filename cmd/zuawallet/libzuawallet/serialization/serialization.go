@@ -32,6 +32,18 @@ type PartiallySignedInput struct {
 type PubKeySignaturePair struct {
 	ExtendedPublicKey string
 	Signature         []byte
+	KeyOrigin         *KeyOrigin
+}
+
+// KeyOrigin describes where the key of a PubKeySignaturePair comes from, so
+// that a signer that only holds a seed can tell whether the key is its own
+// and how to derive it
+type KeyOrigin struct {
+	// Fingerprint is the BIP32 fingerprint of the cosigner's account extended public key
+	Fingerprint [4]byte
+	// DerivationPath is the path of the key from the account extended public key
+	// that Fingerprint identifies, e.g. m/0/5
+	DerivationPath string
 }
 
 // Clone creates a deep-clone of this PartiallySignedTransaction
@@ -69,23 +81,39 @@ func (psp PubKeySignaturePair) Clone() *PubKeySignaturePair {
 		clone.Signature = make([]byte, len(psp.Signature))
 		copy(clone.Signature, psp.Signature)
 	}
+	if psp.KeyOrigin != nil {
+		keyOrigin := *psp.KeyOrigin
+		clone.KeyOrigin = &keyOrigin
+	}
 	return clone
 }
 
-// DeserializePartiallySignedTransaction deserializes a byte slice into PartiallySignedTransaction.
+// DeserializePartiallySignedTransaction deserializes a byte slice in the PST
+// file format into PartiallySignedTransaction.
+// Bare protobuf-encoded transactions, as written before the file format was
+// introduced, are accepted as well.
 func DeserializePartiallySignedTransaction(serializedPartiallySignedTransaction []byte) (*PartiallySignedTransaction, error) {
 	protoPartiallySignedTransaction := &protoserialization.PartiallySignedTransaction{}
-	err := proto.Unmarshal(serializedPartiallySignedTransaction, protoPartiallySignedTransaction)
+	err := proto.Unmarshal(trimPSTMagic(serializedPartiallySignedTransaction), protoPartiallySignedTransaction)
 	if err != nil {
 		return nil, err
 	}
+	if protoPartiallySignedTransaction.Version > PSTVersion {
+		return nil, errors.Errorf("partially signed transaction version %d is not supported. "+
+			"The highest supported version is %d", protoPartiallySignedTransaction.Version, PSTVersion)
+	}
 
 	return partiallySignedTransactionFromProto(protoPartiallySignedTransaction)
 }
 
-// SerializePartiallySignedTransaction serializes a PartiallySignedTransaction.
+// SerializePartiallySignedTransaction serializes a PartiallySignedTransaction
+// in the PST file format.
 func SerializePartiallySignedTransaction(partiallySignedTransaction *PartiallySignedTransaction) ([]byte, error) {
-	return proto.Marshal(partiallySignedTransactionToProto(partiallySignedTransaction))
+	serializedProto, err := proto.Marshal(partiallySignedTransactionToProto(partiallySignedTransaction))
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(PSTMagic), serializedProto...), nil
 }
 
 // DeserializeDomainTransaction Deserialize a Transaction to an *externalapi.DomainTransaction
@@ -133,6 +161,7 @@ func partiallySignedTransactionToProto(partiallySignedTransaction *PartiallySign
 	return &protoserialization.PartiallySignedTransaction{
 		Tx:                    transactionToProto(partiallySignedTransaction.Tx),
 		PartiallySignedInputs: protoInputs,
+		Version:               PSTVersion,
 	}
 }
 
@@ -144,7 +173,10 @@ func partiallySignedInputFromProto(protoPartiallySignedInput *protoserialization
 
 	pubKeySignaturePairs := make([]*PubKeySignaturePair, len(protoPartiallySignedInput.PubKeySignaturePairs))
 	for i, protoPair := range protoPartiallySignedInput.PubKeySignaturePairs {
-		pubKeySignaturePairs[i] = pubKeySignaturePairFromProto(protoPair)
+		pubKeySignaturePairs[i], err = pubKeySignaturePairFromProto(protoPair)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return &PartiallySignedInput{
@@ -169,18 +201,47 @@ func partiallySignedInputToProto(partiallySignedInput *PartiallySignedInput) *pr
 	}
 }
 
-func pubKeySignaturePairFromProto(protoPubKeySignaturePair *protoserialization.PubKeySignaturePair) *PubKeySignaturePair {
-	return &PubKeySignaturePair{
+func pubKeySignaturePairFromProto(protoPubKeySignaturePair *protoserialization.PubKeySignaturePair) (*PubKeySignaturePair, error) {
+	pubKeySignaturePair := &PubKeySignaturePair{
 		ExtendedPublicKey: protoPubKeySignaturePair.ExtendedPubKey,
 		Signature:         protoPubKeySignaturePair.Signature,
 	}
+	if protoPubKeySignaturePair.KeyOrigin != nil {
+		keyOrigin, err := keyOriginFromProto(protoPubKeySignaturePair.KeyOrigin)
+		if err != nil {
+			return nil, err
+		}
+		pubKeySignaturePair.KeyOrigin = keyOrigin
+	}
+	return pubKeySignaturePair, nil
 }
 
 func pubKeySignaturePairToProto(pubKeySignaturePair *PubKeySignaturePair) *protoserialization.PubKeySignaturePair {
-	return &protoserialization.PubKeySignaturePair{
+	protoPubKeySignaturePair := &protoserialization.PubKeySignaturePair{
 		ExtendedPubKey: pubKeySignaturePair.ExtendedPublicKey,
 		Signature:      pubKeySignaturePair.Signature,
 	}
+	if pubKeySignaturePair.KeyOrigin != nil {
+		protoPubKeySignaturePair.KeyOrigin = keyOriginToProto(pubKeySignaturePair.KeyOrigin)
+	}
+	return protoPubKeySignaturePair
+}
+
+func keyOriginFromProto(protoKeyOrigin *protoserialization.KeyOrigin) (*KeyOrigin, error) {
+	keyOrigin := &KeyOrigin{DerivationPath: protoKeyOrigin.DerivationPath}
+	if len(protoKeyOrigin.Fingerprint) != len(keyOrigin.Fingerprint) {
+		return nil, errors.Errorf("key origin fingerprint is %d bytes long, but must be %d bytes long",
+			len(protoKeyOrigin.Fingerprint), len(keyOrigin.Fingerprint))
+	}
+	copy(keyOrigin.Fingerprint[:], protoKeyOrigin.Fingerprint)
+	return keyOrigin, nil
+}
+
+func keyOriginToProto(keyOrigin *KeyOrigin) *protoserialization.KeyOrigin {
+	return &protoserialization.KeyOrigin{
+		Fingerprint:    keyOrigin.Fingerprint[:],
+		DerivationPath: keyOrigin.DerivationPath,
+	}
 }
 
 func transactionFromProto(protoTransaction *protoserialization.TransactionMessage) (*externalapi.DomainTransaction, error) {
@@ -211,14 +272,23 @@ func transactionFromProto(protoTransaction *protoserialization.TransactionMessag
 		return nil, err
 	}
 
+	if protoTransaction.VprogVersion > math.MaxUint8 {
+		return nil, errors.Errorf("protoTransaction.VprogVersion is %d and is too big to be a byte",
+			protoTransaction.VprogVersion)
+	}
+
 	return &externalapi.DomainTransaction{
-		Version:      uint16(protoTransaction.Version),
-		Inputs:       inputs,
-		Outputs:      outputs,
-		LockTime:     protoTransaction.LockTime,
-		SubnetworkID: *subnetworkID,
-		Gas:          protoTransaction.Gas,
-		Payload:      protoTransaction.Payload,
+		Version:       uint16(protoTransaction.Version),
+		Inputs:        inputs,
+		Outputs:       outputs,
+		LockTime:      protoTransaction.LockTime,
+		SubnetworkID:  *subnetworkID,
+		Gas:           protoTransaction.Gas,
+		Payload:       protoTransaction.Payload,
+		VProgVersion:  byte(protoTransaction.VprogVersion),
+		VProgCode:     protoTransaction.VprogCode,
+		VProgData:     protoTransaction.VprogData,
+		VProgGasLimit: protoTransaction.VprogGasLimit,
 	}, nil
 }
 
@@ -234,13 +304,17 @@ func transactionToProto(tx *externalapi.DomainTransaction) *protoserialization.T
 	}
 
 	return &protoserialization.TransactionMessage{
-		Version:      uint32(tx.Version),
-		Inputs:       protoInputs,
-		Outputs:      protoOutputs,
-		LockTime:     tx.LockTime,
-		SubnetworkId: &protoserialization.SubnetworkId{Bytes: tx.SubnetworkID[:]},
-		Gas:          tx.Gas,
-		Payload:      tx.Payload,
+		Version:       uint32(tx.Version),
+		Inputs:        protoInputs,
+		Outputs:       protoOutputs,
+		LockTime:      tx.LockTime,
+		SubnetworkId:  &protoserialization.SubnetworkId{Bytes: tx.SubnetworkID[:]},
+		Gas:           tx.Gas,
+		Payload:       tx.Payload,
+		VprogVersion:  uint32(tx.VProgVersion),
+		VprogCode:     tx.VProgCode,
+		VprogData:     tx.VProgData,
+		VprogGasLimit: tx.VProgGasLimit,
 	}
 }
 
@@ -34,7 +34,32 @@ func CreateUnsignedTransaction(
 	selectedUTXOs []*UTXO) ([]byte, error) {
 
 	sortPublicKeys(extendedPublicKeys)
-	unsignedTransaction, err := createUnsignedTransaction(extendedPublicKeys, minimumSignatures, payments, selectedUTXOs)
+	unsignedTransaction, err := createUnsignedTransaction(extendedPublicKeys, minimumSignatures, payments, selectedUTXOs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return serialization.SerializePartiallySignedTransaction(unsignedTransaction)
+}
+
+// VProgCall contains the vprog details of a transaction that deploys or calls a vprog
+type VProgCall struct {
+	Code     []byte
+	Data     []byte
+	GasLimit uint64
+}
+
+// CreateUnsignedVProgTransaction creates an unsigned transaction that executes the given vprog call
+// once it is accepted
+func CreateUnsignedVProgTransaction(
+	extendedPublicKeys []string,
+	minimumSignatures uint32,
+	payments []*Payment,
+	selectedUTXOs []*UTXO,
+	vprogCall *VProgCall) ([]byte, error) {
+
+	sortPublicKeys(extendedPublicKeys)
+	unsignedTransaction, err := createUnsignedTransaction(extendedPublicKeys, minimumSignatures, payments, selectedUTXOs, vprogCall)
 	if err != nil {
 		return nil, err
 	}
@@ -98,7 +123,8 @@ func createUnsignedTransaction(
 	extendedPublicKeys []string,
 	minimumSignatures uint32,
 	payments []*Payment,
-	selectedUTXOs []*UTXO) (*serialization.PartiallySignedTransaction, error) {
+	selectedUTXOs []*UTXO,
+	vprogCall *VProgCall) (*serialization.PartiallySignedTransaction, error) {
 
 	inputs := make([]*externalapi.DomainTransactionInput, len(selectedUTXOs))
 	partiallySignedInputs := make([]*serialization.PartiallySignedInput, len(selectedUTXOs))
@@ -110,13 +136,24 @@ func createUnsignedTransaction(
 				return nil, err
 			}
 
+			fingerprint, err := extendedKey.Fingerprint()
+			if err != nil {
+				return nil, err
+			}
+
 			derivedKey, err := extendedKey.DeriveFromPath(utxo.DerivationPath)
 			if err != nil {
 				return nil, err
 			}
 
+			// The wallet only knows the account keys of its cosigners, so the key
+			// origin is relative to the account key rather than to the master key
 			emptyPubKeySignaturePairs[i] = &serialization.PubKeySignaturePair{
 				ExtendedPublicKey: derivedKey.String(),
+				KeyOrigin: &serialization.KeyOrigin{
+					Fingerprint:    fingerprint,
+					DerivationPath: utxo.DerivationPath,
+				},
 			}
 		}
 
@@ -154,6 +191,12 @@ func createUnsignedTransaction(
 		Gas:          0,
 		Payload:      nil,
 	}
+	if vprogCall != nil {
+		domainTransaction.VProgVersion = constants.VProgVersion
+		domainTransaction.VProgCode = vprogCall.Code
+		domainTransaction.VProgData = vprogCall.Data
+		domainTransaction.VProgGasLimit = vprogCall.GasLimit
+	}
 
 	return &serialization.PartiallySignedTransaction{
 		Tx:                    domainTransaction,
@@ -260,22 +303,3 @@ func partiallySignedInputMultisigRedeemScript(input *serialization.PartiallySign
 	return multiSigRedeemScript(extendedPublicKeys, input.MinimumSignatures, "m", ecdsa)
 }
 
-func CreateTransaction(
-    params *WalletParams,
-    payments []*Payment,
-    vprogCode []byte,
-    vprogData []byte,
-    vprogGasLimit uint64,
-) (*externalapi.DomainTransaction, error) {
-    
-    // Existing transaction creation...
-    
-    
-    if len(vprogCode) > 0 {
-        tx.VProgCode = vprogCode
-        tx.VProgData = vprogData
-        tx.VProgGasLimit = vprogGasLimit
-    }
-    
-    return tx, nil
-}
@@ -19,10 +19,16 @@ func main() {
 		err = sign(config.(*signConfig))
 	case broadcastSubCmd:
 		err = broadcast(config.(*broadcastConfig))
+	case validateTransactionSubCmd:
+		err = validateTransaction(config.(*validateTransactionConfig))
 	case parseSubCmd:
 		err = parse(config.(*parseConfig))
 	case showAddressesSubCmd:
 		err = showAddresses(config.(*showAddressesConfig))
+	case historySubCmd:
+		err = history(config.(*historyConfig))
+	case labelTransactionSubCmd:
+		err = labelTransaction(config.(*labelTransactionConfig))
 	case newAddressSubCmd:
 		err = newAddress(config.(*newAddressConfig))
 	case dumpUnencryptedDataSubCmd:
@@ -31,6 +37,10 @@ func main() {
 		err = startDaemon(config.(*startDaemonConfig))
 	case sweepSubCmd:
 		err = sweep(config.(*sweepConfig))
+	case deployVProgSubCmd:
+		err = deployVProg(config.(*deployVProgConfig))
+	case callVProgSubCmd:
+		err = callVProg(config.(*callVProgConfig))
 	default:
 		err = errors.Errorf("Unknown sub-command '%s'\n", subCmd)
 	}
@@ -7,6 +7,7 @@ import (
 	"github.com/zuanet/zuad/domain/consensus/utils/consensushashing"
 	"github.com/zuanet/zuad/domain/consensus/utils/constants"
 	"github.com/zuanet/zuad/domain/consensus/utils/txscript"
+	"github.com/zuanet/zuad/domain/consensus/vprog"
 	"github.com/pkg/errors"
 	"io/ioutil"
 	"strings"
@@ -78,6 +79,12 @@ func parse(conf *parseConfig) error {
 		}
 		fmt.Println()
 
+		if partiallySignedTransaction.Tx.VProgVersion > 0 {
+			fmt.Printf("VProg: \tProgram ID: %s \tData: %x \tGas limit: %d\n\n",
+				vprog.ProgramID(partiallySignedTransaction.Tx.VProgCode), partiallySignedTransaction.Tx.VProgData,
+				partiallySignedTransaction.Tx.VProgGasLimit)
+		}
+
 		fmt.Printf("Fee:\t%d Sompi\n\n", allInputSompi-allOutputSompi)
 	}
 
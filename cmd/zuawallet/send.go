@@ -20,6 +20,11 @@ func send(conf *sendConfig) error {
 		return err
 	}
 
+	if keysFile.IsWatchOnly() {
+		return errors.Errorf("Cannot use 'send' command for a watch-only wallet. Use 'create-unsigned-transaction' " +
+			"and sign the result offline with 'sign'")
+	}
+
 	if len(keysFile.ExtendedPublicKeys) > len(keysFile.EncryptedMnemonics) {
 		return errors.Errorf("Cannot use 'send' command for multisig wallet without all of the keys")
 	}
@@ -38,6 +43,15 @@ func send(conf *sendConfig) error {
 		sendAmountSompi = uint64(conf.SendAmount * constants.SompiPerZua)
 	}
 
+	includeOutpoints, err := parseOutpoints(conf.IncludeUTXOs)
+	if err != nil {
+		return err
+	}
+	excludeOutpoints, err := parseOutpoints(conf.ExcludeUTXOs)
+	if err != nil {
+		return err
+	}
+
 	createUnsignedTransactionsResponse, err :=
 		daemonClient.CreateUnsignedTransactions(ctx, &pb.CreateUnsignedTransactionsRequest{
 			From:                     conf.FromAddresses,
@@ -45,6 +59,10 @@ func send(conf *sendConfig) error {
 			Amount:                   sendAmountSompi,
 			IsSendAll:                conf.IsSendAll,
 			UseExistingChangeAddress: conf.UseExistingChangeAddress,
+			FeePriority:              conf.FeePriority,
+			CoinSelection:            conf.CoinSelection,
+			IncludeOutpoints:         includeOutpoints,
+			ExcludeOutpoints:         excludeOutpoints,
 		})
 	if err != nil {
 		return err
@@ -6,6 +6,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/zuanet/zuad/cmd/zuawallet/externalsigner"
 	"github.com/zuanet/zuad/cmd/zuawallet/keys"
 	"github.com/zuanet/zuad/cmd/zuawallet/libzuawallet"
 	"github.com/pkg/errors"
@@ -24,14 +25,6 @@ func sign(conf *signConfig) error {
 		return err
 	}
 
-	if len(conf.Password) == 0 {
-		conf.Password = keys.GetPassword("Password:")
-	}
-	privateKeys, err := keysFile.DecryptMnemonics(conf.Password)
-	if err != nil {
-		return err
-	}
-
 	transactionsHex := conf.Transaction
 	if conf.TransactionFile != "" {
 		transactionHexBytes, err := ioutil.ReadFile(conf.TransactionFile)
@@ -45,13 +38,14 @@ func sign(conf *signConfig) error {
 		return err
 	}
 
-	updatedPartiallySignedTransactions := make([][]byte, len(partiallySignedTransactions))
-	for i, partiallySignedTransaction := range partiallySignedTransactions {
-		updatedPartiallySignedTransactions[i], err =
-			libzuawallet.Sign(conf.NetParams(), privateKeys, partiallySignedTransaction, keysFile.ECDSA)
-		if err != nil {
-			return err
-		}
+	var updatedPartiallySignedTransactions [][]byte
+	if conf.ExternalSigner != "" {
+		updatedPartiallySignedTransactions, err = signWithExternalSigner(conf, keysFile, partiallySignedTransactions)
+	} else {
+		updatedPartiallySignedTransactions, err = signWithKeysFile(conf, keysFile, partiallySignedTransactions)
+	}
+	if err != nil {
+		return err
 	}
 
 	areAllTransactionsFullySigned := true
@@ -75,3 +69,36 @@ func sign(conf *signConfig) error {
 	fmt.Println(encodeTransactionsToHex(updatedPartiallySignedTransactions))
 	return nil
 }
+
+func signWithKeysFile(conf *signConfig, keysFile *keys.File, partiallySignedTransactions [][]byte) ([][]byte, error) {
+	if keysFile.IsWatchOnly() {
+		return nil, errors.Errorf("Cannot sign with a watch-only wallet. Use a keys file that holds the private keys, " +
+			"or sign with --external-signer")
+	}
+
+	if len(conf.Password) == 0 {
+		conf.Password = keys.GetPassword("Password:")
+	}
+	privateKeys, err := keysFile.DecryptMnemonics(conf.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedPartiallySignedTransactions := make([][]byte, len(partiallySignedTransactions))
+	for i, partiallySignedTransaction := range partiallySignedTransactions {
+		updatedPartiallySignedTransactions[i], err =
+			libzuawallet.Sign(conf.NetParams(), privateKeys, partiallySignedTransaction, keysFile.ECDSA)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return updatedPartiallySignedTransactions, nil
+}
+
+func signWithExternalSigner(conf *signConfig, keysFile *keys.File, partiallySignedTransactions [][]byte) ([][]byte, error) {
+	signer, err := externalsigner.New(conf.ExternalSigner)
+	if err != nil {
+		return nil, err
+	}
+	return signer.Sign(conf.NetParams(), keysFile.ECDSA, partiallySignedTransactions)
+}
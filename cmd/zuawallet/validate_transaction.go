@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/zuanet/zuad/cmd/zuawallet/daemon/client"
+	"github.com/zuanet/zuad/cmd/zuawallet/daemon/pb"
+	"github.com/zuanet/zuad/cmd/zuawallet/utils"
+	"github.com/pkg/errors"
+)
+
+func validateTransaction(conf *validateTransactionConfig) error {
+	daemonClient, tearDown, err := client.Connect(conf.DaemonAddress)
+	if err != nil {
+		return err
+	}
+	defer tearDown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), daemonTimeout)
+	defer cancel()
+
+	if conf.Transactions == "" && conf.TransactionsFile == "" {
+		return errors.Errorf("Either --transaction or --transaction-file is required")
+	}
+	if conf.Transactions != "" && conf.TransactionsFile != "" {
+		return errors.Errorf("Both --transaction and --transaction-file cannot be passed at the same time")
+	}
+
+	transactionsHex := conf.Transactions
+	if conf.TransactionsFile != "" {
+		transactionHexBytes, err := ioutil.ReadFile(conf.TransactionsFile)
+		if err != nil {
+			return errors.Wrapf(err, "Could not read hex from %s", conf.TransactionsFile)
+		}
+		transactionsHex = strings.TrimSpace(string(transactionHexBytes))
+	}
+
+	transactions, err := decodeTransactionsFromHex(transactionsHex)
+	if err != nil {
+		return err
+	}
+
+	response, err := daemonClient.ValidateTransactions(ctx, &pb.ValidateTransactionsRequest{Transactions: transactions})
+	if err != nil {
+		return err
+	}
+
+	for i, result := range response.Results {
+		fmt.Printf("Transaction #%d: %s\n", i+1, result.TxID)
+		fmt.Printf("\tMass:\t\t%d grams\n", result.Mass)
+		if result.HasFee {
+			fmt.Printf("\tFee:\t\t%s ZUA\n", utils.FormatZua(result.Fee))
+			fmt.Printf("\tFee rate:\t%.4f sompi/gram\n", result.FeeRate)
+		} else {
+			fmt.Println("\tFee:\t\tunknown (some inputs were not found)")
+		}
+		fmt.Printf("\tStandard:\t%t\n", result.IsStandard)
+		if result.IsOrphan {
+			fmt.Println("\tOrphan:\t\ttrue")
+		}
+		if result.IsAccepted {
+			fmt.Println("\tResult:\t\tthe transaction would be accepted")
+		} else {
+			fmt.Printf("\tResult:\t\tthe transaction would be rejected with %s: %s\n",
+				result.RejectCode, result.RejectReason)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/zuanet/zuad/cmd/zuawallet/daemon/client"
+	"github.com/zuanet/zuad/cmd/zuawallet/daemon/pb"
+	"github.com/zuanet/zuad/cmd/zuawallet/keys"
+	"github.com/zuanet/zuad/cmd/zuawallet/libzuawallet"
+	"github.com/pkg/errors"
+)
+
+func deployVProg(conf *deployVProgConfig) error {
+	codeHex := conf.Code
+	if conf.CodeFile != "" {
+		codeHexBytes, err := ioutil.ReadFile(conf.CodeFile)
+		if err != nil {
+			return errors.Wrapf(err, "Could not read hex from %s", conf.CodeFile)
+		}
+		codeHex = strings.TrimSpace(string(codeHexBytes))
+	}
+	code, err := hex.DecodeString(codeHex)
+	if err != nil {
+		return errors.Wrap(err, "Could not decode the vprog code")
+	}
+
+	return sendVProgTransaction(&conf.vprogTransactionConfig, &pb.CreateUnsignedVProgTransactionRequest{Code: code})
+}
+
+func callVProg(conf *callVProgConfig) error {
+	return sendVProgTransaction(&conf.vprogTransactionConfig, &pb.CreateUnsignedVProgTransactionRequest{ProgramId: conf.ProgramID})
+}
+
+func sendVProgTransaction(conf *vprogTransactionConfig, request *pb.CreateUnsignedVProgTransactionRequest) error {
+	data, err := hex.DecodeString(conf.Data)
+	if err != nil {
+		return errors.Wrap(err, "Could not decode the vprog data")
+	}
+	request.Data = data
+	request.GasLimit = conf.GasLimit
+	request.From = conf.FromAddresses
+	request.UseExistingChangeAddress = conf.UseExistingChangeAddress
+	if request.GasLimit == 0 {
+		request.GasLimit = conf.NetParams().MaxVProgGasLimit
+	}
+
+	keysFile, err := keys.ReadKeysFile(conf.NetParams(), conf.KeysFile)
+	if err != nil {
+		return err
+	}
+
+	if keysFile.IsWatchOnly() {
+		return errors.Errorf("Cannot send vprog transactions from a watch-only wallet")
+	}
+
+	if len(keysFile.ExtendedPublicKeys) > len(keysFile.EncryptedMnemonics) {
+		return errors.Errorf("Cannot send vprog transactions from a multisig wallet without all of the keys")
+	}
+
+	daemonClient, tearDown, err := client.Connect(conf.DaemonAddress)
+	if err != nil {
+		return err
+	}
+	defer tearDown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), daemonTimeout)
+	defer cancel()
+
+	response, err := daemonClient.CreateUnsignedVProgTransaction(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	if len(conf.Password) == 0 {
+		conf.Password = keys.GetPassword("Password:")
+	}
+	mnemonics, err := keysFile.DecryptMnemonics(conf.Password)
+	if err != nil {
+		if strings.Contains(err.Error(), "message authentication failed") {
+			fmt.Fprintf(os.Stderr, "Password decryption failed. Sometimes this is a result of not "+
+				"specifying the same keys file used by the wallet daemon process.\n")
+		}
+		return err
+	}
+
+	signedTransaction, err := libzuawallet.Sign(conf.NetParams(), mnemonics, response.UnsignedTransaction, keysFile.ECDSA)
+	if err != nil {
+		return err
+	}
+
+	// Since we waited for user input when getting the password, which could take unbound amount of time -
+	// create a new context for broadcast, to reset the timeout.
+	broadcastCtx, broadcastCancel := context.WithTimeout(context.Background(), daemonTimeout)
+	defer broadcastCancel()
+
+	broadcastResponse, err := daemonClient.Broadcast(broadcastCtx,
+		&pb.BroadcastRequest{Transactions: [][]byte{signedTransaction}})
+	if err != nil {
+		return err
+	}
+	fmt.Println("Transaction was sent successfully")
+	fmt.Printf("Program ID: %s\n", response.ProgramId)
+	fmt.Printf("Transaction ID: %s\n", broadcastResponse.TxIDs[0])
+
+	if conf.Verbose {
+		fmt.Println("Serialized Transaction (can be parsed via the `parse` command or resent via `broadcast`): ")
+		fmt.Printf("\t%x\n\n", signedTransaction)
+	}
+
+	return nil
+}
@@ -59,6 +59,7 @@ type consensus struct {
 	headersSelectedChainStore           model.HeadersSelectedChainStore
 	daaBlocksStore                      model.DAABlocksStore
 	blocksWithTrustedDataDAAWindowStore model.BlocksWithTrustedDataDAAWindowStore
+	vprogStateStore                     model.VProgStateStore
 
 	consensusEventsChan chan externalapi.ConsensusEvent
 	virtualNotUpdated   bool
@@ -588,6 +589,28 @@ func (s *consensus) GetPruningPointUTXOs(expectedPruningPointHash *externalapi.D
 	return pruningPointUTXOs, nil
 }
 
+func (s *consensus) GetPruningPointVProgState(expectedPruningPointHash *externalapi.DomainHash,
+	fromEntry *externalapi.VProgStateEntry, limit int) ([]*externalapi.VProgStateEntry, error) {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stagingArea := model.NewStagingArea()
+
+	pruningPointHash, err := s.pruningStore.PruningPoint(s.databaseContext, stagingArea)
+	if err != nil {
+		return nil, err
+	}
+
+	if !expectedPruningPointHash.Equal(pruningPointHash) {
+		return nil, errors.Wrapf(ruleerrors.ErrWrongPruningPointHash, "expected pruning point %s but got %s",
+			expectedPruningPointHash,
+			pruningPointHash)
+	}
+
+	return s.vprogStateStore.PruningPointState(s.databaseContext, fromEntry, limit)
+}
+
 func (s *consensus) GetVirtualUTXOs(expectedVirtualParents []*externalapi.DomainHash,
 	fromOutpoint *externalapi.DomainOutpoint, limit int) ([]*externalapi.OutpointAndUTXOEntryPair, error) {
 
@@ -666,6 +689,13 @@ func (s *consensus) AppendImportedPruningPointUTXOs(outpointAndUTXOEntryPairs []
 	return s.pruningManager.AppendImportedPruningPointUTXOs(outpointAndUTXOEntryPairs)
 }
 
+func (s *consensus) AppendImportedPruningPointVProgState(entries []*externalapi.VProgStateEntry) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.pruningManager.AppendImportedPruningPointVProgState(entries)
+}
+
 func (s *consensus) ValidateAndInsertImportedPruningPoint(newPruningPoint *externalapi.DomainHash) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -1146,3 +1176,42 @@ func (s *consensus) isNearlySyncedNoLock() (bool, error) {
 		virtualSelectedParentHeader.TimeInMilliseconds())
 	return false, nil
 }
+
+func (s *consensus) SimulateVProgCall(code []byte, input []byte, gasLimit uint64) (*externalapi.VProgExecutionResult, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stagingArea := model.NewStagingArea()
+
+	return s.consensusStateManager.SimulateVProgCall(stagingArea, code, input, gasLimit)
+}
+
+func (s *consensus) GetVProgCode(programID *externalapi.DomainHash) (code []byte, found bool, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stagingArea := model.NewStagingArea()
+
+	hasCode, err := s.vprogStateStore.HasCode(s.databaseContext, stagingArea, programID)
+	if err != nil {
+		return nil, false, err
+	}
+	if !hasCode {
+		return nil, false, nil
+	}
+
+	code, err = s.vprogStateStore.Code(s.databaseContext, stagingArea, programID)
+	if err != nil {
+		return nil, false, err
+	}
+	return code, true, nil
+}
+
+func (s *consensus) GetVProgStorage(programID *externalapi.DomainHash) (map[string][]byte, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stagingArea := model.NewStagingArea()
+
+	return s.consensusStateManager.VirtualVProgState(stagingArea, programID)
+}
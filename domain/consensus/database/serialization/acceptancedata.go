@@ -24,6 +24,7 @@ func DomainAcceptanceDataToDbAcceptanceData(domainAcceptanceData externalapi.Acc
 				Fee:                         transactionAcceptanceData.Fee,
 				IsAccepted:                  transactionAcceptanceData.IsAccepted,
 				TransactionInputUtxoEntries: dbTransactionInputUTXOEntries,
+				VprogResult:                 DomainVProgExecutionResultToDbVProgExecutionResult(transactionAcceptanceData.VProgResult),
 			}
 		}
 
@@ -67,11 +68,17 @@ func DbAcceptanceDataToDomainAcceptanceData(dbAcceptanceData *DbAcceptanceData)
 				domainTransaction.Inputs[k].UTXOEntry = domainTransactionInputUTXOEntry
 			}
 
+			vprogResult, err := DbVProgExecutionResultToDomainVProgExecutionResult(dbTransactionAcceptanceData.VprogResult)
+			if err != nil {
+				return nil, err
+			}
+
 			domainTransactionAcceptanceData[j] = &externalapi.TransactionAcceptanceData{
 				Transaction:                 domainTransaction,
 				Fee:                         dbTransactionAcceptanceData.Fee,
 				IsAccepted:                  dbTransactionAcceptanceData.IsAccepted,
 				TransactionInputUTXOEntries: domainTransactionInputUTXOEntries,
+				VProgResult:                 vprogResult,
 			}
 		}
 
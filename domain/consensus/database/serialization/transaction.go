@@ -29,13 +29,17 @@ func DomainTransactionToDbTransaction(domainTransaction *externalapi.DomainTrans
 	}
 
 	return &DbTransaction{
-		Version:      uint32(domainTransaction.Version),
-		Inputs:       dbInputs,
-		Outputs:      dbOutputs,
-		LockTime:     domainTransaction.LockTime,
-		SubnetworkID: DomainSubnetworkIDToDbSubnetworkID(&domainTransaction.SubnetworkID),
-		Gas:          domainTransaction.Gas,
-		Payload:      domainTransaction.Payload,
+		Version:       uint32(domainTransaction.Version),
+		Inputs:        dbInputs,
+		Outputs:       dbOutputs,
+		LockTime:      domainTransaction.LockTime,
+		SubnetworkID:  DomainSubnetworkIDToDbSubnetworkID(&domainTransaction.SubnetworkID),
+		Gas:           domainTransaction.Gas,
+		Payload:       domainTransaction.Payload,
+		VprogVersion:  uint32(domainTransaction.VProgVersion),
+		VprogCode:     domainTransaction.VProgCode,
+		VprogData:     domainTransaction.VProgData,
+		VprogGasLimit: domainTransaction.VProgGasLimit,
 	}
 }
 
@@ -75,13 +79,20 @@ func DbTransactionToDomainTransaction(dbTransaction *DbTransaction) (*externalap
 	if dbTransaction.Version > math.MaxUint16 {
 		return nil, errors.Errorf("The transaction version is bigger then uint16.")
 	}
+	if dbTransaction.VprogVersion > math.MaxUint8 {
+		return nil, errors.Errorf("The transaction vprog version is bigger then uint8.")
+	}
 	return &externalapi.DomainTransaction{
-		Version:      uint16(dbTransaction.Version),
-		Inputs:       domainInputs,
-		Outputs:      domainOutputs,
-		LockTime:     dbTransaction.LockTime,
-		SubnetworkID: *domainSubnetworkID,
-		Gas:          dbTransaction.Gas,
-		Payload:      dbTransaction.Payload,
+		Version:       uint16(dbTransaction.Version),
+		Inputs:        domainInputs,
+		Outputs:       domainOutputs,
+		LockTime:      dbTransaction.LockTime,
+		SubnetworkID:  *domainSubnetworkID,
+		Gas:           dbTransaction.Gas,
+		Payload:       dbTransaction.Payload,
+		VProgVersion:  byte(dbTransaction.VprogVersion),
+		VProgCode:     dbTransaction.VprogCode,
+		VProgData:     dbTransaction.VprogData,
+		VProgGasLimit: dbTransaction.VprogGasLimit,
 	}, nil
 }
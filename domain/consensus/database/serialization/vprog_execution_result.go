@@ -0,0 +1,70 @@
+package serialization
+
+import (
+	"sort"
+
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+)
+
+// DomainVProgExecutionResultToDbVProgExecutionResult converts VProgExecutionResult to DbVProgExecutionResult
+func DomainVProgExecutionResultToDbVProgExecutionResult(
+	result *externalapi.VProgExecutionResult) *DbVProgExecutionResult {
+
+	if result == nil {
+		return nil
+	}
+
+	keys := make([]string, 0, len(result.StateChanges))
+	for key := range result.StateChanges {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	dbStateChanges := make([]*DbVProgStateChange, len(keys))
+	for i, key := range keys {
+		dbStateChanges[i] = &DbVProgStateChange{
+			Key:   []byte(key),
+			Value: result.StateChanges[key],
+		}
+	}
+
+	return &DbVProgExecutionResult{
+		ProgramID:    DomainHashToDbHash(result.ProgramID),
+		Success:      result.Success,
+		GasUsed:      result.GasUsed,
+		ReturnData:   result.ReturnData,
+		StateChanges: dbStateChanges,
+		ErrorCode:    result.ErrorCode,
+	}
+}
+
+// DbVProgExecutionResultToDomainVProgExecutionResult converts DbVProgExecutionResult to VProgExecutionResult
+func DbVProgExecutionResultToDomainVProgExecutionResult(
+	dbResult *DbVProgExecutionResult) (*externalapi.VProgExecutionResult, error) {
+
+	if dbResult == nil {
+		return nil, nil
+	}
+
+	programID, err := DbHashToDomainHash(dbResult.ProgramID)
+	if err != nil {
+		return nil, err
+	}
+
+	var stateChanges map[string][]byte
+	if dbResult.Success {
+		stateChanges = make(map[string][]byte, len(dbResult.StateChanges))
+		for _, dbStateChange := range dbResult.StateChanges {
+			stateChanges[string(dbStateChange.Key)] = dbStateChange.Value
+		}
+	}
+
+	return &externalapi.VProgExecutionResult{
+		ProgramID:    programID,
+		Success:      dbResult.Success,
+		GasUsed:      dbResult.GasUsed,
+		ReturnData:   dbResult.ReturnData,
+		StateChanges: stateChanges,
+		ErrorCode:    dbResult.ErrorCode,
+	}, nil
+}
@@ -0,0 +1,46 @@
+package serialization
+
+import (
+	"github.com/zuanet/zuad/domain/consensus/model"
+)
+
+// VProgStateDiffToDBVProgStateDiff converts VProgStateDiff to DbVProgStateDiff
+func VProgStateDiffToDBVProgStateDiff(diff model.VProgStateDiff) *DbVProgStateDiff {
+	keys := diff.SortedKeys()
+	entries := make([]*DbVProgStateDiffEntry, len(keys))
+	for i, key := range keys {
+		programID := key.ProgramID
+		change := diff[key]
+		entries[i] = &DbVProgStateDiffEntry{
+			ProgramID: DomainHashToDbHash(&programID),
+			Key:       []byte(key.Key),
+			OldValue:  change.OldValue,
+			NewValue:  change.NewValue,
+		}
+	}
+
+	return &DbVProgStateDiff{
+		Entries: entries,
+	}
+}
+
+// DBVProgStateDiffToVProgStateDiff converts DbVProgStateDiff to VProgStateDiff
+func DBVProgStateDiffToVProgStateDiff(dbDiff *DbVProgStateDiff) (model.VProgStateDiff, error) {
+	diff := make(model.VProgStateDiff, len(dbDiff.Entries))
+	for _, entry := range dbDiff.Entries {
+		programID, err := DbHashToDomainHash(entry.ProgramID)
+		if err != nil {
+			return nil, err
+		}
+		key := model.VProgStateKey{
+			ProgramID: *programID,
+			Key:       string(entry.Key),
+		}
+		diff[key] = &model.VProgStateChange{
+			OldValue: entry.OldValue,
+			NewValue: entry.NewValue,
+		}
+	}
+
+	return diff, nil
+}
@@ -0,0 +1,183 @@
+package vprogstatestore
+
+import (
+	"github.com/zuanet/zuad/domain/consensus/model"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
+)
+
+// UpdatePruningPointState applies the given diff on the vprog state of the pruning point.
+// The state of the pruning point is kept separately from the state held by the store,
+// so that it can be sent to syncing peers along with the pruning point UTXO set.
+func (vss *vprogStateStore) UpdatePruningPointState(dbContext model.DBWriter, diff model.VProgStateDiff) error {
+	for key, change := range diff {
+		dbKey := vss.pruningPointStateBucket.Bucket(key.ProgramID.ByteSlice()).Key([]byte(key.Key))
+		if len(change.NewValue) == 0 {
+			err := dbContext.Delete(dbKey)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		err := dbContext.Put(dbKey, change.NewValue)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PruningPointState returns up to limit entries of the vprog state of the pruning point,
+// starting after fromEntry. Only the ProgramID and Key of fromEntry are used.
+func (vss *vprogStateStore) PruningPointState(dbContext model.DBReader,
+	fromEntry *externalapi.VProgStateEntry, limit int) ([]*externalapi.VProgStateEntry, error) {
+
+	cursor, err := dbContext.Cursor(vss.pruningPointStateBucket)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	if fromEntry != nil {
+		seekKey := vss.pruningPointStateBucket.Bucket(fromEntry.ProgramID.ByteSlice()).Key(fromEntry.Key)
+		err = cursor.Seek(seekKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	entries := make([]*externalapi.VProgStateEntry, 0, limit)
+	for len(entries) < limit && cursor.Next() {
+		entry, err := readStateEntry(cursor)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// ClearImportedPruningPointState removes all the vprog state entries that were imported
+// along with a pruning point UTXO set
+func (vss *vprogStateStore) ClearImportedPruningPointState(dbContext model.DBWriter) error {
+	return deleteBucket(dbContext, vss.importedPruningPointStateBucket)
+}
+
+// AppendImportedPruningPointState adds the given entries to the imported vprog state
+// of the pruning point
+func (vss *vprogStateStore) AppendImportedPruningPointState(dbTx model.DBTransaction,
+	entries []*externalapi.VProgStateEntry) error {
+
+	for _, entry := range entries {
+		if len(entry.Value) == 0 {
+			return errors.Errorf("the imported vprog state entry %x of program %s is empty",
+				entry.Key, entry.ProgramID)
+		}
+		key := vss.importedPruningPointStateBucket.Bucket(entry.ProgramID.ByteSlice()).Key(entry.Key)
+		err := dbTx.Put(key, entry.Value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ImportPruningPointState replaces both the state held by the store and the state of the
+// pruning point with the vprog state that was imported along with the pruning point UTXO
+// set. It must be called only once the imported state was validated against the UTXO
+// commitment of the pruning point.
+func (vss *vprogStateStore) ImportPruningPointState(dbContext model.DBWriter,
+	pruningPointHash *externalapi.DomainHash) error {
+
+	err := deleteBucket(dbContext, vss.stateBucket)
+	if err != nil {
+		return err
+	}
+	err = deleteBucket(dbContext, vss.pruningPointStateBucket)
+	if err != nil {
+		return err
+	}
+
+	cursor, err := dbContext.Cursor(vss.importedPruningPointStateBucket)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	for ok := cursor.First(); ok; ok = cursor.Next() {
+		importedKey, err := cursor.Key()
+		if err != nil {
+			return err
+		}
+		value, err := cursor.Value()
+		if err != nil {
+			return err
+		}
+		err = dbContext.Put(vss.stateBucket.Key(importedKey.Suffix()), value)
+		if err != nil {
+			return err
+		}
+		err = dbContext.Put(vss.pruningPointStateBucket.Key(importedKey.Suffix()), value)
+		if err != nil {
+			return err
+		}
+	}
+
+	stateBlockHashBytes, err := vss.serializeHash(pruningPointHash)
+	if err != nil {
+		return err
+	}
+	err = dbContext.Put(vss.stateBlockHashKey, stateBlockHashBytes)
+	if err != nil {
+		return err
+	}
+	vss.stateBlockHash = pruningPointHash
+	return nil
+}
+
+// readStateEntry reads the entry at the current position of a cursor over one of the
+// state buckets, whose keys are made of the program ID followed by the entry key
+func readStateEntry(cursor model.DBCursor) (*externalapi.VProgStateEntry, error) {
+	key, err := cursor.Key()
+	if err != nil {
+		return nil, err
+	}
+	value, err := cursor.Value()
+	if err != nil {
+		return nil, err
+	}
+
+	suffix := key.Suffix()
+	// The program ID is followed by the bucket separator
+	if len(suffix) < externalapi.DomainHashSize+1 {
+		return nil, errors.Errorf("malformed vprog state key %x", suffix)
+	}
+	programID, err := externalapi.NewDomainHashFromByteSlice(suffix[:externalapi.DomainHashSize])
+	if err != nil {
+		return nil, err
+	}
+	return &externalapi.VProgStateEntry{
+		ProgramID: programID,
+		Key:       append([]byte{}, suffix[externalapi.DomainHashSize+1:]...),
+		Value:     append([]byte{}, value...),
+	}, nil
+}
+
+func deleteBucket(dbContext model.DBWriter, bucket model.DBBucket) error {
+	cursor, err := dbContext.Cursor(bucket)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	for ok := cursor.First(); ok; ok = cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return err
+		}
+		err = dbContext.Delete(key)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package vprogstatestore
+
+import (
+	"github.com/zuanet/zuad/domain/consensus/model"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+)
+
+type vprogStateStagingShard struct {
+	store          *vprogStateStore
+	diffToAdd      map[externalapi.DomainHash]model.VProgStateDiff
+	diffToDelete   map[externalapi.DomainHash]struct{}
+	stateBlockHash *externalapi.DomainHash
+	stateDiff      model.VProgStateDiff
+	codeToAdd      map[externalapi.DomainHash][]byte
+}
+
+func (vss *vprogStateStore) stagingShard(stagingArea *model.StagingArea) *vprogStateStagingShard {
+	return stagingArea.GetOrCreateShard(vss.shardID, func() model.StagingShard {
+		return &vprogStateStagingShard{
+			store:          vss,
+			diffToAdd:      make(map[externalapi.DomainHash]model.VProgStateDiff),
+			diffToDelete:   make(map[externalapi.DomainHash]struct{}),
+			stateBlockHash: nil,
+			stateDiff:      model.NewVProgStateDiff(),
+			codeToAdd:      make(map[externalapi.DomainHash][]byte),
+		}
+	}).(*vprogStateStagingShard)
+}
+
+func (vsss *vprogStateStagingShard) Commit(dbTx model.DBTransaction) error {
+	for hash, diff := range vsss.diffToAdd {
+		diffBytes, err := vsss.store.serializeDiff(diff)
+		if err != nil {
+			return err
+		}
+		err = dbTx.Put(vsss.store.diffHashAsKey(&hash), diffBytes)
+		if err != nil {
+			return err
+		}
+		vsss.store.diffCache.Add(&hash, diff)
+	}
+
+	for hash := range vsss.diffToDelete {
+		err := dbTx.Delete(vsss.store.diffHashAsKey(&hash))
+		if err != nil {
+			return err
+		}
+		vsss.store.diffCache.Remove(&hash)
+	}
+
+	for programID, code := range vsss.codeToAdd {
+		err := dbTx.Put(vsss.store.codeKey(&programID), code)
+		if err != nil {
+			return err
+		}
+	}
+
+	return vsss.commitState(dbTx)
+}
+
+func (vsss *vprogStateStagingShard) commitState(dbTx model.DBTransaction) error {
+	if vsss.stateBlockHash == nil {
+		return nil
+	}
+
+	for key, change := range vsss.stateDiff {
+		dbKey := vsss.store.stateKey(key)
+		if len(change.NewValue) == 0 {
+			err := dbTx.Delete(dbKey)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		err := dbTx.Put(dbKey, change.NewValue)
+		if err != nil {
+			return err
+		}
+	}
+
+	stateBlockHashBytes, err := vsss.store.serializeHash(vsss.stateBlockHash)
+	if err != nil {
+		return err
+	}
+	err = dbTx.Put(vsss.store.stateBlockHashKey, stateBlockHashBytes)
+	if err != nil {
+		return err
+	}
+	vsss.store.stateBlockHash = vsss.stateBlockHash
+
+	return nil
+}
+
+func (vsss *vprogStateStagingShard) isStaged() bool {
+	return len(vsss.diffToAdd) != 0 || len(vsss.diffToDelete) != 0 || vsss.stateBlockHash != nil ||
+		len(vsss.codeToAdd) != 0
+}
@@ -0,0 +1,310 @@
+package vprogstatestore
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/zuanet/zuad/domain/consensus/database"
+	"github.com/zuanet/zuad/domain/consensus/database/serialization"
+	"github.com/zuanet/zuad/domain/consensus/model"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/utils/lrucache"
+	"github.com/zuanet/zuad/util/staging"
+	"github.com/pkg/errors"
+)
+
+var diffBucketName = []byte("vprog-state-diffs")
+var stateBucketName = []byte("vprog-state")
+var stateBlockHashKeyName = []byte("vprog-state-block-hash")
+var codeBucketName = []byte("vprog-code")
+var pruningPointStateBucketName = []byte("vprog-pruning-point-state")
+var importedPruningPointStateBucketName = []byte("imported-pruning-point-vprog-state")
+
+// vprogStateStore represents a store of the state of vprog programs
+type vprogStateStore struct {
+	shardID           model.StagingShardID
+	diffCache         *lrucache.LRUCache
+	stateBlockHash    *externalapi.DomainHash
+	diffBucket        model.DBBucket
+	stateBucket       model.DBBucket
+	stateBlockHashKey model.DBKey
+	codeBucket        model.DBBucket
+
+	pruningPointStateBucket         model.DBBucket
+	importedPruningPointStateBucket model.DBBucket
+}
+
+// New instantiates a new VProgStateStore
+func New(prefixBucket model.DBBucket, cacheSize int, preallocate bool) model.VProgStateStore {
+	return &vprogStateStore{
+		shardID:           staging.GenerateShardingID(),
+		diffCache:         lrucache.New(cacheSize, preallocate),
+		diffBucket:        prefixBucket.Bucket(diffBucketName),
+		stateBucket:       prefixBucket.Bucket(stateBucketName),
+		stateBlockHashKey: prefixBucket.Key(stateBlockHashKeyName),
+		codeBucket:        prefixBucket.Bucket(codeBucketName),
+
+		pruningPointStateBucket:         prefixBucket.Bucket(pruningPointStateBucketName),
+		importedPruningPointStateBucket: prefixBucket.Bucket(importedPruningPointStateBucketName),
+	}
+}
+
+func (vss *vprogStateStore) IsStaged(stagingArea *model.StagingArea) bool {
+	return vss.stagingShard(stagingArea).isStaged()
+}
+
+// StageDiff stages the given state diff of the given block relative to its selected parent
+func (vss *vprogStateStore) StageDiff(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash,
+	diff model.VProgStateDiff) {
+
+	stagingShard := vss.stagingShard(stagingArea)
+
+	stagingShard.diffToAdd[*blockHash] = diff.Clone()
+	delete(stagingShard.diffToDelete, *blockHash)
+}
+
+// Diff gets the state diff of the given block relative to its selected parent
+func (vss *vprogStateStore) Diff(dbContext model.DBReader, stagingArea *model.StagingArea,
+	blockHash *externalapi.DomainHash) (model.VProgStateDiff, error) {
+
+	stagingShard := vss.stagingShard(stagingArea)
+
+	if diff, ok := stagingShard.diffToAdd[*blockHash]; ok {
+		return diff.Clone(), nil
+	}
+	if _, ok := stagingShard.diffToDelete[*blockHash]; ok {
+		return nil, errors.Wrapf(database.ErrNotFound, "vprog state diff of block %s is deleted", blockHash)
+	}
+
+	if diff, ok := vss.diffCache.Get(blockHash); ok {
+		return diff.(model.VProgStateDiff).Clone(), nil
+	}
+
+	diffBytes, err := dbContext.Get(vss.diffHashAsKey(blockHash))
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := vss.deserializeDiff(diffBytes)
+	if err != nil {
+		return nil, err
+	}
+	vss.diffCache.Add(blockHash, diff)
+	return diff.Clone(), nil
+}
+
+// HasDiff returns whether a state diff is stored for the given block
+func (vss *vprogStateStore) HasDiff(dbContext model.DBReader, stagingArea *model.StagingArea,
+	blockHash *externalapi.DomainHash) (bool, error) {
+
+	stagingShard := vss.stagingShard(stagingArea)
+
+	if _, ok := stagingShard.diffToAdd[*blockHash]; ok {
+		return true, nil
+	}
+	if _, ok := stagingShard.diffToDelete[*blockHash]; ok {
+		return false, nil
+	}
+
+	if vss.diffCache.Has(blockHash) {
+		return true, nil
+	}
+
+	return dbContext.Has(vss.diffHashAsKey(blockHash))
+}
+
+// DeleteDiff deletes the state diff of the given block
+func (vss *vprogStateStore) DeleteDiff(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash) {
+	stagingShard := vss.stagingShard(stagingArea)
+
+	if _, ok := stagingShard.diffToAdd[*blockHash]; ok {
+		delete(stagingShard.diffToAdd, *blockHash)
+		return
+	}
+	stagingShard.diffToDelete[*blockHash] = struct{}{}
+}
+
+// StageState moves the state to the given block by applying the given diff on top of it
+func (vss *vprogStateStore) StageState(stagingArea *model.StagingArea, stateBlockHash *externalapi.DomainHash,
+	diff model.VProgStateDiff) {
+
+	stagingShard := vss.stagingShard(stagingArea)
+
+	stagingShard.stateBlockHash = stateBlockHash
+	stagingShard.stateDiff.AddDiff(diff)
+}
+
+// StateBlockHash returns the hash of the block whose state is held by the store
+func (vss *vprogStateStore) StateBlockHash(dbContext model.DBReader, stagingArea *model.StagingArea) (
+	*externalapi.DomainHash, error) {
+
+	stagingShard := vss.stagingShard(stagingArea)
+
+	if stagingShard.stateBlockHash != nil {
+		return stagingShard.stateBlockHash, nil
+	}
+
+	if vss.stateBlockHash != nil {
+		return vss.stateBlockHash, nil
+	}
+
+	stateBlockHashBytes, err := dbContext.Get(vss.stateBlockHashKey)
+	if err != nil {
+		return nil, err
+	}
+
+	stateBlockHash, err := vss.deserializeHash(stateBlockHashBytes)
+	if err != nil {
+		return nil, err
+	}
+	vss.stateBlockHash = stateBlockHash
+	return stateBlockHash, nil
+}
+
+// HasStateBlockHash returns whether the store holds the state of any block
+func (vss *vprogStateStore) HasStateBlockHash(dbContext model.DBReader, stagingArea *model.StagingArea) (bool, error) {
+	stagingShard := vss.stagingShard(stagingArea)
+
+	if stagingShard.stateBlockHash != nil {
+		return true, nil
+	}
+
+	if vss.stateBlockHash != nil {
+		return true, nil
+	}
+
+	return dbContext.Has(vss.stateBlockHashKey)
+}
+
+// StateValue returns the value of the given entry in the state held by the store
+func (vss *vprogStateStore) StateValue(dbContext model.DBReader, stagingArea *model.StagingArea,
+	key model.VProgStateKey) (value []byte, found bool, err error) {
+
+	stagingShard := vss.stagingShard(stagingArea)
+
+	if change, ok := stagingShard.stateDiff[key]; ok {
+		return change.NewValue, len(change.NewValue) > 0, nil
+	}
+
+	value, err = dbContext.Get(vss.stateKey(key))
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// ProgramState returns all the entries of the given program in the state held by the store
+func (vss *vprogStateStore) ProgramState(dbContext model.DBReader, stagingArea *model.StagingArea,
+	programID *externalapi.DomainHash) (map[string][]byte, error) {
+
+	stagingShard := vss.stagingShard(stagingArea)
+
+	cursor, err := dbContext.Cursor(vss.programStateBucket(programID))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	programState := make(map[string][]byte)
+	for ok := cursor.First(); ok; ok = cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return nil, err
+		}
+		value, err := cursor.Value()
+		if err != nil {
+			return nil, err
+		}
+		programState[string(key.Suffix())] = append([]byte{}, value...)
+	}
+
+	for key, change := range stagingShard.stateDiff {
+		if !key.ProgramID.Equal(programID) {
+			continue
+		}
+		if len(change.NewValue) == 0 {
+			delete(programState, key.Key)
+			continue
+		}
+		programState[key.Key] = change.NewValue
+	}
+
+	return programState, nil
+}
+
+// StageCode stages the code of the given program. Since program IDs are derived from
+// the program code, the code of a program never changes and is never deleted.
+func (vss *vprogStateStore) StageCode(stagingArea *model.StagingArea, programID *externalapi.DomainHash, code []byte) {
+	stagingShard := vss.stagingShard(stagingArea)
+
+	stagingShard.codeToAdd[*programID] = code
+}
+
+// Code returns the code of the given program
+func (vss *vprogStateStore) Code(dbContext model.DBReader, stagingArea *model.StagingArea,
+	programID *externalapi.DomainHash) ([]byte, error) {
+
+	stagingShard := vss.stagingShard(stagingArea)
+
+	if code, ok := stagingShard.codeToAdd[*programID]; ok {
+		return code, nil
+	}
+
+	return dbContext.Get(vss.codeKey(programID))
+}
+
+// HasCode returns whether the code of the given program is stored
+func (vss *vprogStateStore) HasCode(dbContext model.DBReader, stagingArea *model.StagingArea,
+	programID *externalapi.DomainHash) (bool, error) {
+
+	stagingShard := vss.stagingShard(stagingArea)
+
+	if _, ok := stagingShard.codeToAdd[*programID]; ok {
+		return true, nil
+	}
+
+	return dbContext.Has(vss.codeKey(programID))
+}
+
+func (vss *vprogStateStore) diffHashAsKey(hash *externalapi.DomainHash) model.DBKey {
+	return vss.diffBucket.Key(hash.ByteSlice())
+}
+
+func (vss *vprogStateStore) programStateBucket(programID *externalapi.DomainHash) model.DBBucket {
+	return vss.stateBucket.Bucket(programID.ByteSlice())
+}
+
+func (vss *vprogStateStore) stateKey(key model.VProgStateKey) model.DBKey {
+	return vss.programStateBucket(&key.ProgramID).Key([]byte(key.Key))
+}
+
+func (vss *vprogStateStore) codeKey(programID *externalapi.DomainHash) model.DBKey {
+	return vss.codeBucket.Key(programID.ByteSlice())
+}
+
+func (vss *vprogStateStore) serializeDiff(diff model.VProgStateDiff) ([]byte, error) {
+	return proto.Marshal(serialization.VProgStateDiffToDBVProgStateDiff(diff))
+}
+
+func (vss *vprogStateStore) deserializeDiff(diffBytes []byte) (model.VProgStateDiff, error) {
+	dbDiff := &serialization.DbVProgStateDiff{}
+	err := proto.Unmarshal(diffBytes, dbDiff)
+	if err != nil {
+		return nil, err
+	}
+	return serialization.DBVProgStateDiffToVProgStateDiff(dbDiff)
+}
+
+func (vss *vprogStateStore) serializeHash(hash *externalapi.DomainHash) ([]byte, error) {
+	return proto.Marshal(serialization.DomainHashToDbHash(hash))
+}
+
+func (vss *vprogStateStore) deserializeHash(hashBytes []byte) (*externalapi.DomainHash, error) {
+	dbHash := &serialization.DbHash{}
+	err := proto.Unmarshal(hashBytes, dbHash)
+	if err != nil {
+		return nil, err
+	}
+	return serialization.DbHashToDomainHash(dbHash)
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/zuanet/zuad/domain/consensus/datastructures/pruningstore"
 	"github.com/zuanet/zuad/domain/consensus/datastructures/reachabilitydatastore"
 	"github.com/zuanet/zuad/domain/consensus/datastructures/utxodiffstore"
+	"github.com/zuanet/zuad/domain/consensus/datastructures/vprogstatestore"
 	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
 	"github.com/zuanet/zuad/domain/consensus/model/testapi"
 	"github.com/zuanet/zuad/domain/consensus/processes/blockbuilder"
@@ -148,6 +149,7 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 	pruningStore := pruningstore.New(prefixBucket, 2, preallocateCaches)
 	utxoDiffStore := utxodiffstore.New(prefixBucket, 200, preallocateCaches)
 	consensusStateStore := consensusstatestore.New(prefixBucket, 10_000, preallocateCaches)
+	vprogStateStore := vprogstatestore.New(prefixBucket, 200, preallocateCaches)
 
 	headersSelectedTipStore := headersselectedtipstore.New(prefixBucket)
 	finalityStore := finalitystore.New(prefixBucket, 200, preallocateCaches)
@@ -204,7 +206,7 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 		config.MaxBlockLevel,
 	)
 
-	txMassCalculator := txmass.NewCalculator(config.MassPerTxByte, config.MassPerScriptPubKeyByte, config.MassPerSigOp)
+	txMassCalculator := txmass.NewCalculator(config.MassPerTxByte, config.MassPerScriptPubKeyByte, config.MassPerSigOp, config.VProgGasPerMass)
 
 	pastMedianTimeManager := f.pastMedianTimeConsructor(
 		config.TimestampDeviationTolerance,
@@ -215,6 +217,8 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 		config.GenesisHash)
 	transactionValidator := transactionvalidator.New(config.BlockCoinbaseMaturity,
 		config.EnableNonNativeSubnetworks,
+		config.EnableVProgs,
+		config.MaxVProgGasLimit,
 		config.MaxCoinbasePayloadLength,
 		config.K,
 		config.CoinbasePayloadScriptPublicKeyMaxLength,
@@ -304,7 +308,8 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 		blockHeaderStore,
 		headersSelectedTipStore,
 		pruningStore,
-		daaBlocksStore)
+		daaBlocksStore,
+		vprogStateStore)
 	if err != nil {
 		return nil, false, err
 	}
@@ -329,6 +334,7 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 		daaBlocksStore,
 		reachabilityDataStore,
 		daaWindowStore,
+		vprogStateStore,
 
 		config.IsArchival,
 		genesisHash,
@@ -345,6 +351,7 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 		genesisHash,
 		config.EnableNonNativeSubnetworks,
 		config.MaxBlockMass,
+		config.MaxBlockVProgGas,
 		config.MergeSetSizeLimit,
 		config.MaxBlockParents,
 		config.TimestampDeviationTolerance,
@@ -517,6 +524,7 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 		headersSelectedChainStore:           headersSelectedChainStore,
 		daaBlocksStore:                      daaBlocksStore,
 		blocksWithTrustedDataDAAWindowStore: daaWindowStore,
+		vprogStateStore:                     vprogStateStore,
 
 		consensusEventsChan: consensusEventsChan,
 		virtualNotUpdated:   true,
@@ -38,6 +38,7 @@ func initTestTransactionAcceptanceDataForClone() []*externalapi.TransactionAccep
 			1,
 			true,
 			[]externalapi.UTXOEntry{utxo.NewUTXOEntry(1, &externalapi.ScriptPublicKey{Script: []byte{0, 1, 2, 3}, Version: 0}, true, 2)},
+			nil,
 		},
 	}
 	return tests
@@ -83,6 +84,7 @@ func initTransactionAcceptanceDataForEqual() []testTransactionAcceptanceDataStru
 		1,
 		true,
 		[]externalapi.UTXOEntry{utxo.NewUTXOEntry(1, &externalapi.ScriptPublicKey{Script: []byte{0, 1, 2, 3}, Version: 0}, true, 2)},
+		nil,
 	}
 
 	var testTransactionAcceptanceData1 = externalapi.TransactionAcceptanceData{
@@ -112,6 +114,7 @@ func initTransactionAcceptanceDataForEqual() []testTransactionAcceptanceDataStru
 		1,
 		true,
 		[]externalapi.UTXOEntry{utxo.NewUTXOEntry(1, &externalapi.ScriptPublicKey{Script: []byte{0, 1, 2, 3}, Version: 0}, true, 2)},
+		nil,
 	}
 	// test 2: different transactions
 	var testTransactionAcceptanceData2 = externalapi.TransactionAcceptanceData{
@@ -141,6 +144,7 @@ func initTransactionAcceptanceDataForEqual() []testTransactionAcceptanceDataStru
 		1,
 		true,
 		[]externalapi.UTXOEntry{utxo.NewUTXOEntry(1, &externalapi.ScriptPublicKey{Script: []byte{0, 1, 2, 3}, Version: 0}, true, 2)},
+		nil,
 	}
 	//test 3: different Fee
 	var testTransactionAcceptanceData3 = externalapi.TransactionAcceptanceData{
@@ -171,6 +175,7 @@ func initTransactionAcceptanceDataForEqual() []testTransactionAcceptanceDataStru
 		2,
 		true,
 		[]externalapi.UTXOEntry{utxo.NewUTXOEntry(1, &externalapi.ScriptPublicKey{Script: []byte{0, 1, 2, 3}, Version: 0}, true, 2)},
+		nil,
 	}
 	//test 4: different isAccepted
 	var testTransactionAcceptanceData4 = externalapi.TransactionAcceptanceData{
@@ -201,6 +206,7 @@ func initTransactionAcceptanceDataForEqual() []testTransactionAcceptanceDataStru
 		1,
 		false,
 		[]externalapi.UTXOEntry{utxo.NewUTXOEntry(1, &externalapi.ScriptPublicKey{Script: []byte{0, 1, 2, 3}, Version: 0}, true, 2)},
+		nil,
 	}
 
 	//test 5: different TransactionInputUTXOEntries
@@ -232,6 +238,44 @@ func initTransactionAcceptanceDataForEqual() []testTransactionAcceptanceDataStru
 		1,
 		false,
 		[]externalapi.UTXOEntry{utxo.NewUTXOEntry(1, &externalapi.ScriptPublicKey{Script: []byte{0, 1, 2, 3}, Version: 0}, true, 2)},
+		nil,
+	}
+
+	// test 6: different vprog results
+	var testTransactionAcceptanceData6 = externalapi.TransactionAcceptanceData{
+		&externalapi.DomainTransaction{
+			Version: 1,
+			Inputs: []*externalapi.DomainTransactionInput{{externalapi.DomainOutpoint{
+				*externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{0x01}), 0xFFFF},
+				[]byte{1, 2, 3},
+				uint64(0xFFFFFFFF),
+				1,
+				utxo.NewUTXOEntry(1, &externalapi.ScriptPublicKey{Script: []byte{0, 1, 2, 3}, Version: 0}, true, 2)}},
+			Outputs: []*externalapi.DomainTransactionOutput{{uint64(0xFFFF),
+				&externalapi.ScriptPublicKey{Script: []byte{1, 2}, Version: 0}},
+				{uint64(0xFFFF),
+					&externalapi.ScriptPublicKey{Script: []byte{1, 3}, Version: 0}}},
+			LockTime:     1,
+			SubnetworkID: externalapi.DomainSubnetworkID{0x01},
+			Gas:          1,
+			Payload:      []byte{0x01},
+			Fee:          0,
+			Mass:         1,
+			ID: externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02}),
+		},
+		1,
+		true,
+		[]externalapi.UTXOEntry{utxo.NewUTXOEntry(1, &externalapi.ScriptPublicKey{Script: []byte{0, 1, 2, 3}, Version: 0}, true, 2)},
+		&externalapi.VProgExecutionResult{
+			ProgramID:    externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{0x01}),
+			Success:      true,
+			GasUsed:      1,
+			ReturnData:   []byte{0x01},
+			StateChanges: map[string][]byte{"key": {0x01}},
+		},
 	}
 
 	tests := []testTransactionAcceptanceDataStruct{
@@ -253,6 +297,9 @@ func initTransactionAcceptanceDataForEqual() []testTransactionAcceptanceDataStru
 				}, {
 					transactionAcceptanceData: &testTransactionAcceptanceData5,
 					expectedResult:            false,
+				}, {
+					transactionAcceptanceData: &testTransactionAcceptanceData6,
+					expectedResult:            false,
 				}, {
 					transactionAcceptanceData: nil,
 					expectedResult:            false,
@@ -328,6 +375,10 @@ func initTestBlockAcceptanceDataForClone() []*externalapi.BlockAcceptanceData {
 					1,
 					[]byte{0x01},
 					0,
+					[]byte{},
+					[]byte{},
+					0,
+					0,
 					1,
 					externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{
 						0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -338,6 +389,7 @@ func initTestBlockAcceptanceDataForClone() []*externalapi.BlockAcceptanceData {
 				1,
 				true,
 				[]externalapi.UTXOEntry{utxo.NewUTXOEntry(1, &externalapi.ScriptPublicKey{Script: []byte{0, 1, 2, 3}, Version: 0}, true, 2)},
+				nil,
 			}},
 	},
 	}
@@ -376,6 +428,10 @@ func iniBlockAcceptanceDataForEqual() []testBlockAcceptanceDataStruct {
 				1,
 				[]byte{0x01},
 				0,
+				[]byte{},
+				[]byte{},
+				0,
+				0,
 				1,
 				externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{
 					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -386,6 +442,7 @@ func iniBlockAcceptanceDataForEqual() []testBlockAcceptanceDataStruct {
 			1,
 			true,
 			[]externalapi.UTXOEntry{utxo.NewUTXOEntry(1, &externalapi.ScriptPublicKey{Script: []byte{0, 1, 2, 3}, Version: 0}, true, 2)},
+			nil,
 		}}}
 	//test 1: structs are equal
 	var testBlockAcceptanceData1 = externalapi.BlockAcceptanceData{
@@ -409,6 +466,10 @@ func iniBlockAcceptanceDataForEqual() []testBlockAcceptanceDataStruct {
 				1,
 				[]byte{0x01},
 				0,
+				[]byte{},
+				[]byte{},
+				0,
+				0,
 				1,
 				externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{
 					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -419,6 +480,7 @@ func iniBlockAcceptanceDataForEqual() []testBlockAcceptanceDataStruct {
 			1,
 			true,
 			[]externalapi.UTXOEntry{utxo.NewUTXOEntry(1, &externalapi.ScriptPublicKey{Script: []byte{0, 1, 2, 3}, Version: 0}, true, 2)},
+			nil,
 		}}}
 	// test 2: different size
 	var testBlockAcceptanceData2 = externalapi.BlockAcceptanceData{
@@ -442,6 +504,10 @@ func iniBlockAcceptanceDataForEqual() []testBlockAcceptanceDataStruct {
 				1,
 				[]byte{0x01},
 				0,
+				[]byte{},
+				[]byte{},
+				0,
+				0,
 				1,
 				externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{
 					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -452,6 +518,7 @@ func iniBlockAcceptanceDataForEqual() []testBlockAcceptanceDataStruct {
 			1,
 			true,
 			[]externalapi.UTXOEntry{utxo.NewUTXOEntry(1, &externalapi.ScriptPublicKey{Script: []byte{0, 1, 2, 3}, Version: 0}, true, 2)},
+			nil,
 		}, {}}}
 	//test 3: different transactions, same size
 	var testBlockAcceptanceData3 = externalapi.BlockAcceptanceData{
@@ -475,6 +542,10 @@ func iniBlockAcceptanceDataForEqual() []testBlockAcceptanceDataStruct {
 				1,
 				[]byte{0x01},
 				0,
+				[]byte{},
+				[]byte{},
+				0,
+				0,
 				1,
 				externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{
 					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -485,6 +556,7 @@ func iniBlockAcceptanceDataForEqual() []testBlockAcceptanceDataStruct {
 			1,
 			false,
 			[]externalapi.UTXOEntry{utxo.NewUTXOEntry(1, &externalapi.ScriptPublicKey{Script: []byte{0, 1, 2, 3}, Version: 0}, true, 2)},
+			nil,
 		}}}
 
 	// test 4 - different block hash
@@ -509,6 +581,10 @@ func iniBlockAcceptanceDataForEqual() []testBlockAcceptanceDataStruct {
 				1,
 				[]byte{0x01},
 				0,
+				[]byte{},
+				[]byte{},
+				0,
+				0,
 				1,
 				externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{
 					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -519,6 +595,7 @@ func iniBlockAcceptanceDataForEqual() []testBlockAcceptanceDataStruct {
 			1,
 			true,
 			[]externalapi.UTXOEntry{utxo.NewUTXOEntry(1, &externalapi.ScriptPublicKey{Script: []byte{0, 1, 2, 3}, Version: 0}, true, 2)},
+			nil,
 		}}}
 
 	tests := []testBlockAcceptanceDataStruct{
@@ -613,6 +690,10 @@ func initTestAcceptanceDataForClone() []externalapi.AcceptanceData {
 					1,
 					[]byte{0x01},
 					0,
+					[]byte{},
+					[]byte{},
+					0,
+					0,
 					1,
 					externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{
 						0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -623,6 +704,7 @@ func initTestAcceptanceDataForClone() []externalapi.AcceptanceData {
 				1,
 				true,
 				[]externalapi.UTXOEntry{utxo.NewUTXOEntry(1, &externalapi.ScriptPublicKey{Script: []byte{0, 1, 2, 3}, Version: 0}, true, 2)},
+				nil,
 			}},
 	},
 	}
@@ -663,6 +745,10 @@ func initAcceptanceDataForEqual() []testAcceptanceDataStruct {
 					1,
 					[]byte{0x01},
 					0,
+					[]byte{},
+					[]byte{},
+					0,
+					0,
 					1,
 					externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{
 						0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -673,6 +759,7 @@ func initAcceptanceDataForEqual() []testAcceptanceDataStruct {
 				1,
 				true,
 				[]externalapi.UTXOEntry{utxo.NewUTXOEntry(1, &externalapi.ScriptPublicKey{Script: []byte{0, 1, 2, 3}, Version: 0}, true, 2)},
+				nil,
 			}}}}
 	//test 1: structs are equal
 	var testAcceptanceData1 = []*externalapi.BlockAcceptanceData{
@@ -696,6 +783,10 @@ func initAcceptanceDataForEqual() []testAcceptanceDataStruct {
 					1,
 					[]byte{0x01},
 					0,
+					[]byte{},
+					[]byte{},
+					0,
+					0,
 					1,
 					externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{
 						0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -706,6 +797,7 @@ func initAcceptanceDataForEqual() []testAcceptanceDataStruct {
 				1,
 				true,
 				[]externalapi.UTXOEntry{utxo.NewUTXOEntry(1, &externalapi.ScriptPublicKey{Script: []byte{0, 1, 2, 3}, Version: 0}, true, 2)},
+				nil,
 			}}}}
 	// test 2: different size
 	var testAcceptanceData2 = []*externalapi.BlockAcceptanceData{
@@ -729,6 +821,10 @@ func initAcceptanceDataForEqual() []testAcceptanceDataStruct {
 					1,
 					[]byte{0x01},
 					0,
+					[]byte{},
+					[]byte{},
+					0,
+					0,
 					1,
 					externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{
 						0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -739,6 +835,7 @@ func initAcceptanceDataForEqual() []testAcceptanceDataStruct {
 				1,
 				true,
 				[]externalapi.UTXOEntry{utxo.NewUTXOEntry(1, &externalapi.ScriptPublicKey{Script: []byte{0, 1, 2, 3}, Version: 0}, true, 2)},
+				nil,
 			}}}, {}}
 	//test 3: different transactions, same size
 	var testAcceptanceData3 = []*externalapi.BlockAcceptanceData{
@@ -762,6 +859,10 @@ func initAcceptanceDataForEqual() []testAcceptanceDataStruct {
 					1,
 					[]byte{0x01},
 					0,
+					[]byte{},
+					[]byte{},
+					0,
+					0,
 					1,
 					externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{
 						0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -772,6 +873,7 @@ func initAcceptanceDataForEqual() []testAcceptanceDataStruct {
 				1,
 				true,
 				[]externalapi.UTXOEntry{utxo.NewUTXOEntry(1, &externalapi.ScriptPublicKey{Script: []byte{0, 1, 2, 3}, Version: 0}, true, 2)},
+				nil,
 			}}}}
 
 	tests := []testAcceptanceDataStruct{
@@ -91,11 +91,12 @@ type TransactionAcceptanceData struct {
 	Fee                         uint64
 	IsAccepted                  bool
 	TransactionInputUTXOEntries []UTXOEntry
+	VProgResult                 *VProgExecutionResult
 }
 
 // If this doesn't compile, it means the type definition has been changed, so it's
 // an indication to update Equal and Clone accordingly.
-var _ = &TransactionAcceptanceData{&DomainTransaction{}, 0, false, []UTXOEntry{}}
+var _ = &TransactionAcceptanceData{&DomainTransaction{}, 0, false, []UTXOEntry{}, &VProgExecutionResult{}}
 
 // Equal returns whether tad equals to other
 func (tad *TransactionAcceptanceData) Equal(other *TransactionAcceptanceData) bool {
@@ -126,6 +127,10 @@ func (tad *TransactionAcceptanceData) Equal(other *TransactionAcceptanceData) bo
 		}
 	}
 
+	if !tad.VProgResult.Equal(other.VProgResult) {
+		return false
+	}
+
 	return true
 }
 
@@ -141,5 +146,6 @@ func (tad *TransactionAcceptanceData) Clone() *TransactionAcceptanceData {
 		Fee:                         tad.Fee,
 		IsAccepted:                  tad.IsAccepted,
 		TransactionInputUTXOEntries: cloneTransactionInputUTXOEntries,
+		VProgResult:                 tad.VProgResult.Clone(),
 	}
 }
@@ -18,10 +18,10 @@ type DomainTransaction struct {
 	Gas          uint64
 	Payload      []byte
 
-    VProgVersion  byte              `json:"vprogVersion,omitempty"`
-    VProgCode     []byte            `json:"vprogCode,omitempty"`     
-    VProgData     []byte            `json:"vprogData,omitempty"`     
-    VProgGasLimit uint64            `json:"vprogGasLimit,omitempty"` 
+	VProgVersion  byte
+	VProgCode     []byte
+	VProgData     []byte
+	VProgGasLimit uint64
 
 	Fee  uint64
 	Mass uint64
@@ -46,33 +46,45 @@ func (tx *DomainTransaction) Clone() *DomainTransaction {
 		outputsClone[i] = output.Clone()
 	}
 
+	var vprogCodeClone []byte
+	if tx.VProgCode != nil {
+		vprogCodeClone = make([]byte, len(tx.VProgCode))
+		copy(vprogCodeClone, tx.VProgCode)
+	}
+
+	var vprogDataClone []byte
+	if tx.VProgData != nil {
+		vprogDataClone = make([]byte, len(tx.VProgData))
+		copy(vprogDataClone, tx.VProgData)
+	}
+
 	var idClone *DomainTransactionID
 	if tx.ID != nil {
 		idClone = tx.ID.Clone()
 	}
 
 	return &DomainTransaction{
-		Version:      tx.Version,
-		Inputs:       inputsClone,
-		Outputs:      outputsClone,
-		LockTime:     tx.LockTime,
-		SubnetworkID: *tx.SubnetworkID.Clone(),
-		Gas:          tx.Gas,
-		Payload:      payloadClone,
-		Fee:          tx.Fee,
-		Mass:         tx.Mass,
-		ID:           idClone,
-		VProgVersion:   0,    
-		VProgCode:      nil,  
-		VProgData:      nil,  
-		VProgGasLimit:  0,    
+		Version:       tx.Version,
+		Inputs:        inputsClone,
+		Outputs:       outputsClone,
+		LockTime:      tx.LockTime,
+		SubnetworkID:  *tx.SubnetworkID.Clone(),
+		Gas:           tx.Gas,
+		Payload:       payloadClone,
+		VProgVersion:  tx.VProgVersion,
+		VProgCode:     vprogCodeClone,
+		VProgData:     vprogDataClone,
+		VProgGasLimit: tx.VProgGasLimit,
+		Fee:           tx.Fee,
+		Mass:          tx.Mass,
+		ID:            idClone,
 	}
 }
 
 // If this doesn't compile, it means the type definition has been changed, so it's
 // an indication to update Equal and Clone accordingly.
 var _ = DomainTransaction{0, []*DomainTransactionInput{}, []*DomainTransactionOutput{}, 0,
-	DomainSubnetworkID{}, 0, []byte{}, 0, 0,
+	DomainSubnetworkID{}, 0, []byte{}, 0, []byte{}, []byte{}, 0, 0, 0,
 	&DomainTransactionID{}}
 
 // Equal returns whether tx equals to other
@@ -121,6 +133,22 @@ func (tx *DomainTransaction) Equal(other *DomainTransaction) bool {
 		return false
 	}
 
+	if tx.VProgVersion != other.VProgVersion {
+		return false
+	}
+
+	if !bytes.Equal(tx.VProgCode, other.VProgCode) {
+		return false
+	}
+
+	if !bytes.Equal(tx.VProgData, other.VProgData) {
+		return false
+	}
+
+	if tx.VProgGasLimit != other.VProgGasLimit {
+		return false
+	}
+
 	if tx.Fee != 0 && other.Fee != 0 && tx.Fee != other.Fee {
 		panic(errors.New("identical transactions should always have the same fee"))
 	}
@@ -273,8 +301,6 @@ func NewScriptPublicKeyFromString(ScriptPublicKeyString string) *ScriptPublicKey
 type DomainTransactionOutput struct {
 	Value           uint64
 	ScriptPublicKey *ScriptPublicKey
-
-	 VProgOutputCode []byte          `json:"vprogOutputCode,omitempty"`
 }
 
 // If this doesn't compile, it means the type definition has been changed, so it's
@@ -82,6 +82,10 @@ func initTestBaseTransaction() *externalapi.DomainTransaction {
 		1,
 		[]byte{0x01},
 		0,
+		[]byte{},
+		[]byte{},
+		0,
+		0,
 		1,
 		externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -111,6 +115,10 @@ func initTestTransactionToCompare() []*transactionToCompare {
 			1,
 			[]byte{0x01},
 			0,
+			[]byte{},
+			[]byte{},
+			0,
+			0,
 			1,
 			externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -136,6 +144,10 @@ func initTestTransactionToCompare() []*transactionToCompare {
 			1,
 			[]byte{0x01},
 			0,
+			[]byte{},
+			[]byte{},
+			0,
+			0,
 			1,
 			externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -161,6 +173,68 @@ func initTestTransactionToCompare() []*transactionToCompare {
 			1,
 			[]byte{0x01, 0x02}, //Changed
 			0,
+			[]byte{},
+			[]byte{},
+			0,
+			0,
+			1,
+			externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02}),
+		},
+		expectedResult: false,
+	}, {
+		tx: &externalapi.DomainTransaction{
+			1,
+			[]*externalapi.DomainTransactionInput{{externalapi.DomainOutpoint{
+				*externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{0x01}), 0xFFFF},
+				[]byte{1, 2, 3},
+				uint64(0xFFFFFFFF),
+				1,
+				utxo.NewUTXOEntry(1, &externalapi.ScriptPublicKey{Script: []byte{0, 1, 2, 3}, Version: 0}, true, 2)}},
+			[]*externalapi.DomainTransactionOutput{{uint64(0xFFFF),
+				&externalapi.ScriptPublicKey{Script: []byte{1, 2}, Version: 0}},
+				{uint64(0xFFFF),
+					&externalapi.ScriptPublicKey{Script: []byte{1, 3}, Version: 0}}},
+			1,
+			externalapi.DomainSubnetworkID{0x01},
+			1,
+			[]byte{0x01},
+			0,
+			[]byte{0x01}, //Changed
+			[]byte{},
+			0,
+			0,
+			1,
+			externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02}),
+		},
+		expectedResult: false,
+	}, {
+		tx: &externalapi.DomainTransaction{
+			1,
+			[]*externalapi.DomainTransactionInput{{externalapi.DomainOutpoint{
+				*externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{0x01}), 0xFFFF},
+				[]byte{1, 2, 3},
+				uint64(0xFFFFFFFF),
+				1,
+				utxo.NewUTXOEntry(1, &externalapi.ScriptPublicKey{Script: []byte{0, 1, 2, 3}, Version: 0}, true, 2)}},
+			[]*externalapi.DomainTransactionOutput{{uint64(0xFFFF),
+				&externalapi.ScriptPublicKey{Script: []byte{1, 2}, Version: 0}},
+				{uint64(0xFFFF),
+					&externalapi.ScriptPublicKey{Script: []byte{1, 3}, Version: 0}}},
+			1,
+			externalapi.DomainSubnetworkID{0x01},
+			1,
+			[]byte{0x01},
+			0,
+			[]byte{},
+			[]byte{},
+			1000, //Changed
+			0,
 			1,
 			externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -185,6 +259,10 @@ func initTestTransactionToCompare() []*transactionToCompare {
 			1,
 			[]byte{0x01},
 			0,
+			[]byte{},
+			[]byte{},
+			0,
+			0,
 			1,
 			externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -212,6 +290,10 @@ func initTestTransactionToCompare() []*transactionToCompare {
 				1,
 				[]byte{0x01},
 				0,
+				[]byte{},
+				[]byte{},
+				0,
+				0,
 				1,
 				externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -237,6 +319,10 @@ func initTestTransactionToCompare() []*transactionToCompare {
 				externalapi.DomainSubnetworkID{0x01},
 				1,
 				[]byte{0x01},
+				0,
+				[]byte{},
+				[]byte{},
+				0,
 				1000000000, //Changed
 				1,
 				externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -262,6 +348,10 @@ func initTestTransactionToCompare() []*transactionToCompare {
 				1,
 				[]byte{0x01},
 				0,
+				[]byte{},
+				[]byte{},
+				0,
+				0,
 				1,
 				externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -286,6 +376,10 @@ func initTestTransactionToCompare() []*transactionToCompare {
 				1,
 				[]byte{0x01},
 				0,
+				[]byte{},
+				[]byte{},
+				0,
+				0,
 				2, //Changed
 				externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -310,6 +404,10 @@ func initTestTransactionToCompare() []*transactionToCompare {
 				1,
 				[]byte{0x01},
 				0,
+				[]byte{},
+				[]byte{},
+				0,
+				0,
 				1,
 				externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -340,6 +438,10 @@ func initTestTransactionToCompare() []*transactionToCompare {
 				1,
 				[]byte{0x01},
 				0,
+				[]byte{},
+				[]byte{},
+				0,
+				0,
 				1,
 				externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -365,6 +467,10 @@ func initTestTransactionToCompare() []*transactionToCompare {
 				1,
 				[]byte{0x01},
 				0,
+				[]byte{},
+				[]byte{},
+				0,
+				0,
 				1,
 				externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -389,6 +495,10 @@ func initTestTransactionToCompare() []*transactionToCompare {
 				1,
 				[]byte{0x01},
 				0,
+				[]byte{},
+				[]byte{},
+				0,
+				0,
 				1,
 				nil, //changed
 			},
@@ -410,6 +520,10 @@ func initTestTransactionToCompare() []*transactionToCompare {
 				1,
 				[]byte{0x01},
 				0,
+				[]byte{},
+				[]byte{},
+				0,
+				0,
 				1,
 				externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -434,6 +548,10 @@ func initTestTransactionToCompare() []*transactionToCompare {
 				1,
 				[]byte{0x01},
 				0,
+				[]byte{},
+				[]byte{},
+				0,
+				0,
 				1,
 				externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -460,6 +578,10 @@ func initTestTransactionToCompare() []*transactionToCompare {
 				2, // Changed
 				[]byte{0x01},
 				0,
+				[]byte{},
+				[]byte{},
+				0,
+				0,
 				1,
 				externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -534,6 +656,10 @@ func initTestDomainTransactionForEqual() []testDomainTransactionStruct {
 				externalapi.DomainSubnetworkID{0x01},
 				1,
 				[]byte{0x01},
+				0,
+				[]byte{},
+				[]byte{},
+				0,
 				1,
 				1,
 				externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -553,6 +679,10 @@ func initTestDomainTransactionForEqual() []testDomainTransactionStruct {
 					externalapi.DomainSubnetworkID{0x01},
 					0,
 					[]byte{0x01},
+					0,
+					[]byte{},
+					[]byte{},
+					0,
 					1,
 					1,
 					nil,
@@ -567,6 +697,10 @@ func initTestDomainTransactionForEqual() []testDomainTransactionStruct {
 					externalapi.DomainSubnetworkID{0x01},
 					1,
 					[]byte{0x01},
+					0,
+					[]byte{},
+					[]byte{},
+					0,
 					1,
 					1,
 					nil,
@@ -581,6 +715,10 @@ func initTestDomainTransactionForEqual() []testDomainTransactionStruct {
 					externalapi.DomainSubnetworkID{0x01},
 					1,
 					[]byte{0x01},
+					0,
+					[]byte{},
+					[]byte{},
+					0,
 					2, // Changed fee
 					1,
 					nil,
@@ -0,0 +1,118 @@
+package externalapi
+
+import "bytes"
+
+// VProgExecutionResult is the outcome of executing a vprog transaction once it
+// was accepted by a chain block
+type VProgExecutionResult struct {
+	ProgramID    *DomainHash
+	Success      bool
+	GasUsed      uint64
+	ReturnData   []byte
+	StateChanges map[string][]byte
+	ErrorCode    uint32
+}
+
+// If this doesn't compile, it means the type definition has been changed, so it's
+// an indication to update Equal and Clone accordingly.
+var _ = &VProgExecutionResult{&DomainHash{}, false, 0, []byte{}, map[string][]byte{}, 0}
+
+// Equal returns whether result equals to other
+func (result *VProgExecutionResult) Equal(other *VProgExecutionResult) bool {
+	if result == nil || other == nil {
+		return result == other
+	}
+
+	if !result.ProgramID.Equal(other.ProgramID) {
+		return false
+	}
+
+	if result.Success != other.Success {
+		return false
+	}
+
+	if result.GasUsed != other.GasUsed {
+		return false
+	}
+
+	if !bytes.Equal(result.ReturnData, other.ReturnData) {
+		return false
+	}
+
+	if len(result.StateChanges) != len(other.StateChanges) {
+		return false
+	}
+
+	for key, value := range result.StateChanges {
+		otherValue, ok := other.StateChanges[key]
+		if !ok || !bytes.Equal(value, otherValue) {
+			return false
+		}
+	}
+
+	return result.ErrorCode == other.ErrorCode
+}
+
+// Clone returns a clone of VProgExecutionResult
+func (result *VProgExecutionResult) Clone() *VProgExecutionResult {
+	if result == nil {
+		return nil
+	}
+
+	returnDataClone := make([]byte, len(result.ReturnData))
+	copy(returnDataClone, result.ReturnData)
+
+	var stateChangesClone map[string][]byte
+	if result.StateChanges != nil {
+		stateChangesClone = make(map[string][]byte, len(result.StateChanges))
+		for key, value := range result.StateChanges {
+			valueClone := make([]byte, len(value))
+			copy(valueClone, value)
+			stateChangesClone[key] = valueClone
+		}
+	}
+
+	return &VProgExecutionResult{
+		ProgramID:    result.ProgramID,
+		Success:      result.Success,
+		GasUsed:      result.GasUsed,
+		ReturnData:   returnDataClone,
+		StateChanges: stateChangesClone,
+		ErrorCode:    result.ErrorCode,
+	}
+}
+
+// VProgStateEntry is a single entry in the storage of a vprog program
+type VProgStateEntry struct {
+	ProgramID *DomainHash
+	Key       []byte
+	Value     []byte
+}
+
+// If this doesn't compile, it means the type definition has been changed, so it's
+// an indication to update Equal and Clone accordingly.
+var _ = &VProgStateEntry{&DomainHash{}, []byte{}, []byte{}}
+
+// Equal returns whether entry equals to other
+func (entry *VProgStateEntry) Equal(other *VProgStateEntry) bool {
+	if entry == nil || other == nil {
+		return entry == other
+	}
+	return entry.ProgramID.Equal(other.ProgramID) &&
+		bytes.Equal(entry.Key, other.Key) &&
+		bytes.Equal(entry.Value, other.Value)
+}
+
+// Clone returns a clone of VProgStateEntry
+func (entry *VProgStateEntry) Clone() *VProgStateEntry {
+	keyClone := make([]byte, len(entry.Key))
+	copy(keyClone, entry.Key)
+	valueClone := make([]byte, len(entry.Value))
+	copy(valueClone, entry.Value)
+
+	return &VProgStateEntry{
+		ProgramID: entry.ProgramID,
+		Key:       keyClone,
+		Value:     valueClone,
+	}
+}
@@ -0,0 +1,33 @@
+package model
+
+import "github.com/zuanet/zuad/domain/consensus/model/externalapi"
+
+// VProgStateStore represents a store of the state of vprog programs.
+// It holds the full state as of a single selected chain block, along with the
+// state diff of every block relative to its selected parent, so that the state
+// of any block can be restored by walking the selected chain from that block.
+type VProgStateStore interface {
+	Store
+	IsStaged(stagingArea *StagingArea) bool
+
+	StageDiff(stagingArea *StagingArea, blockHash *externalapi.DomainHash, diff VProgStateDiff)
+	Diff(dbContext DBReader, stagingArea *StagingArea, blockHash *externalapi.DomainHash) (VProgStateDiff, error)
+	HasDiff(dbContext DBReader, stagingArea *StagingArea, blockHash *externalapi.DomainHash) (bool, error)
+	DeleteDiff(stagingArea *StagingArea, blockHash *externalapi.DomainHash)
+
+	StageState(stagingArea *StagingArea, stateBlockHash *externalapi.DomainHash, diff VProgStateDiff)
+	StateBlockHash(dbContext DBReader, stagingArea *StagingArea) (*externalapi.DomainHash, error)
+	HasStateBlockHash(dbContext DBReader, stagingArea *StagingArea) (bool, error)
+	StateValue(dbContext DBReader, stagingArea *StagingArea, key VProgStateKey) (value []byte, found bool, err error)
+	ProgramState(dbContext DBReader, stagingArea *StagingArea, programID *externalapi.DomainHash) (map[string][]byte, error)
+
+	StageCode(stagingArea *StagingArea, programID *externalapi.DomainHash, code []byte)
+	Code(dbContext DBReader, stagingArea *StagingArea, programID *externalapi.DomainHash) ([]byte, error)
+	HasCode(dbContext DBReader, stagingArea *StagingArea, programID *externalapi.DomainHash) (bool, error)
+
+	UpdatePruningPointState(dbContext DBWriter, diff VProgStateDiff) error
+	PruningPointState(dbContext DBReader, fromEntry *externalapi.VProgStateEntry, limit int) ([]*externalapi.VProgStateEntry, error)
+	ClearImportedPruningPointState(dbContext DBWriter) error
+	AppendImportedPruningPointState(dbTx DBTransaction, entries []*externalapi.VProgStateEntry) error
+	ImportPruningPointState(dbContext DBWriter, pruningPointHash *externalapi.DomainHash) error
+}
@@ -14,4 +14,6 @@ type ConsensusStateManager interface {
 	RecoverUTXOIfRequired() error
 	ReverseUTXODiffs(tipHash *externalapi.DomainHash, reversalData *UTXODiffReversalData) error
 	ResolveVirtual(maxBlocksToResolve uint64) (*externalapi.VirtualChangeSet, bool, error)
+	SimulateVProgCall(stagingArea *StagingArea, code []byte, input []byte, gasLimit uint64) (*externalapi.VProgExecutionResult, error)
+	VirtualVProgState(stagingArea *StagingArea, programID *externalapi.DomainHash) (map[string][]byte, error)
 }
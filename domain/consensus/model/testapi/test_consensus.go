@@ -71,6 +71,7 @@ type TestConsensus interface {
 	UTXODiffStore() model.UTXODiffStore
 	HeadersSelectedChainStore() model.HeadersSelectedChainStore
 	DAABlocksStore() model.DAABlocksStore
+	VProgStateStore() model.VProgStateStore
 
 	BlockBuilder() TestBlockBuilder
 	BlockProcessor() model.BlockProcessor
@@ -0,0 +1,139 @@
+package model
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+)
+
+// VProgStateKey identifies a single entry in the storage of a vprog program
+type VProgStateKey struct {
+	ProgramID externalapi.DomainHash
+	Key       string
+}
+
+// Less returns whether key is ordered before other
+func (key VProgStateKey) Less(other VProgStateKey) bool {
+	if !key.ProgramID.Equal(&other.ProgramID) {
+		return key.ProgramID.Less(&other.ProgramID)
+	}
+	return key.Key < other.Key
+}
+
+// VProgStateChange is the value of a single vprog storage entry before and
+// after a change. An empty value means that the entry does not exist.
+type VProgStateChange struct {
+	OldValue []byte
+	NewValue []byte
+}
+
+// If this doesn't compile, it means the type definition has been changed, so it's
+// an indication to update Equal and Clone accordingly.
+var _ = &VProgStateChange{[]byte{}, []byte{}}
+
+// Equal returns whether change equals to other
+func (change *VProgStateChange) Equal(other *VProgStateChange) bool {
+	if change == nil || other == nil {
+		return change == other
+	}
+	return bytes.Equal(change.OldValue, other.OldValue) && bytes.Equal(change.NewValue, other.NewValue)
+}
+
+// Clone returns a clone of VProgStateChange
+func (change *VProgStateChange) Clone() *VProgStateChange {
+	return &VProgStateChange{
+		OldValue: cloneBytes(change.OldValue),
+		NewValue: cloneBytes(change.NewValue),
+	}
+}
+
+// VProgStateDiff is a reversible set of changes to the vprog state, mapping
+// every changed entry to its value before and after the change
+type VProgStateDiff map[VProgStateKey]*VProgStateChange
+
+// NewVProgStateDiff creates a new, empty, VProgStateDiff
+func NewVProgStateDiff() VProgStateDiff {
+	return make(VProgStateDiff)
+}
+
+// AddChange applies a change of the given entry from oldValue to newValue on
+// top of this diff. Entries whose value ends up unchanged are removed from
+// the diff.
+func (diff VProgStateDiff) AddChange(key VProgStateKey, oldValue []byte, newValue []byte) {
+	if existing, ok := diff[key]; ok {
+		oldValue = existing.OldValue
+	}
+	if bytes.Equal(oldValue, newValue) {
+		delete(diff, key)
+		return
+	}
+	diff[key] = &VProgStateChange{
+		OldValue: cloneBytes(oldValue),
+		NewValue: cloneBytes(newValue),
+	}
+}
+
+// AddDiff applies all the changes in other on top of this diff
+func (diff VProgStateDiff) AddDiff(other VProgStateDiff) {
+	for key, change := range other {
+		diff.AddChange(key, change.OldValue, change.NewValue)
+	}
+}
+
+// Reversed returns a diff that undoes the changes in this diff
+func (diff VProgStateDiff) Reversed() VProgStateDiff {
+	reversed := make(VProgStateDiff, len(diff))
+	for key, change := range diff {
+		reversed[key] = &VProgStateChange{
+			OldValue: cloneBytes(change.NewValue),
+			NewValue: cloneBytes(change.OldValue),
+		}
+	}
+	return reversed
+}
+
+// SortedKeys returns the keys of all the changed entries in this diff,
+// ordered by program ID and then by key
+func (diff VProgStateDiff) SortedKeys() []VProgStateKey {
+	keys := make([]VProgStateKey, 0, len(diff))
+	for key := range diff {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Less(keys[j])
+	})
+	return keys
+}
+
+// Equal returns whether diff equals to other
+func (diff VProgStateDiff) Equal(other VProgStateDiff) bool {
+	if len(diff) != len(other) {
+		return false
+	}
+	for key, change := range diff {
+		otherChange, ok := other[key]
+		if !ok || !change.Equal(otherChange) {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone returns a clone of VProgStateDiff
+func (diff VProgStateDiff) Clone() VProgStateDiff {
+	clone := make(VProgStateDiff, len(diff))
+	for key, change := range diff {
+		clone[key] = change.Clone()
+	}
+	return clone
+}
+
+func cloneBytes(value []byte) []byte {
+	if value == nil {
+		return nil
+	}
+	clone := make([]byte, len(value))
+	copy(clone, value)
+	return clone
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+)
+
+func TestVProgStateDiff(t *testing.T) {
+	programID := *externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{1})
+	keyA := VProgStateKey{ProgramID: programID, Key: "a"}
+	keyB := VProgStateKey{ProgramID: programID, Key: "b"}
+
+	diff := NewVProgStateDiff()
+	diff.AddChange(keyA, nil, []byte{1})
+	diff.AddChange(keyA, []byte{1}, []byte{2})
+	diff.AddChange(keyB, []byte{5}, []byte{6})
+
+	expected := VProgStateDiff{
+		keyA: {OldValue: nil, NewValue: []byte{2}},
+		keyB: {OldValue: []byte{5}, NewValue: []byte{6}},
+	}
+	if !diff.Equal(expected) {
+		t.Fatalf("Unexpected diff after adding changes: %v", diff)
+	}
+
+	otherDiff := NewVProgStateDiff()
+	otherDiff.AddChange(keyB, []byte{6}, []byte{5})
+	diff.AddDiff(otherDiff)
+	if _, ok := diff[keyB]; ok {
+		t.Fatalf("Expected a change back to the original value to be removed from the diff")
+	}
+
+	reversed := diff.Reversed()
+	reversed.AddDiff(diff)
+	if len(reversed) != 0 {
+		t.Fatalf("Expected a diff combined with its reversal to be empty, but got %v", reversed)
+	}
+
+	clone := diff.Clone()
+	clone[keyA].NewValue[0] = 3
+	if diff[keyA].NewValue[0] != 2 {
+		t.Fatalf("Modifying a clone modified the original diff")
+	}
+
+	keyC := VProgStateKey{ProgramID: programID, Key: "c"}
+	diff.AddChange(keyC, nil, []byte{1})
+	sortedKeys := diff.SortedKeys()
+	if len(sortedKeys) != 2 || sortedKeys[0] != keyA || sortedKeys[1] != keyC {
+		t.Fatalf("Unexpected sorted keys: %v", sortedKeys)
+	}
+}
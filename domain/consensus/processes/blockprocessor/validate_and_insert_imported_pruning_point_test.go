@@ -1,11 +1,15 @@
 package blockprocessor_test
 
 import (
+	"bytes"
 	"github.com/zuanet/zuad/domain/consensus/model"
 	"github.com/zuanet/zuad/domain/consensus/utils/constants"
+	"github.com/zuanet/zuad/domain/consensus/utils/transactionhelper"
 	"github.com/zuanet/zuad/domain/consensus/utils/txscript"
+	"github.com/zuanet/zuad/domain/consensus/vprog"
 	"github.com/zuanet/zuad/domain/dagconfig"
 	"math"
+	"reflect"
 	"testing"
 	"time"
 
@@ -43,6 +47,15 @@ func TestValidateAndInsertImportedPruningPoint(t *testing.T) {
 		consensusConfig.FinalityDuration = time.Duration(finalityDepth) * consensusConfig.TargetTimePerBlock
 		consensusConfig.K = 0
 		consensusConfig.PruningProofM = 1
+		consensusConfig.BlockCoinbaseMaturity = 0
+		consensusConfig.EnableVProgs = true
+
+		// A counter that increments the value under the key 'c' and returns the new value
+		counterCode := []byte{
+			vprog.OpPush, 1, 'c', vprog.OpLoad, vprog.OpPush, 1, 1, vprog.OpAdd, vprog.OpDup,
+			vprog.OpPush, 1, 'c', vprog.OpSwap, vprog.OpStore, vprog.OpReturn,
+		}
+		counterProgramID := vprog.ProgramID(counterCode)
 
 		syncConsensuses := func(tcSyncerRef, tcSynceeRef *testapi.TestConsensus, updatePruningPointJustAfterImportingPruningPoint bool) {
 			tcSyncer, tcSyncee := *tcSyncerRef, *tcSynceeRef
@@ -190,6 +203,23 @@ func TestValidateAndInsertImportedPruningPoint(t *testing.T) {
 				}
 			}
 
+			var fromVProgStateEntry *externalapi.VProgStateEntry
+			var pruningPointVProgState []*externalapi.VProgStateEntry
+			for {
+				vprogStateEntries, err := tcSyncer.GetPruningPointVProgState(pruningPoint, fromVProgStateEntry, step)
+				if err != nil {
+					t.Fatalf("GetPruningPointVProgState: %+v", err)
+				}
+				pruningPointVProgState = append(pruningPointVProgState, vprogStateEntries...)
+				if len(vprogStateEntries) < step {
+					break
+				}
+				fromVProgStateEntry = vprogStateEntries[len(vprogStateEntries)-1]
+			}
+			if len(pruningPointVProgState) == 0 {
+				t.Fatalf("The vprog state of the pruning point is empty")
+			}
+
 			err = synceeStaging.AppendImportedPruningPointUTXOs(pruningPointUTXOs)
 			if err != nil {
 				t.Fatalf("AppendImportedPruningPointUTXOs: %+v", err)
@@ -230,6 +260,26 @@ func TestValidateAndInsertImportedPruningPoint(t *testing.T) {
 				t.Fatalf("AppendImportedPruningPointUTXOs: %+v", err)
 			}
 
+			// Check that ValidateAndInsertImportedPruningPoint fails if the vprog state of the pruning point is missing,
+			// since it's part of the UTXO commitment.
+			err = synceeStaging.ValidateAndInsertImportedPruningPoint(pruningPoint)
+			if !errors.Is(err, ruleerrors.ErrBadPruningPointUTXOSet) {
+				t.Fatalf("Unexpected error: %+v", err)
+			}
+
+			err = synceeStaging.ClearImportedPruningPointData()
+			if err != nil {
+				t.Fatalf("ClearImportedPruningPointData: %+v", err)
+			}
+			err = synceeStaging.AppendImportedPruningPointUTXOs(pruningPointUTXOs)
+			if err != nil {
+				t.Fatalf("AppendImportedPruningPointUTXOs: %+v", err)
+			}
+			err = synceeStaging.AppendImportedPruningPointVProgState(pruningPointVProgState)
+			if err != nil {
+				t.Fatalf("AppendImportedPruningPointVProgState: %+v", err)
+			}
+
 			// Check that ValidateAndInsertImportedPruningPoint works given the right arguments.
 			err = synceeStaging.ValidateAndInsertImportedPruningPoint(pruningPoint)
 			if err != nil {
@@ -322,6 +372,19 @@ func TestValidateAndInsertImportedPruningPoint(t *testing.T) {
 				t.Fatalf("The syncee pruning point has not changed as exepcted")
 			}
 
+			syncerCounterStorage, err := tcSyncer.GetVProgStorage(counterProgramID)
+			if err != nil {
+				t.Fatalf("GetVProgStorage: %+v", err)
+			}
+			synceeCounterStorage, err := synceeStaging.GetVProgStorage(counterProgramID)
+			if err != nil {
+				t.Fatalf("GetVProgStorage: %+v", err)
+			}
+			if !reflect.DeepEqual(synceeCounterStorage, syncerCounterStorage) {
+				t.Fatalf("The vprog storage of the syncee is %v while the syncer's is %v",
+					synceeCounterStorage, syncerCounterStorage)
+			}
+
 			*tcSynceeRef = synceeStaging
 		}
 
@@ -339,8 +402,10 @@ func TestValidateAndInsertImportedPruningPoint(t *testing.T) {
 
 		const numSharedBlocks = 2
 		tipHash := consensusConfig.GenesisHash
+		sharedBlockHashes := make([]*externalapi.DomainHash, 0, numSharedBlocks)
 		for i := 0; i < numSharedBlocks; i++ {
 			tipHash = addBlock(tcSyncer, []*externalapi.DomainHash{tipHash}, t)
+			sharedBlockHashes = append(sharedBlockHashes, tipHash)
 			block, _, err := tcSyncer.GetBlock(tipHash)
 			if err != nil {
 				t.Fatalf("GetBlock: %+v", err)
@@ -358,7 +423,15 @@ func TestValidateAndInsertImportedPruningPoint(t *testing.T) {
 			tipHashSyncee = addBlock(tcSyncee1, []*externalapi.DomainHash{tipHashSyncee}, t)
 		}
 
-		for i := 0; i < finalityDepth-numSharedBlocks-2; i++ {
+		// Call the counter below the pruning point, so that the vprog state of the pruning point isn't empty
+		firstCall := createVProgTransaction(t, tcSyncer, sharedBlockHashes[1], counterCode)
+		tipHash, _, err = tcSyncer.AddBlock([]*externalapi.DomainHash{tipHash}, nil,
+			[]*externalapi.DomainTransaction{firstCall})
+		if err != nil {
+			t.Fatalf("AddBlock: %+v", err)
+		}
+		firstCallBlockHash := tipHash
+		for i := 0; i < finalityDepth-numSharedBlocks-3; i++ {
 			tipHash = addBlock(tcSyncer, []*externalapi.DomainHash{tipHash}, t)
 		}
 
@@ -392,6 +465,22 @@ func TestValidateAndInsertImportedPruningPoint(t *testing.T) {
 			t.Fatalf("Unexpected pruning point %s", pruningPoint)
 		}
 
+		// Call the counter again above the pruning point, on top of the state of the pruning point
+		secondCall := createVProgTransaction(t, tcSyncer, firstCallBlockHash, counterCode)
+		tipHash, _, err = tcSyncer.AddBlock([]*externalapi.DomainHash{tipHash}, nil,
+			[]*externalapi.DomainTransaction{secondCall})
+		if err != nil {
+			t.Fatalf("AddBlock: %+v", err)
+		}
+		tipHash = addBlock(tcSyncer, []*externalapi.DomainHash{tipHash}, t)
+		counterStorage, err := tcSyncer.GetVProgStorage(counterProgramID)
+		if err != nil {
+			t.Fatalf("GetVProgStorage: %+v", err)
+		}
+		if !bytes.Equal(counterStorage["c"], []byte{2}) {
+			t.Fatalf("Unexpected counter value %v", counterStorage["c"])
+		}
+
 		tcSyncee1Ref := &tcSyncee1
 		syncConsensuses(&tcSyncer, tcSyncee1Ref, false)
 
@@ -416,6 +505,24 @@ func TestValidateAndInsertImportedPruningPoint(t *testing.T) {
 	})
 }
 
+func createVProgTransaction(t *testing.T, tc testapi.TestConsensus, blockHash *externalapi.DomainHash,
+	code []byte) *externalapi.DomainTransaction {
+
+	block, _, err := tc.GetBlock(blockHash)
+	if err != nil {
+		t.Fatalf("GetBlock: %+v", err)
+	}
+	transaction, err := testutils.CreateTransaction(block.Transactions[transactionhelper.CoinbaseTransactionIndex], 1)
+	if err != nil {
+		t.Fatalf("CreateTransaction: %+v", err)
+	}
+	transaction.VProgVersion = constants.VProgVersion
+	transaction.VProgCode = code
+	transaction.VProgData = []byte{}
+	transaction.VProgGasLimit = 100_000
+	return transaction
+}
+
 func makeFakeUTXOs() []*externalapi.OutpointAndUTXOEntryPair {
 	return []*externalapi.OutpointAndUTXOEntryPair{
 		{
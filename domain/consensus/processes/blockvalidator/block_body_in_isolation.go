@@ -68,6 +68,11 @@ func (v *blockValidator) ValidateBodyInIsolation(stagingArea *model.StagingArea,
 		return err
 	}
 
+	err = v.checkBlockVProgGas(block)
+	if err != nil {
+		return err
+	}
+
 	err = v.checkBlockDuplicateTransactions(block)
 	if err != nil {
 		return err
@@ -234,6 +239,20 @@ func (v *blockValidator) checkBlockMass(block *externalapi.DomainBlock) error {
 	return nil
 }
 
+func (v *blockValidator) checkBlockVProgGas(block *externalapi.DomainBlock) error {
+	gas := uint64(0)
+	for _, transaction := range block.Transactions {
+		gasBefore := gas
+		gas += transaction.VProgGasLimit
+		if gas > v.maxBlockVProgGas || gas < gasBefore {
+			return errors.Wrapf(ruleerrors.ErrBlockVProgGasTooHigh, "block exceeded the vprog gas limit of %d",
+				v.maxBlockVProgGas)
+		}
+	}
+
+	return nil
+}
+
 func (v *blockValidator) checkNoPrefilledInputs(block *externalapi.DomainBlock) error {
 	for _, tx := range block.Transactions {
 		for i, input := range tx.Inputs {
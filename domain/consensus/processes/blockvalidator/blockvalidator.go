@@ -20,6 +20,7 @@ type blockValidator struct {
 	enableNonNativeSubnetworks  bool
 	powMaxBits                  uint32
 	maxBlockMass                uint64
+	maxBlockVProgGas            uint64
 	mergeSetSizeLimit           uint64
 	maxBlockParents             externalapi.KType
 	timestampDeviationTolerance int
@@ -59,6 +60,7 @@ func New(powMax *big.Int,
 	genesisHash *externalapi.DomainHash,
 	enableNonNativeSubnetworks bool,
 	maxBlockMass uint64,
+	maxBlockVProgGas uint64,
 	mergeSetSizeLimit uint64,
 	maxBlockParents externalapi.KType,
 	timestampDeviationTolerance int,
@@ -100,6 +102,7 @@ func New(powMax *big.Int,
 		enableNonNativeSubnetworks: enableNonNativeSubnetworks,
 		powMaxBits:                 difficulty.BigToCompact(powMax),
 		maxBlockMass:               maxBlockMass,
+		maxBlockVProgGas:           maxBlockVProgGas,
 		mergeSetSizeLimit:          mergeSetSizeLimit,
 		maxBlockParents:            maxBlockParents,
 		maxBlockLevel:              maxBlockLevel,
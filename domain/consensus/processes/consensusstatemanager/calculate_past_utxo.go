@@ -10,6 +10,7 @@ import (
 	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
 	"github.com/zuanet/zuad/domain/consensus/ruleerrors"
 	"github.com/zuanet/zuad/domain/consensus/utils/transactionhelper"
+	"github.com/zuanet/zuad/domain/consensus/vprog"
 )
 
 func (csm *consensusStateManager) CalculatePastUTXOAndAcceptanceData(stagingArea *model.StagingArea,
@@ -67,14 +68,27 @@ func (csm *consensusStateManager) calculatePastUTXOAndAcceptanceDataWithSelected
 		return nil, nil, nil, err
 	}
 
+	var selectedParentVProgState *vprogState
+	if !blockHash.Equal(csm.genesisHash) {
+		log.Debugf("Restoring the vprog state of the selected parent %s", blockGHOSTDAGData.SelectedParent())
+		selectedParentVProgState, err = csm.restoreVProgState(stagingArea, blockGHOSTDAGData.SelectedParent())
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
 	log.Debugf("Applying blue blocks to the selected parent past UTXO of block %s", blockHash)
-	acceptanceData, utxoDiff, err := csm.applyMergeSetBlocks(stagingArea, blockHash, selectedParentPastUTXO, daaScore)
+	acceptanceData, utxoDiff, vprogStateDiff, err := csm.applyMergeSetBlocks(
+		stagingArea, blockHash, selectedParentPastUTXO, selectedParentVProgState, daaScore)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
+	log.Debugf("Staging the vprog state diff of block %s", blockHash)
+	csm.vprogStateStore.StageDiff(stagingArea, blockHash, vprogStateDiff)
+
 	log.Debugf("Calculating the multiset of %s", blockHash)
-	multiset, err := csm.calculateMultiset(stagingArea, blockHash, acceptanceData, blockGHOSTDAGData, daaScore)
+	multiset, err := csm.calculateMultiset(stagingArea, blockHash, acceptanceData, vprogStateDiff, blockGHOSTDAGData, daaScore)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -142,31 +156,32 @@ func (csm *consensusStateManager) restorePastUTXO(
 }
 
 func (csm *consensusStateManager) applyMergeSetBlocks(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash,
-	selectedParentPastUTXODiff externalapi.UTXODiff, daaScore uint64) (
-	externalapi.AcceptanceData, externalapi.MutableUTXODiff, error) {
+	selectedParentPastUTXODiff externalapi.UTXODiff, selectedParentVProgState *vprogState, daaScore uint64) (
+	externalapi.AcceptanceData, externalapi.MutableUTXODiff, model.VProgStateDiff, error) {
 
 	log.Tracef("applyMergeSetBlocks start for block %s", blockHash)
 	defer log.Tracef("applyMergeSetBlocks end for block %s", blockHash)
 
 	mergeSetHashes, err := csm.ghostdagManager.GetSortedMergeSet(stagingArea, blockHash)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	log.Debugf("Merge set for block %s is %v", blockHash, mergeSetHashes)
 	mergeSetBlocks, err := csm.blockStore.Blocks(csm.databaseContext, stagingArea, mergeSetHashes)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	selectedParentMedianTime, err := csm.pastMedianTimeManager.PastMedianTime(stagingArea, blockHash)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	log.Tracef("The past median time for block %s is: %d", blockHash, selectedParentMedianTime)
 
 	multiblockAcceptanceData := make(externalapi.AcceptanceData, len(mergeSetBlocks))
 	accumulatedUTXODiff := selectedParentPastUTXODiff.CloneMutable()
 	accumulatedMass := uint64(0)
+	vprogStateDiff := vprog.NewStateDiff()
 
 	for i, mergeSetBlock := range mergeSetBlocks {
 		mergeSetBlockHash := consensushashing.BlockHash(mergeSetBlock)
@@ -187,17 +202,28 @@ func (csm *consensusStateManager) applyMergeSetBlocks(stagingArea *model.Staging
 			isAccepted, accumulatedMass, err = csm.maybeAcceptTransaction(stagingArea, transaction, blockHash,
 				isSelectedParent, accumulatedUTXODiff, accumulatedMass, selectedParentMedianTime, daaScore)
 			if err != nil {
-				return nil, nil, err
+				return nil, nil, nil, err
 			}
 			log.Tracef("Transaction %s in block %s isAccepted: %t, fee: %d",
 				transactionID, mergeSetBlockHash, isAccepted, transaction.Fee)
 
 			var transactionInputUTXOEntries []externalapi.UTXOEntry
+			var vprogResult *externalapi.VProgExecutionResult
 			if isAccepted {
 				transactionInputUTXOEntries = make([]externalapi.UTXOEntry, len(transaction.Inputs))
 				for k, input := range transaction.Inputs {
 					transactionInputUTXOEntries[k] = input.UTXOEntry
 				}
+
+				if transactionhelper.IsVProgTransaction(transaction) {
+					vprogResult, err = csm.executeVProgTransaction(transaction, vprogStateDiff, selectedParentVProgState)
+					if err != nil {
+						return nil, nil, nil, err
+					}
+					if vprogResult.Success {
+						csm.vprogStateStore.StageCode(stagingArea, vprogResult.ProgramID, transaction.VProgCode)
+					}
+				}
 			}
 
 			blockAcceptanceData.TransactionAcceptanceData[j] = &externalapi.TransactionAcceptanceData{
@@ -205,12 +231,18 @@ func (csm *consensusStateManager) applyMergeSetBlocks(stagingArea *model.Staging
 				Fee:                         transaction.Fee,
 				IsAccepted:                  isAccepted,
 				TransactionInputUTXOEntries: transactionInputUTXOEntries,
+				VProgResult:                 vprogResult,
 			}
 		}
 		multiblockAcceptanceData[i] = blockAcceptanceData
 	}
 
-	return multiblockAcceptanceData, accumulatedUTXODiff, nil
+	blockVProgStateDiff, err := selectedParentVProgState.diffWithStateChanges(vprogStateDiff)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return multiblockAcceptanceData, accumulatedUTXODiff, blockVProgStateDiff, nil
 }
 
 func (csm *consensusStateManager) maybeAcceptTransaction(stagingArea *model.StagingArea,
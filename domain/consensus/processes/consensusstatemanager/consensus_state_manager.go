@@ -3,6 +3,7 @@ package consensusstatemanager
 import (
 	"github.com/zuanet/zuad/domain/consensus/model"
 	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/vprog"
 )
 
 // consensusStateManager manages the node's consensus state
@@ -34,6 +35,9 @@ type consensusStateManager struct {
 	blockHeaderStore        model.BlockHeaderStore
 	pruningStore            model.PruningStore
 	daaBlocksStore          model.DAABlocksStore
+	vprogStateStore         model.VProgStateStore
+
+	vprogEngine *vprog.ExecutionEngine
 
 	stores []model.Store
 }
@@ -66,7 +70,8 @@ func New(
 	blockHeaderStore model.BlockHeaderStore,
 	headersSelectedTipStore model.HeaderSelectedTipStore,
 	pruningStore model.PruningStore,
-	daaBlocksStore model.DAABlocksStore) (model.ConsensusStateManager, error) {
+	daaBlocksStore model.DAABlocksStore,
+	vprogStateStore model.VProgStateStore) (model.ConsensusStateManager, error) {
 
 	csm := &consensusStateManager{
 		maxBlockParents:   maxBlockParents,
@@ -97,6 +102,9 @@ func New(
 		headersSelectedTipStore: headersSelectedTipStore,
 		pruningStore:            pruningStore,
 		daaBlocksStore:          daaBlocksStore,
+		vprogStateStore:         vprogStateStore,
+
+		vprogEngine: vprog.NewExecutionEngine(),
 
 		stores: []model.Store{
 			consensusStateStore,
@@ -111,6 +119,7 @@ func New(
 			blockHeaderStore,
 			headersSelectedTipStore,
 			pruningStore,
+			vprogStateStore,
 		},
 	}
 
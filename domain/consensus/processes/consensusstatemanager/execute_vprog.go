@@ -0,0 +1,49 @@
+package consensusstatemanager
+
+import (
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/utils/consensushashing"
+	"github.com/zuanet/zuad/domain/consensus/vprog"
+)
+
+// executeVProgTransaction executes the vprog of an accepted transaction on top
+// of the given state diff and base state, and adds its state changes to the
+// diff if the execution succeeded.
+//
+// A failed execution does not make the transaction invalid: it remains
+// accepted, pays its fee, and its outputs are added to the UTXO set, but its
+// state changes are discarded.
+func (csm *consensusStateManager) executeVProgTransaction(transaction *externalapi.DomainTransaction,
+	stateDiff vprog.StateDiff, baseState *vprogState) (*externalapi.VProgExecutionResult, error) {
+
+	transactionID := consensushashing.TransactionID(transaction)
+	programID := vprog.ProgramID(transaction.VProgCode)
+	log.Tracef("Executing vprog %s of transaction %s", programID, transactionID)
+
+	result, err := csm.vprogEngine.ExecuteWithStorage(transaction.VProgCode, transaction.VProgData,
+		transaction.VProgGasLimit, stateDiff.StorageReader(programID, baseState.programStorage(programID)))
+	if err != nil {
+		return nil, err
+	}
+	log.Tracef("Execution of vprog %s of transaction %s success: %t, gas used: %d",
+		programID, transactionID, result.Success, result.GasUsed)
+
+	if !result.Success {
+		return &externalapi.VProgExecutionResult{
+			ProgramID:  programID,
+			Success:    false,
+			GasUsed:    result.GasUsed,
+			ReturnData: result.ReturnData,
+			ErrorCode:  uint32(result.ErrorCode),
+		}, nil
+	}
+
+	stateDiff.AddStateChanges(programID, result.StateChanges)
+	return &externalapi.VProgExecutionResult{
+		ProgramID:    programID,
+		Success:      true,
+		GasUsed:      result.GasUsed,
+		ReturnData:   result.ReturnData,
+		StateChanges: result.StateChanges,
+	}, nil
+}
@@ -0,0 +1,137 @@
+package consensusstatemanager_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/zuanet/zuad/domain/consensus"
+	"github.com/zuanet/zuad/domain/consensus/model"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/model/testapi"
+	"github.com/zuanet/zuad/domain/consensus/utils/consensushashing"
+	"github.com/zuanet/zuad/domain/consensus/utils/constants"
+	"github.com/zuanet/zuad/domain/consensus/utils/testutils"
+	"github.com/zuanet/zuad/domain/consensus/utils/transactionhelper"
+	"github.com/zuanet/zuad/domain/consensus/vprog"
+)
+
+func TestVProgExecution(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+		consensusConfig.EnableVProgs = true
+		factory := consensus.NewFactory()
+
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestVProgExecution")
+		if err != nil {
+			t.Fatalf("Error setting up consensus: %+v", err)
+		}
+		defer teardown(false)
+
+		// Build the following DAG:
+		// G <- A <- B <- C <- D <- E
+		// Where block D has two vprog transactions: one that stores a value and
+		// one that reverts. Block E accepts both.
+		blockAHash, _, err := tc.AddBlock([]*externalapi.DomainHash{consensusConfig.GenesisHash}, nil, nil)
+		if err != nil {
+			t.Fatalf("Error creating block A: %+v", err)
+		}
+		blockBHash, _, err := tc.AddBlock([]*externalapi.DomainHash{blockAHash}, nil, nil)
+		if err != nil {
+			t.Fatalf("Error creating block B: %+v", err)
+		}
+		blockCHash, _, err := tc.AddBlock([]*externalapi.DomainHash{blockBHash}, nil, nil)
+		if err != nil {
+			t.Fatalf("Error creating block C: %+v", err)
+		}
+
+		storeCode := []byte{vprog.OpPush, 1, 'k', vprog.OpPush, 1, 42, vprog.OpStore, vprog.OpStop}
+		storeTransaction := createVProgTransaction(t, tc, blockBHash, storeCode)
+		revertCode := []byte{vprog.OpPush, 1, 1, vprog.OpRevert}
+		revertTransaction := createVProgTransaction(t, tc, blockCHash, revertCode)
+
+		blockDHash, _, err := tc.AddBlock([]*externalapi.DomainHash{blockCHash}, nil,
+			[]*externalapi.DomainTransaction{storeTransaction, revertTransaction})
+		if err != nil {
+			t.Fatalf("Error creating block D: %+v", err)
+		}
+		blockEHash, _, err := tc.AddBlock([]*externalapi.DomainHash{blockDHash}, nil, nil)
+		if err != nil {
+			t.Fatalf("Error creating block E: %+v", err)
+		}
+
+		acceptanceData, err := tc.AcceptanceDataStore().Get(tc.DatabaseContext(), model.NewStagingArea(), blockEHash)
+		if err != nil {
+			t.Fatalf("Error getting the acceptance data of block E: %+v", err)
+		}
+		var blockDAcceptanceData *externalapi.BlockAcceptanceData
+		for _, blockAcceptanceData := range acceptanceData {
+			if blockAcceptanceData.BlockHash.Equal(blockDHash) {
+				blockDAcceptanceData = blockAcceptanceData
+			}
+		}
+		if blockDAcceptanceData == nil {
+			t.Fatalf("Block D is missing from the acceptance data of block E")
+		}
+
+		storeResult := findVProgResult(t, blockDAcceptanceData, storeTransaction)
+		if !storeResult.Success {
+			t.Fatalf("Expected the store transaction to succeed but it failed with error code %d",
+				storeResult.ErrorCode)
+		}
+		if !storeResult.ProgramID.Equal(vprog.ProgramID(storeCode)) {
+			t.Fatalf("Unexpected program ID %s", storeResult.ProgramID)
+		}
+		if len(storeResult.StateChanges) != 1 || !bytes.Equal(storeResult.StateChanges["k"], []byte{42}) {
+			t.Fatalf("Unexpected state changes %v", storeResult.StateChanges)
+		}
+
+		revertResult := findVProgResult(t, blockDAcceptanceData, revertTransaction)
+		if revertResult.Success {
+			t.Fatalf("Expected the revert transaction to fail")
+		}
+		if revertResult.ErrorCode != uint32(vprog.ErrReverted) {
+			t.Fatalf("Unexpected error code %d", revertResult.ErrorCode)
+		}
+		if len(revertResult.StateChanges) != 0 {
+			t.Fatalf("Expected a failed execution to have no state changes, got %v", revertResult.StateChanges)
+		}
+	})
+}
+
+func createVProgTransaction(t *testing.T, tc testapi.TestConsensus, blockHash *externalapi.DomainHash,
+	code []byte) *externalapi.DomainTransaction {
+
+	block, _, err := tc.GetBlock(blockHash)
+	if err != nil {
+		t.Fatalf("Error getting block %s: %+v", blockHash, err)
+	}
+	transaction, err := testutils.CreateTransaction(block.Transactions[transactionhelper.CoinbaseTransactionIndex], 1)
+	if err != nil {
+		t.Fatalf("Error creating transaction: %+v", err)
+	}
+	transaction.VProgVersion = constants.VProgVersion
+	transaction.VProgCode = code
+	transaction.VProgData = []byte{}
+	transaction.VProgGasLimit = 100_000
+	return transaction
+}
+
+func findVProgResult(t *testing.T, blockAcceptanceData *externalapi.BlockAcceptanceData,
+	transaction *externalapi.DomainTransaction) *externalapi.VProgExecutionResult {
+
+	transactionID := consensushashing.TransactionID(transaction)
+	for _, transactionAcceptanceData := range blockAcceptanceData.TransactionAcceptanceData {
+		if !consensushashing.TransactionID(transactionAcceptanceData.Transaction).Equal(transactionID) {
+			continue
+		}
+		if !transactionAcceptanceData.IsAccepted {
+			t.Fatalf("Transaction %s was not accepted", transactionID)
+		}
+		if transactionAcceptanceData.VProgResult == nil {
+			t.Fatalf("Transaction %s has no vprog result", transactionID)
+		}
+		return transactionAcceptanceData.VProgResult
+	}
+	t.Fatalf("Transaction %s is missing from the acceptance data", transactionID)
+	return nil
+}
@@ -187,6 +187,12 @@ func (csm *consensusStateManager) importVirtualUTXOSetAndPruningPointUTXOSet(pru
 		return err
 	}
 
+	log.Debugf("Importing the vprog state of the pruning point")
+	err = csm.vprogStateStore.ImportPruningPointState(csm.databaseContext, pruningPoint)
+	if err != nil {
+		return err
+	}
+
 	log.Debugf("Importing the new pruning point UTXO set")
 	err = csm.pruningStore.CommitImportedPruningPointUTXOSet(csm.databaseContext)
 	if err != nil {
@@ -10,6 +10,7 @@ import (
 func (csm *consensusStateManager) calculateMultiset(stagingArea *model.StagingArea,
 	blockHash *externalapi.DomainHash,
 	acceptanceData externalapi.AcceptanceData,
+	vprogStateDiff model.VProgStateDiff,
 	blockGHOSTDAGData *externalapi.BlockGHOSTDAGData,
 	daaScore uint64) (model.Multiset, error) {
 
@@ -48,6 +49,9 @@ func (csm *consensusStateManager) calculateMultiset(stagingArea *model.StagingAr
 		}
 	}
 
+	log.Tracef("Adding %d vprog state changes to the multiset", len(vprogStateDiff))
+	addVProgStateDiffToMultiset(ms, vprogStateDiff)
+
 	return ms, nil
 }
 
@@ -107,3 +111,17 @@ func removeUTXOFromMultiset(multiset model.Multiset, entry externalapi.UTXOEntry
 
 	return nil
 }
+
+// addVProgStateDiffToMultiset replaces the old values of all the entries in the given
+// vprog state diff with their new values, thereby folding the vprog state into the
+// UTXO commitment
+func addVProgStateDiffToMultiset(multiset model.Multiset, vprogStateDiff model.VProgStateDiff) {
+	for key, change := range vprogStateDiff {
+		if len(change.OldValue) > 0 {
+			multiset.Remove(consensushashing.VProgStateEntryHash(&key.ProgramID, []byte(key.Key), change.OldValue).ByteSlice())
+		}
+		if len(change.NewValue) > 0 {
+			multiset.Add(consensushashing.VProgStateEntryHash(&key.ProgramID, []byte(key.Key), change.NewValue).ByteSlice())
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package consensusstatemanager
+
+import (
+	"github.com/zuanet/zuad/domain/consensus/model"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/vprog"
+)
+
+// SimulateVProgCall executes the given vprog code with the given input on top of the
+// vprog state of the virtual block. Nothing is staged: the state changes of the
+// execution are only reported in the returned result.
+func (csm *consensusStateManager) SimulateVProgCall(stagingArea *model.StagingArea, code []byte, input []byte,
+	gasLimit uint64) (*externalapi.VProgExecutionResult, error) {
+
+	virtualVProgState, err := csm.restoreVirtualVProgState(stagingArea)
+	if err != nil {
+		return nil, err
+	}
+
+	programID := vprog.ProgramID(code)
+	log.Debugf("Simulating a call to vprog %s with a gas limit of %d", programID, gasLimit)
+
+	result, err := csm.vprogEngine.ExecuteWithStorage(code, input, gasLimit, virtualVProgState.programStorage(programID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &externalapi.VProgExecutionResult{
+		ProgramID:    programID,
+		Success:      result.Success,
+		GasUsed:      result.GasUsed,
+		ReturnData:   result.ReturnData,
+		StateChanges: result.StateChanges,
+		ErrorCode:    uint32(result.ErrorCode),
+	}, nil
+}
+
+// VirtualVProgState returns all the entries of the given program in the vprog state of the virtual block
+func (csm *consensusStateManager) VirtualVProgState(stagingArea *model.StagingArea,
+	programID *externalapi.DomainHash) (map[string][]byte, error) {
+
+	virtualVProgState, err := csm.restoreVirtualVProgState(stagingArea)
+	if err != nil {
+		return nil, err
+	}
+	return virtualVProgState.programState(programID)
+}
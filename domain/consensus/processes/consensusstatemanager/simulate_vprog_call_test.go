@@ -0,0 +1,96 @@
+package consensusstatemanager_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/zuanet/zuad/domain/consensus"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/utils/testutils"
+	"github.com/zuanet/zuad/domain/consensus/vprog"
+)
+
+func TestSimulateVProgCall(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+		consensusConfig.EnableVProgs = true
+		factory := consensus.NewFactory()
+
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestSimulateVProgCall")
+		if err != nil {
+			t.Fatalf("Error setting up consensus: %+v", err)
+		}
+		defer teardown(false)
+
+		// A counter that increments the value under the key 'c' and returns the new value
+		counterCode := []byte{
+			vprog.OpPush, 1, 'c', vprog.OpLoad, vprog.OpPush, 1, 1, vprog.OpAdd, vprog.OpDup,
+			vprog.OpPush, 1, 'c', vprog.OpSwap, vprog.OpStore, vprog.OpReturn,
+		}
+		programID := vprog.ProgramID(counterCode)
+
+		_, found, err := tc.GetVProgCode(programID)
+		if err != nil {
+			t.Fatalf("GetVProgCode: %+v", err)
+		}
+		if found {
+			t.Fatalf("Expected the counter not to be deployed yet")
+		}
+
+		// Deploy the counter by calling it once, and have the call accepted by the virtual:
+		// G <- A <- B <- C
+		blockAHash, _, err := tc.AddBlock([]*externalapi.DomainHash{consensusConfig.GenesisHash}, nil, nil)
+		if err != nil {
+			t.Fatalf("Error adding block: %+v", err)
+		}
+		blockBHash, _, err := tc.AddBlock([]*externalapi.DomainHash{blockAHash}, nil, nil)
+		if err != nil {
+			t.Fatalf("Error adding block: %+v", err)
+		}
+		deployment := createVProgTransaction(t, tc, blockBHash, counterCode)
+		_, _, err = tc.AddBlock([]*externalapi.DomainHash{blockBHash}, nil,
+			[]*externalapi.DomainTransaction{deployment})
+		if err != nil {
+			t.Fatalf("Error adding block: %+v", err)
+		}
+
+		code, found, err := tc.GetVProgCode(programID)
+		if err != nil {
+			t.Fatalf("GetVProgCode: %+v", err)
+		}
+		if !found || !bytes.Equal(code, counterCode) {
+			t.Fatalf("Expected the counter to be deployed, but got %v (found: %t)", code, found)
+		}
+
+		result, err := tc.SimulateVProgCall(counterCode, nil, consensusConfig.MaxVProgGasLimit)
+		if err != nil {
+			t.Fatalf("SimulateVProgCall: %+v", err)
+		}
+		if !result.Success || !bytes.Equal(result.ReturnData, []byte{2}) {
+			t.Fatalf("Unexpected simulation result: %+v", result)
+		}
+		if result.GasUsed == 0 {
+			t.Fatalf("Expected the simulation to use gas")
+		}
+		if len(result.StateChanges) != 1 || !bytes.Equal(result.StateChanges["c"], []byte{2}) {
+			t.Fatalf("Unexpected state changes of the simulation: %v", result.StateChanges)
+		}
+
+		// The simulation must not modify the state of the program
+		storage, err := tc.GetVProgStorage(programID)
+		if err != nil {
+			t.Fatalf("GetVProgStorage: %+v", err)
+		}
+		if len(storage) != 1 || !bytes.Equal(storage["c"], []byte{1}) {
+			t.Fatalf("Unexpected storage after the simulation: %v", storage)
+		}
+
+		result, err = tc.SimulateVProgCall(counterCode, nil, 1)
+		if err != nil {
+			t.Fatalf("SimulateVProgCall: %+v", err)
+		}
+		if result.Success {
+			t.Fatalf("Expected the simulation to run out of gas")
+		}
+	})
+}
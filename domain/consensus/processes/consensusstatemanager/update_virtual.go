@@ -69,6 +69,16 @@ func (csm *consensusStateManager) updateVirtualWithParents(
 		return nil, err
 	}
 
+	newVirtualSelectedParent, err := csm.virtualSelectedParent(stagingArea)
+	if err != nil {
+		return nil, err
+	}
+	log.Debugf("Moving the vprog state to the new virtual selected parent %s", newVirtualSelectedParent)
+	err = csm.moveVProgStateToBlock(stagingArea, newVirtualSelectedParent)
+	if err != nil {
+		return nil, err
+	}
+
 	// This is needed for `csm.CalculatePastUTXOAndAcceptanceData`
 	_, err = csm.difficultyManager.StageDAADataAndReturnRequiredDifficulty(stagingArea, model.VirtualBlockHash, false)
 	if err != nil {
@@ -0,0 +1,194 @@
+package consensusstatemanager
+
+import (
+	"github.com/zuanet/zuad/domain/consensus/model"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/vprog"
+	"github.com/zuanet/zuad/infrastructure/logger"
+	"github.com/pkg/errors"
+)
+
+// vprogState is a read-only view of the vprog state of a single block. It is
+// made of the state held by the vprogStateStore and the diff that takes it to
+// the state of said block.
+type vprogState struct {
+	csm         *consensusStateManager
+	stagingArea *model.StagingArea
+	diff        model.VProgStateDiff
+}
+
+func (state *vprogState) value(key model.VProgStateKey) (value []byte, found bool, err error) {
+	if change, ok := state.diff[key]; ok {
+		return change.NewValue, len(change.NewValue) > 0, nil
+	}
+	return state.csm.vprogStateStore.StateValue(state.csm.databaseContext, state.stagingArea, key)
+}
+
+// programStorage returns a vprog.StorageReader for the storage of the given program
+func (state *vprogState) programStorage(programID *externalapi.DomainHash) vprog.StorageReader {
+	return &vprogProgramStorage{
+		state:     state,
+		programID: *programID,
+	}
+}
+
+// diffWithStateChanges builds a reversible diff out of the given state changes that
+// were made on top of this state
+func (state *vprogState) diffWithStateChanges(stateChanges vprog.StateDiff) (model.VProgStateDiff, error) {
+	diff := model.NewVProgStateDiff()
+	for programID, programStateChanges := range stateChanges {
+		for key, newValue := range programStateChanges {
+			stateKey := model.VProgStateKey{ProgramID: programID, Key: key}
+			oldValue, _, err := state.value(stateKey)
+			if err != nil {
+				return nil, err
+			}
+			diff.AddChange(stateKey, oldValue, newValue)
+		}
+	}
+	return diff, nil
+}
+
+// programState returns all the entries of the given program in this state
+func (state *vprogState) programState(programID *externalapi.DomainHash) (map[string][]byte, error) {
+	programState, err := state.csm.vprogStateStore.ProgramState(state.csm.databaseContext, state.stagingArea, programID)
+	if err != nil {
+		return nil, err
+	}
+	for key, change := range state.diff {
+		if !key.ProgramID.Equal(programID) {
+			continue
+		}
+		if len(change.NewValue) == 0 {
+			delete(programState, key.Key)
+			continue
+		}
+		programState[key.Key] = change.NewValue
+	}
+	return programState, nil
+}
+
+type vprogProgramStorage struct {
+	state     *vprogState
+	programID externalapi.DomainHash
+}
+
+func (storage *vprogProgramStorage) Get(key []byte) (value []byte, found bool, err error) {
+	return storage.state.value(model.VProgStateKey{ProgramID: storage.programID, Key: string(key)})
+}
+
+// restoreVProgState returns the vprog state of the given block
+func (csm *consensusStateManager) restoreVProgState(stagingArea *model.StagingArea,
+	blockHash *externalapi.DomainHash) (*vprogState, error) {
+
+	diff, err := csm.vprogStateDiffFromStateBlock(stagingArea, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	return &vprogState{
+		csm:         csm,
+		stagingArea: stagingArea,
+		diff:        diff,
+	}, nil
+}
+
+// restoreVirtualVProgState returns the vprog state of the virtual block, which is the
+// vprog state of the virtual selected parent with the diff of the virtual applied on top
+func (csm *consensusStateManager) restoreVirtualVProgState(stagingArea *model.StagingArea) (*vprogState, error) {
+	virtualSelectedParent, err := csm.virtualSelectedParent(stagingArea)
+	if err != nil {
+		return nil, err
+	}
+	state, err := csm.restoreVProgState(stagingArea, virtualSelectedParent)
+	if err != nil {
+		return nil, err
+	}
+	virtualDiff, err := csm.blockVProgStateDiff(stagingArea, model.VirtualBlockHash)
+	if err != nil {
+		return nil, err
+	}
+	state.diff.AddDiff(virtualDiff)
+	return state, nil
+}
+
+// moveVProgStateToBlock stages the vprog state of the given block as the state held by the vprogStateStore
+func (csm *consensusStateManager) moveVProgStateToBlock(stagingArea *model.StagingArea,
+	blockHash *externalapi.DomainHash) error {
+
+	onEnd := logger.LogAndMeasureExecutionTime(log, "moveVProgStateToBlock")
+	defer onEnd()
+
+	diff, err := csm.vprogStateDiffFromStateBlock(stagingArea, blockHash)
+	if err != nil {
+		return err
+	}
+	log.Debugf("Moving the vprog state to block %s with a diff of %d entries", blockHash, len(diff))
+	csm.vprogStateStore.StageState(stagingArea, blockHash, diff)
+	return nil
+}
+
+// vprogStateDiffFromStateBlock returns the diff between the vprog state held by the
+// vprogStateStore and the vprog state of the given block. It is calculated by reverting
+// the diffs of the selected chain blocks down to the common chain ancestor of the two
+// blocks, and then applying the diffs of the selected chain blocks up to the given block.
+func (csm *consensusStateManager) vprogStateDiffFromStateBlock(stagingArea *model.StagingArea,
+	blockHash *externalapi.DomainHash) (model.VProgStateDiff, error) {
+
+	stateBlockHash, err := csm.vprogStateBlockHash(stagingArea)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := model.NewVProgStateDiff()
+	if stateBlockHash.Equal(blockHash) {
+		return diff, nil
+	}
+
+	chainPath, err := csm.dagTraversalManager.CalculateChainPath(stagingArea, stateBlockHash, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	for _, removedBlockHash := range chainPath.Removed {
+		blockDiff, err := csm.blockVProgStateDiff(stagingArea, removedBlockHash)
+		if err != nil {
+			return nil, err
+		}
+		diff.AddDiff(blockDiff.Reversed())
+	}
+	for _, addedBlockHash := range chainPath.Added {
+		blockDiff, err := csm.blockVProgStateDiff(stagingArea, addedBlockHash)
+		if err != nil {
+			return nil, err
+		}
+		diff.AddDiff(blockDiff)
+	}
+
+	return diff, nil
+}
+
+func (csm *consensusStateManager) vprogStateBlockHash(stagingArea *model.StagingArea) (*externalapi.DomainHash, error) {
+	hasStateBlockHash, err := csm.vprogStateStore.HasStateBlockHash(csm.databaseContext, stagingArea)
+	if err != nil {
+		return nil, err
+	}
+	if !hasStateBlockHash {
+		// The state was never moved, which means that no vprog had
+		// been executed so far and the state of the virtual selected
+		// parent is empty
+		return csm.virtualSelectedParent(stagingArea)
+	}
+	return csm.vprogStateStore.StateBlockHash(csm.databaseContext, stagingArea)
+}
+
+// blockVProgStateDiff returns the vprog state diff of the given block relative to
+// its selected parent. Every block whose UTXO state was resolved has a stored diff,
+// so a missing diff means that the vprog state can't be restored.
+func (csm *consensusStateManager) blockVProgStateDiff(stagingArea *model.StagingArea,
+	blockHash *externalapi.DomainHash) (model.VProgStateDiff, error) {
+
+	diff, err := csm.vprogStateStore.Diff(csm.databaseContext, stagingArea, blockHash)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get the vprog state diff of block %s", blockHash)
+	}
+	return diff, nil
+}
@@ -0,0 +1,135 @@
+package consensusstatemanager_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/zuanet/zuad/domain/consensus"
+	"github.com/zuanet/zuad/domain/consensus/model"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/model/testapi"
+	"github.com/zuanet/zuad/domain/consensus/utils/testutils"
+	"github.com/zuanet/zuad/domain/consensus/vprog"
+)
+
+func TestVProgStatePersistence(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+		consensusConfig.EnableVProgs = true
+		factory := consensus.NewFactory()
+
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestVProgStatePersistence")
+		if err != nil {
+			t.Fatalf("Error setting up consensus: %+v", err)
+		}
+		defer teardown(false)
+
+		// A counter that increments the value under the key 'c' and returns the new value
+		counterCode := []byte{
+			vprog.OpPush, 1, 'c', vprog.OpLoad, vprog.OpPush, 1, 1, vprog.OpAdd, vprog.OpDup,
+			vprog.OpPush, 1, 'c', vprog.OpSwap, vprog.OpStore, vprog.OpReturn,
+		}
+		counterKey := model.VProgStateKey{ProgramID: *vprog.ProgramID(counterCode), Key: "c"}
+
+		addBlock := func(parentHash *externalapi.DomainHash,
+			transactions []*externalapi.DomainTransaction) *externalapi.DomainHash {
+
+			blockHash, _, err := tc.AddBlock([]*externalapi.DomainHash{parentHash}, nil, transactions)
+			if err != nil {
+				t.Fatalf("Error adding block: %+v", err)
+			}
+			return blockHash
+		}
+		addChain := func(parentHash *externalapi.DomainHash, length int) *externalapi.DomainHash {
+			for i := 0; i < length; i++ {
+				parentHash = addBlock(parentHash, nil)
+			}
+			return parentHash
+		}
+
+		// Build the following DAG:
+		// G <- A <- B <- C <- D <- E <- F <- G1 <- H
+		// Where blocks E and G1 each call the counter once. Blocks F and H accept them.
+		blockAHash := addBlock(consensusConfig.GenesisHash, nil)
+		blockBHash := addBlock(blockAHash, nil)
+		blockCHash := addBlock(blockBHash, nil)
+		blockDHash := addBlock(blockCHash, nil)
+
+		firstCall := createVProgTransaction(t, tc, blockBHash, counterCode)
+		blockEHash := addBlock(blockDHash, []*externalapi.DomainTransaction{firstCall})
+		blockFHash := addBlock(blockEHash, nil)
+
+		secondCall := createVProgTransaction(t, tc, blockCHash, counterCode)
+		blockG1Hash := addBlock(blockFHash, []*externalapi.DomainTransaction{secondCall})
+		blockHHash := addBlock(blockG1Hash, nil)
+
+		firstResult := findAcceptedVProgResult(t, tc, blockFHash, blockEHash, firstCall)
+		if !bytes.Equal(firstResult.ReturnData, []byte{1}) {
+			t.Fatalf("Unexpected return data of the first call: %v", firstResult.ReturnData)
+		}
+		secondResult := findAcceptedVProgResult(t, tc, blockHHash, blockG1Hash, secondCall)
+		if !bytes.Equal(secondResult.ReturnData, []byte{2}) {
+			t.Fatalf("Unexpected return data of the second call: %v", secondResult.ReturnData)
+		}
+		checkVProgState(t, tc, blockHHash, counterKey, []byte{2})
+
+		// Reorg to a longer chain that doesn't call the counter:
+		// D <- S1 <- S2 <- S3 <- S4 <- S5
+		sideChainTipHash := addChain(blockDHash, 5)
+		checkVProgState(t, tc, sideChainTipHash, counterKey, nil)
+
+		// Reorg back by extending the original chain:
+		// H <- I <- J
+		mainChainTipHash := addChain(blockHHash, 2)
+		checkVProgState(t, tc, mainChainTipHash, counterKey, []byte{2})
+
+		multiset, err := tc.MultisetStore().Get(tc.DatabaseContext(), model.NewStagingArea(), mainChainTipHash)
+		if err != nil {
+			t.Fatalf("Error getting the multiset: %+v", err)
+		}
+		mainChainTip, _, err := tc.GetBlock(mainChainTipHash)
+		if err != nil {
+			t.Fatalf("Error getting block: %+v", err)
+		}
+		if !mainChainTip.Header.UTXOCommitment().Equal(multiset.Hash()) {
+			t.Fatalf("The UTXO commitment of the main chain tip doesn't match its multiset")
+		}
+	})
+}
+
+func findAcceptedVProgResult(t *testing.T, tc testapi.TestConsensus, acceptingBlockHash *externalapi.DomainHash,
+	blockHash *externalapi.DomainHash, transaction *externalapi.DomainTransaction) *externalapi.VProgExecutionResult {
+
+	acceptanceData, err := tc.AcceptanceDataStore().Get(tc.DatabaseContext(), model.NewStagingArea(), acceptingBlockHash)
+	if err != nil {
+		t.Fatalf("Error getting the acceptance data of block %s: %+v", acceptingBlockHash, err)
+	}
+	for _, blockAcceptanceData := range acceptanceData {
+		if blockAcceptanceData.BlockHash.Equal(blockHash) {
+			return findVProgResult(t, blockAcceptanceData, transaction)
+		}
+	}
+	t.Fatalf("Block %s is missing from the acceptance data of block %s", blockHash, acceptingBlockHash)
+	return nil
+}
+
+func checkVProgState(t *testing.T, tc testapi.TestConsensus, expectedStateBlockHash *externalapi.DomainHash,
+	key model.VProgStateKey, expectedValue []byte) {
+
+	stagingArea := model.NewStagingArea()
+	stateBlockHash, err := tc.VProgStateStore().StateBlockHash(tc.DatabaseContext(), stagingArea)
+	if err != nil {
+		t.Fatalf("Error getting the vprog state block hash: %+v", err)
+	}
+	if !stateBlockHash.Equal(expectedStateBlockHash) {
+		t.Fatalf("Expected the vprog state to be of block %s but got %s", expectedStateBlockHash, stateBlockHash)
+	}
+
+	value, found, err := tc.VProgStateStore().StateValue(tc.DatabaseContext(), stagingArea, key)
+	if err != nil {
+		t.Fatalf("Error getting a vprog state value: %+v", err)
+	}
+	if found != (expectedValue != nil) || !bytes.Equal(value, expectedValue) {
+		t.Fatalf("Expected the vprog state value to be %v but got %v (found: %t)", expectedValue, value, found)
+	}
+}
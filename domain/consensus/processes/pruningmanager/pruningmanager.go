@@ -36,6 +36,7 @@ type pruningManager struct {
 	utxoDiffStore                       model.UTXODiffStore
 	daaBlocksStore                      model.DAABlocksStore
 	reachabilityDataStore               model.ReachabilityDataStore
+	vprogStateStore                     model.VProgStateStore
 
 	isArchivalNode                  bool
 	genesisHash                     *externalapi.DomainHash
@@ -71,6 +72,7 @@ func New(
 	daaBlocksStore model.DAABlocksStore,
 	reachabilityDataStore model.ReachabilityDataStore,
 	blocksWithTrustedDataDAAWindowStore model.BlocksWithTrustedDataDAAWindowStore,
+	vprogStateStore model.VProgStateStore,
 
 	isArchivalNode bool,
 	genesisHash *externalapi.DomainHash,
@@ -101,6 +103,7 @@ func New(
 		daaBlocksStore:                      daaBlocksStore,
 		reachabilityDataStore:               reachabilityDataStore,
 		blocksWithTrustedDataDAAWindowStore: blocksWithTrustedDataDAAWindowStore,
+		vprogStateStore:                     vprogStateStore,
 
 		isArchivalNode:                  isArchivalNode,
 		genesisHash:                     genesisHash,
@@ -523,6 +526,7 @@ func (pm *pruningManager) deleteBlock(stagingArea *model.StagingArea, blockHash
 	pm.blocksStore.Delete(stagingArea, blockHash)
 	pm.utxoDiffStore.Delete(stagingArea, blockHash)
 	pm.daaBlocksStore.Delete(stagingArea, blockHash)
+	pm.vprogStateStore.DeleteDiff(stagingArea, blockHash)
 
 	return false, nil
 }
@@ -716,6 +720,22 @@ func (pm *pruningManager) validateUTXOSetFitsCommitment(stagingArea *model.Stagi
 		}
 		utxoSetMultiset.Add(serializedUTXO)
 	}
+
+	var fromEntry *externalapi.VProgStateEntry
+	const step = 1000
+	for {
+		vprogStateEntries, err := pm.vprogStateStore.PruningPointState(pm.databaseContext, fromEntry, step)
+		if err != nil {
+			return err
+		}
+		for _, entry := range vprogStateEntries {
+			utxoSetMultiset.Add(consensushashing.VProgStateEntryHash(entry.ProgramID, entry.Key, entry.Value).ByteSlice())
+		}
+		if len(vprogStateEntries) < step {
+			break
+		}
+		fromEntry = vprogStateEntries[len(vprogStateEntries)-1]
+	}
 	utxoSetHash := utxoSetMultiset.Hash()
 
 	header, err := pm.blockHeaderStore.BlockHeader(pm.databaseContext, stagingArea, pruningPointHash)
@@ -917,6 +937,52 @@ func (pm *pruningManager) calculateDiffBetweenPreviousAndCurrentPruningPointsUsi
 	return utxoDiff.ToImmutable(), err
 }
 
+// updatePruningPointVProgState applies the vprog state diffs of the selected chain blocks
+// between the previous pruning point and the current one on the vprog state of the
+// pruning point. Applying a diff is idempotent, so this can be safely repeated if the
+// node was stopped in the middle of updating the pruning point.
+func (pm *pruningManager) updatePruningPointVProgState(stagingArea *model.StagingArea,
+	currentPruningHash *externalapi.DomainHash) error {
+
+	if currentPruningHash.Equal(pm.genesisHash) {
+		// The vprog state of the genesis is empty
+		return nil
+	}
+
+	pruningPointIndex, err := pm.pruningStore.CurrentPruningPointIndex(pm.databaseContext, stagingArea)
+	if err != nil {
+		return err
+	}
+	if pruningPointIndex == 0 {
+		return errors.Errorf("previous pruning point doesn't exist")
+	}
+	previousPruningHash, err := pm.pruningStore.PruningPointByIndex(pm.databaseContext, stagingArea, pruningPointIndex-1)
+	if err != nil {
+		return err
+	}
+
+	iterator, err := pm.dagTraversalManager.SelectedChildIterator(stagingArea, currentPruningHash, previousPruningHash, false)
+	if err != nil {
+		return err
+	}
+	defer iterator.Close()
+
+	diff := model.NewVProgStateDiff()
+	for ok := iterator.First(); ok; ok = iterator.Next() {
+		child, err := iterator.Get()
+		if err != nil {
+			return err
+		}
+		childDiff, err := pm.vprogStateStore.Diff(pm.databaseContext, stagingArea, child)
+		if err != nil {
+			return errors.Wrapf(err, "could not get the vprog state diff of chain block %s", child)
+		}
+		diff.AddDiff(childDiff)
+	}
+
+	return pm.vprogStateStore.UpdatePruningPointState(pm.databaseContext, diff)
+}
+
 // finalityScore is the number of finality intervals passed since
 // the given block.
 func (pm *pruningManager) finalityScore(blueScore uint64) uint64 {
@@ -928,6 +994,10 @@ func (pm *pruningManager) ClearImportedPruningPointData() error {
 	if err != nil {
 		return err
 	}
+	err = pm.vprogStateStore.ClearImportedPruningPointState(pm.databaseContext)
+	if err != nil {
+		return err
+	}
 	return pm.pruningStore.ClearImportedPruningPointUTXOs(pm.databaseContext)
 }
 
@@ -965,6 +1035,39 @@ func (pm *pruningManager) AppendImportedPruningPointUTXOs(outpointAndUTXOEntryPa
 	return dbTx.Commit()
 }
 
+// AppendImportedPruningPointVProgState adds the given entries of the vprog state of the
+// pruning point to the imported pruning point data. The vprog state is committed to in
+// the UTXO commitment, so its entries are added to the imported multiset as well.
+func (pm *pruningManager) AppendImportedPruningPointVProgState(entries []*externalapi.VProgStateEntry) error {
+	dbTx, err := pm.databaseContext.Begin()
+	if err != nil {
+		return err
+	}
+	defer dbTx.RollbackUnlessClosed()
+
+	importedMultiset, err := pm.pruningStore.ImportedPruningPointMultiset(dbTx)
+	if err != nil {
+		if !database.IsNotFoundError(err) {
+			return err
+		}
+		importedMultiset = multiset.New()
+	}
+	for _, entry := range entries {
+		importedMultiset.Add(consensushashing.VProgStateEntryHash(entry.ProgramID, entry.Key, entry.Value).ByteSlice())
+	}
+	err = pm.pruningStore.UpdateImportedPruningPointMultiset(dbTx, importedMultiset)
+	if err != nil {
+		return err
+	}
+
+	err = pm.vprogStateStore.AppendImportedPruningPointState(dbTx, entries)
+	if err != nil {
+		return err
+	}
+
+	return dbTx.Commit()
+}
+
 func (pm *pruningManager) UpdatePruningPointIfRequired() error {
 	hadStartedUpdatingPruningPointUTXOSet, err := pm.pruningStore.HadStartedUpdatingPruningPointUTXOSet(pm.databaseContext)
 	if err != nil {
@@ -1014,6 +1117,11 @@ func (pm *pruningManager) updatePruningPoint() error {
 	if err != nil {
 		return err
 	}
+	log.Debugf("Updating the pruning point vprog state")
+	err = pm.updatePruningPointVProgState(stagingArea, pruningPoint)
+	if err != nil {
+		return err
+	}
 	if pm.shouldSanityCheckPruningUTXOSet && !pruningPoint.Equal(pm.genesisHash) {
 		err = pm.validateUTXOSetFitsCommitment(stagingArea, pruningPoint)
 		if err != nil {
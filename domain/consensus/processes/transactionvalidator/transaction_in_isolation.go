@@ -6,6 +6,7 @@ import (
 	"github.com/zuanet/zuad/domain/consensus/utils/constants"
 	"github.com/zuanet/zuad/domain/consensus/utils/subnetworks"
 	"github.com/zuanet/zuad/domain/consensus/utils/transactionhelper"
+	"github.com/zuanet/zuad/domain/consensus/vprog"
 	"github.com/pkg/errors"
 )
 
@@ -41,6 +42,11 @@ func (v *transactionValidator) ValidateTransactionInIsolation(tx *externalapi.Do
 		return err
 	}
 
+	err = v.checkVProgInIsolation(tx)
+	if err != nil {
+		return err
+	}
+
 	// TODO: fill it with the node's subnetwork id.
 	err = v.checkTransactionSubnetwork(tx, nil)
 	if err != nil {
@@ -194,3 +200,44 @@ func (v *transactionValidator) checkTransactionSubnetwork(tx *externalapi.Domain
 	}
 	return nil
 }
+
+func (v *transactionValidator) checkVProgInIsolation(tx *externalapi.DomainTransaction) error {
+	if !transactionhelper.IsVProgTransaction(tx) {
+		if tx.VProgVersion != 0 || len(tx.VProgData) > 0 || tx.VProgGasLimit != 0 {
+			return errors.Wrapf(ruleerrors.ErrInvalidVProgFields, "transaction without vprog code "+
+				"has non-zero vprog fields")
+		}
+		return nil
+	}
+
+	if !v.enableVProgs {
+		return errors.Wrapf(ruleerrors.ErrVProgsDisabled, "transaction carries a vprog "+
+			"while vprogs are disabled")
+	}
+
+	if transactionhelper.IsCoinBase(tx) {
+		return errors.Wrapf(ruleerrors.ErrCoinbaseWithVProg, "coinbase transaction carries a vprog")
+	}
+
+	if tx.VProgVersion != constants.VProgVersion {
+		return errors.Wrapf(ruleerrors.ErrUnsupportedVProgVersion, "vprog version %d is not supported",
+			tx.VProgVersion)
+	}
+
+	if len(tx.VProgCode) > vprog.MaxCodeSize {
+		return errors.Wrapf(ruleerrors.ErrVProgCodeTooLarge, "vprog code of %d bytes exceeds "+
+			"the maximum of %d", len(tx.VProgCode), vprog.MaxCodeSize)
+	}
+
+	if len(tx.VProgData) > vprog.MaxInputSize {
+		return errors.Wrapf(ruleerrors.ErrVProgDataTooLarge, "vprog data of %d bytes exceeds "+
+			"the maximum of %d", len(tx.VProgData), vprog.MaxInputSize)
+	}
+
+	if tx.VProgGasLimit == 0 || tx.VProgGasLimit > v.maxVProgGasLimit {
+		return errors.Wrapf(ruleerrors.ErrVProgGasLimitExceeded, "vprog gas limit of %d is out of "+
+			"range (max: %d)", tx.VProgGasLimit, v.maxVProgGasLimit)
+	}
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/zuanet/zuad/domain/consensus/utils/subnetworks"
 	"github.com/zuanet/zuad/domain/consensus/utils/testutils"
 	"github.com/zuanet/zuad/domain/consensus/utils/transactionhelper"
+	"github.com/zuanet/zuad/domain/consensus/vprog"
 	"github.com/pkg/errors"
 )
 
@@ -129,6 +130,81 @@ func TestValidateTransactionInIsolationAndPopulateMass(t *testing.T) {
 	})
 }
 
+func TestValidateVProgTransactionInIsolation(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		cfg := *consensusConfig
+		cfg.EnableVProgs = true
+
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(&cfg, "TestValidateVProgTransactionInIsolation")
+		if err != nil {
+			t.Fatalf("Error setting up consensus: %+v", err)
+		}
+		defer teardown(false)
+
+		disabledCfg := *consensusConfig
+		disabledCfg.EnableVProgs = false
+		tcDisabled, teardownDisabled, err := factory.NewTestConsensus(&disabledCfg,
+			"TestValidateVProgTransactionInIsolationDisabled")
+		if err != nil {
+			t.Fatalf("Error setting up consensus: %+v", err)
+		}
+		defer teardownDisabled(false)
+
+		tests := []struct {
+			name                   string
+			extraModificationsFunc func(*externalapi.DomainTransaction)
+			expectedErr            error
+		}{
+			{"good one", nil, nil},
+			{"unsupported version", func(tx *externalapi.DomainTransaction) {
+				tx.VProgVersion = constants.VProgVersion + 1
+			}, ruleerrors.ErrUnsupportedVProgVersion},
+			{"too big code", func(tx *externalapi.DomainTransaction) {
+				tx.VProgCode = make([]byte, vprog.MaxCodeSize+1)
+			}, ruleerrors.ErrVProgCodeTooLarge},
+			{"too big data", func(tx *externalapi.DomainTransaction) {
+				tx.VProgData = make([]byte, vprog.MaxInputSize+1)
+			}, ruleerrors.ErrVProgDataTooLarge},
+			{"zero gas limit", func(tx *externalapi.DomainTransaction) {
+				tx.VProgGasLimit = 0
+			}, ruleerrors.ErrVProgGasLimitExceeded},
+			{"too high gas limit", func(tx *externalapi.DomainTransaction) {
+				tx.VProgGasLimit = cfg.MaxVProgGasLimit + 1
+			}, ruleerrors.ErrVProgGasLimitExceeded},
+			{"vprog fields without code", func(tx *externalapi.DomainTransaction) {
+				tx.VProgCode = nil
+			}, ruleerrors.ErrInvalidVProgFields},
+		}
+
+		for _, test := range tests {
+			tx := createTxForTest(1, 1, 1, nil)
+			tx.VProgVersion = constants.VProgVersion
+			tx.VProgCode = []byte{vprog.OpStop}
+			tx.VProgData = []byte{1, 2, 3}
+			tx.VProgGasLimit = 1000
+
+			if test.extraModificationsFunc != nil {
+				test.extraModificationsFunc(tx)
+			}
+
+			err := tc.TransactionValidator().ValidateTransactionInIsolation(tx, 0)
+			if !errors.Is(err, test.expectedErr) {
+				t.Errorf("TestValidateVProgTransactionInIsolation: '%s': unexpected error %+v", test.name, err)
+			}
+		}
+
+		tx := createTxForTest(1, 1, 1, nil)
+		tx.VProgVersion = constants.VProgVersion
+		tx.VProgCode = []byte{vprog.OpStop}
+		tx.VProgGasLimit = 1000
+		err = tcDisabled.TransactionValidator().ValidateTransactionInIsolation(tx, 0)
+		if !errors.Is(err, ruleerrors.ErrVProgsDisabled) {
+			t.Errorf("TestValidateVProgTransactionInIsolation: unexpected error when vprogs are disabled: %+v", err)
+		}
+	})
+}
+
 func createTxForTest(numInputs uint32, numOutputs uint32, outputValue uint64, subnetworkData *txSubnetworkData) *externalapi.DomainTransaction {
 	txIns := []*externalapi.DomainTransactionInput{}
 	txOuts := []*externalapi.DomainTransactionOutput{}
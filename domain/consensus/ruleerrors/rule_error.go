@@ -244,6 +244,37 @@ var (
 	ErrCoinbaseWithInputs                             = newRuleError("ErrCoinbaseWithInputs")
 	ErrCoinbaseTooManyOutputs                         = newRuleError("ErrCoinbaseTooManyOutputs")
 	ErrCoinbaseTooLongScriptPublicKey                 = newRuleError("ErrCoinbaseTooLongScriptPublicKey")
+
+	// ErrVProgsDisabled indicates that a transaction carries a vprog while
+	// vprogs are disabled on the network.
+	ErrVProgsDisabled = newRuleError("ErrVProgsDisabled")
+
+	// ErrInvalidVProgFields indicates that a transaction that does not carry a
+	// vprog has a non-zero vprog field.
+	ErrInvalidVProgFields = newRuleError("ErrInvalidVProgFields")
+
+	// ErrUnsupportedVProgVersion indicates that a transaction's vprog version
+	// is unknown.
+	ErrUnsupportedVProgVersion = newRuleError("ErrUnsupportedVProgVersion")
+
+	// ErrVProgCodeTooLarge indicates that a transaction's vprog code exceeds
+	// the maximum allowed size.
+	ErrVProgCodeTooLarge = newRuleError("ErrVProgCodeTooLarge")
+
+	// ErrVProgDataTooLarge indicates that a transaction's vprog input data
+	// exceeds the maximum allowed size.
+	ErrVProgDataTooLarge = newRuleError("ErrVProgDataTooLarge")
+
+	// ErrVProgGasLimitExceeded indicates that a transaction's vprog gas limit
+	// is zero or exceeds the maximum allowed per transaction.
+	ErrVProgGasLimitExceeded = newRuleError("ErrVProgGasLimitExceeded")
+
+	// ErrBlockVProgGasTooHigh indicates that the sum of the vprog gas limits of
+	// a block's transactions exceeds the maximum allowed per block.
+	ErrBlockVProgGasTooHigh = newRuleError("ErrBlockVProgGasTooHigh")
+
+	// ErrCoinbaseWithVProg indicates that a coinbase transaction carries a vprog.
+	ErrCoinbaseWithVProg = newRuleError("ErrCoinbaseWithVProg")
 )
 
 // RuleError identifies a rule violation. It is used to indicate that
@@ -67,6 +67,10 @@ func (tc *testConsensus) UTXODiffStore() model.UTXODiffStore {
 	return tc.utxoDiffStore
 }
 
+func (tc *testConsensus) VProgStateStore() model.VProgStateStore {
+	return tc.vprogStateStore
+}
+
 func (tc *testConsensus) BlockBuilder() testapi.TestBlockBuilder {
 	return tc.testBlockBuilder
 }
@@ -5,6 +5,7 @@ import (
 	"github.com/zuanet/zuad/domain/consensus/utils/hashes"
 	"github.com/zuanet/zuad/domain/consensus/utils/serialization"
 	"github.com/zuanet/zuad/domain/consensus/utils/subnetworks"
+	"github.com/zuanet/zuad/domain/consensus/utils/transactionhelper"
 	"github.com/pkg/errors"
 )
 
@@ -56,6 +57,7 @@ type SighashReusedValues struct {
 	sigOpCountsHash     *externalapi.DomainHash
 	outputsHash         *externalapi.DomainHash
 	payloadHash         *externalapi.DomainHash
+	vprogHash           *externalapi.DomainHash
 }
 
 // CalculateSignatureHashSchnorr will, given a script and hash type calculate the signature hash
@@ -128,6 +130,13 @@ func calculateSignatureHash(tx *externalapi.DomainTransaction, inputIndex int, t
 	payloadHash := getPayloadHash(tx, reusedValues)
 	infallibleWriteElement(hashWriter, payloadHash)
 
+	// The vprog fields are only committed to by vprog transactions, so that the
+	// signature hashes of all other transactions remain unchanged
+	if transactionhelper.IsVProgTransaction(tx) {
+		vprogHash := getVProgHash(tx, reusedValues)
+		infallibleWriteElement(hashWriter, vprogHash)
+	}
+
 	infallibleWriteElement(hashWriter, uint8(hashType))
 
 	return hashWriter.Finalize(), nil
@@ -222,6 +231,18 @@ func getPayloadHash(tx *externalapi.DomainTransaction, reusedValues *SighashReus
 	return reusedValues.payloadHash
 }
 
+func getVProgHash(tx *externalapi.DomainTransaction, reusedValues *SighashReusedValues) *externalapi.DomainHash {
+	if reusedValues.vprogHash == nil {
+		hashWriter := hashes.NewTransactionSigningHashWriter()
+		infallibleWriteElement(hashWriter, tx.VProgVersion)
+		infallibleWriteElement(hashWriter, tx.VProgCode)
+		infallibleWriteElement(hashWriter, tx.VProgData)
+		infallibleWriteElement(hashWriter, tx.VProgGasLimit)
+		reusedValues.vprogHash = hashWriter.Finalize()
+	}
+	return reusedValues.vprogHash
+}
+
 func hashTxOut(hashWriter hashes.HashWriter, txOut *externalapi.DomainTransactionOutput) {
 	infallibleWriteElement(hashWriter, txOut.Value)
 	infallibleWriteElement(hashWriter, txOut.ScriptPublicKey.Version)
@@ -126,9 +126,37 @@ func serializeTransaction(w io.Writer, tx *externalapi.DomainTransaction, encodi
 		return err
 	}
 
+	// The vprog fields are only committed to by vprog transactions, so that the
+	// hashes and IDs of all other transactions remain unchanged
+	if transactionhelper.IsVProgTransaction(tx) {
+		err = writeVProgFields(w, tx)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+func writeVProgFields(w io.Writer, tx *externalapi.DomainTransaction) error {
+	_, err := w.Write([]byte{tx.VProgVersion})
+	if err != nil {
+		return err
+	}
+
+	err = writeVarBytes(w, tx.VProgCode)
+	if err != nil {
+		return err
+	}
+
+	err = writeVarBytes(w, tx.VProgData)
+	if err != nil {
+		return err
+	}
+
+	return binaryserializer.PutUint64(w, tx.VProgGasLimit)
+}
+
 // writeTransactionInput encodes ti to the zua protocol encoding for a transaction
 // input to w.
 func writeTransactionInput(w io.Writer, ti *externalapi.DomainTransactionInput, encodingFlags txEncoding) error {
@@ -0,0 +1,24 @@
+package consensushashing
+
+import (
+	"encoding/binary"
+
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/utils/hashes"
+)
+
+// VProgStateEntryHash returns the hash of the given vprog state entry. It is
+// the element that represents the entry in the UTXO commitment, and since
+// it's shorter than any serialized UTXO, the two can never collide.
+func VProgStateEntryHash(programID *externalapi.DomainHash, key []byte, value []byte) *externalapi.DomainHash {
+	var length [8]byte
+	writer := hashes.NewVProgStateEntryWriter()
+	writer.InfallibleWrite(programID.ByteSlice())
+	binary.LittleEndian.PutUint64(length[:], uint64(len(key)))
+	writer.InfallibleWrite(length[:])
+	writer.InfallibleWrite(key)
+	binary.LittleEndian.PutUint64(length[:], uint64(len(value)))
+	writer.InfallibleWrite(length[:])
+	writer.InfallibleWrite(value)
+	return writer.Finalize()
+}
@@ -12,6 +12,9 @@ const (
 	// MaxScriptPublicKeyVersion is the current latest supported public key script version.
 	MaxScriptPublicKeyVersion uint16 = 0
 
+	// VProgVersion is the current supported vprog version.
+	VProgVersion byte = 1
+
 	// SompiPerZua is the number of sompi in one zua (1 ZUA).
 	SompiPerZua = 100_000_000
 
@@ -16,6 +16,8 @@ const (
 	proofOfWorkDomain             = "ProofOfWorkHash"
 	heavyHashDomain               = "HeavyHash"
 	merkleBranchDomain            = "MerkleBranchHash"
+	vprogProgramIDDomain          = "VProgProgramID"
+	vprogStateEntryDomain         = "VProgStateEntry"
 )
 
 // transactionSigningECDSADomainHash is a hashed version of transcationSigningECDSADomain that is used
@@ -57,6 +59,24 @@ func NewTransactionSigningHashECDSAWriter() HashWriter {
 	return hashWriter
 }
 
+// NewVProgProgramIDWriter Returns a new HashWriter used for vprog program IDs
+func NewVProgProgramIDWriter() HashWriter {
+	blake, err := blake2b.New256([]byte(vprogProgramIDDomain))
+	if err != nil {
+		panic(errors.Wrapf(err, "this should never happen. %s is less than 64 bytes", vprogProgramIDDomain))
+	}
+	return HashWriter{blake}
+}
+
+// NewVProgStateEntryWriter Returns a new HashWriter used for hashing vprog state entries
+func NewVProgStateEntryWriter() HashWriter {
+	blake, err := blake2b.New256([]byte(vprogStateEntryDomain))
+	if err != nil {
+		panic(errors.Wrapf(err, "this should never happen. %s is less than 64 bytes", vprogStateEntryDomain))
+	}
+	return HashWriter{blake}
+}
+
 // NewBlockHashWriter Returns a new HashWriter used for hashing blocks
 func NewBlockHashWriter() HashWriter {
 	blake, err := blake2b.New256([]byte(blockDomain))
@@ -18,8 +18,6 @@ func NewSubnetworkTransaction(version uint16, inputs []*externalapi.DomainTransa
 		SubnetworkID: *subnetworkID,
 		Gas:          gas,
 		Payload:      payload,
-		VProgVersion:     1,
-		VProgGasLimit:    100000,
 		Fee:          0,
 		Mass:         0,
 	}
@@ -41,18 +39,16 @@ func NewNativeTransaction(version uint16, inputs []*externalapi.DomainTransactio
 	}
 }
 
-func NewDomainTransactionWithVProg(
-    version uint16,
-    inputs []*externalapi.DomainTransactionInput,
-    outputs []*externalapi.DomainTransactionOutput,
-    vprogCode []byte,
-    vprogData []byte,
-    vprogGasLimit uint64,
-) *externalapi.DomainTransaction {
-    
-    tx := NewDomainTransaction(version, inputs, outputs, 0, nil, 0, nil)
-    tx.VProgCode = vprogCode
-    tx.VProgData = vprogData
-    tx.VProgGasLimit = vprogGasLimit
-    return tx
+// NewVProgTransaction returns a new native transaction that executes the given
+// vprog code with the given input data
+func NewVProgTransaction(version uint16, inputs []*externalapi.DomainTransactionInput,
+	outputs []*externalapi.DomainTransactionOutput, vprogVersion byte, vprogCode []byte,
+	vprogData []byte, vprogGasLimit uint64) *externalapi.DomainTransaction {
+
+	transaction := NewNativeTransaction(version, inputs, outputs)
+	transaction.VProgVersion = vprogVersion
+	transaction.VProgCode = vprogCode
+	transaction.VProgData = vprogData
+	transaction.VProgGasLimit = vprogGasLimit
+	return transaction
 }
@@ -0,0 +1,12 @@
+package transactionhelper
+
+import (
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+)
+
+// IsVProgTransaction determines whether or not a transaction carries a vprog program.
+// A vprog transaction executes its VProgCode with VProgData as input once it is
+// accepted by a chain block.
+func IsVProgTransaction(tx *externalapi.DomainTransaction) bool {
+	return len(tx.VProgCode) > 0
+}
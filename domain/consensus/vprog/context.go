@@ -0,0 +1,244 @@
+package vprog
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Execution limits. Exceeding any of them fails execution deterministically.
+const (
+	// MaxCodeSize is the maximum size of a vprog program in bytes.
+	MaxCodeSize = 100_000
+
+	// MaxInputSize is the maximum size of a vprog program's input in bytes.
+	MaxInputSize = 50_000
+
+	// MaxStackDepth is the maximum number of items on the stack.
+	MaxStackDepth = 1024
+
+	// MaxStackItemSize is the maximum size of a single stack item in bytes.
+	MaxStackItemSize = 520
+
+	// MaxMemorySize is the maximum size of a program's memory in bytes.
+	MaxMemorySize = 64 * 1024
+
+	// MaxCallDepth is the maximum nesting of OpCall subroutines.
+	MaxCallDepth = 64
+
+	// MaxStorageKeySize is the maximum size of a storage key in bytes.
+	MaxStorageKeySize = 64
+
+	// maxNumberSize is the maximum size of a stack item interpreted as
+	// a number.
+	maxNumberSize = 8
+)
+
+// ExecutionContext holds the state of a single vprog execution.
+type ExecutionContext struct {
+	Code     []byte
+	Input    []byte
+	GasLimit uint64
+	GasUsed  uint64
+
+	PC          uint64
+	Stack       [][]byte
+	ReturnStack []uint64
+	Memory      []byte
+	Storage     map[string][]byte
+	ReturnData  []byte
+
+	storageReader    StorageReader
+	jumpDestinations []bool
+	halted           bool
+}
+
+func newExecutionContext(code []byte, input []byte, gasLimit uint64, storageReader StorageReader) *ExecutionContext {
+	return &ExecutionContext{
+		Code:             code,
+		Input:            input,
+		GasLimit:         gasLimit,
+		Stack:            make([][]byte, 0),
+		Memory:           make([]byte, 0),
+		Storage:          make(map[string][]byte),
+		storageReader:    storageReader,
+		jumpDestinations: analyzeJumpDestinations(code),
+	}
+}
+
+// analyzeJumpDestinations marks every offset in code that holds an
+// OpJumpDest which is not part of the data of an OpPush.
+func analyzeJumpDestinations(code []byte) []bool {
+	jumpDestinations := make([]bool, len(code))
+	for pc := 0; pc < len(code); pc++ {
+		switch code[pc] {
+		case OpJumpDest:
+			jumpDestinations[pc] = true
+		case OpPush:
+			if pc+1 < len(code) {
+				pc += 1 + int(code[pc+1])
+			}
+		}
+	}
+	return jumpDestinations
+}
+
+// load returns the value stored under key. Values written during this
+// execution take precedence over the ones in the storage reader.
+func (ctx *ExecutionContext) load(key []byte) ([]byte, error) {
+	if value, ok := ctx.Storage[string(key)]; ok {
+		return value, nil
+	}
+	if ctx.storageReader == nil {
+		return nil, nil
+	}
+	value, _, err := ctx.storageReader.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// useGas charges the given amount of gas, failing with ErrOutOfGas if the
+// gas limit would be exceeded. On failure all remaining gas is consumed.
+func (ctx *ExecutionContext) useGas(amount uint64) error {
+	remaining := ctx.GasLimit - ctx.GasUsed
+	if amount > remaining {
+		ctx.GasUsed = ctx.GasLimit
+		return executionError(ErrOutOfGas,
+			fmt.Sprintf("out of gas: %d required but only %d remaining", amount, remaining))
+	}
+	ctx.GasUsed += amount
+	return nil
+}
+
+// jump moves the program counter to destination, which must be a valid
+// jump destination.
+func (ctx *ExecutionContext) jump(destination uint64) error {
+	if destination >= uint64(len(ctx.jumpDestinations)) || !ctx.jumpDestinations[destination] {
+		return executionError(ErrInvalidJumpDestination,
+			fmt.Sprintf("offset %d is not a valid jump destination", destination))
+	}
+	ctx.PC = destination
+	return nil
+}
+
+// expandMemory grows memory to at least size bytes, charging GasMemWord for
+// every new word.
+func (ctx *ExecutionContext) expandMemory(size uint64) error {
+	currentSize := uint64(len(ctx.Memory))
+	if size <= currentSize {
+		return nil
+	}
+	if size > MaxMemorySize {
+		return executionError(ErrMemoryLimitExceeded,
+			fmt.Sprintf("memory of %d bytes exceeds the maximum of %d", size, MaxMemorySize))
+	}
+	newSize := wordCount(size) * wordSize
+	if newSize > MaxMemorySize {
+		newSize = MaxMemorySize
+	}
+	err := ctx.useGas(GasMemWord * (wordCount(newSize) - wordCount(currentSize)))
+	if err != nil {
+		return err
+	}
+	ctx.Memory = append(ctx.Memory, make([]byte, newSize-currentSize)...)
+	return nil
+}
+
+// push adds the given item to the top of the stack.
+//
+// Stack transformation: [... x1 x2] -> [... x1 x2 item]
+func (ctx *ExecutionContext) push(item []byte) error {
+	if len(item) > MaxStackItemSize {
+		return executionError(ErrElementTooBig,
+			fmt.Sprintf("stack item of %d bytes exceeds the maximum of %d", len(item), MaxStackItemSize))
+	}
+	if len(ctx.Stack) >= MaxStackDepth {
+		return executionError(ErrStackOverflow,
+			fmt.Sprintf("stack depth exceeds the maximum of %d", MaxStackDepth))
+	}
+	ctx.Stack = append(ctx.Stack, item)
+	return nil
+}
+
+// pop removes the item at the top of the stack and returns it.
+//
+// Stack transformation: [... x1 x2 x3] -> [... x1 x2]
+func (ctx *ExecutionContext) pop() ([]byte, error) {
+	if len(ctx.Stack) == 0 {
+		return nil, executionError(ErrStackUnderflow, "attempt to pop from an empty stack")
+	}
+	top := len(ctx.Stack) - 1
+	item := ctx.Stack[top]
+	ctx.Stack = ctx.Stack[:top]
+	return item, nil
+}
+
+// peek returns the item n positions below the top of the stack without
+// removing it. peek(0) returns the top item.
+func (ctx *ExecutionContext) peek(n int) ([]byte, error) {
+	if n >= len(ctx.Stack) {
+		return nil, executionError(ErrStackUnderflow,
+			fmt.Sprintf("attempt to access stack item %d of a stack of depth %d", n, len(ctx.Stack)))
+	}
+	return ctx.Stack[len(ctx.Stack)-1-n], nil
+}
+
+// popNumber pops the item at the top of the stack and interprets it as a
+// little-endian unsigned number.
+func (ctx *ExecutionContext) popNumber() (uint64, error) {
+	item, err := ctx.pop()
+	if err != nil {
+		return 0, err
+	}
+	return asNumber(item)
+}
+
+// stackUnderflowError returns an ErrStackUnderflow for an opcode that
+// requires the given number of stack items.
+func stackUnderflowError(op *opcode, required int, depth int) error {
+	return executionError(ErrStackUnderflow,
+		fmt.Sprintf("%s requires %d stack items but the stack has %d", op.name, required, depth))
+}
+
+// asNumber interprets item as a little-endian unsigned number of at most
+// 8 bytes. The empty item is zero.
+func asNumber(item []byte) (uint64, error) {
+	if len(item) > maxNumberSize {
+		return 0, executionError(ErrNumberTooBig,
+			fmt.Sprintf("numeric value of %d bytes exceeds the maximum of %d", len(item), maxNumberSize))
+	}
+	var buffer [maxNumberSize]byte
+	copy(buffer[:], item)
+	return binary.LittleEndian.Uint64(buffer[:]), nil
+}
+
+// fromNumber encodes value in its minimal little-endian form. Zero is
+// encoded as the empty item.
+func fromNumber(value uint64) []byte {
+	var buffer [maxNumberSize]byte
+	binary.LittleEndian.PutUint64(buffer[:], value)
+	length := maxNumberSize
+	for length > 0 && buffer[length-1] == 0 {
+		length--
+	}
+	return buffer[:length]
+}
+
+// asBool returns whether item holds any non-zero byte.
+func asBool(item []byte) bool {
+	for _, b := range item {
+		if b != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// fromBool converts a boolean into its stack representation.
+func fromBool(value bool) []byte {
+	if value {
+		return []byte{1}
+	}
+	return []byte{}
+}
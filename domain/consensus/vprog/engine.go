@@ -1,72 +1,110 @@
+// Package vprog implements the deterministic bytecode interpreter that runs
+// vprog programs attached to transactions.
+//
+// Programs operate on a stack of byte items. Items used as numbers are
+// little-endian unsigned integers of at most 8 bytes, with the empty item
+// being zero. Every opcode is charged gas according to the schedule in
+// opcode.go, and every failure is reported through a deterministic ErrorCode,
+// so that all nodes executing a program arrive at the exact same result.
 package vprog
 
-type ExecutionEngine struct {
-    databaseContext model.DBManager
-    blockDAAScore   uint64
-}
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
 
+// ExecutionEngine executes vprog programs
+type ExecutionEngine struct{}
+
+// ExecutionResult is the outcome of a single vprog execution
 type ExecutionResult struct {
-    Success     bool
-    GasUsed     uint64
-    ReturnData  []byte
-    StateChanges map[string][]byte
-    Error       string
+	Success      bool
+	GasUsed      uint64
+	ReturnData   []byte
+	StateChanges map[string][]byte
+	ErrorCode    ErrorCode
+	Error        string
 }
 
+// NewExecutionEngine creates a new ExecutionEngine
+func NewExecutionEngine() *ExecutionEngine {
+	return &ExecutionEngine{}
+}
+
+// Execute runs code with the given input against empty storage. See
+// ExecuteWithStorage for details.
 func (engine *ExecutionEngine) Execute(code []byte, input []byte, gasLimit uint64) (*ExecutionResult, error) {
-    ctx := &ExecutionContext{
-        Code:      code,
-        Input:     input,
-        GasLimit:  gasLimit,
-        GasUsed:   0,
-        Stack:     make([][]byte, 0),
-        Memory:    make([]byte, 0),
-        Storage:   make(map[string][]byte),
-    }
-    
-    // Simple interpreter loop
-    for ctx.PC < uint64(len(code)) && ctx.GasUsed < gasLimit {
-        opcode := code[ctx.PC]
-        ctx.PC++
-        
-        if err := engine.executeOpcode(ctx, opcode); err != nil {
-            return &ExecutionResult{
-                Success: false,
-                GasUsed: ctx.GasUsed,
-                Error:   err.Error(),
-            }, nil
-        }
-    }
-    
-    return &ExecutionResult{
-        Success:    true,
-        GasUsed:    ctx.GasUsed,
-        ReturnData: ctx.ReturnData,
-    }, nil
+	return engine.ExecuteWithStorage(code, input, gasLimit, nil)
+}
+
+// ExecuteWithStorage runs code with the given input until it halts, fails or
+// exhausts gasLimit. Storage keys that were not written during the execution
+// are read from storage, which may be nil if the program has no prior state.
+//
+// Execution failures are reported through the returned ExecutionResult; on
+// failure no state changes are returned. An error is returned only for
+// failures that are unrelated to the program itself, such as a failure to
+// read from storage.
+func (engine *ExecutionEngine) ExecuteWithStorage(code []byte, input []byte, gasLimit uint64,
+	storage StorageReader) (*ExecutionResult, error) {
+
+	if len(code) > MaxCodeSize {
+		return failedResult(0, nil, executionError(ErrCodeTooBig,
+			fmt.Sprintf("program of %d bytes exceeds the maximum of %d", len(code), MaxCodeSize))), nil
+	}
+	if len(input) > MaxInputSize {
+		return failedResult(0, nil, executionError(ErrInputTooBig,
+			fmt.Sprintf("input of %d bytes exceeds the maximum of %d", len(input), MaxInputSize))), nil
+	}
+
+	ctx := newExecutionContext(code, input, gasLimit, storage)
+	for !ctx.halted && ctx.PC < uint64(len(code)) {
+		opcodeValue := code[ctx.PC]
+		ctx.PC++
+
+		err := engine.executeOpcode(ctx, opcodeValue)
+		if err != nil {
+			if !errors.As(err, &Error{}) {
+				return nil, err
+			}
+			return failedResult(ctx.GasUsed, ctx.ReturnData, err), nil
+		}
+	}
+
+	return &ExecutionResult{
+		Success:      true,
+		GasUsed:      ctx.GasUsed,
+		ReturnData:   ctx.ReturnData,
+		StateChanges: ctx.Storage,
+	}, nil
+}
+
+func (engine *ExecutionEngine) executeOpcode(ctx *ExecutionContext, opcodeValue byte) error {
+	op := &opcodeArray[opcodeValue]
+	if !op.isDefined() {
+		return executionError(ErrInvalidOpcode,
+			fmt.Sprintf("%s at offset %d is not a valid opcode", OpcodeName(opcodeValue), ctx.PC-1))
+	}
+
+	err := ctx.useGas(op.gas)
+	if err != nil {
+		return err
+	}
+	return op.opfunc(op, ctx)
 }
 
-func (engine *ExecutionEngine) executeOpcode(ctx *ExecutionContext, opcode byte) error {
-    // Implement basic opcodes
-    switch opcode {
-    case OP_PUSH:
-        // Push data to stack
-    case OP_DUP:
-        // Duplicate stack item
-    case OP_SWAP:
-        // Swap stack items
-    case OP_ADD, OP_SUB, OP_MUL:
-        // Arithmetic operations
-    case OP_EQUAL, OP_GREATER:
-        // Comparison operations
-    case OP_JUMP, OP_JUMPI:
-        // Control flow
-    case OP_STORE, OP_LOAD:
-        // Storage operations
-    case OP_CALL:
-        // Function calls
-    case OP_RETURN:
-        // Return from execution
-    }
-    
-    return nil
+func failedResult(gasUsed uint64, returnData []byte, err error) *ExecutionResult {
+	errorCode := ErrInternal
+	var executionErr Error
+	if errors.As(err, &executionErr) {
+		errorCode = executionErr.ErrorCode
+	}
+	return &ExecutionResult{
+		Success:    false,
+		GasUsed:    gasUsed,
+		ReturnData: returnData,
+		ErrorCode:  errorCode,
+		Error:      err.Error(),
+	}
 }
@@ -0,0 +1,478 @@
+package vprog
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// program concatenates the given opcodes and push data into a program.
+func program(parts ...[]byte) []byte {
+	var code []byte
+	for _, part := range parts {
+		code = append(code, part...)
+	}
+	return code
+}
+
+func op(value byte) []byte {
+	return []byte{value}
+}
+
+func push(data ...byte) []byte {
+	return append([]byte{OpPush, byte(len(data))}, data...)
+}
+
+func pushNumber(value uint64) []byte {
+	return push(fromNumber(value)...)
+}
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		name               string
+		code               []byte
+		input              []byte
+		gasLimit           uint64
+		expectedSuccess    bool
+		expectedErrorCode  ErrorCode
+		expectedGasUsed    uint64
+		expectedReturnData []byte
+		expectedState      map[string][]byte
+	}{
+		{
+			name:            "empty program",
+			code:            nil,
+			gasLimit:        100,
+			expectedSuccess: true,
+			expectedState:   map[string][]byte{},
+		},
+		{
+			name:               "add",
+			code:               program(pushNumber(2), pushNumber(3), op(OpAdd), op(OpReturn)),
+			gasLimit:           100,
+			expectedSuccess:    true,
+			expectedGasUsed:    3 * GasVeryLow,
+			expectedReturnData: fromNumber(5),
+			expectedState:      map[string][]byte{},
+		},
+		{
+			name:               "sub takes the top item as the subtrahend",
+			code:               program(pushNumber(10), pushNumber(3), op(OpSub), op(OpReturn)),
+			gasLimit:           100,
+			expectedSuccess:    true,
+			expectedGasUsed:    3 * GasVeryLow,
+			expectedReturnData: fromNumber(7),
+			expectedState:      map[string][]byte{},
+		},
+		{
+			name:              "sub underflow",
+			code:              program(pushNumber(3), pushNumber(10), op(OpSub)),
+			gasLimit:          100,
+			expectedErrorCode: ErrArithmeticOverflow,
+			expectedGasUsed:   3 * GasVeryLow,
+		},
+		{
+			name: "mul overflow",
+			code: program(push(0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff), pushNumber(2),
+				op(OpMul)),
+			gasLimit:          100,
+			expectedErrorCode: ErrArithmeticOverflow,
+			expectedGasUsed:   2*GasVeryLow + GasLow,
+		},
+		{
+			name:              "div by zero",
+			code:              program(pushNumber(1), pushNumber(0), op(OpDiv)),
+			gasLimit:          100,
+			expectedErrorCode: ErrDivisionByZero,
+			expectedGasUsed:   2*GasVeryLow + GasLow,
+		},
+		{
+			name:               "mod",
+			code:               program(pushNumber(17), pushNumber(5), op(OpMod), op(OpReturn)),
+			gasLimit:           100,
+			expectedSuccess:    true,
+			expectedGasUsed:    2*GasVeryLow + GasLow,
+			expectedReturnData: fromNumber(2),
+			expectedState:      map[string][]byte{},
+		},
+		{
+			name:              "number too big",
+			code:              program(push(1, 2, 3, 4, 5, 6, 7, 8, 9), pushNumber(1), op(OpAdd)),
+			gasLimit:          100,
+			expectedErrorCode: ErrNumberTooBig,
+			expectedGasUsed:   3 * GasVeryLow,
+		},
+		{
+			name:               "dup swap over",
+			code:               program(pushNumber(1), pushNumber(2), op(OpSwap), op(OpOver), op(OpDup), op(OpPop), op(OpReturn)),
+			gasLimit:           100,
+			expectedSuccess:    true,
+			expectedGasUsed:    5*GasVeryLow + GasBase,
+			expectedReturnData: fromNumber(2),
+			expectedState:      map[string][]byte{},
+		},
+		{
+			name:              "pop from empty stack",
+			code:              program(op(OpPop)),
+			gasLimit:          100,
+			expectedErrorCode: ErrStackUnderflow,
+			expectedGasUsed:   GasBase,
+		},
+		{
+			name:              "swap with one item",
+			code:              program(pushNumber(1), op(OpSwap)),
+			gasLimit:          100,
+			expectedErrorCode: ErrStackUnderflow,
+			expectedGasUsed:   2 * GasVeryLow,
+		},
+		{
+			name:               "equal compares bytes",
+			code:               program(push(1, 2), push(1, 2), op(OpEqual), op(OpReturn)),
+			gasLimit:           100,
+			expectedSuccess:    true,
+			expectedGasUsed:    3 * GasVeryLow,
+			expectedReturnData: []byte{1},
+			expectedState:      map[string][]byte{},
+		},
+		{
+			name:               "greater",
+			code:               program(pushNumber(5), pushNumber(3), op(OpGreater), op(OpNot), op(OpReturn)),
+			gasLimit:           100,
+			expectedSuccess:    true,
+			expectedGasUsed:    4 * GasVeryLow,
+			expectedReturnData: []byte{},
+			expectedState:      map[string][]byte{},
+		},
+		{
+			name:               "less",
+			code:               program(pushNumber(3), pushNumber(5), op(OpLess), op(OpReturn)),
+			gasLimit:           100,
+			expectedSuccess:    true,
+			expectedGasUsed:    3 * GasVeryLow,
+			expectedReturnData: []byte{1},
+			expectedState:      map[string][]byte{},
+		},
+		{
+			name: "jump skips code",
+			// 0: PUSH 6, 3: JUMP, 4-5: skipped, 6: JUMPDEST
+			code:            program(pushNumber(6), op(OpJump), op(OpInvalidForTest), op(OpInvalidForTest), op(OpJumpDest)),
+			gasLimit:        100,
+			expectedSuccess: true,
+			expectedGasUsed: GasVeryLow + GasMid + GasBase,
+			expectedState:   map[string][]byte{},
+		},
+		{
+			name:              "jump to non-jumpdest",
+			code:              program(pushNumber(0), op(OpJump)),
+			gasLimit:          100,
+			expectedErrorCode: ErrInvalidJumpDestination,
+			expectedGasUsed:   GasVeryLow + GasMid,
+		},
+		{
+			name: "jump into push data",
+			// The OpJumpDest at offset 5 is data of the push at offset 3
+			code:              program(pushNumber(5), op(OpJump), push(OpJumpDest)),
+			gasLimit:          100,
+			expectedErrorCode: ErrInvalidJumpDestination,
+			expectedGasUsed:   GasVeryLow + GasMid,
+		},
+		{
+			name: "jumpi not taken",
+			code: program(pushNumber(0), pushNumber(9), op(OpJumpI), pushNumber(7), op(OpReturn),
+				op(OpJumpDest)),
+			gasLimit:           100,
+			expectedSuccess:    true,
+			expectedGasUsed:    3*GasVeryLow + GasHigh,
+			expectedReturnData: fromNumber(7),
+			expectedState:      map[string][]byte{},
+		},
+		{
+			name: "loop counting down",
+			// counter = 3; loop: if counter == 0 goto end; counter -= 1; goto loop; end: return counter
+			code: program(
+				pushNumber(3),                                     // 0
+				op(OpJumpDest),                                    // 3
+				op(OpDup), op(OpNot), pushNumber(18), op(OpJumpI), // 4
+				pushNumber(1), op(OpSub), pushNumber(3), op(OpJump), // 10
+				op(OpJumpDest), op(OpReturn)), // 18
+			gasLimit:           1000,
+			expectedSuccess:    true,
+			expectedGasUsed:    GasVeryLow + 4*(GasBase+2*GasVeryLow+GasVeryLow+GasHigh) + 3*(3*GasVeryLow+GasMid) + GasBase,
+			expectedReturnData: fromNumber(0),
+			expectedState:      map[string][]byte{},
+		},
+		{
+			name: "call and ret",
+			// 0: PUSH 8, 3: CALL, 4: PUSH 42, 7: RETURN, 8: JUMPDEST, 9: RET
+			code: program(pushNumber(8), op(OpCall), pushNumber(42), op(OpReturn), op(OpJumpDest),
+				op(OpRet)),
+			gasLimit:           100,
+			expectedSuccess:    true,
+			expectedGasUsed:    GasVeryLow + GasCall + GasBase + GasLow + GasVeryLow,
+			expectedReturnData: fromNumber(42),
+			expectedState:      map[string][]byte{},
+		},
+		{
+			name:              "ret outside of subroutine",
+			code:              program(op(OpRet)),
+			gasLimit:          100,
+			expectedErrorCode: ErrReturnStackUnderflow,
+			expectedGasUsed:   GasLow,
+		},
+		{
+			name:              "infinite recursion",
+			code:              program(op(OpJumpDest), pushNumber(0), op(OpCall)),
+			gasLimit:          100_000,
+			expectedErrorCode: ErrCallDepthExceeded,
+			expectedGasUsed:   (MaxCallDepth + 1) * (GasBase + GasVeryLow + GasCall),
+		},
+		{
+			name:              "infinite loop runs out of gas",
+			code:              program(op(OpJumpDest), pushNumber(0), op(OpJump)),
+			gasLimit:          1000,
+			expectedErrorCode: ErrOutOfGas,
+			expectedGasUsed:   1000,
+		},
+		{
+			name: "memory store and load",
+			code: program(pushNumber(40), push(1, 2, 3), op(OpMStore),
+				pushNumber(41), pushNumber(2), op(OpMLoad), op(OpReturn)),
+			gasLimit:           100,
+			expectedSuccess:    true,
+			expectedGasUsed:    6*GasVeryLow + GasDataWord + 2*GasMemWord + GasDataWord,
+			expectedReturnData: []byte{2, 3},
+			expectedState:      map[string][]byte{},
+		},
+		{
+			name:              "memory load out of bounds",
+			code:              program(pushNumber(0), pushNumber(1), op(OpMLoad)),
+			gasLimit:          100,
+			expectedErrorCode: ErrInvalidMemoryAccess,
+			expectedGasUsed:   3 * GasVeryLow,
+		},
+		{
+			name:              "memory limit",
+			code:              program(pushNumber(MaxMemorySize), push(1), op(OpMStore)),
+			gasLimit:          100_000,
+			expectedErrorCode: ErrMemoryLimitExceeded,
+			expectedGasUsed:   3*GasVeryLow + GasDataWord,
+		},
+		{
+			name:               "storage store and load",
+			code:               program(push('k'), push('v'), op(OpStore), push('k'), op(OpLoad), op(OpReturn)),
+			gasLimit:           10_000,
+			expectedSuccess:    true,
+			expectedGasUsed:    3*GasVeryLow + GasStore + 2*GasStoreByte + GasLoad,
+			expectedReturnData: []byte{'v'},
+			expectedState:      map[string][]byte{"k": {'v'}},
+		},
+		{
+			name:               "load of missing key",
+			code:               program(push('k'), op(OpLoad), op(OpReturn)),
+			gasLimit:           1000,
+			expectedSuccess:    true,
+			expectedGasUsed:    GasVeryLow + GasLoad,
+			expectedReturnData: []byte{},
+			expectedState:      map[string][]byte{},
+		},
+		{
+			name:              "storage key too big",
+			code:              program(push(make([]byte, MaxStorageKeySize+1)...), push(1), op(OpStore)),
+			gasLimit:          10_000,
+			expectedErrorCode: ErrStorageKeyTooBig,
+			expectedGasUsed:   2*GasVeryLow + GasStore,
+		},
+		{
+			name:               "input",
+			code:               program(pushNumber(1), op(OpInputSize), pushNumber(1), op(OpSub), op(OpInputLoad), op(OpReturn)),
+			input:              []byte{9, 8, 7},
+			gasLimit:           100,
+			expectedSuccess:    true,
+			expectedGasUsed:    2*GasVeryLow + GasBase + 2*GasVeryLow + GasDataWord,
+			expectedReturnData: []byte{8, 7},
+			expectedState:      map[string][]byte{},
+		},
+		{
+			name:              "input out of bounds",
+			code:              program(pushNumber(2), pushNumber(2), op(OpInputLoad)),
+			input:             []byte{9, 8, 7},
+			gasLimit:          100,
+			expectedErrorCode: ErrInvalidInputAccess,
+			expectedGasUsed:   3 * GasVeryLow,
+		},
+		{
+			name:               "revert discards state and keeps return data",
+			code:               program(push('k'), push('v'), op(OpStore), push('x'), op(OpRevert)),
+			gasLimit:           10_000,
+			expectedErrorCode:  ErrReverted,
+			expectedGasUsed:    3*GasVeryLow + GasStore + 2*GasStoreByte,
+			expectedReturnData: []byte{'x'},
+		},
+		{
+			name:              "invalid opcode",
+			code:              program(op(OpInvalidForTest)),
+			gasLimit:          100,
+			expectedErrorCode: ErrInvalidOpcode,
+		},
+		{
+			name:              "truncated push",
+			code:              []byte{OpPush, 3, 1},
+			gasLimit:          100,
+			expectedErrorCode: ErrMalformedPush,
+			expectedGasUsed:   GasVeryLow,
+		},
+		{
+			name:              "push without length",
+			code:              []byte{OpPush},
+			gasLimit:          100,
+			expectedErrorCode: ErrMalformedPush,
+			expectedGasUsed:   GasVeryLow,
+		},
+		{
+			name:              "out of gas before the first opcode",
+			code:              program(pushNumber(1)),
+			gasLimit:          GasVeryLow - 1,
+			expectedErrorCode: ErrOutOfGas,
+			expectedGasUsed:   GasVeryLow - 1,
+		},
+		{
+			name:            "stop halts",
+			code:            program(op(OpStop), op(OpInvalidForTest)),
+			gasLimit:        100,
+			expectedSuccess: true,
+			expectedState:   map[string][]byte{},
+		},
+		{
+			name:              "code too big",
+			code:              make([]byte, MaxCodeSize+1),
+			gasLimit:          100,
+			expectedErrorCode: ErrCodeTooBig,
+		},
+		{
+			name:              "input too big",
+			code:              nil,
+			input:             make([]byte, MaxInputSize+1),
+			gasLimit:          100,
+			expectedErrorCode: ErrInputTooBig,
+		},
+	}
+
+	engine := NewExecutionEngine()
+	for _, test := range tests {
+		result, err := engine.Execute(test.code, test.input, test.gasLimit)
+		if err != nil {
+			t.Fatalf("%s: Execute: %s", test.name, err)
+		}
+		if result.Success != test.expectedSuccess {
+			t.Errorf("%s: expected success %t but got %t (%s)",
+				test.name, test.expectedSuccess, result.Success, result.Error)
+			continue
+		}
+		if !result.Success && result.ErrorCode != test.expectedErrorCode {
+			t.Errorf("%s: expected error code %s but got %s (%s)",
+				test.name, test.expectedErrorCode, result.ErrorCode, result.Error)
+		}
+		if result.GasUsed != test.expectedGasUsed {
+			t.Errorf("%s: expected %d gas used but got %d", test.name, test.expectedGasUsed, result.GasUsed)
+		}
+		if !bytes.Equal(result.ReturnData, test.expectedReturnData) {
+			t.Errorf("%s: expected return data %x but got %x",
+				test.name, test.expectedReturnData, result.ReturnData)
+		}
+		if !reflect.DeepEqual(result.StateChanges, test.expectedState) {
+			t.Errorf("%s: expected state changes %v but got %v",
+				test.name, test.expectedState, result.StateChanges)
+		}
+	}
+}
+
+func TestStackLimits(t *testing.T) {
+	engine := NewExecutionEngine()
+
+	var code []byte
+	for i := 0; i <= MaxStackDepth; i++ {
+		code = append(code, pushNumber(1)...)
+	}
+	result, err := engine.Execute(code, nil, 1_000_000)
+	if err != nil {
+		t.Fatalf("Execute: %s", err)
+	}
+	if result.Success || result.ErrorCode != ErrStackOverflow {
+		t.Fatalf("expected ErrStackOverflow but got %s", result.ErrorCode)
+	}
+
+	code = program(pushNumber(0), pushNumber(MaxStackItemSize+1), op(OpMLoad))
+	result, err = engine.Execute(code, nil, 1_000_000)
+	if err != nil {
+		t.Fatalf("Execute: %s", err)
+	}
+	if result.Success || result.ErrorCode != ErrElementTooBig {
+		t.Fatalf("expected ErrElementTooBig but got %s", result.ErrorCode)
+	}
+}
+
+func TestOpcodeTable(t *testing.T) {
+	for i := range opcodeArray {
+		op := &opcodeArray[i]
+		if !op.isDefined() {
+			continue
+		}
+		if int(op.value) != i {
+			t.Errorf("opcode %s is at index %d but has value %d", op.name, i, op.value)
+		}
+	}
+	if OpcodeName(OpInvalidForTest) != "OP_UNKNOWN255" {
+		t.Errorf("unexpected name for undefined opcode: %s", OpcodeName(OpInvalidForTest))
+	}
+	if _, ok := OpcodeGas(OpInvalidForTest); ok {
+		t.Errorf("undefined opcode reported as defined")
+	}
+}
+
+func TestErrorCodeStringer(t *testing.T) {
+	for c := ErrInternal; c < numErrorCodes; c++ {
+		if _, ok := errorCodeStrings[c]; !ok {
+			t.Errorf("error code %d has no string representation", c)
+		}
+	}
+}
+
+// OpInvalidForTest is an opcode value that is not defined by the engine.
+const OpInvalidForTest = 0xff
+
+func FuzzExecute(f *testing.F) {
+	f.Add([]byte{}, []byte{}, uint64(100))
+	f.Add(program(pushNumber(2), pushNumber(3), op(OpAdd), op(OpReturn)), []byte{}, uint64(100))
+	f.Add(program(op(OpJumpDest), pushNumber(0), op(OpCall)), []byte{}, uint64(100_000))
+	f.Add(program(push('k'), op(OpInputSize), op(OpStore)), []byte{1, 2, 3}, uint64(10_000))
+	f.Add(program(pushNumber(100), push(1, 2, 3), op(OpMStore), pushNumber(0), pushNumber(64), op(OpMLoad)),
+		[]byte{}, uint64(1000))
+
+	engine := NewExecutionEngine()
+	f.Fuzz(func(t *testing.T, code []byte, input []byte, gasLimit uint64) {
+		// Bound the gas limit so that looping programs terminate quickly
+		gasLimit %= 1_000_000
+
+		result, err := engine.Execute(code, input, gasLimit)
+		if err != nil {
+			t.Fatalf("Execute: %s", err)
+		}
+		if result.GasUsed > gasLimit {
+			t.Fatalf("used %d gas with a limit of %d", result.GasUsed, gasLimit)
+		}
+		if result.Success && result.StateChanges == nil {
+			t.Fatalf("successful execution returned nil state changes")
+		}
+		if !result.Success && (result.StateChanges != nil || result.ErrorCode == ErrInternal) {
+			t.Fatalf("unexpected failed result: %+v", result)
+		}
+
+		again, err := engine.Execute(code, input, gasLimit)
+		if err != nil {
+			t.Fatalf("Execute: %s", err)
+		}
+		if !reflect.DeepEqual(result, again) {
+			t.Fatalf("execution is not deterministic: %+v != %+v", result, again)
+		}
+	})
+}
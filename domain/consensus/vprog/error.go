@@ -0,0 +1,157 @@
+package vprog
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// ErrorCode identifies the deterministic reason a vprog execution failed.
+// Error codes are part of consensus: two nodes executing the same program
+// with the same input and gas limit must always arrive at the same code.
+type ErrorCode int
+
+// These constants are used to identify a specific Error.
+const (
+	// ErrInternal is returned if internal consistency checks fail. In
+	// practice this error should never be seen as it would mean there is an
+	// error in the engine logic.
+	ErrInternal ErrorCode = iota
+
+	// ErrCodeTooBig is returned when the program is larger than MaxCodeSize.
+	ErrCodeTooBig
+
+	// ErrInputTooBig is returned when the input is larger than MaxInputSize.
+	ErrInputTooBig
+
+	// ErrOutOfGas is returned when executing the next opcode would exceed the
+	// gas limit.
+	ErrOutOfGas
+
+	// ErrInvalidOpcode is returned when an undefined opcode is encountered.
+	ErrInvalidOpcode
+
+	// ErrMalformedPush is returned when an OpPush is truncated by the end of
+	// the program.
+	ErrMalformedPush
+
+	// ErrStackUnderflow is returned when an opcode requires more stack items
+	// than are available.
+	ErrStackUnderflow
+
+	// ErrStackOverflow is returned when the stack grows beyond MaxStackDepth.
+	ErrStackOverflow
+
+	// ErrElementTooBig is returned when a stack item larger than
+	// MaxStackItemSize would be created.
+	ErrElementTooBig
+
+	// ErrNumberTooBig is returned when a stack item interpreted as a number
+	// is longer than 8 bytes.
+	ErrNumberTooBig
+
+	// ErrArithmeticOverflow is returned when an arithmetic operation
+	// overflows or underflows a uint64.
+	ErrArithmeticOverflow
+
+	// ErrDivisionByZero is returned when OpDiv or OpMod is executed with a
+	// zero divisor.
+	ErrDivisionByZero
+
+	// ErrInvalidJumpDestination is returned when a jump targets anything
+	// other than an OpJumpDest that is not part of push data.
+	ErrInvalidJumpDestination
+
+	// ErrCallDepthExceeded is returned when OpCall nests deeper than
+	// MaxCallDepth.
+	ErrCallDepthExceeded
+
+	// ErrReturnStackUnderflow is returned when OpRet is executed outside of
+	// a subroutine.
+	ErrReturnStackUnderflow
+
+	// ErrMemoryLimitExceeded is returned when memory would grow beyond
+	// MaxMemorySize.
+	ErrMemoryLimitExceeded
+
+	// ErrInvalidMemoryAccess is returned when OpMLoad reads past the end of
+	// memory.
+	ErrInvalidMemoryAccess
+
+	// ErrInvalidInputAccess is returned when OpInputLoad reads past the end
+	// of the input.
+	ErrInvalidInputAccess
+
+	// ErrStorageKeyTooBig is returned when a storage key is larger than
+	// MaxStorageKeySize.
+	ErrStorageKeyTooBig
+
+	// ErrReverted is returned when the program executes OpRevert.
+	ErrReverted
+
+	// numErrorCodes is the maximum error code number used in tests. This
+	// entry MUST be the last entry in the enum.
+	numErrorCodes
+)
+
+var errorCodeStrings = map[ErrorCode]string{
+	ErrInternal:               "ErrInternal",
+	ErrCodeTooBig:             "ErrCodeTooBig",
+	ErrInputTooBig:            "ErrInputTooBig",
+	ErrOutOfGas:               "ErrOutOfGas",
+	ErrInvalidOpcode:          "ErrInvalidOpcode",
+	ErrMalformedPush:          "ErrMalformedPush",
+	ErrStackUnderflow:         "ErrStackUnderflow",
+	ErrStackOverflow:          "ErrStackOverflow",
+	ErrElementTooBig:          "ErrElementTooBig",
+	ErrNumberTooBig:           "ErrNumberTooBig",
+	ErrArithmeticOverflow:     "ErrArithmeticOverflow",
+	ErrDivisionByZero:         "ErrDivisionByZero",
+	ErrInvalidJumpDestination: "ErrInvalidJumpDestination",
+	ErrCallDepthExceeded:      "ErrCallDepthExceeded",
+	ErrReturnStackUnderflow:   "ErrReturnStackUnderflow",
+	ErrMemoryLimitExceeded:    "ErrMemoryLimitExceeded",
+	ErrInvalidMemoryAccess:    "ErrInvalidMemoryAccess",
+	ErrInvalidInputAccess:     "ErrInvalidInputAccess",
+	ErrStorageKeyTooBig:       "ErrStorageKeyTooBig",
+	ErrReverted:               "ErrReverted",
+}
+
+// String returns the ErrorCode as a human-readable name.
+func (e ErrorCode) String() string {
+	if s := errorCodeStrings[e]; s != "" {
+		return s
+	}
+	return fmt.Sprintf("Unknown ErrorCode (%d)", int(e))
+}
+
+// Error identifies an execution failure. It is used to indicate three
+// classes of errors:
+// 1) Malformed programs, such as invalid opcodes or truncated pushes
+// 2) Resource exhaustion, such as running out of gas or exceeding a limit
+// 3) Program-initiated failures, such as OpRevert
+type Error struct {
+	ErrorCode   ErrorCode
+	Description string
+}
+
+// Error satisfies the error interface and prints human-readable errors.
+func (e Error) Error() string {
+	return e.Description
+}
+
+// executionError creates an Error given a set of arguments.
+func executionError(c ErrorCode, desc string) Error {
+	return Error{ErrorCode: c, Description: desc}
+}
+
+// IsErrorCode returns whether or not the provided error is an execution
+// error with the provided error code.
+func IsErrorCode(err error, c ErrorCode) bool {
+	var errError Error
+	if ok := errors.As(err, &errError); ok {
+		return errError.ErrorCode == c
+	}
+
+	return false
+}
@@ -0,0 +1,502 @@
+package vprog
+
+import (
+	"fmt"
+)
+
+// An opcode defines the information related to a vprog opcode: its
+// human-readable name, the static gas it costs to execute and the function
+// performing it. Opcodes whose cost depends on their operands charge the
+// dynamic part from within opfunc.
+type opcode struct {
+	value  byte
+	name   string
+	gas    uint64
+	opfunc func(*opcode, *ExecutionContext) error
+}
+
+// These constants are the values of the vprog opcodes.
+const (
+	OpStop = 0x00
+	OpPush = 0x01 // followed by a one-byte length and that many data bytes
+	OpPop  = 0x02
+	OpDup  = 0x03
+	OpSwap = 0x04
+	OpOver = 0x05
+
+	OpAdd = 0x10
+	OpSub = 0x11
+	OpMul = 0x12
+	OpDiv = 0x13
+	OpMod = 0x14
+
+	OpEqual   = 0x20
+	OpGreater = 0x21
+	OpLess    = 0x22
+	OpNot     = 0x23
+
+	OpJump     = 0x30
+	OpJumpI    = 0x31
+	OpJumpDest = 0x32
+
+	OpMStore = 0x40
+	OpMLoad  = 0x41
+
+	OpStore = 0x50
+	OpLoad  = 0x51
+
+	OpInputSize = 0x60
+	OpInputLoad = 0x61
+
+	OpCall   = 0x70
+	OpRet    = 0x71
+	OpReturn = 0x72
+	OpRevert = 0x73
+)
+
+// The gas schedule. Every opcode is charged its static cost before it is
+// executed. Opcodes that touch memory, input or storage are additionally
+// charged per byte or per word they operate on.
+const (
+	GasZero      = 0
+	GasBase      = 1
+	GasVeryLow   = 3
+	GasLow       = 5
+	GasMid       = 8
+	GasHigh      = 10
+	GasCall      = 20
+	GasLoad      = 200
+	GasStore     = 5000
+	GasDataWord  = 3  // per 32-byte word copied by OpMStore, OpMLoad and OpInputLoad
+	GasMemWord   = 3  // per 32-byte word memory grows by
+	GasStoreByte = 50 // per byte of key and value written by OpStore
+)
+
+// wordSize is the granularity of dynamic memory and copy gas.
+const wordSize = 32
+
+// opcodeArray holds details about all possible opcodes. Entries with a nil
+// opfunc are undefined and fail execution with ErrInvalidOpcode.
+var opcodeArray = [256]opcode{
+	OpStop: {OpStop, "OP_STOP", GasZero, opcodeStop},
+	OpPush: {OpPush, "OP_PUSH", GasVeryLow, opcodePush},
+	OpPop:  {OpPop, "OP_POP", GasBase, opcodePop},
+	OpDup:  {OpDup, "OP_DUP", GasVeryLow, opcodeDup},
+	OpSwap: {OpSwap, "OP_SWAP", GasVeryLow, opcodeSwap},
+	OpOver: {OpOver, "OP_OVER", GasVeryLow, opcodeOver},
+
+	OpAdd: {OpAdd, "OP_ADD", GasVeryLow, opcodeAdd},
+	OpSub: {OpSub, "OP_SUB", GasVeryLow, opcodeSub},
+	OpMul: {OpMul, "OP_MUL", GasLow, opcodeMul},
+	OpDiv: {OpDiv, "OP_DIV", GasLow, opcodeDiv},
+	OpMod: {OpMod, "OP_MOD", GasLow, opcodeMod},
+
+	OpEqual:   {OpEqual, "OP_EQUAL", GasVeryLow, opcodeEqual},
+	OpGreater: {OpGreater, "OP_GREATER", GasVeryLow, opcodeGreater},
+	OpLess:    {OpLess, "OP_LESS", GasVeryLow, opcodeLess},
+	OpNot:     {OpNot, "OP_NOT", GasVeryLow, opcodeNot},
+
+	OpJump:     {OpJump, "OP_JUMP", GasMid, opcodeJump},
+	OpJumpI:    {OpJumpI, "OP_JUMPI", GasHigh, opcodeJumpI},
+	OpJumpDest: {OpJumpDest, "OP_JUMPDEST", GasBase, opcodeJumpDest},
+
+	OpMStore: {OpMStore, "OP_MSTORE", GasVeryLow, opcodeMStore},
+	OpMLoad:  {OpMLoad, "OP_MLOAD", GasVeryLow, opcodeMLoad},
+
+	OpStore: {OpStore, "OP_STORE", GasStore, opcodeStore},
+	OpLoad:  {OpLoad, "OP_LOAD", GasLoad, opcodeLoad},
+
+	OpInputSize: {OpInputSize, "OP_INPUTSIZE", GasBase, opcodeInputSize},
+	OpInputLoad: {OpInputLoad, "OP_INPUTLOAD", GasVeryLow, opcodeInputLoad},
+
+	OpCall:   {OpCall, "OP_CALL", GasCall, opcodeCall},
+	OpRet:    {OpRet, "OP_RET", GasLow, opcodeRet},
+	OpReturn: {OpReturn, "OP_RETURN", GasZero, opcodeReturn},
+	OpRevert: {OpRevert, "OP_REVERT", GasZero, opcodeRevert},
+}
+
+// isDefined returns whether the opcode has an implementation.
+func (op *opcode) isDefined() bool {
+	return op.opfunc != nil
+}
+
+// OpcodeName returns the human-readable name of the given opcode value, or
+// OP_UNKNOWN<value> for undefined opcodes.
+func OpcodeName(value byte) string {
+	op := &opcodeArray[value]
+	if !op.isDefined() {
+		return fmt.Sprintf("OP_UNKNOWN%d", value)
+	}
+	return op.name
+}
+
+// OpcodeGas returns the static gas cost of the given opcode value and
+// whether the opcode is defined.
+func OpcodeGas(value byte) (uint64, bool) {
+	op := &opcodeArray[value]
+	return op.gas, op.isDefined()
+}
+
+// wordCount returns the number of 32-byte words needed to hold size bytes.
+func wordCount(size uint64) uint64 {
+	return (size + wordSize - 1) / wordSize
+}
+
+func opcodeStop(op *opcode, ctx *ExecutionContext) error {
+	ctx.halted = true
+	return nil
+}
+
+func opcodePush(op *opcode, ctx *ExecutionContext) error {
+	codeLength := uint64(len(ctx.Code))
+	if ctx.PC >= codeLength {
+		return executionError(ErrMalformedPush,
+			fmt.Sprintf("%s at offset %d is missing its length byte", op.name, ctx.PC-1))
+	}
+	length := uint64(ctx.Code[ctx.PC])
+	start := ctx.PC + 1
+	end := start + length
+	if end > codeLength {
+		return executionError(ErrMalformedPush,
+			fmt.Sprintf("%s at offset %d requires %d bytes, but program only has %d remaining",
+				op.name, ctx.PC-1, length, codeLength-start))
+	}
+	data := make([]byte, length)
+	copy(data, ctx.Code[start:end])
+	ctx.PC = end
+	return ctx.push(data)
+}
+
+func opcodePop(op *opcode, ctx *ExecutionContext) error {
+	_, err := ctx.pop()
+	return err
+}
+
+func opcodeDup(op *opcode, ctx *ExecutionContext) error {
+	item, err := ctx.peek(0)
+	if err != nil {
+		return err
+	}
+	return ctx.push(item)
+}
+
+func opcodeSwap(op *opcode, ctx *ExecutionContext) error {
+	if len(ctx.Stack) < 2 {
+		return stackUnderflowError(op, 2, len(ctx.Stack))
+	}
+	top := len(ctx.Stack) - 1
+	ctx.Stack[top], ctx.Stack[top-1] = ctx.Stack[top-1], ctx.Stack[top]
+	return nil
+}
+
+func opcodeOver(op *opcode, ctx *ExecutionContext) error {
+	item, err := ctx.peek(1)
+	if err != nil {
+		return err
+	}
+	return ctx.push(item)
+}
+
+// binaryNumericOperation pops b and then a off the stack and pushes the
+// result of operation(a, b).
+func binaryNumericOperation(ctx *ExecutionContext, operation func(a, b uint64) (uint64, error)) error {
+	b, err := ctx.popNumber()
+	if err != nil {
+		return err
+	}
+	a, err := ctx.popNumber()
+	if err != nil {
+		return err
+	}
+	result, err := operation(a, b)
+	if err != nil {
+		return err
+	}
+	return ctx.push(fromNumber(result))
+}
+
+func opcodeAdd(op *opcode, ctx *ExecutionContext) error {
+	return binaryNumericOperation(ctx, func(a, b uint64) (uint64, error) {
+		result := a + b
+		if result < a {
+			return 0, executionError(ErrArithmeticOverflow, fmt.Sprintf("%d + %d overflows", a, b))
+		}
+		return result, nil
+	})
+}
+
+func opcodeSub(op *opcode, ctx *ExecutionContext) error {
+	return binaryNumericOperation(ctx, func(a, b uint64) (uint64, error) {
+		if b > a {
+			return 0, executionError(ErrArithmeticOverflow, fmt.Sprintf("%d - %d underflows", a, b))
+		}
+		return a - b, nil
+	})
+}
+
+func opcodeMul(op *opcode, ctx *ExecutionContext) error {
+	return binaryNumericOperation(ctx, func(a, b uint64) (uint64, error) {
+		if a == 0 || b == 0 {
+			return 0, nil
+		}
+		result := a * b
+		if result/b != a {
+			return 0, executionError(ErrArithmeticOverflow, fmt.Sprintf("%d * %d overflows", a, b))
+		}
+		return result, nil
+	})
+}
+
+func opcodeDiv(op *opcode, ctx *ExecutionContext) error {
+	return binaryNumericOperation(ctx, func(a, b uint64) (uint64, error) {
+		if b == 0 {
+			return 0, executionError(ErrDivisionByZero, fmt.Sprintf("%d / 0", a))
+		}
+		return a / b, nil
+	})
+}
+
+func opcodeMod(op *opcode, ctx *ExecutionContext) error {
+	return binaryNumericOperation(ctx, func(a, b uint64) (uint64, error) {
+		if b == 0 {
+			return 0, executionError(ErrDivisionByZero, fmt.Sprintf("%d %% 0", a))
+		}
+		return a % b, nil
+	})
+}
+
+func opcodeEqual(op *opcode, ctx *ExecutionContext) error {
+	b, err := ctx.pop()
+	if err != nil {
+		return err
+	}
+	a, err := ctx.pop()
+	if err != nil {
+		return err
+	}
+	return ctx.push(fromBool(string(a) == string(b)))
+}
+
+func opcodeGreater(op *opcode, ctx *ExecutionContext) error {
+	return binaryNumericOperation(ctx, func(a, b uint64) (uint64, error) {
+		if a > b {
+			return 1, nil
+		}
+		return 0, nil
+	})
+}
+
+func opcodeLess(op *opcode, ctx *ExecutionContext) error {
+	return binaryNumericOperation(ctx, func(a, b uint64) (uint64, error) {
+		if a < b {
+			return 1, nil
+		}
+		return 0, nil
+	})
+}
+
+func opcodeNot(op *opcode, ctx *ExecutionContext) error {
+	item, err := ctx.pop()
+	if err != nil {
+		return err
+	}
+	return ctx.push(fromBool(!asBool(item)))
+}
+
+func opcodeJump(op *opcode, ctx *ExecutionContext) error {
+	destination, err := ctx.popNumber()
+	if err != nil {
+		return err
+	}
+	return ctx.jump(destination)
+}
+
+func opcodeJumpI(op *opcode, ctx *ExecutionContext) error {
+	destination, err := ctx.popNumber()
+	if err != nil {
+		return err
+	}
+	condition, err := ctx.pop()
+	if err != nil {
+		return err
+	}
+	if !asBool(condition) {
+		return nil
+	}
+	return ctx.jump(destination)
+}
+
+func opcodeJumpDest(op *opcode, ctx *ExecutionContext) error {
+	return nil
+}
+
+func opcodeMStore(op *opcode, ctx *ExecutionContext) error {
+	value, err := ctx.pop()
+	if err != nil {
+		return err
+	}
+	offset, err := ctx.popNumber()
+	if err != nil {
+		return err
+	}
+	err = ctx.useGas(GasDataWord * wordCount(uint64(len(value))))
+	if err != nil {
+		return err
+	}
+	end := offset + uint64(len(value))
+	if end < offset {
+		return executionError(ErrMemoryLimitExceeded, fmt.Sprintf("memory offset %d overflows", offset))
+	}
+	err = ctx.expandMemory(end)
+	if err != nil {
+		return err
+	}
+	copy(ctx.Memory[offset:end], value)
+	return nil
+}
+
+func opcodeMLoad(op *opcode, ctx *ExecutionContext) error {
+	length, err := ctx.popNumber()
+	if err != nil {
+		return err
+	}
+	offset, err := ctx.popNumber()
+	if err != nil {
+		return err
+	}
+	if length > MaxStackItemSize {
+		return executionError(ErrElementTooBig,
+			fmt.Sprintf("%s of %d bytes exceeds the maximum of %d", op.name, length, MaxStackItemSize))
+	}
+	end := offset + length
+	if end < offset || end > uint64(len(ctx.Memory)) {
+		return executionError(ErrInvalidMemoryAccess,
+			fmt.Sprintf("%s of [%d, %d+%d) is out of bounds of memory of size %d",
+				op.name, offset, offset, length, len(ctx.Memory)))
+	}
+	err = ctx.useGas(GasDataWord * wordCount(length))
+	if err != nil {
+		return err
+	}
+	data := make([]byte, length)
+	copy(data, ctx.Memory[offset:end])
+	return ctx.push(data)
+}
+
+func opcodeStore(op *opcode, ctx *ExecutionContext) error {
+	value, err := ctx.pop()
+	if err != nil {
+		return err
+	}
+	key, err := ctx.pop()
+	if err != nil {
+		return err
+	}
+	if len(key) > MaxStorageKeySize {
+		return executionError(ErrStorageKeyTooBig,
+			fmt.Sprintf("storage key of %d bytes exceeds the maximum of %d", len(key), MaxStorageKeySize))
+	}
+	err = ctx.useGas(GasStoreByte * uint64(len(key)+len(value)))
+	if err != nil {
+		return err
+	}
+	ctx.Storage[string(key)] = value
+	return nil
+}
+
+func opcodeLoad(op *opcode, ctx *ExecutionContext) error {
+	key, err := ctx.pop()
+	if err != nil {
+		return err
+	}
+	if len(key) > MaxStorageKeySize {
+		return executionError(ErrStorageKeyTooBig,
+			fmt.Sprintf("storage key of %d bytes exceeds the maximum of %d", len(key), MaxStorageKeySize))
+	}
+	value, err := ctx.load(key)
+	if err != nil {
+		return err
+	}
+	valueClone := make([]byte, len(value))
+	copy(valueClone, value)
+	return ctx.push(valueClone)
+}
+
+func opcodeInputSize(op *opcode, ctx *ExecutionContext) error {
+	return ctx.push(fromNumber(uint64(len(ctx.Input))))
+}
+
+func opcodeInputLoad(op *opcode, ctx *ExecutionContext) error {
+	length, err := ctx.popNumber()
+	if err != nil {
+		return err
+	}
+	offset, err := ctx.popNumber()
+	if err != nil {
+		return err
+	}
+	if length > MaxStackItemSize {
+		return executionError(ErrElementTooBig,
+			fmt.Sprintf("%s of %d bytes exceeds the maximum of %d", op.name, length, MaxStackItemSize))
+	}
+	end := offset + length
+	if end < offset || end > uint64(len(ctx.Input)) {
+		return executionError(ErrInvalidInputAccess,
+			fmt.Sprintf("%s of [%d, %d+%d) is out of bounds of input of size %d",
+				op.name, offset, offset, length, len(ctx.Input)))
+	}
+	err = ctx.useGas(GasDataWord * wordCount(length))
+	if err != nil {
+		return err
+	}
+	data := make([]byte, length)
+	copy(data, ctx.Input[offset:end])
+	return ctx.push(data)
+}
+
+func opcodeCall(op *opcode, ctx *ExecutionContext) error {
+	destination, err := ctx.popNumber()
+	if err != nil {
+		return err
+	}
+	if len(ctx.ReturnStack) >= MaxCallDepth {
+		return executionError(ErrCallDepthExceeded,
+			fmt.Sprintf("call depth exceeds the maximum of %d", MaxCallDepth))
+	}
+	returnAddress := ctx.PC
+	err = ctx.jump(destination)
+	if err != nil {
+		return err
+	}
+	ctx.ReturnStack = append(ctx.ReturnStack, returnAddress)
+	return nil
+}
+
+func opcodeRet(op *opcode, ctx *ExecutionContext) error {
+	if len(ctx.ReturnStack) == 0 {
+		return executionError(ErrReturnStackUnderflow, fmt.Sprintf("%s outside of a subroutine", op.name))
+	}
+	top := len(ctx.ReturnStack) - 1
+	ctx.PC = ctx.ReturnStack[top]
+	ctx.ReturnStack = ctx.ReturnStack[:top]
+	return nil
+}
+
+func opcodeReturn(op *opcode, ctx *ExecutionContext) error {
+	data, err := ctx.pop()
+	if err != nil {
+		return err
+	}
+	ctx.ReturnData = data
+	ctx.halted = true
+	return nil
+}
+
+func opcodeRevert(op *opcode, ctx *ExecutionContext) error {
+	data, err := ctx.pop()
+	if err != nil {
+		return err
+	}
+	ctx.ReturnData = data
+	return executionError(ErrReverted, fmt.Sprintf("%s executed at offset %d", op.name, ctx.PC-1))
+}
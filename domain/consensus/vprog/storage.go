@@ -0,0 +1,68 @@
+package vprog
+
+import (
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/utils/hashes"
+)
+
+// StorageReader provides read access to the storage of a single program
+type StorageReader interface {
+	Get(key []byte) (value []byte, found bool, err error)
+}
+
+// ProgramID returns the ID of the program with the given code. All
+// transactions carrying the same code operate on the same storage.
+func ProgramID(code []byte) *externalapi.DomainHash {
+	writer := hashes.NewVProgProgramIDWriter()
+	writer.InfallibleWrite(code)
+	return writer.Finalize()
+}
+
+// StateDiff accumulates the state changes of consecutive executions, keyed
+// by program ID
+type StateDiff map[externalapi.DomainHash]map[string][]byte
+
+// NewStateDiff creates a new, empty, StateDiff
+func NewStateDiff() StateDiff {
+	return make(StateDiff)
+}
+
+// AddStateChanges applies the given state changes of the given program on top
+// of this diff
+func (diff StateDiff) AddStateChanges(programID *externalapi.DomainHash, stateChanges map[string][]byte) {
+	if len(stateChanges) == 0 {
+		return
+	}
+	programDiff, ok := diff[*programID]
+	if !ok {
+		programDiff = make(map[string][]byte, len(stateChanges))
+		diff[*programID] = programDiff
+	}
+	for key, value := range stateChanges {
+		programDiff[key] = value
+	}
+}
+
+// StorageReader returns a StorageReader for the given program that reads
+// from this diff first and falls back to base. base may be nil.
+func (diff StateDiff) StorageReader(programID *externalapi.DomainHash, base StorageReader) StorageReader {
+	return &stateDiffStorageReader{
+		programDiff: diff[*programID],
+		base:        base,
+	}
+}
+
+type stateDiffStorageReader struct {
+	programDiff map[string][]byte
+	base        StorageReader
+}
+
+func (reader *stateDiffStorageReader) Get(key []byte) (value []byte, found bool, err error) {
+	if value, ok := reader.programDiff[string(key)]; ok {
+		return value, true, nil
+	}
+	if reader.base == nil {
+		return nil, false, nil
+	}
+	return reader.base.Get(key)
+}
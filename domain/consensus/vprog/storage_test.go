@@ -0,0 +1,78 @@
+package vprog
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+type mapStorageReader map[string][]byte
+
+func (reader mapStorageReader) Get(key []byte) ([]byte, bool, error) {
+	value, ok := reader[string(key)]
+	return value, ok, nil
+}
+
+type failingStorageReader struct{}
+
+func (failingStorageReader) Get(key []byte) ([]byte, bool, error) {
+	return nil, false, errors.New("storage failure")
+}
+
+func TestExecuteWithStorage(t *testing.T) {
+	engine := NewExecutionEngine()
+	code := program(push('a'), op(OpLoad), push('b'), op(OpLoad), op(OpAdd), op(OpReturn))
+
+	result, err := engine.ExecuteWithStorage(code, nil, 1000, mapStorageReader{"a": fromNumber(2), "b": fromNumber(3)})
+	if err != nil {
+		t.Fatalf("ExecuteWithStorage: %s", err)
+	}
+	if !result.Success || !bytes.Equal(result.ReturnData, fromNumber(5)) {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if len(result.StateChanges) != 0 {
+		t.Fatalf("reads must not appear in state changes, got %v", result.StateChanges)
+	}
+
+	_, err = engine.ExecuteWithStorage(code, nil, 1000, failingStorageReader{})
+	if err == nil {
+		t.Fatalf("expected a storage failure to be returned as an error")
+	}
+}
+
+func TestStateDiff(t *testing.T) {
+	engine := NewExecutionEngine()
+	code := program(push('k'), op(OpDup), op(OpLoad), pushNumber(1), op(OpAdd), op(OpStore))
+	programID := ProgramID(code)
+	base := mapStorageReader{"k": fromNumber(10)}
+
+	diff := NewStateDiff()
+	for i := 0; i < 3; i++ {
+		result, err := engine.ExecuteWithStorage(code, nil, 100_000, diff.StorageReader(programID, base))
+		if err != nil {
+			t.Fatalf("ExecuteWithStorage: %s", err)
+		}
+		if !result.Success {
+			t.Fatalf("execution failed: %s", result.Error)
+		}
+		diff.AddStateChanges(programID, result.StateChanges)
+	}
+
+	value, found, err := diff.StorageReader(programID, base).Get([]byte{'k'})
+	if err != nil {
+		t.Fatalf("Get: %s", err)
+	}
+	if !found || !bytes.Equal(value, fromNumber(13)) {
+		t.Fatalf("expected the counter to be 13, got %x (found: %t)", value, found)
+	}
+
+	otherProgramID := ProgramID(program(op(OpStop)))
+	_, found, err = diff.StorageReader(otherProgramID, nil).Get([]byte{'k'})
+	if err != nil {
+		t.Fatalf("Get: %s", err)
+	}
+	if found {
+		t.Fatalf("state of one program leaked into another")
+	}
+}
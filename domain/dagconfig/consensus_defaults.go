@@ -32,6 +32,13 @@ const (
 	defaultMassPerTxByte           = 1
 	defaultMassPerScriptPubKeyByte = 10
 	defaultMassPerSigOp            = 1000
+	// defaultMaxVProgGasLimit and defaultMaxBlockVProgGas bound the vprog gas a single transaction and a
+	// single block may use respectively.
+	defaultMaxVProgGasLimit = 1_000_000
+	defaultMaxBlockVProgGas = 10_000_000
+	// defaultVProgGasPerMass is the number of vprog gas units that add a single gram to a transaction's mass.
+	// With the default values, a transaction that uses the maximum vprog gas limit adds 10,000 grams.
+	defaultVProgGasPerMass = 100
 	// defaultMaxBlockParents is the number of blocks any block can point to.
 	// Should be about d/defaultTargetTimePerBlock where d is a bound on the round trip time of a block.
 	defaultMaxBlockParents = 10
@@ -63,6 +63,9 @@ type Params struct {
 	// RPCPort defines the rpc server port
 	RPCPort string
 
+	// JSONRPCPort defines the JSON-RPC server port
+	JSONRPCPort string
+
 	// DefaultPort defines the default peer-to-peer port for the network.
 	DefaultPort string
 
@@ -169,6 +172,19 @@ type Params struct {
 	// signature operation adds to a transaction.
 	MassPerSigOp uint64
 
+	// EnableVProgs enables transactions that carry vprogs
+	EnableVProgs bool
+
+	// MaxVProgGasLimit is the maximum vprog gas limit a transaction is allowed
+	MaxVProgGasLimit uint64
+
+	// MaxBlockVProgGas is the maximum sum of the vprog gas limits of a block's transactions
+	MaxBlockVProgGas uint64
+
+	// VProgGasPerMass is the number of vprog gas units that add a single gram
+	// to a transaction's mass.
+	VProgGasPerMass uint64
+
 	// MergeSetSizeLimit is the maximum number of blocks in a block's merge set
 	MergeSetSizeLimit uint64
 
@@ -212,6 +228,7 @@ var MainnetParams = Params{
 	Name:        "zuad-mainnet",
 	Net:         appmessage.Mainnet,
 	RPCPort:     "46005",
+	JSONRPCPort: "46006",
 	DefaultPort: "46009",
 	DNSSeeds: []string{
 		"199.188.204.20",
@@ -255,6 +272,9 @@ var MainnetParams = Params{
 	// EnableNonNativeSubnetworks enables non-native/coinbase transactions
 	EnableNonNativeSubnetworks: false,
 
+	// EnableVProgs enables transactions that carry vprogs
+	EnableVProgs: false,
+
 	DisableDifficultyAdjustment: false,
 
 	MaxCoinbasePayloadLength:                defaultMaxCoinbasePayloadLength,
@@ -263,6 +283,9 @@ var MainnetParams = Params{
 	MassPerTxByte:                           defaultMassPerTxByte,
 	MassPerScriptPubKeyByte:                 defaultMassPerScriptPubKeyByte,
 	MassPerSigOp:                            defaultMassPerSigOp,
+	MaxVProgGasLimit:                        defaultMaxVProgGasLimit,
+	MaxBlockVProgGas:                        defaultMaxBlockVProgGas,
+	VProgGasPerMass:                         defaultVProgGasPerMass,
 	MergeSetSizeLimit:                       defaultMergeSetSizeLimit,
 	CoinbasePayloadScriptPublicKeyMaxLength: defaultCoinbasePayloadScriptPublicKeyMaxLength,
 	PruningProofM:                           defaultPruningProofM,
@@ -281,6 +304,7 @@ var TestnetParams = Params{
 	Name:        "zuad-testnet-10",
 	Net:         appmessage.Testnet,
 	RPCPort:     "16210",
+	JSONRPCPort: "16212",
 	DefaultPort: "16211",
 	DNSSeeds:    []string{},
 
@@ -320,6 +344,9 @@ var TestnetParams = Params{
 	// EnableNonNativeSubnetworks enables non-native/coinbase transactions
 	EnableNonNativeSubnetworks: false,
 
+	// EnableVProgs enables transactions that carry vprogs
+	EnableVProgs: false,
+
 	DisableDifficultyAdjustment: false,
 
 	MaxCoinbasePayloadLength:                defaultMaxCoinbasePayloadLength,
@@ -328,6 +355,9 @@ var TestnetParams = Params{
 	MassPerTxByte:                           defaultMassPerTxByte,
 	MassPerScriptPubKeyByte:                 defaultMassPerScriptPubKeyByte,
 	MassPerSigOp:                            defaultMassPerSigOp,
+	MaxVProgGasLimit:                        defaultMaxVProgGasLimit,
+	MaxBlockVProgGas:                        defaultMaxBlockVProgGas,
+	VProgGasPerMass:                         defaultVProgGasPerMass,
 	MergeSetSizeLimit:                       defaultMergeSetSizeLimit,
 	CoinbasePayloadScriptPublicKeyMaxLength: defaultCoinbasePayloadScriptPublicKeyMaxLength,
 	PruningProofM:                           defaultPruningProofM,
@@ -349,6 +379,7 @@ var SimnetParams = Params{
 	Name:        "zuad-simnet",
 	Net:         appmessage.Simnet,
 	RPCPort:     "16510",
+	JSONRPCPort: "16512",
 	DefaultPort: "16511",
 	DNSSeeds:    []string{}, // NOTE: There must NOT be any seeds.
 
@@ -386,6 +417,9 @@ var SimnetParams = Params{
 	// EnableNonNativeSubnetworks enables non-native/coinbase transactions
 	EnableNonNativeSubnetworks: false,
 
+	// EnableVProgs enables transactions that carry vprogs
+	EnableVProgs: true,
+
 	DisableDifficultyAdjustment: true,
 
 	MaxCoinbasePayloadLength:                defaultMaxCoinbasePayloadLength,
@@ -394,6 +428,9 @@ var SimnetParams = Params{
 	MassPerTxByte:                           defaultMassPerTxByte,
 	MassPerScriptPubKeyByte:                 defaultMassPerScriptPubKeyByte,
 	MassPerSigOp:                            defaultMassPerSigOp,
+	MaxVProgGasLimit:                        defaultMaxVProgGasLimit,
+	MaxBlockVProgGas:                        defaultMaxBlockVProgGas,
+	VProgGasPerMass:                         defaultVProgGasPerMass,
 	MergeSetSizeLimit:                       defaultMergeSetSizeLimit,
 	CoinbasePayloadScriptPublicKeyMaxLength: defaultCoinbasePayloadScriptPublicKeyMaxLength,
 	PruningProofM:                           defaultPruningProofM,
@@ -409,6 +446,7 @@ var DevnetParams = Params{
 	Name:        "zuad-devnet",
 	Net:         appmessage.Devnet,
 	RPCPort:     "16610",
+	JSONRPCPort: "16612",
 	DefaultPort: "16611",
 	DNSSeeds:    []string{}, // NOTE: There must NOT be any seeds.
 
@@ -448,6 +486,9 @@ var DevnetParams = Params{
 	// EnableNonNativeSubnetworks enables non-native/coinbase transactions
 	EnableNonNativeSubnetworks: false,
 
+	// EnableVProgs enables transactions that carry vprogs
+	EnableVProgs: true,
+
 	DisableDifficultyAdjustment: false,
 
 	MaxCoinbasePayloadLength:                defaultMaxCoinbasePayloadLength,
@@ -456,6 +497,9 @@ var DevnetParams = Params{
 	MassPerTxByte:                           defaultMassPerTxByte,
 	MassPerScriptPubKeyByte:                 defaultMassPerScriptPubKeyByte,
 	MassPerSigOp:                            defaultMassPerSigOp,
+	MaxVProgGasLimit:                        defaultMaxVProgGasLimit,
+	MaxBlockVProgGas:                        defaultMaxBlockVProgGas,
+	VProgGasPerMass:                         defaultVProgGasPerMass,
 	MergeSetSizeLimit:                       defaultMergeSetSizeLimit,
 	CoinbasePayloadScriptPublicKeyMaxLength: defaultCoinbasePayloadScriptPublicKeyMaxLength,
 	PruningProofM:                           defaultPruningProofM,
@@ -0,0 +1,7 @@
+package mempoolsnapshot
+
+import (
+	"github.com/zuanet/zuad/infrastructure/logger"
+)
+
+var log = logger.RegisterSubSystem("MPSN")
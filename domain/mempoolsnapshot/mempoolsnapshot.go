@@ -0,0 +1,201 @@
+package mempoolsnapshot
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/zuanet/zuad/domain"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/utils/consensushashing"
+	"github.com/zuanet/zuad/domain/miningmanager/mempool"
+	"github.com/zuanet/zuad/infrastructure/db/database"
+	"github.com/zuanet/zuad/infrastructure/logger"
+)
+
+// snapshotInterval is the interval between periodic mempool snapshots
+const snapshotInterval = 10 * time.Minute
+
+// MempoolSnapshot periodically saves the transactions in the mempool to the database,
+// so that they can be restored after a restart
+type MempoolSnapshot struct {
+	domain   domain.Domain
+	database database.Database
+
+	mutex    sync.Mutex
+	shutdown chan struct{}
+	wg       sync.WaitGroup
+}
+
+// New creates a new MempoolSnapshot
+func New(domain domain.Domain, database database.Database) *MempoolSnapshot {
+	return &MempoolSnapshot{
+		domain:   domain,
+		database: database,
+		shutdown: make(chan struct{}),
+	}
+}
+
+// Restore inserts the transactions of the last saved snapshot into the mempool.
+// Every transaction is revalidated against the current virtual UTXO set, and
+// transactions that are no longer valid are dropped.
+func (ms *MempoolSnapshot) Restore() error {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	onEnd := logger.LogAndMeasureExecutionTime(log, "MempoolSnapshot.Restore")
+	defer onEnd()
+
+	transactions, err := loadTransactions(ms.database, transactionsBucket)
+	if err != nil {
+		return err
+	}
+	orphans, err := loadTransactions(ms.database, orphansBucket)
+	if err != nil {
+		return err
+	}
+	highPriorityTransactionIDs, err := loadHighPriorityTransactionIDs(ms.database)
+	if err != nil {
+		return err
+	}
+
+	restoredCount := 0
+	for _, transaction := range transactions {
+		isRestored, err := ms.restoreTransaction(transaction, false, highPriorityTransactionIDs)
+		if err != nil {
+			return err
+		}
+		if isRestored {
+			restoredCount++
+		}
+	}
+	for _, orphan := range orphans {
+		isRestored, err := ms.restoreTransaction(orphan, true, highPriorityTransactionIDs)
+		if err != nil {
+			return err
+		}
+		if isRestored {
+			restoredCount++
+		}
+	}
+
+	log.Infof("Restored %d out of %d transactions from the mempool snapshot",
+		restoredCount, len(transactions)+len(orphans))
+	return nil
+}
+
+func (ms *MempoolSnapshot) restoreTransaction(transaction *externalapi.DomainTransaction, isOrphan bool,
+	highPriorityTransactionIDs map[externalapi.DomainTransactionID]struct{}) (bool, error) {
+
+	transactionID := consensushashing.TransactionID(transaction)
+	_, isHighPriority := highPriorityTransactionIDs[*transactionID]
+	_, err := ms.domain.MiningManager().ValidateAndInsertTransaction(transaction, isHighPriority, isOrphan)
+	if err != nil {
+		if !errors.As(err, &mempool.RuleError{}) {
+			return false, err
+		}
+		log.Infof("Dropped transaction %s from the mempool snapshot: %s", transactionID, err)
+		return false, nil
+	}
+	return true, nil
+}
+
+// Save saves the transactions currently in the mempool, replacing the previous snapshot
+func (ms *MempoolSnapshot) Save() error {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	onEnd := logger.LogAndMeasureExecutionTime(log, "MempoolSnapshot.Save")
+	defer onEnd()
+
+	transactions, orphans := ms.domain.MiningManager().AllTransactions(true, true)
+	highPriorityTransactionIDs := ms.domain.MiningManager().HighPriorityTransactionIDs()
+
+	dbTransaction, err := ms.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer dbTransaction.RollbackUnlessClosed()
+
+	err = storeTransactions(dbTransaction, transactionsBucket, sortTopologically(transactions))
+	if err != nil {
+		return err
+	}
+	err = storeTransactions(dbTransaction, orphansBucket, sortTopologically(orphans))
+	if err != nil {
+		return err
+	}
+	err = storeHighPriorityTransactionIDs(dbTransaction, highPriorityTransactionIDs)
+	if err != nil {
+		return err
+	}
+
+	err = dbTransaction.Commit()
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("Saved %d transactions and %d orphans to the mempool snapshot", len(transactions), len(orphans))
+	return nil
+}
+
+// Start starts saving the mempool periodically
+func (ms *MempoolSnapshot) Start() {
+	ms.wg.Add(1)
+	go func() {
+		defer ms.wg.Done()
+
+		ticker := time.NewTicker(snapshotInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				err := ms.Save()
+				if err != nil {
+					log.Errorf("Error saving the mempool snapshot: %+v", err)
+				}
+			case <-ms.shutdown:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops saving the mempool periodically, and saves it one last time
+func (ms *MempoolSnapshot) Stop() error {
+	close(ms.shutdown)
+	ms.wg.Wait()
+
+	return ms.Save()
+}
+
+// sortTopologically sorts the given transactions so that every transaction
+// appears after all of its parents among them
+func sortTopologically(transactions []*externalapi.DomainTransaction) []*externalapi.DomainTransaction {
+	transactionsByID := make(map[externalapi.DomainTransactionID]*externalapi.DomainTransaction, len(transactions))
+	for _, transaction := range transactions {
+		transactionsByID[*consensushashing.TransactionID(transaction)] = transaction
+	}
+
+	sorted := make([]*externalapi.DomainTransaction, 0, len(transactions))
+	visited := make(map[externalapi.DomainTransactionID]struct{}, len(transactions))
+	var visit func(transactionID *externalapi.DomainTransactionID, transaction *externalapi.DomainTransaction)
+	visit = func(transactionID *externalapi.DomainTransactionID, transaction *externalapi.DomainTransaction) {
+		if _, ok := visited[*transactionID]; ok {
+			return
+		}
+		visited[*transactionID] = struct{}{}
+		for _, input := range transaction.Inputs {
+			parentID := input.PreviousOutpoint.TransactionID
+			if parent, ok := transactionsByID[parentID]; ok {
+				visit(&parentID, parent)
+			}
+		}
+		sorted = append(sorted, transaction)
+	}
+	for _, transaction := range transactions {
+		visit(consensushashing.TransactionID(transaction), transaction)
+	}
+	return sorted
+}
@@ -0,0 +1,128 @@
+package mempoolsnapshot_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/zuanet/zuad/domain"
+	"github.com/zuanet/zuad/domain/consensus"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/utils/consensushashing"
+	"github.com/zuanet/zuad/domain/consensus/utils/testutils"
+	"github.com/zuanet/zuad/domain/mempoolsnapshot"
+	"github.com/zuanet/zuad/domain/miningmanager/mempool"
+	"github.com/zuanet/zuad/infrastructure/db/database/ldb"
+)
+
+func TestMempoolSnapshot(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+
+		dataDir, err := ioutil.TempDir("", fmt.Sprintf("TestMempoolSnapshot-%s", consensusConfig.Name))
+		if err != nil {
+			t.Fatalf("ioutil.TempDir: %+v", err)
+		}
+		defer os.RemoveAll(dataDir)
+
+		db, err := ldb.NewLevelDB(dataDir, 8)
+		if err != nil {
+			t.Fatalf("NewLevelDB: %+v", err)
+		}
+		defer db.Close()
+
+		domainInstance, err := domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), db)
+		if err != nil {
+			t.Fatalf("New: %+v", err)
+		}
+
+		scriptPublicKey, _ := testutils.OpTrueScript()
+		addBlock := func(transactions []*externalapi.DomainTransaction) *externalapi.DomainBlock {
+			coinbaseData := &externalapi.DomainCoinbaseData{
+				ScriptPublicKey: scriptPublicKey,
+				ExtraData:       []byte{},
+			}
+			block, err := domainInstance.Consensus().BuildBlock(coinbaseData, transactions)
+			if err != nil {
+				t.Fatalf("BuildBlock: %+v", err)
+			}
+			err = domainInstance.Consensus().ValidateAndInsertBlock(block, true)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertBlock: %+v", err)
+			}
+			return block
+		}
+		createTransaction := func(txToSpend *externalapi.DomainTransaction) *externalapi.DomainTransaction {
+			transaction, err := testutils.CreateTransaction(txToSpend, 1000)
+			if err != nil {
+				t.Fatalf("CreateTransaction: %+v", err)
+			}
+			return transaction
+		}
+
+		// The coinbase transaction of the third block is the first to pay a block reward
+		addBlock(nil)
+		addBlock(nil)
+		fundingBlock := addBlock(nil)
+
+		parentTransaction := createTransaction(fundingBlock.Transactions[0])
+		childTransaction := createTransaction(parentTransaction)
+		missingTransaction := createTransaction(childTransaction)
+		missingTransaction.Outputs[0].Value--
+		orphanTransaction := createTransaction(missingTransaction)
+
+		_, err = domainInstance.MiningManager().ValidateAndInsertTransaction(parentTransaction.Clone(), false, true)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertTransaction: %+v", err)
+		}
+		for _, transaction := range []*externalapi.DomainTransaction{childTransaction, orphanTransaction} {
+			_, err = domainInstance.MiningManager().ValidateAndInsertTransaction(transaction.Clone(), true, true)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertTransaction: %+v", err)
+			}
+		}
+
+		err = mempoolsnapshot.New(domainInstance, db).Save()
+		if err != nil {
+			t.Fatalf("Save: %+v", err)
+		}
+
+		// Mine the parent transaction after the snapshot was taken, so it should be dropped on restore
+		addBlock([]*externalapi.DomainTransaction{parentTransaction.Clone()})
+
+		restartedDomainInstance, err := domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), db)
+		if err != nil {
+			t.Fatalf("New: %+v", err)
+		}
+		err = mempoolsnapshot.New(restartedDomainInstance, db).Restore()
+		if err != nil {
+			t.Fatalf("Restore: %+v", err)
+		}
+
+		transactions, orphans := restartedDomainInstance.MiningManager().AllTransactions(true, true)
+		if len(transactions) != 1 || !transactions[0].Equal(childTransaction) {
+			t.Fatalf("Expected only the child transaction to be restored to the mempool, but got %d transactions",
+				len(transactions))
+		}
+		if len(orphans) != 1 || !orphans[0].Equal(orphanTransaction) {
+			t.Fatalf("Expected only the orphan transaction to be restored to the orphan pool, but got %d orphans",
+				len(orphans))
+		}
+
+		highPriorityTransactionIDs := restartedDomainInstance.MiningManager().HighPriorityTransactionIDs()
+		expectedHighPriorityTransactionIDs := map[externalapi.DomainTransactionID]struct{}{
+			*consensushashing.TransactionID(childTransaction):  {},
+			*consensushashing.TransactionID(orphanTransaction): {},
+		}
+		if len(highPriorityTransactionIDs) != len(expectedHighPriorityTransactionIDs) {
+			t.Fatalf("Expected %d high priority transactions after the restore, but got %d",
+				len(expectedHighPriorityTransactionIDs), len(highPriorityTransactionIDs))
+		}
+		for _, transactionID := range highPriorityTransactionIDs {
+			if _, ok := expectedHighPriorityTransactionIDs[*transactionID]; !ok {
+				t.Fatalf("Transaction %s unexpectedly restored with high priority", transactionID)
+			}
+		}
+	})
+}
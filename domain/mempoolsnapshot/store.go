@@ -0,0 +1,151 @@
+package mempoolsnapshot
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/zuanet/zuad/domain/consensus/database/serialization"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/infrastructure/db/database"
+)
+
+var transactionsBucket = database.MakeBucket([]byte("mempool-snapshot-transactions"))
+var orphansBucket = database.MakeBucket([]byte("mempool-snapshot-orphans"))
+
+// highPriorityBucket holds the IDs of the transactions and orphans of the snapshot that
+// were submitted with high priority. It's kept apart from the transactions, so that
+// snapshots saved before it existed can still be read.
+var highPriorityBucket = database.MakeBucket([]byte("mempool-snapshot-high-priority"))
+
+// storeTransactions replaces the transactions stored in the given bucket with the given
+// transactions. The transactions are keyed by their position, so that they're read back
+// in the same order.
+func storeTransactions(dbTransaction database.Transaction, bucket *database.Bucket,
+	transactions []*externalapi.DomainTransaction) error {
+
+	err := clearBucket(dbTransaction, bucket)
+	if err != nil {
+		return err
+	}
+
+	for i, transaction := range transactions {
+		serializedTransaction, err := proto.Marshal(serialization.DomainTransactionToDbTransaction(transaction))
+		if err != nil {
+			return err
+		}
+		err = dbTransaction.Put(transactionKey(bucket, uint64(i)), serializedTransaction)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// storeHighPriorityTransactionIDs replaces the IDs stored in highPriorityBucket with the
+// given transaction IDs
+func storeHighPriorityTransactionIDs(dbTransaction database.Transaction,
+	transactionIDs []*externalapi.DomainTransactionID) error {
+
+	err := clearBucket(dbTransaction, highPriorityBucket)
+	if err != nil {
+		return err
+	}
+
+	for _, transactionID := range transactionIDs {
+		err = dbTransaction.Put(highPriorityBucket.Key(transactionID.ByteSlice()), []byte{})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadHighPriorityTransactionIDs returns the set of IDs stored in highPriorityBucket
+func loadHighPriorityTransactionIDs(db database.Database) (map[externalapi.DomainTransactionID]struct{}, error) {
+	cursor, err := db.Cursor(highPriorityBucket)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	transactionIDs := make(map[externalapi.DomainTransactionID]struct{})
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return nil, err
+		}
+		transactionID, err := externalapi.NewDomainTransactionIDFromByteSlice(key.Suffix())
+		if err != nil {
+			return nil, err
+		}
+		transactionIDs[*transactionID] = struct{}{}
+	}
+	return transactionIDs, nil
+}
+
+// clearBucket deletes all the keys in the given bucket
+func clearBucket(dbTransaction database.Transaction, bucket *database.Bucket) error {
+	cursor, err := dbTransaction.Cursor(bucket)
+	if err != nil {
+		return err
+	}
+	var keysToDelete []*database.Key
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			cursor.Close()
+			return err
+		}
+		keysToDelete = append(keysToDelete, key)
+	}
+	err = cursor.Close()
+	if err != nil {
+		return err
+	}
+	for _, key := range keysToDelete {
+		err = dbTransaction.Delete(key)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadTransactions returns the transactions stored in the given bucket, in the order
+// they were stored in
+func loadTransactions(db database.Database, bucket *database.Bucket) ([]*externalapi.DomainTransaction, error) {
+	cursor, err := db.Cursor(bucket)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	var transactions []*externalapi.DomainTransaction
+	for cursor.Next() {
+		serializedTransaction, err := cursor.Value()
+		if err != nil {
+			return nil, err
+		}
+		dbTransaction := &serialization.DbTransaction{}
+		err = proto.Unmarshal(serializedTransaction, dbTransaction)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to deserialize a mempool snapshot transaction")
+		}
+		transaction, err := serialization.DbTransactionToDomainTransaction(dbTransaction)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, transaction)
+	}
+	return transactions, nil
+}
+
+func transactionKey(bucket *database.Bucket, index uint64) *database.Key {
+	var serializedIndex [8]byte
+	binary.BigEndian.PutUint64(serializedIndex[:], index)
+	return bucket.Key(serializedIndex[:])
+}
@@ -198,6 +198,22 @@ func syncConsensuses(syncer, syncee externalapi.Consensus) error {
 		}
 	}
 
+	var fromVProgStateEntry *externalapi.VProgStateEntry
+	for {
+		vprogStateEntries, err := syncer.GetPruningPointVProgState(pruningPoint, fromVProgStateEntry, step)
+		if err != nil {
+			return err
+		}
+		err = syncee.AppendImportedPruningPointVProgState(vprogStateEntries)
+		if err != nil {
+			return err
+		}
+		if len(vprogStateEntries) < step {
+			break
+		}
+		fromVProgStateEntry = vprogStateEntries[len(vprogStateEntries)-1]
+	}
+
 	// Check that ValidateAndInsertImportedPruningPoint works given the right arguments.
 	err = syncee.ValidateAndInsertImportedPruningPoint(pruningPoint)
 	if err != nil {
@@ -23,6 +23,8 @@ type candidateTx struct {
 	txValue  float64
 	gasLimit uint64
 
+	packageTransactionIDs []*consensusexternalapi.DomainTransactionID
+
 	p     float64
 	start float64
 	end   float64
@@ -41,11 +43,11 @@ type blockTemplateBuilder struct {
 
 // New creates a new blockTemplateBuilder
 func New(consensusReference consensusreference.ConsensusReference, mempool miningmanagerapi.Mempool,
-	blockMaxMass uint64, coinbasePayloadScriptPublicKeyMaxLength uint8) miningmanagerapi.BlockTemplateBuilder {
+	blockMaxMass uint64, blockMaxVProgGas uint64, coinbasePayloadScriptPublicKeyMaxLength uint8) miningmanagerapi.BlockTemplateBuilder {
 	return &blockTemplateBuilder{
 		consensusReference: consensusReference,
 		mempool:            mempool,
-		policy:             policy{BlockMaxMass: blockMaxMass},
+		policy:             policy{BlockMaxMass: blockMaxMass, BlockMaxVProgGas: blockMaxVProgGas},
 
 		coinbasePayloadScriptPublicKeyMaxLength: coinbasePayloadScriptPublicKeyMaxLength,
 	}
@@ -120,16 +122,18 @@ func (btb *blockTemplateBuilder) BuildBlockTemplate(
 
 	mempoolTransactions := btb.mempool.BlockCandidateTransactions()
 	candidateTxs := make([]*candidateTx, 0, len(mempoolTransactions))
-	for _, tx := range mempoolTransactions {
+	for _, mempoolTransaction := range mempoolTransactions {
+		tx := mempoolTransaction.Transaction
 		// Calculate the tx value
 		gasLimit := uint64(0)
 		if !subnetworks.IsBuiltInOrNative(tx.SubnetworkID) {
 			panic("We currently don't support non native subnetworks")
 		}
 		candidateTxs = append(candidateTxs, &candidateTx{
-			DomainTransaction: tx,
-			txValue:           btb.calcTxValue(tx),
-			gasLimit:          gasLimit,
+			DomainTransaction:     tx,
+			txValue:               btb.calcTxValue(mempoolTransaction),
+			gasLimit:              gasLimit,
+			packageTransactionIDs: mempoolTransaction.PackageTransactionIDs,
 		})
 	}
 
@@ -151,7 +155,7 @@ func (btb *blockTemplateBuilder) BuildBlockTemplate(
 		for _, tx := range invalidTxsErr.InvalidTransactions {
 			invalidTxs = append(invalidTxs, tx.Transaction)
 		}
-		err = btb.mempool.RemoveTransactions(invalidTxs, true)
+		err = btb.mempool.RemoveTransactions(invalidTxs, true, miningmanagerapi.MempoolRemovalReasonInvalid)
 		if err != nil {
 			// mempool.RemoveTransactions might return errors in situations that are perfectly fine in this context.
 			// TODO: Once the mempool invariants are clear, this should be converted back `return nil, err`:
@@ -208,15 +212,17 @@ func (btb *blockTemplateBuilder) ModifyBlockTemplate(newCoinbaseData *consensuse
 // calcTxValue calculates a value to be used in transaction selection.
 // The higher the number the more likely it is that the transaction will be
 // included in the block.
-func (btb *blockTemplateBuilder) calcTxValue(tx *consensusexternalapi.DomainTransaction) float64 {
+// The value is based on the fee rate of the package the transaction belongs
+// to, so that a transaction is prioritized by the descendants that pay for it.
+func (btb *blockTemplateBuilder) calcTxValue(candidate *miningmanagerapi.BlockCandidateTransaction) float64 {
 	massLimit := btb.policy.BlockMaxMass
 
-	mass := tx.Mass
-	fee := tx.Fee
-	if subnetworks.IsBuiltInOrNative(tx.SubnetworkID) {
+	mass := candidate.PackageMass
+	fee := candidate.PackageFee
+	if subnetworks.IsBuiltInOrNative(candidate.Transaction.SubnetworkID) {
 		return float64(fee) / (float64(mass) / float64(massLimit))
 	}
 	// TODO: Replace with real gas once implemented
 	gasLimit := uint64(math.MaxUint64)
-	return float64(fee) / (float64(mass)/float64(massLimit) + float64(tx.Gas)/float64(gasLimit))
+	return float64(fee) / (float64(mass)/float64(massLimit) + float64(candidate.Transaction.Gas)/float64(gasLimit))
 }
@@ -11,4 +11,8 @@ type policy struct {
 	// BlockMaxMass is the maximum block mass to be used when generating a
 	// block template.
 	BlockMaxMass uint64
+
+	// BlockMaxVProgGas is the maximum sum of vprog gas limits to be used
+	// when generating a block template.
+	BlockMaxVProgGas uint64
 }
@@ -67,6 +67,7 @@ func (btb *blockTemplateBuilder) selectTransactions(candidateTxs []*candidateTx)
 	usedCount, usedP := 0, 0.0
 	candidateTxs, totalP := rebalanceCandidates(candidateTxs, true)
 	gasUsageMap := make(map[consensusexternalapi.DomainSubnetworkID]uint64)
+	vprogGasUsage := uint64(0)
 
 	markCandidateTxForDeletion := func(candidateTx *candidateTx) {
 		candidateTx.isMarkedForDeletion = true
@@ -74,7 +75,23 @@ func (btb *blockTemplateBuilder) selectTransactions(candidateTxs []*candidateTx)
 		usedP += candidateTx.p
 	}
 
+	candidateTxsByID := make(map[consensusexternalapi.DomainTransactionID]*candidateTx, len(candidateTxs))
+	for _, candidateTx := range candidateTxs {
+		candidateTxsByID[*consensushashing.TransactionID(candidateTx.DomainTransaction)] = candidateTx
+	}
+
 	selectedTxs := make([]*candidateTx, 0)
+	addSelectedTx := func(selectedTx *candidateTx) {
+		selectedTxs = append(selectedTxs, selectedTx)
+		vprogGasUsage += selectedTx.VProgGasLimit
+		txsForBlockTemplate.totalMass += selectedTx.Mass
+		txsForBlockTemplate.totalFees += selectedTx.Fee
+
+		log.Tracef("Adding tx %s (feePerMegaGram %d)",
+			consensushashing.TransactionID(selectedTx.DomainTransaction), selectedTx.Fee*1e6/selectedTx.Mass)
+
+		markCandidateTxForDeletion(selectedTx)
+	}
 	for len(candidateTxs)-usedCount > 0 {
 		// Rebalance the candidates if it's required
 		if usedP >= rebalanceThreshold*totalP {
@@ -108,6 +125,16 @@ func (btb *blockTemplateBuilder) selectTransactions(candidateTxs []*candidateTx)
 			break
 		}
 
+		// Enforce maximum vprog gas per block. Also check for overflow.
+		// Other transactions may still fit, so only this one is skipped.
+		if vprogGasUsage+tx.VProgGasLimit < vprogGasUsage ||
+			vprogGasUsage+tx.VProgGasLimit > btb.policy.BlockMaxVProgGas {
+			log.Tracef("Tx %s would exceed the vprog gas limit of the block. "+
+				"Skipping it.", consensushashing.TransactionID(tx))
+			markCandidateTxForDeletion(selectedTx)
+			continue
+		}
+
 		// Enforce maximum gas per subnetwork per block. Also check
 		// for overflow.
 		if !subnetworks.IsBuiltInOrNative(tx.SubnetworkID) {
@@ -142,14 +169,27 @@ func (btb *blockTemplateBuilder) selectTransactions(candidateTxs []*candidateTx)
 		// Add the transaction to the result, increment counters, and
 		// save the masses, fees, and signature operation counts to the
 		// result.
-		selectedTxs = append(selectedTxs, selectedTx)
-		txsForBlockTemplate.totalMass += selectedTx.Mass
-		txsForBlockTemplate.totalFees += selectedTx.Fee
-
-		log.Tracef("Adding tx %s (feePerMegaGram %d)",
-			consensushashing.TransactionID(tx), selectedTx.Fee*1e6/selectedTx.Mass)
-
-		markCandidateTxForDeletion(selectedTx)
+		addSelectedTx(selectedTx)
+
+		// Add the other candidates of the package the transaction was valued by,
+		// so that the descendant transaction which pays for them may be included
+		// in one of the following blocks. Package members that don't fit are
+		// left for the following blocks as well.
+		for _, packageTransactionID := range selectedTx.packageTransactionIDs {
+			packageTx, ok := candidateTxsByID[*packageTransactionID]
+			if !ok || packageTx.isMarkedForDeletion || !subnetworks.IsBuiltInOrNative(packageTx.SubnetworkID) {
+				continue
+			}
+			if txsForBlockTemplate.totalMass+packageTx.Mass < txsForBlockTemplate.totalMass ||
+				txsForBlockTemplate.totalMass+packageTx.Mass > btb.policy.BlockMaxMass {
+				continue
+			}
+			if vprogGasUsage+packageTx.VProgGasLimit < vprogGasUsage ||
+				vprogGasUsage+packageTx.VProgGasLimit > btb.policy.BlockMaxVProgGas {
+				continue
+			}
+			addSelectedTx(packageTx)
+		}
 	}
 
 	sort.Slice(selectedTxs, func(i, j int) bool {
@@ -4,29 +4,35 @@ import (
 	"github.com/zuanet/zuad/domain/consensusreference"
 	"github.com/zuanet/zuad/domain/dagconfig"
 	"github.com/zuanet/zuad/domain/miningmanager/blocktemplatebuilder"
+	"github.com/zuanet/zuad/domain/miningmanager/feeestimator"
 	mempoolpkg "github.com/zuanet/zuad/domain/miningmanager/mempool"
+	miningmanagermodel "github.com/zuanet/zuad/domain/miningmanager/model"
 	"sync"
 	"time"
 )
 
 // Factory instantiates new mining managers
 type Factory interface {
-	NewMiningManager(consensus consensusreference.ConsensusReference, params *dagconfig.Params, mempoolConfig *mempoolpkg.Config) MiningManager
+	NewMiningManager(consensus consensusreference.ConsensusReference, params *dagconfig.Params, mempoolConfig *mempoolpkg.Config,
+		mempoolEventsChan chan miningmanagermodel.MempoolEvent) MiningManager
 }
 
 type factory struct{}
 
 // NewMiningManager instantiate a new mining manager
 func (f *factory) NewMiningManager(consensusReference consensusreference.ConsensusReference, params *dagconfig.Params,
-	mempoolConfig *mempoolpkg.Config) MiningManager {
+	mempoolConfig *mempoolpkg.Config, mempoolEventsChan chan miningmanagermodel.MempoolEvent) MiningManager {
 
-	mempool := mempoolpkg.New(mempoolConfig, consensusReference)
-	blockTemplateBuilder := blocktemplatebuilder.New(consensusReference, mempool, params.MaxBlockMass, params.CoinbasePayloadScriptPublicKeyMaxLength)
+	mempool := mempoolpkg.New(mempoolConfig, consensusReference, mempoolEventsChan)
+	blockTemplateBuilder := blocktemplatebuilder.New(consensusReference, mempool, params.MaxBlockMass, params.MaxBlockVProgGas,
+		params.CoinbasePayloadScriptPublicKeyMaxLength)
+	feeEstimator := feeestimator.New(consensusReference, mempool, params.MaxBlockMass, params.TargetTimePerBlock)
 
 	return &miningManager{
 		consensusReference:   consensusReference,
 		mempool:              mempool,
 		blockTemplateBuilder: blockTemplateBuilder,
+		feeEstimator:         feeEstimator,
 		cachingTime:          time.Time{},
 		cacheLock:            &sync.Mutex{},
 	}
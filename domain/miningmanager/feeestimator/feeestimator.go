@@ -0,0 +1,227 @@
+package feeestimator
+
+import (
+	"sort"
+	"time"
+
+	consensusexternalapi "github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/utils/transactionhelper"
+	"github.com/zuanet/zuad/domain/consensusreference"
+	miningmanagerapi "github.com/zuanet/zuad/domain/miningmanager/model"
+)
+
+const (
+	// recentChainBlockCount is the number of selected chain blocks whose
+	// acceptance data is used to estimate the fee rates of recent blocks
+	recentChainBlockCount = 10
+
+	// fullBlockMassRatio is the ratio of the max block mass above which a
+	// block is considered full, meaning that its transactions had to compete
+	// over their inclusion
+	fullBlockMassRatio = 0.9
+
+	// normalInclusionTime and lowInclusionTime are the inclusion times targeted
+	// by the normal and low buckets. The priority bucket targets the next block.
+	normalInclusionTime = time.Minute
+	lowInclusionTime    = 10 * time.Minute
+)
+
+// feeEstimator estimates fee rates from two sources:
+//  1. The fee rate distribution of the transaction pool. Assuming that blocks are filled
+//     with the transactions that pay the highest fee rates, a transaction waits for the
+//     mass of all the transactions that pay more than it to be included first.
+//  2. The minimum fee rates of the full blocks accepted by the recent selected chain blocks.
+//     A full block means that there was more demand than space, so its minimum fee rate is
+//     the price its transactions had to pay. This covers the case where the transaction pool
+//     is not representative, e.g. right after the node started.
+type feeEstimator struct {
+	consensusReference consensusreference.ConsensusReference
+	mempool            miningmanagerapi.Mempool
+	blockMaxMass       uint64
+	targetTimePerBlock time.Duration
+}
+
+// New creates a new feeEstimator
+func New(consensusReference consensusreference.ConsensusReference, mempool miningmanagerapi.Mempool,
+	blockMaxMass uint64, targetTimePerBlock time.Duration) miningmanagerapi.FeeEstimator {
+
+	return &feeEstimator{
+		consensusReference: consensusReference,
+		mempool:            mempool,
+		blockMaxMass:       blockMaxMass,
+		targetTimePerBlock: targetTimePerBlock,
+	}
+}
+
+// EstimateFees returns the fee rates for the priority, normal and low buckets:
+//   - The priority fee rate is the lowest that's expected to be included in the next block,
+//     and no lower than the highest minimum fee rate of the recent full blocks.
+//   - The normal fee rate is the lowest that's expected to be included within a minute,
+//     and no lower than the median minimum fee rate of the recent full blocks.
+//   - The low fee rate is the lowest that's expected to be included within ten minutes.
+//
+// None of them is lower than the mempool's current minimum relay fee rate.
+func (fe *feeEstimator) EstimateFees() (*miningmanagerapi.FeeEstimate, error) {
+	distribution := fe.mempool.FeeRateDistribution()
+	// MinimumRelayTransactionFee is in sompi/kg while fee rates are in sompi/gram
+	minimumFeeRate := float64(fe.mempool.MinimumRelayTransactionFee()) / 1000
+	recentFeeRates, err := fe.recentFullBlocksMinimumFeeRates()
+	if err != nil {
+		return nil, err
+	}
+
+	priorityFeeRate := fe.mempoolFeeRateForBlockCount(distribution, 1, minimumFeeRate)
+	normalFeeRate := fe.mempoolFeeRateForBlockCount(distribution, fe.blockCountForInclusionTime(normalInclusionTime),
+		minimumFeeRate)
+	lowFeeRate := fe.mempoolFeeRateForBlockCount(distribution, fe.blockCountForInclusionTime(lowInclusionTime),
+		minimumFeeRate)
+	if len(recentFeeRates) > 0 {
+		priorityFeeRate = maxFeeRate(priorityFeeRate, recentFeeRates[len(recentFeeRates)-1])
+		normalFeeRate = maxFeeRate(normalFeeRate, recentFeeRates[len(recentFeeRates)/2])
+	}
+
+	return &miningmanagerapi.FeeEstimate{
+		PriorityBucket: fe.bucket(distribution, priorityFeeRate),
+		NormalBucket:   fe.bucket(distribution, normalFeeRate),
+		LowBucket:      fe.bucket(distribution, lowFeeRate),
+	}, nil
+}
+
+func (fe *feeEstimator) blockCountForInclusionTime(inclusionTime time.Duration) uint64 {
+	blockCount := uint64(inclusionTime / fe.targetTimePerBlock)
+	if blockCount == 0 {
+		return 1
+	}
+	return blockCount
+}
+
+// mempoolFeeRateForBlockCount returns the fee rate that a transaction has to pay in order to
+// be included within the given amount of blocks, according to the given fee rate distribution.
+// The returned fee rate is never lower than the given minimum fee rate.
+func (fe *feeEstimator) mempoolFeeRateForBlockCount(distribution []*miningmanagerapi.FeeRateAndMass,
+	blockCount uint64, minimumFeeRate float64) float64 {
+
+	availableMass := blockCount * fe.blockMaxMass
+	accumulatedMass := uint64(0)
+	for _, feeRateAndMass := range distribution {
+		accumulatedMass += feeRateAndMass.Mass
+		if accumulatedMass > availableMass {
+			return maxFeeRate(minimumFeeRate, feeRateAndMass.FeeRate)
+		}
+	}
+	return minimumFeeRate
+}
+
+// bucket estimates the time it takes a transaction that pays the given fee rate to be included:
+// one block, plus the time it takes to include the mass of all the transactions that pay more.
+func (fe *feeEstimator) bucket(distribution []*miningmanagerapi.FeeRateAndMass,
+	feeRate float64) *miningmanagerapi.FeeRateBucket {
+
+	massAhead := uint64(0)
+	for _, feeRateAndMass := range distribution {
+		if feeRateAndMass.FeeRate <= feeRate {
+			break
+		}
+		massAhead += feeRateAndMass.Mass
+	}
+	blockCount := massAhead/fe.blockMaxMass + 1
+
+	return &miningmanagerapi.FeeRateBucket{
+		FeeRate:          feeRate,
+		EstimatedSeconds: float64(blockCount) * fe.targetTimePerBlock.Seconds(),
+	}
+}
+
+// recentFullBlocksMinimumFeeRates returns the minimum fee rate of every full block accepted
+// by the recent selected chain blocks, in ascending order
+func (fe *feeEstimator) recentFullBlocksMinimumFeeRates() ([]float64, error) {
+	consensus := fe.consensusReference.Consensus()
+
+	chainBlockHashes, err := fe.recentChainBlockHashes()
+	if err != nil {
+		return nil, err
+	}
+	acceptanceData, err := consensus.GetBlocksAcceptanceData(chainBlockHashes)
+	if err != nil {
+		return nil, err
+	}
+
+	var minimumFeeRates []float64
+	for _, chainBlockAcceptanceData := range acceptanceData {
+		for _, blockAcceptanceData := range chainBlockAcceptanceData {
+			blockMass := uint64(0)
+			minimumFeeRate := float64(0)
+			for _, transactionAcceptanceData := range blockAcceptanceData.TransactionAcceptanceData {
+				transaction := transactionAcceptanceData.Transaction
+				if transactionhelper.IsCoinBase(transaction) {
+					continue
+				}
+				mass := fe.transactionMass(consensus, transaction)
+				blockMass += mass
+				if !transactionAcceptanceData.IsAccepted || mass == 0 {
+					continue
+				}
+				feeRate := float64(transactionAcceptanceData.Fee) / float64(mass)
+				if minimumFeeRate == 0 || feeRate < minimumFeeRate {
+					minimumFeeRate = feeRate
+				}
+			}
+			if float64(blockMass) >= fullBlockMassRatio*float64(fe.blockMaxMass) && minimumFeeRate > 0 {
+				minimumFeeRates = append(minimumFeeRates, minimumFeeRate)
+			}
+		}
+	}
+
+	sort.Float64s(minimumFeeRates)
+	return minimumFeeRates, nil
+}
+
+func (fe *feeEstimator) transactionMass(consensus consensusexternalapi.Consensus,
+	transaction *consensusexternalapi.DomainTransaction) uint64 {
+
+	if transaction.Mass != 0 {
+		return transaction.Mass
+	}
+	// Acceptance data may be shared with other readers, so the mass is
+	// populated on a copy rather than on the transaction itself
+	transactionCopy := *transaction
+	consensus.PopulateMass(&transactionCopy)
+	return transactionCopy.Mass
+}
+
+// recentChainBlockHashes returns up to recentChainBlockCount of the most recent
+// selected chain blocks, stopping at the pruning point
+func (fe *feeEstimator) recentChainBlockHashes() ([]*consensusexternalapi.DomainHash, error) {
+	consensus := fe.consensusReference.Consensus()
+
+	pruningPoint, err := consensus.PruningPoint()
+	if err != nil {
+		return nil, err
+	}
+	current, err := consensus.GetVirtualSelectedParent()
+	if err != nil {
+		return nil, err
+	}
+
+	chainBlockHashes := make([]*consensusexternalapi.DomainHash, 0, recentChainBlockCount)
+	for len(chainBlockHashes) < recentChainBlockCount && !current.Equal(pruningPoint) {
+		chainBlockHashes = append(chainBlockHashes, current)
+
+		blockInfo, err := consensus.GetBlockInfo(current)
+		if err != nil {
+			return nil, err
+		}
+		if blockInfo.SelectedParent == nil {
+			break
+		}
+		current = blockInfo.SelectedParent
+	}
+	return chainBlockHashes, nil
+}
+
+func maxFeeRate(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
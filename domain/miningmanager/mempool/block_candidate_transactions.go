@@ -0,0 +1,95 @@
+package mempool
+
+import (
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/miningmanager/mempool/model"
+	miningmanagermodel "github.com/zuanet/zuad/domain/miningmanager/model"
+)
+
+// maximumPackageAncestors is the maximum number of mempool ancestors a transaction
+// may have for it to pay for them. Deeper transactions are too far away from being
+// mined for their fee to matter in the selection of the next block's transactions.
+const maximumPackageAncestors = 50
+
+// blockCandidateTransactions returns the transactions in the pool that have no parents
+// in the pool, along with the package with the highest fee rate that each of them
+// belongs to.
+func (tp *transactionsPool) blockCandidateTransactions() []*miningmanagermodel.BlockCandidateTransaction {
+	candidates := make(map[externalapi.DomainTransactionID]*miningmanagermodel.BlockCandidateTransaction)
+	for transactionID, mempoolTransaction := range tp.allTransactions {
+		if len(mempoolTransaction.ParentTransactionsInPool()) > 0 {
+			continue
+		}
+		transaction := mempoolTransaction.Transaction()
+		candidates[transactionID] = &miningmanagermodel.BlockCandidateTransaction{
+			Transaction: transaction.Clone(), //this pointer leaves the mempool, and gets its utxo set to nil, hence we clone.
+			PackageFee:  transaction.Fee,
+			PackageMass: transaction.Mass,
+		}
+	}
+
+	for _, mempoolTransaction := range tp.allTransactions {
+		if len(mempoolTransaction.ParentTransactionsInPool()) == 0 {
+			continue
+		}
+		ancestors, ok := tp.packageAncestors(mempoolTransaction)
+		if !ok {
+			continue
+		}
+
+		packageFee := mempoolTransaction.Transaction().Fee
+		packageMass := mempoolTransaction.Transaction().Mass
+		candidateIDs := make([]*externalapi.DomainTransactionID, 0, len(ancestors))
+		for ancestorID, ancestor := range ancestors {
+			packageFee += ancestor.Transaction().Fee
+			packageMass += ancestor.Transaction().Mass
+			if _, ok := candidates[ancestorID]; ok {
+				ancestorID := ancestorID
+				candidateIDs = append(candidateIDs, &ancestorID)
+			}
+		}
+
+		packageFeeRate := float64(packageFee) / float64(packageMass)
+		for i, candidateID := range candidateIDs {
+			candidate := candidates[*candidateID]
+			if packageFeeRate <= float64(candidate.PackageFee)/float64(candidate.PackageMass) {
+				continue
+			}
+			candidate.PackageFee = packageFee
+			candidate.PackageMass = packageMass
+			candidate.PackageTransactionIDs = make([]*externalapi.DomainTransactionID, 0, len(candidateIDs)-1)
+			candidate.PackageTransactionIDs = append(candidate.PackageTransactionIDs, candidateIDs[:i]...)
+			candidate.PackageTransactionIDs = append(candidate.PackageTransactionIDs, candidateIDs[i+1:]...)
+		}
+	}
+
+	result := make([]*miningmanagermodel.BlockCandidateTransaction, 0, len(candidates))
+	for _, candidate := range candidates {
+		result = append(result, candidate)
+	}
+	return result
+}
+
+// packageAncestors returns all the ancestors of the given transaction in the pool.
+// It returns false if the transaction has more than maximumPackageAncestors ancestors.
+func (tp *transactionsPool) packageAncestors(transaction *model.MempoolTransaction) (model.IDToTransactionMap, bool) {
+	ancestors := model.IDToTransactionMap{}
+	stack := []*model.MempoolTransaction{transaction}
+	for len(stack) > 0 {
+		var current *model.MempoolTransaction
+		last := len(stack) - 1
+		current, stack = stack[last], stack[:last]
+
+		for parentID, parent := range current.ParentTransactionsInPool() {
+			if _, ok := ancestors[parentID]; ok {
+				continue
+			}
+			if len(ancestors) == maximumPackageAncestors {
+				return nil, false
+			}
+			ancestors[parentID] = parent
+			stack = append(stack, parent)
+		}
+	}
+	return ancestors, true
+}
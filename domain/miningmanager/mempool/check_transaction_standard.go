@@ -2,6 +2,9 @@ package mempool
 
 import (
 	"fmt"
+	"math"
+
+	"github.com/zuanet/zuad/util"
 
 	"github.com/zuanet/zuad/util/txmass"
 
@@ -188,12 +191,13 @@ func (mp *mempool) checkTransactionStandardInContext(transaction *externalapi.Do
 // transaction with the passed mass to be accepted into the mampool and relayed.
 func (mp *mempool) minimumRequiredTransactionRelayFee(mass uint64) uint64 {
 	// Calculate the minimum fee for a transaction to be allowed into the
-	// mempool and relayed by scaling the base fee. MinimumRelayTransactionFee is in
+	// mempool and relayed by scaling the base fee. minimumRelayTransactionFee is in
 	// sompi/kg so multiply by mass (which is in grams) and divide by 1000 to get minimum sompis.
-	minimumFee := (mass * uint64(mp.config.MinimumRelayTransactionFee)) / 1000
+	minimumRelayTransactionFee := mp.minimumRelayTransactionFee()
+	minimumFee := (mass * uint64(minimumRelayTransactionFee)) / 1000
 
-	if minimumFee == 0 && mp.config.MinimumRelayTransactionFee > 0 {
-		minimumFee = uint64(mp.config.MinimumRelayTransactionFee)
+	if minimumFee == 0 && minimumRelayTransactionFee > 0 {
+		minimumFee = uint64(minimumRelayTransactionFee)
 	}
 
 	// Set the minimum fee to the maximum possible value if the calculated
@@ -204,3 +208,15 @@ func (mp *mempool) minimumRequiredTransactionRelayFee(mass uint64) uint64 {
 
 	return minimumFee
 }
+
+// minimumRelayTransactionFee returns the current minimum relay fee, in sompi/kg. It is the
+// configured MinimumRelayTransactionFee, unless the mempool has recently been full, in which
+// case transactions have to pay more than the ones it evicted.
+func (mp *mempool) minimumRelayTransactionFee() util.Amount {
+	// evictionMinimumFeeRate is in sompi/gram while the relay fee is in sompi/kg
+	evictionMinimumFee := util.Amount(math.Ceil(mp.transactionsPool.evictionMinimumFeeRate() * 1000))
+	if evictionMinimumFee > mp.config.MinimumRelayTransactionFee {
+		return evictionMinimumFee
+	}
+	return mp.config.MinimumRelayTransactionFee
+}
@@ -13,6 +13,10 @@ import (
 const (
 	defaultMaximumTransactionCount = 1_000_000
 
+	// defaultMaximumMempoolMassInBlocks is the default limit of the total mass of the transactions
+	// in the mempool, in units of the maximum block mass
+	defaultMaximumMempoolMassInBlocks = 1000
+
 	defaultTransactionExpireIntervalSeconds     uint64 = 60
 	defaultTransactionExpireScanIntervalSeconds uint64 = 10
 	defaultOrphanExpireIntervalSeconds          uint64 = 60
@@ -23,6 +27,10 @@ const (
 	// removeOrphans when removeRedeemers = true
 	defaultMaximumOrphanTransactionCount = 50
 
+	// defaultMaximumTransactionPackageSize is the default limit of the number of transactions
+	// that are validated and inserted together as a package
+	defaultMaximumTransactionPackageSize = 25
+
 	// defaultMinimumRelayTransactionFee specifies the minimum transaction fee for a transaction to be accepted to
 	// the mempool and relayed. It is specified in sompi per 1kg (or 1000 grams) of transaction mass.
 	defaultMinimumRelayTransactionFee = util.Amount(1000)
@@ -38,6 +46,7 @@ const (
 // Config represents a mempool configuration
 type Config struct {
 	MaximumTransactionCount               uint64
+	MaximumMempoolMass                    uint64
 	TransactionExpireIntervalDAAScore     uint64
 	TransactionExpireScanIntervalDAAScore uint64
 	TransactionExpireScanIntervalSeconds  uint64
@@ -45,6 +54,8 @@ type Config struct {
 	OrphanExpireScanIntervalDAAScore      uint64
 	MaximumOrphanTransactionMass          uint64
 	MaximumOrphanTransactionCount         uint64
+	MaximumTransactionPackageSize         uint64
+	EnableReplaceByFee                    bool
 	AcceptNonStandard                     bool
 	MaximumMassPerBlock                   uint64
 	MinimumRelayTransactionFee            util.Amount
@@ -58,6 +69,7 @@ func DefaultConfig(dagParams *dagconfig.Params) *Config {
 
 	return &Config{
 		MaximumTransactionCount:               defaultMaximumTransactionCount,
+		MaximumMempoolMass:                    dagParams.MaxBlockMass * defaultMaximumMempoolMassInBlocks,
 		TransactionExpireIntervalDAAScore:     uint64(float64(defaultTransactionExpireIntervalSeconds) / targetBlocksPerSecond),
 		TransactionExpireScanIntervalDAAScore: uint64(float64(defaultTransactionExpireScanIntervalSeconds) / targetBlocksPerSecond),
 		TransactionExpireScanIntervalSeconds:  defaultTransactionExpireScanIntervalSeconds,
@@ -65,6 +77,8 @@ func DefaultConfig(dagParams *dagconfig.Params) *Config {
 		OrphanExpireScanIntervalDAAScore:      uint64(float64(defaultOrphanExpireScanIntervalSeconds) / targetBlocksPerSecond),
 		MaximumOrphanTransactionMass:          defaultMaximumOrphanTransactionMass,
 		MaximumOrphanTransactionCount:         defaultMaximumOrphanTransactionCount,
+		MaximumTransactionPackageSize:         defaultMaximumTransactionPackageSize,
+		EnableReplaceByFee:                    true,
 		AcceptNonStandard:                     dagParams.RelayNonStdTxs,
 		MaximumMassPerBlock:                   dagParams.MaxBlockMass,
 		MinimumRelayTransactionFee:            defaultMinimumRelayTransactionFee,
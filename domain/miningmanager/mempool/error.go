@@ -105,10 +105,10 @@ func newRuleError(err error) RuleError {
 	}
 }
 
-// extractRejectCode attempts to return a relevant reject code for a given error
+// ExtractRejectCode attempts to return a relevant reject code for a given error
 // by examining the error for known types. It will return true if a code
 // was successfully extracted.
-func extractRejectCode(err error) (RejectCode, bool) {
+func ExtractRejectCode(err error) (RejectCode, bool) {
 	// Pull the underlying error out of a RuleError.
 	var ruleErr RuleError
 	if ok := errors.As(err, &ruleErr); ok {
@@ -4,6 +4,7 @@ import (
 	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
 	"github.com/zuanet/zuad/domain/consensus/utils/consensushashing"
 	"github.com/zuanet/zuad/domain/consensus/utils/transactionhelper"
+	miningmanagermodel "github.com/zuanet/zuad/domain/miningmanager/model"
 )
 
 func (mp *mempool) handleNewBlockTransactions(blockTransactions []*externalapi.DomainTransaction) (
@@ -15,7 +16,7 @@ func (mp *mempool) handleNewBlockTransactions(blockTransactions []*externalapi.D
 	acceptedOrphans := []*externalapi.DomainTransaction{}
 	for _, transaction := range blockTransactions {
 		transactionID := consensushashing.TransactionID(transaction)
-		err := mp.removeTransaction(transactionID, false)
+		err := mp.removeTransaction(transactionID, false, miningmanagermodel.MempoolRemovalReasonMined)
 		if err != nil {
 			return nil, err
 		}
@@ -52,7 +53,8 @@ func (mp *mempool) handleNewBlockTransactions(blockTransactions []*externalapi.D
 func (mp *mempool) removeDoubleSpends(transaction *externalapi.DomainTransaction) error {
 	for _, input := range transaction.Inputs {
 		if redeemer, ok := mp.mempoolUTXOSet.transactionByPreviousOutpoint[input.PreviousOutpoint]; ok {
-			err := mp.removeTransaction(redeemer.TransactionID(), true)
+			err := mp.removeTransaction(redeemer.TransactionID(), true,
+				miningmanagermodel.MempoolRemovalReasonDoubleSpent)
 			if err != nil {
 				return err
 			}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
 	miningmanagermodel "github.com/zuanet/zuad/domain/miningmanager/model"
+	"github.com/zuanet/zuad/util"
 )
 
 type mempool struct {
@@ -18,13 +19,20 @@ type mempool struct {
 	mempoolUTXOSet   *mempoolUTXOSet
 	transactionsPool *transactionsPool
 	orphansPool      *orphansPool
+
+	eventsChan        chan miningmanagermodel.MempoolEvent
+	hasEventListeners func() bool
 }
 
-// New constructs a new mempool
-func New(config *Config, consensusReference consensusreference.ConsensusReference) miningmanagermodel.Mempool {
+// New constructs a new mempool. If eventsChan is not nil, the mempool
+// sends an event to it whenever its transactions change.
+func New(config *Config, consensusReference consensusreference.ConsensusReference,
+	eventsChan chan miningmanagermodel.MempoolEvent) miningmanagermodel.Mempool {
+
 	mp := &mempool{
 		config:             config,
 		consensusReference: consensusReference,
+		eventsChan:         eventsChan,
 	}
 
 	mp.mempoolUTXOSet = newMempoolUTXOSet(mp)
@@ -43,6 +51,38 @@ func (mp *mempool) ValidateAndInsertTransaction(transaction *externalapi.DomainT
 	return mp.validateAndInsertTransaction(transaction, isHighPriority, allowOrphan)
 }
 
+func (mp *mempool) ValidateAndReplaceTransaction(transaction *externalapi.DomainTransaction, isHighPriority bool) (
+	acceptedTransactions []*externalapi.DomainTransaction, replacedTransactions []*externalapi.DomainTransaction, err error) {
+
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	return mp.validateAndReplaceTransaction(transaction, isHighPriority)
+}
+
+func (mp *mempool) ValidateAndInsertOrReplaceTransaction(transaction *externalapi.DomainTransaction,
+	isHighPriority bool, allowOrphan bool) (acceptedTransactions []*externalapi.DomainTransaction,
+	replacedTransactions []*externalapi.DomainTransaction, err error) {
+
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	if mp.config.EnableReplaceByFee && len(mp.mempoolUTXOSet.conflictingTransactions(transaction)) > 0 {
+		return mp.validateAndReplaceTransaction(transaction, isHighPriority)
+	}
+	acceptedTransactions, err = mp.validateAndInsertTransaction(transaction, isHighPriority, allowOrphan)
+	return acceptedTransactions, nil, err
+}
+
+func (mp *mempool) ValidateAndInsertTransactionPackage(transactions []*externalapi.DomainTransaction, isHighPriority bool) (
+	acceptedTransactions []*externalapi.DomainTransaction, err error) {
+
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	return mp.validateAndInsertTransactionPackage(transactions, isHighPriority)
+}
+
 func (mp *mempool) GetTransaction(transactionID *externalapi.DomainTransactionID,
 	includeTransactionPool bool,
 	includeOrphanPool bool) (
@@ -112,6 +152,26 @@ func (mp *mempool) AllTransactions(includeTransactionPool bool, includeOrphanPoo
 	return transactionPoolTransactions, orphanPoolTransactions
 }
 
+// HighPriorityTransactionIDs returns the IDs of the high priority transactions in both
+// the transaction pool and the orphan pool
+func (mp *mempool) HighPriorityTransactionIDs() []*externalapi.DomainTransactionID {
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+
+	transactionIDs := make([]*externalapi.DomainTransactionID, 0, len(mp.transactionsPool.highPriorityTransactions))
+	for _, transaction := range mp.transactionsPool.highPriorityTransactions {
+		transactionID := *transaction.TransactionID()
+		transactionIDs = append(transactionIDs, &transactionID)
+	}
+	for _, orphan := range mp.orphansPool.allOrphans {
+		if orphan.IsHighPriority() {
+			transactionID := *orphan.TransactionID()
+			transactionIDs = append(transactionIDs, &transactionID)
+		}
+	}
+	return transactionIDs
+}
+
 func (mp *mempool) TransactionCount(includeTransactionPool bool, includeOrphanPool bool) int {
 	mp.mtx.RLock()
 	defer mp.mtx.RUnlock()
@@ -128,6 +188,13 @@ func (mp *mempool) TransactionCount(includeTransactionPool bool, includeOrphanPo
 	return transactionCount
 }
 
+func (mp *mempool) FeeRateDistribution() []*miningmanagermodel.FeeRateAndMass {
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+
+	return mp.transactionsPool.feeRateDistribution()
+}
+
 func (mp *mempool) HandleNewBlockTransactions(transactions []*externalapi.DomainTransaction) (
 	acceptedOrphans []*externalapi.DomainTransaction, err error) {
 
@@ -137,11 +204,11 @@ func (mp *mempool) HandleNewBlockTransactions(transactions []*externalapi.Domain
 	return mp.handleNewBlockTransactions(transactions)
 }
 
-func (mp *mempool) BlockCandidateTransactions() []*externalapi.DomainTransaction {
+func (mp *mempool) BlockCandidateTransactions() []*miningmanagermodel.BlockCandidateTransaction {
 	mp.mtx.RLock()
 	defer mp.mtx.RUnlock()
 
-	return mp.transactionsPool.allReadyTransactions()
+	return mp.transactionsPool.blockCandidateTransactions()
 }
 
 func (mp *mempool) RevalidateHighPriorityTransactions() (validTransactions []*externalapi.DomainTransaction, err error) {
@@ -151,16 +218,62 @@ func (mp *mempool) RevalidateHighPriorityTransactions() (validTransactions []*ex
 	return mp.revalidateHighPriorityTransactions()
 }
 
-func (mp *mempool) RemoveTransactions(transactions []*externalapi.DomainTransaction, removeRedeemers bool) error {
+func (mp *mempool) RemoveTransactions(transactions []*externalapi.DomainTransaction, removeRedeemers bool,
+	reason miningmanagermodel.MempoolRemovalReason) error {
+
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	return mp.removeTransactions(transactions, removeRedeemers, reason)
+}
+
+func (mp *mempool) RemoveTransaction(transactionID *externalapi.DomainTransactionID, removeRedeemers bool,
+	reason miningmanagermodel.MempoolRemovalReason) error {
+
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	return mp.removeTransaction(transactionID, removeRedeemers, reason)
+}
+
+func (mp *mempool) MinimumRelayTransactionFee() util.Amount {
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+
+	return mp.minimumRelayTransactionFee()
+}
+
+func (mp *mempool) MaximumTransactionPackageSize() uint64 {
+	return mp.config.MaximumTransactionPackageSize
+}
+
+func (mp *mempool) ValidateTransaction(transaction *externalapi.DomainTransaction) (
+	*miningmanagermodel.TransactionValidationResult, error) {
+
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+
+	return mp.validateTransactionDryRun(transaction)
+}
+
+// SetHasEventListeners sets the function the mempool uses to check whether
+// anyone listens to its events. Events are not sent while it returns false.
+func (mp *mempool) SetHasEventListeners(hasEventListeners func() bool) {
 	mp.mtx.Lock()
 	defer mp.mtx.Unlock()
 
-	return mp.removeTransactions(transactions, removeRedeemers)
+	mp.hasEventListeners = hasEventListeners
 }
 
-func (mp *mempool) RemoveTransaction(transactionID *externalapi.DomainTransactionID, removeRedeemers bool) error {
+// CloseEventsChannel closes the events channel of the mempool, if there is one.
+// No events are sent once it's closed.
+func (mp *mempool) CloseEventsChannel() {
 	mp.mtx.Lock()
 	defer mp.mtx.Unlock()
 
-	return mp.removeTransaction(transactionID, removeRedeemers)
+	if mp.eventsChan == nil {
+		return
+	}
+	close(mp.eventsChan)
+	mp.eventsChan = nil
 }
@@ -0,0 +1,51 @@
+package mempool
+
+import (
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	miningmanagermodel "github.com/zuanet/zuad/domain/miningmanager/model"
+)
+
+// shouldSendEvents returns whether there's an events channel that isn't closed,
+// and whether anyone listens to it
+func (mp *mempool) shouldSendEvents() bool {
+	if mp.eventsChan == nil {
+		return false
+	}
+	return mp.hasEventListeners == nil || mp.hasEventListeners()
+}
+
+// sendEvent sends the given event to the mempool events channel, if there is one.
+// The mempool never waits for its listeners, so the event is dropped if the channel is full.
+func (mp *mempool) sendEvent(event miningmanagermodel.MempoolEvent) {
+	if !mp.shouldSendEvents() {
+		return
+	}
+	select {
+	case mp.eventsChan <- event:
+	default:
+		log.Warnf("The mempool events channel is full. Dropping a %T event", event)
+	}
+}
+
+func (mp *mempool) sendTransactionAddedEvent(transaction *externalapi.DomainTransaction) {
+	// Cloning the transaction is relatively heavy, so it's skipped if the event isn't sent
+	if !mp.shouldSendEvents() {
+		return
+	}
+	mp.sendEvent(&miningmanagermodel.TransactionAddedToMempool{
+		Transaction: transaction.Clone(), //this pointer leaves the mempool, hence we clone.
+	})
+}
+
+func (mp *mempool) sendTransactionRemovedEvent(transactionID *externalapi.DomainTransactionID,
+	reason miningmanagermodel.MempoolRemovalReason) {
+
+	if !mp.shouldSendEvents() {
+		return
+	}
+	transactionIDCopy := *transactionID
+	mp.sendEvent(&miningmanagermodel.TransactionRemovedFromMempool{
+		TransactionID: &transactionIDCopy,
+		Reason:        reason,
+	})
+}
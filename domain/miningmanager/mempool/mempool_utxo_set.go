@@ -80,3 +80,23 @@ func (mpus *mempoolUTXOSet) checkDoubleSpends(transaction *externalapi.DomainTra
 
 	return nil
 }
+
+// conflictingTransactions returns the transactions in the mempool that spend
+// any of the outpoints spent by the given transaction
+func (mpus *mempoolUTXOSet) conflictingTransactions(transaction *externalapi.DomainTransaction) []*model.MempoolTransaction {
+	conflictingTransactions := []*model.MempoolTransaction{}
+	visited := make(map[externalapi.DomainTransactionID]struct{})
+	for _, input := range transaction.Inputs {
+		existingTransaction, exists := mpus.transactionByPreviousOutpoint[input.PreviousOutpoint]
+		if !exists {
+			continue
+		}
+		if _, ok := visited[*existingTransaction.TransactionID()]; ok {
+			continue
+		}
+		visited[*existingTransaction.TransactionID()] = struct{}{}
+		conflictingTransactions = append(conflictingTransactions, existingTransaction)
+	}
+
+	return conflictingTransactions
+}
@@ -16,6 +16,11 @@ func (tobf *TransactionsOrderedByFeeRate) GetByIndex(index int) *MempoolTransact
 	return tobf.slice[index]
 }
 
+// Len returns the number of transactions in the set
+func (tobf *TransactionsOrderedByFeeRate) Len() int {
+	return len(tobf.slice)
+}
+
 // Push inserts a transaction into the set, placing it in the correct place to preserve order
 func (tobf *TransactionsOrderedByFeeRate) Push(transaction *MempoolTransaction) error {
 	index, _, err := tobf.findTransactionIndex(transaction)
@@ -63,7 +68,9 @@ func (tobf *TransactionsOrderedByFeeRate) RemoveAtIndex(index int) error {
 // If the transaction was not found, will return wasFound=false and index=the index at which transaction can be inserted
 // while preserving the order.
 func (tobf *TransactionsOrderedByFeeRate) findTransactionIndex(transaction *MempoolTransaction) (index int, wasFound bool, err error) {
-	if transaction.Transaction().Fee == 0 || transaction.Transaction().Mass == 0 {
+	// The fee may legitimately be zero for a transaction whose child pays for it as part of a package,
+	// so only the mass tells whether the transaction was populated
+	if transaction.Transaction().Mass == 0 {
 		return 0, false, errors.Errorf("findTransactionIndex expects a transaction with " +
 			"populated fee and mass")
 	}
@@ -216,7 +216,7 @@ func (op *orphansPool) unorphanTransaction(transaction *model.OrphanTransaction)
 	mempoolTransaction := model.NewMempoolTransaction(
 		transaction.Transaction(),
 		op.mempool.transactionsPool.getParentTransactionsInPool(transaction.Transaction()),
-		false,
+		transaction.IsHighPriority(),
 		virtualDAAScore,
 	)
 	err = op.mempool.transactionsPool.addMempoolTransaction(mempoolTransaction)
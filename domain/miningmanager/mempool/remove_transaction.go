@@ -4,11 +4,14 @@ import (
 	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
 	"github.com/zuanet/zuad/domain/consensus/utils/consensushashing"
 	"github.com/zuanet/zuad/domain/miningmanager/mempool/model"
+	miningmanagermodel "github.com/zuanet/zuad/domain/miningmanager/model"
 )
 
-func (mp *mempool) removeTransactions(transactions []*externalapi.DomainTransaction, removeRedeemers bool) error {
+func (mp *mempool) removeTransactions(transactions []*externalapi.DomainTransaction, removeRedeemers bool,
+	reason miningmanagermodel.MempoolRemovalReason) error {
+
 	for _, transaction := range transactions {
-		err := mp.removeTransaction(consensushashing.TransactionID(transaction), removeRedeemers)
+		err := mp.removeTransaction(consensushashing.TransactionID(transaction), removeRedeemers, reason)
 		if err != nil {
 			return err
 		}
@@ -16,7 +19,12 @@ func (mp *mempool) removeTransactions(transactions []*externalapi.DomainTransact
 	return nil
 }
 
-func (mp *mempool) removeTransaction(transactionID *externalapi.DomainTransactionID, removeRedeemers bool) error {
+// removeTransaction removes the given transaction from the mempool, along with its redeemers if
+// removeRedeemers is set. The reason is reported to the mempool's listeners for every transaction
+// that's removed from the transaction pool.
+func (mp *mempool) removeTransaction(transactionID *externalapi.DomainTransactionID, removeRedeemers bool,
+	reason miningmanagermodel.MempoolRemovalReason) error {
+
 	if _, ok := mp.orphansPool.allOrphans[*transactionID]; ok {
 		return mp.orphansPool.removeOrphan(transactionID, true)
 	}
@@ -37,10 +45,15 @@ func (mp *mempool) removeTransaction(transactionID *externalapi.DomainTransactio
 	}
 
 	for _, transactionToRemove := range transactionsToRemove {
+		// A redeemer that descends from the transaction through several paths appears more than once
+		if _, ok := mp.transactionsPool.allTransactions[*transactionToRemove.TransactionID()]; !ok {
+			continue
+		}
 		err := mp.removeTransactionFromSets(transactionToRemove, removeRedeemers)
 		if err != nil {
 			return err
 		}
+		mp.sendTransactionRemovedEvent(transactionToRemove.TransactionID(), reason)
 	}
 
 	if removeRedeemers {
@@ -0,0 +1,157 @@
+package mempool
+
+import (
+	"fmt"
+
+	"github.com/zuanet/zuad/infrastructure/logger"
+
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/utils/consensushashing"
+	"github.com/zuanet/zuad/domain/miningmanager/mempool/model"
+	miningmanagermodel "github.com/zuanet/zuad/domain/miningmanager/model"
+)
+
+// validateAndReplaceTransaction validates the given transaction and inserts it into the
+// mempool in place of the mempool transactions it double spends.
+// The replaced transactions are evicted along with their descendants and any orphans that
+// depend on them. A replacement is only accepted if its fee rate is strictly higher than
+// that of every transaction it directly conflicts with, and if its fee is strictly higher
+// than the total fee of all the transactions it evicts.
+func (mp *mempool) validateAndReplaceTransaction(transaction *externalapi.DomainTransaction, isHighPriority bool) (
+	acceptedTransactions []*externalapi.DomainTransaction, replacedTransactions []*externalapi.DomainTransaction, err error) {
+
+	transactionID := consensushashing.TransactionID(transaction)
+	onEnd := logger.LogAndMeasureExecutionTime(log, fmt.Sprintf("validateAndReplaceTransaction %s", transactionID))
+	defer onEnd()
+
+	if !mp.config.EnableReplaceByFee {
+		return nil, nil, transactionRuleError(RejectNonstandard, "replace-by-fee is disabled")
+	}
+
+	// Populate mass in the beginning, it will be used in multiple places throughout the validation and insertion.
+	mp.consensusReference.Consensus().PopulateMass(transaction)
+
+	err = mp.validateTransactionInIsolation(transaction)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conflictingTransactions := mp.mempoolUTXOSet.conflictingTransactions(transaction)
+	if len(conflictingTransactions) == 0 {
+		return nil, nil, transactionRuleError(RejectInvalid, fmt.Sprintf(
+			"transaction %s doesn't double spend any transaction in the mempool", transactionID))
+	}
+	evictedTransactions := mp.transactionsToEvict(conflictingTransactions)
+
+	parentsInPool, missingOutpoints, err := mp.fillInputsAndGetMissingParents(transaction)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(missingOutpoints) > 0 {
+		return nil, nil, transactionRuleError(RejectBadOrphan, fmt.Sprintf(
+			"replacement transaction %s is an orphan", transactionID))
+	}
+	for parentID := range parentsInPool {
+		if _, ok := evictedTransactions[parentID]; ok {
+			return nil, nil, transactionRuleError(RejectInvalid, fmt.Sprintf(
+				"replacement transaction %s spends an output of transaction %s, which it replaces",
+				transactionID, parentID))
+		}
+	}
+
+	err = mp.validateTransactionInContext(transaction)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = checkReplacementFees(transaction, conflictingTransactions, evictedTransactions)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The replaced transactions can't be restored once they're removed, so a replacement that the
+	// full pool would evict right away must be rejected while they're still in place
+	if mp.transactionsPool.wouldBeEvicted([]*externalapi.DomainTransaction{transaction}, parentsInPool, evictedTransactions, isHighPriority) {
+		return nil, nil, transactionRuleError(RejectInsufficientFee, fmt.Sprintf(
+			"replacement transaction %s would be evicted because the mempool is full and its fee rate is too low",
+			transactionID))
+	}
+
+	replacedTransactions = make([]*externalapi.DomainTransaction, 0, len(evictedTransactions))
+	for _, evictedTransaction := range evictedTransactions {
+		replacedTransactions = append(replacedTransactions, evictedTransaction.Transaction().Clone()) //these pointer leave the mempool, hence we clone.
+	}
+	for _, conflictingTransaction := range conflictingTransactions {
+		log.Debugf("Replacing transaction %s with transaction %s", conflictingTransaction.TransactionID(), transactionID)
+		err = mp.removeTransaction(conflictingTransaction.TransactionID(), true,
+			miningmanagermodel.MempoolRemovalReasonDoubleSpent)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	mempoolTransaction, err := mp.transactionsPool.addTransaction(transaction, parentsInPool, isHighPriority)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	acceptedOrphans, err := mp.orphansPool.processOrphansAfterAcceptedTransaction(mempoolTransaction.Transaction())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	acceptedTransactions = append([]*externalapi.DomainTransaction{transaction.Clone()}, acceptedOrphans...) //these pointer leave the mempool, hence we clone.
+
+	err = mp.transactionsPool.limitMempoolSize()
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, ok := mp.transactionsPool.allTransactions[*mempoolTransaction.TransactionID()]; !ok {
+		return nil, nil, transactionRuleError(RejectInsufficientFee, fmt.Sprintf(
+			"transaction %s was evicted because the mempool is full and its fee rate is too low",
+			mempoolTransaction.TransactionID()))
+	}
+
+	return mp.transactionsPool.transactionsStillInPool(acceptedTransactions), replacedTransactions, nil
+}
+
+// transactionsToEvict returns the given conflicting transactions along with all their
+// descendants in the mempool
+func (mp *mempool) transactionsToEvict(conflictingTransactions []*model.MempoolTransaction) model.IDToTransactionMap {
+	transactionsToEvict := model.IDToTransactionMap{}
+	for _, conflictingTransaction := range conflictingTransactions {
+		transactionsToEvict[*conflictingTransaction.TransactionID()] = conflictingTransaction
+		for _, redeemer := range mp.transactionsPool.getRedeemers(conflictingTransaction) {
+			transactionsToEvict[*redeemer.TransactionID()] = redeemer
+		}
+	}
+	return transactionsToEvict
+}
+
+func checkReplacementFees(transaction *externalapi.DomainTransaction,
+	conflictingTransactions []*model.MempoolTransaction, evictedTransactions model.IDToTransactionMap) error {
+
+	transactionID := consensushashing.TransactionID(transaction)
+	feeRate := float64(transaction.Fee) / float64(transaction.Mass)
+	for _, conflictingTransaction := range conflictingTransactions {
+		conflictingFeeRate := float64(conflictingTransaction.Transaction().Fee) /
+			float64(conflictingTransaction.Transaction().Mass)
+		if feeRate <= conflictingFeeRate {
+			return transactionRuleError(RejectInsufficientFee, fmt.Sprintf(
+				"replacement transaction %s has a fee rate of %f, which is not higher than the fee rate "+
+					"of %f of the transaction %s it replaces",
+				transactionID, feeRate, conflictingFeeRate, conflictingTransaction.TransactionID()))
+		}
+	}
+
+	evictedFee := uint64(0)
+	for _, evictedTransaction := range evictedTransactions {
+		evictedFee += evictedTransaction.Transaction().Fee
+	}
+	if transaction.Fee <= evictedFee {
+		return transactionRuleError(RejectInsufficientFee, fmt.Sprintf(
+			"replacement transaction %s has a fee of %d, which is not higher than the total fee of %d "+
+				"of the %d transactions it evicts", transactionID, transaction.Fee, evictedFee, len(evictedTransactions)))
+	}
+
+	return nil
+}
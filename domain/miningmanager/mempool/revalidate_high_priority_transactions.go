@@ -3,6 +3,7 @@ package mempool
 import (
 	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
 	"github.com/zuanet/zuad/domain/miningmanager/mempool/model"
+	miningmanagermodel "github.com/zuanet/zuad/domain/miningmanager/model"
 	"github.com/zuanet/zuad/infrastructure/logger"
 )
 
@@ -35,7 +36,8 @@ func (mp *mempool) revalidateTransaction(transaction *model.MempoolTransaction)
 	}
 	if len(missingParents) > 0 {
 		log.Debugf("Removing transaction %s, it failed revalidation", transaction.TransactionID())
-		err := mp.removeTransaction(transaction.TransactionID(), true)
+		err := mp.removeTransaction(transaction.TransactionID(), true,
+			miningmanagermodel.MempoolRemovalReasonDoubleSpent)
 		if err != nil {
 			return false, err
 		}
@@ -1,6 +1,7 @@
 package mempool
 
 import (
+	"math"
 	"time"
 
 	"github.com/pkg/errors"
@@ -8,16 +9,28 @@ import (
 	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
 	"github.com/zuanet/zuad/domain/consensus/utils/consensushashing"
 	"github.com/zuanet/zuad/domain/miningmanager/mempool/model"
+	miningmanagermodel "github.com/zuanet/zuad/domain/miningmanager/model"
 )
 
+// evictionFeeRateHalfLife is the time it takes the minimum fee rate imposed by a full pool
+// to halve
+const evictionFeeRateHalfLife = 30 * time.Minute
+
 type transactionsPool struct {
 	mempool                       *mempool
 	allTransactions               model.IDToTransactionMap
 	highPriorityTransactions      model.IDToTransactionMap
 	chainedTransactionsByParentID model.IDToTransactionsSliceMap
 	transactionsOrderedByFeeRate  model.TransactionsOrderedByFeeRate
+	totalMass                     uint64
 	lastExpireScanDAAScore        uint64
 	lastExpireScanTime            time.Time
+
+	// evictionFeeRate is the fee rate, in sompi per gram, that a transaction had to beat
+	// in order to enter the pool the last time it was full. It decays over time since
+	// lastEvictionTime, see evictionMinimumFeeRate.
+	evictionFeeRate  float64
+	lastEvictionTime time.Time
 }
 
 func newTransactionsPool(mp *mempool) *transactionsPool {
@@ -27,8 +40,11 @@ func newTransactionsPool(mp *mempool) *transactionsPool {
 		highPriorityTransactions:      model.IDToTransactionMap{},
 		chainedTransactionsByParentID: model.IDToTransactionsSliceMap{},
 		transactionsOrderedByFeeRate:  model.TransactionsOrderedByFeeRate{},
+		totalMass:                     0,
 		lastExpireScanDAAScore:        0,
 		lastExpireScanTime:            time.Now(),
+		evictionFeeRate:               0,
+		lastEvictionTime:              time.Time{},
 	}
 }
 
@@ -53,6 +69,7 @@ func (tp *transactionsPool) addTransaction(transaction *externalapi.DomainTransa
 
 func (tp *transactionsPool) addMempoolTransaction(transaction *model.MempoolTransaction) error {
 	tp.allTransactions[*transaction.TransactionID()] = transaction
+	tp.totalMass += transaction.Transaction().Mass
 
 	for _, parentTransactionInPool := range transaction.ParentTransactionsInPool() {
 		parentTransactionID := *parentTransactionInPool.TransactionID()
@@ -74,10 +91,15 @@ func (tp *transactionsPool) addMempoolTransaction(transaction *model.MempoolTran
 		tp.highPriorityTransactions[*transaction.TransactionID()] = transaction
 	}
 
+	tp.mempool.sendTransactionAddedEvent(transaction.Transaction())
+
 	return nil
 }
 
 func (tp *transactionsPool) removeTransaction(transaction *model.MempoolTransaction) error {
+	if _, ok := tp.allTransactions[*transaction.TransactionID()]; ok {
+		tp.totalMass -= transaction.Transaction().Mass
+	}
 	delete(tp.allTransactions, *transaction.TransactionID())
 
 	err := tp.transactionsOrderedByFeeRate.Remove(transaction)
@@ -119,7 +141,8 @@ func (tp *transactionsPool) expireOldTransactions() error {
 		if daaScoreSinceAdded > tp.mempool.config.TransactionExpireIntervalDAAScore {
 			log.Debugf("Removing transaction %s, because it expired. DAAScore moved by %d, expire interval: %d",
 				mempoolTransaction.TransactionID(), daaScoreSinceAdded, tp.mempool.config.TransactionExpireIntervalDAAScore)
-			err = tp.mempool.removeTransaction(mempoolTransaction.TransactionID(), true)
+			err = tp.mempool.removeTransaction(mempoolTransaction.TransactionID(), true,
+				miningmanagermodel.MempoolRemovalReasonExpired)
 			if err != nil {
 				return err
 			}
@@ -131,18 +154,6 @@ func (tp *transactionsPool) expireOldTransactions() error {
 	return nil
 }
 
-func (tp *transactionsPool) allReadyTransactions() []*externalapi.DomainTransaction {
-	result := []*externalapi.DomainTransaction{}
-
-	for _, mempoolTransaction := range tp.allTransactions {
-		if len(mempoolTransaction.ParentTransactionsInPool()) == 0 {
-			result = append(result, mempoolTransaction.Transaction().Clone()) //this pointer leaves the mempool, and gets its utxo set to nil, hence we clone.
-		}
-	}
-
-	return result
-}
-
 func (tp *transactionsPool) getParentTransactionsInPool(
 	transaction *externalapi.DomainTransaction) model.IDToTransactionMap {
 
@@ -173,28 +184,45 @@ func (tp *transactionsPool) getRedeemers(transaction *model.MempoolTransaction)
 	return redeemers
 }
 
-func (tp *transactionsPool) limitTransactionCount() error {
+// limitMempoolSize evicts the transactions with the lowest fee rate, along with their
+// descendants, until the pool is within both its maximum transaction count and its
+// maximum total mass. High priority transactions are never evicted.
+func (tp *transactionsPool) limitMempoolSize() error {
+	return tp.limitMempoolSizeProtecting(nil)
+}
+
+// limitMempoolSizeProtecting works like limitMempoolSize, except that it never evicts the
+// given protected transactions, the same as it never evicts high priority transactions
+func (tp *transactionsPool) limitMempoolSizeProtecting(protectedTransactions model.IDToTransactionMap) error {
 	currentIndex := 0
 
-	for uint64(len(tp.allTransactions)) > tp.mempool.config.MaximumTransactionCount {
+	for tp.isOverLimit() {
 		var transactionToRemove *model.MempoolTransaction
 		for {
 			transactionToRemove = tp.transactionsOrderedByFeeRate.GetByIndex(currentIndex)
-			if !transactionToRemove.IsHighPriority() {
+			_, isProtected := protectedTransactions[*transactionToRemove.TransactionID()]
+			if !transactionToRemove.IsHighPriority() && !isProtected {
 				break
 			}
 			currentIndex++
 			if currentIndex >= len(tp.allTransactions) {
 				log.Warnf(
-					"Number of high-priority transactions in mempool (%d) is higher than maximum allowed (%d)",
-					len(tp.allTransactions), tp.mempool.config.MaximumTransactionCount)
+					"High-priority transactions in mempool (count: %d, mass: %d) exceed the maximum allowed "+
+						"(count: %d, mass: %d)", len(tp.allTransactions), tp.totalMass,
+					tp.mempool.config.MaximumTransactionCount, tp.mempool.config.MaximumMempoolMass)
 				return nil
 			}
 		}
 
-		log.Debugf("Removing transaction %s, because mempoolTransaction count (%d) exceeded the limit (%d)",
-			transactionToRemove.TransactionID(), len(tp.allTransactions), tp.mempool.config.MaximumTransactionCount)
-		err := tp.mempool.removeTransaction(transactionToRemove.TransactionID(), true)
+		if tp.totalMass > tp.mempool.config.MaximumMempoolMass {
+			tp.raiseEvictionFeeRate(transactionToRemove)
+		}
+
+		log.Debugf("Removing transaction %s, because the mempool (count: %d, mass: %d) exceeded its limits "+
+			"(count: %d, mass: %d)", transactionToRemove.TransactionID(), len(tp.allTransactions), tp.totalMass,
+			tp.mempool.config.MaximumTransactionCount, tp.mempool.config.MaximumMempoolMass)
+		err := tp.mempool.removeTransaction(transactionToRemove.TransactionID(), true,
+			miningmanagermodel.MempoolRemovalReasonEvicted)
 		if err != nil {
 			return err
 		}
@@ -205,6 +233,114 @@ func (tp *transactionsPool) limitTransactionCount() error {
 	return nil
 }
 
+func (tp *transactionsPool) isOverLimit() bool {
+	return uint64(len(tp.allTransactions)) > tp.mempool.config.MaximumTransactionCount ||
+		tp.totalMass > tp.mempool.config.MaximumMempoolMass
+}
+
+// wouldBeEvicted returns whether limitMempoolSize would evict the given transactions, or one
+// of their ancestors, if they were added to the pool with the given parents in place of the
+// given transactions to remove. The transactions are ranked together by their combined fee
+// rate, so that a package is judged as a whole. It lets a caller reject transactions before
+// they change the pool.
+func (tp *transactionsPool) wouldBeEvicted(transactions []*externalapi.DomainTransaction,
+	parentsInPool model.IDToTransactionMap, transactionsToRemove model.IDToTransactionMap,
+	isHighPriority bool) bool {
+
+	removedTransactions := make(map[externalapi.DomainTransactionID]struct{}, len(transactionsToRemove))
+	count := uint64(len(tp.allTransactions)) + uint64(len(transactions))
+	mass := tp.totalMass
+	fee := uint64(0)
+	transactionsMass := uint64(0)
+	for _, transaction := range transactions {
+		fee += transaction.Fee
+		transactionsMass += transaction.Mass
+	}
+	mass += transactionsMass
+	remove := func(transactionToRemove *model.MempoolTransaction) {
+		if _, ok := removedTransactions[*transactionToRemove.TransactionID()]; ok {
+			return
+		}
+		removedTransactions[*transactionToRemove.TransactionID()] = struct{}{}
+		count--
+		mass -= transactionToRemove.Transaction().Mass
+	}
+	for _, transactionToRemove := range transactionsToRemove {
+		remove(transactionToRemove)
+	}
+
+	feeRate := float64(fee) / float64(transactionsMass)
+	for i := 0; i < tp.transactionsOrderedByFeeRate.Len(); i++ {
+		if count <= tp.mempool.config.MaximumTransactionCount && mass <= tp.mempool.config.MaximumMempoolMass {
+			return false
+		}
+		candidate := tp.transactionsOrderedByFeeRate.GetByIndex(i)
+		if _, ok := removedTransactions[*candidate.TransactionID()]; ok || candidate.IsHighPriority() {
+			continue
+		}
+		// Transactions with an equal fee rate are ordered by their ID, so treat a tie as a loss
+		candidateFeeRate := float64(candidate.Transaction().Fee) / float64(candidate.Transaction().Mass)
+		if !isHighPriority && candidateFeeRate >= feeRate {
+			return true
+		}
+
+		remove(candidate)
+		for _, redeemer := range tp.getRedeemers(candidate) {
+			remove(redeemer)
+		}
+		for parentID := range parentsInPool {
+			if _, ok := removedTransactions[parentID]; ok {
+				return true
+			}
+		}
+	}
+
+	// Only high priority transactions are left, so limitMempoolSize evicts the given
+	// transactions themselves if the pool is still over its limits, unless they're high
+	// priority too
+	isOverLimit := count > tp.mempool.config.MaximumTransactionCount || mass > tp.mempool.config.MaximumMempoolMass
+	return !isHighPriority && isOverLimit
+}
+
+// transactionsStillInPool returns the given transactions that are still in the pool, leaving
+// out those that limitMempoolSize has evicted since they were accepted
+func (tp *transactionsPool) transactionsStillInPool(
+	transactions []*externalapi.DomainTransaction) []*externalapi.DomainTransaction {
+
+	transactionsInPool := make([]*externalapi.DomainTransaction, 0, len(transactions))
+	for _, transaction := range transactions {
+		if _, ok := tp.allTransactions[*consensushashing.TransactionID(transaction)]; ok {
+			transactionsInPool = append(transactionsInPool, transaction)
+		}
+	}
+	return transactionsInPool
+}
+
+// raiseEvictionFeeRate makes sure that transactions entering the pool from now on pay more
+// than the given transaction, which is evicted because the pool is full. The new fee rate
+// is higher than the evicted transaction's by the minimum relay fee rate, so that a
+// transaction can't enter the pool only to evict another that pays just a little less.
+func (tp *transactionsPool) raiseEvictionFeeRate(evictedTransaction *model.MempoolTransaction) {
+	// MinimumRelayTransactionFee is in sompi/kg while fee rates are in sompi/gram
+	evictionFeeRate := float64(evictedTransaction.Transaction().Fee)/float64(evictedTransaction.Transaction().Mass) +
+		float64(tp.mempool.config.MinimumRelayTransactionFee)/1000
+	if evictionFeeRate > tp.evictionMinimumFeeRate() {
+		tp.evictionFeeRate = evictionFeeRate
+		tp.lastEvictionTime = time.Now()
+	}
+}
+
+// evictionMinimumFeeRate returns the fee rate, in sompi per gram, that transactions must pay
+// due to the pool having been full. It halves every evictionFeeRateHalfLife, so that the
+// requirement relaxes once the pool is no longer under pressure.
+func (tp *transactionsPool) evictionMinimumFeeRate() float64 {
+	if tp.evictionFeeRate == 0 {
+		return 0
+	}
+	halfLives := time.Since(tp.lastEvictionTime).Seconds() / evictionFeeRateHalfLife.Seconds()
+	return tp.evictionFeeRate * math.Pow(0.5, halfLives)
+}
+
 func (tp *transactionsPool) getTransaction(transactionID *externalapi.DomainTransactionID, clone bool) (*externalapi.DomainTransaction, bool) {
 	if mempoolTransaction, ok := tp.allTransactions[*transactionID]; ok {
 		if clone {
@@ -247,32 +383,21 @@ func (tp *transactionsPool) getAllTransactions() []*externalapi.DomainTransactio
 	return allTransactions
 }
 
-func (tp *transactionsPool) transactionCount() int {
-	return len(tp.allTransactions)
+// feeRateDistribution returns the fee rate and mass of every transaction in the pool,
+// ordered from the highest fee rate to the lowest
+func (tp *transactionsPool) feeRateDistribution() []*miningmanagermodel.FeeRateAndMass {
+	distribution := make([]*miningmanagermodel.FeeRateAndMass, 0, tp.transactionsOrderedByFeeRate.Len())
+	for i := tp.transactionsOrderedByFeeRate.Len() - 1; i >= 0; i-- {
+		transaction := tp.transactionsOrderedByFeeRate.GetByIndex(i).Transaction()
+		distribution = append(distribution, &miningmanagermodel.FeeRateAndMass{
+			FeeRate: float64(transaction.Fee) / float64(transaction.Mass),
+			Mass:    transaction.Mass,
+		})
+	}
+	return distribution
 }
 
- if len(tx.VProgCode) > 0 {
-        if err := mp.processVProgTransaction(tx); err != nil {
-            return nil, err
-        }
-    }
-    
-    return txDesc, nil
+func (tp *transactionsPool) transactionCount() int {
+	return len(tp.allTransactions)
 }
 
-func (mp *mempool) processVProgTransaction(tx *externalapi.DomainTransaction) error {
-    // Additional processing untuk vprog transactions:
-    
-    if err := mp.checkVProgResourceLimits(tx); err != nil {
-        return err
-    }
-    
-    result, err := mp.vprogEngine.Validate(tx.VProgCode, tx.VProgData)
-    if err != nil {
-        return err
-    }
-    
-    mp.vprogContexts[tx.TransactionID] = result
-    
-    return nil
-}
@@ -7,6 +7,9 @@ import (
 
 	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
 	"github.com/zuanet/zuad/domain/consensus/utils/consensushashing"
+	"github.com/zuanet/zuad/domain/consensus/utils/constants"
+	"github.com/zuanet/zuad/domain/consensus/utils/utxo"
+	"github.com/zuanet/zuad/domain/miningmanager/mempool/model"
 )
 
 func (mp *mempool) validateAndInsertTransaction(transaction *externalapi.DomainTransaction, isHighPriority bool,
@@ -56,10 +59,222 @@ func (mp *mempool) validateAndInsertTransaction(transaction *externalapi.DomainT
 
 	acceptedTransactions = append([]*externalapi.DomainTransaction{transaction.Clone()}, acceptedOrphans...) //these pointer leave the mempool, hence we clone.
 
-	err = mp.transactionsPool.limitTransactionCount()
+	err = mp.transactionsPool.limitMempoolSize()
 	if err != nil {
 		return nil, err
 	}
+	if _, ok := mp.transactionsPool.allTransactions[*mempoolTransaction.TransactionID()]; !ok {
+		return nil, transactionRuleError(RejectInsufficientFee, fmt.Sprintf(
+			"transaction %s was evicted because the mempool is full and its fee rate is too low",
+			mempoolTransaction.TransactionID()))
+	}
+
+	// Orphans accepted along with the transaction may have been evicted right away
+	return mp.transactionsPool.transactionsStillInPool(acceptedTransactions), nil
+}
+
+// validateAndInsertTransactionPackage validates the given package of dependent transactions and
+// inserts either all of them into the mempool, or none of them. The transactions must be sorted
+// so that parents come before their children, and transactions that are already in the mempool
+// are skipped. The minimum relay fee is only required of the package as a whole, so that a
+// child may pay for a parent that wouldn't be accepted on its own.
+func (mp *mempool) validateAndInsertTransactionPackage(transactions []*externalapi.DomainTransaction,
+	isHighPriority bool) (acceptedTransactions []*externalapi.DomainTransaction, err error) {
+
+	onEnd := logger.LogAndMeasureExecutionTime(log,
+		fmt.Sprintf("validateAndInsertTransactionPackage of %d transactions", len(transactions)))
+	defer onEnd()
+
+	if len(transactions) == 0 {
+		return nil, transactionRuleError(RejectInvalid, "transaction package is empty")
+	}
+	// The maximum package size is a local policy, so exceeding it doesn't make a package invalid
+	if uint64(len(transactions)) > mp.config.MaximumTransactionPackageSize {
+		return nil, transactionRuleError(RejectNonstandard, fmt.Sprintf(
+			"transaction package has %d transactions, which is more than the maximum of %d",
+			len(transactions), mp.config.MaximumTransactionPackageSize))
+	}
+
+	transactionsToInsert, err := mp.validateTransactionPackage(transactions)
+	if err != nil {
+		return nil, err
+	}
+
+	// Members taken out of the orphan pool can't be restored once the package is inserted, so a
+	// package that the full pool would evict must be rejected before the pool changes. Members are
+	// ranked by the fee rate of the whole package, the same as the minimum relay fee is checked.
+	parentsInPool := model.IDToTransactionMap{}
+	for _, transaction := range transactionsToInsert {
+		for parentID, parent := range mp.transactionsPool.getParentTransactionsInPool(transaction) {
+			parentsInPool[parentID] = parent
+		}
+	}
+	if mp.transactionsPool.wouldBeEvicted(transactionsToInsert, parentsInPool, nil, isHighPriority) {
+		return nil, transactionRuleError(RejectInsufficientFee,
+			"transaction package would be evicted because the mempool is full and its fee rate is too low")
+	}
+
+	acceptedTransactions = make([]*externalapi.DomainTransaction, 0, len(transactionsToInsert))
+	mempoolTransactions := make([]*model.MempoolTransaction, len(transactionsToInsert))
+	packageTransactions := make(model.IDToTransactionMap, len(transactionsToInsert))
+	for i, transaction := range transactionsToInsert {
+		// A transaction of the package may already be waiting in the orphan pool for its parents
+		err = mp.orphansPool.removeOrphan(consensushashing.TransactionID(transaction), false)
+		if err != nil {
+			return nil, err
+		}
+
+		parentsInPool := mp.transactionsPool.getParentTransactionsInPool(transaction)
+		mempoolTransactions[i], err = mp.transactionsPool.addTransaction(transaction, parentsInPool, isHighPriority)
+		if err != nil {
+			return nil, err
+		}
+		packageTransactions[*mempoolTransactions[i].TransactionID()] = mempoolTransactions[i]
+		acceptedTransactions = append(acceptedTransactions, transaction.Clone()) //these pointer leave the mempool, hence we clone.
+	}
+
+	// The eviction order ranks every transaction by its own fee rate, which would evict a cheap
+	// parent that the package pays for, so make room for the package without evicting its members.
+	// wouldBeEvicted has made sure that enough cheaper transactions can be evicted instead.
+	err = mp.transactionsPool.limitMempoolSizeProtecting(packageTransactions)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mempoolTransaction := range mempoolTransactions {
+		acceptedOrphans, err := mp.orphansPool.processOrphansAfterAcceptedTransaction(mempoolTransaction.Transaction())
+		if err != nil {
+			return nil, err
+		}
+		acceptedTransactions = append(acceptedTransactions, acceptedOrphans...)
+	}
+
+	err = mp.transactionsPool.limitMempoolSizeProtecting(packageTransactions)
+	if err != nil {
+		return nil, err
+	}
+	for _, mempoolTransaction := range mempoolTransactions {
+		if _, ok := mp.transactionsPool.allTransactions[*mempoolTransaction.TransactionID()]; !ok {
+			return nil, transactionRuleError(RejectInsufficientFee, fmt.Sprintf(
+				"transaction %s of the package was evicted because the mempool is full and its fee rate is too low",
+				mempoolTransaction.TransactionID()))
+		}
+	}
+
+	// Orphans accepted along with the package may have been evicted right away
+	return mp.transactionsPool.transactionsStillInPool(acceptedTransactions), nil
+}
+
+// validateTransactionPackage runs the checks of validateAndInsertTransaction on every transaction
+// of the given package, treating the outputs of the transactions that precede it in the package
+// as if they were in the mempool. It returns the transactions that are not yet in the mempool.
+func (mp *mempool) validateTransactionPackage(transactions []*externalapi.DomainTransaction) (
+	transactionsToInsert []*externalapi.DomainTransaction, err error) {
+
+	packageIndexes := make(map[externalapi.DomainTransactionID]int, len(transactions))
+	for i, transaction := range transactions {
+		transactionID := consensushashing.TransactionID(transaction)
+		if _, ok := packageIndexes[*transactionID]; ok {
+			return nil, transactionRuleError(RejectInvalid, fmt.Sprintf(
+				"transaction %s appears more than once in the package", transactionID))
+		}
+		packageIndexes[*transactionID] = i
+	}
+
+	transactionsToInsert = make([]*externalapi.DomainTransaction, 0, len(transactions))
+	packageTransactionsToInsert := make(map[externalapi.DomainTransactionID]*externalapi.DomainTransaction)
+	spentOutpoints := make(map[externalapi.DomainOutpoint]*externalapi.DomainTransactionID)
+	totalFee := uint64(0)
+	totalMass := uint64(0)
+	for i, transaction := range transactions {
+		transactionID := consensushashing.TransactionID(transaction)
+		if _, ok := mp.transactionsPool.allTransactions[*transactionID]; ok {
+			continue
+		}
+
+		for _, input := range transaction.Inputs {
+			parentIndex, ok := packageIndexes[input.PreviousOutpoint.TransactionID]
+			if ok && parentIndex > i {
+				return nil, transactionRuleError(RejectInvalid, fmt.Sprintf(
+					"transaction %s appears in the package before its parent %s",
+					transactionID, input.PreviousOutpoint.TransactionID))
+			}
+			if spendingTransactionID, ok := spentOutpoints[input.PreviousOutpoint]; ok {
+				return nil, transactionRuleError(RejectDuplicate, fmt.Sprintf(
+					"output %s is spent by both transaction %s and transaction %s of the package",
+					input.PreviousOutpoint, spendingTransactionID, transactionID))
+			}
+			spentOutpoints[input.PreviousOutpoint] = transactionID
+		}
+
+		// Populate mass in the beginning, it will be used in multiple places throughout the validation.
+		mp.consensusReference.Consensus().PopulateMass(transaction)
+
+		err = mp.validateTransactionPreUTXOEntry(transaction)
+		if err != nil {
+			return nil, err
+		}
+
+		err = fillInputsFromPackage(transaction, packageTransactionsToInsert)
+		if err != nil {
+			return nil, err
+		}
+		_, missingOutpoints, err := mp.fillInputsAndGetMissingParents(transaction)
+		if err != nil {
+			return nil, err
+		}
+		if len(missingOutpoints) > 0 {
+			return nil, transactionRuleError(RejectBadOrphan, fmt.Sprintf(
+				"transaction %s of the package spends outputs that are found neither in the package, "+
+					"the mempool nor the UTXO set", transactionID))
+		}
 
-	return acceptedTransactions, nil
+		err = mp.validateTransactionInContext(transaction)
+		if err != nil {
+			// The fee is checked for the package as a whole below
+			if rejectCode, _ := ExtractRejectCode(err); rejectCode != RejectInsufficientFee {
+				return nil, err
+			}
+		}
+
+		totalFee += transaction.Fee
+		totalMass += transaction.Mass
+		packageTransactionsToInsert[*transactionID] = transaction
+		transactionsToInsert = append(transactionsToInsert, transaction)
+	}
+
+	if len(transactionsToInsert) == 0 {
+		return nil, transactionRuleError(RejectDuplicate, "all the transactions of the package are already in the mempool")
+	}
+
+	if !mp.config.AcceptNonStandard {
+		minimumFee := mp.minimumRequiredTransactionRelayFee(totalMass)
+		if totalFee < minimumFee {
+			return nil, transactionRuleError(RejectInsufficientFee, fmt.Sprintf(
+				"transaction package has %d fees which is under the required amount of %d for its total mass of %d",
+				totalFee, minimumFee, totalMass))
+		}
+	}
+
+	return transactionsToInsert, nil
+}
+
+func fillInputsFromPackage(transaction *externalapi.DomainTransaction,
+	packageTransactions map[externalapi.DomainTransactionID]*externalapi.DomainTransaction) error {
+
+	for _, input := range transaction.Inputs {
+		parent, ok := packageTransactions[input.PreviousOutpoint.TransactionID]
+		if !ok {
+			continue
+		}
+		if input.PreviousOutpoint.Index >= uint32(len(parent.Outputs)) {
+			return transactionRuleError(RejectInvalid, fmt.Sprintf(
+				"transaction %s spends output %s, which doesn't exist",
+				consensushashing.TransactionID(transaction), input.PreviousOutpoint))
+		}
+		relevantOutput := parent.Outputs[input.PreviousOutpoint.Index]
+		input.UTXOEntry = utxo.NewUTXOEntry(relevantOutput.Value, relevantOutput.ScriptPublicKey,
+			false, constants.UnacceptedDAAScore)
+	}
+	return nil
 }
@@ -31,7 +31,7 @@ func (mp *mempool) validateTransactionInIsolation(transaction *externalapi.Domai
 			// Attempt to extract a reject code from the error so
 			// it can be retained. When not possible, fall back to
 			// a non standard error.
-			rejectCode, found := extractRejectCode(err)
+			rejectCode, found := ExtractRejectCode(err)
 			if !found {
 				rejectCode = RejectNonstandard
 			}
@@ -50,7 +50,7 @@ func (mp *mempool) validateTransactionInContext(transaction *externalapi.DomainT
 			// Attempt to extract a reject code from the error so
 			// it can be retained. When not possible, fall back to
 			// a non standard error.
-			rejectCode, found := extractRejectCode(err)
+			rejectCode, found := ExtractRejectCode(err)
 			if !found {
 				rejectCode = RejectNonstandard
 			}
@@ -0,0 +1,72 @@
+package mempool
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/utils/consensushashing"
+	miningmanagermodel "github.com/zuanet/zuad/domain/miningmanager/model"
+)
+
+// validateTransactionDryRun runs the checks that validateAndInsertTransaction runs on the
+// given transaction, without inserting it into the mempool. Validation stops at the first
+// rule error, which is returned as part of the result rather than as an error.
+func (mp *mempool) validateTransactionDryRun(transaction *externalapi.DomainTransaction) (
+	*miningmanagermodel.TransactionValidationResult, error) {
+
+	// Filling the inputs and the consensus data modifies the transaction, so we work on a copy
+	transaction = transaction.Clone()
+	transactionID := consensushashing.TransactionID(transaction)
+
+	mp.consensusReference.Consensus().PopulateMass(transaction)
+	result := &miningmanagermodel.TransactionValidationResult{
+		Mass:       transaction.Mass,
+		IsStandard: mp.checkTransactionStandardInIsolation(transaction) == nil,
+	}
+	setRuleError := func(err error) error {
+		if !errors.As(err, &RuleError{}) {
+			return err
+		}
+		if result.RuleError == nil {
+			result.RuleError = err
+		}
+		return nil
+	}
+
+	err := setRuleError(mp.validateTransactionPreUTXOEntry(transaction))
+	if err != nil {
+		return nil, err
+	}
+
+	_, missingOutpoints, err := mp.fillInputsAndGetMissingParents(transaction)
+	if err != nil {
+		err = setRuleError(err)
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+	if len(missingOutpoints) > 0 {
+		result.IsOrphan = true
+		// SubmitTransaction may put the transaction in the orphan pool, but it'd
+		// only enter the mempool once its missing parents do.
+		if result.RuleError == nil {
+			result.RuleError = transactionRuleError(RejectBadOrphan,
+				fmt.Sprintf("transaction %s is an orphan", transactionID))
+		}
+		return result, nil
+	}
+
+	result.Fee = transaction.Fee
+	result.HasFee = true
+	result.IsStandard = result.IsStandard && mp.checkTransactionStandardInContext(transaction) == nil
+
+	err = setRuleError(mp.validateTransactionInContext(transaction))
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
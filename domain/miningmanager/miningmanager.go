@@ -7,6 +7,7 @@ import (
 	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
 	"github.com/zuanet/zuad/domain/consensusreference"
 	miningmanagermodel "github.com/zuanet/zuad/domain/miningmanager/model"
+	"github.com/zuanet/zuad/util"
 )
 
 // MiningManager creates block templates for mining as well as maintaining
@@ -29,16 +30,30 @@ type MiningManager interface {
 		transactionPoolTransactions []*externalapi.DomainTransaction,
 		orphanPoolTransactions []*externalapi.DomainTransaction)
 	TransactionCount(includeTransactionPool bool, includeOrphanPool bool) int
+	HighPriorityTransactionIDs() []*externalapi.DomainTransactionID
 	HandleNewBlockTransactions(txs []*externalapi.DomainTransaction) ([]*externalapi.DomainTransaction, error)
 	ValidateAndInsertTransaction(transaction *externalapi.DomainTransaction, isHighPriority bool, allowOrphan bool) (
 		acceptedTransactions []*externalapi.DomainTransaction, err error)
+	ValidateAndReplaceTransaction(transaction *externalapi.DomainTransaction, isHighPriority bool) (
+		acceptedTransactions []*externalapi.DomainTransaction, replacedTransactions []*externalapi.DomainTransaction, err error)
+	ValidateAndInsertTransactionPackage(transactions []*externalapi.DomainTransaction, isHighPriority bool) (
+		acceptedTransactions []*externalapi.DomainTransaction, err error)
+	ValidateAndInsertOrReplaceTransaction(transaction *externalapi.DomainTransaction, isHighPriority bool, allowOrphan bool) (
+		acceptedTransactions []*externalapi.DomainTransaction, replacedTransactions []*externalapi.DomainTransaction, err error)
+	ValidateTransaction(transaction *externalapi.DomainTransaction) (*miningmanagermodel.TransactionValidationResult, error)
 	RevalidateHighPriorityTransactions() (validTransactions []*externalapi.DomainTransaction, err error)
+	GetFeeEstimate() (*miningmanagermodel.FeeEstimate, error)
+	MinimumRelayTransactionFee() util.Amount
+	MaximumTransactionPackageSize() uint64
+	SetHasMempoolEventListeners(hasMempoolEventListeners func() bool)
+	CloseMempoolEventsChannel()
 }
 
 type miningManager struct {
 	consensusReference   consensusreference.ConsensusReference
 	mempool              miningmanagermodel.Mempool
 	blockTemplateBuilder miningmanagermodel.BlockTemplateBuilder
+	feeEstimator         miningmanagermodel.FeeEstimator
 	cachedBlockTemplate  *externalapi.DomainBlockTemplate
 	cachingTime          time.Time
 	cacheLock            *sync.Mutex
@@ -117,6 +132,36 @@ func (mm *miningManager) ValidateAndInsertTransaction(transaction *externalapi.D
 	return mm.mempool.ValidateAndInsertTransaction(transaction, isHighPriority, allowOrphan)
 }
 
+// ValidateAndReplaceTransaction validates the given transaction, and
+// adds it to the set of known transactions in place of the transactions
+// it double spends, if it pays a higher fee than them
+func (mm *miningManager) ValidateAndReplaceTransaction(transaction *externalapi.DomainTransaction,
+	isHighPriority bool) (acceptedTransactions []*externalapi.DomainTransaction,
+	replacedTransactions []*externalapi.DomainTransaction, err error) {
+
+	return mm.mempool.ValidateAndReplaceTransaction(transaction, isHighPriority)
+}
+
+// ValidateAndInsertOrReplaceTransaction validates the given transaction, and adds it
+// to the set of known transactions. If it double spends known transactions and
+// replace-by-fee is enabled, it's added in their place if it pays a higher fee
+// than them, as in ValidateAndReplaceTransaction.
+func (mm *miningManager) ValidateAndInsertOrReplaceTransaction(transaction *externalapi.DomainTransaction,
+	isHighPriority bool, allowOrphan bool) (acceptedTransactions []*externalapi.DomainTransaction,
+	replacedTransactions []*externalapi.DomainTransaction, err error) {
+
+	return mm.mempool.ValidateAndInsertOrReplaceTransaction(transaction, isHighPriority, allowOrphan)
+}
+
+// ValidateAndInsertTransactionPackage validates the given package of dependent
+// transactions, sorted so that parents come before their children, and adds
+// either all of them to the set of known transactions, or none of them
+func (mm *miningManager) ValidateAndInsertTransactionPackage(transactions []*externalapi.DomainTransaction,
+	isHighPriority bool) (acceptedTransactions []*externalapi.DomainTransaction, err error) {
+
+	return mm.mempool.ValidateAndInsertTransactionPackage(transactions, isHighPriority)
+}
+
 func (mm *miningManager) GetTransaction(
 	transactionID *externalapi.DomainTransactionID,
 	includeTransactionPool bool,
@@ -149,8 +194,55 @@ func (mm *miningManager) TransactionCount(includeTransactionPool bool, includeOr
 	return mm.mempool.TransactionCount(includeTransactionPool, includeOrphanPool)
 }
 
+// HighPriorityTransactionIDs returns the IDs of the mempool transactions and orphans
+// that were submitted with high priority
+func (mm *miningManager) HighPriorityTransactionIDs() []*externalapi.DomainTransactionID {
+	return mm.mempool.HighPriorityTransactionIDs()
+}
+
+// ValidateTransaction runs the checks that ValidateAndInsertTransaction runs on the
+// given transaction, without inserting it into the mempool
+func (mm *miningManager) ValidateTransaction(transaction *externalapi.DomainTransaction) (
+	*miningmanagermodel.TransactionValidationResult, error) {
+
+	return mm.mempool.ValidateTransaction(transaction)
+}
+
 func (mm *miningManager) RevalidateHighPriorityTransactions() (
 	validTransactions []*externalapi.DomainTransaction, err error) {
 
 	return mm.mempool.RevalidateHighPriorityTransactions()
 }
+
+// GetFeeEstimate returns the recommended fee rates for transactions
+// to be included in blocks within various amounts of time
+func (mm *miningManager) GetFeeEstimate() (*miningmanagermodel.FeeEstimate, error) {
+	return mm.feeEstimator.EstimateFees()
+}
+
+// MinimumRelayTransactionFee returns the minimum fee, in sompi/kg, that the mempool
+// currently requires from transactions. It rises above the configured minimum
+// while the mempool is full.
+func (mm *miningManager) MinimumRelayTransactionFee() util.Amount {
+	return mm.mempool.MinimumRelayTransactionFee()
+}
+
+// MaximumTransactionPackageSize returns the maximum number of transactions the mempool
+// admits together as a single package
+func (mm *miningManager) MaximumTransactionPackageSize() uint64 {
+	return mm.mempool.MaximumTransactionPackageSize()
+}
+
+// SetHasMempoolEventListeners sets the function the mempool uses to check
+// whether anyone listens to its events, so that it doesn't send events while
+// no one does
+func (mm *miningManager) SetHasMempoolEventListeners(hasMempoolEventListeners func() bool) {
+	mm.mempool.SetHasEventListeners(hasMempoolEventListeners)
+}
+
+// CloseMempoolEventsChannel closes the mempool events channel. The mempool
+// stops sending events once it's closed, so it's safe to call while the
+// mempool is still in use.
+func (mm *miningManager) CloseMempoolEventsChannel() {
+	mm.mempool.CloseEventsChannel()
+}
@@ -41,7 +41,7 @@ func TestValidateAndInsertTransaction(t *testing.T) {
 		tcAsConsensus := tc.(externalapi.Consensus)
 		tcAsConsensusPointer := &tcAsConsensus
 		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
-		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params))
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params), nil)
 		transactionsToInsert := make([]*externalapi.DomainTransaction, 10)
 		for i := range transactionsToInsert {
 			transactionsToInsert[i] = createTransactionWithUTXOEntry(t, i, 0)
@@ -92,7 +92,7 @@ func TestImmatureSpend(t *testing.T) {
 		tcAsConsensus := tc.(externalapi.Consensus)
 		tcAsConsensusPointer := &tcAsConsensus
 		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
-		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params))
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params), nil)
 		tx := createTransactionWithUTXOEntry(t, 0, consensusConfig.GenesisBlock.Header.DAAScore())
 		_, err = miningManager.ValidateAndInsertTransaction(tx, false, false)
 		txRuleError := &mempool.TxRuleError{}
@@ -122,7 +122,7 @@ func TestInsertDoubleTransactionsToMempool(t *testing.T) {
 		tcAsConsensus := tc.(externalapi.Consensus)
 		tcAsConsensusPointer := &tcAsConsensus
 		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
-		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params))
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params), nil)
 		transaction := createTransactionWithUTXOEntry(t, 0, 0)
 		_, err = miningManager.ValidateAndInsertTransaction(transaction, false, true)
 		if err != nil {
@@ -151,7 +151,7 @@ func TestDoubleSpendInMempool(t *testing.T) {
 		tcAsConsensus := tc.(externalapi.Consensus)
 		tcAsConsensusPointer := &tcAsConsensus
 		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
-		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params))
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params), nil)
 		transaction, err := createChildAndParentTxsAndAddParentToConsensus(tc)
 		if err != nil {
 			t.Fatalf("Error creating transaction: %+v", err)
@@ -187,7 +187,7 @@ func TestHandleNewBlockTransactions(t *testing.T) {
 		tcAsConsensus := tc.(externalapi.Consensus)
 		tcAsConsensusPointer := &tcAsConsensus
 		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
-		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params))
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params), nil)
 		transactionsToInsert := make([]*externalapi.DomainTransaction, 10)
 		for i := range transactionsToInsert {
 			transaction := createTransactionWithUTXOEntry(t, i, 0)
@@ -257,7 +257,7 @@ func TestDoubleSpendWithBlock(t *testing.T) {
 		tcAsConsensus := tc.(externalapi.Consensus)
 		tcAsConsensusPointer := &tcAsConsensus
 		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
-		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params))
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params), nil)
 		transactionInTheMempool := createTransactionWithUTXOEntry(t, 0, 0)
 		_, err = miningManager.ValidateAndInsertTransaction(transactionInTheMempool, false, true)
 		if err != nil {
@@ -279,6 +279,663 @@ func TestDoubleSpendWithBlock(t *testing.T) {
 }
 
 // TestOrphanTransactions verifies that a transaction could be a part of a new block template, only if it's not an orphan.
+func TestReplaceByFee(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestReplaceByFee")
+		if err != nil {
+			t.Fatalf("Error setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		miningFactory := miningmanager.NewFactory()
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params), nil)
+
+		parentTransaction, childTransaction, err := createParentAndChildrenTransactions(tc)
+		if err != nil {
+			t.Fatalf("Error creating transactions: %+v", err)
+		}
+		// The replacements spend the same outpoint as the parent transaction, and pay a higher fee
+		// by paying less to its output
+		createReplacement := func(additionalFee uint64) *externalapi.DomainTransaction {
+			replacement := parentTransaction.Clone()
+			replacement.Outputs[0].Value -= additionalFee
+			replacement.ID = nil // The cached ID of the parent transaction is no longer valid
+			return replacement
+		}
+		lowTotalFeeReplacement := createReplacement(500)
+		replacement := createReplacement(5000)
+
+		// The orphan spends the child transaction along with an outpoint that doesn't exist
+		orphanTransaction, err := testutils.CreateTransaction(childTransaction, 1000)
+		if err != nil {
+			t.Fatalf("Error creating transaction: %+v", err)
+		}
+		missingInput := orphanTransaction.Inputs[0].Clone()
+		missingInput.PreviousOutpoint = externalapi.DomainOutpoint{
+			TransactionID: *externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{1}),
+		}
+		orphanTransaction.Inputs = append(orphanTransaction.Inputs, missingInput)
+
+		for _, transaction := range []*externalapi.DomainTransaction{parentTransaction, childTransaction, orphanTransaction} {
+			_, err = miningManager.ValidateAndInsertTransaction(transaction, false, true)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertTransaction: %+v", err)
+			}
+		}
+		if miningManager.TransactionCount(false, true) != 1 {
+			t.Fatalf("Expected the orphan transaction to be in the orphan pool")
+		}
+
+		_, _, err = miningManager.ValidateAndReplaceTransaction(createTransactionWithUTXOEntry(t, 0, 0), false)
+		if err == nil || !errors.As(err, &mempool.RuleError{}) {
+			t.Fatalf("Expected a replacement that doesn't double spend anything to be rejected, but got: %v", err)
+		}
+
+		// The replacement has a higher fee rate than the parent transaction, but not a higher fee than both
+		// the parent and child transactions
+		_, _, err = miningManager.ValidateAndReplaceTransaction(lowTotalFeeReplacement, false)
+		if err == nil || !strings.Contains(err.Error(), "total fee") {
+			t.Fatalf("Expected a replacement with an insufficient fee to be rejected, but got: %v", err)
+		}
+
+		acceptedTransactions, replacedTransactions, err := miningManager.ValidateAndReplaceTransaction(replacement, false)
+		if err != nil {
+			t.Fatalf("ValidateAndReplaceTransaction: %+v", err)
+		}
+		if len(acceptedTransactions) != 1 || !acceptedTransactions[0].Equal(replacement) {
+			t.Fatalf("Expected only the replacement to be accepted, but got %d transactions", len(acceptedTransactions))
+		}
+		if len(replacedTransactions) != 2 || !contains(parentTransaction, replacedTransactions) ||
+			!contains(childTransaction, replacedTransactions) {
+			t.Fatalf("Expected the parent and child transactions to be replaced, but got %d transactions",
+				len(replacedTransactions))
+		}
+
+		transactionsFromMempool, orphansFromMempool := miningManager.AllTransactions(true, true)
+		if len(transactionsFromMempool) != 1 || !contains(replacement, transactionsFromMempool) {
+			t.Fatalf("Expected only the replacement to be in the mempool, but got %d transactions",
+				len(transactionsFromMempool))
+		}
+		if len(orphansFromMempool) != 0 {
+			t.Fatalf("Expected the orphan that depends on the replaced transactions to be evicted")
+		}
+	})
+}
+
+func TestMempoolMassLimit(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestMempoolMassLimit")
+		if err != nil {
+			t.Fatalf("Error setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		// The transactions differ only by the value of their output, so they all have the same mass
+		createTransaction := func(i int, fee uint64) *externalapi.DomainTransaction {
+			transaction := createTransactionWithUTXOEntry(t, i, 0)
+			transaction.Outputs[0].Value = transaction.Inputs[0].UTXOEntry.Amount() - fee
+			return transaction
+		}
+		lowFeeTransaction := createTransaction(0, 10000)
+		mediumFeeTransaction := createTransaction(1, 20000)
+		highFeeTransaction := createTransaction(2, 30000)
+		secondLowFeeTransaction := createTransaction(3, 10000)
+
+		// Make room for exactly two transactions
+		tc.PopulateMass(lowFeeTransaction)
+		mempoolConfig := mempool.DefaultConfig(&consensusConfig.Params)
+		mempoolConfig.MaximumMempoolMass = 2 * lowFeeTransaction.Mass
+
+		miningFactory := miningmanager.NewFactory()
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempoolConfig, nil)
+
+		for _, transaction := range []*externalapi.DomainTransaction{lowFeeTransaction, mediumFeeTransaction, highFeeTransaction} {
+			_, err = miningManager.ValidateAndInsertTransaction(transaction, false, true)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertTransaction: %+v", err)
+			}
+		}
+
+		transactionsFromMempool, _ := miningManager.AllTransactions(true, false)
+		if len(transactionsFromMempool) != 2 || contains(lowFeeTransaction, transactionsFromMempool) {
+			t.Fatalf("Expected the lowest fee rate transaction to be evicted from the mempool")
+		}
+		if miningManager.MinimumRelayTransactionFee() <= mempoolConfig.MinimumRelayTransactionFee {
+			t.Fatalf("Expected the minimum relay fee to rise above %d after an eviction, but got %d",
+				mempoolConfig.MinimumRelayTransactionFee, miningManager.MinimumRelayTransactionFee())
+		}
+
+		// The mempool now requires a higher fee rate than the rate of the evicted transaction
+		_, err = miningManager.ValidateAndInsertTransaction(secondLowFeeTransaction, false, true)
+		if err == nil || !errors.As(err, &mempool.RuleError{}) {
+			t.Fatalf("Expected a transaction paying the evicted fee rate to be rejected, but got: %v", err)
+		}
+	})
+}
+
+func TestReplaceByFeeInFullMempool(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestReplaceByFeeInFullMempool")
+		if err != nil {
+			t.Fatalf("Error setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		createTransaction := func(i int, fee uint64) *externalapi.DomainTransaction {
+			transaction := createTransactionWithUTXOEntry(t, i, 0)
+			transaction.Outputs[0].Value = transaction.Inputs[0].UTXOEntry.Amount() - fee
+			return transaction
+		}
+		lowFeeTransaction := createTransaction(0, 10000)
+		highFeeTransaction := createTransaction(1, 30000)
+
+		// The replacement pays a higher fee rate than the transaction it replaces, but has twice its
+		// mass, so the mempool can't hold it along with the high fee transaction
+		replacement := lowFeeTransaction.Clone()
+		replacement.Outputs[0].Value -= 15000
+		replacement.Mass = 2 * lowFeeTransaction.Mass
+		replacement.ID = nil // The cached ID of the replaced transaction is no longer valid
+
+		// Make room for exactly two transactions
+		tc.PopulateMass(lowFeeTransaction)
+		mempoolConfig := mempool.DefaultConfig(&consensusConfig.Params)
+		mempoolConfig.MaximumMempoolMass = 2 * lowFeeTransaction.Mass
+
+		miningFactory := miningmanager.NewFactory()
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempoolConfig, nil)
+
+		for _, transaction := range []*externalapi.DomainTransaction{lowFeeTransaction, highFeeTransaction} {
+			_, err = miningManager.ValidateAndInsertTransaction(transaction, false, true)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertTransaction: %+v", err)
+			}
+		}
+
+		_, _, err = miningManager.ValidateAndReplaceTransaction(replacement, false)
+		if err == nil || !strings.Contains(err.Error(), "would be evicted") {
+			t.Fatalf("Expected a replacement that the full mempool would evict to be rejected, but got: %v", err)
+		}
+
+		transactionsFromMempool, _ := miningManager.AllTransactions(true, false)
+		if len(transactionsFromMempool) != 2 || !contains(lowFeeTransaction, transactionsFromMempool) {
+			t.Fatalf("Expected the rejected replacement to leave the transaction it double spends in the mempool")
+		}
+	})
+}
+
+func TestEvictedOrphansAreNotAccepted(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestEvictedOrphansAreNotAccepted")
+		if err != nil {
+			t.Fatalf("Error setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		createTransaction := func(i int, fee uint64) *externalapi.DomainTransaction {
+			transaction := createTransactionWithUTXOEntry(t, i, 0)
+			transaction.Outputs[0].Value = transaction.Inputs[0].UTXOEntry.Amount() - fee
+			return transaction
+		}
+		highFeeTransaction := createTransaction(0, 30000)
+		parentTransaction := createTransaction(1, 20000)
+		orphanTransaction, err := testutils.CreateTransaction(parentTransaction, 5000)
+		if err != nil {
+			t.Fatalf("Error creating transaction: %+v", err)
+		}
+
+		mempoolConfig := mempool.DefaultConfig(&consensusConfig.Params)
+		mempoolConfig.MaximumTransactionCount = 2
+
+		miningFactory := miningmanager.NewFactory()
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempoolConfig, nil)
+
+		for _, transaction := range []*externalapi.DomainTransaction{highFeeTransaction, orphanTransaction} {
+			_, err = miningManager.ValidateAndInsertTransaction(transaction, false, true)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertTransaction: %+v", err)
+			}
+		}
+
+		// Accepting the parent accepts the orphan as well, which then has the lowest fee rate in the
+		// full mempool and is evicted right away
+		acceptedTransactions, err := miningManager.ValidateAndInsertTransaction(parentTransaction, false, true)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertTransaction: %+v", err)
+		}
+		if len(acceptedTransactions) != 1 || !acceptedTransactions[0].Equal(parentTransaction) {
+			t.Fatalf("Expected only the parent transaction to be accepted, but got %d transactions",
+				len(acceptedTransactions))
+		}
+		transactionsFromMempool, _ := miningManager.AllTransactions(true, false)
+		if len(transactionsFromMempool) != 2 || contains(orphanTransaction, transactionsFromMempool) {
+			t.Fatalf("Expected the orphan transaction to be evicted from the mempool")
+		}
+	})
+}
+
+func TestMempoolEvents(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestMempoolEvents")
+		if err != nil {
+			t.Fatalf("Error setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		miningFactory := miningmanager.NewFactory()
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+		mempoolEventsChan := make(chan model.MempoolEvent, 100)
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params,
+			mempool.DefaultConfig(&consensusConfig.Params), mempoolEventsChan)
+
+		receiveEvent := func() model.MempoolEvent {
+			select {
+			case event := <-mempoolEventsChan:
+				return event
+			default:
+				t.Fatalf("Expected a mempool event")
+				return nil
+			}
+		}
+		expectRemovedEvent := func(transaction *externalapi.DomainTransaction, reason model.MempoolRemovalReason) {
+			removedEvent, ok := receiveEvent().(*model.TransactionRemovedFromMempool)
+			if !ok || !removedEvent.TransactionID.Equal(consensushashing.TransactionID(transaction)) ||
+				removedEvent.Reason != reason {
+				t.Fatalf("Expected transaction %s to be removed from the mempool as %s",
+					consensushashing.TransactionID(transaction), reason)
+			}
+		}
+
+		parentTransaction, childTransaction, err := createParentAndChildrenTransactions(tc)
+		if err != nil {
+			t.Fatalf("Error creating transactions: %+v", err)
+		}
+		for _, transaction := range []*externalapi.DomainTransaction{parentTransaction, childTransaction} {
+			_, err = miningManager.ValidateAndInsertTransaction(transaction, false, true)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertTransaction: %+v", err)
+			}
+			addedEvent, ok := receiveEvent().(*model.TransactionAddedToMempool)
+			if !ok || !addedEvent.Transaction.Equal(transaction) {
+				t.Fatalf("Expected transaction %s to be added to the mempool",
+					consensushashing.TransactionID(transaction))
+			}
+		}
+
+		_, err = miningManager.HandleNewBlockTransactions([]*externalapi.DomainTransaction{nil, parentTransaction})
+		if err != nil {
+			t.Fatalf("HandleNewBlockTransactions: %+v", err)
+		}
+		expectRemovedEvent(parentTransaction, model.MempoolRemovalReasonMined)
+
+		// The double spend spends the same outpoint as the child transaction, and pays a higher fee
+		doubleSpendTransaction := childTransaction.Clone()
+		doubleSpendTransaction.Outputs[0].Value--
+		doubleSpendTransaction.ID = nil // The cached ID of the child transaction is no longer valid
+		_, err = miningManager.HandleNewBlockTransactions([]*externalapi.DomainTransaction{nil, doubleSpendTransaction})
+		if err != nil {
+			t.Fatalf("HandleNewBlockTransactions: %+v", err)
+		}
+		expectRemovedEvent(childTransaction, model.MempoolRemovalReasonDoubleSpent)
+
+		if len(mempoolEventsChan) != 0 {
+			t.Fatalf("Expected no more mempool events, but got %d", len(mempoolEventsChan))
+		}
+
+		// No events are sent while no one listens to them
+		hasListeners := false
+		miningManager.SetHasMempoolEventListeners(func() bool { return hasListeners })
+		unlistenedTransaction := createTransactionWithUTXOEntry(t, 0, 0)
+		_, err = miningManager.ValidateAndInsertTransaction(unlistenedTransaction, false, true)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertTransaction: %+v", err)
+		}
+		if len(mempoolEventsChan) != 0 {
+			t.Fatalf("Expected no mempool events without listeners, but got %d", len(mempoolEventsChan))
+		}
+
+		// The mempool keeps working after its events channel is closed
+		hasListeners = true
+		miningManager.CloseMempoolEventsChannel()
+		_, err = miningManager.ValidateAndInsertTransaction(createTransactionWithUTXOEntry(t, 1, 0), false, true)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertTransaction: %+v", err)
+		}
+		if _, ok := <-mempoolEventsChan; ok {
+			t.Fatalf("Expected no mempool events after the events channel is closed")
+		}
+	})
+}
+
+func TestValidateTransaction(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestValidateTransaction")
+		if err != nil {
+			t.Fatalf("Error setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		miningFactory := miningmanager.NewFactory()
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params), nil)
+
+		parentTransaction, childTransaction, err := createParentAndChildrenTransactions(tc)
+		if err != nil {
+			t.Fatalf("Error creating transactions: %+v", err)
+		}
+
+		result, err := miningManager.ValidateTransaction(parentTransaction)
+		if err != nil {
+			t.Fatalf("ValidateTransaction: %+v", err)
+		}
+		if result.RuleError != nil {
+			t.Fatalf("Expected transaction %s to be valid, but got: %s",
+				consensushashing.TransactionID(parentTransaction), result.RuleError)
+		}
+		if !result.HasFee || result.Fee != 1000 || result.Mass == 0 || !result.IsStandard || result.IsOrphan {
+			t.Fatalf("Unexpected validation result for transaction %s: %+v",
+				consensushashing.TransactionID(parentTransaction), result)
+		}
+		if parentTransaction.Inputs[0].UTXOEntry != nil {
+			t.Fatalf("ValidateTransaction unexpectedly modified the given transaction")
+		}
+		transactionsFromMempool, _ := miningManager.AllTransactions(true, false)
+		if len(transactionsFromMempool) != 0 {
+			t.Fatalf("Expected ValidateTransaction not to insert the transaction into the mempool")
+		}
+
+		// The child spends an output of the parent, which is neither in the mempool nor in the UTXO set
+		result, err = miningManager.ValidateTransaction(childTransaction)
+		if err != nil {
+			t.Fatalf("ValidateTransaction: %+v", err)
+		}
+		if !result.IsOrphan || result.HasFee {
+			t.Fatalf("Expected transaction %s to be an orphan", consensushashing.TransactionID(childTransaction))
+		}
+		rejectCode, _ := mempool.ExtractRejectCode(result.RuleError)
+		if rejectCode != mempool.RejectBadOrphan {
+			t.Fatalf("Expected an orphan rule error, but got: %v", result.RuleError)
+		}
+
+		_, err = miningManager.ValidateAndInsertTransaction(parentTransaction, false, true)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertTransaction: %+v", err)
+		}
+		result, err = miningManager.ValidateTransaction(childTransaction)
+		if err != nil {
+			t.Fatalf("ValidateTransaction: %+v", err)
+		}
+		if result.RuleError != nil || result.IsOrphan {
+			t.Fatalf("Expected transaction %s to be valid once its parent is in the mempool, but got: %v",
+				consensushashing.TransactionID(childTransaction), result.RuleError)
+		}
+
+		zeroFeeTransaction := childTransaction.Clone()
+		zeroFeeTransaction.Outputs[0].Value += 1000
+		zeroFeeTransaction.ID = nil
+		result, err = miningManager.ValidateTransaction(zeroFeeTransaction)
+		if err != nil {
+			t.Fatalf("ValidateTransaction: %+v", err)
+		}
+		if !result.HasFee || result.Fee != 0 {
+			t.Fatalf("Expected transaction %s to have no fee", consensushashing.TransactionID(zeroFeeTransaction))
+		}
+		rejectCode, _ = mempool.ExtractRejectCode(result.RuleError)
+		if rejectCode != mempool.RejectInsufficientFee {
+			t.Fatalf("Expected an insufficient fee rule error, but got: %v", result.RuleError)
+		}
+	})
+}
+
+func TestTransactionPackage(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestTransactionPackage")
+		if err != nil {
+			t.Fatalf("Error setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		miningFactory := miningmanager.NewFactory()
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params), nil)
+
+		expectRejectCode := func(err error, expectedRejectCode mempool.RejectCode) {
+			if !errors.As(err, &mempool.RuleError{}) {
+				t.Fatalf("Expected a rule error, but got: %+v", err)
+			}
+			rejectCode, _ := mempool.ExtractRejectCode(err)
+			if rejectCode != expectedRejectCode {
+				t.Fatalf("Expected reject code %s, but got: %s", expectedRejectCode, err)
+			}
+		}
+
+		fundedTransaction, _, err := createParentAndChildrenTransactions(tc)
+		if err != nil {
+			t.Fatalf("Error creating transactions: %+v", err)
+		}
+		// The parent pays no fee, so it isn't accepted on its own. createParentAndChildrenTransactions
+		// creates transactions that pay a fee of 1000.
+		parentTransaction := fundedTransaction.Clone()
+		parentTransaction.Outputs[0].Value += 1000
+		parentTransaction.ID = nil
+		childTransaction, err := testutils.CreateTransaction(parentTransaction, 10000)
+		if err != nil {
+			t.Fatalf("Error creating transaction: %+v", err)
+		}
+		lowFeeChildTransaction, err := testutils.CreateTransaction(parentTransaction, 0)
+		if err != nil {
+			t.Fatalf("Error creating transaction: %+v", err)
+		}
+
+		_, err = miningManager.ValidateAndInsertTransaction(parentTransaction.Clone(), false, false)
+		expectRejectCode(err, mempool.RejectInsufficientFee)
+
+		_, err = miningManager.ValidateAndInsertTransactionPackage(
+			[]*externalapi.DomainTransaction{childTransaction.Clone(), parentTransaction.Clone()}, false)
+		expectRejectCode(err, mempool.RejectInvalid)
+
+		_, err = miningManager.ValidateAndInsertTransactionPackage(
+			[]*externalapi.DomainTransaction{parentTransaction.Clone(), lowFeeChildTransaction.Clone()}, false)
+		expectRejectCode(err, mempool.RejectInsufficientFee)
+
+		_, err = miningManager.ValidateAndInsertTransactionPackage(
+			[]*externalapi.DomainTransaction{parentTransaction.Clone(), childTransaction.Clone(),
+				lowFeeChildTransaction.Clone()}, false)
+		expectRejectCode(err, mempool.RejectDuplicate)
+
+		if miningManager.TransactionCount(true, true) != 0 {
+			t.Fatalf("Expected rejected packages not to leave any transactions in the mempool")
+		}
+
+		acceptedTransactions, err := miningManager.ValidateAndInsertTransactionPackage(
+			[]*externalapi.DomainTransaction{parentTransaction.Clone(), childTransaction.Clone()}, false)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertTransactionPackage: %+v", err)
+		}
+		transactionsFromMempool, _ := miningManager.AllTransactions(true, false)
+		for _, transaction := range []*externalapi.DomainTransaction{parentTransaction, childTransaction} {
+			if !contains(transaction, acceptedTransactions) || !contains(transaction, transactionsFromMempool) {
+				t.Fatalf("Expected transaction %s to be accepted to the mempool",
+					consensushashing.TransactionID(transaction))
+			}
+		}
+
+		_, err = miningManager.ValidateAndInsertTransactionPackage(
+			[]*externalapi.DomainTransaction{parentTransaction.Clone(), childTransaction.Clone()}, false)
+		expectRejectCode(err, mempool.RejectDuplicate)
+	})
+}
+
+func TestTransactionPackageInFullMempool(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestTransactionPackageInFullMempool")
+		if err != nil {
+			t.Fatalf("Error setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		createTransaction := func(i int, fee uint64) *externalapi.DomainTransaction {
+			transaction := createTransactionWithUTXOEntry(t, i, 0)
+			transaction.Outputs[0].Value = transaction.Inputs[0].UTXOEntry.Amount() - fee
+			transaction.Fee = fee
+			return transaction
+		}
+		createChildTransaction := func(parentTransaction *externalapi.DomainTransaction, fee uint64) *externalapi.DomainTransaction {
+			transaction := parentTransaction.Clone()
+			transaction.Inputs[0].PreviousOutpoint = externalapi.DomainOutpoint{
+				TransactionID: *consensushashing.TransactionID(parentTransaction),
+				Index:         0,
+			}
+			transaction.Inputs[0].UTXOEntry = utxo.NewUTXOEntry(parentTransaction.Outputs[0].Value,
+				parentTransaction.Outputs[0].ScriptPublicKey, false, constants.UnacceptedDAAScore)
+			transaction.Outputs[0].Value = parentTransaction.Outputs[0].Value - fee
+			transaction.Fee = fee
+			transaction.ID = nil
+			return transaction
+		}
+		mediumFeeTransaction := createTransaction(0, 20000)
+		highFeeTransaction := createTransaction(1, 40000)
+		// The parent pays no fee, so it has the lowest fee rate in the mempool once it's inserted
+		parentTransaction := createTransaction(2, 0)
+		lowFeeChildTransaction := createChildTransaction(parentTransaction, 30000)
+		highFeeChildTransaction := createChildTransaction(parentTransaction, 50000)
+
+		mempoolConfig := mempool.DefaultConfig(&consensusConfig.Params)
+		mempoolConfig.MaximumTransactionCount = 3
+
+		miningFactory := miningmanager.NewFactory()
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempoolConfig, nil)
+
+		for _, transaction := range []*externalapi.DomainTransaction{mediumFeeTransaction, highFeeTransaction} {
+			_, err = miningManager.ValidateAndInsertTransaction(transaction.Clone(), false, true)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertTransaction: %+v", err)
+			}
+		}
+		// Without its UTXO entry, the child waits in the orphan pool for its parent
+		orphanTransaction := lowFeeChildTransaction.Clone()
+		orphanTransaction.Inputs[0].UTXOEntry = nil
+		_, err = miningManager.ValidateAndInsertTransaction(orphanTransaction, false, true)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertTransaction: %+v", err)
+		}
+
+		// The package pays a lower fee rate than every transaction in the full mempool, so it's
+		// rejected without taking its child out of the orphan pool
+		_, err = miningManager.ValidateAndInsertTransactionPackage(
+			[]*externalapi.DomainTransaction{parentTransaction.Clone(), lowFeeChildTransaction.Clone()}, false)
+		if err == nil || !strings.Contains(err.Error(), "would be evicted") {
+			t.Fatalf("Expected a package that the full mempool would evict to be rejected, but got: %v", err)
+		}
+		transactionsFromMempool, orphansFromMempool := miningManager.AllTransactions(true, true)
+		if len(transactionsFromMempool) != 2 || len(orphansFromMempool) != 1 ||
+			!consensushashing.TransactionID(orphansFromMempool[0]).Equal(consensushashing.TransactionID(lowFeeChildTransaction)) {
+			t.Fatalf("Expected the rejected package to leave the mempool and the orphan pool unchanged")
+		}
+
+		// The package pays a higher fee rate than the medium fee transaction as a whole, so the
+		// medium fee transaction is evicted in its place, even though the parent pays no fee itself
+		acceptedTransactions, err := miningManager.ValidateAndInsertTransactionPackage(
+			[]*externalapi.DomainTransaction{parentTransaction.Clone(), highFeeChildTransaction.Clone()}, false)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertTransactionPackage: %+v", err)
+		}
+		transactionsFromMempool, _ = miningManager.AllTransactions(true, false)
+		for _, transaction := range []*externalapi.DomainTransaction{parentTransaction, highFeeChildTransaction} {
+			if !contains(transaction, acceptedTransactions) || !contains(transaction, transactionsFromMempool) {
+				t.Fatalf("Expected transaction %s to be accepted to the mempool",
+					consensushashing.TransactionID(transaction))
+			}
+		}
+		if len(transactionsFromMempool) != 3 || contains(mediumFeeTransaction, transactionsFromMempool) {
+			t.Fatalf("Expected the medium fee transaction to be evicted from the mempool")
+		}
+	})
+}
+
+func TestChildPaysForParent(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestChildPaysForParent")
+		if err != nil {
+			t.Fatalf("Error setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+		mempoolInstance := mempool.New(mempool.DefaultConfig(&consensusConfig.Params), consensusReference, nil)
+
+		parentTransaction, _, err := createParentAndChildrenTransactions(tc)
+		if err != nil {
+			t.Fatalf("Error creating transactions: %+v", err)
+		}
+		const childFee = 100000
+		childTransaction, err := testutils.CreateTransaction(parentTransaction, childFee)
+		if err != nil {
+			t.Fatalf("Error creating transaction: %+v", err)
+		}
+		for _, transaction := range []*externalapi.DomainTransaction{parentTransaction, childTransaction} {
+			_, err = mempoolInstance.ValidateAndInsertTransaction(transaction, false, false)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertTransaction: %+v", err)
+			}
+		}
+
+		candidates := mempoolInstance.BlockCandidateTransactions()
+		if len(candidates) != 1 || !candidates[0].Transaction.Equal(parentTransaction) {
+			t.Fatalf("Expected only the parent transaction to be a block candidate, but got %d candidates",
+				len(candidates))
+		}
+		candidate := candidates[0]
+		expectedPackageFee := parentTransaction.Fee + childFee
+		expectedPackageMass := parentTransaction.Mass + childTransaction.Mass
+		if candidate.PackageFee != expectedPackageFee || candidate.PackageMass != expectedPackageMass {
+			t.Fatalf("Expected the package of the parent transaction to have a fee of %d and a mass of %d, "+
+				"but got a fee of %d and a mass of %d", expectedPackageFee, expectedPackageMass,
+				candidate.PackageFee, candidate.PackageMass)
+		}
+	})
+}
+
 func TestOrphanTransactions(t *testing.T) {
 	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
 		consensusConfig.BlockCoinbaseMaturity = 0
@@ -293,7 +950,7 @@ func TestOrphanTransactions(t *testing.T) {
 		tcAsConsensus := tc.(externalapi.Consensus)
 		tcAsConsensusPointer := &tcAsConsensus
 		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
-		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params))
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params), nil)
 		// Before each parent transaction, We will add two blocks by consensus in order to fund the parent transactions.
 		parentTransactions, childTransactions, err := createArraysOfParentAndChildrenTransactions(tc)
 		if err != nil {
@@ -396,7 +1053,7 @@ func TestHighPriorityTransactions(t *testing.T) {
 		tcAsConsensus := tc.(externalapi.Consensus)
 		tcAsConsensusPointer := &tcAsConsensus
 		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
-		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempoolConfig)
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempoolConfig, nil)
 
 		// Create 3 pairs of transaction parent-and-child pairs: 1 low priority and 2 high priority
 		lowPriorityParentTransaction, lowPriorityChildTransaction, err := createParentAndChildrenTransactions(tc)
@@ -489,7 +1146,7 @@ func TestRevalidateHighPriorityTransactions(t *testing.T) {
 		tcAsConsensus := tc.(externalapi.Consensus)
 		tcAsConsensusPointer := &tcAsConsensus
 		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
-		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempoolConfig)
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempoolConfig, nil)
 
 		// Create two valid transactions that double-spend each other (childTransaction1, childTransaction2)
 		parentTransaction, childTransaction1, err := createParentAndChildrenTransactions(tc)
@@ -592,7 +1249,7 @@ func TestModifyBlockTemplate(t *testing.T) {
 		tcAsConsensus := tc.(externalapi.Consensus)
 		tcAsConsensusPointer := &tcAsConsensus
 		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
-		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params))
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params), nil)
 
 		// Create some complex transactions. Logic taken from TestOrphanTransactions
 
@@ -741,6 +1398,54 @@ func TestModifyBlockTemplate(t *testing.T) {
 	})
 }
 
+func TestGetFeeEstimate(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestGetFeeEstimate")
+		if err != nil {
+			t.Fatalf("Error setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		miningFactory := miningmanager.NewFactory()
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+		mempoolConfig := mempool.DefaultConfig(&consensusConfig.Params)
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempoolConfig, nil)
+
+		for i := 0; i < 10; i++ {
+			transaction := createTransactionWithUTXOEntry(t, i, 0)
+			transaction.Fee = uint64(1000 * (i + 1))
+			_, err = miningManager.ValidateAndInsertTransaction(transaction, false, true)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertTransaction: %v", err)
+			}
+		}
+
+		estimate, err := miningManager.GetFeeEstimate()
+		if err != nil {
+			t.Fatalf("GetFeeEstimate: %+v", err)
+		}
+
+		minimumFeeRate := float64(mempoolConfig.MinimumRelayTransactionFee) / 1000
+		buckets := []*model.FeeRateBucket{estimate.PriorityBucket, estimate.NormalBucket, estimate.LowBucket}
+		for i, bucket := range buckets {
+			if bucket.FeeRate < minimumFeeRate {
+				t.Fatalf("Bucket %d has a fee rate of %f, which is below the minimum of %f", i, bucket.FeeRate, minimumFeeRate)
+			}
+			if bucket.EstimatedSeconds <= 0 {
+				t.Fatalf("Bucket %d has a non-positive estimated inclusion time of %f", i, bucket.EstimatedSeconds)
+			}
+			if i > 0 && bucket.FeeRate > buckets[i-1].FeeRate {
+				t.Fatalf("Bucket %d has a fee rate of %f, which is higher than the fee rate of bucket %d: %f",
+					i, bucket.FeeRate, i-1, buckets[i-1].FeeRate)
+			}
+		}
+	})
+}
+
 func sweepCompareModifiedTemplateToBuilt(
 	t *testing.T, consensusConfig *consensus.Config, builder model.BlockTemplateBuilder) {
 	for i := 0; i < 4; i++ {
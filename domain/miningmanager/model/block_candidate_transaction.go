@@ -0,0 +1,27 @@
+package model
+
+import (
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+)
+
+// BlockCandidateTransaction is a mempool transaction that may be included in the
+// next block, along with the package of mempool transactions it is best mined with.
+//
+// A package is a mempool transaction together with all its ancestors in the mempool,
+// and its fee rate is the total fee of the package divided by its total mass. This
+// lets a high-fee child pay for its low-fee parents (child-pays-for-parent).
+// Since a block may not contain a transaction along with its parent, only the
+// ancestors that are themselves block candidates can be included together, which
+// makes the rest of the package valid for inclusion in the following blocks.
+type BlockCandidateTransaction struct {
+	Transaction *externalapi.DomainTransaction
+
+	// PackageFee and PackageMass are the fee and mass of the package with the highest
+	// fee rate that this transaction belongs to. If the transaction's own fee rate is
+	// higher than that of any package, they are the fee and mass of the transaction.
+	PackageFee  uint64
+	PackageMass uint64
+
+	// PackageTransactionIDs are the IDs of the other block candidates in that package
+	PackageTransactionIDs []*externalapi.DomainTransactionID
+}
@@ -0,0 +1,26 @@
+package model
+
+// FeeRateBucket is a fee rate, in sompi per gram of mass, along with the
+// estimated time it takes a transaction that pays it to be included in a block
+type FeeRateBucket struct {
+	FeeRate          float64
+	EstimatedSeconds float64
+}
+
+// FeeEstimate holds the recommended fee rates for three levels of urgency
+type FeeEstimate struct {
+	PriorityBucket *FeeRateBucket
+	NormalBucket   *FeeRateBucket
+	LowBucket      *FeeRateBucket
+}
+
+// FeeRateAndMass is the fee rate and mass of a single transaction
+type FeeRateAndMass struct {
+	FeeRate float64
+	Mass    uint64
+}
+
+// FeeEstimator estimates the fee rates required for transactions to be included in blocks
+type FeeEstimator interface {
+	EstimateFees() (*FeeEstimate, error)
+}
@@ -2,16 +2,24 @@ package model
 
 import (
 	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/util"
 )
 
 // Mempool maintains a set of known transactions that
 // are intended to be mined into new blocks
 type Mempool interface {
 	HandleNewBlockTransactions(txs []*externalapi.DomainTransaction) ([]*externalapi.DomainTransaction, error)
-	BlockCandidateTransactions() []*externalapi.DomainTransaction
+	BlockCandidateTransactions() []*BlockCandidateTransaction
 	ValidateAndInsertTransaction(transaction *externalapi.DomainTransaction, isHighPriority bool, allowOrphan bool) (
 		acceptedTransactions []*externalapi.DomainTransaction, err error)
-	RemoveTransactions(txs []*externalapi.DomainTransaction, removeRedeemers bool) error
+	ValidateAndReplaceTransaction(transaction *externalapi.DomainTransaction, isHighPriority bool) (
+		acceptedTransactions []*externalapi.DomainTransaction, replacedTransactions []*externalapi.DomainTransaction, err error)
+	ValidateAndInsertOrReplaceTransaction(transaction *externalapi.DomainTransaction, isHighPriority bool, allowOrphan bool) (
+		acceptedTransactions []*externalapi.DomainTransaction, replacedTransactions []*externalapi.DomainTransaction, err error)
+	ValidateAndInsertTransactionPackage(transactions []*externalapi.DomainTransaction, isHighPriority bool) (
+		acceptedTransactions []*externalapi.DomainTransaction, err error)
+	ValidateTransaction(transaction *externalapi.DomainTransaction) (*TransactionValidationResult, error)
+	RemoveTransactions(txs []*externalapi.DomainTransaction, removeRedeemers bool, reason MempoolRemovalReason) error
 	GetTransaction(
 		transactionID *externalapi.DomainTransactionID,
 		includeTransactionPool bool,
@@ -37,6 +45,12 @@ type Mempool interface {
 	TransactionCount(
 		includeTransactionPool bool,
 		includeOrphanPool bool) int
+	HighPriorityTransactionIDs() []*externalapi.DomainTransactionID
 	RevalidateHighPriorityTransactions() (validTransactions []*externalapi.DomainTransaction, err error)
 	IsTransactionOutputDust(output *externalapi.DomainTransactionOutput) bool
+	FeeRateDistribution() []*FeeRateAndMass
+	MinimumRelayTransactionFee() util.Amount
+	MaximumTransactionPackageSize() uint64
+	SetHasEventListeners(hasEventListeners func() bool)
+	CloseEventsChannel()
 }
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+)
+
+// MempoolEvent is an event raised by the mempool when its transactions change
+type MempoolEvent interface {
+	isMempoolEvent()
+}
+
+// TransactionAddedToMempool is an event raised by the mempool when a transaction
+// was added to it. Orphans are only added once their missing parents are found.
+type TransactionAddedToMempool struct {
+	Transaction *externalapi.DomainTransaction
+}
+
+func (*TransactionAddedToMempool) isMempoolEvent() {}
+
+// TransactionRemovedFromMempool is an event raised by the mempool when a transaction
+// was removed from it
+type TransactionRemovedFromMempool struct {
+	TransactionID *externalapi.DomainTransactionID
+	Reason        MempoolRemovalReason
+}
+
+func (*TransactionRemovedFromMempool) isMempoolEvent() {}
+
+// MempoolRemovalReason is the reason a transaction was removed from the mempool
+type MempoolRemovalReason uint8
+
+const (
+	// MempoolRemovalReasonMined means that the transaction was included in a block
+	MempoolRemovalReasonMined MempoolRemovalReason = iota
+
+	// MempoolRemovalReasonDoubleSpent means that an input of the transaction, or of one
+	// of its ancestors, was spent by another transaction
+	MempoolRemovalReasonDoubleSpent
+
+	// MempoolRemovalReasonExpired means that the transaction, or one of its ancestors,
+	// stayed in the mempool for too long
+	MempoolRemovalReasonExpired
+
+	// MempoolRemovalReasonEvicted means that the transaction, or one of its ancestors,
+	// was evicted to make room for transactions that pay a higher fee rate
+	MempoolRemovalReasonEvicted
+
+	// MempoolRemovalReasonInvalid means that the transaction was found to be invalid
+	// while building a block template
+	MempoolRemovalReasonInvalid
+)
+
+var mempoolRemovalReasonStrings = map[MempoolRemovalReason]string{
+	MempoolRemovalReasonMined:       "mined",
+	MempoolRemovalReasonDoubleSpent: "doubleSpent",
+	MempoolRemovalReasonExpired:     "expired",
+	MempoolRemovalReasonEvicted:     "evicted",
+	MempoolRemovalReasonInvalid:     "invalid",
+}
+
+func (reason MempoolRemovalReason) String() string {
+	return mempoolRemovalReasonStrings[reason]
+}
@@ -0,0 +1,26 @@
+package model
+
+// TransactionValidationResult is the result of validating a transaction against the mempool
+// without inserting it
+type TransactionValidationResult struct {
+	// Mass is the mass of the transaction
+	Mass uint64
+
+	// Fee is the fee of the transaction. It is only known if all of the transaction's
+	// inputs are found, in which case HasFee is set.
+	Fee    uint64
+	HasFee bool
+
+	// IsStandard is set if the transaction passes the mempool's standardness checks,
+	// regardless of whether the mempool accepts non-standard transactions.
+	// The checks that depend on the transaction's inputs only run if its fee is known.
+	IsStandard bool
+
+	// IsOrphan is set if some of the transaction's inputs are found neither in the
+	// UTXO set nor in the mempool
+	IsOrphan bool
+
+	// RuleError is the error the mempool would reject the transaction with, or nil
+	// if the transaction would be accepted
+	RuleError error
+}
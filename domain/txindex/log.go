@@ -0,0 +1,7 @@
+package txindex
+
+import (
+	"github.com/zuanet/zuad/infrastructure/logger"
+)
+
+var log = logger.RegisterSubSystem("TXIN")
@@ -0,0 +1,18 @@
+package txindex
+
+import (
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+)
+
+// TXAcceptanceData holds the block that included an accepted transaction and the
+// selected chain block that accepted it
+type TXAcceptanceData struct {
+	IncludingBlockHash *externalapi.DomainHash
+	AcceptingBlockHash *externalapi.DomainHash
+}
+
+// TXAcceptanceDataMap is a map between transaction IDs and their acceptance data
+type TXAcceptanceDataMap map[externalapi.DomainTransactionID]*TXAcceptanceData
+
+// TXIDs is a set of transaction IDs
+type TXIDs map[externalapi.DomainTransactionID]interface{}
@@ -0,0 +1,28 @@
+package txindex
+
+import (
+	"testing"
+
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+)
+
+func Test_serializeTXAcceptanceData(t *testing.T) {
+	txAcceptanceData := &TXAcceptanceData{
+		IncludingBlockHash: externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{1}),
+		AcceptingBlockHash: externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{2}),
+	}
+	serialized := serializeTXAcceptanceData(txAcceptanceData)
+	result, err := deserializeTXAcceptanceData(serialized)
+	if err != nil {
+		t.Fatalf("Failed deserializing tx acceptance data: %v", err)
+	}
+	if !result.IncludingBlockHash.Equal(txAcceptanceData.IncludingBlockHash) ||
+		!result.AcceptingBlockHash.Equal(txAcceptanceData.AcceptingBlockHash) {
+		t.Fatalf("Expected %+v but got %+v", txAcceptanceData, result)
+	}
+
+	_, err = deserializeTXAcceptanceData(serialized[:len(serialized)-1])
+	if err == nil {
+		t.Fatalf("Expected deserializing truncated tx acceptance data to fail")
+	}
+}
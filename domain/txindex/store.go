@@ -0,0 +1,161 @@
+package txindex
+
+import (
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/infrastructure/db/database"
+	"github.com/zuanet/zuad/infrastructure/logger"
+	"github.com/pkg/errors"
+)
+
+var txIndexBucket = database.MakeBucket([]byte("tx-index"))
+var virtualSelectedParentKey = database.MakeBucket([]byte("")).Key([]byte("tx-index-virtual-selected-parent"))
+
+type txIndexStore struct {
+	database database.Database
+	toAdd    TXAcceptanceDataMap
+	toRemove TXIDs
+
+	virtualSelectedParent *externalapi.DomainHash
+}
+
+func newTXIndexStore(database database.Database) *txIndexStore {
+	return &txIndexStore{
+		database: database,
+		toAdd:    make(TXAcceptanceDataMap),
+		toRemove: make(TXIDs),
+	}
+}
+
+func (tis *txIndexStore) add(txID *externalapi.DomainTransactionID, txAcceptanceData *TXAcceptanceData) {
+	log.Tracef("Adding transaction %s accepted by block %s to the TX index",
+		txID, txAcceptanceData.AcceptingBlockHash)
+
+	delete(tis.toRemove, *txID)
+	tis.toAdd[*txID] = txAcceptanceData
+}
+
+func (tis *txIndexStore) remove(txID *externalapi.DomainTransactionID) {
+	log.Tracef("Removing transaction %s from the TX index", txID)
+
+	delete(tis.toAdd, *txID)
+	tis.toRemove[*txID] = struct{}{}
+}
+
+func (tis *txIndexStore) updateVirtualSelectedParent(virtualSelectedParent *externalapi.DomainHash) {
+	tis.virtualSelectedParent = virtualSelectedParent
+}
+
+func (tis *txIndexStore) discard() {
+	tis.toAdd = make(TXAcceptanceDataMap)
+	tis.toRemove = make(TXIDs)
+	tis.virtualSelectedParent = nil
+}
+
+func (tis *txIndexStore) commit() error {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "txIndexStore.commit")
+	defer onEnd()
+
+	dbTransaction, err := tis.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer dbTransaction.RollbackUnlessClosed()
+
+	for txID := range tis.toRemove {
+		err := dbTransaction.Delete(tis.txIDKey(&txID))
+		if err != nil {
+			return err
+		}
+	}
+
+	for txID, txAcceptanceData := range tis.toAdd {
+		err := dbTransaction.Put(tis.txIDKey(&txID), serializeTXAcceptanceData(txAcceptanceData))
+		if err != nil {
+			return err
+		}
+	}
+
+	if tis.virtualSelectedParent != nil {
+		err = dbTransaction.Put(virtualSelectedParentKey, tis.virtualSelectedParent.ByteSlice())
+		if err != nil {
+			return err
+		}
+	}
+
+	err = dbTransaction.Commit()
+	if err != nil {
+		return err
+	}
+
+	tis.discard()
+	return nil
+}
+
+func (tis *txIndexStore) txIDKey(txID *externalapi.DomainTransactionID) *database.Key {
+	return txIndexBucket.Key(txID.ByteSlice())
+}
+
+func (tis *txIndexStore) isAnythingStaged() bool {
+	return len(tis.toAdd) > 0 || len(tis.toRemove) > 0
+}
+
+func (tis *txIndexStore) getTXAcceptanceData(txID *externalapi.DomainTransactionID) (*TXAcceptanceData, bool, error) {
+	if tis.isAnythingStaged() {
+		return nil, false, errors.Errorf("cannot get tx acceptance data while staging isn't empty")
+	}
+
+	serializedTXAcceptanceData, err := tis.database.Get(tis.txIDKey(txID))
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	txAcceptanceData, err := deserializeTXAcceptanceData(serializedTXAcceptanceData)
+	if err != nil {
+		return nil, false, err
+	}
+	return txAcceptanceData, true, nil
+}
+
+func (tis *txIndexStore) getVirtualSelectedParent() (*externalapi.DomainHash, error) {
+	if tis.isAnythingStaged() {
+		return nil, errors.Errorf("cannot get the virtual selected parent while staging isn't empty")
+	}
+
+	serializedHash, err := tis.database.Get(virtualSelectedParentKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return externalapi.NewDomainHashFromByteSlice(serializedHash)
+}
+
+func (tis *txIndexStore) deleteAll() error {
+	// First we delete the virtual selected parent, so if anything goes wrong, the TX index will be marked
+	// as "not synced" and will be reset.
+	err := tis.database.Delete(virtualSelectedParentKey)
+	if err != nil {
+		return err
+	}
+
+	cursor, err := tis.database.Cursor(txIndexBucket)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return err
+		}
+
+		err = tis.database.Delete(key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
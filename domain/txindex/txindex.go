@@ -0,0 +1,196 @@
+package txindex
+
+import (
+	"sync"
+
+	"github.com/zuanet/zuad/domain"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/utils/consensushashing"
+	"github.com/zuanet/zuad/infrastructure/db/database"
+	"github.com/zuanet/zuad/infrastructure/logger"
+)
+
+// TXIndex maintains an index between the IDs of accepted transactions
+// and the blocks that included and accepted them.
+//
+// The index is built from the acceptance data of the virtual selected parent
+// chain and is kept in its own bucket, so entries of transactions that were
+// accepted below the pruning point remain available after it moves.
+type TXIndex struct {
+	domain domain.Domain
+	store  *txIndexStore
+
+	mutex sync.Mutex
+}
+
+// New creates a new TX index.
+//
+// NOTE: While this is called no new blocks can be added to the consensus.
+func New(domain domain.Domain, database database.Database) (*TXIndex, error) {
+	txIndex := &TXIndex{
+		domain: domain,
+		store:  newTXIndexStore(database),
+	}
+	isSynced, err := txIndex.isSynced()
+	if err != nil {
+		return nil, err
+	}
+
+	if !isSynced {
+		err := txIndex.Reset()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return txIndex, nil
+}
+
+// Reset deletes the whole TX index and resyncs it from the acceptance data
+// of the selected chain above the pruning point.
+func (ti *TXIndex) Reset() error {
+	ti.mutex.Lock()
+	defer ti.mutex.Unlock()
+
+	err := ti.store.deleteAll()
+	if err != nil {
+		return err
+	}
+
+	pruningPoint, err := ti.domain.Consensus().PruningPoint()
+	if err != nil {
+		return err
+	}
+
+	selectedChain, err := ti.domain.Consensus().GetVirtualSelectedParentChainFromBlock(pruningPoint)
+	if err != nil {
+		return err
+	}
+
+	for start := 0; start < len(selectedChain.Added); {
+		const step = 1000
+		end := start + step
+		if end > len(selectedChain.Added) {
+			end = len(selectedChain.Added)
+		}
+
+		err = ti.addAcceptedTransactions(selectedChain.Added[start:end])
+		if err != nil {
+			return err
+		}
+
+		err = ti.store.commit()
+		if err != nil {
+			return err
+		}
+
+		start = end
+	}
+
+	virtualSelectedParent := pruningPoint
+	if len(selectedChain.Added) > 0 {
+		virtualSelectedParent = selectedChain.Added[len(selectedChain.Added)-1]
+	}
+
+	// This has to be done last to mark that the reset went smoothly and no reset has to be called next time.
+	ti.store.updateVirtualSelectedParent(virtualSelectedParent)
+	return ti.store.commit()
+}
+
+func (ti *TXIndex) isSynced() (bool, error) {
+	txIndexVirtualSelectedParent, err := ti.store.getVirtualSelectedParent()
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	virtualSelectedParent, err := ti.domain.Consensus().GetVirtualSelectedParent()
+	if err != nil {
+		return false, err
+	}
+
+	return txIndexVirtualSelectedParent.Equal(virtualSelectedParent), nil
+}
+
+// Update updates the TX index with the given DAG selected parent chain changes
+func (ti *TXIndex) Update(virtualChangeSet *externalapi.VirtualChangeSet) error {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "TXIndex.Update")
+	defer onEnd()
+
+	ti.mutex.Lock()
+	defer ti.mutex.Unlock()
+
+	chainChanges := virtualChangeSet.VirtualSelectedParentChainChanges
+	if chainChanges == nil || len(chainChanges.Added) == 0 {
+		return nil
+	}
+
+	log.Tracef("Updating TX index with selected chain changes: %+v", chainChanges)
+	err := ti.removeAcceptedTransactions(chainChanges.Removed)
+	if err != nil {
+		return err
+	}
+
+	err = ti.addAcceptedTransactions(chainChanges.Added)
+	if err != nil {
+		return err
+	}
+
+	ti.store.updateVirtualSelectedParent(chainChanges.Added[len(chainChanges.Added)-1])
+	return ti.store.commit()
+}
+
+func (ti *TXIndex) addAcceptedTransactions(acceptingBlockHashes []*externalapi.DomainHash) error {
+	blocksAcceptanceData, err := ti.domain.Consensus().GetBlocksAcceptanceData(acceptingBlockHashes)
+	if err != nil {
+		return err
+	}
+
+	for i, acceptanceData := range blocksAcceptanceData {
+		for _, blockAcceptanceData := range acceptanceData {
+			for _, transactionAcceptanceData := range blockAcceptanceData.TransactionAcceptanceData {
+				if !transactionAcceptanceData.IsAccepted {
+					continue
+				}
+				ti.store.add(consensushashing.TransactionID(transactionAcceptanceData.Transaction), &TXAcceptanceData{
+					IncludingBlockHash: blockAcceptanceData.BlockHash,
+					AcceptingBlockHash: acceptingBlockHashes[i],
+				})
+			}
+		}
+	}
+	return nil
+}
+
+func (ti *TXIndex) removeAcceptedTransactions(acceptingBlockHashes []*externalapi.DomainHash) error {
+	blocksAcceptanceData, err := ti.domain.Consensus().GetBlocksAcceptanceData(acceptingBlockHashes)
+	if err != nil {
+		return err
+	}
+
+	for _, acceptanceData := range blocksAcceptanceData {
+		for _, blockAcceptanceData := range acceptanceData {
+			for _, transactionAcceptanceData := range blockAcceptanceData.TransactionAcceptanceData {
+				if !transactionAcceptanceData.IsAccepted {
+					continue
+				}
+				ti.store.remove(consensushashing.TransactionID(transactionAcceptanceData.Transaction))
+			}
+		}
+	}
+	return nil
+}
+
+// TXAcceptanceData returns the including and accepting blocks of the given transaction,
+// and whether the transaction was accepted by the virtual selected parent chain at all
+func (ti *TXIndex) TXAcceptanceData(txID *externalapi.DomainTransactionID) (*TXAcceptanceData, bool, error) {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "TXIndex.TXAcceptanceData")
+	defer onEnd()
+
+	ti.mutex.Lock()
+	defer ti.mutex.Unlock()
+
+	return ti.store.getTXAcceptanceData(txID)
+}
@@ -0,0 +1,114 @@
+package txindex_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/zuanet/zuad/domain"
+	"github.com/zuanet/zuad/domain/consensus"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/utils/consensushashing"
+	"github.com/zuanet/zuad/domain/consensus/utils/testutils"
+	"github.com/zuanet/zuad/domain/miningmanager/mempool"
+	"github.com/zuanet/zuad/domain/txindex"
+	"github.com/zuanet/zuad/infrastructure/db/database/ldb"
+)
+
+func TestTXIndex(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		dataDir, err := ioutil.TempDir("", fmt.Sprintf("TestTXIndex-%s", consensusConfig.Name))
+		if err != nil {
+			t.Fatalf("ioutil.TempDir: %+v", err)
+		}
+		defer os.RemoveAll(dataDir)
+
+		db, err := ldb.NewLevelDB(dataDir, 8)
+		if err != nil {
+			t.Fatalf("NewLevelDB: %+v", err)
+		}
+		defer db.Close()
+
+		domainInstance, err := domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), db)
+		if err != nil {
+			t.Fatalf("New: %+v", err)
+		}
+
+		txIndex, err := txindex.New(domainInstance, db)
+		if err != nil {
+			t.Fatalf("txindex.New: %+v", err)
+		}
+
+		addBlock := func() *externalapi.DomainBlock {
+			coinbaseData := &externalapi.DomainCoinbaseData{
+				ScriptPublicKey: &externalapi.ScriptPublicKey{},
+				ExtraData:       []byte{},
+			}
+			block, err := domainInstance.Consensus().BuildBlock(coinbaseData, nil)
+			if err != nil {
+				t.Fatalf("BuildBlock: %+v", err)
+			}
+			err = domainInstance.Consensus().ValidateAndInsertBlock(block, true)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertBlock: %+v", err)
+			}
+
+			for len(domainInstance.ConsensusEventsChannel()) > 0 {
+				virtualChangeSet, ok := (<-domainInstance.ConsensusEventsChannel()).(*externalapi.VirtualChangeSet)
+				if !ok {
+					continue
+				}
+				err = txIndex.Update(virtualChangeSet)
+				if err != nil {
+					t.Fatalf("Update: %+v", err)
+				}
+			}
+			return block
+		}
+
+		// Build the chain G <- A <- B. The coinbase transaction of A is accepted by B,
+		// while the coinbase transaction of B isn't accepted by any chain block yet.
+		blockA := addBlock()
+		blockB := addBlock()
+		blockAHash := consensushashing.BlockHash(blockA)
+		blockBHash := consensushashing.BlockHash(blockB)
+		coinbaseAID := consensushashing.TransactionID(blockA.Transactions[0])
+		coinbaseBID := consensushashing.TransactionID(blockB.Transactions[0])
+
+		checkIndex := func() {
+			txAcceptanceData, found, err := txIndex.TXAcceptanceData(coinbaseAID)
+			if err != nil {
+				t.Fatalf("TXAcceptanceData: %+v", err)
+			}
+			if !found {
+				t.Fatalf("Expected the coinbase transaction of block A to be found")
+			}
+			if !txAcceptanceData.IncludingBlockHash.Equal(blockAHash) {
+				t.Fatalf("Expected the including block to be %s but got %s",
+					blockAHash, txAcceptanceData.IncludingBlockHash)
+			}
+			if !txAcceptanceData.AcceptingBlockHash.Equal(blockBHash) {
+				t.Fatalf("Expected the accepting block to be %s but got %s",
+					blockBHash, txAcceptanceData.AcceptingBlockHash)
+			}
+
+			_, found, err = txIndex.TXAcceptanceData(coinbaseBID)
+			if err != nil {
+				t.Fatalf("TXAcceptanceData: %+v", err)
+			}
+			if found {
+				t.Fatalf("Expected the coinbase transaction of block B not to be found")
+			}
+		}
+
+		checkIndex()
+
+		// Rebuilding the index from the acceptance data of the selected chain should yield the same result
+		err = txIndex.Reset()
+		if err != nil {
+			t.Fatalf("Reset: %+v", err)
+		}
+		checkIndex()
+	})
+}
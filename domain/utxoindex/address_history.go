@@ -0,0 +1,145 @@
+package utxoindex
+
+import (
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/utils/consensushashing"
+	"github.com/zuanet/zuad/infrastructure/logger"
+	"github.com/pkg/errors"
+)
+
+// The address history is built from the acceptance data of the virtual selected parent chain.
+// Every chain block contributes an entry for each scriptPublicKey that one of its accepted
+// transactions paid to or spent from, and a reorg removes the entries of the chain blocks
+// that left the chain.
+//
+// Acceptance data is pruned along with the rest of the block data, so when the index is
+// reset it can only rebuild the history of the chain above the current pruning point. From
+// then on the history keeps growing, and entries are never removed when the pruning point
+// moves. That is, the history reaches back to the pruning point at the time the index was
+// last reset. The index is reset when the address history is first enabled, when the node
+// syncs a new pruning point UTXO set, and when it was not shut down cleanly.
+
+// AddressHistory returns the history of the given scriptPublicKey, ordered by DAA score and
+// starting from fromDAAScore. Up to limit entries are returned, unless the last DAA score has
+// more entries, in which case all of them are. nextDAAScore is the DAA score to pass to the
+// next call in order to get the next page, or 0 if there are no more entries.
+func (ui *UTXOIndex) AddressHistory(scriptPublicKey *externalapi.ScriptPublicKey, fromDAAScore uint64, limit int) (
+	entries []*AddressHistoryEntry, nextDAAScore uint64, err error) {
+
+	onEnd := logger.LogAndMeasureExecutionTime(log, "UTXOIndex.AddressHistory")
+	defer onEnd()
+
+	if !ui.isAddressHistoryEnabled {
+		return nil, 0, errors.Errorf("the address history is not enabled")
+	}
+
+	ui.mutex.Lock()
+	defer ui.mutex.Unlock()
+
+	return ui.store.getAddressHistory(scriptPublicKey, fromDAAScore, limit)
+}
+
+func (ui *UTXOIndex) resetAddressHistory() error {
+	pruningPoint, err := ui.domain.Consensus().PruningPoint()
+	if err != nil {
+		return err
+	}
+
+	selectedChain, err := ui.domain.Consensus().GetVirtualSelectedParentChainFromBlock(pruningPoint)
+	if err != nil {
+		return err
+	}
+
+	for _, acceptingBlockHash := range selectedChain.Added {
+		entries, err := ui.addressHistoryEntries(acceptingBlockHash)
+		if err != nil {
+			return err
+		}
+		for key, entry := range entries {
+			ui.store.addAddressHistoryEntry(key, entry)
+		}
+
+		err = ui.store.commitAddressHistoryWithoutVirtualParents()
+		if err != nil {
+			return err
+		}
+	}
+
+	return ui.store.markAddressHistoryEnabled()
+}
+
+func (ui *UTXOIndex) updateAddressHistory(chainChanges *externalapi.SelectedChainPath) error {
+	for _, removedBlockHash := range chainChanges.Removed {
+		entries, err := ui.addressHistoryEntries(removedBlockHash)
+		if err != nil {
+			return err
+		}
+		for key := range entries {
+			ui.store.removeAddressHistoryEntry(key)
+		}
+	}
+
+	for _, addedBlockHash := range chainChanges.Added {
+		entries, err := ui.addressHistoryEntries(addedBlockHash)
+		if err != nil {
+			return err
+		}
+		for key, entry := range entries {
+			ui.store.addAddressHistoryEntry(key, entry)
+		}
+	}
+
+	return nil
+}
+
+// addressHistoryEntries returns the address history entries of all the transactions
+// accepted by the given chain block
+func (ui *UTXOIndex) addressHistoryEntries(acceptingBlockHash *externalapi.DomainHash) (
+	map[addressHistoryKey]*AddressHistoryEntry, error) {
+
+	acceptingBlockHeader, err := ui.domain.Consensus().GetBlockHeader(acceptingBlockHash)
+	if err != nil {
+		return nil, err
+	}
+	acceptanceData, err := ui.domain.Consensus().GetBlockAcceptanceData(acceptingBlockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[addressHistoryKey]*AddressHistoryEntry)
+	entry := func(scriptPublicKey *externalapi.ScriptPublicKey,
+		transactionID *externalapi.DomainTransactionID) *AddressHistoryEntry {
+
+		key := addressHistoryKey{
+			scriptPublicKey: ScriptPublicKeyString(scriptPublicKey.String()),
+			daaScore:        acceptingBlockHeader.DAAScore(),
+			transactionID:   *transactionID,
+		}
+		if _, ok := entries[key]; !ok {
+			entries[key] = &AddressHistoryEntry{
+				TransactionID:      transactionID,
+				AcceptingBlockHash: acceptingBlockHash,
+				DAAScore:           acceptingBlockHeader.DAAScore(),
+			}
+		}
+		return entries[key]
+	}
+
+	for _, blockAcceptanceData := range acceptanceData {
+		for _, transactionAcceptanceData := range blockAcceptanceData.TransactionAcceptanceData {
+			if !transactionAcceptanceData.IsAccepted {
+				continue
+			}
+
+			transactionID := consensushashing.TransactionID(transactionAcceptanceData.Transaction)
+			for _, utxoEntry := range transactionAcceptanceData.TransactionInputUTXOEntries {
+				entry(utxoEntry.ScriptPublicKey(), transactionID).SpentAmount += utxoEntry.Amount()
+			}
+			for _, output := range transactionAcceptanceData.Transaction.Outputs {
+				entry(output.ScriptPublicKey, transactionID).ReceivedAmount += output.Value
+			}
+		}
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,168 @@
+package utxoindex
+
+import (
+	"encoding/binary"
+
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+var addressHistoryBucket = database.MakeBucket([]byte("utxo-index-address-history"))
+var addressHistoryEnabledKey = database.MakeBucket([]byte("")).Key([]byte("utxo-index-address-history-enabled"))
+
+const addressHistoryKeySuffixSize = 8 + externalapi.DomainHashSize
+
+func (uis *utxoIndexStore) addAddressHistoryEntry(key addressHistoryKey, entry *AddressHistoryEntry) {
+	log.Tracef("Adding transaction %s to the history of scriptPublicKey %s",
+		entry.TransactionID, key.scriptPublicKey)
+
+	delete(uis.addressHistoryToRemove, key)
+	uis.addressHistoryToAdd[key] = entry
+}
+
+func (uis *utxoIndexStore) removeAddressHistoryEntry(key addressHistoryKey) {
+	log.Tracef("Removing transaction %s from the history of scriptPublicKey %s",
+		key.transactionID, key.scriptPublicKey)
+
+	delete(uis.addressHistoryToAdd, key)
+	uis.addressHistoryToRemove[key] = struct{}{}
+}
+
+func (uis *utxoIndexStore) commitAddressHistory(dbTransaction database.Transaction) error {
+	for key := range uis.addressHistoryToRemove {
+		err := dbTransaction.Delete(uis.convertAddressHistoryKeyToDBKey(key))
+		if err != nil {
+			return err
+		}
+	}
+
+	for key, entry := range uis.addressHistoryToAdd {
+		err := dbTransaction.Put(uis.convertAddressHistoryKeyToDBKey(key), serializeAddressHistoryEntry(entry))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// commitAddressHistoryWithoutVirtualParents commits only the staged address history.
+// It's used while resetting the index, before its virtual parents are known.
+func (uis *utxoIndexStore) commitAddressHistoryWithoutVirtualParents() error {
+	dbTransaction, err := uis.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer dbTransaction.RollbackUnlessClosed()
+
+	err = uis.commitAddressHistory(dbTransaction)
+	if err != nil {
+		return err
+	}
+
+	err = dbTransaction.Commit()
+	if err != nil {
+		return err
+	}
+
+	uis.discard()
+	return nil
+}
+
+func (uis *utxoIndexStore) addressHistoryBucketForScriptPublicKey(
+	scriptPublicKey *externalapi.ScriptPublicKey) *database.Bucket {
+
+	return addressHistoryBucket.Bucket(scriptPublicKeyBucketName(scriptPublicKey))
+}
+
+// convertAddressHistoryKeyToDBKey serializes the DAA score as big endian, so
+// that the entries of a scriptPublicKey are iterated in the order of their DAA score
+func (uis *utxoIndexStore) convertAddressHistoryKeyToDBKey(key addressHistoryKey) *database.Key {
+	scriptPublicKey := externalapi.NewScriptPublicKeyFromString(string(key.scriptPublicKey))
+	suffix := make([]byte, addressHistoryKeySuffixSize)
+	binary.BigEndian.PutUint64(suffix[:8], key.daaScore)
+	copy(suffix[8:], key.transactionID.ByteSlice())
+	return uis.addressHistoryBucketForScriptPublicKey(scriptPublicKey).Key(suffix)
+}
+
+// getAddressHistory returns up to limit entries of the given scriptPublicKey whose DAA score is
+// at least fromDAAScore. A page never ends in the middle of a DAA score, so it may contain more
+// than limit entries. nextDAAScore is the DAA score the next page starts from, or 0 if there are
+// no more entries.
+func (uis *utxoIndexStore) getAddressHistory(scriptPublicKey *externalapi.ScriptPublicKey, fromDAAScore uint64,
+	limit int) (entries []*AddressHistoryEntry, nextDAAScore uint64, err error) {
+
+	if uis.isAnythingStaged() {
+		return nil, 0, errors.Errorf("cannot get the address history while staging isn't empty")
+	}
+
+	bucket := uis.addressHistoryBucketForScriptPublicKey(scriptPublicKey)
+	cursor, err := uis.database.Cursor(bucket)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close()
+
+	seekSuffix := make([]byte, 8)
+	binary.BigEndian.PutUint64(seekSuffix, fromDAAScore)
+	err = cursor.Seek(bucket.Key(seekSuffix))
+	if err != nil && !database.IsNotFoundError(err) {
+		return nil, 0, err
+	}
+
+	entries = make([]*AddressHistoryEntry, 0)
+	for hasEntry := true; hasEntry; hasEntry = cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			if database.IsNotFoundError(err) {
+				break
+			}
+			return nil, 0, err
+		}
+
+		// The bucket of a scriptPublicKey might prefix the bucket of another,
+		// longer scriptPublicKey. The keys of the latter are skipped.
+		suffix := key.Suffix()
+		if len(suffix) != addressHistoryKeySuffixSize {
+			continue
+		}
+
+		daaScore := binary.BigEndian.Uint64(suffix[:8])
+		if len(entries) >= limit && daaScore != entries[len(entries)-1].DAAScore {
+			return entries, daaScore, nil
+		}
+
+		transactionID, err := externalapi.NewDomainTransactionIDFromByteSlice(suffix[8:])
+		if err != nil {
+			return nil, 0, err
+		}
+		serializedEntry, err := cursor.Value()
+		if err != nil {
+			return nil, 0, err
+		}
+		entry, err := deserializeAddressHistoryEntry(serializedEntry, daaScore, transactionID)
+		if err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, 0, nil
+}
+
+func (uis *utxoIndexStore) isAddressHistoryEnabled() (bool, error) {
+	return uis.database.Has(addressHistoryEnabledKey)
+}
+
+func (uis *utxoIndexStore) markAddressHistoryEnabled() error {
+	return uis.database.Put(addressHistoryEnabledKey, []byte{})
+}
+
+func (uis *utxoIndexStore) deleteAddressHistory() error {
+	err := uis.database.Delete(addressHistoryEnabledKey)
+	if err != nil {
+		return err
+	}
+
+	return uis.deleteBucket(addressHistoryBucket)
+}
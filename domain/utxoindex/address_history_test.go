@@ -0,0 +1,133 @@
+package utxoindex_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/zuanet/zuad/domain"
+	"github.com/zuanet/zuad/domain/consensus"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	"github.com/zuanet/zuad/domain/consensus/utils/testutils"
+	"github.com/zuanet/zuad/domain/miningmanager/mempool"
+	"github.com/zuanet/zuad/domain/utxoindex"
+	"github.com/zuanet/zuad/infrastructure/db/database/ldb"
+)
+
+func TestAddressHistory(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		dataDir, err := ioutil.TempDir("", fmt.Sprintf("TestAddressHistory-%s", consensusConfig.Name))
+		if err != nil {
+			t.Fatalf("ioutil.TempDir: %+v", err)
+		}
+		defer os.RemoveAll(dataDir)
+
+		db, err := ldb.NewLevelDB(dataDir, 8)
+		if err != nil {
+			t.Fatalf("NewLevelDB: %+v", err)
+		}
+		defer db.Close()
+
+		domainInstance, err := domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), db)
+		if err != nil {
+			t.Fatalf("New: %+v", err)
+		}
+
+		utxoIndex, err := utxoindex.New(domainInstance, db, true)
+		if err != nil {
+			t.Fatalf("utxoindex.New: %+v", err)
+		}
+
+		scriptPublicKey := &externalapi.ScriptPublicKey{Script: []byte{1, 2, 3}, Version: 0}
+		addBlock := func() {
+			coinbaseData := &externalapi.DomainCoinbaseData{
+				ScriptPublicKey: scriptPublicKey,
+				ExtraData:       []byte{},
+			}
+			block, err := domainInstance.Consensus().BuildBlock(coinbaseData, nil)
+			if err != nil {
+				t.Fatalf("BuildBlock: %+v", err)
+			}
+			err = domainInstance.Consensus().ValidateAndInsertBlock(block, true)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertBlock: %+v", err)
+			}
+
+			for len(domainInstance.ConsensusEventsChannel()) > 0 {
+				virtualChangeSet, ok := (<-domainInstance.ConsensusEventsChannel()).(*externalapi.VirtualChangeSet)
+				if !ok {
+					continue
+				}
+				_, err = utxoIndex.Update(virtualChangeSet)
+				if err != nil {
+					t.Fatalf("Update: %+v", err)
+				}
+			}
+		}
+
+		const blockCount = 5
+		for i := 0; i < blockCount; i++ {
+			addBlock()
+		}
+
+		allEntries, nextDAAScore, err := utxoIndex.AddressHistory(scriptPublicKey, 0, 100)
+		if err != nil {
+			t.Fatalf("AddressHistory: %+v", err)
+		}
+		if nextDAAScore != 0 {
+			t.Fatalf("Expected no more entries but got nextDAAScore %d", nextDAAScore)
+		}
+		if len(allEntries) == 0 {
+			t.Fatalf("Expected the coinbase transactions to be in the address history")
+		}
+		for i, entry := range allEntries {
+			if entry.ReceivedAmount == 0 || entry.SpentAmount != 0 {
+				t.Fatalf("Unexpected amounts in entry %d: received %d, spent %d",
+					i, entry.ReceivedAmount, entry.SpentAmount)
+			}
+			if i > 0 && entry.DAAScore < allEntries[i-1].DAAScore {
+				t.Fatalf("The address history is not ordered by DAA score")
+			}
+		}
+
+		// Paging one entry at a time should yield the same entries
+		var pagedEntries []*utxoindex.AddressHistoryEntry
+		fromDAAScore := uint64(0)
+		for {
+			entries, nextDAAScore, err := utxoIndex.AddressHistory(scriptPublicKey, fromDAAScore, 1)
+			if err != nil {
+				t.Fatalf("AddressHistory: %+v", err)
+			}
+			pagedEntries = append(pagedEntries, entries...)
+			if nextDAAScore == 0 {
+				break
+			}
+			if nextDAAScore <= fromDAAScore {
+				t.Fatalf("Expected nextDAAScore to advance past %d but got %d", fromDAAScore, nextDAAScore)
+			}
+			fromDAAScore = nextDAAScore
+		}
+		if len(pagedEntries) != len(allEntries) {
+			t.Fatalf("Expected %d paged entries but got %d", len(allEntries), len(pagedEntries))
+		}
+		for i := range allEntries {
+			if !pagedEntries[i].TransactionID.Equal(allEntries[i].TransactionID) {
+				t.Fatalf("Paged entry %d differs from the unpaged one", i)
+			}
+		}
+
+		// Rebuilding the history from the acceptance data of the selected chain should yield the same result
+		err = utxoIndex.Reset()
+		if err != nil {
+			t.Fatalf("Reset: %+v", err)
+		}
+		resetEntries, _, err := utxoIndex.AddressHistory(scriptPublicKey, 0, 100)
+		if err != nil {
+			t.Fatalf("AddressHistory: %+v", err)
+		}
+		if len(resetEntries) != len(allEntries) {
+			t.Fatalf("Expected %d entries after reset but got %d", len(allEntries), len(resetEntries))
+		}
+	})
+}
@@ -15,6 +15,25 @@ type UTXOOutpointEntryPairs map[externalapi.DomainOutpoint]externalapi.UTXOEntry
 // UTXOOutpoints is a set of UTXO outpoints
 type UTXOOutpoints map[externalapi.DomainOutpoint]interface{}
 
+// AddressHistoryEntry is a transaction that was accepted by the virtual selected parent
+// chain and that paid to or spent from some scriptPublicKey. The amounts are the total
+// amounts the transaction paid to and spent from that scriptPublicKey.
+type AddressHistoryEntry struct {
+	TransactionID      *externalapi.DomainTransactionID
+	AcceptingBlockHash *externalapi.DomainHash
+	DAAScore           uint64
+	ReceivedAmount     uint64
+	SpentAmount        uint64
+}
+
+// addressHistoryKey identifies an AddressHistoryEntry of a scriptPublicKey.
+// Entries are ordered by the DAA score of their accepting block.
+type addressHistoryKey struct {
+	scriptPublicKey ScriptPublicKeyString
+	daaScore        uint64
+	transactionID   externalapi.DomainTransactionID
+}
+
 // UTXOChanges is the set of changes made to the UTXO index after
 // a successful update
 type UTXOChanges struct {
@@ -70,3 +70,35 @@ func deserializeHashes(serializedHashes []byte) ([]*externalapi.DomainHash, erro
 
 	return hashes, nil
 }
+
+const serializedAddressHistoryEntrySize = externalapi.DomainHashSize + 8 + 8
+
+func serializeAddressHistoryEntry(entry *AddressHistoryEntry) []byte {
+	serializedEntry := make([]byte, serializedAddressHistoryEntrySize)
+	copy(serializedEntry[:externalapi.DomainHashSize], entry.AcceptingBlockHash.ByteSlice())
+	binary.LittleEndian.PutUint64(serializedEntry[externalapi.DomainHashSize:], entry.ReceivedAmount)
+	binary.LittleEndian.PutUint64(serializedEntry[externalapi.DomainHashSize+8:], entry.SpentAmount)
+	return serializedEntry
+}
+
+func deserializeAddressHistoryEntry(serializedEntry []byte, daaScore uint64,
+	transactionID *externalapi.DomainTransactionID) (*AddressHistoryEntry, error) {
+
+	if len(serializedEntry) != serializedAddressHistoryEntrySize {
+		return nil, errors.Wrapf(io.ErrUnexpectedEOF, "serialized address history entry is of size %d "+
+			"while %d is expected", len(serializedEntry), serializedAddressHistoryEntrySize)
+	}
+
+	acceptingBlockHash, err := externalapi.NewDomainHashFromByteSlice(serializedEntry[:externalapi.DomainHashSize])
+	if err != nil {
+		return nil, err
+	}
+
+	return &AddressHistoryEntry{
+		TransactionID:      transactionID,
+		AcceptingBlockHash: acceptingBlockHash,
+		DAAScore:           daaScore,
+		ReceivedAmount:     binary.LittleEndian.Uint64(serializedEntry[externalapi.DomainHashSize:]),
+		SpentAmount:        binary.LittleEndian.Uint64(serializedEntry[externalapi.DomainHashSize+8:]),
+	}, nil
+}
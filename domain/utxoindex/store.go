@@ -19,6 +19,9 @@ type utxoIndexStore struct {
 	toAdd    map[ScriptPublicKeyString]UTXOOutpointEntryPairs
 	toRemove map[ScriptPublicKeyString]UTXOOutpointEntryPairs
 
+	addressHistoryToAdd    map[addressHistoryKey]*AddressHistoryEntry
+	addressHistoryToRemove map[addressHistoryKey]struct{}
+
 	virtualParents []*externalapi.DomainHash
 }
 
@@ -27,6 +30,9 @@ func newUTXOIndexStore(database database.Database) *utxoIndexStore {
 		database: database,
 		toAdd:    make(map[ScriptPublicKeyString]UTXOOutpointEntryPairs),
 		toRemove: make(map[ScriptPublicKeyString]UTXOOutpointEntryPairs),
+
+		addressHistoryToAdd:    make(map[addressHistoryKey]*AddressHistoryEntry),
+		addressHistoryToRemove: make(map[addressHistoryKey]struct{}),
 	}
 }
 
@@ -106,6 +112,8 @@ func (uis *utxoIndexStore) updateVirtualParents(virtualParents []*externalapi.Do
 func (uis *utxoIndexStore) discard() {
 	uis.toAdd = make(map[ScriptPublicKeyString]UTXOOutpointEntryPairs)
 	uis.toRemove = make(map[ScriptPublicKeyString]UTXOOutpointEntryPairs)
+	uis.addressHistoryToAdd = make(map[addressHistoryKey]*AddressHistoryEntry)
+	uis.addressHistoryToRemove = make(map[addressHistoryKey]struct{})
 	uis.virtualParents = nil
 }
 
@@ -159,6 +167,11 @@ func (uis *utxoIndexStore) commit() error {
 		}
 	}
 
+	err = uis.commitAddressHistory(dbTransaction)
+	if err != nil {
+		return err
+	}
+
 	serializeParentHashes := serializeHashes(uis.virtualParents)
 	err = dbTransaction.Put(virtualParentsKey, serializeParentHashes)
 	if err != nil {
@@ -214,10 +227,14 @@ func (uis *utxoIndexStore) updateAndCommitVirtualParentsWithoutTransaction(virtu
 }
 
 func (uis *utxoIndexStore) bucketForScriptPublicKey(scriptPublicKey *externalapi.ScriptPublicKey) *database.Bucket {
+	return utxoIndexBucket.Bucket(scriptPublicKeyBucketName(scriptPublicKey))
+}
+
+func scriptPublicKeyBucketName(scriptPublicKey *externalapi.ScriptPublicKey) []byte {
 	var scriptPublicKeyBytes = make([]byte, 2+len(scriptPublicKey.Script)) // uint16
 	binary.LittleEndian.PutUint16(scriptPublicKeyBytes[:2], scriptPublicKey.Version)
 	copy(scriptPublicKeyBytes[2:], scriptPublicKey.Script)
-	return utxoIndexBucket.Bucket(scriptPublicKeyBytes)
+	return scriptPublicKeyBytes
 }
 
 func (uis *utxoIndexStore) convertOutpointToKey(bucket *database.Bucket, outpoint *externalapi.DomainOutpoint) (*database.Key, error) {
@@ -260,7 +277,8 @@ func (uis *utxoIndexStore) stagedData() (
 }
 
 func (uis *utxoIndexStore) isAnythingStaged() bool {
-	return len(uis.toAdd) > 0 || len(uis.toRemove) > 0
+	return len(uis.toAdd) > 0 || len(uis.toRemove) > 0 ||
+		len(uis.addressHistoryToAdd) > 0 || len(uis.addressHistoryToRemove) > 0
 }
 
 func (uis *utxoIndexStore) getUTXOOutpointEntryPairs(scriptPublicKey *externalapi.ScriptPublicKey) (UTXOOutpointEntryPairs, error) {
@@ -323,7 +341,16 @@ func (uis *utxoIndexStore) deleteAll() error {
 		return err
 	}
 
-	cursor, err := uis.database.Cursor(utxoIndexBucket)
+	err = uis.deleteBucket(utxoIndexBucket)
+	if err != nil {
+		return err
+	}
+
+	return uis.deleteAddressHistory()
+}
+
+func (uis *utxoIndexStore) deleteBucket(bucket *database.Bucket) error {
+	cursor, err := uis.database.Cursor(bucket)
 	if err != nil {
 		return err
 	}
@@ -9,21 +9,25 @@ import (
 )
 
 // UTXOIndex maintains an index between transaction scriptPublicKeys
-// and UTXOs
+// and UTXOs, and optionally the history of the transactions that paid
+// to or spent from them
 type UTXOIndex struct {
-	domain domain.Domain
-	store  *utxoIndexStore
+	domain                  domain.Domain
+	store                   *utxoIndexStore
+	isAddressHistoryEnabled bool
 
 	mutex sync.Mutex
 }
 
-// New creates a new UTXO index.
+// New creates a new UTXO index. If isAddressHistoryEnabled is set, the
+// index also maintains the address history. See AddressHistory.
 //
 // NOTE: While this is called no new blocks can be added to the consensus.
-func New(domain domain.Domain, database database.Database) (*UTXOIndex, error) {
+func New(domain domain.Domain, database database.Database, isAddressHistoryEnabled bool) (*UTXOIndex, error) {
 	utxoIndex := &UTXOIndex{
-		domain: domain,
-		store:  newUTXOIndexStore(database),
+		domain:                  domain,
+		store:                   newUTXOIndexStore(database),
+		isAddressHistoryEnabled: isAddressHistoryEnabled,
 	}
 	isSynced, err := utxoIndex.isSynced()
 	if err != nil {
@@ -62,6 +66,13 @@ func (ui *UTXOIndex) Reset() error {
 		return err
 	}
 
+	if ui.isAddressHistoryEnabled {
+		err = ui.resetAddressHistory()
+		if err != nil {
+			return err
+		}
+	}
+
 	err = ui.store.initializeCirculatingSompiSupply() //At this point the database is empty, so the sole purpose of this call is to initialize the circulating supply key
 	if err != nil {
 		return err
@@ -100,6 +111,16 @@ func (ui *UTXOIndex) isSynced() (bool, error) {
 		return false, err
 	}
 
+	// Enabling or disabling the address history requires a reset, so that
+	// the history never has gaps
+	isAddressHistoryEnabled, err := ui.store.isAddressHistoryEnabled()
+	if err != nil {
+		return false, err
+	}
+	if isAddressHistoryEnabled != ui.isAddressHistoryEnabled {
+		return false, nil
+	}
+
 	virtualInfo, err := ui.domain.Consensus().GetVirtualInfo()
 	if err != nil {
 		return false, err
@@ -127,6 +148,13 @@ func (ui *UTXOIndex) Update(virtualChangeSet *externalapi.VirtualChangeSet) (*UT
 		return nil, err
 	}
 
+	if ui.isAddressHistoryEnabled && virtualChangeSet.VirtualSelectedParentChainChanges != nil {
+		err = ui.updateAddressHistory(virtualChangeSet.VirtualSelectedParentChainChanges)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	ui.store.updateVirtualParents(virtualChangeSet.VirtualParents)
 
 	added, removed, _ := ui.store.stagedData()
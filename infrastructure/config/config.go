@@ -6,7 +6,9 @@ package config
 
 import (
 	// _ "embed" is necessary for the go:embed feature.
+	"crypto/ed25519"
 	_ "embed"
+	"encoding/hex"
 	"fmt"
 	"net"
 	"os"
@@ -18,6 +20,7 @@ import (
 
 	"github.com/btcsuite/go-socks/socks"
 	"github.com/jessevdk/go-flags"
+	"github.com/zuanet/zuad/app/appmessage"
 	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
 	"github.com/zuanet/zuad/domain/dagconfig"
 	"github.com/zuanet/zuad/infrastructure/logger"
@@ -53,7 +56,7 @@ const (
 	defaultSigCacheMaxSize  = 100_000
 	sampleConfigFilename    = "sample-zuad.conf"
 	defaultMaxUTXOCacheSize = 5_000_000_000
-	defaultProtocolVersion  = 5
+	defaultProtocolVersion  = 6
 )
 
 var (
@@ -82,20 +85,22 @@ type Flags struct {
 	ConfigFile                      string        `short:"C" long:"configfile" description:"Path to configuration file"`
 	AppDir                          string        `short:"b" long:"appdir" description:"Directory to store data"`
 	LogDir                          string        `long:"logdir" description:"Directory to log output."`
-	AddPeers                        []string      `short:"a" long:"addpeer" description:"Add a peer to connect with at startup"`
-	ConnectPeers                    []string      `long:"connect" description:"Connect only to the specified peers at startup"`
+	AddPeers                        []string      `short:"a" long:"addpeer" description:"Add a peer to connect with at startup -- Use identitykey@address to only accept the peer if it proves to hold the given P2P identity key"`
+	ConnectPeers                    []string      `long:"connect" description:"Connect only to the specified peers at startup -- Use identitykey@address to only accept the peer if it proves to hold the given P2P identity key"`
 	DisableListen                   bool          `long:"nolisten" description:"Disable listening for incoming connections -- NOTE: Listening is automatically disabled if the --connect or --proxy options are used without also specifying listen interfaces via --listen"`
 	Listeners                       []string      `long:"listen" description:"Add an interface/port to listen for connections (default all interfaces port: 46009, testnet: 46209)"`
 	TargetOutboundPeers             int           `long:"outpeers" description:"Target number of outbound peers"`
 	MaxInboundPeers                 int           `long:"maxinpeers" description:"Max number of inbound peers"`
 	EnableBanning                   bool          `long:"enablebanning" description:"Enable banning of misbehaving peers"`
 	BanDuration                     time.Duration `long:"banduration" description:"How long to ban misbehaving peers. Valid time units are {s, m, h}. Minimum 1 second"`
-	BanThreshold                    uint32        `long:"banthreshold" description:"Maximum allowed ban score before disconnecting and banning misbehaving peers."`
+	BanThreshold                    uint32        `long:"banthreshold" description:"Maximum allowed misbehavior score before banning misbehaving peers. Peers are deprioritized at a quarter of it and disconnected at half of it. Misbehavior scores decay by half every 10 minutes"`
 	Whitelists                      []string      `long:"whitelist" description:"Add an IP network or IP that will not be banned. (eg. 192.168.1.0/24 or ::1)"`
 	RPCListeners                    []string      `long:"rpclisten" description:"Add an interface/port to listen for RPC connections (default port: 46005, testnet: 46205)"`
 	RPCCert                         string        `long:"rpccert" description:"File containing the certificate file"`
 	RPCKey                          string        `long:"rpckey" description:"File containing the certificate key"`
 	RPCMaxClients                   int           `long:"rpcmaxclients" description:"Max number of RPC clients for standard connections"`
+	JSONRPCListeners                []string      `long:"jsonrpclisten" description:"Add an interface/port to listen for JSON-RPC connections over HTTP and WebSocket (default port: 46006, testnet: 16212). JSON-RPC is disabled unless at least one is given"`
+	JSONRPCAllowedOrigins           []string      `long:"jsonrpcallowedorigin" description:"Add an origin (eg. https://example.com) from which web pages are allowed to access JSON-RPC. Browser requests from any other origin are rejected"`
 	RPCMaxWebsockets                int           `long:"rpcmaxwebsockets" description:"Max number of RPC websocket connections"`
 	RPCMaxConcurrentReqs            int           `long:"rpcmaxconcurrentreqs" description:"Max number of concurrent RPC requests that may be processed concurrently"`
 	DisableRPC                      bool          `long:"norpc" description:"Disable built-in RPC server"`
@@ -107,12 +112,21 @@ type Flags struct {
 	Proxy                           string        `long:"proxy" description:"Connect via SOCKS5 proxy (eg. 127.0.0.1:9050)"`
 	ProxyUser                       string        `long:"proxyuser" description:"Username for proxy server"`
 	ProxyPass                       string        `long:"proxypass" default-mask:"-" description:"Password for proxy server"`
+	OnionProxy                      string        `long:"onion" description:"Connect to Tor onion services via SOCKS5 proxy (eg. 127.0.0.1:9050) -- Defaults to --proxy"`
+	OnionProxyUser                  string        `long:"onionuser" description:"Username for onion proxy server"`
+	OnionProxyPass                  string        `long:"onionpass" default-mask:"-" description:"Password for onion proxy server"`
+	NoOnion                         bool          `long:"noonion" description:"Disable connecting to Tor onion services"`
+	TorControl                      string        `long:"torcontrol" description:"Create an onion service for incoming connections through the control port of a Tor process (eg. 127.0.0.1:9051)"`
+	TorPassword                     string        `long:"torpassword" default-mask:"-" description:"Password for the Tor control port, if it's protected by a hashed password"`
+	NoP2PEncryption                 bool          `long:"nop2pencryption" description:"Do not offer encryption to peers, so that all P2P connections are unencrypted"`
+	RequireP2PEncryption            bool          `long:"requirep2pencryption" description:"Disconnect from peers that don't support encrypted P2P connections"`
 	DbType                          string        `long:"dbtype" description:"Database backend to use for the Block DAG"`
 	Profile                         string        `long:"profile" description:"Enable HTTP profiling on given port -- NOTE port must be between 1024 and 65536"`
 	LogLevel                        string        `short:"d" long:"loglevel" description:"Logging level for all subsystems {trace, debug, info, warn, error, critical} -- You may also specify <subsystem>=<level>,<subsystem2>=<level>,... to set the log level for individual subsystems -- Use show to list available subsystems"`
 	Upnp                            bool          `long:"upnp" description:"Use UPnP to map our listening port outside of NAT"`
 	MinRelayTxFee                   float64       `long:"minrelaytxfee" description:"The minimum transaction fee in ZUA/kB to be considered a non-zero fee."`
 	MaxOrphanTxs                    uint64        `long:"maxorphantx" description:"Max number of orphan transactions to keep in memory"`
+	DisableReplaceByFee             bool          `long:"norbf" description:"Disable replacing mempool transactions with transactions that double spend them and pay a higher fee"`
 	BlockMaxMass                    uint64        `long:"blockmaxmass" description:"Maximum transaction mass to be used when creating a block"`
 	UserAgentComments               []string      `long:"uacomment" description:"Comment to add to the user agent -- See BIP 14 for more information."`
 	NoPeerBloomFilters              bool          `long:"nopeerbloomfilters" description:"Disable bloom filtering support"`
@@ -123,6 +137,9 @@ type Flags struct {
 	ResetDatabase                   bool          `long:"reset-db" description:"Reset database before starting node. It's needed when switching between subnetworks."`
 	MaxUTXOCacheSize                uint64        `long:"maxutxocachesize" description:"Max size of loaded UTXO into ram from the disk in bytes"`
 	UTXOIndex                       bool          `long:"utxoindex" description:"Enable the UTXO index"`
+	TXIndex                         bool          `long:"txindex" description:"Enable the transaction index, which maps accepted transaction IDs to the blocks that included and accepted them"`
+	AddressHistoryIndex             bool          `long:"addresshistoryindex" description:"Enable the address history index, which maps addresses to the accepted transactions that paid to or spent from them (requires --utxoindex)"`
+	PersistMempool                  bool          `long:"persistmempool" description:"Save the mempool to the database on shutdown and periodically, and restore it on startup"`
 	IsArchivalNode                  bool          `long:"archival" description:"Run as an archival node: don't delete old block data when moving the pruning point (Warning: heavy disk usage)'"`
 	AllowSubmitBlockWhenNotSynced   bool          `long:"allow-submit-block-when-not-synced" hidden:"true" description:"Allow the node to accept blocks from RPC while not synced (this flag is mainly used for testing)"`
 	EnableSanityCheckPruningUTXOSet bool          `long:"enable-sanity-check-pruning-utxo" hidden:"true" description:"When moving the pruning point - check that the utxo set matches the utxo commitment"`
@@ -142,6 +159,17 @@ type Config struct {
 	MinRelayTxFee util.Amount
 	Whitelists    []*net.IPNet
 	SubnetworkID  *externalapi.DomainSubnetworkID // nil in full nodes
+
+	// PinnedP2PIdentityKeys are the identity keys of the peers given to
+	// --addpeer or --connect as identitykey@address
+	PinnedP2PIdentityKeys []*PinnedP2PIdentityKey
+}
+
+// PinnedP2PIdentityKey is the identity key a peer must prove to hold on
+// connections to its address
+type PinnedP2PIdentityKey struct {
+	Address     *appmessage.NetAddress
+	IdentityKey []byte
 }
 
 // ServiceOptions defines the configuration options for the daemon as a service on
@@ -374,6 +402,15 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	// A zero ban threshold would ban peers for any misbehavior, however minor.
+	if cfg.BanThreshold == 0 {
+		str := "%s: The banthreshold option may not be zero"
+		err := errors.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
 	// Validate any given whitelisted IP addresses and networks.
 	if len(cfg.Whitelists) > 0 {
 		var ip net.IP
@@ -416,9 +453,48 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
-	// --proxy or --connect without --listen disables listening.
+	// --addresshistoryindex extends the UTXO index, so it can't be used without it.
+	if cfg.AddressHistoryIndex && !cfg.UTXOIndex {
+		str := "%s: the --addresshistoryindex option requires --utxoindex"
+		err := errors.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// --nop2pencryption and --requirep2pencryption contradict each other.
+	if cfg.NoP2PEncryption && cfg.RequireP2PEncryption {
+		str := "%s: the --nop2pencryption and --requirep2pencryption options can not be " +
+			"mixed"
+		err := errors.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// --onion and --noonion contradict each other.
+	if cfg.OnionProxy != "" && cfg.NoOnion {
+		str := "%s: the --onion and --noonion options can not be mixed"
+		err := errors.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// The onion service created through --torcontrol forwards incoming
+	// connections to our P2P listener.
+	if cfg.TorControl != "" && cfg.DisableListen {
+		str := "%s: the --torcontrol and --nolisten options can not be mixed"
+		err := errors.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// --proxy or --connect without --listen disables listening, unless
+	// incoming connections are accepted through an onion service.
 	if (cfg.Proxy != "" || len(cfg.ConnectPeers) > 0) &&
-		len(cfg.Listeners) == 0 {
+		len(cfg.Listeners) == 0 && cfg.TorControl == "" {
 		cfg.DisableListen = true
 	}
 
@@ -450,6 +526,14 @@ func LoadConfig() (*Config, error) {
 		}
 	}
 
+	if cfg.DisableRPC && len(cfg.JSONRPCListeners) > 0 {
+		str := "%s: --jsonrpclisten and --norpc can not be used together"
+		err := errors.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
 	if cfg.RPCMaxConcurrentReqs < 0 {
 		str := "%s: The rpcmaxwebsocketconcurrentrequests option may " +
 			"not be less than 0 -- parsed [%d]"
@@ -519,6 +603,14 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	// Add default port to all JSON-RPC listener addresses if needed and
+	// remove duplicate addresses.
+	cfg.JSONRPCListeners, err = network.NormalizeAddresses(cfg.JSONRPCListeners,
+		cfg.NetParams().JSONRPCPort)
+	if err != nil {
+		return nil, err
+	}
+
 	// Disallow --addpeer and --connect used together
 	if len(cfg.AddPeers) > 0 && len(cfg.ConnectPeers) > 0 {
 		str := "%s: --addpeer and --connect can not be used together"
@@ -530,18 +622,41 @@ func LoadConfig() (*Config, error) {
 
 	// Add default port to all added peer addresses if needed and remove
 	// duplicate addresses.
+	cfg.AddPeers, err = cfg.extractPinnedP2PIdentityKeys(cfg.AddPeers)
+	if err != nil {
+		err := errors.Errorf("%s: invalid --addpeer: %s", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
 	cfg.AddPeers, err = network.NormalizeAddresses(cfg.AddPeers,
 		cfg.NetParams().DefaultPort)
 	if err != nil {
 		return nil, err
 	}
 
+	cfg.ConnectPeers, err = cfg.extractPinnedP2PIdentityKeys(cfg.ConnectPeers)
+	if err != nil {
+		err := errors.Errorf("%s: invalid --connect: %s", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
 	cfg.ConnectPeers, err = network.NormalizeAddresses(cfg.ConnectPeers,
 		cfg.NetParams().DefaultPort)
 	if err != nil {
 		return nil, err
 	}
 
+	// Identity keys can only be checked on encrypted connections
+	if cfg.NoP2PEncryption && len(cfg.PinnedP2PIdentityKeys) > 0 {
+		str := "%s: P2P identity keys can not be pinned with --nop2pencryption"
+		err := errors.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
 	// Setup dial and DNS resolution (lookup) functions depending on the
 	// specified options. The default is to use the standard
 	// net.DialTimeout function as well as the system DNS resolver. When a
@@ -567,6 +682,54 @@ func LoadConfig() (*Config, error) {
 		cfg.Dial = proxy.DialTimeout
 	}
 
+	// Onion addresses are dialed through the onion proxy, which defaults
+	// to the regular proxy. Without either, onion addresses aren't dialed
+	// at all, since they can only be reached through Tor.
+	if cfg.OnionProxy == "" && cfg.Proxy != "" && !cfg.NoOnion {
+		cfg.OnionProxy = cfg.Proxy
+		cfg.OnionProxyUser = cfg.ProxyUser
+		cfg.OnionProxyPass = cfg.ProxyPass
+	}
+	onionDial := func(string, string, time.Duration) (net.Conn, error) {
+		return nil, errors.New("onion addresses can only be dialed through a proxy - see --onion")
+	}
+	if cfg.OnionProxy != "" {
+		_, _, err := net.SplitHostPort(cfg.OnionProxy)
+		if err != nil {
+			str := "%s: Onion proxy address '%s' is invalid: %s"
+			err := errors.Errorf(str, funcName, cfg.OnionProxy, err)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, err
+		}
+
+		onionProxy := &socks.Proxy{
+			Addr:     cfg.OnionProxy,
+			Username: cfg.OnionProxyUser,
+			Password: cfg.OnionProxyPass,
+		}
+		onionDial = onionProxy.DialTimeout
+	}
+	directDial := cfg.Dial
+	cfg.Dial = func(network string, address string, timeout time.Duration) (net.Conn, error) {
+		host, _, err := net.SplitHostPort(address)
+		if err == nil && strings.HasSuffix(strings.ToLower(host), ".onion") {
+			return onionDial(network, address, timeout)
+		}
+		return directDial(network, address, timeout)
+	}
+
+	if cfg.TorControl != "" {
+		_, _, err := net.SplitHostPort(cfg.TorControl)
+		if err != nil {
+			str := "%s: Tor control address '%s' is invalid: %s"
+			err := errors.Errorf(str, funcName, cfg.TorControl, err)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, err
+		}
+	}
+
 	// Warn about missing config file only after all other configuration is
 	// done. This prevents the warning on help messages and invalid
 	// options. Note this should go directly before the return.
@@ -576,6 +739,55 @@ func LoadConfig() (*Config, error) {
 	return cfg, nil
 }
 
+// extractPinnedP2PIdentityKeys strips the identity keys off the given peers
+// that are given as identitykey@address, and adds them to the pinned identity
+// keys of the config. It returns the addresses of all the peers.
+func (cfg *Config) extractPinnedP2PIdentityKeys(peers []string) ([]string, error) {
+	addresses := make([]string, len(peers))
+	for i, peer := range peers {
+		separatorIndex := strings.Index(peer, "@")
+		if separatorIndex == -1 {
+			addresses[i] = peer
+			continue
+		}
+
+		identityKey, err := hex.DecodeString(peer[:separatorIndex])
+		if err != nil {
+			return nil, errors.Wrapf(err, "the identity key of %s is malformed", peer)
+		}
+		if len(identityKey) != ed25519.PublicKeySize {
+			return nil, errors.Errorf("the identity key of %s is %d bytes long, but must be %d bytes long",
+				peer, len(identityKey), ed25519.PublicKeySize)
+		}
+
+		address, err := network.NormalizeAddress(peer[separatorIndex+1:], cfg.NetParams().DefaultPort)
+		if err != nil {
+			return nil, err
+		}
+		host, portString, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+		port, err := strconv.ParseUint(portString, 10, 16)
+		if err != nil {
+			return nil, errors.Wrapf(err, "the port of %s is malformed", peer)
+		}
+		// Identity keys are looked up by the address a connection is made to,
+		// so they can't be pinned to names that resolve to other addresses
+		netAddress, err := appmessage.ParseNetAddress(host, uint16(port))
+		if err != nil {
+			return nil, errors.Wrapf(err, "can not pin the identity key of %s", peer)
+		}
+
+		cfg.PinnedP2PIdentityKeys = append(cfg.PinnedP2PIdentityKeys, &PinnedP2PIdentityKey{
+			Address:     netAddress,
+			IdentityKey: identityKey,
+		})
+		addresses[i] = address
+	}
+	return addresses, nil
+}
+
 // createDefaultConfig copies the file sample-zuad.conf to the given destination path,
 // and populates it with some randomly generated RPC username and password.
 func createDefaultConfigFile(destinationPath string) error {
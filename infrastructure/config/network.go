@@ -33,6 +33,9 @@ type overrideDAGParamsConfig struct {
 	MassPerTxByte                           *uint64            `json:"massPerTxByte"`
 	MassPerScriptPubKeyByte                 *uint64            `json:"massPerScriptPubKeyByte"`
 	MassPerSigOp                            *uint64            `json:"massPerSigOp"`
+	MaxVProgGasLimit                        *uint64            `json:"maxVProgGasLimit"`
+	MaxBlockVProgGas                        *uint64            `json:"maxBlockVProgGas"`
+	VProgGasPerMass                         *uint64            `json:"vprogGasPerMass"`
 	CoinbasePayloadScriptPublicKeyMaxLength *uint8             `json:"coinbasePayloadScriptPublicKeyMaxLength"`
 	PowMax                                  *string            `json:"powMax"`
 	BlockCoinbaseMaturity                   *uint64            `json:"blockCoinbaseMaturity"`
@@ -46,6 +49,7 @@ type overrideDAGParamsConfig struct {
 	RelayNonStdTxs                          *bool              `json:"relayNonStdTxs"`
 	AcceptUnroutable                        *bool              `json:"acceptUnroutable"`
 	EnableNonNativeSubnetworks              *bool              `json:"enableNonNativeSubnetworks"`
+	EnableVProgs                            *bool              `json:"enableVProgs"`
 	DisableDifficultyAdjustment             *bool              `json:"disableDifficultyAdjustment"`
 	SkipProofOfWork                         *bool              `json:"skipProofOfWork"`
 	HardForkOmitGenesisFromParentsDAAScore  *uint64            `json:"hardForkOmitGenesisFromParentsDaaScore"`
@@ -150,6 +154,18 @@ func (networkFlags *NetworkFlags) overrideDAGParams() error {
 		networkFlags.ActiveNetParams.MassPerSigOp = *config.MassPerSigOp
 	}
 
+	if config.MaxVProgGasLimit != nil {
+		networkFlags.ActiveNetParams.MaxVProgGasLimit = *config.MaxVProgGasLimit
+	}
+
+	if config.MaxBlockVProgGas != nil {
+		networkFlags.ActiveNetParams.MaxBlockVProgGas = *config.MaxBlockVProgGas
+	}
+
+	if config.VProgGasPerMass != nil {
+		networkFlags.ActiveNetParams.VProgGasPerMass = *config.VProgGasPerMass
+	}
+
 	if config.CoinbasePayloadScriptPublicKeyMaxLength != nil {
 		networkFlags.ActiveNetParams.CoinbasePayloadScriptPublicKeyMaxLength = *config.CoinbasePayloadScriptPublicKeyMaxLength
 	}
@@ -209,6 +225,10 @@ func (networkFlags *NetworkFlags) overrideDAGParams() error {
 		networkFlags.ActiveNetParams.EnableNonNativeSubnetworks = *config.EnableNonNativeSubnetworks
 	}
 
+	if config.EnableVProgs != nil {
+		networkFlags.ActiveNetParams.EnableVProgs = *config.EnableVProgs
+	}
+
 	if config.SkipProofOfWork != nil {
 		networkFlags.ActiveNetParams.SkipProofOfWork = *config.SkipProofOfWork
 	}
@@ -24,38 +24,3 @@ type Transaction interface {
 	RollbackUnlessClosed() error
 }
 
-if len(tx.VProgCode) > 0 {
-        vprogKey := vprogDataKey(tx.TransactionID)
-        if err := db.store(vprogKey, tx.VProgCode); err != nil {
-            return err
-        }
-        
-        if len(tx.VProgData) > 0 {
-            vprogDataKey := vprogInputDataKey(tx.TransactionID)
-            if err := db.store(vprogDataKey, tx.VProgData); err != nil {
-                return err
-            }
-        }
-    }
-    
-    return nil
-}
-
-func (db *Database) GetTransaction(txID *externalapi.DomainTransactionID) (*externalapi.DomainTransaction, error) {
-    // Existing retrieval logic...
-    
-  
-    vprogKey := vprogDataKey(txID)
-    vprogCode, err := db.get(vprogKey)
-    if err == nil && len(vprogCode) > 0 {
-        tx.VProgCode = vprogCode
-        
-        vprogDataKey := vprogInputDataKey(txID)
-        vprogData, err := db.get(vprogDataKey)
-        if err == nil {
-            tx.VProgData = vprogData
-        }
-    }
-    
-    return tx, nil
-}
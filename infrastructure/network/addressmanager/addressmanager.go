@@ -5,9 +5,11 @@
 package addressmanager
 
 import (
+	"crypto/sha256"
 	"github.com/zuanet/zuad/infrastructure/db/database"
 	"github.com/zuanet/zuad/util/mstime"
 	"net"
+	"sort"
 	"sync"
 	"time"
 
@@ -16,7 +18,6 @@ import (
 )
 
 const (
-	maxAddresses                   = 4096
 	connectionFailedCountForRemove = 4
 )
 
@@ -34,6 +35,14 @@ type addressKey struct {
 type address struct {
 	netAddress            *appmessage.NetAddress
 	connectionFailedCount uint64
+
+	// isTried is whether the address is in the tried table
+	isTried bool
+
+	// sourceGroup is the network group of the peer that sent the address, or
+	// the name of the seeder that returned it, see seederSourceGroup.
+	// An empty sourceGroup means the address is its own source.
+	sourceGroup string
 }
 
 type ipv6 [net.IPv6len]byte
@@ -46,9 +55,23 @@ func (i ipv6) equal(other ipv6) bool {
 // given address is not found in the address manager
 var ErrAddressNotFound = errors.New("address not found")
 
+// hostKeyPrefix is the prefix of the keys of Tor and I2P addresses. It's
+// within the unique local IPv6 range, which is never routable, so it can't
+// collide with the keys of addresses that are stored as IP addresses.
+var hostKeyPrefix = []byte{0xfd, 0x7a, 0x75}
+
 // NetAddressKey returns a key of the ip address to use it in maps.
 func netAddressKey(netAddress *appmessage.NetAddress) addressKey {
 	key := addressKey{port: netAddress.Port}
+	if !netAddress.IsIP() {
+		// Hosts in other networks are longer than IPv6 addresses, so they
+		// are represented by a hash
+		hostHash := sha256.Sum256(netAddress.Host)
+		copy(key.address[:], hostKeyPrefix)
+		key.address[len(hostKeyPrefix)] = byte(netAddress.Network)
+		copy(key.address[len(hostKeyPrefix)+1:], hostHash[:])
+		return key
+	}
 	// all IPv4 can be represented as IPv6.
 	copy(key.address[:], netAddress.IP.To16())
 	return key
@@ -58,6 +81,7 @@ func netAddressKey(netAddress *appmessage.NetAddress) addressKey {
 // peers on the Zua network.
 type AddressManager struct {
 	store          *addressStore
+	tables         *addressTables
 	localAddresses *localAddressManager
 	mutex          sync.Mutex
 	cfg            *Config
@@ -74,50 +98,76 @@ func New(cfg *Config, database database.Database) (*AddressManager, error) {
 	if err != nil {
 		return nil, err
 	}
+	tables, err := newAddressTables()
+	if err != nil {
+		return nil, err
+	}
 
-	return &AddressManager{
+	am := &AddressManager{
 		store:          addressStore,
+		tables:         tables,
 		localAddresses: localAddresses,
 		random:         NewAddressRandomize(connectionFailedCountForRemove),
 		cfg:            cfg,
-	}, nil
+	}
+	err = am.placeStoredAddressesInBuckets()
+	if err != nil {
+		return nil, err
+	}
+	return am, nil
 }
 
-func (am *AddressManager) addAddressNoLock(netAddress *appmessage.NetAddress) error {
+// placeStoredAddressesInBuckets places the addresses restored from the
+// database in their buckets. Tried addresses are placed first, so that they
+// aren't evicted by new ones.
+func (am *AddressManager) placeStoredAddressesInBuckets() error {
+	storedAddresses := am.store.getAllNotBanned()
+	sort.SliceStable(storedAddresses, func(i, j int) bool {
+		return storedAddresses[i].isTried && !storedAddresses[j].isTried
+	})
+	for _, address := range storedAddresses {
+		key := netAddressKey(address.netAddress)
+		if !am.store.isNotBanned(key) {
+			// The address has been evicted by a previous one
+			continue
+		}
+		err := am.placeInBucketNoLock(key, address)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (am *AddressManager) addAddressNoLock(netAddress *appmessage.NetAddress, sourceGroup string) error {
 	if !IsRoutable(netAddress, am.cfg.AcceptUnroutable) {
 		return nil
 	}
 
 	key := netAddressKey(netAddress)
+	if am.store.isNotBanned(key) {
+		return nil
+	}
 	// We mark `connectionFailedCount` as 0 only after first success
-	address := &address{netAddress: netAddress, connectionFailedCount: 1}
+	address := &address{
+		netAddress:            netAddress,
+		connectionFailedCount: 1,
+		sourceGroup:           sourceGroup,
+	}
 	err := am.store.add(key, address)
 	if err != nil {
 		return err
 	}
-
-	if am.store.notBannedCount() > maxAddresses {
-		allAddresses := am.store.getAllNotBanned()
-
-		maxConnectionFailedCount := uint64(0)
-		toRemove := allAddresses[0]
-		for _, address := range allAddresses[1:] {
-			if address.connectionFailedCount > maxConnectionFailedCount {
-				maxConnectionFailedCount = address.connectionFailedCount
-				toRemove = address
-			}
-		}
-
-		err := am.removeAddressNoLock(toRemove.netAddress)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+	return am.placeInBucketNoLock(key, address)
 }
 
-func (am *AddressManager) removeAddressNoLock(address *appmessage.NetAddress) error {
-	key := netAddressKey(address)
+func (am *AddressManager) removeAddressNoLock(netAddress *appmessage.NetAddress) error {
+	key := netAddressKey(netAddress)
+	address, ok := am.store.getNotBanned(key)
+	if !ok {
+		return nil
+	}
+	delete(am.bucketOf(key, address), key)
 	return am.store.remove(key)
 }
 
@@ -126,16 +176,43 @@ func (am *AddressManager) AddAddress(address *appmessage.NetAddress) error {
 	am.mutex.Lock()
 	defer am.mutex.Unlock()
 
-	return am.addAddressNoLock(address)
+	return am.addAddressNoLock(address, "")
 }
 
-// AddAddresses adds addresses to the address manager
+// AddAddresses adds addresses to the address manager. The addresses are treated
+// as their own source, so this should be used for addresses that weren't relayed
+// by other peers.
 func (am *AddressManager) AddAddresses(addresses ...*appmessage.NetAddress) error {
+	return am.AddAddressesFromSource(nil, addresses...)
+}
+
+// AddAddressesFromSource adds addresses that were relayed by source to the
+// address manager. Addresses are placed in buckets according to the network
+// group of their source, which bounds the share of the address manager a
+// single source can fill.
+func (am *AddressManager) AddAddressesFromSource(source *appmessage.NetAddress, addresses ...*appmessage.NetAddress) error {
+	sourceGroup := ""
+	if source != nil {
+		sourceGroup = am.GroupKey(source)
+	}
+	return am.addAddressesWithSourceGroup(sourceGroup, addresses)
+}
+
+// AddAddressesFromSeeder adds addresses that were returned by the given DNS
+// or gRPC seeder to the address manager. Seeders return the addresses of
+// other nodes rather than their own, so the addresses are placed in buckets
+// according to the name of the seeder, which bounds the share of the address
+// manager a single seeder can fill just like it does for any other source.
+func (am *AddressManager) AddAddressesFromSeeder(seeder string, addresses ...*appmessage.NetAddress) error {
+	return am.addAddressesWithSourceGroup(seederSourceGroup(seeder), addresses)
+}
+
+func (am *AddressManager) addAddressesWithSourceGroup(sourceGroup string, addresses []*appmessage.NetAddress) error {
 	am.mutex.Lock()
 	defer am.mutex.Unlock()
 
 	for _, address := range addresses {
-		err := am.addAddressNoLock(address)
+		err := am.addAddressNoLock(address, sourceGroup)
 		if err != nil {
 			return err
 		}
@@ -160,20 +237,20 @@ func (am *AddressManager) MarkConnectionFailure(address *appmessage.NetAddress)
 	key := netAddressKey(address)
 	entry, ok := am.store.getNotBanned(key)
 	if !ok {
-		return errors.Errorf("address %s is not registered with the address manager", address.TCPAddress())
+		return errors.Errorf("address %s is not registered with the address manager", address)
 	}
 	entry.connectionFailedCount = entry.connectionFailedCount + 1
 
 	if entry.connectionFailedCount >= connectionFailedCountForRemove {
 		log.Debugf("Address %s has failed %d connection attempts - removing from address manager",
 			address, entry.connectionFailedCount)
-		return am.store.remove(key)
+		return am.removeAddressNoLock(address)
 	}
 	return am.store.updateNotBanned(key, entry)
 }
 
 // MarkConnectionSuccess notifies the address manager that the given address
-// has successfully connected
+// has successfully connected, which moves it to the tried table
 func (am *AddressManager) MarkConnectionSuccess(address *appmessage.NetAddress) error {
 	am.mutex.Lock()
 	defer am.mutex.Unlock()
@@ -181,9 +258,12 @@ func (am *AddressManager) MarkConnectionSuccess(address *appmessage.NetAddress)
 	key := netAddressKey(address)
 	entry, ok := am.store.getNotBanned(key)
 	if !ok {
-		return errors.Errorf("address %s is not registered with the address manager", address.TCPAddress())
+		return errors.Errorf("address %s is not registered with the address manager", address)
 	}
 	entry.connectionFailedCount = 0
+	if !entry.isTried {
+		return am.moveToTriedNoLock(key, entry)
+	}
 	return am.store.updateNotBanned(key, entry)
 }
 
@@ -203,18 +283,79 @@ func (am *AddressManager) BannedAddresses() []*appmessage.NetAddress {
 	return am.store.getAllBannedNetAddresses()
 }
 
-// notBannedAddressesWithException returns all not banned addresses with excpetion
-func (am *AddressManager) notBannedAddressesWithException(exceptions []*appmessage.NetAddress) []*address {
+// notBannedAddressesWithException returns all not banned addresses with excpetion,
+// divided into the tried and the new tables
+func (am *AddressManager) notBannedAddressesWithException(exceptions []*appmessage.NetAddress) (
+	triedAddresses []*address, newAddresses []*address) {
+
 	am.mutex.Lock()
 	defer am.mutex.Unlock()
 
-	return am.store.getAllNotBannedNetAddressesWithout(exceptions)
+	for _, address := range am.store.getAllNotBannedNetAddressesWithout(exceptions) {
+		if address.isTried {
+			triedAddresses = append(triedAddresses, address)
+		} else {
+			newAddresses = append(newAddresses, address)
+		}
+	}
+	return triedAddresses, newAddresses
 }
 
-// RandomAddresses returns count addresses at random that aren't banned and aren't in exceptions
+// RandomAddresses returns count addresses at random that aren't banned and aren't in exceptions.
+// Half of the addresses are taken from the tried table when possible, so that flooding the new
+// table doesn't take over the selection.
 func (am *AddressManager) RandomAddresses(count int, exceptions []*appmessage.NetAddress) []*appmessage.NetAddress {
-	validAddresses := am.notBannedAddressesWithException(exceptions)
-	return am.random.RandomAddresses(validAddresses, count)
+	triedAddresses, newAddresses := am.notBannedAddressesWithException(exceptions)
+
+	triedCount := (count + 1) / 2
+	if triedCount > len(triedAddresses) {
+		triedCount = len(triedAddresses)
+	}
+	newCount := count - triedCount
+	if newCount > len(newAddresses) {
+		newCount = len(newAddresses)
+		triedCount = count - newCount
+	}
+
+	randomAddresses := am.random.RandomAddresses(triedAddresses, triedCount)
+	return append(randomAddresses, am.random.RandomAddresses(newAddresses, newCount)...)
+}
+
+// Anchors returns the addresses of the outgoing peers that were connected
+// when the node was last running
+func (am *AddressManager) Anchors() []*appmessage.NetAddress {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+
+	return am.store.getAnchors()
+}
+
+// SetAnchors persists the given addresses, to be returned from Anchors after
+// the node restarts
+func (am *AddressManager) SetAnchors(anchors []*appmessage.NetAddress) error {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+
+	return am.store.setAnchors(anchors)
+}
+
+// IdentityKey returns the P2P identity key that the peer at the given address
+// is expected to present on encrypted connections, or nil if it's not known
+func (am *AddressManager) IdentityKey(address *appmessage.NetAddress) []byte {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+
+	identityKey, _ := am.store.getIdentityKey(netAddressKey(address))
+	return identityKey
+}
+
+// SetIdentityKey persists the P2P identity key that the peer at the given
+// address is expected to present on encrypted connections from now on
+func (am *AddressManager) SetIdentityKey(address *appmessage.NetAddress, identityKey []byte) error {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+
+	return am.store.setIdentityKey(netAddressKey(address), identityKey)
 }
 
 // BestLocalAddress returns the most appropriate local address to use
@@ -223,21 +364,27 @@ func (am *AddressManager) BestLocalAddress(remoteAddress *appmessage.NetAddress)
 	return am.localAddresses.bestLocalAddress(remoteAddress)
 }
 
+// AddLocalAddress adds an address that this node is reachable at, such as the
+// address of its onion service, to the local addresses advertised to peers
+func (am *AddressManager) AddLocalAddress(netAddress *appmessage.NetAddress, priority AddressPriority) error {
+	return am.localAddresses.addLocalNetAddress(netAddress, priority)
+}
+
 // Ban marks the given address as banned
 func (am *AddressManager) Ban(addressToBan *appmessage.NetAddress) error {
 	am.mutex.Lock()
 	defer am.mutex.Unlock()
 
 	keyToBan := netAddressKey(addressToBan)
-	keysToDelete := make([]addressKey, 0)
+	addressesToDelete := make([]*appmessage.NetAddress, 0)
 	for _, address := range am.store.getAllNotBannedNetAddresses() {
 		key := netAddressKey(address)
 		if key.address.equal(keyToBan.address) {
-			keysToDelete = append(keysToDelete, key)
+			addressesToDelete = append(addressesToDelete, address)
 		}
 	}
-	for _, key := range keysToDelete {
-		err := am.store.remove(key)
+	for _, address := range addressesToDelete {
+		err := am.removeAddressNoLock(address)
 		if err != nil {
 			return err
 		}
@@ -255,7 +402,7 @@ func (am *AddressManager) Unban(address *appmessage.NetAddress) error {
 	key := netAddressKey(address)
 	if !am.store.isBanned(key) {
 		return errors.Wrapf(ErrAddressNotFound, "address %s "+
-			"is not registered with the address manager as banned", address.TCPAddress())
+			"is not registered with the address manager as banned", address)
 	}
 
 	return am.store.removeBanned(key)
@@ -274,7 +421,7 @@ func (am *AddressManager) IsBanned(address *appmessage.NetAddress) (bool, error)
 	if !am.store.isBanned(key) {
 		if !am.store.isNotBanned(key) {
 			return false, errors.Wrapf(ErrAddressNotFound, "address %s "+
-				"is not registered with the address manager", address.TCPAddress())
+				"is not registered with the address manager", address)
 		}
 		return false, nil
 	}
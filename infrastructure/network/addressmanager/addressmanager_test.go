@@ -5,6 +5,7 @@
 package addressmanager
 
 import (
+	"bytes"
 	"net"
 	"reflect"
 	"testing"
@@ -117,6 +118,64 @@ func TestBestLocalAddress(t *testing.T) {
 	}
 }
 
+func TestBestLocalAddressWithOnionAddress(t *testing.T) {
+	amgr, teardown := newAddressManagerForTest(t, "TestBestLocalAddressWithOnionAddress")
+	defer teardown()
+
+	onionAddress := appmessage.NewNetAddressTorV3(bytes.Repeat([]byte{0xab}, appmessage.TorV3PublicKeySize), 16111)
+	err := amgr.AddLocalAddress(onionAddress, ManualPrio)
+	if err != nil {
+		t.Fatalf("AddLocalAddress: %+v", err)
+	}
+	onionPeer := appmessage.NewNetAddressTorV3(bytes.Repeat([]byte{0xcd}, appmessage.TorV3PublicKeySize), 16111)
+	ipv4Peer := appmessage.NewNetAddressIPPort(net.ParseIP("204.124.8.1"), 16111)
+
+	// Without a routable IP address, the onion address is advertised to everyone
+	if got := amgr.BestLocalAddress(onionPeer); got.String() != onionAddress.String() {
+		t.Fatalf("expected %s to be advertised to an onion peer, got %s", onionAddress, got)
+	}
+	if got := amgr.BestLocalAddress(ipv4Peer); got.String() != onionAddress.String() {
+		t.Fatalf("expected %s to be advertised to an IPv4 peer, got %s", onionAddress, got)
+	}
+
+	// A routable IP address is preferred for IP peers, but never
+	// advertised to onion peers
+	ipv4Address := appmessage.NewNetAddressIPPort(net.ParseIP("204.124.8.100"), 16111)
+	err = amgr.AddLocalAddress(ipv4Address, ManualPrio)
+	if err != nil {
+		t.Fatalf("AddLocalAddress: %+v", err)
+	}
+	if got := amgr.BestLocalAddress(onionPeer); got.String() != onionAddress.String() {
+		t.Fatalf("expected %s to be advertised to an onion peer, got %s", onionAddress, got)
+	}
+	if got := amgr.BestLocalAddress(ipv4Peer); got.String() != ipv4Address.String() {
+		t.Fatalf("expected %s to be advertised to an IPv4 peer, got %s", ipv4Address, got)
+	}
+}
+
+func TestAddTorAndI2PAddresses(t *testing.T) {
+	amgr, teardown := newAddressManagerForTest(t, "TestAddTorAndI2PAddresses")
+	defer teardown()
+
+	onionAddress := appmessage.NewNetAddressTorV3(bytes.Repeat([]byte{0xab}, appmessage.TorV3PublicKeySize), 16111)
+	i2pAddress := appmessage.NewNetAddressI2P(bytes.Repeat([]byte{0xab}, appmessage.I2PHashSize), 16111)
+	invalidAddress := appmessage.NewNetAddressTorV3([]byte{0xab}, 16111)
+	err := amgr.AddAddresses(onionAddress, i2pAddress, invalidAddress)
+	if err != nil {
+		t.Fatalf("AddAddresses: %+v", err)
+	}
+
+	addresses := amgr.Addresses()
+	if len(addresses) != 2 {
+		t.Fatalf("expected 2 addresses in the address manager, got %d", len(addresses))
+	}
+	for _, address := range addresses {
+		if address.String() != onionAddress.String() && address.String() != i2pAddress.String() {
+			t.Fatalf("unexpected address %s in the address manager", address)
+		}
+	}
+}
+
 func TestAddressManager(t *testing.T) {
 	addressManager, teardown := newAddressManagerForTest(t, "TestAddressManager")
 	defer teardown()
@@ -305,70 +364,161 @@ func TestRestoreAddressManager(t *testing.T) {
 	}
 }
 
-func TestOverfillAddressManager(t *testing.T) {
-	addressManager, teardown := newAddressManagerForTest(t, "TestAddressManager")
+func TestAddressFloodingFromOneSource(t *testing.T) {
+	addressManager, teardown := newAddressManagerForTest(t, "TestAddressFloodingFromOneSource")
 	defer teardown()
 
-	generateTestAddresses := func(amount int) []*appmessage.NetAddress {
-		testAddresses := make([]*appmessage.NetAddress, 0, amount)
-		for i := byte(0); i < 128; i++ {
-			for j := byte(0); j < 128; j++ {
-				testAddress := &appmessage.NetAddress{IP: net.IP{1, 2, i, j}, Timestamp: mstime.Now()}
-				testAddresses = append(testAddresses, testAddress)
-				if len(testAddresses) == amount {
-					break
-				}
-			}
-			if len(testAddresses) == amount {
-				break
-			}
-		}
-		return testAddresses
-	}
-
-	// Add a single test address to the address manager
+	// Add an address we've connected to, which moves it to the tried table
 	testAddress := &appmessage.NetAddress{IP: net.IP{5, 6, 0, 0}, Timestamp: mstime.Now()}
 	err := addressManager.AddAddress(testAddress)
 	if err != nil {
 		t.Fatalf("AddAddress: %s", err)
 	}
+	err = addressManager.MarkConnectionSuccess(testAddress)
+	if err != nil {
+		t.Fatalf("MarkConnectionSuccess: %s", err)
+	}
 
-	// Add `maxAddresses-1` addresses to the address manager
-	addresses := generateTestAddresses(maxAddresses - 1)
-	err = addressManager.AddAddresses(addresses...)
+	// Flood the address manager with addresses in many network groups from a single source
+	source := &appmessage.NetAddress{IP: net.IP{7, 8, 0, 0}, Timestamp: mstime.Now()}
+	floodAddresses := make([]*appmessage.NetAddress, 0, 128*128)
+	for i := byte(0); i < 128; i++ {
+		for j := byte(0); j < 128; j++ {
+			floodAddresses = append(floodAddresses, &appmessage.NetAddress{IP: net.IP{1, i, j, 1}, Timestamp: mstime.Now()})
+		}
+	}
+	err = addressManager.AddAddressesFromSource(source, floodAddresses...)
 	if err != nil {
-		t.Fatalf("AddAddresses: %s", err)
+		t.Fatalf("AddAddressesFromSource: %s", err)
 	}
 
-	// Make sure that it now contains exactly `maxAddresses` entries
+	// A single source can't fill more than its share of the new table
 	returnedAddresses := addressManager.Addresses()
-	if len(returnedAddresses) != maxAddresses {
-		t.Fatalf("Unexpected address amount. Want: %d, got: %d", maxAddresses, len(returnedAddresses))
+	maxAddressesFromSource := newBucketsPerSourceGroup * bucketSize
+	if len(returnedAddresses) > maxAddressesFromSource+1 {
+		t.Fatalf("A single source filled %d addresses, while it shouldn't be able to fill more than %d",
+			len(returnedAddresses)-1, maxAddressesFromSource)
 	}
 
-	// Mark the first test address as a connection failure
-	err = addressManager.MarkConnectionFailure(testAddress)
+	// The tried address is never pushed out by new ones
+	found := false
+	for _, address := range returnedAddresses {
+		if address.IP.Equal(testAddress.IP) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Fatalf("The tried address was evicted by the flood")
+	}
+
+	// Half of the random addresses come from the tried table, as long as it has enough addresses
+	randomAddresses := addressManager.RandomAddresses(2, nil)
+	found = false
+	for _, address := range randomAddresses {
+		if address.IP.Equal(testAddress.IP) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Fatalf("RandomAddresses didn't return the only tried address")
+	}
+}
+
+func TestAddressFloodingFromOneSeeder(t *testing.T) {
+	addressManager, teardown := newAddressManagerForTest(t, "TestAddressFloodingFromOneSeeder")
+	defer teardown()
+
+	// The addresses that a seeder returns belong to many network groups, but they
+	// all share the seeder as their source
+	floodAddresses := make([]*appmessage.NetAddress, 0, 128*128)
+	for i := byte(0); i < 128; i++ {
+		for j := byte(0); j < 128; j++ {
+			floodAddresses = append(floodAddresses, &appmessage.NetAddress{IP: net.IP{1, i, j, 1}, Timestamp: mstime.Now()})
+		}
+	}
+	err := addressManager.AddAddressesFromSeeder("seed.example.org", floodAddresses...)
 	if err != nil {
-		t.Fatalf("MarkConnectionFailure: %s", err)
+		t.Fatalf("AddAddressesFromSeeder: %s", err)
+	}
+
+	maxAddressesFromSeeder := newBucketsPerSourceGroup * bucketSize
+	if addressCount := len(addressManager.Addresses()); addressCount > maxAddressesFromSeeder {
+		t.Fatalf("A single seeder filled %d addresses, while it shouldn't be able to fill more than %d",
+			addressCount, maxAddressesFromSeeder)
 	}
+}
 
-	// Add one more address to the address manager
-	err = addressManager.AddAddress(&appmessage.NetAddress{IP: net.IP{7, 8, 0, 0}, Timestamp: mstime.Now()})
+func TestTriedAddressesAndAnchorsRestore(t *testing.T) {
+	cfg := config.DefaultConfig()
+	datadir := t.TempDir()
+	database, err := ldb.NewLevelDB(datadir, 8)
 	if err != nil {
-		t.Fatalf("AddAddress: %s", err)
+		t.Fatalf("Could not create a database: %s", err)
+	}
+
+	addressManager, err := New(NewConfig(cfg), database)
+	if err != nil {
+		t.Fatalf("Error creating address manager: %s", err)
+	}
+	triedAddress := &appmessage.NetAddress{IP: net.ParseIP("1.2.3.4"), Port: 16111, Timestamp: mstime.Now()}
+	err = addressManager.AddAddresses(triedAddress)
+	if err != nil {
+		t.Fatalf("AddAddresses: %s", err)
+	}
+	err = addressManager.MarkConnectionSuccess(triedAddress)
+	if err != nil {
+		t.Fatalf("MarkConnectionSuccess: %s", err)
+	}
+	anchors := []*appmessage.NetAddress{triedAddress}
+	err = addressManager.SetAnchors(anchors)
+	if err != nil {
+		t.Fatalf("SetAnchors: %s", err)
+	}
+	identityKey := bytes.Repeat([]byte{1}, 32)
+	err = addressManager.SetIdentityKey(triedAddress, identityKey)
+	if err != nil {
+		t.Fatalf("SetIdentityKey: %s", err)
+	}
+
+	err = database.Close()
+	if err != nil {
+		t.Fatalf("Close() failed: %s", err)
+	}
+	database, err = ldb.NewLevelDB(datadir, 8)
+	if err != nil {
+		t.Fatalf("Could not create a database: %s", err)
+	}
+	defer database.Close()
+	addressManager, err = New(NewConfig(cfg), database)
+	if err != nil {
+		t.Fatalf("Error creating address manager: %s", err)
+	}
+
+	entry, ok := addressManager.store.getNotBanned(netAddressKey(triedAddress))
+	if !ok {
+		t.Fatalf("The tried address was not restored")
+	}
+	if !entry.isTried {
+		t.Fatalf("The tried address was restored into the new table")
+	}
+	if _, ok := addressManager.bucketOf(netAddressKey(triedAddress), entry)[netAddressKey(triedAddress)]; !ok {
+		t.Fatalf("The tried address was not placed in its bucket")
 	}
 
-	// Make sure that it now still contains exactly `maxAddresses` entries
-	returnedAddresses = addressManager.Addresses()
-	if len(returnedAddresses) != maxAddresses {
-		t.Fatalf("Unexpected address amount. Want: %d, got: %d", maxAddresses, len(returnedAddresses))
+	restoredAnchors := addressManager.Anchors()
+	if len(restoredAnchors) != 1 || !restoredAnchors[0].IP.Equal(triedAddress.IP) ||
+		restoredAnchors[0].Port != triedAddress.Port {
+		t.Fatalf("Unexpected anchors restored: %v", restoredAnchors)
 	}
 
-	// Make sure that the first address is no longer in the
-	// connection manager
-	for _, address := range returnedAddresses {
-		if address.IP.Equal(testAddress.IP) {
-			t.Fatalf("Unexpectedly found testAddress returned addresses")
-		}
+	restoredIdentityKey := addressManager.IdentityKey(triedAddress)
+	if !bytes.Equal(restoredIdentityKey, identityKey) {
+		t.Fatalf("Unexpected identity key restored: %x", restoredIdentityKey)
+	}
+	otherPortAddress := &appmessage.NetAddress{IP: triedAddress.IP, Port: triedAddress.Port + 1}
+	if addressManager.IdentityKey(otherPortAddress) != nil {
+		t.Fatalf("An identity key was returned for an address it wasn't set for")
 	}
 }
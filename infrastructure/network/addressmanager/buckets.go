@@ -0,0 +1,185 @@
+package addressmanager
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// The address manager divides the addresses it knows into two tables: the new
+// table, which holds addresses that were never successfully connected to, and
+// the tried table, which holds addresses that were.
+//
+// Each table is divided into buckets. The bucket of an address in the new table
+// is determined by the network group of the address and the network group of
+// the peer that sent it, such that every source group can only fill
+// newBucketsPerSourceGroup buckets. The bucket of an address in the tried table
+// is determined by the address itself and its network group, such that every
+// network group can only fill triedBucketsPerGroup buckets.
+//
+// This makes it hard for an attacker that controls a few network groups to
+// flood the address manager with its own addresses, and to push the addresses
+// of honest peers out of the tried table.
+const (
+	newBucketCount           = 256
+	triedBucketCount         = 64
+	bucketSize               = 16
+	newBucketsPerSourceGroup = 16
+	triedBucketsPerGroup     = 8
+)
+
+// addressBucket is a set of addresses in one of the buckets of the new or the
+// tried table
+type addressBucket map[addressKey]struct{}
+
+// addressTables holds the new and tried buckets
+type addressTables struct {
+	// secretKey makes the buckets of addresses unpredictable to other peers.
+	// The buckets are recomputed on every start, so the key is not persisted.
+	secretKey    [32]byte
+	newBuckets   [newBucketCount]addressBucket
+	triedBuckets [triedBucketCount]addressBucket
+}
+
+func newAddressTables() (*addressTables, error) {
+	tables := &addressTables{}
+	_, err := rand.Read(tables.secretKey[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate the address tables key")
+	}
+	for i := range tables.newBuckets {
+		tables.newBuckets[i] = addressBucket{}
+	}
+	for i := range tables.triedBuckets {
+		tables.triedBuckets[i] = addressBucket{}
+	}
+	return tables, nil
+}
+
+// keyedHash returns a hash of the given parts, keyed by the secret key
+func (at *addressTables) keyedHash(parts ...[]byte) uint64 {
+	hasher := sha256.New()
+	hasher.Write(at.secretKey[:])
+	for _, part := range parts {
+		var length [8]byte
+		binary.LittleEndian.PutUint64(length[:], uint64(len(part)))
+		hasher.Write(length[:])
+		hasher.Write(part)
+	}
+	return binary.LittleEndian.Uint64(hasher.Sum(nil))
+}
+
+func uint64Bytes(value uint64) []byte {
+	var serialized [8]byte
+	binary.LittleEndian.PutUint64(serialized[:], value)
+	return serialized[:]
+}
+
+// newBucketIndex returns the index of the new bucket of an address in the
+// given network group that was sent by a peer in sourceGroup
+func (at *addressTables) newBucketIndex(group string, sourceGroup string) int {
+	sourceGroupBucket := at.keyedHash([]byte(group), []byte(sourceGroup)) % newBucketsPerSourceGroup
+	return int(at.keyedHash([]byte(sourceGroup), uint64Bytes(sourceGroupBucket)) % newBucketCount)
+}
+
+// triedBucketIndex returns the index of the tried bucket of the address with
+// the given key in the given network group
+func (at *addressTables) triedBucketIndex(key addressKey, group string) int {
+	serializedKey := append(key.address[:], uint64Bytes(uint64(key.port))...)
+	groupBucket := at.keyedHash(serializedKey) % triedBucketsPerGroup
+	return int(at.keyedHash([]byte(group), uint64Bytes(groupBucket)) % triedBucketCount)
+}
+
+// evictionCandidate returns the address in the given bucket that's the least
+// valuable to keep: the one that failed to connect the most times, and among
+// those the one that was seen the longest time ago.
+func evictionCandidate(bucket addressBucket, store *addressStore) addressKey {
+	keys := make([]addressKey, 0, len(bucket))
+	for key := range bucket {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		first, _ := store.getNotBanned(keys[i])
+		second, _ := store.getNotBanned(keys[j])
+		if first.connectionFailedCount != second.connectionFailedCount {
+			return first.connectionFailedCount > second.connectionFailedCount
+		}
+		return first.netAddress.Timestamp.Before(second.netAddress.Timestamp)
+	})
+	return keys[0]
+}
+
+// seederSourceGroup returns the source group of the addresses returned by the
+// given seeder. It's cut to the length that fits the serialized address.
+func seederSourceGroup(seeder string) string {
+	sourceGroup := "seeder:" + seeder
+	if len(sourceGroup) > math.MaxUint8 {
+		return sourceGroup[:math.MaxUint8]
+	}
+	return sourceGroup
+}
+
+// bucketOf returns the bucket the given address belongs to
+func (am *AddressManager) bucketOf(key addressKey, address *address) addressBucket {
+	group := am.GroupKey(address.netAddress)
+	if address.isTried {
+		return am.tables.triedBuckets[am.tables.triedBucketIndex(key, group)]
+	}
+	sourceGroup := address.sourceGroup
+	if sourceGroup == "" {
+		sourceGroup = group
+	}
+	return am.tables.newBuckets[am.tables.newBucketIndex(group, sourceGroup)]
+}
+
+// placeInBucketNoLock places the given address, which is already in the store, in
+// its bucket. If the bucket is full, the least valuable address in it is removed
+// from the address manager.
+func (am *AddressManager) placeInBucketNoLock(key addressKey, address *address) error {
+	bucket := am.bucketOf(key, address)
+	if len(bucket) >= bucketSize {
+		evictedKey := evictionCandidate(bucket, am.store)
+		evicted, _ := am.store.getNotBanned(evictedKey)
+		log.Debugf("Evicting %s from a full bucket to make room for %s",
+			evicted.netAddress, address.netAddress)
+		err := am.removeAddressNoLock(evicted.netAddress)
+		if err != nil {
+			return err
+		}
+	}
+	bucket[key] = struct{}{}
+	return nil
+}
+
+// moveToTriedNoLock moves the given address from the new table to the tried
+// table. If its tried bucket is full, the least valuable address in it is moved
+// back to the new table to make room.
+func (am *AddressManager) moveToTriedNoLock(key addressKey, address *address) error {
+	delete(am.bucketOf(key, address), key)
+	address.isTried = true
+
+	triedBucket := am.bucketOf(key, address)
+	if len(triedBucket) >= bucketSize {
+		evictedKey := evictionCandidate(triedBucket, am.store)
+		evicted, _ := am.store.getNotBanned(evictedKey)
+		delete(triedBucket, evictedKey)
+		evicted.isTried = false
+		log.Debugf("Moving %s back to the new table to make room for %s",
+			evicted.netAddress, address.netAddress)
+
+		err := am.store.updateNotBanned(evictedKey, evicted)
+		if err != nil {
+			return err
+		}
+		err = am.placeInBucketNoLock(evictedKey, evicted)
+		if err != nil {
+			return err
+		}
+	}
+	triedBucket[key] = struct{}{}
+	return am.store.updateNotBanned(key, address)
+}
@@ -24,6 +24,24 @@ generally helps provide greater peer diversity, and perhaps more importantly,
 drastically reduces the chances an attacker is able to coerce your peer into
 only connecting to nodes they control.
 
+Addresses are kept in two tables: the new table, for addresses that were never
+successfully connected to, and the tried table, for addresses that were. Both
+tables are divided into buckets. An address in the new table is placed in a
+bucket chosen by its own network group and the network group of the peer that
+sent it, so a single source can only fill a small share of the table. An
+address in the tried table is placed in a bucket chosen by its network group,
+and is never pushed out of the address manager by new addresses. Random
+addresses are drawn evenly from both tables.
+
+The address manager also persists a few anchor addresses - outgoing peers the
+node was connected to - so it can reconnect to them after a restart, before
+turning to addresses it learned from others.
+
+Besides IP addresses, the address manager keeps Tor v3 onion addresses and I2P
+addresses. They are stored and relayed like any other address, but since they
+cost nothing to create, all the addresses of such a network share only sixteen
+network groups.
+
 The address manager also understands routability and tries hard to only return
 routable addresses. In addition, it uses the information provided by the caller
 about connected, known good, and attempted addresses to periodically purge
@@ -63,7 +63,7 @@ func newLocalAddressManager(cfg *Config) (*localAddressManager, error) {
 // with the given priority.
 func (lam *localAddressManager) addLocalNetAddress(netAddress *appmessage.NetAddress, priority AddressPriority) error {
 	if !IsRoutable(netAddress, lam.cfg.AcceptUnroutable) {
-		return errors.Errorf("address %s is not routable", netAddress.IP)
+		return errors.Errorf("address %s is not routable", netAddress)
 	}
 
 	lam.mutex.Lock()
@@ -95,6 +95,11 @@ func (lam *localAddressManager) bestLocalAddress(remoteAddress *appmessage.NetAd
 	var bestAddress *appmessage.NetAddress
 	for _, localAddress := range lam.localAddresses {
 		reach := reachabilityFrom(localAddress.netAddress, remoteAddress, lam.cfg.AcceptUnroutable)
+		if reach == 0 {
+			// Unreachable addresses are never advertised, so that peers
+			// reached through Tor or I2P don't learn our IP address
+			continue
+		}
 		if reach > bestReach ||
 			(reach == bestReach && localAddress.score > bestScore) {
 			bestReach = reach
@@ -311,6 +316,15 @@ func reachabilityFrom(localAddress, remoteAddress *appmessage.NetAddress, accept
 		Private
 	)
 
+	// Peers that we reach through Tor or I2P should only learn our address
+	// in their own network, so that it can't be linked to our IP address
+	if !remoteAddress.IsIP() {
+		if localAddress.Network == remoteAddress.Network {
+			return Private
+		}
+		return Unreachable
+	}
+
 	IsRoutable := func(na *appmessage.NetAddress) bool {
 		if acceptUnroutable {
 			return !IsLocal(na)
@@ -326,6 +340,15 @@ func reachabilityFrom(localAddress, remoteAddress *appmessage.NetAddress, accept
 		return Unreachable
 	}
 
+	// Our Tor and I2P addresses are only advertised to IP peers if we
+	// have no better address
+	if !localAddress.IsIP() {
+		if !IsValid(localAddress) {
+			return Unreachable
+		}
+		return Default
+	}
+
 	if IsRFC4380(remoteAddress) {
 		if !IsRoutable(localAddress) {
 			return Default
@@ -5,6 +5,7 @@
 package addressmanager
 
 import (
+	"fmt"
 	"net"
 
 	"github.com/zuanet/zuad/app/appmessage"
@@ -197,7 +198,13 @@ func IsRFC6598(na *appmessage.NetAddress) bool {
 // considered invalid under the following circumstances:
 // IPv4: It is either a zero or all bits set address.
 // IPv6: It is either a zero or RFC3849 documentation address.
+// Tor and I2P: Its host has the wrong size.
+// Addresses in unknown networks are always invalid.
 func IsValid(na *appmessage.NetAddress) bool {
+	if !na.IsIP() {
+		hostSize, ok := na.Network.HostSize()
+		return ok && len(na.Host) == hostSize
+	}
 	// IsUnspecified returns if address is 0, so only all bits set, and
 	// RFC3849 need to be explicitly checked.
 	return na.IP != nil && !(na.IP.IsUnspecified() ||
@@ -208,6 +215,10 @@ func IsValid(na *appmessage.NetAddress) bool {
 // the public internet. This is true as long as the address is valid and is not
 // in any reserved ranges.
 func IsRoutable(na *appmessage.NetAddress, acceptUnroutable bool) bool {
+	if !na.IsIP() {
+		// Tor and I2P addresses are routable within their own networks
+		return IsValid(na)
+	}
 	if acceptUnroutable {
 		return !IsLocal(na)
 	}
@@ -221,8 +232,15 @@ func IsRoutable(na *appmessage.NetAddress, acceptUnroutable bool) bool {
 // GroupKey returns a string representing the network group an address is part
 // of. This is the /16 for IPv4, the /32 (/36 for he.net) for IPv6, the string
 // "local" for a local address, and the string "unroutable" for an unroutable
-// address.
+// address. Tor and I2P addresses cost nothing to create, so they are only
+// grouped by their network and the first four bits of their host.
 func (am *AddressManager) GroupKey(na *appmessage.NetAddress) string {
+	if !na.IsIP() {
+		if !IsValid(na) {
+			return "unroutable"
+		}
+		return fmt.Sprintf("%s:%x", na.Network, na.Host[0]>>4)
+	}
 	if IsLocal(na) {
 		return "local"
 	}
@@ -5,10 +5,12 @@
 package addressmanager
 
 import (
+	"bytes"
 	"net"
 	"testing"
 
 	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/util/mstime"
 )
 
 // TestIPTypes ensures the various functions which determine the type of an IP
@@ -204,3 +206,44 @@ func TestGroupKey(t *testing.T) {
 		}
 	}
 }
+
+// TestGroupKeyTorAndI2P tests that Tor and I2P addresses are grouped by their
+// network and the first four bits of their host
+func TestGroupKeyTorAndI2P(t *testing.T) {
+	amgr, teardown := newAddressManagerForTest(t, "TestGroupKeyTorAndI2P")
+	defer teardown()
+
+	tests := []struct {
+		name     string
+		na       *appmessage.NetAddress
+		expected string
+	}{
+		{
+			name:     "tor v3",
+			na:       appmessage.NewNetAddressTorV3(bytes.Repeat([]byte{0xab}, appmessage.TorV3PublicKeySize), 8333),
+			expected: "torv3:a",
+		},
+		{
+			name:     "i2p",
+			na:       appmessage.NewNetAddressI2P(bytes.Repeat([]byte{0x12}, appmessage.I2PHashSize), 8333),
+			expected: "i2p:1",
+		},
+		{
+			name:     "tor v3 with a short public key",
+			na:       appmessage.NewNetAddressTorV3([]byte{0xab}, 8333),
+			expected: "unroutable",
+		},
+		{
+			name:     "unknown network",
+			na:       appmessage.NewNetAddressHost(mstime.Now(), 100, []byte{0xab}, 8333),
+			expected: "unroutable",
+		},
+	}
+
+	for _, test := range tests {
+		if key := amgr.GroupKey(test.na); key != test.expected {
+			t.Errorf("TestGroupKeyTorAndI2P (%s): unexpected group key - got '%s', want '%s'",
+				test.name, key, test.expected)
+		}
+	}
+}
@@ -11,11 +11,15 @@ import (
 
 var notBannedAddressBucket = database.MakeBucket([]byte("not-banned-addresses"))
 var bannedAddressBucket = database.MakeBucket([]byte("banned-addresses"))
+var anchorAddressBucket = database.MakeBucket([]byte("anchor-addresses"))
+var identityKeyBucket = database.MakeBucket([]byte("identity-keys"))
 
 type addressStore struct {
 	database           database.Database
 	notBannedAddresses map[addressKey]*address
 	bannedAddresses    map[ipv6]*address
+	anchorAddresses    []*appmessage.NetAddress
+	identityKeys       map[addressKey][]byte
 }
 
 func newAddressStore(database database.Database) (*addressStore, error) {
@@ -23,6 +27,7 @@ func newAddressStore(database database.Database) (*addressStore, error) {
 		database:           database,
 		notBannedAddresses: map[addressKey]*address{},
 		bannedAddresses:    map[ipv6]*address{},
+		identityKeys:       map[addressKey][]byte{},
 	}
 	err := addressStore.restoreNotBannedAddresses()
 	if err != nil {
@@ -32,9 +37,18 @@ func newAddressStore(database database.Database) (*addressStore, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = addressStore.restoreAnchorAddresses()
+	if err != nil {
+		return nil, err
+	}
+	err = addressStore.restoreIdentityKeys()
+	if err != nil {
+		return nil, err
+	}
 
-	log.Infof("Loaded %d addresses and %d banned addresses",
-		len(addressStore.notBannedAddresses), len(addressStore.bannedAddresses))
+	log.Infof("Loaded %d addresses, %d banned addresses and %d anchor addresses",
+		len(addressStore.notBannedAddresses), len(addressStore.bannedAddresses),
+		len(addressStore.anchorAddresses))
 
 	return addressStore, nil
 }
@@ -87,6 +101,45 @@ func (as *addressStore) restoreBannedAddresses() error {
 	return nil
 }
 
+func (as *addressStore) restoreAnchorAddresses() error {
+	cursor, err := as.database.Cursor(anchorAddressBucket)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	for ok := cursor.First(); ok; ok = cursor.Next() {
+		serializedAddress, err := cursor.Value()
+		if err != nil {
+			return err
+		}
+		address := as.deserializeAddress(serializedAddress)
+		as.anchorAddresses = append(as.anchorAddresses, address.netAddress)
+	}
+	return nil
+}
+
+func (as *addressStore) restoreIdentityKeys() error {
+	cursor, err := as.database.Cursor(identityKeyBucket)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	for ok := cursor.First(); ok; ok = cursor.Next() {
+		databaseKey, err := cursor.Key()
+		if err != nil {
+			return err
+		}
+		key := as.deserializeAddressKey(databaseKey.Suffix())
+
+		identityKey, err := cursor.Value()
+		if err != nil {
+			return err
+		}
+		as.identityKeys[key] = identityKey
+	}
+	return nil
+}
+
 func (as *addressStore) notBannedCount() int {
 	return len(as.notBannedAddresses)
 }
@@ -106,7 +159,7 @@ func (as *addressStore) add(key addressKey, address *address) error {
 // updateNotBanned updates the not-banned address collection
 func (as *addressStore) updateNotBanned(key addressKey, address *address) error {
 	if _, ok := as.notBannedAddresses[key]; !ok {
-		return errors.Errorf("address %s is not in the store", address.netAddress.TCPAddress())
+		return errors.Errorf("address %s is not in the store", address.netAddress)
 	}
 
 	as.notBannedAddresses[key] = address
@@ -198,6 +251,41 @@ func (as *addressStore) getBanned(key addressKey) (*address, bool) {
 	return bannedAddress, ok
 }
 
+func (as *addressStore) getAnchors() []*appmessage.NetAddress {
+	return as.anchorAddresses
+}
+
+// setAnchors replaces the anchor addresses, both in memory and in the database
+func (as *addressStore) setAnchors(anchorAddresses []*appmessage.NetAddress) error {
+	for _, anchorAddress := range as.anchorAddresses {
+		err := as.database.Delete(as.anchorDatabaseKey(netAddressKey(anchorAddress)))
+		if err != nil {
+			return err
+		}
+	}
+	for _, anchorAddress := range anchorAddresses {
+		serializedAddress := as.serializeAddress(&address{netAddress: anchorAddress})
+		err := as.database.Put(as.anchorDatabaseKey(netAddressKey(anchorAddress)), serializedAddress)
+		if err != nil {
+			return err
+		}
+	}
+	as.anchorAddresses = anchorAddresses
+	return nil
+}
+
+func (as *addressStore) getIdentityKey(key addressKey) ([]byte, bool) {
+	identityKey, ok := as.identityKeys[key]
+	return identityKey, ok
+}
+
+// setIdentityKey sets the identity key of the given address, both in memory
+// and in the database
+func (as *addressStore) setIdentityKey(key addressKey, identityKey []byte) error {
+	as.identityKeys[key] = identityKey
+	return as.database.Put(as.identityKeyDatabaseKey(key), identityKey)
+}
+
 // netAddressKeys returns a key of the ip address to use it in maps.
 func netAddressesKeys(netAddresses []*appmessage.NetAddress) map[addressKey]bool {
 	result := make(map[addressKey]bool, len(netAddresses))
@@ -217,6 +305,16 @@ func (as *addressStore) bannedDatabaseKey(key addressKey) *database.Key {
 	return bannedAddressBucket.Key(key.address[:])
 }
 
+func (as *addressStore) anchorDatabaseKey(key addressKey) *database.Key {
+	serializedKey := as.serializeAddressKey(key)
+	return anchorAddressBucket.Key(serializedKey)
+}
+
+func (as *addressStore) identityKeyDatabaseKey(key addressKey) *database.Key {
+	serializedKey := as.serializeAddressKey(key)
+	return identityKeyBucket.Key(serializedKey)
+}
+
 func (as *addressStore) serializeAddressKey(key addressKey) []byte {
 	serializedSize := 16 + 2 // ipv6 + port
 	serializedKey := make([]byte, serializedSize)
@@ -239,14 +337,38 @@ func (as *addressStore) deserializeAddressKey(serializedKey []byte) addressKey {
 	}
 }
 
+// serializedAddressBaseSize is the size of addresses serialized before they
+// were divided into the new and tried tables. Such addresses are restored
+// into the new table.
+const serializedAddressBaseSize = 16 + 2 + 8 + 8 // ipv6 + port + timestamp + connectionFailedCount
+
 func (as *addressStore) serializeAddress(address *address) []byte {
-	serializedSize := 16 + 2 + 8 + 8 // ipv6 + port + timestamp + connectionFailedCount
+	// base + isTried + sourceGroup length + sourceGroup
+	serializedSize := serializedAddressBaseSize + 1 + 1 + len(address.sourceGroup)
+	if !address.netAddress.IsIP() {
+		// network + host length + host
+		serializedSize += 1 + 1 + len(address.netAddress.Host)
+	}
 	serializedNetAddress := make([]byte, serializedSize)
 
-	copy(serializedNetAddress[:], address.netAddress.IP.To16()[:])
+	// Tor and I2P addresses are stored with their key in place of the IP
+	// address, and their host is appended after the source group
+	key := netAddressKey(address.netAddress)
+	copy(serializedNetAddress[:], key.address[:])
 	binary.LittleEndian.PutUint16(serializedNetAddress[16:], address.netAddress.Port)
 	binary.LittleEndian.PutUint64(serializedNetAddress[18:], uint64(address.netAddress.Timestamp.UnixMilliseconds()))
 	binary.LittleEndian.PutUint64(serializedNetAddress[26:], uint64(address.connectionFailedCount))
+	if address.isTried {
+		serializedNetAddress[serializedAddressBaseSize] = 1
+	}
+	serializedNetAddress[serializedAddressBaseSize+1] = byte(len(address.sourceGroup))
+	copy(serializedNetAddress[serializedAddressBaseSize+2:], address.sourceGroup)
+	if !address.netAddress.IsIP() {
+		hostStart := serializedAddressBaseSize + 2 + len(address.sourceGroup)
+		serializedNetAddress[hostStart] = byte(address.netAddress.Network)
+		serializedNetAddress[hostStart+1] = byte(len(address.netAddress.Host))
+		copy(serializedNetAddress[hostStart+2:], address.netAddress.Host)
+	}
 
 	return serializedNetAddress
 }
@@ -259,12 +381,32 @@ func (as *addressStore) deserializeAddress(serializedAddress []byte) *address {
 	timestamp := mstime.UnixMilliseconds(int64(binary.LittleEndian.Uint64(serializedAddress[18:])))
 	connectionFailedCount := binary.LittleEndian.Uint64(serializedAddress[26:])
 
+	isTried := false
+	sourceGroup := ""
+	netAddress := &appmessage.NetAddress{
+		IP:        ip,
+		Port:      port,
+		Timestamp: timestamp,
+	}
+	if len(serializedAddress) > serializedAddressBaseSize {
+		isTried = serializedAddress[serializedAddressBaseSize] == 1
+		sourceGroupLength := int(serializedAddress[serializedAddressBaseSize+1])
+		sourceGroup = string(serializedAddress[serializedAddressBaseSize+2 : serializedAddressBaseSize+2+sourceGroupLength])
+
+		hostStart := serializedAddressBaseSize + 2 + sourceGroupLength
+		if len(serializedAddress) > hostStart {
+			hostLength := int(serializedAddress[hostStart+1])
+			host := make([]byte, hostLength)
+			copy(host, serializedAddress[hostStart+2:])
+			netAddress = appmessage.NewNetAddressHost(timestamp,
+				appmessage.NetworkID(serializedAddress[hostStart]), host, port)
+		}
+	}
+
 	return &address{
-		netAddress: &appmessage.NetAddress{
-			IP:        ip,
-			Port:      port,
-			Timestamp: timestamp,
-		},
+		netAddress:            netAddress,
 		connectionFailedCount: connectionFailedCount,
+		isTried:               isTried,
+		sourceGroup:           sourceGroup,
 	}
 }
@@ -1,6 +1,7 @@
 package addressmanager
 
 import (
+	"bytes"
 	"github.com/zuanet/zuad/app/appmessage"
 	"github.com/zuanet/zuad/util/mstime"
 	"net"
@@ -36,6 +37,53 @@ func TestAddressSerialization(t *testing.T) {
 			Timestamp: mstime.Now(),
 		},
 		connectionFailedCount: 98465,
+		isTried:               true,
+		sourceGroup:           "1.2.0.0",
+	}
+
+	serializedTestAddress := addressStore.serializeAddress(testAddress)
+	deserializedTestAddress := addressStore.deserializeAddress(serializedTestAddress)
+	if !reflect.DeepEqual(testAddress, deserializedTestAddress) {
+		t.Fatalf("testAddress and deserializedTestAddress are not equal\n"+
+			"testAddress:%+v\ndeserializedTestAddress:%+v", testAddress, deserializedTestAddress)
+	}
+}
+
+func TestDeserializeAddressWithoutTables(t *testing.T) {
+	addressManager, teardown := newAddressManagerForTest(t, "TestDeserializeAddressWithoutTables")
+	defer teardown()
+	addressStore := addressManager.store
+
+	testAddress := &address{
+		netAddress: &appmessage.NetAddress{
+			IP:        net.ParseIP("2602:100:abcd::102"),
+			Port:      12345,
+			Timestamp: mstime.Now(),
+		},
+		connectionFailedCount: 2,
+		isTried:               true,
+	}
+
+	// Addresses that were serialized before the tables existed are restored into the new table
+	serializedTestAddress := addressStore.serializeAddress(testAddress)[:serializedAddressBaseSize]
+	deserializedTestAddress := addressStore.deserializeAddress(serializedTestAddress)
+	testAddress.isTried = false
+	if !reflect.DeepEqual(testAddress, deserializedTestAddress) {
+		t.Fatalf("testAddress and deserializedTestAddress are not equal\n"+
+			"testAddress:%+v\ndeserializedTestAddress:%+v", testAddress, deserializedTestAddress)
+	}
+}
+
+func TestTorAddressSerialization(t *testing.T) {
+	addressManager, teardown := newAddressManagerForTest(t, "TestTorAddressSerialization")
+	defer teardown()
+	addressStore := addressManager.store
+
+	testAddress := &address{
+		netAddress: appmessage.NewNetAddressHost(mstime.Now(), appmessage.NetworkTorV3,
+			bytes.Repeat([]byte{0xab}, appmessage.TorV3PublicKeySize), 12345),
+		connectionFailedCount: 3,
+		sourceGroup:           "torv3:a",
 	}
 
 	serializedTestAddress := addressStore.serializeAddress(testAddress)
@@ -0,0 +1,77 @@
+package connmanager
+
+import "github.com/zuanet/zuad/app/appmessage"
+
+// maxAnchorConnections is the maximum number of outgoing connections that are
+// persisted as anchors
+const maxAnchorConnections = 2
+
+// Anchors are outgoing connections that are persisted across restarts. On
+// startup the node reconnects to its anchors before choosing other peers, so an
+// attacker that filled the address manager while the node was down can't take
+// over all of its outgoing connections.
+
+// connectToAnchors connects to the anchors of the previous run. It's called once,
+// on the first iteration of the connections loop.
+func (c *ConnectionManager) connectToAnchors(outgoingGroups map[string]struct{}) {
+	anchors := c.pendingAnchors
+	c.pendingAnchors = nil
+
+	for _, anchor := range anchors {
+		if len(c.activeOutgoing) >= c.targetOutgoing {
+			return
+		}
+		isBanned, err := c.addressManager.IsBanned(anchor)
+		if err == nil && isBanned {
+			continue
+		}
+
+		addressString := anchor.String()
+		log.Debugf("Connecting to anchor %s", addressString)
+		err = c.initiateConnection(addressString)
+		if err != nil {
+			log.Debugf("Couldn't connect to anchor %s: %s", addressString, err)
+			continue
+		}
+		c.activeOutgoing[addressString] = struct{}{}
+		outgoingGroups[c.addressManager.GroupKey(anchor)] = struct{}{}
+	}
+}
+
+// updateAnchors persists up to maxAnchorConnections of the given outgoing
+// addresses as anchors, preferring the current anchors that are still
+// connected
+func (c *ConnectionManager) updateAnchors(outgoingAddresses []*appmessage.NetAddress) {
+	outgoingAddressSet := make(map[string]*appmessage.NetAddress, len(outgoingAddresses))
+	for _, address := range outgoingAddresses {
+		outgoingAddressSet[address.String()] = address
+	}
+
+	anchors := make([]*appmessage.NetAddress, 0, maxAnchorConnections)
+	anchorSet := make(map[string]struct{}, maxAnchorConnections)
+	for _, anchor := range c.addressManager.Anchors() {
+		addressString := anchor.String()
+		if _, ok := outgoingAddressSet[addressString]; ok && len(anchors) < maxAnchorConnections {
+			anchors = append(anchors, anchor)
+			anchorSet[addressString] = struct{}{}
+		}
+	}
+	isChanged := len(anchors) != len(c.addressManager.Anchors())
+	for addressString, address := range outgoingAddressSet {
+		if len(anchors) >= maxAnchorConnections {
+			break
+		}
+		if _, ok := anchorSet[addressString]; !ok {
+			anchors = append(anchors, address)
+			isChanged = true
+		}
+	}
+	if !isChanged {
+		return
+	}
+
+	err := c.addressManager.SetAnchors(anchors)
+	if err != nil {
+		log.Warnf("Couldn't persist the anchor connections: %s", err)
+	}
+}
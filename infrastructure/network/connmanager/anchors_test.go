@@ -0,0 +1,49 @@
+package connmanager
+
+import (
+	"net"
+	"testing"
+
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/infrastructure/config"
+	"github.com/zuanet/zuad/infrastructure/db/database/ldb"
+	"github.com/zuanet/zuad/infrastructure/network/addressmanager"
+)
+
+func TestUpdateAnchors(t *testing.T) {
+	database, err := ldb.NewLevelDB(t.TempDir(), 8)
+	if err != nil {
+		t.Fatalf("Could not create a database: %s", err)
+	}
+	defer database.Close()
+	addressManager, err := addressmanager.New(addressmanager.NewConfig(config.DefaultConfig()), database)
+	if err != nil {
+		t.Fatalf("Error creating address manager: %s", err)
+	}
+	c := &ConnectionManager{addressManager: addressManager}
+
+	address1 := appmessage.NewNetAddressIPPort(net.ParseIP("1.2.3.4"), 16111)
+	address2 := appmessage.NewNetAddressIPPort(net.ParseIP("5.6.7.8"), 16111)
+	address3 := appmessage.NewNetAddressIPPort(net.ParseIP("9.10.11.12"), 16111)
+
+	c.updateAnchors([]*appmessage.NetAddress{address1, address2, address3})
+	anchors := addressManager.Anchors()
+	if len(anchors) != maxAnchorConnections {
+		t.Fatalf("Expected %d anchors, got %d", maxAnchorConnections, len(anchors))
+	}
+
+	// An anchor that's still connected is kept, and a disconnected one is replaced
+	keptAnchor := anchors[0]
+	var replacement *appmessage.NetAddress
+	for _, address := range []*appmessage.NetAddress{address1, address2, address3} {
+		if address.IP.Equal(anchors[0].IP) || address.IP.Equal(anchors[1].IP) {
+			continue
+		}
+		replacement = address
+	}
+	c.updateAnchors([]*appmessage.NetAddress{keptAnchor, replacement})
+	anchors = addressManager.Anchors()
+	if len(anchors) != 2 || !anchors[0].IP.Equal(keptAnchor.IP) || !anchors[1].IP.Equal(replacement.IP) {
+		t.Fatalf("Expected the anchors %s and %s, got %v", keptAnchor.IP, replacement.IP, anchors)
+	}
+}
@@ -38,10 +38,14 @@ type ConnectionManager struct {
 	targetOutgoing   int
 	activeIncoming   map[string]struct{}
 	maxIncoming      int
+	pendingAnchors   []*appmessage.NetAddress
 
 	stop                   uint32
 	connectionRequestsLock sync.RWMutex
 
+	misbehaviorScores     map[string]*misbehaviorScore
+	misbehaviorScoresLock sync.Mutex
+
 	resetLoopChan chan struct{}
 	loopTicker    *time.Ticker
 }
@@ -49,15 +53,16 @@ type ConnectionManager struct {
 // New instantiates a new instance of a ConnectionManager
 func New(cfg *config.Config, netAdapter *netadapter.NetAdapter, addressManager *addressmanager.AddressManager) (*ConnectionManager, error) {
 	c := &ConnectionManager{
-		cfg:              cfg,
-		netAdapter:       netAdapter,
-		addressManager:   addressManager,
-		activeRequested:  map[string]*connectionRequest{},
-		pendingRequested: map[string]*connectionRequest{},
-		activeOutgoing:   map[string]struct{}{},
-		activeIncoming:   map[string]struct{}{},
-		resetLoopChan:    make(chan struct{}),
-		loopTicker:       time.NewTicker(connectionsLoopInterval),
+		cfg:               cfg,
+		netAdapter:        netAdapter,
+		addressManager:    addressManager,
+		activeRequested:   map[string]*connectionRequest{},
+		pendingRequested:  map[string]*connectionRequest{},
+		activeOutgoing:    map[string]struct{}{},
+		activeIncoming:    map[string]struct{}{},
+		misbehaviorScores: map[string]*misbehaviorScore{},
+		resetLoopChan:     make(chan struct{}),
+		loopTicker:        time.NewTicker(connectionsLoopInterval),
 	}
 
 	connectPeers := cfg.AddPeers
@@ -67,6 +72,7 @@ func New(cfg *config.Config, netAdapter *netadapter.NetAdapter, addressManager *
 
 	c.maxIncoming = cfg.MaxInboundPeers
 	c.targetOutgoing = cfg.TargetOutboundPeers
+	c.pendingAnchors = addressManager.Anchors()
 
 	for _, connectPeer := range connectPeers {
 		c.pendingRequested[connectPeer] = &connectionRequest{
@@ -124,6 +130,8 @@ func (c *ConnectionManager) connectionsLoop() {
 
 		c.checkIncomingConnections(connSet)
 
+		c.pruneMisbehaviorScores()
+
 		c.waitTillNextIteration()
 	}
 }
@@ -257,16 +265,13 @@ func (c *ConnectionManager) seedFromDNS() {
 	cfg := c.cfg
 	if len(c.activeOutgoing) == 0 && !cfg.DisableDNSSeed {
 		dnsseed.SeedFromDNS(cfg.NetParams(), cfg.DNSSeed, false, nil,
-			cfg.Lookup, func(addresses []*appmessage.NetAddress) {
-				// Zuad uses a lookup of the dns seeder here. Since seeder returns
-				// IPs of nodes and not its own IP, we can not know real IP of
-				// source. So we'll take first returned address as source.
-				_ = c.addressManager.AddAddresses(addresses...)
+			cfg.Lookup, func(seeder string, addresses []*appmessage.NetAddress) {
+				_ = c.addressManager.AddAddressesFromSeeder(seeder, addresses...)
 			})
 
 		dnsseed.SeedFromGRPC(cfg.NetParams(), cfg.GRPCSeed, false, nil,
-			func(addresses []*appmessage.NetAddress) {
-				_ = c.addressManager.AddAddresses(addresses...)
+			func(seeder string, addresses []*appmessage.NetAddress) {
+				_ = c.addressManager.AddAddressesFromSeeder(seeder, addresses...)
 			})
 	}
 }
@@ -1,7 +1,10 @@
 package connmanager
 
+import "github.com/zuanet/zuad/infrastructure/network/netadapter"
+
 // checkIncomingConnections makes sure there's no more than maxIncoming incoming connections
-// if there are - it randomly disconnects enough to go below that number
+// if there are - it disconnects enough to go below that number, starting from the ones
+// with the highest misbehavior score
 func (c *ConnectionManager) checkIncomingConnections(incomingConnectionSet connectionSet) {
 	if len(incomingConnectionSet) <= c.maxIncoming {
 		return
@@ -11,14 +14,14 @@ func (c *ConnectionManager) checkIncomingConnections(incomingConnectionSet conne
 	log.Debugf("Got %d incoming connections while only %d are allowed. Disconnecting "+
 		"%d", len(incomingConnectionSet), c.maxIncoming, numConnectionsOverMax)
 
-	// randomly disconnect nodes until the number of incoming connections is smaller than maxIncoming
+	// Iterating over the set randomizes the order of connections with equal scores
+	connections := make([]*netadapter.NetConnection, 0, len(incomingConnectionSet))
 	for _, connection := range incomingConnectionSet {
+		connections = append(connections, connection)
+	}
+
+	for _, connection := range c.sortByMisbehaviorScore(connections)[:numConnectionsOverMax] {
 		log.Debugf("Disconnecting %s due to exceeding incoming connections", connection)
 		connection.Disconnect()
-
-		numConnectionsOverMax--
-		if numConnectionsOverMax == 0 {
-			break
-		}
 	}
 }
@@ -0,0 +1,178 @@
+package connmanager
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter"
+)
+
+// misbehaviorScoreHalfLife is the time it takes a misbehavior score to decay
+// to half of its value
+const misbehaviorScoreHalfLife = 10 * time.Minute
+
+// MisbehaviorResponse is the way the node responds to a misbehaving peer
+type MisbehaviorResponse int
+
+// These constants define the responses to misbehavior, from the mildest to
+// the harshest
+const (
+	// MisbehaviorResponseNone means the peer is treated as usual
+	MisbehaviorResponseNone MisbehaviorResponse = iota
+
+	// MisbehaviorResponseDeprioritize means the peer is the first to be
+	// disconnected when there are too many incoming connections
+	MisbehaviorResponseDeprioritize
+
+	// MisbehaviorResponseDisconnect means the peer is disconnected
+	MisbehaviorResponseDisconnect
+
+	// MisbehaviorResponseBan means the peer is banned and disconnected
+	MisbehaviorResponseBan
+)
+
+var misbehaviorResponseStrings = map[MisbehaviorResponse]string{
+	MisbehaviorResponseNone:         "none",
+	MisbehaviorResponseDeprioritize: "deprioritize",
+	MisbehaviorResponseDisconnect:   "disconnect",
+	MisbehaviorResponseBan:          "ban",
+}
+
+func (response MisbehaviorResponse) String() string {
+	return misbehaviorResponseStrings[response]
+}
+
+// misbehaviorScore is a score that decays exponentially over time
+type misbehaviorScore struct {
+	value      float64
+	lastUpdate time.Time
+}
+
+// valueAt returns the value of the score at the given time
+func (s *misbehaviorScore) valueAt(now time.Time) float64 {
+	elapsed := now.Sub(s.lastUpdate)
+	if elapsed <= 0 {
+		return s.value
+	}
+	return s.value * math.Exp2(-elapsed.Seconds()/misbehaviorScoreHalfLife.Seconds())
+}
+
+func (s *misbehaviorScore) increase(weight uint32, now time.Time) {
+	s.value = s.valueAt(now) + float64(weight)
+	s.lastUpdate = now
+}
+
+// misbehaviorResponse returns the response that the given score calls for.
+// Peers are deprioritized at a quarter of the ban threshold, disconnected at
+// half of it, and banned once they reach it.
+func (c *ConnectionManager) misbehaviorResponse(score uint32) MisbehaviorResponse {
+	banThreshold := c.cfg.BanThreshold
+	switch {
+	case score >= banThreshold && c.cfg.EnableBanning:
+		return MisbehaviorResponseBan
+	case score >= banThreshold/2:
+		return MisbehaviorResponseDisconnect
+	case score >= banThreshold/4:
+		return MisbehaviorResponseDeprioritize
+	default:
+		return MisbehaviorResponseNone
+	}
+}
+
+// AddMisbehavior adds the given weight to the misbehavior score of the IP of
+// the given netConnection, and returns the new score together with the
+// response that it calls for.
+//
+// Scores are kept per IP - or per onion or I2P address for peers in those
+// networks - rather than per connection, so that a peer can't clear its
+// score by reconnecting.
+func (c *ConnectionManager) AddMisbehavior(netConnection *netadapter.NetConnection,
+	weight uint32) (uint32, MisbehaviorResponse) {
+
+	c.misbehaviorScoresLock.Lock()
+	defer c.misbehaviorScoresLock.Unlock()
+
+	host := netConnection.NetAddress().HostName()
+	score, ok := c.misbehaviorScores[host]
+	if !ok {
+		score = &misbehaviorScore{}
+		c.misbehaviorScores[host] = score
+	}
+	score.increase(weight, time.Now())
+
+	value := uint32(score.value)
+	return value, c.misbehaviorResponseForAddress(netConnection.NetAddress(), value)
+}
+
+// misbehaviorResponseForAddress returns the response that the given score of
+// the given address calls for. Loopback peers are never banned: peers that
+// connect through an onion service or any other local proxy all come from a
+// loopback address, so banning one of them would ban all of them.
+func (c *ConnectionManager) misbehaviorResponseForAddress(netAddress *appmessage.NetAddress,
+	score uint32) MisbehaviorResponse {
+
+	response := c.misbehaviorResponse(score)
+	if response == MisbehaviorResponseBan && isLoopback(netAddress) {
+		return MisbehaviorResponseDisconnect
+	}
+	return response
+}
+
+func isLoopback(netAddress *appmessage.NetAddress) bool {
+	return netAddress.IsIP() && netAddress.IP.IsLoopback()
+}
+
+// MisbehaviorScore returns the current misbehavior score of the host of the
+// given netConnection
+func (c *ConnectionManager) MisbehaviorScore(netConnection *netadapter.NetConnection) uint32 {
+	c.misbehaviorScoresLock.Lock()
+	defer c.misbehaviorScoresLock.Unlock()
+
+	return c.misbehaviorScoreAt(netConnection, time.Now())
+}
+
+func (c *ConnectionManager) misbehaviorScoreAt(netConnection *netadapter.NetConnection, now time.Time) uint32 {
+	score, ok := c.misbehaviorScores[netConnection.NetAddress().HostName()]
+	if !ok {
+		return 0
+	}
+	return uint32(score.valueAt(now))
+}
+
+// pruneMisbehaviorScores removes the scores that have decayed to zero
+func (c *ConnectionManager) pruneMisbehaviorScores() {
+	c.misbehaviorScoresLock.Lock()
+	defer c.misbehaviorScoresLock.Unlock()
+
+	now := time.Now()
+	for ip, score := range c.misbehaviorScores {
+		if score.valueAt(now) < 1 {
+			delete(c.misbehaviorScores, ip)
+		}
+	}
+}
+
+// sortByMisbehaviorScore returns the given connections sorted from the
+// highest misbehavior score to the lowest. Loopback connections are sorted as
+// if their score was zero, since they all share a single score, and one of
+// them misbehaving shouldn't make all of them the first to be disconnected.
+func (c *ConnectionManager) sortByMisbehaviorScore(connections []*netadapter.NetConnection) []*netadapter.NetConnection {
+	c.misbehaviorScoresLock.Lock()
+	defer c.misbehaviorScoresLock.Unlock()
+
+	now := time.Now()
+	scores := make(map[*netadapter.NetConnection]uint32, len(connections))
+	for _, connection := range connections {
+		if isLoopback(connection.NetAddress()) {
+			scores[connection] = 0
+			continue
+		}
+		scores[connection] = c.misbehaviorScoreAt(connection, now)
+	}
+	sort.SliceStable(connections, func(i, j int) bool {
+		return scores[connections[i]] > scores[connections[j]]
+	})
+	return connections
+}
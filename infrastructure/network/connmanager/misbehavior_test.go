@@ -0,0 +1,90 @@
+package connmanager
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/infrastructure/config"
+)
+
+func TestMisbehaviorScoreDecay(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	score := &misbehaviorScore{}
+	score.increase(80, start)
+
+	tests := []struct {
+		elapsed       time.Duration
+		expectedValue uint32
+	}{
+		{elapsed: 0, expectedValue: 80},
+		{elapsed: -time.Minute, expectedValue: 80},
+		{elapsed: misbehaviorScoreHalfLife, expectedValue: 40},
+		{elapsed: 2 * misbehaviorScoreHalfLife, expectedValue: 20},
+		{elapsed: 10 * misbehaviorScoreHalfLife, expectedValue: 0},
+	}
+	for _, test := range tests {
+		value := uint32(score.valueAt(start.Add(test.elapsed)))
+		if value != test.expectedValue {
+			t.Errorf("expected a score of %d after %s, got %d", test.expectedValue, test.elapsed, value)
+		}
+	}
+
+	// Increasing the score adds to its decayed value
+	score.increase(10, start.Add(misbehaviorScoreHalfLife))
+	if value := uint32(score.valueAt(start.Add(misbehaviorScoreHalfLife))); value != 50 {
+		t.Errorf("expected a score of 50 after an increase, got %d", value)
+	}
+}
+
+func TestMisbehaviorResponse(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.BanThreshold = 100
+	cfg.EnableBanning = true
+	c := &ConnectionManager{cfg: cfg}
+
+	tests := []struct {
+		score            uint32
+		expectedResponse MisbehaviorResponse
+	}{
+		{score: 0, expectedResponse: MisbehaviorResponseNone},
+		{score: 24, expectedResponse: MisbehaviorResponseNone},
+		{score: 25, expectedResponse: MisbehaviorResponseDeprioritize},
+		{score: 50, expectedResponse: MisbehaviorResponseDisconnect},
+		{score: 99, expectedResponse: MisbehaviorResponseDisconnect},
+		{score: 100, expectedResponse: MisbehaviorResponseBan},
+		{score: 1000, expectedResponse: MisbehaviorResponseBan},
+	}
+	for _, test := range tests {
+		response := c.misbehaviorResponse(test.score)
+		if response != test.expectedResponse {
+			t.Errorf("expected %s for a score of %d, got %s", test.expectedResponse, test.score, response)
+		}
+	}
+
+	cfg.EnableBanning = false
+	if response := c.misbehaviorResponse(1000); response != MisbehaviorResponseDisconnect {
+		t.Errorf("expected %s when banning is disabled, got %s", MisbehaviorResponseDisconnect, response)
+	}
+}
+
+func TestMisbehaviorResponseForLoopback(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.BanThreshold = 100
+	cfg.EnableBanning = true
+	c := &ConnectionManager{cfg: cfg}
+
+	loopbackAddress := appmessage.NewNetAddressIPPort(net.ParseIP("127.0.0.1"), 46011)
+	if response := c.misbehaviorResponseForAddress(loopbackAddress, 100); response != MisbehaviorResponseDisconnect {
+		t.Errorf("expected %s for a loopback peer, got %s", MisbehaviorResponseDisconnect, response)
+	}
+	if response := c.misbehaviorResponseForAddress(loopbackAddress, 25); response != MisbehaviorResponseDeprioritize {
+		t.Errorf("expected %s for a loopback peer, got %s", MisbehaviorResponseDeprioritize, response)
+	}
+
+	remoteAddress := appmessage.NewNetAddressIPPort(net.ParseIP("203.0.113.1"), 46011)
+	if response := c.misbehaviorResponseForAddress(remoteAddress, 100); response != MisbehaviorResponseBan {
+		t.Errorf("expected %s for a remote peer, got %s", MisbehaviorResponseBan, response)
+	}
+}
@@ -2,13 +2,23 @@ package connmanager
 
 import "github.com/zuanet/zuad/app/appmessage"
 
+// outgoingCandidatesFactor is how many more addresses than needed are requested
+// from the address manager, so that addresses in network groups that we're
+// already connected to can be skipped
+const outgoingCandidatesFactor = 4
+
 // checkOutgoingConnections goes over all activeOutgoing and makes sure they are still active.
-// Then it opens connections so that we have targetOutgoing active connections
+// Then it opens connections so that we have targetOutgoing active connections, at most one
+// in every network group
 func (c *ConnectionManager) checkOutgoingConnections(connSet connectionSet) {
+	outgoingGroups := make(map[string]struct{})
+	outgoingAddresses := make([]*appmessage.NetAddress, 0, len(c.activeOutgoing))
 	for address := range c.activeOutgoing {
 		connection, ok := connSet.get(address)
 		if ok { // connection is still connected
 			connSet.remove(connection)
+			outgoingGroups[c.addressManager.GroupKey(connection.NetAddress())] = struct{}{}
+			outgoingAddresses = append(outgoingAddresses, connection.NetAddress())
 			continue
 		}
 
@@ -16,6 +26,12 @@ func (c *ConnectionManager) checkOutgoingConnections(connSet connectionSet) {
 		delete(c.activeOutgoing, address)
 	}
 
+	if c.pendingAnchors != nil {
+		c.connectToAnchors(outgoingGroups)
+	} else if c.targetOutgoing > 0 {
+		c.updateAnchors(outgoingAddresses)
+	}
+
 	connections := c.netAdapter.P2PConnections()
 	connectedAddresses := make([]*appmessage.NetAddress, len(connections))
 	for i, connection := range connections {
@@ -31,10 +47,25 @@ func (c *ConnectionManager) checkOutgoingConnections(connSet connectionSet) {
 		liveConnections, c.targetOutgoing, c.targetOutgoing-liveConnections)
 
 	connectionsNeededCount := c.targetOutgoing - len(c.activeOutgoing)
-	netAddresses := c.addressManager.RandomAddresses(connectionsNeededCount, connectedAddresses)
+	netAddresses := c.addressManager.RandomAddresses(connectionsNeededCount*outgoingCandidatesFactor, connectedAddresses)
 
+	diverseAddressCount := 0
 	for _, netAddress := range netAddresses {
-		addressString := netAddress.TCPAddress().String()
+		if len(c.activeOutgoing) >= c.targetOutgoing {
+			break
+		}
+		if !c.isReachable(netAddress) {
+			continue
+		}
+		group := c.addressManager.GroupKey(netAddress)
+		if _, ok := outgoingGroups[group]; ok {
+			log.Debugf("Skipping %s because we already have an outgoing connection in its network group %s",
+				netAddress, group)
+			continue
+		}
+		diverseAddressCount++
+
+		addressString := netAddress.String()
 
 		log.Debugf("Connecting to %s because we have %d outgoing connections and the target is "+
 			"%d", addressString, len(c.activeOutgoing), c.targetOutgoing)
@@ -45,17 +76,32 @@ func (c *ConnectionManager) checkOutgoingConnections(connSet connectionSet) {
 			c.addressManager.MarkConnectionFailure(netAddress)
 			continue
 		}
-		c.addressManager.MarkConnectionSuccess(netAddress)
 
 		c.activeOutgoing[addressString] = struct{}{}
+		outgoingGroups[group] = struct{}{}
 	}
 
-	if len(netAddresses) < connectionsNeededCount {
+	if diverseAddressCount < connectionsNeededCount {
 		log.Debugf("Need %d more outgoing connections - seeding addresses from DNS",
-			connectionsNeededCount-len(netAddresses))
+			connectionsNeededCount-diverseAddressCount)
 
 		// seedFromDNS is an asynchronous method, therefore addresses for connection
 		// should be available on next iteration
 		c.seedFromDNS()
 	}
 }
+
+// isReachable returns whether the node is able to connect to the given
+// address. Onion addresses are only reachable through a proxy. I2P addresses
+// are never reachable: they are only kept and relayed to peers that can
+// connect to them.
+func (c *ConnectionManager) isReachable(netAddress *appmessage.NetAddress) bool {
+	switch netAddress.Network {
+	case appmessage.NetworkIP:
+		return true
+	case appmessage.NetworkTorV3:
+		return c.cfg.OnionProxy != ""
+	default:
+		return false
+	}
+}
@@ -0,0 +1,33 @@
+package connmanager
+
+import (
+	"bytes"
+	"net"
+	"testing"
+
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/infrastructure/config"
+)
+
+func TestIsReachable(t *testing.T) {
+	ipAddress := appmessage.NewNetAddressIPPort(net.ParseIP("1.2.3.4"), 16111)
+	onionAddress := appmessage.NewNetAddressTorV3(bytes.Repeat([]byte{0xab}, appmessage.TorV3PublicKeySize), 16111)
+	i2pAddress := appmessage.NewNetAddressI2P(bytes.Repeat([]byte{0xab}, appmessage.I2PHashSize), 16111)
+
+	cfg := config.DefaultConfig()
+	c := &ConnectionManager{cfg: cfg}
+	if !c.isReachable(ipAddress) {
+		t.Fatalf("%s is unreachable", ipAddress)
+	}
+	if c.isReachable(onionAddress) {
+		t.Fatalf("%s is reachable without an onion proxy", onionAddress)
+	}
+	if c.isReachable(i2pAddress) {
+		t.Fatalf("%s is reachable", i2pAddress)
+	}
+
+	cfg.OnionProxy = "127.0.0.1:9050"
+	if !c.isReachable(onionAddress) {
+		t.Fatalf("%s is unreachable through an onion proxy", onionAddress)
+	}
+}
@@ -34,8 +34,9 @@ const (
 )
 
 // OnSeed is the signature of the callback function which is invoked when DNS
-// seeding is successful.
-type OnSeed func(addrs []*appmessage.NetAddress)
+// seeding is successful. seeder is the name of the seed that returned the
+// addresses.
+type OnSeed func(seeder string, addrs []*appmessage.NetAddress)
 
 // LookupFunc is the signature of the DNS lookup function.
 type LookupFunc func(string) ([]net.IP, error)
@@ -89,7 +90,7 @@ func SeedFromDNS(dagParams *dagconfig.Params, customSeed string, includeAllSubne
 					peer, uint16(intPort))
 			}
 
-			seedFn(addresses)
+			seedFn(dnsseed, addresses)
 		})
 	}
 }
@@ -154,7 +155,7 @@ func SeedFromGRPC(dagParams *dagconfig.Params, customSeed string, includeAllSubn
 					peer, uint16(intPort))
 			}
 
-			seedFn(addresses)
+			seedFn(host, addresses)
 		})
 	}
 }
@@ -7,9 +7,11 @@ import (
 	"github.com/zuanet/zuad/app/appmessage"
 	"github.com/zuanet/zuad/infrastructure/config"
 	"github.com/zuanet/zuad/infrastructure/network/netadapter/id"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/p2pencryption"
 	routerpkg "github.com/zuanet/zuad/infrastructure/network/netadapter/router"
 	"github.com/zuanet/zuad/infrastructure/network/netadapter/server"
 	"github.com/zuanet/zuad/infrastructure/network/netadapter/server/grpcserver"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/server/jsonrpcserver"
 	"github.com/pkg/errors"
 )
 
@@ -28,7 +30,9 @@ type NetAdapter struct {
 	p2pServer            server.P2PServer
 	p2pRouterInitializer RouterInitializer
 	rpcServer            server.Server
+	jsonRPCServer        server.Server
 	rpcRouterInitializer RouterInitializer
+	p2pIdentity          *p2pencryption.Identity
 	stop                 uint32
 
 	p2pConnections     map[*NetConnection]struct{}
@@ -42,7 +46,7 @@ func NewNetAdapter(cfg *config.Config) (*NetAdapter, error) {
 	if err != nil {
 		return nil, err
 	}
-	p2pServer, err := grpcserver.NewP2PServer(cfg.Listeners)
+	p2pServer, err := grpcserver.NewP2PServer(cfg.Listeners, cfg.Dial)
 	if err != nil {
 		return nil, err
 	}
@@ -62,6 +66,15 @@ func NewNetAdapter(cfg *config.Config) (*NetAdapter, error) {
 	adapter.p2pServer.SetOnConnectedHandler(adapter.onP2PConnectedHandler)
 	adapter.rpcServer.SetOnConnectedHandler(adapter.onRPCConnectedHandler)
 
+	if len(cfg.JSONRPCListeners) > 0 {
+		adapter.jsonRPCServer, err = jsonrpcserver.NewJSONRPCServer(cfg.JSONRPCListeners, cfg.JSONRPCAllowedOrigins,
+			cfg.RPCMaxWebsockets, cfg.RPCMaxConcurrentReqs)
+		if err != nil {
+			return nil, err
+		}
+		adapter.jsonRPCServer.SetOnConnectedHandler(adapter.onRPCConnectedHandler)
+	}
+
 	return &adapter, nil
 }
 
@@ -82,6 +95,12 @@ func (na *NetAdapter) Start() error {
 	if err != nil {
 		return err
 	}
+	if na.jsonRPCServer != nil {
+		err = na.jsonRPCServer.Start()
+		if err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -95,6 +114,12 @@ func (na *NetAdapter) Stop() error {
 	if err != nil {
 		return err
 	}
+	if na.jsonRPCServer != nil {
+		err = na.jsonRPCServer.Stop()
+		if err != nil {
+			return err
+		}
+	}
 	return na.rpcServer.Stop()
 }
 
@@ -172,6 +197,18 @@ func (na *NetAdapter) ID() *id.ID {
 	return na.id
 }
 
+// SetP2PIdentity sets the identity with which the net adapter
+// offers encryption to its peers
+func (na *NetAdapter) SetP2PIdentity(identity *p2pencryption.Identity) {
+	na.p2pIdentity = identity
+}
+
+// P2PIdentity returns the identity with which the net adapter offers
+// encryption to its peers, or nil if encryption is disabled
+func (na *NetAdapter) P2PIdentity() *p2pencryption.Identity {
+	return na.p2pIdentity
+}
+
 // P2PBroadcast sends the given `message` to every peer corresponding
 // to each NetConnection in the given netConnections
 func (na *NetAdapter) P2PBroadcast(netConnections []*NetConnection, message appmessage.Message) error {
@@ -8,6 +8,7 @@ import (
 	"sync/atomic"
 
 	"github.com/zuanet/zuad/infrastructure/network/netadapter/id"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/p2pencryption"
 	"github.com/zuanet/zuad/infrastructure/network/netadapter/server"
 )
 
@@ -18,6 +19,7 @@ type NetConnection struct {
 	router                *routerpkg.Router
 	onDisconnectedHandler server.OnDisconnectedHandler
 	isRouterClosed        uint32
+	remoteIdentityKey     []byte
 }
 
 func newNetConnection(connection server.Connection, routerInitializer RouterInitializer, name string) *NetConnection {
@@ -67,7 +69,7 @@ func (c *NetConnection) SetID(peerID *id.ID) {
 
 // Address returns the address associated with this connection
 func (c *NetConnection) Address() string {
-	return c.connection.Address().String()
+	return c.NetAddress().String()
 }
 
 // IsOutbound returns whether the connection is outbound
@@ -77,9 +79,37 @@ func (c *NetConnection) IsOutbound() bool {
 
 // NetAddress returns the NetAddress associated with this connection
 func (c *NetConnection) NetAddress() *appmessage.NetAddress {
+	if addressedConnection, ok := c.connection.(server.AddressedConnection); ok {
+		return addressedConnection.NetAddress()
+	}
 	return appmessage.NewNetAddress(c.connection.Address())
 }
 
+// EnableEncryption encrypts all further messages of this connection with the
+// given session. It returns an error if the underlying connection doesn't
+// support encryption
+func (c *NetConnection) EnableEncryption(session *p2pencryption.Session) error {
+	encryptableConnection, ok := c.connection.(server.EncryptableConnection)
+	if !ok {
+		return errors.Errorf("connection %s does not support encryption", c.connection)
+	}
+	c.remoteIdentityKey = session.RemoteIdentityKey()
+	encryptableConnection.EnableEncryption(session)
+	return nil
+}
+
+// IsEncrypted returns whether this connection is encrypted
+func (c *NetConnection) IsEncrypted() bool {
+	encryptableConnection, ok := c.connection.(server.EncryptableConnection)
+	return ok && encryptableConnection.IsEncrypted()
+}
+
+// RemoteIdentityKey returns the identity key the remote peer
+// proved to hold, or nil if the connection isn't encrypted
+func (c *NetConnection) RemoteIdentityKey() []byte {
+	return c.remoteIdentityKey
+}
+
 func (c *NetConnection) setOnDisconnectedHandler(onDisconnectedHandler server.OnDisconnectedHandler) {
 	c.onDisconnectedHandler = onDisconnectedHandler
 }
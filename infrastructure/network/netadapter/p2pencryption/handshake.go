@@ -0,0 +1,157 @@
+// Package p2pencryption implements the opportunistic encryption of P2P
+// connections. Peers that support it exchange an Offer in their version
+// messages, derive a Session from an X25519 key exchange, and from then on
+// send every message encrypted with ChaCha20-Poly1305. Every offer is signed
+// by the persistent Identity of its node, which authenticates the peer on
+// the other end of the session.
+package p2pencryption
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+)
+
+// protocolName is mixed into every signature and key derivation, so that keys
+// and signatures from other protocols can never be mistaken for ours
+const protocolName = "zuad-p2p-encryption-v1"
+
+// Offer is what a node advertises in its version message to offer encrypting
+// the connection: its identity and a fresh ephemeral key signed by it
+type Offer struct {
+	IdentityPublicKey  []byte
+	EphemeralPublicKey []byte
+	Signature          []byte
+}
+
+// Handshake holds the local side of the key exchange of a single connection
+type Handshake struct {
+	identity            *Identity
+	ephemeralPrivateKey []byte
+	offer               *Offer
+}
+
+// NewHandshake generates a new ephemeral key for a connection and signs it
+// with the given identity
+func NewHandshake(identity *Identity) (*Handshake, error) {
+	ephemeralPrivateKey := make([]byte, curve25519.ScalarSize)
+	_, err := io.ReadFull(rand.Reader, ephemeralPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	ephemeralPublicKey, err := curve25519.X25519(ephemeralPrivateKey, curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+
+	identityPublicKey := identity.PublicKey()
+	return &Handshake{
+		identity:            identity,
+		ephemeralPrivateKey: ephemeralPrivateKey,
+		offer: &Offer{
+			IdentityPublicKey:  identityPublicKey,
+			EphemeralPublicKey: ephemeralPublicKey,
+			Signature:          identity.sign(offerSignedData(identityPublicKey, ephemeralPublicKey)),
+		},
+	}, nil
+}
+
+// Offer returns the offer to put in the local version message
+func (h *Handshake) Offer() *Offer {
+	return h.offer
+}
+
+func offerSignedData(identityPublicKey []byte, ephemeralPublicKey []byte) []byte {
+	signedData := make([]byte, 0, len(protocolName)+len(identityPublicKey)+len(ephemeralPublicKey))
+	signedData = append(signedData, protocolName...)
+	signedData = append(signedData, identityPublicKey...)
+	return append(signedData, ephemeralPublicKey...)
+}
+
+// verifyOffer makes sure the remote ephemeral key was signed by the remote identity
+func verifyOffer(offer *Offer) error {
+	if len(offer.IdentityPublicKey) != ed25519.PublicKeySize {
+		return errors.Errorf("the identity public key is %d bytes long, but must be %d bytes long",
+			len(offer.IdentityPublicKey), ed25519.PublicKeySize)
+	}
+	if len(offer.EphemeralPublicKey) != curve25519.PointSize {
+		return errors.Errorf("the ephemeral public key is %d bytes long, but must be %d bytes long",
+			len(offer.EphemeralPublicKey), curve25519.PointSize)
+	}
+	signedData := offerSignedData(offer.IdentityPublicKey, offer.EphemeralPublicKey)
+	if !ed25519.Verify(offer.IdentityPublicKey, signedData, offer.Signature) {
+		return errors.New("the ephemeral public key is not signed by the identity")
+	}
+	return nil
+}
+
+// Complete verifies the offer of the remote peer and derives the session
+// that encrypts the connection. isOutbound tells whether the local node
+// initiated the connection, which decides the direction each derived key is
+// used for.
+//
+// localVersion and remoteVersion are the serialized version messages the
+// offers were exchanged in. They are bound into the derived keys, so if
+// anything in either version message was tampered with on the way, the two
+// sides end up with different keys and the first encrypted message fails to
+// decrypt.
+func (h *Handshake) Complete(remoteOffer *Offer, isOutbound bool, localVersion []byte,
+	remoteVersion []byte) (*Session, error) {
+
+	err := verifyOffer(remoteOffer)
+	if err != nil {
+		return nil, err
+	}
+
+	sharedSecret, err := curve25519.X25519(h.ephemeralPrivateKey, remoteOffer.EphemeralPublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute the shared secret")
+	}
+
+	outboundOffer, inboundOffer := h.offer, remoteOffer
+	outboundVersion, inboundVersion := localVersion, remoteVersion
+	if !isOutbound {
+		outboundOffer, inboundOffer = remoteOffer, h.offer
+		outboundVersion, inboundVersion = remoteVersion, localVersion
+	}
+	info := []byte(protocolName)
+	for _, offer := range []*Offer{outboundOffer, inboundOffer} {
+		info = append(info, offer.IdentityPublicKey...)
+		info = append(info, offer.EphemeralPublicKey...)
+	}
+
+	keys := make([]byte, 2*keySize)
+	salt := transcriptHash(outboundVersion, inboundVersion)
+	_, err = io.ReadFull(hkdf.New(sha256.New, sharedSecret, salt, info), keys)
+	if err != nil {
+		return nil, err
+	}
+	outboundKey, inboundKey := keys[:keySize], keys[keySize:]
+
+	sendKey, receiveKey := outboundKey, inboundKey
+	if !isOutbound {
+		sendKey, receiveKey = inboundKey, outboundKey
+	}
+	return newSession(sendKey, receiveKey, remoteOffer.IdentityPublicKey)
+}
+
+// transcriptHash hashes the version messages of both sides of the connection.
+// Every message is prefixed with its length, so that bytes can't be moved from
+// one message to the other without changing the hash.
+func transcriptHash(outboundVersion []byte, inboundVersion []byte) []byte {
+	hash := sha256.New()
+	hash.Write([]byte(protocolName))
+	for _, version := range [][]byte{outboundVersion, inboundVersion} {
+		var length [8]byte
+		binary.LittleEndian.PutUint64(length[:], uint64(len(version)))
+		hash.Write(length[:])
+		hash.Write(version)
+	}
+	return hash.Sum(nil)
+}
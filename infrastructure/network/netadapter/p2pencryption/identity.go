@@ -0,0 +1,79 @@
+package p2pencryption
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// IdentityFileName is the name of the file in the app directory that holds the
+// node's identity key
+const IdentityFileName = "p2p_identity.key"
+
+// Identity is the long-term key a node authenticates itself with on encrypted
+// connections. It stays the same across restarts, so peers can recognize the
+// node even if its address changes.
+type Identity struct {
+	privateKey ed25519.PrivateKey
+}
+
+// GenerateIdentity generates a new random identity
+func GenerateIdentity() (*Identity, error) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return &Identity{privateKey: privateKey}, nil
+}
+
+// LoadOrCreateIdentity loads the identity stored in the given file, or
+// generates a new one and stores it there if the file doesn't exist
+func LoadOrCreateIdentity(path string) (*Identity, error) {
+	content, err := os.ReadFile(path)
+	if err == nil {
+		return deserializeIdentity(content)
+	}
+	if !os.IsNotExist(err) {
+		return nil, errors.Wrapf(err, "could not read the P2P identity from %s", path)
+	}
+
+	identity, err := GenerateIdentity()
+	if err != nil {
+		return nil, err
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return nil, err
+	}
+	err = os.WriteFile(path, []byte(hex.EncodeToString(identity.privateKey.Seed())+"\n"), 0600)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not write the P2P identity to %s", path)
+	}
+	return identity, nil
+}
+
+func deserializeIdentity(content []byte) (*Identity, error) {
+	seed, err := hex.DecodeString(strings.TrimSpace(string(content)))
+	if err != nil {
+		return nil, errors.Wrap(err, "the P2P identity file is malformed")
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, errors.Errorf("the P2P identity key is %d bytes long, but must be %d bytes long",
+			len(seed), ed25519.SeedSize)
+	}
+	return &Identity{privateKey: ed25519.NewKeyFromSeed(seed)}, nil
+}
+
+// PublicKey returns the public key of the identity
+func (identity *Identity) PublicKey() ed25519.PublicKey {
+	return identity.privateKey.Public().(ed25519.PublicKey)
+}
+
+func (identity *Identity) sign(message []byte) []byte {
+	return ed25519.Sign(identity.privateKey, message)
+}
@@ -0,0 +1,198 @@
+package p2pencryption
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newHandshakeForTest(t *testing.T) *Handshake {
+	identity, err := GenerateIdentity()
+	if err != nil {
+		t.Fatalf("GenerateIdentity: %+v", err)
+	}
+	handshake, err := NewHandshake(identity)
+	if err != nil {
+		t.Fatalf("NewHandshake: %+v", err)
+	}
+	return handshake
+}
+
+var (
+	outboundVersionForTest = []byte("outbound version")
+	inboundVersionForTest  = []byte("inbound version")
+)
+
+// completeHandshakes returns the sessions of the outbound and the inbound side of a connection
+func completeHandshakes(t *testing.T, outbound *Handshake, inbound *Handshake) (*Session, *Session) {
+	outboundSession, err := outbound.Complete(inbound.Offer(), true, outboundVersionForTest, inboundVersionForTest)
+	if err != nil {
+		t.Fatalf("Complete (outbound): %+v", err)
+	}
+	inboundSession, err := inbound.Complete(outbound.Offer(), false, inboundVersionForTest, outboundVersionForTest)
+	if err != nil {
+		t.Fatalf("Complete (inbound): %+v", err)
+	}
+	return outboundSession, inboundSession
+}
+
+func TestSession(t *testing.T) {
+	outbound, inbound := newHandshakeForTest(t), newHandshakeForTest(t)
+	outboundSession, inboundSession := completeHandshakes(t, outbound, inbound)
+
+	if !bytes.Equal(outboundSession.RemoteIdentityKey(), inbound.Offer().IdentityPublicKey) {
+		t.Fatalf("the outbound session has the wrong remote identity")
+	}
+	if !bytes.Equal(inboundSession.RemoteIdentityKey(), outbound.Offer().IdentityPublicKey) {
+		t.Fatalf("the inbound session has the wrong remote identity")
+	}
+
+	for i := 0; i < 3; i++ {
+		message := []byte{byte(i), 1, 2, 3}
+		decrypted, err := inboundSession.Decrypt(outboundSession.Encrypt(message))
+		if err != nil {
+			t.Fatalf("Decrypt (inbound): %+v", err)
+		}
+		if !bytes.Equal(decrypted, message) {
+			t.Fatalf("inbound decrypted %x instead of %x", decrypted, message)
+		}
+
+		decrypted, err = outboundSession.Decrypt(inboundSession.Encrypt(message))
+		if err != nil {
+			t.Fatalf("Decrypt (outbound): %+v", err)
+		}
+		if !bytes.Equal(decrypted, message) {
+			t.Fatalf("outbound decrypted %x instead of %x", decrypted, message)
+		}
+	}
+}
+
+func TestSessionRejectsTamperedAndReplayedMessages(t *testing.T) {
+	outboundSession, inboundSession := completeHandshakes(t, newHandshakeForTest(t), newHandshakeForTest(t))
+
+	ciphertext := outboundSession.Encrypt([]byte("block"))
+	tampered := append([]byte{}, ciphertext...)
+	tampered[0] ^= 1
+	_, err := inboundSession.Decrypt(tampered)
+	if err == nil {
+		t.Fatalf("a tampered message was decrypted")
+	}
+
+	_, err = inboundSession.Decrypt(ciphertext)
+	if err != nil {
+		t.Fatalf("Decrypt: %+v", err)
+	}
+	_, err = inboundSession.Decrypt(ciphertext)
+	if err == nil {
+		t.Fatalf("a replayed message was decrypted")
+	}
+
+	// A message must not be decryptable by its own sender
+	_, err = outboundSession.Decrypt(outboundSession.Encrypt([]byte("block")))
+	if err == nil {
+		t.Fatalf("a message was decrypted with the key of the opposite direction")
+	}
+}
+
+func TestSessionBindsVersionMessages(t *testing.T) {
+	outbound, inbound := newHandshakeForTest(t), newHandshakeForTest(t)
+
+	// The inbound side got a version message that was tampered with on the way
+	tamperedOutboundVersion := []byte("outbound versioN")
+	outboundSession, err := outbound.Complete(inbound.Offer(), true, outboundVersionForTest, inboundVersionForTest)
+	if err != nil {
+		t.Fatalf("Complete (outbound): %+v", err)
+	}
+	inboundSession, err := inbound.Complete(outbound.Offer(), false, inboundVersionForTest, tamperedOutboundVersion)
+	if err != nil {
+		t.Fatalf("Complete (inbound): %+v", err)
+	}
+
+	_, err = inboundSession.Decrypt(outboundSession.Encrypt([]byte("verack")))
+	if err == nil {
+		t.Fatalf("a message was decrypted even though the version messages of the sides differ")
+	}
+}
+
+func TestCompleteRejectsInvalidOffers(t *testing.T) {
+	local, remote := newHandshakeForTest(t), newHandshakeForTest(t)
+	otherEphemeralKey := newHandshakeForTest(t).Offer().EphemeralPublicKey
+
+	tests := []struct {
+		name  string
+		offer *Offer
+	}{
+		{
+			name: "short identity key",
+			offer: &Offer{
+				IdentityPublicKey:  remote.Offer().IdentityPublicKey[1:],
+				EphemeralPublicKey: remote.Offer().EphemeralPublicKey,
+				Signature:          remote.Offer().Signature,
+			},
+		},
+		{
+			name: "short ephemeral key",
+			offer: &Offer{
+				IdentityPublicKey:  remote.Offer().IdentityPublicKey,
+				EphemeralPublicKey: remote.Offer().EphemeralPublicKey[1:],
+				Signature:          remote.Offer().Signature,
+			},
+		},
+		{
+			name: "ephemeral key not signed by the identity",
+			offer: &Offer{
+				IdentityPublicKey:  remote.Offer().IdentityPublicKey,
+				EphemeralPublicKey: otherEphemeralKey,
+				Signature:          remote.Offer().Signature,
+			},
+		},
+		{
+			name: "missing signature",
+			offer: &Offer{
+				IdentityPublicKey:  remote.Offer().IdentityPublicKey,
+				EphemeralPublicKey: remote.Offer().EphemeralPublicKey,
+			},
+		},
+	}
+	for _, test := range tests {
+		_, err := local.Complete(test.offer, true, outboundVersionForTest, inboundVersionForTest)
+		if err == nil {
+			t.Errorf("%s: Complete unexpectedly succeeded", test.name)
+		}
+	}
+}
+
+func TestLoadOrCreateIdentity(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "appdir", IdentityFileName)
+
+	identity, err := LoadOrCreateIdentity(path)
+	if err != nil {
+		t.Fatalf("LoadOrCreateIdentity (create): %+v", err)
+	}
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat: %+v", err)
+	}
+	if fileInfo.Mode().Perm()&0077 != 0 {
+		t.Fatalf("the identity file is accessible by other users: %s", fileInfo.Mode())
+	}
+
+	loadedIdentity, err := LoadOrCreateIdentity(path)
+	if err != nil {
+		t.Fatalf("LoadOrCreateIdentity (load): %+v", err)
+	}
+	if !bytes.Equal(identity.PublicKey(), loadedIdentity.PublicKey()) {
+		t.Fatalf("the loaded identity %x is different from the created identity %x",
+			loadedIdentity.PublicKey(), identity.PublicKey())
+	}
+
+	err = os.WriteFile(path, []byte("not a key"), 0600)
+	if err != nil {
+		t.Fatalf("WriteFile: %+v", err)
+	}
+	_, err = LoadOrCreateIdentity(path)
+	if err == nil {
+		t.Fatalf("a corrupted identity file was loaded")
+	}
+}
@@ -0,0 +1,72 @@
+package p2pencryption
+
+import (
+	"crypto/cipher"
+	"crypto/ed25519"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const keySize = chacha20poly1305.KeySize
+
+// Session encrypts the messages sent over a connection and decrypts the
+// messages received from it.
+//
+// Every direction has its own key and a counter that serves as the nonce, so
+// dropped, replayed or reordered messages fail to decrypt. This relies on the
+// underlying stream delivering messages in order, which means Encrypt and
+// Decrypt must each be called by a single goroutine.
+type Session struct {
+	sendAEAD          cipher.AEAD
+	receiveAEAD       cipher.AEAD
+	sendCounter       uint64
+	receiveCounter    uint64
+	remoteIdentityKey ed25519.PublicKey
+}
+
+func newSession(sendKey []byte, receiveKey []byte, remoteIdentityKey ed25519.PublicKey) (*Session, error) {
+	sendAEAD, err := chacha20poly1305.New(sendKey)
+	if err != nil {
+		return nil, err
+	}
+	receiveAEAD, err := chacha20poly1305.New(receiveKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Session{
+		sendAEAD:          sendAEAD,
+		receiveAEAD:       receiveAEAD,
+		remoteIdentityKey: remoteIdentityKey,
+	}, nil
+}
+
+// RemoteIdentityKey returns the identity public key the remote peer proved it holds
+func (s *Session) RemoteIdentityKey() ed25519.PublicKey {
+	return s.remoteIdentityKey
+}
+
+// Encrypt encrypts the next message sent over the connection
+func (s *Session) Encrypt(plaintext []byte) []byte {
+	nonce := counterNonce(s.sendCounter)
+	s.sendCounter++
+	return s.sendAEAD.Seal(nil, nonce, plaintext, nil)
+}
+
+// Decrypt decrypts and authenticates the next message received from the connection
+func (s *Session) Decrypt(ciphertext []byte) ([]byte, error) {
+	nonce := counterNonce(s.receiveCounter)
+	plaintext, err := s.receiveAEAD.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not decrypt message number %d", s.receiveCounter)
+	}
+	s.receiveCounter++
+	return plaintext, nil
+}
+
+func counterNonce(counter uint64) []byte {
+	nonce := make([]byte, chacha20poly1305.NonceSize)
+	binary.LittleEndian.PutUint64(nonce, counter)
+	return nonce
+}
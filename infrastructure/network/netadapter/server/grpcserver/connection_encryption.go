@@ -0,0 +1,123 @@
+package grpcserver
+
+import (
+	"sync"
+	"time"
+
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/p2pencryption"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/server/grpcserver/protowire"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// encryptionSessionTimeout is how long the receive loop waits for the
+// handshake to set up the session once an encrypted message arrived
+const encryptionSessionTimeout = 30 * time.Second
+
+// connectionEncryption keeps track of the encryption of a single connection.
+//
+// Version messages are always sent in the clear, since the keys are exchanged
+// through them. Every other message is encrypted once the handshake enabled a
+// session and the local version message was sent, so that the remote peer
+// always receives our version message - which it needs to derive the
+// session - before any encrypted message.
+type connectionEncryption struct {
+	lock               sync.Mutex
+	session            *p2pencryption.Session
+	sessionEnabled     chan struct{}
+	isLocalVersionSent bool
+}
+
+func newConnectionEncryption() *connectionEncryption {
+	return &connectionEncryption{sessionEnabled: make(chan struct{})}
+}
+
+// EnableEncryption encrypts all further messages of the connection with the
+// given session
+//
+// This is part of the EncryptableConnection interface
+func (c *gRPCConnection) EnableEncryption(session *p2pencryption.Session) {
+	c.encryption.lock.Lock()
+	defer c.encryption.lock.Unlock()
+
+	if c.encryption.session != nil {
+		return
+	}
+	c.encryption.session = session
+	close(c.encryption.sessionEnabled)
+}
+
+// IsEncrypted returns whether the handshake enabled encryption for this connection
+//
+// This is part of the EncryptableConnection interface
+func (c *gRPCConnection) IsEncrypted() bool {
+	c.encryption.lock.Lock()
+	defer c.encryption.lock.Unlock()
+
+	return c.encryption.session != nil
+}
+
+func (c *gRPCConnection) encryptOutgoing(message appmessage.Message,
+	messageProto *protowire.ZuadMessage) (*protowire.ZuadMessage, error) {
+
+	c.encryption.lock.Lock()
+	defer c.encryption.lock.Unlock()
+
+	if message.Command() == appmessage.CmdVersion {
+		c.encryption.isLocalVersionSent = true
+		return messageProto, nil
+	}
+	if c.encryption.session == nil || !c.encryption.isLocalVersionSent {
+		return messageProto, nil
+	}
+
+	plaintext, err := proto.Marshal(messageProto)
+	if err != nil {
+		return nil, err
+	}
+	return &protowire.ZuadMessage{
+		Payload: &protowire.ZuadMessage_Encrypted{
+			Encrypted: &protowire.EncryptedMessage{Ciphertext: c.encryption.session.Encrypt(plaintext)},
+		},
+	}, nil
+}
+
+func (c *gRPCConnection) decryptIncoming(protoMessage *protowire.ZuadMessage) (*protowire.ZuadMessage, error) {
+	encryptedPayload, isEncrypted := protoMessage.Payload.(*protowire.ZuadMessage_Encrypted)
+	if !isEncrypted {
+		_, isVersion := protoMessage.Payload.(*protowire.ZuadMessage_Version)
+		if !isVersion && c.IsEncrypted() {
+			return nil, errors.New("received an unencrypted message on an encrypted connection")
+		}
+		return protoMessage, nil
+	}
+
+	// The remote peer encrypts only after sending its version message, but the
+	// handshake might still be processing it
+	select {
+	case <-c.encryption.sessionEnabled:
+	case <-c.stopChan:
+		return nil, errors.New("the connection was closed while waiting for the encryption session")
+	case <-time.After(encryptionSessionTimeout):
+		return nil, errors.New("received an encrypted message on a connection without an encryption session")
+	}
+
+	c.encryption.lock.Lock()
+	session := c.encryption.session
+	c.encryption.lock.Unlock()
+
+	plaintext, err := session.Decrypt(encryptedPayload.Encrypted.GetCiphertext())
+	if err != nil {
+		return nil, err
+	}
+	decryptedMessage := &protowire.ZuadMessage{}
+	err = proto.Unmarshal(plaintext, decryptedMessage)
+	if err != nil {
+		return nil, err
+	}
+	if _, isEncrypted := decryptedMessage.Payload.(*protowire.ZuadMessage_Encrypted); isEncrypted {
+		return nil, errors.New("received a doubly encrypted message")
+	}
+	return decryptedMessage, nil
+}
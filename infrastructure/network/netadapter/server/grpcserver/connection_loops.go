@@ -66,6 +66,10 @@ func (c *gRPCConnection) sendLoop() error {
 		if err != nil {
 			return err
 		}
+		messageProto, err = c.encryptOutgoing(message, messageProto)
+		if err != nil {
+			return err
+		}
 
 		err = c.send(messageProto)
 		if err != nil {
@@ -85,6 +89,13 @@ func (c *gRPCConnection) receiveLoop() error {
 			}
 			return err
 		}
+		protoMessage, err = c.decryptIncoming(protoMessage)
+		if err != nil {
+			if c.onInvalidMessageHandler != nil {
+				c.onInvalidMessageHandler(err)
+			}
+			return err
+		}
 		message, err := protoMessage.ToAppMessage()
 		if err != nil {
 			if c.onInvalidMessageHandler != nil {
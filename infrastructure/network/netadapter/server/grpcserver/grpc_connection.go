@@ -5,8 +5,10 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"github.com/zuanet/zuad/app/appmessage"
 	"github.com/zuanet/zuad/infrastructure/network/netadapter/router"
 	"github.com/zuanet/zuad/infrastructure/network/netadapter/server/grpcserver/protowire"
+	"github.com/zuanet/zuad/util/mstime"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -18,6 +20,7 @@ import (
 type gRPCConnection struct {
 	server                   *gRPCServer
 	address                  *net.TCPAddr
+	netAddress               *appmessage.NetAddress
 	stream                   grpcStream
 	router                   *router.Router
 	lowLevelClientConnection *grpc.ClientConn
@@ -33,6 +36,8 @@ type gRPCConnection struct {
 	onDisconnectedHandler   server.OnDisconnectedHandler
 	onInvalidMessageHandler server.OnInvalidMessageHandler
 
+	encryption *connectionEncryption
+
 	isConnected uint32
 }
 
@@ -41,15 +46,23 @@ type grpcStream interface {
 	Recv() (*protowire.ZuadMessage, error)
 }
 
-func newConnection(server *gRPCServer, address *net.TCPAddr, stream grpcStream,
+// newConnection returns a new connection to the given address. netAddress
+// is the address the connection was dialed to. It may be nil, in which case
+// it's derived from address.
+func newConnection(server *gRPCServer, address *net.TCPAddr, netAddress *appmessage.NetAddress, stream grpcStream,
 	lowLevelClientConnection *grpc.ClientConn) *gRPCConnection {
+	if netAddress == nil {
+		netAddress = appmessage.NewNetAddress(address)
+	}
 	connection := &gRPCConnection{
 		server:                   server,
 		address:                  address,
+		netAddress:               netAddress,
 		stream:                   stream,
 		stopChan:                 make(chan struct{}),
 		isConnected:              1,
 		lowLevelClientConnection: lowLevelClientConnection,
+		encryption:               newConnectionEncryption(),
 	}
 
 	return connection
@@ -69,19 +82,19 @@ func (c *gRPCConnection) Start(router *router.Router) {
 			if isStatus {
 				switch status.Code() {
 				case codes.Canceled:
-					log.Debugf("connectionLoop canceled connection for %s: %s", c.address, err)
+					log.Debugf("connectionLoop canceled connection for %s: %s", c.netAddress, err)
 				default:
-					log.Errorf("status error from connectionLoops for %s: %s", c.address, err)
+					log.Errorf("status error from connectionLoops for %s: %s", c.netAddress, err)
 				}
 			} else {
-				log.Errorf("unknown error from connectionLoops for %s: %s", c.address, err)
+				log.Errorf("unknown error from connectionLoops for %s: %s", c.netAddress, err)
 			}
 		}
 	})
 }
 
 func (c *gRPCConnection) String() string {
-	return c.Address().String()
+	return c.netAddress.String()
 }
 
 func (c *gRPCConnection) IsConnected() bool {
@@ -127,6 +140,16 @@ func (c *gRPCConnection) Address() *net.TCPAddr {
 	return c.address
 }
 
+// NetAddress returns the address of the remote peer, timestamped with the
+// current time
+//
+// This is part of the AddressedConnection interface
+func (c *gRPCConnection) NetAddress() *appmessage.NetAddress {
+	netAddress := *c.netAddress
+	netAddress.Timestamp = mstime.Now()
+	return &netAddress
+}
+
 func (c *gRPCConnection) receive() (*protowire.ZuadMessage, error) {
 	// We use RLock here and in send() because they can work
 	// in parallel. closeSend(), however, must not have either
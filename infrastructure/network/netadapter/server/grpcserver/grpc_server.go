@@ -109,7 +109,7 @@ func (s *gRPCServer) handleInboundConnection(ctx context.Context, stream grpcStr
 		return errors.Errorf("non-tcp connections are not supported")
 	}
 
-	connection := newConnection(s, tcpAddress, stream, nil)
+	connection := newConnection(s, tcpAddress, nil, stream, nil)
 
 	err = s.onConnectedHandler(connection)
 	if err != nil {
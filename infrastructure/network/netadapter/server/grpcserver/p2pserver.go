@@ -2,6 +2,7 @@ package grpcserver
 
 import (
 	"context"
+	"github.com/zuanet/zuad/app/appmessage"
 	"github.com/zuanet/zuad/infrastructure/network/netadapter/server"
 	"github.com/zuanet/zuad/infrastructure/network/netadapter/server/grpcserver/protowire"
 	"github.com/zuanet/zuad/util/panics"
@@ -10,26 +11,39 @@ import (
 	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/peer"
 	"net"
+	"strconv"
 	"time"
 )
 
+// DialFunc opens a TCP connection to the given address. It's used to route
+// outgoing P2P connections through SOCKS proxies, such as Tor
+type DialFunc func(network, address string, timeout time.Duration) (net.Conn, error)
+
 type p2pServer struct {
 	protowire.UnimplementedP2PServer
 	gRPCServer
+	dial DialFunc
 }
 
 const p2pMaxMessageSize = 1024 * 1024 * 1024 // 1GB
 
+// onionDialTimeout is the timeout for connecting to onion addresses
+const onionDialTimeout = 30 * time.Second
+
 // p2pMaxInboundConnections is the max amount of inbound connections for the P2P server.
 // Note that inbound connections are not limited by the gRPC server. (A value of 0 means
 // unlimited inbound connections.) The P2P limiting logic is more applicative, and as such
 // is handled in the ConnectionManager instead.
 const p2pMaxInboundConnections = 0
 
-// NewP2PServer creates a new P2PServer
-func NewP2PServer(listeningAddresses []string) (server.P2PServer, error) {
+// NewP2PServer creates a new P2PServer. Outgoing connections are opened with
+// the given dial function, or with net.DialTimeout if it's nil
+func NewP2PServer(listeningAddresses []string, dial DialFunc) (server.P2PServer, error) {
+	if dial == nil {
+		dial = net.DialTimeout
+	}
 	gRPCServer := newGRPCServer(listeningAddresses, p2pMaxMessageSize, p2pMaxInboundConnections, "P2P")
-	p2pServer := &p2pServer{gRPCServer: *gRPCServer}
+	p2pServer := &p2pServer{gRPCServer: *gRPCServer, dial: dial}
 	protowire.RegisterP2PServer(gRPCServer.server, p2pServer)
 	return p2pServer, nil
 }
@@ -45,11 +59,19 @@ func (p *p2pServer) MessageStream(stream protowire.P2P_MessageStreamServer) erro
 func (p *p2pServer) Connect(address string) (server.Connection, error) {
 	log.Debugf("%s Dialing to %s", p.name, address)
 
-	const dialTimeout = 1 * time.Second
+	// netAddress is nil if the host of the address is a name that isn't an
+	// IP, onion or I2P address
+	netAddress := parseDialedAddress(address)
+	dialTimeout := 1 * time.Second
+	if netAddress != nil && netAddress.Network == appmessage.NetworkTorV3 {
+		// Building a circuit to an onion service takes much longer
+		dialTimeout = onionDialTimeout
+	}
 	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
 	defer cancel()
 
-	gRPCClientConnection, err := grpc.DialContext(ctx, address, grpc.WithInsecure(), grpc.WithBlock())
+	gRPCClientConnection, err := grpc.DialContext(ctx, address, grpc.WithInsecure(), grpc.WithBlock(),
+		grpc.WithContextDialer(p.dialContext))
 	if err != nil {
 		return nil, errors.Wrapf(err, "%s error connecting to %s", p.name, address)
 	}
@@ -65,12 +87,17 @@ func (p *p2pServer) Connect(address string) (server.Connection, error) {
 	if !ok {
 		return nil, errors.Errorf("%s error getting stream peer info from context for %s", p.name, address)
 	}
+	// Connections through a proxy don't have the TCP address of the peer,
+	// so their address is taken from the dialed address instead. Direct
+	// connections use the TCP address they were actually made to.
 	tcpAddress, ok := peerInfo.Addr.(*net.TCPAddr)
-	if !ok {
+	if ok {
+		netAddress = nil
+	} else if netAddress == nil {
 		return nil, errors.Errorf("non-tcp addresses are not supported")
 	}
 
-	connection := newConnection(&p.gRPCServer, tcpAddress, stream, gRPCClientConnection)
+	connection := newConnection(&p.gRPCServer, tcpAddress, netAddress, stream, gRPCClientConnection)
 
 	err = p.onConnectedHandler(connection)
 	if err != nil {
@@ -81,3 +108,29 @@ func (p *p2pServer) Connect(address string) (server.Connection, error) {
 
 	return connection, nil
 }
+
+func (p *p2pServer) dialContext(ctx context.Context, address string) (net.Conn, error) {
+	timeout := time.Duration(0)
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	return p.dial("tcp", address, timeout)
+}
+
+// parseDialedAddress returns the NetAddress of the given dialed address, or
+// nil if its host is a name that isn't an IP, onion or I2P address
+func parseDialedAddress(address string) *appmessage.NetAddress {
+	host, portString, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil
+	}
+	port, err := strconv.ParseUint(portString, 10, 16)
+	if err != nil {
+		return nil
+	}
+	netAddress, err := appmessage.ParseNetAddress(host, uint16(port))
+	if err != nil {
+		return nil
+	}
+	return netAddress
+}
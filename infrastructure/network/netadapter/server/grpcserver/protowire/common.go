@@ -103,9 +103,21 @@ func (x *NetAddress) toAppMessage() (*appmessage.NetAddress, error) {
 	if x.Port > math.MaxUint16 {
 		return nil, errors.Errorf("port number is larger than %d", math.MaxUint16)
 	}
+	if x.Network > math.MaxUint8 {
+		return nil, errors.Errorf("network ID is larger than %d", math.MaxUint8)
+	}
+	// Addresses in unknown networks are passed on as-is, and are
+	// later dropped by the address manager
+	network := appmessage.NetworkID(x.Network)
+	if hostSize, ok := network.HostSize(); ok && len(x.Host) != hostSize {
+		return nil, errors.Errorf("expected a host of %d bytes for a %s address but got %d",
+			hostSize, network, len(x.Host))
+	}
 	return &appmessage.NetAddress{
 		Timestamp: mstime.UnixMilliseconds(x.Timestamp),
+		Network:   network,
 		IP:        x.Ip,
+		Host:      x.Host,
 		Port:      uint16(x.Port),
 	}, nil
 }
@@ -115,6 +127,8 @@ func appMessageNetAddressToProto(address *appmessage.NetAddress) *NetAddress {
 		Timestamp: address.Timestamp.UnixMilliseconds(),
 		Ip:        address.IP,
 		Port:      uint32(address.Port),
+		Network:   uint32(address.Network),
+		Host:      address.Host,
 	}
 }
 
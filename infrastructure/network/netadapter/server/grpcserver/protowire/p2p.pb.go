@@ -130,6 +130,12 @@ type NetAddress struct {
 	Timestamp int64  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	Ip        []byte `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`
 	Port      uint32 `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
+	// network is the network of the address: 0 for IP addresses, 1 for Tor v3
+	// onion services and 2 for I2P. Peers that don't know it read addresses in
+	// other networks as addresses with an empty ip, and drop them
+	Network uint32 `protobuf:"varint,5,opt,name=network,proto3" json:"network,omitempty"`
+	// host is the address within networks other than IP
+	Host []byte `protobuf:"bytes,6,opt,name=host,proto3" json:"host,omitempty"`
 }
 
 func (x *NetAddress) Reset() {
@@ -185,6 +191,20 @@ func (x *NetAddress) GetPort() uint32 {
 	return 0
 }
 
+func (x *NetAddress) GetNetwork() uint32 {
+	if x != nil {
+		return x.Network
+	}
+	return 0
+}
+
+func (x *NetAddress) GetHost() []byte {
+	if x != nil {
+		return x.Host
+	}
+	return nil
+}
+
 type SubnetworkId struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -237,13 +257,17 @@ type TransactionMessage struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Version      uint32               `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
-	Inputs       []*TransactionInput  `protobuf:"bytes,2,rep,name=inputs,proto3" json:"inputs,omitempty"`
-	Outputs      []*TransactionOutput `protobuf:"bytes,3,rep,name=outputs,proto3" json:"outputs,omitempty"`
-	LockTime     uint64               `protobuf:"varint,4,opt,name=lockTime,proto3" json:"lockTime,omitempty"`
-	SubnetworkId *SubnetworkId        `protobuf:"bytes,5,opt,name=subnetworkId,proto3" json:"subnetworkId,omitempty"`
-	Gas          uint64               `protobuf:"varint,6,opt,name=gas,proto3" json:"gas,omitempty"`
-	Payload      []byte               `protobuf:"bytes,8,opt,name=payload,proto3" json:"payload,omitempty"`
+	Version       uint32               `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Inputs        []*TransactionInput  `protobuf:"bytes,2,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	Outputs       []*TransactionOutput `protobuf:"bytes,3,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	LockTime      uint64               `protobuf:"varint,4,opt,name=lockTime,proto3" json:"lockTime,omitempty"`
+	SubnetworkId  *SubnetworkId        `protobuf:"bytes,5,opt,name=subnetworkId,proto3" json:"subnetworkId,omitempty"`
+	Gas           uint64               `protobuf:"varint,6,opt,name=gas,proto3" json:"gas,omitempty"`
+	Payload       []byte               `protobuf:"bytes,8,opt,name=payload,proto3" json:"payload,omitempty"`
+	VprogVersion  uint32               `protobuf:"varint,9,opt,name=vprogVersion,proto3" json:"vprogVersion,omitempty"`
+	VprogCode     []byte               `protobuf:"bytes,10,opt,name=vprogCode,proto3" json:"vprogCode,omitempty"`
+	VprogData     []byte               `protobuf:"bytes,11,opt,name=vprogData,proto3" json:"vprogData,omitempty"`
+	VprogGasLimit uint64               `protobuf:"varint,12,opt,name=vprogGasLimit,proto3" json:"vprogGasLimit,omitempty"`
 }
 
 func (x *TransactionMessage) Reset() {
@@ -327,6 +351,34 @@ func (x *TransactionMessage) GetPayload() []byte {
 	return nil
 }
 
+func (x *TransactionMessage) GetVprogVersion() uint32 {
+	if x != nil {
+		return x.VprogVersion
+	}
+	return 0
+}
+
+func (x *TransactionMessage) GetVprogCode() []byte {
+	if x != nil {
+		return x.VprogCode
+	}
+	return nil
+}
+
+func (x *TransactionMessage) GetVprogData() []byte {
+	if x != nil {
+		return x.VprogData
+	}
+	return nil
+}
+
+func (x *TransactionMessage) GetVprogGasLimit() uint64 {
+	if x != nil {
+		return x.VprogGasLimit
+	}
+	return 0
+}
+
 type TransactionInput struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1499,15 +1551,16 @@ type VersionMessage struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ProtocolVersion uint32        `protobuf:"varint,1,opt,name=protocolVersion,proto3" json:"protocolVersion,omitempty"`
-	Services        uint64        `protobuf:"varint,2,opt,name=services,proto3" json:"services,omitempty"`
-	Timestamp       int64         `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Address         *NetAddress   `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
-	Id              []byte        `protobuf:"bytes,5,opt,name=id,proto3" json:"id,omitempty"`
-	UserAgent       string        `protobuf:"bytes,6,opt,name=userAgent,proto3" json:"userAgent,omitempty"`
-	DisableRelayTx  bool          `protobuf:"varint,8,opt,name=disableRelayTx,proto3" json:"disableRelayTx,omitempty"`
-	SubnetworkId    *SubnetworkId `protobuf:"bytes,9,opt,name=subnetworkId,proto3" json:"subnetworkId,omitempty"`
-	Network         string        `protobuf:"bytes,10,opt,name=network,proto3" json:"network,omitempty"`
+	ProtocolVersion uint32                  `protobuf:"varint,1,opt,name=protocolVersion,proto3" json:"protocolVersion,omitempty"`
+	Services        uint64                  `protobuf:"varint,2,opt,name=services,proto3" json:"services,omitempty"`
+	Timestamp       int64                   `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Address         *NetAddress             `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	Id              []byte                  `protobuf:"bytes,5,opt,name=id,proto3" json:"id,omitempty"`
+	UserAgent       string                  `protobuf:"bytes,6,opt,name=userAgent,proto3" json:"userAgent,omitempty"`
+	DisableRelayTx  bool                    `protobuf:"varint,8,opt,name=disableRelayTx,proto3" json:"disableRelayTx,omitempty"`
+	SubnetworkId    *SubnetworkId           `protobuf:"bytes,9,opt,name=subnetworkId,proto3" json:"subnetworkId,omitempty"`
+	Network         string                  `protobuf:"bytes,10,opt,name=network,proto3" json:"network,omitempty"`
+	EncryptionOffer *EncryptionOfferMessage `protobuf:"bytes,11,opt,name=encryptionOffer,proto3" json:"encryptionOffer,omitempty"` // omitted by peers that don't support encrypted connections
 }
 
 func (x *VersionMessage) Reset() {
@@ -1605,6 +1658,126 @@ func (x *VersionMessage) GetNetwork() string {
 	return ""
 }
 
+func (x *VersionMessage) GetEncryptionOffer() *EncryptionOfferMessage {
+	if x != nil {
+		return x.EncryptionOffer
+	}
+	return nil
+}
+
+// EncryptionOfferMessage carries the keys a peer offers for encrypting the
+// connection once both version messages were exchanged
+type EncryptionOfferMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IdentityPublicKey  []byte `protobuf:"bytes,1,opt,name=identityPublicKey,proto3" json:"identityPublicKey,omitempty"`
+	EphemeralPublicKey []byte `protobuf:"bytes,2,opt,name=ephemeralPublicKey,proto3" json:"ephemeralPublicKey,omitempty"`
+	Signature          []byte `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *EncryptionOfferMessage) Reset() {
+	*x = EncryptionOfferMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_p2p_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EncryptionOfferMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncryptionOfferMessage) ProtoMessage() {}
+
+func (x *EncryptionOfferMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncryptionOfferMessage.ProtoReflect.Descriptor instead.
+func (*EncryptionOfferMessage) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *EncryptionOfferMessage) GetIdentityPublicKey() []byte {
+	if x != nil {
+		return x.IdentityPublicKey
+	}
+	return nil
+}
+
+func (x *EncryptionOfferMessage) GetEphemeralPublicKey() []byte {
+	if x != nil {
+		return x.EphemeralPublicKey
+	}
+	return nil
+}
+
+func (x *EncryptionOfferMessage) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+// EncryptedMessage wraps a serialized ZuadMessage on an encrypted connection
+type EncryptedMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ciphertext []byte `protobuf:"bytes,1,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+}
+
+func (x *EncryptedMessage) Reset() {
+	*x = EncryptedMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_p2p_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EncryptedMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncryptedMessage) ProtoMessage() {}
+
+func (x *EncryptedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncryptedMessage.ProtoReflect.Descriptor instead.
+func (*EncryptedMessage) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *EncryptedMessage) GetCiphertext() []byte {
+	if x != nil {
+		return x.Ciphertext
+	}
+	return nil
+}
+
 type RejectMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1616,7 +1789,7 @@ type RejectMessage struct {
 func (x *RejectMessage) Reset() {
 	*x = RejectMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[28]
+		mi := &file_p2p_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1629,7 +1802,7 @@ func (x *RejectMessage) String() string {
 func (*RejectMessage) ProtoMessage() {}
 
 func (x *RejectMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[28]
+	mi := &file_p2p_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1642,7 +1815,7 @@ func (x *RejectMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RejectMessage.ProtoReflect.Descriptor instead.
 func (*RejectMessage) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{28}
+	return file_p2p_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *RejectMessage) GetReason() string {
@@ -1663,7 +1836,7 @@ type RequestPruningPointUTXOSetMessage struct {
 func (x *RequestPruningPointUTXOSetMessage) Reset() {
 	*x = RequestPruningPointUTXOSetMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[29]
+		mi := &file_p2p_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1676,7 +1849,7 @@ func (x *RequestPruningPointUTXOSetMessage) String() string {
 func (*RequestPruningPointUTXOSetMessage) ProtoMessage() {}
 
 func (x *RequestPruningPointUTXOSetMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[29]
+	mi := &file_p2p_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1689,7 +1862,7 @@ func (x *RequestPruningPointUTXOSetMessage) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use RequestPruningPointUTXOSetMessage.ProtoReflect.Descriptor instead.
 func (*RequestPruningPointUTXOSetMessage) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{29}
+	return file_p2p_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *RequestPruningPointUTXOSetMessage) GetPruningPointHash() *Hash {
@@ -1705,12 +1878,13 @@ type PruningPointUtxoSetChunkMessage struct {
 	unknownFields protoimpl.UnknownFields
 
 	OutpointAndUtxoEntryPairs []*OutpointAndUtxoEntryPair `protobuf:"bytes,1,rep,name=outpointAndUtxoEntryPairs,proto3" json:"outpointAndUtxoEntryPairs,omitempty"`
+	VprogStateEntries         []*VProgStateEntry          `protobuf:"bytes,2,rep,name=vprogStateEntries,proto3" json:"vprogStateEntries,omitempty"`
 }
 
 func (x *PruningPointUtxoSetChunkMessage) Reset() {
 	*x = PruningPointUtxoSetChunkMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[30]
+		mi := &file_p2p_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1723,7 +1897,7 @@ func (x *PruningPointUtxoSetChunkMessage) String() string {
 func (*PruningPointUtxoSetChunkMessage) ProtoMessage() {}
 
 func (x *PruningPointUtxoSetChunkMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[30]
+	mi := &file_p2p_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1736,7 +1910,7 @@ func (x *PruningPointUtxoSetChunkMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PruningPointUtxoSetChunkMessage.ProtoReflect.Descriptor instead.
 func (*PruningPointUtxoSetChunkMessage) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{30}
+	return file_p2p_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *PruningPointUtxoSetChunkMessage) GetOutpointAndUtxoEntryPairs() []*OutpointAndUtxoEntryPair {
@@ -1746,6 +1920,76 @@ func (x *PruningPointUtxoSetChunkMessage) GetOutpointAndUtxoEntryPairs() []*Outp
 	return nil
 }
 
+func (x *PruningPointUtxoSetChunkMessage) GetVprogStateEntries() []*VProgStateEntry {
+	if x != nil {
+		return x.VprogStateEntries
+	}
+	return nil
+}
+
+type VProgStateEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProgramId *Hash  `protobuf:"bytes,1,opt,name=programId,proto3" json:"programId,omitempty"`
+	Key       []byte `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value     []byte `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *VProgStateEntry) Reset() {
+	*x = VProgStateEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_p2p_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VProgStateEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VProgStateEntry) ProtoMessage() {}
+
+func (x *VProgStateEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VProgStateEntry.ProtoReflect.Descriptor instead.
+func (*VProgStateEntry) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *VProgStateEntry) GetProgramId() *Hash {
+	if x != nil {
+		return x.ProgramId
+	}
+	return nil
+}
+
+func (x *VProgStateEntry) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *VProgStateEntry) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
 type OutpointAndUtxoEntryPair struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1758,7 +2002,7 @@ type OutpointAndUtxoEntryPair struct {
 func (x *OutpointAndUtxoEntryPair) Reset() {
 	*x = OutpointAndUtxoEntryPair{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[31]
+		mi := &file_p2p_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1771,7 +2015,7 @@ func (x *OutpointAndUtxoEntryPair) String() string {
 func (*OutpointAndUtxoEntryPair) ProtoMessage() {}
 
 func (x *OutpointAndUtxoEntryPair) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[31]
+	mi := &file_p2p_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1784,7 +2028,7 @@ func (x *OutpointAndUtxoEntryPair) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OutpointAndUtxoEntryPair.ProtoReflect.Descriptor instead.
 func (*OutpointAndUtxoEntryPair) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{31}
+	return file_p2p_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *OutpointAndUtxoEntryPair) GetOutpoint() *Outpoint {
@@ -1815,7 +2059,7 @@ type UtxoEntry struct {
 func (x *UtxoEntry) Reset() {
 	*x = UtxoEntry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[32]
+		mi := &file_p2p_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1828,7 +2072,7 @@ func (x *UtxoEntry) String() string {
 func (*UtxoEntry) ProtoMessage() {}
 
 func (x *UtxoEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[32]
+	mi := &file_p2p_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1841,7 +2085,7 @@ func (x *UtxoEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UtxoEntry.ProtoReflect.Descriptor instead.
 func (*UtxoEntry) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{32}
+	return file_p2p_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *UtxoEntry) GetAmount() uint64 {
@@ -1881,7 +2125,7 @@ type RequestNextPruningPointUtxoSetChunkMessage struct {
 func (x *RequestNextPruningPointUtxoSetChunkMessage) Reset() {
 	*x = RequestNextPruningPointUtxoSetChunkMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[33]
+		mi := &file_p2p_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1894,7 +2138,7 @@ func (x *RequestNextPruningPointUtxoSetChunkMessage) String() string {
 func (*RequestNextPruningPointUtxoSetChunkMessage) ProtoMessage() {}
 
 func (x *RequestNextPruningPointUtxoSetChunkMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[33]
+	mi := &file_p2p_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1907,7 +2151,7 @@ func (x *RequestNextPruningPointUtxoSetChunkMessage) ProtoReflect() protoreflect
 
 // Deprecated: Use RequestNextPruningPointUtxoSetChunkMessage.ProtoReflect.Descriptor instead.
 func (*RequestNextPruningPointUtxoSetChunkMessage) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{33}
+	return file_p2p_proto_rawDescGZIP(), []int{36}
 }
 
 type DonePruningPointUtxoSetChunksMessage struct {
@@ -1919,7 +2163,7 @@ type DonePruningPointUtxoSetChunksMessage struct {
 func (x *DonePruningPointUtxoSetChunksMessage) Reset() {
 	*x = DonePruningPointUtxoSetChunksMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[34]
+		mi := &file_p2p_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1932,7 +2176,7 @@ func (x *DonePruningPointUtxoSetChunksMessage) String() string {
 func (*DonePruningPointUtxoSetChunksMessage) ProtoMessage() {}
 
 func (x *DonePruningPointUtxoSetChunksMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[34]
+	mi := &file_p2p_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1945,7 +2189,7 @@ func (x *DonePruningPointUtxoSetChunksMessage) ProtoReflect() protoreflect.Messa
 
 // Deprecated: Use DonePruningPointUtxoSetChunksMessage.ProtoReflect.Descriptor instead.
 func (*DonePruningPointUtxoSetChunksMessage) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{34}
+	return file_p2p_proto_rawDescGZIP(), []int{37}
 }
 
 type RequestIBDBlocksMessage struct {
@@ -1959,7 +2203,7 @@ type RequestIBDBlocksMessage struct {
 func (x *RequestIBDBlocksMessage) Reset() {
 	*x = RequestIBDBlocksMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[35]
+		mi := &file_p2p_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1972,7 +2216,7 @@ func (x *RequestIBDBlocksMessage) String() string {
 func (*RequestIBDBlocksMessage) ProtoMessage() {}
 
 func (x *RequestIBDBlocksMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[35]
+	mi := &file_p2p_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1985,7 +2229,7 @@ func (x *RequestIBDBlocksMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestIBDBlocksMessage.ProtoReflect.Descriptor instead.
 func (*RequestIBDBlocksMessage) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{35}
+	return file_p2p_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *RequestIBDBlocksMessage) GetHashes() []*Hash {
@@ -2004,7 +2248,7 @@ type UnexpectedPruningPointMessage struct {
 func (x *UnexpectedPruningPointMessage) Reset() {
 	*x = UnexpectedPruningPointMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[36]
+		mi := &file_p2p_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2017,7 +2261,7 @@ func (x *UnexpectedPruningPointMessage) String() string {
 func (*UnexpectedPruningPointMessage) ProtoMessage() {}
 
 func (x *UnexpectedPruningPointMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[36]
+	mi := &file_p2p_proto_msgTypes[39]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2030,7 +2274,7 @@ func (x *UnexpectedPruningPointMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UnexpectedPruningPointMessage.ProtoReflect.Descriptor instead.
 func (*UnexpectedPruningPointMessage) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{36}
+	return file_p2p_proto_rawDescGZIP(), []int{39}
 }
 
 type IbdBlockLocatorMessage struct {
@@ -2045,7 +2289,7 @@ type IbdBlockLocatorMessage struct {
 func (x *IbdBlockLocatorMessage) Reset() {
 	*x = IbdBlockLocatorMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[37]
+		mi := &file_p2p_proto_msgTypes[40]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2058,7 +2302,7 @@ func (x *IbdBlockLocatorMessage) String() string {
 func (*IbdBlockLocatorMessage) ProtoMessage() {}
 
 func (x *IbdBlockLocatorMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[37]
+	mi := &file_p2p_proto_msgTypes[40]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2071,7 +2315,7 @@ func (x *IbdBlockLocatorMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IbdBlockLocatorMessage.ProtoReflect.Descriptor instead.
 func (*IbdBlockLocatorMessage) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{37}
+	return file_p2p_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *IbdBlockLocatorMessage) GetTargetHash() *Hash {
@@ -2100,7 +2344,7 @@ type RequestIBDChainBlockLocatorMessage struct {
 func (x *RequestIBDChainBlockLocatorMessage) Reset() {
 	*x = RequestIBDChainBlockLocatorMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[38]
+		mi := &file_p2p_proto_msgTypes[41]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2113,7 +2357,7 @@ func (x *RequestIBDChainBlockLocatorMessage) String() string {
 func (*RequestIBDChainBlockLocatorMessage) ProtoMessage() {}
 
 func (x *RequestIBDChainBlockLocatorMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[38]
+	mi := &file_p2p_proto_msgTypes[41]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2126,7 +2370,7 @@ func (x *RequestIBDChainBlockLocatorMessage) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use RequestIBDChainBlockLocatorMessage.ProtoReflect.Descriptor instead.
 func (*RequestIBDChainBlockLocatorMessage) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{38}
+	return file_p2p_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *RequestIBDChainBlockLocatorMessage) GetLowHash() *Hash {
@@ -2154,7 +2398,7 @@ type IbdChainBlockLocatorMessage struct {
 func (x *IbdChainBlockLocatorMessage) Reset() {
 	*x = IbdChainBlockLocatorMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[39]
+		mi := &file_p2p_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2167,7 +2411,7 @@ func (x *IbdChainBlockLocatorMessage) String() string {
 func (*IbdChainBlockLocatorMessage) ProtoMessage() {}
 
 func (x *IbdChainBlockLocatorMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[39]
+	mi := &file_p2p_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2180,7 +2424,7 @@ func (x *IbdChainBlockLocatorMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IbdChainBlockLocatorMessage.ProtoReflect.Descriptor instead.
 func (*IbdChainBlockLocatorMessage) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{39}
+	return file_p2p_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *IbdChainBlockLocatorMessage) GetBlockLocatorHashes() []*Hash {
@@ -2202,7 +2446,7 @@ type RequestAnticoneMessage struct {
 func (x *RequestAnticoneMessage) Reset() {
 	*x = RequestAnticoneMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[40]
+		mi := &file_p2p_proto_msgTypes[43]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2215,7 +2459,7 @@ func (x *RequestAnticoneMessage) String() string {
 func (*RequestAnticoneMessage) ProtoMessage() {}
 
 func (x *RequestAnticoneMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[40]
+	mi := &file_p2p_proto_msgTypes[43]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2228,7 +2472,7 @@ func (x *RequestAnticoneMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestAnticoneMessage.ProtoReflect.Descriptor instead.
 func (*RequestAnticoneMessage) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{40}
+	return file_p2p_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *RequestAnticoneMessage) GetBlockHash() *Hash {
@@ -2256,7 +2500,7 @@ type IbdBlockLocatorHighestHashMessage struct {
 func (x *IbdBlockLocatorHighestHashMessage) Reset() {
 	*x = IbdBlockLocatorHighestHashMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[41]
+		mi := &file_p2p_proto_msgTypes[44]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2269,7 +2513,7 @@ func (x *IbdBlockLocatorHighestHashMessage) String() string {
 func (*IbdBlockLocatorHighestHashMessage) ProtoMessage() {}
 
 func (x *IbdBlockLocatorHighestHashMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[41]
+	mi := &file_p2p_proto_msgTypes[44]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2282,7 +2526,7 @@ func (x *IbdBlockLocatorHighestHashMessage) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use IbdBlockLocatorHighestHashMessage.ProtoReflect.Descriptor instead.
 func (*IbdBlockLocatorHighestHashMessage) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{41}
+	return file_p2p_proto_rawDescGZIP(), []int{44}
 }
 
 func (x *IbdBlockLocatorHighestHashMessage) GetHighestHash() *Hash {
@@ -2301,7 +2545,7 @@ type IbdBlockLocatorHighestHashNotFoundMessage struct {
 func (x *IbdBlockLocatorHighestHashNotFoundMessage) Reset() {
 	*x = IbdBlockLocatorHighestHashNotFoundMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[42]
+		mi := &file_p2p_proto_msgTypes[45]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2314,7 +2558,7 @@ func (x *IbdBlockLocatorHighestHashNotFoundMessage) String() string {
 func (*IbdBlockLocatorHighestHashNotFoundMessage) ProtoMessage() {}
 
 func (x *IbdBlockLocatorHighestHashNotFoundMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[42]
+	mi := &file_p2p_proto_msgTypes[45]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2327,7 +2571,7 @@ func (x *IbdBlockLocatorHighestHashNotFoundMessage) ProtoReflect() protoreflect.
 
 // Deprecated: Use IbdBlockLocatorHighestHashNotFoundMessage.ProtoReflect.Descriptor instead.
 func (*IbdBlockLocatorHighestHashNotFoundMessage) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{42}
+	return file_p2p_proto_rawDescGZIP(), []int{45}
 }
 
 type BlockHeadersMessage struct {
@@ -2341,7 +2585,7 @@ type BlockHeadersMessage struct {
 func (x *BlockHeadersMessage) Reset() {
 	*x = BlockHeadersMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[43]
+		mi := &file_p2p_proto_msgTypes[46]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2354,7 +2598,7 @@ func (x *BlockHeadersMessage) String() string {
 func (*BlockHeadersMessage) ProtoMessage() {}
 
 func (x *BlockHeadersMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[43]
+	mi := &file_p2p_proto_msgTypes[46]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2367,7 +2611,7 @@ func (x *BlockHeadersMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlockHeadersMessage.ProtoReflect.Descriptor instead.
 func (*BlockHeadersMessage) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{43}
+	return file_p2p_proto_rawDescGZIP(), []int{46}
 }
 
 func (x *BlockHeadersMessage) GetBlockHeaders() []*BlockHeader {
@@ -2386,7 +2630,7 @@ type RequestPruningPointAndItsAnticoneMessage struct {
 func (x *RequestPruningPointAndItsAnticoneMessage) Reset() {
 	*x = RequestPruningPointAndItsAnticoneMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[44]
+		mi := &file_p2p_proto_msgTypes[47]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2399,7 +2643,7 @@ func (x *RequestPruningPointAndItsAnticoneMessage) String() string {
 func (*RequestPruningPointAndItsAnticoneMessage) ProtoMessage() {}
 
 func (x *RequestPruningPointAndItsAnticoneMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[44]
+	mi := &file_p2p_proto_msgTypes[47]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2412,7 +2656,7 @@ func (x *RequestPruningPointAndItsAnticoneMessage) ProtoReflect() protoreflect.M
 
 // Deprecated: Use RequestPruningPointAndItsAnticoneMessage.ProtoReflect.Descriptor instead.
 func (*RequestPruningPointAndItsAnticoneMessage) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{44}
+	return file_p2p_proto_rawDescGZIP(), []int{47}
 }
 
 type RequestNextPruningPointAndItsAnticoneBlocksMessage struct {
@@ -2424,7 +2668,7 @@ type RequestNextPruningPointAndItsAnticoneBlocksMessage struct {
 func (x *RequestNextPruningPointAndItsAnticoneBlocksMessage) Reset() {
 	*x = RequestNextPruningPointAndItsAnticoneBlocksMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[45]
+		mi := &file_p2p_proto_msgTypes[48]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2437,7 +2681,7 @@ func (x *RequestNextPruningPointAndItsAnticoneBlocksMessage) String() string {
 func (*RequestNextPruningPointAndItsAnticoneBlocksMessage) ProtoMessage() {}
 
 func (x *RequestNextPruningPointAndItsAnticoneBlocksMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[45]
+	mi := &file_p2p_proto_msgTypes[48]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2450,7 +2694,7 @@ func (x *RequestNextPruningPointAndItsAnticoneBlocksMessage) ProtoReflect() prot
 
 // Deprecated: Use RequestNextPruningPointAndItsAnticoneBlocksMessage.ProtoReflect.Descriptor instead.
 func (*RequestNextPruningPointAndItsAnticoneBlocksMessage) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{45}
+	return file_p2p_proto_rawDescGZIP(), []int{48}
 }
 
 type BlockWithTrustedDataMessage struct {
@@ -2467,7 +2711,7 @@ type BlockWithTrustedDataMessage struct {
 func (x *BlockWithTrustedDataMessage) Reset() {
 	*x = BlockWithTrustedDataMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[46]
+		mi := &file_p2p_proto_msgTypes[49]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2480,7 +2724,7 @@ func (x *BlockWithTrustedDataMessage) String() string {
 func (*BlockWithTrustedDataMessage) ProtoMessage() {}
 
 func (x *BlockWithTrustedDataMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[46]
+	mi := &file_p2p_proto_msgTypes[49]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2493,7 +2737,7 @@ func (x *BlockWithTrustedDataMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlockWithTrustedDataMessage.ProtoReflect.Descriptor instead.
 func (*BlockWithTrustedDataMessage) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{46}
+	return file_p2p_proto_rawDescGZIP(), []int{49}
 }
 
 func (x *BlockWithTrustedDataMessage) GetBlock() *BlockMessage {
@@ -2536,7 +2780,7 @@ type DaaBlock struct {
 func (x *DaaBlock) Reset() {
 	*x = DaaBlock{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[47]
+		mi := &file_p2p_proto_msgTypes[50]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2549,7 +2793,7 @@ func (x *DaaBlock) String() string {
 func (*DaaBlock) ProtoMessage() {}
 
 func (x *DaaBlock) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[47]
+	mi := &file_p2p_proto_msgTypes[50]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2562,7 +2806,7 @@ func (x *DaaBlock) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DaaBlock.ProtoReflect.Descriptor instead.
 func (*DaaBlock) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{47}
+	return file_p2p_proto_rawDescGZIP(), []int{50}
 }
 
 func (x *DaaBlock) GetBlock() *BlockMessage {
@@ -2591,7 +2835,7 @@ type DaaBlockV4 struct {
 func (x *DaaBlockV4) Reset() {
 	*x = DaaBlockV4{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[48]
+		mi := &file_p2p_proto_msgTypes[51]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2604,7 +2848,7 @@ func (x *DaaBlockV4) String() string {
 func (*DaaBlockV4) ProtoMessage() {}
 
 func (x *DaaBlockV4) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[48]
+	mi := &file_p2p_proto_msgTypes[51]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2617,7 +2861,7 @@ func (x *DaaBlockV4) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DaaBlockV4.ProtoReflect.Descriptor instead.
 func (*DaaBlockV4) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{48}
+	return file_p2p_proto_rawDescGZIP(), []int{51}
 }
 
 func (x *DaaBlockV4) GetHeader() *BlockHeader {
@@ -2646,7 +2890,7 @@ type BlockGhostdagDataHashPair struct {
 func (x *BlockGhostdagDataHashPair) Reset() {
 	*x = BlockGhostdagDataHashPair{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[49]
+		mi := &file_p2p_proto_msgTypes[52]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2659,7 +2903,7 @@ func (x *BlockGhostdagDataHashPair) String() string {
 func (*BlockGhostdagDataHashPair) ProtoMessage() {}
 
 func (x *BlockGhostdagDataHashPair) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[49]
+	mi := &file_p2p_proto_msgTypes[52]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2672,7 +2916,7 @@ func (x *BlockGhostdagDataHashPair) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlockGhostdagDataHashPair.ProtoReflect.Descriptor instead.
 func (*BlockGhostdagDataHashPair) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{49}
+	return file_p2p_proto_rawDescGZIP(), []int{52}
 }
 
 func (x *BlockGhostdagDataHashPair) GetHash() *Hash {
@@ -2705,7 +2949,7 @@ type GhostdagData struct {
 func (x *GhostdagData) Reset() {
 	*x = GhostdagData{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[50]
+		mi := &file_p2p_proto_msgTypes[53]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2718,7 +2962,7 @@ func (x *GhostdagData) String() string {
 func (*GhostdagData) ProtoMessage() {}
 
 func (x *GhostdagData) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[50]
+	mi := &file_p2p_proto_msgTypes[53]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2731,7 +2975,7 @@ func (x *GhostdagData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GhostdagData.ProtoReflect.Descriptor instead.
 func (*GhostdagData) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{50}
+	return file_p2p_proto_rawDescGZIP(), []int{53}
 }
 
 func (x *GhostdagData) GetBlueScore() uint64 {
@@ -2788,7 +3032,7 @@ type BluesAnticoneSizes struct {
 func (x *BluesAnticoneSizes) Reset() {
 	*x = BluesAnticoneSizes{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[51]
+		mi := &file_p2p_proto_msgTypes[54]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2801,7 +3045,7 @@ func (x *BluesAnticoneSizes) String() string {
 func (*BluesAnticoneSizes) ProtoMessage() {}
 
 func (x *BluesAnticoneSizes) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[51]
+	mi := &file_p2p_proto_msgTypes[54]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2814,7 +3058,7 @@ func (x *BluesAnticoneSizes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BluesAnticoneSizes.ProtoReflect.Descriptor instead.
 func (*BluesAnticoneSizes) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{51}
+	return file_p2p_proto_rawDescGZIP(), []int{54}
 }
 
 func (x *BluesAnticoneSizes) GetBlueHash() *Hash {
@@ -2840,7 +3084,7 @@ type DoneBlocksWithTrustedDataMessage struct {
 func (x *DoneBlocksWithTrustedDataMessage) Reset() {
 	*x = DoneBlocksWithTrustedDataMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[52]
+		mi := &file_p2p_proto_msgTypes[55]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2853,7 +3097,7 @@ func (x *DoneBlocksWithTrustedDataMessage) String() string {
 func (*DoneBlocksWithTrustedDataMessage) ProtoMessage() {}
 
 func (x *DoneBlocksWithTrustedDataMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[52]
+	mi := &file_p2p_proto_msgTypes[55]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2866,7 +3110,7 @@ func (x *DoneBlocksWithTrustedDataMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DoneBlocksWithTrustedDataMessage.ProtoReflect.Descriptor instead.
 func (*DoneBlocksWithTrustedDataMessage) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{52}
+	return file_p2p_proto_rawDescGZIP(), []int{55}
 }
 
 type PruningPointsMessage struct {
@@ -2880,7 +3124,7 @@ type PruningPointsMessage struct {
 func (x *PruningPointsMessage) Reset() {
 	*x = PruningPointsMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[53]
+		mi := &file_p2p_proto_msgTypes[56]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2893,7 +3137,7 @@ func (x *PruningPointsMessage) String() string {
 func (*PruningPointsMessage) ProtoMessage() {}
 
 func (x *PruningPointsMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[53]
+	mi := &file_p2p_proto_msgTypes[56]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2906,7 +3150,7 @@ func (x *PruningPointsMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PruningPointsMessage.ProtoReflect.Descriptor instead.
 func (*PruningPointsMessage) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{53}
+	return file_p2p_proto_rawDescGZIP(), []int{56}
 }
 
 func (x *PruningPointsMessage) GetHeaders() []*BlockHeader {
@@ -2925,7 +3169,7 @@ type RequestPruningPointProofMessage struct {
 func (x *RequestPruningPointProofMessage) Reset() {
 	*x = RequestPruningPointProofMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[54]
+		mi := &file_p2p_proto_msgTypes[57]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2938,7 +3182,7 @@ func (x *RequestPruningPointProofMessage) String() string {
 func (*RequestPruningPointProofMessage) ProtoMessage() {}
 
 func (x *RequestPruningPointProofMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[54]
+	mi := &file_p2p_proto_msgTypes[57]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2951,7 +3195,7 @@ func (x *RequestPruningPointProofMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestPruningPointProofMessage.ProtoReflect.Descriptor instead.
 func (*RequestPruningPointProofMessage) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{54}
+	return file_p2p_proto_rawDescGZIP(), []int{57}
 }
 
 type PruningPointProofMessage struct {
@@ -2965,7 +3209,7 @@ type PruningPointProofMessage struct {
 func (x *PruningPointProofMessage) Reset() {
 	*x = PruningPointProofMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[55]
+		mi := &file_p2p_proto_msgTypes[58]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2978,7 +3222,7 @@ func (x *PruningPointProofMessage) String() string {
 func (*PruningPointProofMessage) ProtoMessage() {}
 
 func (x *PruningPointProofMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[55]
+	mi := &file_p2p_proto_msgTypes[58]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2991,7 +3235,7 @@ func (x *PruningPointProofMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PruningPointProofMessage.ProtoReflect.Descriptor instead.
 func (*PruningPointProofMessage) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{55}
+	return file_p2p_proto_rawDescGZIP(), []int{58}
 }
 
 func (x *PruningPointProofMessage) GetHeaders() []*PruningPointProofHeaderArray {
@@ -3012,7 +3256,7 @@ type PruningPointProofHeaderArray struct {
 func (x *PruningPointProofHeaderArray) Reset() {
 	*x = PruningPointProofHeaderArray{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[56]
+		mi := &file_p2p_proto_msgTypes[59]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3025,7 +3269,7 @@ func (x *PruningPointProofHeaderArray) String() string {
 func (*PruningPointProofHeaderArray) ProtoMessage() {}
 
 func (x *PruningPointProofHeaderArray) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[56]
+	mi := &file_p2p_proto_msgTypes[59]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3038,7 +3282,7 @@ func (x *PruningPointProofHeaderArray) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PruningPointProofHeaderArray.ProtoReflect.Descriptor instead.
 func (*PruningPointProofHeaderArray) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{56}
+	return file_p2p_proto_rawDescGZIP(), []int{59}
 }
 
 func (x *PruningPointProofHeaderArray) GetHeaders() []*BlockHeader {
@@ -3048,29 +3292,461 @@ func (x *PruningPointProofHeaderArray) GetHeaders() []*BlockHeader {
 	return nil
 }
 
-type ReadyMessage struct {
+type ReadyMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ReadyMessage) Reset() {
+	*x = ReadyMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_p2p_proto_msgTypes[60]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadyMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadyMessage) ProtoMessage() {}
+
+func (x *ReadyMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_msgTypes[60]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadyMessage.ProtoReflect.Descriptor instead.
+func (*ReadyMessage) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_rawDescGZIP(), []int{60}
+}
+
+type BlockWithTrustedDataV4Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Block               *BlockMessage `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	DaaWindowIndices    []uint64      `protobuf:"varint,2,rep,packed,name=daaWindowIndices,proto3" json:"daaWindowIndices,omitempty"`
+	GhostdagDataIndices []uint64      `protobuf:"varint,3,rep,packed,name=ghostdagDataIndices,proto3" json:"ghostdagDataIndices,omitempty"`
+}
+
+func (x *BlockWithTrustedDataV4Message) Reset() {
+	*x = BlockWithTrustedDataV4Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_p2p_proto_msgTypes[61]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockWithTrustedDataV4Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockWithTrustedDataV4Message) ProtoMessage() {}
+
+func (x *BlockWithTrustedDataV4Message) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_msgTypes[61]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockWithTrustedDataV4Message.ProtoReflect.Descriptor instead.
+func (*BlockWithTrustedDataV4Message) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *BlockWithTrustedDataV4Message) GetBlock() *BlockMessage {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+func (x *BlockWithTrustedDataV4Message) GetDaaWindowIndices() []uint64 {
+	if x != nil {
+		return x.DaaWindowIndices
+	}
+	return nil
+}
+
+func (x *BlockWithTrustedDataV4Message) GetGhostdagDataIndices() []uint64 {
+	if x != nil {
+		return x.GhostdagDataIndices
+	}
+	return nil
+}
+
+type TrustedDataMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DaaWindow    []*DaaBlockV4                `protobuf:"bytes,1,rep,name=daaWindow,proto3" json:"daaWindow,omitempty"`
+	GhostdagData []*BlockGhostdagDataHashPair `protobuf:"bytes,2,rep,name=ghostdagData,proto3" json:"ghostdagData,omitempty"`
+}
+
+func (x *TrustedDataMessage) Reset() {
+	*x = TrustedDataMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_p2p_proto_msgTypes[62]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrustedDataMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrustedDataMessage) ProtoMessage() {}
+
+func (x *TrustedDataMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_msgTypes[62]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrustedDataMessage.ProtoReflect.Descriptor instead.
+func (*TrustedDataMessage) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *TrustedDataMessage) GetDaaWindow() []*DaaBlockV4 {
+	if x != nil {
+		return x.DaaWindow
+	}
+	return nil
+}
+
+func (x *TrustedDataMessage) GetGhostdagData() []*BlockGhostdagDataHashPair {
+	if x != nil {
+		return x.GhostdagData
+	}
+	return nil
+}
+
+// TransactionPackageMessage carries a set of dependent transactions, sorted so
+// that parents come before their children, that should be validated and
+// admitted to the mempool together
+type TransactionPackageMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Transactions []*TransactionMessage `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+}
+
+func (x *TransactionPackageMessage) Reset() {
+	*x = TransactionPackageMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_p2p_proto_msgTypes[63]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransactionPackageMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionPackageMessage) ProtoMessage() {}
+
+func (x *TransactionPackageMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_msgTypes[63]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionPackageMessage.ProtoReflect.Descriptor instead.
+func (*TransactionPackageMessage) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *TransactionPackageMessage) GetTransactions() []*TransactionMessage {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+// InvTransactionPackageMessage announces a package of dependent transactions
+// by the IDs of its transactions, in package order (protocol version 6 and above)
+type InvTransactionPackageMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ids []*TransactionId `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *InvTransactionPackageMessage) Reset() {
+	*x = InvTransactionPackageMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_p2p_proto_msgTypes[64]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InvTransactionPackageMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvTransactionPackageMessage) ProtoMessage() {}
+
+func (x *InvTransactionPackageMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_msgTypes[64]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvTransactionPackageMessage.ProtoReflect.Descriptor instead.
+func (*InvTransactionPackageMessage) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *InvTransactionPackageMessage) GetIds() []*TransactionId {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+// RequestTransactionPackageMessage requests an announced package of dependent
+// transactions. It's answered with a TransactionPackageMessage, which is empty
+// if the package isn't available anymore
+type RequestTransactionPackageMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ids []*TransactionId `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *RequestTransactionPackageMessage) Reset() {
+	*x = RequestTransactionPackageMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_p2p_proto_msgTypes[65]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestTransactionPackageMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestTransactionPackageMessage) ProtoMessage() {}
+
+func (x *RequestTransactionPackageMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_msgTypes[65]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestTransactionPackageMessage.ProtoReflect.Descriptor instead.
+func (*RequestTransactionPackageMessage) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *RequestTransactionPackageMessage) GetIds() []*TransactionId {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+// RequestCompactBlockMessage requests a relayed block as a compact block
+// (protocol version 6 and above)
+type RequestCompactBlockMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash *Hash `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+}
+
+func (x *RequestCompactBlockMessage) Reset() {
+	*x = RequestCompactBlockMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_p2p_proto_msgTypes[66]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestCompactBlockMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestCompactBlockMessage) ProtoMessage() {}
+
+func (x *RequestCompactBlockMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_msgTypes[66]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestCompactBlockMessage.ProtoReflect.Descriptor instead.
+func (*RequestCompactBlockMessage) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *RequestCompactBlockMessage) GetHash() *Hash {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+// CompactBlockMessage is a block in which every transaction, except for the
+// prefilled ones, is replaced by its 6-byte short transaction ID
+type CompactBlockMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Header *BlockHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// Mixed together with the block hash into the key of the short transaction IDs
+	ShortIdSalt uint64   `protobuf:"varint,2,opt,name=shortIdSalt,proto3" json:"shortIdSalt,omitempty"`
+	ShortIds    []uint64 `protobuf:"varint,3,rep,packed,name=shortIds,proto3" json:"shortIds,omitempty"`
+	// Always contains the coinbase transaction, sorted by index
+	PrefilledTransactions []*PrefilledTransaction `protobuf:"bytes,4,rep,name=prefilledTransactions,proto3" json:"prefilledTransactions,omitempty"`
+}
+
+func (x *CompactBlockMessage) Reset() {
+	*x = CompactBlockMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_p2p_proto_msgTypes[67]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompactBlockMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompactBlockMessage) ProtoMessage() {}
+
+func (x *CompactBlockMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_msgTypes[67]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompactBlockMessage.ProtoReflect.Descriptor instead.
+func (*CompactBlockMessage) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *CompactBlockMessage) GetHeader() *BlockHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *CompactBlockMessage) GetShortIdSalt() uint64 {
+	if x != nil {
+		return x.ShortIdSalt
+	}
+	return 0
+}
+
+func (x *CompactBlockMessage) GetShortIds() []uint64 {
+	if x != nil {
+		return x.ShortIds
+	}
+	return nil
+}
+
+func (x *CompactBlockMessage) GetPrefilledTransactions() []*PrefilledTransaction {
+	if x != nil {
+		return x.PrefilledTransactions
+	}
+	return nil
+}
+
+type PrefilledTransaction struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Index       uint32              `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Transaction *TransactionMessage `protobuf:"bytes,2,opt,name=transaction,proto3" json:"transaction,omitempty"`
 }
 
-func (x *ReadyMessage) Reset() {
-	*x = ReadyMessage{}
+func (x *PrefilledTransaction) Reset() {
+	*x = PrefilledTransaction{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[57]
+		mi := &file_p2p_proto_msgTypes[68]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ReadyMessage) String() string {
+func (x *PrefilledTransaction) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReadyMessage) ProtoMessage() {}
+func (*PrefilledTransaction) ProtoMessage() {}
 
-func (x *ReadyMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[57]
+func (x *PrefilledTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_msgTypes[68]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3081,38 +3757,53 @@ func (x *ReadyMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReadyMessage.ProtoReflect.Descriptor instead.
-func (*ReadyMessage) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{57}
+// Deprecated: Use PrefilledTransaction.ProtoReflect.Descriptor instead.
+func (*PrefilledTransaction) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_rawDescGZIP(), []int{68}
 }
 
-type BlockWithTrustedDataV4Message struct {
+func (x *PrefilledTransaction) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *PrefilledTransaction) GetTransaction() *TransactionMessage {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+// RequestBlockTransactionsMessage requests the transactions of a compact block
+// that are missing from the mempool of the requesting peer
+type RequestBlockTransactionsMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Block               *BlockMessage `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
-	DaaWindowIndices    []uint64      `protobuf:"varint,2,rep,packed,name=daaWindowIndices,proto3" json:"daaWindowIndices,omitempty"`
-	GhostdagDataIndices []uint64      `protobuf:"varint,3,rep,packed,name=ghostdagDataIndices,proto3" json:"ghostdagDataIndices,omitempty"`
+	BlockHash *Hash    `protobuf:"bytes,1,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	Indexes   []uint32 `protobuf:"varint,2,rep,packed,name=indexes,proto3" json:"indexes,omitempty"`
 }
 
-func (x *BlockWithTrustedDataV4Message) Reset() {
-	*x = BlockWithTrustedDataV4Message{}
+func (x *RequestBlockTransactionsMessage) Reset() {
+	*x = RequestBlockTransactionsMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[58]
+		mi := &file_p2p_proto_msgTypes[69]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *BlockWithTrustedDataV4Message) String() string {
+func (x *RequestBlockTransactionsMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BlockWithTrustedDataV4Message) ProtoMessage() {}
+func (*RequestBlockTransactionsMessage) ProtoMessage() {}
 
-func (x *BlockWithTrustedDataV4Message) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[58]
+func (x *RequestBlockTransactionsMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_msgTypes[69]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3123,58 +3814,51 @@ func (x *BlockWithTrustedDataV4Message) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BlockWithTrustedDataV4Message.ProtoReflect.Descriptor instead.
-func (*BlockWithTrustedDataV4Message) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{58}
-}
-
-func (x *BlockWithTrustedDataV4Message) GetBlock() *BlockMessage {
-	if x != nil {
-		return x.Block
-	}
-	return nil
+// Deprecated: Use RequestBlockTransactionsMessage.ProtoReflect.Descriptor instead.
+func (*RequestBlockTransactionsMessage) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_rawDescGZIP(), []int{69}
 }
 
-func (x *BlockWithTrustedDataV4Message) GetDaaWindowIndices() []uint64 {
+func (x *RequestBlockTransactionsMessage) GetBlockHash() *Hash {
 	if x != nil {
-		return x.DaaWindowIndices
+		return x.BlockHash
 	}
 	return nil
 }
 
-func (x *BlockWithTrustedDataV4Message) GetGhostdagDataIndices() []uint64 {
+func (x *RequestBlockTransactionsMessage) GetIndexes() []uint32 {
 	if x != nil {
-		return x.GhostdagDataIndices
+		return x.Indexes
 	}
 	return nil
 }
 
-type TrustedDataMessage struct {
+type BlockTransactionsMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	DaaWindow    []*DaaBlockV4                `protobuf:"bytes,1,rep,name=daaWindow,proto3" json:"daaWindow,omitempty"`
-	GhostdagData []*BlockGhostdagDataHashPair `protobuf:"bytes,2,rep,name=ghostdagData,proto3" json:"ghostdagData,omitempty"`
+	BlockHash    *Hash                 `protobuf:"bytes,1,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	Transactions []*TransactionMessage `protobuf:"bytes,2,rep,name=transactions,proto3" json:"transactions,omitempty"`
 }
 
-func (x *TrustedDataMessage) Reset() {
-	*x = TrustedDataMessage{}
+func (x *BlockTransactionsMessage) Reset() {
+	*x = BlockTransactionsMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_proto_msgTypes[59]
+		mi := &file_p2p_proto_msgTypes[70]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *TrustedDataMessage) String() string {
+func (x *BlockTransactionsMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TrustedDataMessage) ProtoMessage() {}
+func (*BlockTransactionsMessage) ProtoMessage() {}
 
-func (x *TrustedDataMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_proto_msgTypes[59]
+func (x *BlockTransactionsMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_msgTypes[70]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3185,21 +3869,21 @@ func (x *TrustedDataMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TrustedDataMessage.ProtoReflect.Descriptor instead.
-func (*TrustedDataMessage) Descriptor() ([]byte, []int) {
-	return file_p2p_proto_rawDescGZIP(), []int{59}
+// Deprecated: Use BlockTransactionsMessage.ProtoReflect.Descriptor instead.
+func (*BlockTransactionsMessage) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_rawDescGZIP(), []int{70}
 }
 
-func (x *TrustedDataMessage) GetDaaWindow() []*DaaBlockV4 {
+func (x *BlockTransactionsMessage) GetBlockHash() *Hash {
 	if x != nil {
-		return x.DaaWindow
+		return x.BlockHash
 	}
 	return nil
 }
 
-func (x *TrustedDataMessage) GetGhostdagData() []*BlockGhostdagDataHashPair {
+func (x *BlockTransactionsMessage) GetTransactions() []*TransactionMessage {
 	if x != nil {
-		return x.GhostdagData
+		return x.Transactions
 	}
 	return nil
 }
@@ -3222,195 +3906,235 @@ var file_p2p_proto_rawDesc = []byte{
 	0x72, 0x65, 0x73, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x4e, 0x65, 0x74, 0x41, 0x64,
 	0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x0b, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x4c, 0x69,
-	0x73, 0x74, 0x22, 0x4e, 0x0a, 0x0a, 0x4e, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x73, 0x74, 0x22, 0x7c, 0x0a, 0x0a, 0x4e, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
 	0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x0e,
 	0x0a, 0x02, 0x69, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x70, 0x12, 0x12,
 	0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x70, 0x6f,
-	0x72, 0x74, 0x22, 0x24, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
-	0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x22, 0xa0, 0x02, 0x0a, 0x12, 0x54, 0x72, 0x61,
-	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
-	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x33, 0x0a, 0x06, 0x69, 0x6e, 0x70,
-	0x75, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x12, 0x36,
-	0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x6e,
-	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x07, 0x6f,
-	0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x69,
-	0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x69,
-	0x6d, 0x65, 0x12, 0x3b, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
-	0x49, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x77, 0x69, 0x72, 0x65, 0x2e, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49,
-	0x64, 0x52, 0x0c, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x64, 0x12,
-	0x10, 0x0a, 0x03, 0x67, 0x61, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x67, 0x61,
-	0x73, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x08, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0xb9, 0x01, 0x0a, 0x10,
-	0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x70, 0x75, 0x74,
-	0x12, 0x3f, 0x0a, 0x10, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x4f, 0x75, 0x74, 0x70,
-	0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52,
-	0x10, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x4f, 0x75, 0x74, 0x70, 0x6f, 0x69, 0x6e,
-	0x74, 0x12, 0x28, 0x0a, 0x0f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x53, 0x63,
-	0x72, 0x69, 0x70, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x73, 0x69, 0x67, 0x6e,
-	0x61, 0x74, 0x75, 0x72, 0x65, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73,
-	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73,
-	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x69, 0x67, 0x4f, 0x70,
-	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x73, 0x69, 0x67,
-	0x4f, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x60, 0x0a, 0x08, 0x4f, 0x75, 0x74, 0x70, 0x6f,
-	0x69, 0x6e, 0x74, 0x12, 0x3e, 0x0a, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x49, 0x64, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x25, 0x0a, 0x0d, 0x54, 0x72, 0x61,
-	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x79,
-	0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73,
-	0x22, 0x43, 0x0a, 0x0f, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63,
-	0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76,
-	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65,
-	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x6f, 0x0a, 0x11, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x12, 0x44, 0x0a, 0x0f, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63,
-	0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x50, 0x75, 0x62, 0x6c,
-	0x69, 0x63, 0x4b, 0x65, 0x79, 0x52, 0x0f, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x50, 0x75, 0x62,
-	0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x22, 0x81, 0x01, 0x0a, 0x0c, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65,
-	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77,
-	0x69, 0x72, 0x65, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52,
-	0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x41, 0x0a, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73,
-	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x72, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x12, 0x0a, 0x04,
+	0x68, 0x6f, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74,
+	0x22, 0x24, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x22, 0xa6, 0x03, 0x0a, 0x12, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x33, 0x0a, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77,
+	0x69, 0x72, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x6e, 0x70, 0x75, 0x74, 0x52, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x12, 0x36, 0x0a, 0x07,
+	0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x0c, 0x74, 0x72,
-	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xe9, 0x03, 0x0a, 0x0b, 0x42,
-	0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
-	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72,
-	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x36, 0x0a, 0x07, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x18,
-	0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72,
-	0x65, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x50, 0x61, 0x72, 0x65,
-	0x6e, 0x74, 0x73, 0x52, 0x07, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x37, 0x0a, 0x0e,
-	0x68, 0x61, 0x73, 0x68, 0x4d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65,
-	0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x0e, 0x68, 0x61, 0x73, 0x68, 0x4d, 0x65, 0x72, 0x6b, 0x6c,
-	0x65, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x43, 0x0a, 0x14, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65,
-	0x64, 0x49, 0x64, 0x4d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e,
-	0x48, 0x61, 0x73, 0x68, 0x52, 0x14, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x49, 0x64,
-	0x4d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x37, 0x0a, 0x0e, 0x75, 0x74,
-	0x78, 0x6f, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x07, 0x6f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x3b, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69,
+	0x72, 0x65, 0x2e, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x64, 0x52,
+	0x0c, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x64, 0x12, 0x10, 0x0a,
+	0x03, 0x67, 0x61, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x67, 0x61, 0x73, 0x12,
+	0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x76, 0x70, 0x72,
+	0x6f, 0x67, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0c, 0x76, 0x70, 0x72, 0x6f, 0x67, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a,
+	0x09, 0x76, 0x70, 0x72, 0x6f, 0x67, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x09, 0x76, 0x70, 0x72, 0x6f, 0x67, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x76,
+	0x70, 0x72, 0x6f, 0x67, 0x44, 0x61, 0x74, 0x61, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09,
+	0x76, 0x70, 0x72, 0x6f, 0x67, 0x44, 0x61, 0x74, 0x61, 0x12, 0x24, 0x0a, 0x0d, 0x76, 0x70, 0x72,
+	0x6f, 0x67, 0x47, 0x61, 0x73, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0d, 0x76, 0x70, 0x72, 0x6f, 0x67, 0x47, 0x61, 0x73, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x22,
+	0xb9, 0x01, 0x0a, 0x10, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x6e, 0x70, 0x75, 0x74, 0x12, 0x3f, 0x0a, 0x10, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73,
+	0x4f, 0x75, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x52, 0x10, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x4f, 0x75, 0x74,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f,
+	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x12,
+	0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73,
+	0x69, 0x67, 0x4f, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0a, 0x73, 0x69, 0x67, 0x4f, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x60, 0x0a, 0x08, 0x4f,
+	0x75, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x3e, 0x0a, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x25, 0x0a,
+	0x0d, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x62,
+	0x79, 0x74, 0x65, 0x73, 0x22, 0x43, 0x0a, 0x0f, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x50, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x6f, 0x0a, 0x11, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x12, 0x44, 0x0a, 0x0f, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x50, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x52, 0x0f, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x22, 0x81, 0x01, 0x0a, 0x0c, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x68,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x41, 0x0a, 0x0c, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xe9,
+	0x03, 0x0a, 0x0b, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x18,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x36, 0x0a, 0x07, 0x70, 0x61, 0x72, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x65, 0x76, 0x65, 0x6c,
+	0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x07, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x73,
+	0x12, 0x37, 0x0a, 0x0e, 0x68, 0x61, 0x73, 0x68, 0x4d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f,
+	0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x77, 0x69, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x0e, 0x68, 0x61, 0x73, 0x68, 0x4d,
+	0x65, 0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x43, 0x0a, 0x14, 0x61, 0x63, 0x63,
+	0x65, 0x70, 0x74, 0x65, 0x64, 0x49, 0x64, 0x4d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77,
+	0x69, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x14, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74,
+	0x65, 0x64, 0x49, 0x64, 0x4d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x37,
+	0x0a, 0x0e, 0x75, 0x74, 0x78, 0x6f, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69,
+	0x72, 0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x0e, 0x75, 0x74, 0x78, 0x6f, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x69, 0x74, 0x73, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x04, 0x62, 0x69, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e,
+	0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x64, 0x61, 0x61, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x08, 0x64, 0x61, 0x61, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x62,
+	0x6c, 0x75, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x62,
+	0x6c, 0x75, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x12, 0x33, 0x0a, 0x0c, 0x70, 0x72, 0x75, 0x6e, 0x69,
+	0x6e, 0x67, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x0c,
+	0x70, 0x72, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09,
+	0x62, 0x6c, 0x75, 0x65, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x09, 0x62, 0x6c, 0x75, 0x65, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x48, 0x0a, 0x11, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x12,
+	0x33, 0x0a, 0x0c, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72,
+	0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x0c, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x61,
+	0x73, 0x68, 0x65, 0x73, 0x22, 0x1c, 0x0a, 0x04, 0x48, 0x61, 0x73, 0x68, 0x12, 0x14, 0x0a, 0x05,
+	0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x62, 0x79, 0x74,
+	0x65, 0x73, 0x22, 0x5f, 0x0a, 0x1a, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x2b, 0x0a, 0x08, 0x68, 0x69, 0x67, 0x68, 0x48, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x48,
-	0x61, 0x73, 0x68, 0x52, 0x0e, 0x75, 0x74, 0x78, 0x6f, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d,
-	0x65, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x69, 0x74, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x04, 0x62, 0x69, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x08,
-	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64,
-	0x61, 0x61, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x64,
-	0x61, 0x61, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x6c, 0x75, 0x65, 0x57,
-	0x6f, 0x72, 0x6b, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x62, 0x6c, 0x75, 0x65, 0x57,
-	0x6f, 0x72, 0x6b, 0x12, 0x33, 0x0a, 0x0c, 0x70, 0x72, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x50, 0x6f,
-	0x69, 0x6e, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x0c, 0x70, 0x72, 0x75, 0x6e,
-	0x69, 0x6e, 0x67, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x6c, 0x75, 0x65,
-	0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x62, 0x6c, 0x75,
-	0x65, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x48, 0x0a, 0x11, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c,
-	0x65, 0x76, 0x65, 0x6c, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x33, 0x0a, 0x0c, 0x70,
-	0x61, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x48, 0x61,
-	0x73, 0x68, 0x52, 0x0c, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73,
-	0x22, 0x1c, 0x0a, 0x04, 0x48, 0x61, 0x73, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x79, 0x74, 0x65,
-	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x22, 0x5f,
-	0x0a, 0x1a, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x6f,
-	0x63, 0x61, 0x74, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2b, 0x0a, 0x08,
-	0x68, 0x69, 0x67, 0x68, 0x48, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52,
-	0x08, 0x68, 0x69, 0x67, 0x68, 0x48, 0x61, 0x73, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d,
-	0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22,
-	0x3e, 0x0a, 0x13, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x27, 0x0a, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69,
-	0x72, 0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x22,
-	0x6f, 0x0a, 0x15, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
-	0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x29, 0x0a, 0x07, 0x6c, 0x6f, 0x77, 0x48,
-	0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x07, 0x6c, 0x6f, 0x77, 0x48,
-	0x61, 0x73, 0x68, 0x12, 0x2b, 0x0a, 0x08, 0x68, 0x69, 0x67, 0x68, 0x48, 0x61, 0x73, 0x68, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72,
-	0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x08, 0x68, 0x69, 0x67, 0x68, 0x48, 0x61, 0x73, 0x68,
-	0x22, 0x1b, 0x0a, 0x19, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4e, 0x65, 0x78, 0x74, 0x48,
-	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x14, 0x0a,
-	0x12, 0x44, 0x6f, 0x6e, 0x65, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x22, 0x44, 0x0a, 0x19, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65,
-	0x6c, 0x61, 0x79, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x12, 0x27, 0x0a, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x73,
-	0x68, 0x52, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x22, 0x48, 0x0a, 0x1a, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2a, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65,
-	0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x52, 0x03,
-	0x69, 0x64, 0x73, 0x22, 0x46, 0x0a, 0x1a, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x4e, 0x6f, 0x74, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x12, 0x28, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x52, 0x02, 0x69, 0x64, 0x22, 0x3b, 0x0a, 0x14, 0x49,
-	0x6e, 0x76, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x12, 0x23, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x48, 0x61,
-	0x73, 0x68, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x22, 0x44, 0x0a, 0x16, 0x49, 0x6e, 0x76, 0x54,
-	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x12, 0x2a, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x6e,
-	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x52, 0x03, 0x69, 0x64, 0x73, 0x22, 0x23,
-	0x0a, 0x0b, 0x50, 0x69, 0x6e, 0x67, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a,
-	0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6e, 0x6f,
-	0x6e, 0x63, 0x65, 0x22, 0x23, 0x0a, 0x0b, 0x50, 0x6f, 0x6e, 0x67, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x22, 0x0f, 0x0a, 0x0d, 0x56, 0x65, 0x72, 0x61,
-	0x63, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xd2, 0x02, 0x0a, 0x0e, 0x56, 0x65,
-	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x28, 0x0a, 0x0f,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x56,
-	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x12, 0x2f, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x4e, 0x65,
-	0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
-	0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69,
-	0x64, 0x12, 0x1c, 0x0a, 0x09, 0x75, 0x73, 0x65, 0x72, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x73, 0x65, 0x72, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x12,
-	0x26, 0x0a, 0x0e, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x54,
-	0x78, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65,
-	0x52, 0x65, 0x6c, 0x61, 0x79, 0x54, 0x78, 0x12, 0x3b, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x6e, 0x65,
-	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74,
-	0x77, 0x6f, 0x72, 0x6b, 0x49, 0x64, 0x52, 0x0c, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x77, 0x6f,
-	0x72, 0x6b, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18,
-	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x22, 0x27,
-	0x0a, 0x0d, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
-	0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x60, 0x0a, 0x21, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x50, 0x72, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x55, 0x54,
-	0x58, 0x4f, 0x53, 0x65, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x3b, 0x0a, 0x10,
-	0x70, 0x72, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69,
-	0x72, 0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x10, 0x70, 0x72, 0x75, 0x6e, 0x69, 0x6e, 0x67,
-	0x50, 0x6f, 0x69, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x22, 0x84, 0x01, 0x0a, 0x1f, 0x50, 0x72,
-	0x75, 0x6e, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x55, 0x74, 0x78, 0x6f, 0x53, 0x65,
-	0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x61, 0x0a,
-	0x19, 0x6f, 0x75, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x41, 0x6e, 0x64, 0x55, 0x74, 0x78, 0x6f,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x50, 0x61, 0x69, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x4f, 0x75, 0x74,
+	0x61, 0x73, 0x68, 0x52, 0x08, 0x68, 0x69, 0x67, 0x68, 0x48, 0x61, 0x73, 0x68, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x22, 0x3e, 0x0a, 0x13, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x6f, 0x63, 0x61,
+	0x74, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x27, 0x0a, 0x06, 0x68, 0x61,
+	0x73, 0x68, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x06, 0x68, 0x61, 0x73,
+	0x68, 0x65, 0x73, 0x22, 0x6f, 0x0a, 0x15, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x29, 0x0a, 0x07,
+	0x6c, 0x6f, 0x77, 0x48, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x07,
+	0x6c, 0x6f, 0x77, 0x48, 0x61, 0x73, 0x68, 0x12, 0x2b, 0x0a, 0x08, 0x68, 0x69, 0x67, 0x68, 0x48,
+	0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x08, 0x68, 0x69, 0x67, 0x68,
+	0x48, 0x61, 0x73, 0x68, 0x22, 0x1b, 0x0a, 0x19, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4e,
+	0x65, 0x78, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0x14, 0x0a, 0x12, 0x44, 0x6f, 0x6e, 0x65, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x44, 0x0a, 0x19, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x27, 0x0a, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65,
+	0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x22, 0x48, 0x0a,
+	0x1a, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2a, 0x0a, 0x03, 0x69,
+	0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x77, 0x69, 0x72, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x52, 0x03, 0x69, 0x64, 0x73, 0x22, 0x46, 0x0a, 0x1a, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x6f, 0x74, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x28, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x52, 0x02, 0x69, 0x64, 0x22,
+	0x3b, 0x0a, 0x14, 0x49, 0x6e, 0x76, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x23, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72,
+	0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x22, 0x44, 0x0a, 0x16,
+	0x49, 0x6e, 0x76, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2a, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x52, 0x03, 0x69,
+	0x64, 0x73, 0x22, 0x23, 0x0a, 0x0b, 0x50, 0x69, 0x6e, 0x67, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x22, 0x23, 0x0a, 0x0b, 0x50, 0x6f, 0x6e, 0x67, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x22, 0x0f, 0x0a, 0x0d,
+	0x56, 0x65, 0x72, 0x61, 0x63, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x9f, 0x03,
+	0x0a, 0x0e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x28, 0x0a, 0x0f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x12, 0x2f, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72,
+	0x65, 0x2e, 0x4e, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x07, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x75, 0x73, 0x65, 0x72, 0x41, 0x67, 0x65,
+	0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x73, 0x65, 0x72, 0x41, 0x67,
+	0x65, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65,
+	0x6c, 0x61, 0x79, 0x54, 0x78, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x64, 0x69, 0x73,
+	0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x54, 0x78, 0x12, 0x3b, 0x0a, 0x0c, 0x73,
+	0x75, 0x62, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x53, 0x75,
+	0x62, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x64, 0x52, 0x0c, 0x73, 0x75, 0x62, 0x6e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x12, 0x4b, 0x0a, 0x0f, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x4f, 0x66, 0x66, 0x65, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x0f,
+	0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x22,
+	0x94, 0x01, 0x0a, 0x16, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x66,
+	0x66, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2c, 0x0a, 0x11, 0x69, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x11, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x50,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x2e, 0x0a, 0x12, 0x65, 0x70, 0x68, 0x65,
+	0x6d, 0x65, 0x72, 0x61, 0x6c, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x12, 0x65, 0x70, 0x68, 0x65, 0x6d, 0x65, 0x72, 0x61, 0x6c, 0x50,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x32, 0x0a, 0x10, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70,
+	0x74, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x69,
+	0x70, 0x68, 0x65, 0x72, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a,
+	0x63, 0x69, 0x70, 0x68, 0x65, 0x72, 0x74, 0x65, 0x78, 0x74, 0x22, 0x27, 0x0a, 0x0d, 0x52, 0x65,
+	0x6a, 0x65, 0x63, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x22, 0x60, 0x0a, 0x21, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x50, 0x72,
+	0x75, 0x6e, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x55, 0x54, 0x58, 0x4f, 0x53, 0x65,
+	0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x3b, 0x0a, 0x10, 0x70, 0x72, 0x75, 0x6e,
+	0x69, 0x6e, 0x67, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x48,
+	0x61, 0x73, 0x68, 0x52, 0x10, 0x70, 0x72, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x69, 0x6e,
+	0x74, 0x48, 0x61, 0x73, 0x68, 0x22, 0xce, 0x01, 0x0a, 0x1f, 0x50, 0x72, 0x75, 0x6e, 0x69, 0x6e,
+	0x67, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x55, 0x74, 0x78, 0x6f, 0x53, 0x65, 0x74, 0x43, 0x68, 0x75,
+	0x6e, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x61, 0x0a, 0x19, 0x6f, 0x75, 0x74,
 	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x41, 0x6e, 0x64, 0x55, 0x74, 0x78, 0x6f, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x50, 0x61, 0x69, 0x72, 0x52, 0x19, 0x6f, 0x75, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x41,
-	0x6e, 0x64, 0x55, 0x74, 0x78, 0x6f, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x50, 0x61, 0x69, 0x72, 0x73,
+	0x79, 0x50, 0x61, 0x69, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x41, 0x6e, 0x64, 0x55, 0x74, 0x78, 0x6f, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x50, 0x61, 0x69,
+	0x72, 0x52, 0x19, 0x6f, 0x75, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x41, 0x6e, 0x64, 0x55, 0x74,
+	0x78, 0x6f, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x50, 0x61, 0x69, 0x72, 0x73, 0x12, 0x48, 0x0a, 0x11,
+	0x76, 0x70, 0x72, 0x6f, 0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77,
+	0x69, 0x72, 0x65, 0x2e, 0x56, 0x50, 0x72, 0x6f, 0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x11, 0x76, 0x70, 0x72, 0x6f, 0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x45,
+	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x68, 0x0a, 0x0f, 0x56, 0x50, 0x72, 0x6f, 0x67, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x2d, 0x0a, 0x09, 0x70, 0x72, 0x6f,
+	0x67, 0x72, 0x61, 0x6d, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x09, 0x70,
+	0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
 	0x22, 0x7f, 0x0a, 0x18, 0x4f, 0x75, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x41, 0x6e, 0x64, 0x55,
 	0x74, 0x78, 0x6f, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x50, 0x61, 0x69, 0x72, 0x12, 0x2f, 0x0a, 0x08,
 	0x6f, 0x75, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
@@ -3597,10 +4321,66 @@ var file_p2p_proto_rawDesc = []byte{
 	0x28, 0x0b, 0x32, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x42,
 	0x6c, 0x6f, 0x63, 0x6b, 0x47, 0x68, 0x6f, 0x73, 0x74, 0x64, 0x61, 0x67, 0x44, 0x61, 0x74, 0x61,
 	0x48, 0x61, 0x73, 0x68, 0x50, 0x61, 0x69, 0x72, 0x52, 0x0c, 0x67, 0x68, 0x6f, 0x73, 0x74, 0x64,
-	0x61, 0x67, 0x44, 0x61, 0x74, 0x61, 0x42, 0x26, 0x5a, 0x24, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6b, 0x61, 0x73, 0x70, 0x61, 0x6e, 0x65, 0x74, 0x2f, 0x6b, 0x61,
-	0x73, 0x70, 0x61, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x61, 0x67, 0x44, 0x61, 0x74, 0x61, 0x22, 0x5e, 0x0a, 0x19, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x41, 0x0a, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x4a, 0x0a, 0x1c, 0x49, 0x6e, 0x76, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2a, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x52, 0x03, 0x69,
+	0x64, 0x73, 0x22, 0x4e, 0x0a, 0x20, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2a, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x52, 0x03, 0x69,
+	0x64, 0x73, 0x22, 0x41, 0x0a, 0x1a, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6d,
+	0x70, 0x61, 0x63, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x23, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52,
+	0x04, 0x68, 0x61, 0x73, 0x68, 0x22, 0xda, 0x01, 0x0a, 0x13, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63,
+	0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2e, 0x0a,
+	0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x20, 0x0a,
+	0x0b, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x53, 0x61, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0b, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x53, 0x61, 0x6c, 0x74, 0x12,
+	0x1a, 0x0a, 0x08, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x04, 0x52, 0x08, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x73, 0x12, 0x55, 0x0a, 0x15, 0x70,
+	0x72, 0x65, 0x66, 0x69, 0x6c, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x6c, 0x6c, 0x65, 0x64,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x15, 0x70, 0x72, 0x65,
+	0x66, 0x69, 0x6c, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x22, 0x6d, 0x0a, 0x14, 0x50, 0x72, 0x65, 0x66, 0x69, 0x6c, 0x6c, 0x65, 0x64, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x12, 0x3f, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72,
+	0x65, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0x6a, 0x0a, 0x1f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x2d, 0x0a, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73,
+	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77,
+	0x69, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0d, 0x52, 0x07, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x22, 0x8c, 0x01,
+	0x0a, 0x18, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2d, 0x0a, 0x09, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x09,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x41, 0x0a, 0x0c, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x0c,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x22, 0x5a, 0x20,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x7a, 0x75, 0x61, 0x6e, 0x65,
+	0x74, 0x2f, 0x7a, 0x75, 0x61, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -3615,7 +4395,7 @@ func file_p2p_proto_rawDescGZIP() []byte {
 	return file_p2p_proto_rawDescData
 }
 
-var file_p2p_proto_msgTypes = make([]protoimpl.MessageInfo, 60)
+var file_p2p_proto_msgTypes = make([]protoimpl.MessageInfo, 71)
 var file_p2p_proto_goTypes = []interface{}{
 	(*RequestAddressesMessage)(nil),                            // 0: protowire.RequestAddressesMessage
 	(*AddressesMessage)(nil),                                   // 1: protowire.AddressesMessage
@@ -3645,38 +4425,49 @@ var file_p2p_proto_goTypes = []interface{}{
 	(*PongMessage)(nil),                                        // 25: protowire.PongMessage
 	(*VerackMessage)(nil),                                      // 26: protowire.VerackMessage
 	(*VersionMessage)(nil),                                     // 27: protowire.VersionMessage
-	(*RejectMessage)(nil),                                      // 28: protowire.RejectMessage
-	(*RequestPruningPointUTXOSetMessage)(nil),                  // 29: protowire.RequestPruningPointUTXOSetMessage
-	(*PruningPointUtxoSetChunkMessage)(nil),                    // 30: protowire.PruningPointUtxoSetChunkMessage
-	(*OutpointAndUtxoEntryPair)(nil),                           // 31: protowire.OutpointAndUtxoEntryPair
-	(*UtxoEntry)(nil),                                          // 32: protowire.UtxoEntry
-	(*RequestNextPruningPointUtxoSetChunkMessage)(nil),         // 33: protowire.RequestNextPruningPointUtxoSetChunkMessage
-	(*DonePruningPointUtxoSetChunksMessage)(nil),               // 34: protowire.DonePruningPointUtxoSetChunksMessage
-	(*RequestIBDBlocksMessage)(nil),                            // 35: protowire.RequestIBDBlocksMessage
-	(*UnexpectedPruningPointMessage)(nil),                      // 36: protowire.UnexpectedPruningPointMessage
-	(*IbdBlockLocatorMessage)(nil),                             // 37: protowire.IbdBlockLocatorMessage
-	(*RequestIBDChainBlockLocatorMessage)(nil),                 // 38: protowire.RequestIBDChainBlockLocatorMessage
-	(*IbdChainBlockLocatorMessage)(nil),                        // 39: protowire.IbdChainBlockLocatorMessage
-	(*RequestAnticoneMessage)(nil),                             // 40: protowire.RequestAnticoneMessage
-	(*IbdBlockLocatorHighestHashMessage)(nil),                  // 41: protowire.IbdBlockLocatorHighestHashMessage
-	(*IbdBlockLocatorHighestHashNotFoundMessage)(nil),          // 42: protowire.IbdBlockLocatorHighestHashNotFoundMessage
-	(*BlockHeadersMessage)(nil),                                // 43: protowire.BlockHeadersMessage
-	(*RequestPruningPointAndItsAnticoneMessage)(nil),           // 44: protowire.RequestPruningPointAndItsAnticoneMessage
-	(*RequestNextPruningPointAndItsAnticoneBlocksMessage)(nil), // 45: protowire.RequestNextPruningPointAndItsAnticoneBlocksMessage
-	(*BlockWithTrustedDataMessage)(nil),                        // 46: protowire.BlockWithTrustedDataMessage
-	(*DaaBlock)(nil),                                           // 47: protowire.DaaBlock
-	(*DaaBlockV4)(nil),                                         // 48: protowire.DaaBlockV4
-	(*BlockGhostdagDataHashPair)(nil),                          // 49: protowire.BlockGhostdagDataHashPair
-	(*GhostdagData)(nil),                                       // 50: protowire.GhostdagData
-	(*BluesAnticoneSizes)(nil),                                 // 51: protowire.BluesAnticoneSizes
-	(*DoneBlocksWithTrustedDataMessage)(nil),                   // 52: protowire.DoneBlocksWithTrustedDataMessage
-	(*PruningPointsMessage)(nil),                               // 53: protowire.PruningPointsMessage
-	(*RequestPruningPointProofMessage)(nil),                    // 54: protowire.RequestPruningPointProofMessage
-	(*PruningPointProofMessage)(nil),                           // 55: protowire.PruningPointProofMessage
-	(*PruningPointProofHeaderArray)(nil),                       // 56: protowire.PruningPointProofHeaderArray
-	(*ReadyMessage)(nil),                                       // 57: protowire.ReadyMessage
-	(*BlockWithTrustedDataV4Message)(nil),                      // 58: protowire.BlockWithTrustedDataV4Message
-	(*TrustedDataMessage)(nil),                                 // 59: protowire.TrustedDataMessage
+	(*EncryptionOfferMessage)(nil),                             // 28: protowire.EncryptionOfferMessage
+	(*EncryptedMessage)(nil),                                   // 29: protowire.EncryptedMessage
+	(*RejectMessage)(nil),                                      // 30: protowire.RejectMessage
+	(*RequestPruningPointUTXOSetMessage)(nil),                  // 31: protowire.RequestPruningPointUTXOSetMessage
+	(*PruningPointUtxoSetChunkMessage)(nil),                    // 32: protowire.PruningPointUtxoSetChunkMessage
+	(*VProgStateEntry)(nil),                                    // 33: protowire.VProgStateEntry
+	(*OutpointAndUtxoEntryPair)(nil),                           // 34: protowire.OutpointAndUtxoEntryPair
+	(*UtxoEntry)(nil),                                          // 35: protowire.UtxoEntry
+	(*RequestNextPruningPointUtxoSetChunkMessage)(nil),         // 36: protowire.RequestNextPruningPointUtxoSetChunkMessage
+	(*DonePruningPointUtxoSetChunksMessage)(nil),               // 37: protowire.DonePruningPointUtxoSetChunksMessage
+	(*RequestIBDBlocksMessage)(nil),                            // 38: protowire.RequestIBDBlocksMessage
+	(*UnexpectedPruningPointMessage)(nil),                      // 39: protowire.UnexpectedPruningPointMessage
+	(*IbdBlockLocatorMessage)(nil),                             // 40: protowire.IbdBlockLocatorMessage
+	(*RequestIBDChainBlockLocatorMessage)(nil),                 // 41: protowire.RequestIBDChainBlockLocatorMessage
+	(*IbdChainBlockLocatorMessage)(nil),                        // 42: protowire.IbdChainBlockLocatorMessage
+	(*RequestAnticoneMessage)(nil),                             // 43: protowire.RequestAnticoneMessage
+	(*IbdBlockLocatorHighestHashMessage)(nil),                  // 44: protowire.IbdBlockLocatorHighestHashMessage
+	(*IbdBlockLocatorHighestHashNotFoundMessage)(nil),          // 45: protowire.IbdBlockLocatorHighestHashNotFoundMessage
+	(*BlockHeadersMessage)(nil),                                // 46: protowire.BlockHeadersMessage
+	(*RequestPruningPointAndItsAnticoneMessage)(nil),           // 47: protowire.RequestPruningPointAndItsAnticoneMessage
+	(*RequestNextPruningPointAndItsAnticoneBlocksMessage)(nil), // 48: protowire.RequestNextPruningPointAndItsAnticoneBlocksMessage
+	(*BlockWithTrustedDataMessage)(nil),                        // 49: protowire.BlockWithTrustedDataMessage
+	(*DaaBlock)(nil),                                           // 50: protowire.DaaBlock
+	(*DaaBlockV4)(nil),                                         // 51: protowire.DaaBlockV4
+	(*BlockGhostdagDataHashPair)(nil),                          // 52: protowire.BlockGhostdagDataHashPair
+	(*GhostdagData)(nil),                                       // 53: protowire.GhostdagData
+	(*BluesAnticoneSizes)(nil),                                 // 54: protowire.BluesAnticoneSizes
+	(*DoneBlocksWithTrustedDataMessage)(nil),                   // 55: protowire.DoneBlocksWithTrustedDataMessage
+	(*PruningPointsMessage)(nil),                               // 56: protowire.PruningPointsMessage
+	(*RequestPruningPointProofMessage)(nil),                    // 57: protowire.RequestPruningPointProofMessage
+	(*PruningPointProofMessage)(nil),                           // 58: protowire.PruningPointProofMessage
+	(*PruningPointProofHeaderArray)(nil),                       // 59: protowire.PruningPointProofHeaderArray
+	(*ReadyMessage)(nil),                                       // 60: protowire.ReadyMessage
+	(*BlockWithTrustedDataV4Message)(nil),                      // 61: protowire.BlockWithTrustedDataV4Message
+	(*TrustedDataMessage)(nil),                                 // 62: protowire.TrustedDataMessage
+	(*TransactionPackageMessage)(nil),                          // 63: protowire.TransactionPackageMessage
+	(*InvTransactionPackageMessage)(nil),                       // 64: protowire.InvTransactionPackageMessage
+	(*RequestTransactionPackageMessage)(nil),                   // 65: protowire.RequestTransactionPackageMessage
+	(*RequestCompactBlockMessage)(nil),                         // 66: protowire.RequestCompactBlockMessage
+	(*CompactBlockMessage)(nil),                                // 67: protowire.CompactBlockMessage
+	(*PrefilledTransaction)(nil),                               // 68: protowire.PrefilledTransaction
+	(*RequestBlockTransactionsMessage)(nil),                    // 69: protowire.RequestBlockTransactionsMessage
+	(*BlockTransactionsMessage)(nil),                           // 70: protowire.BlockTransactionsMessage
 }
 var file_p2p_proto_depIdxs = []int32{
 	3,  // 0: protowire.RequestAddressesMessage.subnetworkId:type_name -> protowire.SubnetworkId
@@ -3706,46 +4497,59 @@ var file_p2p_proto_depIdxs = []int32{
 	7,  // 24: protowire.InvTransactionsMessage.ids:type_name -> protowire.TransactionId
 	2,  // 25: protowire.VersionMessage.address:type_name -> protowire.NetAddress
 	3,  // 26: protowire.VersionMessage.subnetworkId:type_name -> protowire.SubnetworkId
-	13, // 27: protowire.RequestPruningPointUTXOSetMessage.pruningPointHash:type_name -> protowire.Hash
-	31, // 28: protowire.PruningPointUtxoSetChunkMessage.outpointAndUtxoEntryPairs:type_name -> protowire.OutpointAndUtxoEntryPair
-	6,  // 29: protowire.OutpointAndUtxoEntryPair.outpoint:type_name -> protowire.Outpoint
-	32, // 30: protowire.OutpointAndUtxoEntryPair.utxoEntry:type_name -> protowire.UtxoEntry
-	8,  // 31: protowire.UtxoEntry.scriptPublicKey:type_name -> protowire.ScriptPublicKey
-	13, // 32: protowire.RequestIBDBlocksMessage.hashes:type_name -> protowire.Hash
-	13, // 33: protowire.IbdBlockLocatorMessage.targetHash:type_name -> protowire.Hash
-	13, // 34: protowire.IbdBlockLocatorMessage.blockLocatorHashes:type_name -> protowire.Hash
-	13, // 35: protowire.RequestIBDChainBlockLocatorMessage.lowHash:type_name -> protowire.Hash
-	13, // 36: protowire.RequestIBDChainBlockLocatorMessage.highHash:type_name -> protowire.Hash
-	13, // 37: protowire.IbdChainBlockLocatorMessage.blockLocatorHashes:type_name -> protowire.Hash
-	13, // 38: protowire.RequestAnticoneMessage.blockHash:type_name -> protowire.Hash
-	13, // 39: protowire.RequestAnticoneMessage.contextHash:type_name -> protowire.Hash
-	13, // 40: protowire.IbdBlockLocatorHighestHashMessage.highestHash:type_name -> protowire.Hash
-	11, // 41: protowire.BlockHeadersMessage.blockHeaders:type_name -> protowire.BlockHeader
-	10, // 42: protowire.BlockWithTrustedDataMessage.block:type_name -> protowire.BlockMessage
-	47, // 43: protowire.BlockWithTrustedDataMessage.daaWindow:type_name -> protowire.DaaBlock
-	49, // 44: protowire.BlockWithTrustedDataMessage.ghostdagData:type_name -> protowire.BlockGhostdagDataHashPair
-	10, // 45: protowire.DaaBlock.block:type_name -> protowire.BlockMessage
-	50, // 46: protowire.DaaBlock.ghostdagData:type_name -> protowire.GhostdagData
-	11, // 47: protowire.DaaBlockV4.header:type_name -> protowire.BlockHeader
-	50, // 48: protowire.DaaBlockV4.ghostdagData:type_name -> protowire.GhostdagData
-	13, // 49: protowire.BlockGhostdagDataHashPair.hash:type_name -> protowire.Hash
-	50, // 50: protowire.BlockGhostdagDataHashPair.ghostdagData:type_name -> protowire.GhostdagData
-	13, // 51: protowire.GhostdagData.selectedParent:type_name -> protowire.Hash
-	13, // 52: protowire.GhostdagData.mergeSetBlues:type_name -> protowire.Hash
-	13, // 53: protowire.GhostdagData.mergeSetReds:type_name -> protowire.Hash
-	51, // 54: protowire.GhostdagData.bluesAnticoneSizes:type_name -> protowire.BluesAnticoneSizes
-	13, // 55: protowire.BluesAnticoneSizes.blueHash:type_name -> protowire.Hash
-	11, // 56: protowire.PruningPointsMessage.headers:type_name -> protowire.BlockHeader
-	56, // 57: protowire.PruningPointProofMessage.headers:type_name -> protowire.PruningPointProofHeaderArray
-	11, // 58: protowire.PruningPointProofHeaderArray.headers:type_name -> protowire.BlockHeader
-	10, // 59: protowire.BlockWithTrustedDataV4Message.block:type_name -> protowire.BlockMessage
-	48, // 60: protowire.TrustedDataMessage.daaWindow:type_name -> protowire.DaaBlockV4
-	49, // 61: protowire.TrustedDataMessage.ghostdagData:type_name -> protowire.BlockGhostdagDataHashPair
-	62, // [62:62] is the sub-list for method output_type
-	62, // [62:62] is the sub-list for method input_type
-	62, // [62:62] is the sub-list for extension type_name
-	62, // [62:62] is the sub-list for extension extendee
-	0,  // [0:62] is the sub-list for field type_name
+	28, // 27: protowire.VersionMessage.encryptionOffer:type_name -> protowire.EncryptionOfferMessage
+	13, // 28: protowire.RequestPruningPointUTXOSetMessage.pruningPointHash:type_name -> protowire.Hash
+	34, // 29: protowire.PruningPointUtxoSetChunkMessage.outpointAndUtxoEntryPairs:type_name -> protowire.OutpointAndUtxoEntryPair
+	33, // 30: protowire.PruningPointUtxoSetChunkMessage.vprogStateEntries:type_name -> protowire.VProgStateEntry
+	13, // 31: protowire.VProgStateEntry.programId:type_name -> protowire.Hash
+	6,  // 32: protowire.OutpointAndUtxoEntryPair.outpoint:type_name -> protowire.Outpoint
+	35, // 33: protowire.OutpointAndUtxoEntryPair.utxoEntry:type_name -> protowire.UtxoEntry
+	8,  // 34: protowire.UtxoEntry.scriptPublicKey:type_name -> protowire.ScriptPublicKey
+	13, // 35: protowire.RequestIBDBlocksMessage.hashes:type_name -> protowire.Hash
+	13, // 36: protowire.IbdBlockLocatorMessage.targetHash:type_name -> protowire.Hash
+	13, // 37: protowire.IbdBlockLocatorMessage.blockLocatorHashes:type_name -> protowire.Hash
+	13, // 38: protowire.RequestIBDChainBlockLocatorMessage.lowHash:type_name -> protowire.Hash
+	13, // 39: protowire.RequestIBDChainBlockLocatorMessage.highHash:type_name -> protowire.Hash
+	13, // 40: protowire.IbdChainBlockLocatorMessage.blockLocatorHashes:type_name -> protowire.Hash
+	13, // 41: protowire.RequestAnticoneMessage.blockHash:type_name -> protowire.Hash
+	13, // 42: protowire.RequestAnticoneMessage.contextHash:type_name -> protowire.Hash
+	13, // 43: protowire.IbdBlockLocatorHighestHashMessage.highestHash:type_name -> protowire.Hash
+	11, // 44: protowire.BlockHeadersMessage.blockHeaders:type_name -> protowire.BlockHeader
+	10, // 45: protowire.BlockWithTrustedDataMessage.block:type_name -> protowire.BlockMessage
+	50, // 46: protowire.BlockWithTrustedDataMessage.daaWindow:type_name -> protowire.DaaBlock
+	52, // 47: protowire.BlockWithTrustedDataMessage.ghostdagData:type_name -> protowire.BlockGhostdagDataHashPair
+	10, // 48: protowire.DaaBlock.block:type_name -> protowire.BlockMessage
+	53, // 49: protowire.DaaBlock.ghostdagData:type_name -> protowire.GhostdagData
+	11, // 50: protowire.DaaBlockV4.header:type_name -> protowire.BlockHeader
+	53, // 51: protowire.DaaBlockV4.ghostdagData:type_name -> protowire.GhostdagData
+	13, // 52: protowire.BlockGhostdagDataHashPair.hash:type_name -> protowire.Hash
+	53, // 53: protowire.BlockGhostdagDataHashPair.ghostdagData:type_name -> protowire.GhostdagData
+	13, // 54: protowire.GhostdagData.selectedParent:type_name -> protowire.Hash
+	13, // 55: protowire.GhostdagData.mergeSetBlues:type_name -> protowire.Hash
+	13, // 56: protowire.GhostdagData.mergeSetReds:type_name -> protowire.Hash
+	54, // 57: protowire.GhostdagData.bluesAnticoneSizes:type_name -> protowire.BluesAnticoneSizes
+	13, // 58: protowire.BluesAnticoneSizes.blueHash:type_name -> protowire.Hash
+	11, // 59: protowire.PruningPointsMessage.headers:type_name -> protowire.BlockHeader
+	59, // 60: protowire.PruningPointProofMessage.headers:type_name -> protowire.PruningPointProofHeaderArray
+	11, // 61: protowire.PruningPointProofHeaderArray.headers:type_name -> protowire.BlockHeader
+	10, // 62: protowire.BlockWithTrustedDataV4Message.block:type_name -> protowire.BlockMessage
+	51, // 63: protowire.TrustedDataMessage.daaWindow:type_name -> protowire.DaaBlockV4
+	52, // 64: protowire.TrustedDataMessage.ghostdagData:type_name -> protowire.BlockGhostdagDataHashPair
+	4,  // 65: protowire.TransactionPackageMessage.transactions:type_name -> protowire.TransactionMessage
+	7,  // 66: protowire.InvTransactionPackageMessage.ids:type_name -> protowire.TransactionId
+	7,  // 67: protowire.RequestTransactionPackageMessage.ids:type_name -> protowire.TransactionId
+	13, // 68: protowire.RequestCompactBlockMessage.hash:type_name -> protowire.Hash
+	11, // 69: protowire.CompactBlockMessage.header:type_name -> protowire.BlockHeader
+	68, // 70: protowire.CompactBlockMessage.prefilledTransactions:type_name -> protowire.PrefilledTransaction
+	4,  // 71: protowire.PrefilledTransaction.transaction:type_name -> protowire.TransactionMessage
+	13, // 72: protowire.RequestBlockTransactionsMessage.blockHash:type_name -> protowire.Hash
+	13, // 73: protowire.BlockTransactionsMessage.blockHash:type_name -> protowire.Hash
+	4,  // 74: protowire.BlockTransactionsMessage.transactions:type_name -> protowire.TransactionMessage
+	75, // [75:75] is the sub-list for method output_type
+	75, // [75:75] is the sub-list for method input_type
+	75, // [75:75] is the sub-list for extension type_name
+	75, // [75:75] is the sub-list for extension extendee
+	0,  // [0:75] is the sub-list for field type_name
 }
 
 func init() { file_p2p_proto_init() }
@@ -4091,7 +4895,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RejectMessage); i {
+			switch v := v.(*EncryptionOfferMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4103,7 +4907,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestPruningPointUTXOSetMessage); i {
+			switch v := v.(*EncryptedMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4115,7 +4919,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PruningPointUtxoSetChunkMessage); i {
+			switch v := v.(*RejectMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4127,7 +4931,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OutpointAndUtxoEntryPair); i {
+			switch v := v.(*RequestPruningPointUTXOSetMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4139,7 +4943,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UtxoEntry); i {
+			switch v := v.(*PruningPointUtxoSetChunkMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4151,7 +4955,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestNextPruningPointUtxoSetChunkMessage); i {
+			switch v := v.(*VProgStateEntry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4163,7 +4967,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DonePruningPointUtxoSetChunksMessage); i {
+			switch v := v.(*OutpointAndUtxoEntryPair); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4175,7 +4979,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestIBDBlocksMessage); i {
+			switch v := v.(*UtxoEntry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4187,7 +4991,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UnexpectedPruningPointMessage); i {
+			switch v := v.(*RequestNextPruningPointUtxoSetChunkMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4199,7 +5003,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IbdBlockLocatorMessage); i {
+			switch v := v.(*DonePruningPointUtxoSetChunksMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4211,7 +5015,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestIBDChainBlockLocatorMessage); i {
+			switch v := v.(*RequestIBDBlocksMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4223,7 +5027,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IbdChainBlockLocatorMessage); i {
+			switch v := v.(*UnexpectedPruningPointMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4235,7 +5039,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestAnticoneMessage); i {
+			switch v := v.(*IbdBlockLocatorMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4247,7 +5051,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IbdBlockLocatorHighestHashMessage); i {
+			switch v := v.(*RequestIBDChainBlockLocatorMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4259,7 +5063,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IbdBlockLocatorHighestHashNotFoundMessage); i {
+			switch v := v.(*IbdChainBlockLocatorMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4271,7 +5075,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlockHeadersMessage); i {
+			switch v := v.(*RequestAnticoneMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4283,7 +5087,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestPruningPointAndItsAnticoneMessage); i {
+			switch v := v.(*IbdBlockLocatorHighestHashMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4295,7 +5099,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestNextPruningPointAndItsAnticoneBlocksMessage); i {
+			switch v := v.(*IbdBlockLocatorHighestHashNotFoundMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4307,7 +5111,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlockWithTrustedDataMessage); i {
+			switch v := v.(*BlockHeadersMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4319,7 +5123,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DaaBlock); i {
+			switch v := v.(*RequestPruningPointAndItsAnticoneMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4331,7 +5135,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DaaBlockV4); i {
+			switch v := v.(*RequestNextPruningPointAndItsAnticoneBlocksMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4343,7 +5147,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlockGhostdagDataHashPair); i {
+			switch v := v.(*BlockWithTrustedDataMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4355,7 +5159,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GhostdagData); i {
+			switch v := v.(*DaaBlock); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4367,7 +5171,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BluesAnticoneSizes); i {
+			switch v := v.(*DaaBlockV4); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4379,7 +5183,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DoneBlocksWithTrustedDataMessage); i {
+			switch v := v.(*BlockGhostdagDataHashPair); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4391,7 +5195,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PruningPointsMessage); i {
+			switch v := v.(*GhostdagData); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4403,7 +5207,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestPruningPointProofMessage); i {
+			switch v := v.(*BluesAnticoneSizes); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4415,7 +5219,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PruningPointProofMessage); i {
+			switch v := v.(*DoneBlocksWithTrustedDataMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4427,7 +5231,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PruningPointProofHeaderArray); i {
+			switch v := v.(*PruningPointsMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4439,7 +5243,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReadyMessage); i {
+			switch v := v.(*RequestPruningPointProofMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4451,7 +5255,7 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlockWithTrustedDataV4Message); i {
+			switch v := v.(*PruningPointProofMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4463,6 +5267,42 @@ func file_p2p_proto_init() {
 			}
 		}
 		file_p2p_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PruningPointProofHeaderArray); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_p2p_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadyMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_p2p_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockWithTrustedDataV4Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_p2p_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TrustedDataMessage); i {
 			case 0:
 				return &v.state
@@ -4474,6 +5314,102 @@ func file_p2p_proto_init() {
 				return nil
 			}
 		}
+		file_p2p_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransactionPackageMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_p2p_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InvTransactionPackageMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_p2p_proto_msgTypes[65].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestTransactionPackageMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_p2p_proto_msgTypes[66].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestCompactBlockMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_p2p_proto_msgTypes[67].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompactBlockMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_p2p_proto_msgTypes[68].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrefilledTransaction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_p2p_proto_msgTypes[69].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestBlockTransactionsMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_p2p_proto_msgTypes[70].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockTransactionsMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -4481,7 +5417,7 @@ func file_p2p_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_p2p_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   60,
+			NumMessages:   71,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
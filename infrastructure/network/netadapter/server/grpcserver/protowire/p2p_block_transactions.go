@@ -0,0 +1,47 @@
+package protowire
+
+import (
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *ZuadMessage_BlockTransactions) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ZuadMessage_BlockTransactions is nil")
+	}
+	return x.BlockTransactions.toAppMessage()
+}
+
+func (x *BlockTransactionsMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "BlockTransactionsMessage is nil")
+	}
+	blockHash, err := x.BlockHash.toDomain()
+	if err != nil {
+		return nil, err
+	}
+	transactions := make([]*appmessage.MsgTx, len(x.Transactions))
+	for i, protoTransaction := range x.Transactions {
+		transaction, err := protoTransaction.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+		transactions[i] = transaction.(*appmessage.MsgTx)
+	}
+	return &appmessage.MsgBlockTransactions{
+		BlockHash:    blockHash,
+		Transactions: transactions,
+	}, nil
+}
+
+func (x *ZuadMessage_BlockTransactions) fromAppMessage(msgBlockTransactions *appmessage.MsgBlockTransactions) error {
+	x.BlockTransactions = &BlockTransactionsMessage{
+		BlockHash:    domainHashToProto(msgBlockTransactions.BlockHash),
+		Transactions: make([]*TransactionMessage, len(msgBlockTransactions.Transactions)),
+	}
+	for i, msgTx := range msgBlockTransactions.Transactions {
+		x.BlockTransactions.Transactions[i] = new(TransactionMessage)
+		x.BlockTransactions.Transactions[i].fromAppMessage(msgTx)
+	}
+	return nil
+}
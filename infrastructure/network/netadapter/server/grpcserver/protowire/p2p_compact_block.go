@@ -0,0 +1,85 @@
+package protowire
+
+import (
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *ZuadMessage_CompactBlock) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ZuadMessage_CompactBlock is nil")
+	}
+	return x.CompactBlock.toAppMessage()
+}
+
+func (x *CompactBlockMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "CompactBlockMessage is nil")
+	}
+	header, err := x.Header.toAppMessage()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, shortID := range x.ShortIds {
+		if shortID > appmessage.MaxShortTransactionID {
+			return nil, errors.Errorf("short transaction ID %d is longer than 6 bytes", shortID)
+		}
+	}
+
+	prefilledTransactions := make([]*appmessage.PrefilledTransaction, len(x.PrefilledTransactions))
+	for i, protoPrefilledTransaction := range x.PrefilledTransactions {
+		prefilledTransaction, err := protoPrefilledTransaction.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+		prefilledTransactions[i] = prefilledTransaction
+	}
+
+	return &appmessage.MsgCompactBlock{
+		Header:                *header,
+		ShortIDSalt:           x.ShortIdSalt,
+		ShortIDs:              x.ShortIds,
+		PrefilledTransactions: prefilledTransactions,
+	}, nil
+}
+
+func (x *PrefilledTransaction) toAppMessage() (*appmessage.PrefilledTransaction, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "PrefilledTransaction is nil")
+	}
+	msgTx, err := x.Transaction.toAppMessage()
+	if err != nil {
+		return nil, err
+	}
+	return &appmessage.PrefilledTransaction{
+		Index: x.Index,
+		Tx:    msgTx.(*appmessage.MsgTx),
+	}, nil
+}
+
+func (x *ZuadMessage_CompactBlock) fromAppMessage(msgCompactBlock *appmessage.MsgCompactBlock) error {
+	protoHeader := new(BlockHeader)
+	err := protoHeader.fromAppMessage(&msgCompactBlock.Header)
+	if err != nil {
+		return err
+	}
+
+	protoPrefilledTransactions := make([]*PrefilledTransaction, len(msgCompactBlock.PrefilledTransactions))
+	for i, prefilledTransaction := range msgCompactBlock.PrefilledTransactions {
+		protoTx := new(TransactionMessage)
+		protoTx.fromAppMessage(prefilledTransaction.Tx)
+		protoPrefilledTransactions[i] = &PrefilledTransaction{
+			Index:       prefilledTransaction.Index,
+			Transaction: protoTx,
+		}
+	}
+
+	x.CompactBlock = &CompactBlockMessage{
+		Header:                protoHeader,
+		ShortIdSalt:           msgCompactBlock.ShortIDSalt,
+		ShortIds:              msgCompactBlock.ShortIDs,
+		PrefilledTransactions: protoPrefilledTransactions,
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package protowire
+
+import (
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *ZuadMessage_InvTransactionPackage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ZuadMessage_InvTransactionPackage is nil")
+	}
+	return x.InvTransactionPackage.toAppMessage()
+}
+
+func (x *InvTransactionPackageMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "InvTransactionPackageMessage is nil")
+	}
+	if len(x.Ids) > appmessage.MaxTransactionsPerTransactionPackageMsg {
+		return nil, errors.Errorf("too many transaction IDs for message "+
+			"[count %d, max %d]", len(x.Ids), appmessage.MaxTransactionsPerTransactionPackageMsg)
+	}
+
+	ids, err := protoTransactionIDsToDomain(x.Ids)
+	if err != nil {
+		return nil, err
+	}
+	return &appmessage.MsgInvTransactionPackage{TxIDs: ids}, nil
+}
+
+func (x *ZuadMessage_InvTransactionPackage) fromAppMessage(
+	msgInvTransactionPackage *appmessage.MsgInvTransactionPackage) error {
+
+	if len(msgInvTransactionPackage.TxIDs) > appmessage.MaxTransactionsPerTransactionPackageMsg {
+		return errors.Errorf("too many transaction IDs for message "+
+			"[count %d, max %d]", len(msgInvTransactionPackage.TxIDs), appmessage.MaxTransactionsPerTransactionPackageMsg)
+	}
+
+	x.InvTransactionPackage = &InvTransactionPackageMessage{
+		Ids: wireTransactionIDsToProto(msgInvTransactionPackage.TxIDs),
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package protowire
 
 import (
 	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
 	"github.com/pkg/errors"
 )
 
@@ -17,8 +18,32 @@ func (x *ZuadMessage_PruningPointUtxoSetChunk) toAppMessage() (appmessage.Messag
 		}
 		outpointAndUTXOEntryPairs[i] = outpointEntryPairAppMessage
 	}
+	vprogStateEntries := make([]*externalapi.VProgStateEntry, len(x.PruningPointUtxoSetChunk.VprogStateEntries))
+	for i, vprogStateEntry := range x.PruningPointUtxoSetChunk.VprogStateEntries {
+		domainVProgStateEntry, err := vprogStateEntry.toDomain()
+		if err != nil {
+			return nil, err
+		}
+		vprogStateEntries[i] = domainVProgStateEntry
+	}
 	return &appmessage.MsgPruningPointUTXOSetChunk{
 		OutpointAndUTXOEntryPairs: outpointAndUTXOEntryPairs,
+		VProgStateEntries:         vprogStateEntries,
+	}, nil
+}
+
+func (x *VProgStateEntry) toDomain() (*externalapi.VProgStateEntry, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "VProgStateEntry is nil")
+	}
+	programID, err := x.ProgramId.toDomain()
+	if err != nil {
+		return nil, err
+	}
+	return &externalapi.VProgStateEntry{
+		ProgramID: programID,
+		Key:       x.Key,
+		Value:     x.Value,
 	}, nil
 }
 
@@ -63,8 +88,17 @@ func (x *ZuadMessage_PruningPointUtxoSetChunk) fromAppMessage(message *appmessag
 			UtxoEntry: utxoEntry,
 		}
 	}
+	vprogStateEntries := make([]*VProgStateEntry, len(message.VProgStateEntries))
+	for i, vprogStateEntry := range message.VProgStateEntries {
+		vprogStateEntries[i] = &VProgStateEntry{
+			ProgramId: domainHashToProto(vprogStateEntry.ProgramID),
+			Key:       vprogStateEntry.Key,
+			Value:     vprogStateEntry.Value,
+		}
+	}
 	x.PruningPointUtxoSetChunk = &PruningPointUtxoSetChunkMessage{
 		OutpointAndUtxoEntryPairs: outpointAndUTXOEntryPairs,
+		VprogStateEntries:         vprogStateEntries,
 	}
 	return nil
 }
@@ -0,0 +1,31 @@
+package protowire
+
+import (
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *ZuadMessage_RequestCompactBlock) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ZuadMessage_RequestCompactBlock is nil")
+	}
+	return x.RequestCompactBlock.toAppMessage()
+}
+
+func (x *RequestCompactBlockMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "RequestCompactBlockMessage is nil")
+	}
+	hash, err := x.Hash.toDomain()
+	if err != nil {
+		return nil, err
+	}
+	return &appmessage.MsgRequestCompactBlock{Hash: hash}, nil
+}
+
+func (x *ZuadMessage_RequestCompactBlock) fromAppMessage(msgRequestCompactBlock *appmessage.MsgRequestCompactBlock) error {
+	x.RequestCompactBlock = &RequestCompactBlockMessage{
+		Hash: domainHashToProto(msgRequestCompactBlock.Hash),
+	}
+	return nil
+}
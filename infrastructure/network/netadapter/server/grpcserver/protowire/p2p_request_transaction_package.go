@@ -0,0 +1,43 @@
+package protowire
+
+import (
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *ZuadMessage_RequestTransactionPackage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ZuadMessage_RequestTransactionPackage is nil")
+	}
+	return x.RequestTransactionPackage.toAppMessage()
+}
+
+func (x *RequestTransactionPackageMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "RequestTransactionPackageMessage is nil")
+	}
+	if len(x.Ids) > appmessage.MaxTransactionsPerTransactionPackageMsg {
+		return nil, errors.Errorf("too many transaction IDs for message "+
+			"[count %d, max %d]", len(x.Ids), appmessage.MaxTransactionsPerTransactionPackageMsg)
+	}
+
+	ids, err := protoTransactionIDsToDomain(x.Ids)
+	if err != nil {
+		return nil, err
+	}
+	return &appmessage.MsgRequestTransactionPackage{TxIDs: ids}, nil
+}
+
+func (x *ZuadMessage_RequestTransactionPackage) fromAppMessage(
+	msgRequestTransactionPackage *appmessage.MsgRequestTransactionPackage) error {
+
+	if len(msgRequestTransactionPackage.TxIDs) > appmessage.MaxTransactionsPerTransactionPackageMsg {
+		return errors.Errorf("too many transaction IDs for message "+
+			"[count %d, max %d]", len(msgRequestTransactionPackage.TxIDs), appmessage.MaxTransactionsPerTransactionPackageMsg)
+	}
+
+	x.RequestTransactionPackage = &RequestTransactionPackageMessage{
+		Ids: wireTransactionIDsToProto(msgRequestTransactionPackage.TxIDs),
+	}
+	return nil
+}
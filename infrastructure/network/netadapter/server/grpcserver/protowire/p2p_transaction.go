@@ -50,6 +50,9 @@ func (x *TransactionMessage) toAppMessage() (appmessage.Message, error) {
 	if x.Version > math.MaxUint16 {
 		return nil, errors.Errorf("Invalid transaction version - bigger then uint16")
 	}
+	if x.VprogVersion > math.MaxUint8 {
+		return nil, errors.Errorf("Invalid transaction vprog version - bigger then uint8")
+	}
 	return &appmessage.MsgTx{
 		Version:      uint16(x.Version),
 		TxIn:         inputs,
@@ -58,6 +61,11 @@ func (x *TransactionMessage) toAppMessage() (appmessage.Message, error) {
 		SubnetworkID: *subnetworkID,
 		Gas:          x.Gas,
 		Payload:      x.Payload,
+
+		VProgVersion:  byte(x.VprogVersion),
+		VProgCode:     x.VprogCode,
+		VProgData:     x.VprogData,
+		VProgGasLimit: x.VprogGasLimit,
 	}, nil
 }
 
@@ -115,12 +123,16 @@ func (x *TransactionMessage) fromAppMessage(msgTx *appmessage.MsgTx) {
 	}
 
 	*x = TransactionMessage{
-		Version:      uint32(msgTx.Version),
-		Inputs:       protoInputs,
-		Outputs:      protoOutputs,
-		LockTime:     msgTx.LockTime,
-		SubnetworkId: domainSubnetworkIDToProto(&msgTx.SubnetworkID),
-		Gas:          msgTx.Gas,
-		Payload:      msgTx.Payload,
+		Version:       uint32(msgTx.Version),
+		Inputs:        protoInputs,
+		Outputs:       protoOutputs,
+		LockTime:      msgTx.LockTime,
+		SubnetworkId:  domainSubnetworkIDToProto(&msgTx.SubnetworkID),
+		Gas:           msgTx.Gas,
+		Payload:       msgTx.Payload,
+		VprogVersion:  uint32(msgTx.VProgVersion),
+		VprogCode:     msgTx.VProgCode,
+		VprogData:     msgTx.VProgData,
+		VprogGasLimit: msgTx.VProgGasLimit,
 	}
 }
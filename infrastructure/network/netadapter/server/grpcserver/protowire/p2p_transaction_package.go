@@ -0,0 +1,48 @@
+package protowire
+
+import (
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *ZuadMessage_TransactionPackage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ZuadMessage_TransactionPackage is nil")
+	}
+	return x.TransactionPackage.toAppMessage()
+}
+
+func (x *TransactionPackageMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "TransactionPackageMessage is nil")
+	}
+	if len(x.Transactions) > appmessage.MaxTransactionsPerTransactionPackageMsg {
+		return nil, errors.Errorf("too many transactions for message "+
+			"[count %d, max %d]", len(x.Transactions), appmessage.MaxTransactionsPerTransactionPackageMsg)
+	}
+	transactions := make([]*appmessage.MsgTx, len(x.Transactions))
+	for i, protoTransaction := range x.Transactions {
+		transaction, err := protoTransaction.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+		transactions[i] = transaction.(*appmessage.MsgTx)
+	}
+	return &appmessage.MsgTransactionPackage{Transactions: transactions}, nil
+}
+
+func (x *ZuadMessage_TransactionPackage) fromAppMessage(msgTransactionPackage *appmessage.MsgTransactionPackage) error {
+	if len(msgTransactionPackage.Transactions) > appmessage.MaxTransactionsPerTransactionPackageMsg {
+		return errors.Errorf("too many transactions for message "+
+			"[count %d, max %d]", len(msgTransactionPackage.Transactions), appmessage.MaxTransactionsPerTransactionPackageMsg)
+	}
+
+	x.TransactionPackage = &TransactionPackageMessage{
+		Transactions: make([]*TransactionMessage, len(msgTransactionPackage.Transactions)),
+	}
+	for i, msgTx := range msgTransactionPackage.Transactions {
+		x.TransactionPackage.Transactions[i] = new(TransactionMessage)
+		x.TransactionPackage.Transactions[i].fromAppMessage(msgTx)
+	}
+	return nil
+}
@@ -54,9 +54,22 @@ func (x *VersionMessage) toAppMessage() (appmessage.Message, error) {
 		UserAgent:       x.UserAgent,
 		DisableRelayTx:  x.DisableRelayTx,
 		SubnetworkID:    subnetworkID,
+		EncryptionOffer: x.EncryptionOffer.toAppMessage(),
 	}, nil
 }
 
+func (x *EncryptionOfferMessage) toAppMessage() *appmessage.EncryptionOffer {
+	// EncryptionOffer is optional for peers that don't support encryption
+	if x == nil {
+		return nil
+	}
+	return &appmessage.EncryptionOffer{
+		IdentityPublicKey:  x.IdentityPublicKey,
+		EphemeralPublicKey: x.EphemeralPublicKey,
+		Signature:          x.Signature,
+	}
+}
+
 func (x *ZuadMessage_Version) fromAppMessage(msgVersion *appmessage.MsgVersion) error {
 	err := appmessage.ValidateUserAgent(msgVersion.UserAgent)
 	if err != nil {
@@ -84,6 +97,18 @@ func (x *ZuadMessage_Version) fromAppMessage(msgVersion *appmessage.MsgVersion)
 		UserAgent:       msgVersion.UserAgent,
 		DisableRelayTx:  msgVersion.DisableRelayTx,
 		SubnetworkId:    domainSubnetworkIDToProto(msgVersion.SubnetworkID),
+		EncryptionOffer: appMessageEncryptionOfferToProto(msgVersion.EncryptionOffer),
 	}
 	return nil
 }
+
+func appMessageEncryptionOfferToProto(encryptionOffer *appmessage.EncryptionOffer) *EncryptionOfferMessage {
+	if encryptionOffer == nil {
+		return nil
+	}
+	return &EncryptionOfferMessage{
+		IdentityPublicKey:  encryptionOffer.IdentityPublicKey,
+		EphemeralPublicKey: encryptionOffer.EphemeralPublicKey,
+		Signature:          encryptionOffer.Signature,
+	}
+}
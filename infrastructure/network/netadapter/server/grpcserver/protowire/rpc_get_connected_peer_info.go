@@ -37,6 +37,9 @@ func (x *ZuadMessage_GetConnectedPeerInfoResponse) fromAppMessage(message *appme
 			AdvertisedProtocolVersion: info.AdvertisedProtocolVersion,
 			TimeConnected:             info.TimeConnected,
 			IsIbdPeer:                 info.IsIBDPeer,
+			IsEncrypted:               info.IsEncrypted,
+			IdentityKey:               info.IdentityKey,
+			MisbehaviorScore:          info.MisbehaviorScore,
 		}
 	}
 	x.GetConnectedPeerInfoResponse = &GetConnectedPeerInfoResponseMessage{
@@ -88,5 +91,8 @@ func (x *GetConnectedPeerInfoMessage) toAppMessage() (*appmessage.GetConnectedPe
 		AdvertisedProtocolVersion: x.AdvertisedProtocolVersion,
 		TimeConnected:             x.TimeOffset,
 		IsIBDPeer:                 x.IsIbdPeer,
+		IsEncrypted:               x.IsEncrypted,
+		IdentityKey:               x.IdentityKey,
+		MisbehaviorScore:          x.MisbehaviorScore,
 	}, nil
 }
@@ -1,8 +1,8 @@
 package protowire
 
 import (
-	"github.com/zuanet/zuad/app/appmessage"
 	"github.com/pkg/errors"
+	"github.com/zuanet/zuad/app/appmessage"
 )
 
 func (x *ZuadMessage_GetInfoRequest) toAppMessage() (appmessage.Message, error) {
@@ -27,12 +27,13 @@ func (x *ZuadMessage_GetInfoResponse) fromAppMessage(message *appmessage.GetInfo
 		err = &RPCError{Message: message.Error.Message}
 	}
 	x.GetInfoResponse = &GetInfoResponseMessage{
-		P2PId:         message.P2PID,
-		ServerVersion: message.ServerVersion,
-		MempoolSize:   message.MempoolSize,
-		IsUtxoIndexed: message.IsUtxoIndexed,
-		IsSynced:      message.IsSynced,
-		Error:         err,
+		P2PId:           message.P2PID,
+		ServerVersion:   message.ServerVersion,
+		MempoolSize:     message.MempoolSize,
+		IsUtxoIndexed:   message.IsUtxoIndexed,
+		IsSynced:        message.IsSynced,
+		MinimumRelayFee: message.MinimumRelayFee,
+		Error:           err,
 	}
 	return nil
 }
@@ -52,11 +53,12 @@ func (x *GetInfoResponseMessage) toAppMessage() (appmessage.Message, error) {
 	}
 
 	return &appmessage.GetInfoResponseMessage{
-		P2PID:         x.P2PId,
-		MempoolSize:   x.MempoolSize,
-		ServerVersion: x.ServerVersion,
-		IsUtxoIndexed: x.IsUtxoIndexed,
-		IsSynced:      x.IsSynced,
+		P2PID:           x.P2PId,
+		MempoolSize:     x.MempoolSize,
+		ServerVersion:   x.ServerVersion,
+		IsUtxoIndexed:   x.IsUtxoIndexed,
+		IsSynced:        x.IsSynced,
+		MinimumRelayFee: x.MinimumRelayFee,
 
 		Error: rpcErr,
 	}, nil
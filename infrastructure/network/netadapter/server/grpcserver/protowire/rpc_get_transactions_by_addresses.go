@@ -0,0 +1,145 @@
+package protowire
+
+import (
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *ZuadMessage_GetTransactionsByAddressesRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ZuadMessage_GetTransactionsByAddressesRequest is nil")
+	}
+	return x.GetTransactionsByAddressesRequest.toAppMessage()
+}
+
+func (x *ZuadMessage_GetTransactionsByAddressesRequest) fromAppMessage(
+	message *appmessage.GetTransactionsByAddressesRequestMessage) error {
+
+	x.GetTransactionsByAddressesRequest = &GetTransactionsByAddressesRequestMessage{
+		Addresses:    message.Addresses,
+		FromDaaScore: message.FromDAAScore,
+		Limit:        message.Limit,
+	}
+	return nil
+}
+
+func (x *GetTransactionsByAddressesRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetTransactionsByAddressesRequestMessage is nil")
+	}
+	return &appmessage.GetTransactionsByAddressesRequestMessage{
+		Addresses:    x.Addresses,
+		FromDAAScore: x.FromDaaScore,
+		Limit:        x.Limit,
+	}, nil
+}
+
+func (x *ZuadMessage_GetTransactionsByAddressesResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ZuadMessage_GetTransactionsByAddressesResponse is nil")
+	}
+	return x.GetTransactionsByAddressesResponse.toAppMessage()
+}
+
+func (x *ZuadMessage_GetTransactionsByAddressesResponse) fromAppMessage(
+	message *appmessage.GetTransactionsByAddressesResponseMessage) error {
+
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	entries := make([]*TransactionsByAddressEntry, len(message.Entries))
+	for i, entry := range message.Entries {
+		entries[i] = &TransactionsByAddressEntry{}
+		entries[i].fromAppMessage(entry)
+	}
+	x.GetTransactionsByAddressesResponse = &GetTransactionsByAddressesResponseMessage{
+		Entries: entries,
+		Error:   err,
+	}
+	return nil
+}
+
+func (x *GetTransactionsByAddressesResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetTransactionsByAddressesResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	if rpcErr != nil && len(x.Entries) != 0 {
+		return nil, errors.New("GetTransactionsByAddressesResponseMessage contains both an error and a response")
+	}
+
+	entries := make([]*appmessage.TransactionsByAddressEntry, len(x.Entries))
+	for i, entry := range x.Entries {
+		entryAsAppMessage, err := entry.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+		entries[i] = entryAsAppMessage
+	}
+
+	return &appmessage.GetTransactionsByAddressesResponseMessage{
+		Entries: entries,
+		Error:   rpcErr,
+	}, nil
+}
+
+func (x *TransactionsByAddressEntry) toAppMessage() (*appmessage.TransactionsByAddressEntry, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "TransactionsByAddressEntry is nil")
+	}
+	transactions := make([]*appmessage.RPCAddressTransaction, len(x.Transactions))
+	for i, transaction := range x.Transactions {
+		transactionAsAppMessage, err := transaction.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+		transactions[i] = transactionAsAppMessage
+	}
+	return &appmessage.TransactionsByAddressEntry{
+		Address:      x.Address,
+		Transactions: transactions,
+		NextDAAScore: x.NextDaaScore,
+	}, nil
+}
+
+func (x *TransactionsByAddressEntry) fromAppMessage(message *appmessage.TransactionsByAddressEntry) {
+	transactions := make([]*RpcAddressTransaction, len(message.Transactions))
+	for i, transaction := range message.Transactions {
+		transactions[i] = &RpcAddressTransaction{}
+		transactions[i].fromAppMessage(transaction)
+	}
+	*x = TransactionsByAddressEntry{
+		Address:      message.Address,
+		Transactions: transactions,
+		NextDaaScore: message.NextDAAScore,
+	}
+}
+
+func (x *RpcAddressTransaction) toAppMessage() (*appmessage.RPCAddressTransaction, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "RpcAddressTransaction is nil")
+	}
+	return &appmessage.RPCAddressTransaction{
+		TransactionID:      x.TransactionId,
+		AcceptingBlockHash: x.AcceptingBlockHash,
+		DAAScore:           x.DaaScore,
+		ReceivedAmount:     x.ReceivedAmount,
+		SpentAmount:        x.SpentAmount,
+	}, nil
+}
+
+func (x *RpcAddressTransaction) fromAppMessage(message *appmessage.RPCAddressTransaction) {
+	*x = RpcAddressTransaction{
+		TransactionId:      message.TransactionID,
+		AcceptingBlockHash: message.AcceptingBlockHash,
+		DaaScore:           message.DAAScore,
+		ReceivedAmount:     message.ReceivedAmount,
+		SpentAmount:        message.SpentAmount,
+	}
+}
@@ -0,0 +1,66 @@
+package protowire
+
+import (
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *ZuadMessage_GetVProgCodeRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ZuadMessage_GetVProgCodeRequest is nil")
+	}
+	return x.GetVProgCodeRequest.toAppMessage()
+}
+
+func (x *ZuadMessage_GetVProgCodeRequest) fromAppMessage(message *appmessage.GetVProgCodeRequestMessage) error {
+	x.GetVProgCodeRequest = &GetVProgCodeRequestMessage{
+		ProgramId: message.ProgramID,
+	}
+	return nil
+}
+
+func (x *GetVProgCodeRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetVProgCodeRequestMessage is nil")
+	}
+	return &appmessage.GetVProgCodeRequestMessage{
+		ProgramID: x.ProgramId,
+	}, nil
+}
+
+func (x *ZuadMessage_GetVProgCodeResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ZuadMessage_GetVProgCodeResponse is nil")
+	}
+	return x.GetVProgCodeResponse.toAppMessage()
+}
+
+func (x *ZuadMessage_GetVProgCodeResponse) fromAppMessage(message *appmessage.GetVProgCodeResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.GetVProgCodeResponse = &GetVProgCodeResponseMessage{
+		Code: message.Code,
+
+		Error: err,
+	}
+	return nil
+}
+
+func (x *GetVProgCodeResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetVProgCodeResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	return &appmessage.GetVProgCodeResponseMessage{
+		Code: x.Code,
+
+		Error: rpcErr,
+	}, nil
+}
@@ -0,0 +1,76 @@
+package protowire
+
+import (
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *ZuadMessage_GetVProgStorageRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ZuadMessage_GetVProgStorageRequest is nil")
+	}
+	return x.GetVProgStorageRequest.toAppMessage()
+}
+
+func (x *ZuadMessage_GetVProgStorageRequest) fromAppMessage(message *appmessage.GetVProgStorageRequestMessage) error {
+	x.GetVProgStorageRequest = &GetVProgStorageRequestMessage{
+		ProgramId: message.ProgramID,
+		Keys:      message.Keys,
+	}
+	return nil
+}
+
+func (x *GetVProgStorageRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetVProgStorageRequestMessage is nil")
+	}
+	return &appmessage.GetVProgStorageRequestMessage{
+		ProgramID: x.ProgramId,
+		Keys:      x.Keys,
+	}, nil
+}
+
+func (x *ZuadMessage_GetVProgStorageResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ZuadMessage_GetVProgStorageResponse is nil")
+	}
+	return x.GetVProgStorageResponse.toAppMessage()
+}
+
+func (x *ZuadMessage_GetVProgStorageResponse) fromAppMessage(message *appmessage.GetVProgStorageResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.GetVProgStorageResponse = &GetVProgStorageResponseMessage{
+		Entries: vprogStorageEntriesFromAppMessage(message.Entries),
+
+		Error: err,
+	}
+	return nil
+}
+
+func (x *GetVProgStorageResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetVProgStorageResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	if rpcErr != nil && len(x.Entries) != 0 {
+		return nil, errors.New("GetVProgStorageResponseMessage contains both an error and a response")
+	}
+	entries, err := vprogStorageEntriesToAppMessage(x.Entries)
+	if err != nil {
+		return nil, err
+	}
+
+	return &appmessage.GetVProgStorageResponseMessage{
+		Entries: entries,
+
+		Error: rpcErr,
+	}, nil
+}
@@ -0,0 +1,104 @@
+package protowire
+
+import (
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *ZuadMessage_NotifyMempoolChangedRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ZuadMessage_NotifyMempoolChangedRequest is nil")
+	}
+	return x.NotifyMempoolChangedRequest.toAppMessage()
+}
+
+func (x *ZuadMessage_NotifyMempoolChangedRequest) fromAppMessage(message *appmessage.NotifyMempoolChangedRequestMessage) error {
+	x.NotifyMempoolChangedRequest = &NotifyMempoolChangedRequestMessage{
+		IncludeTransactions: message.IncludeTransactions,
+	}
+	return nil
+}
+
+func (x *NotifyMempoolChangedRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "NotifyMempoolChangedRequestMessage is nil")
+	}
+	return &appmessage.NotifyMempoolChangedRequestMessage{
+		IncludeTransactions: x.IncludeTransactions,
+	}, nil
+}
+
+func (x *ZuadMessage_NotifyMempoolChangedResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ZuadMessage_NotifyMempoolChangedResponse is nil")
+	}
+	return x.NotifyMempoolChangedResponse.toAppMessage()
+}
+
+func (x *ZuadMessage_NotifyMempoolChangedResponse) fromAppMessage(message *appmessage.NotifyMempoolChangedResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.NotifyMempoolChangedResponse = &NotifyMempoolChangedResponseMessage{
+		Error: err,
+	}
+	return nil
+}
+
+func (x *NotifyMempoolChangedResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "NotifyMempoolChangedResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.NotifyMempoolChangedResponseMessage{
+		Error: rpcErr,
+	}, nil
+}
+
+func (x *ZuadMessage_MempoolChangedNotification) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ZuadMessage_MempoolChangedNotification is nil")
+	}
+	return x.MempoolChangedNotification.toAppMessage()
+}
+
+func (x *ZuadMessage_MempoolChangedNotification) fromAppMessage(message *appmessage.MempoolChangedNotificationMessage) error {
+	var transaction *RpcTransaction
+	if message.Transaction != nil {
+		transaction = new(RpcTransaction)
+		transaction.fromAppMessage(message.Transaction)
+	}
+	x.MempoolChangedNotification = &MempoolChangedNotificationMessage{
+		Event:         message.Event,
+		TransactionId: message.TransactionID,
+		RemovalReason: message.RemovalReason,
+		Transaction:   transaction,
+	}
+	return nil
+}
+
+func (x *MempoolChangedNotificationMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "MempoolChangedNotificationMessage is nil")
+	}
+	var transaction *appmessage.RPCTransaction
+	// Transaction is an optional field
+	if x.Transaction != nil {
+		var err error
+		transaction, err = x.Transaction.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &appmessage.MempoolChangedNotificationMessage{
+		Event:         x.Event,
+		TransactionID: x.TransactionId,
+		RemovalReason: x.RemovalReason,
+		Transaction:   transaction,
+	}, nil
+}
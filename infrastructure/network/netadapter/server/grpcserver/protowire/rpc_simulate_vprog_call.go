@@ -0,0 +1,123 @@
+package protowire
+
+import (
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *ZuadMessage_SimulateVProgCallRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ZuadMessage_SimulateVProgCallRequest is nil")
+	}
+	return x.SimulateVProgCallRequest.toAppMessage()
+}
+
+func (x *ZuadMessage_SimulateVProgCallRequest) fromAppMessage(message *appmessage.SimulateVProgCallRequestMessage) error {
+	x.SimulateVProgCallRequest = &SimulateVProgCallRequestMessage{
+		Code:     message.Code,
+		Data:     message.Data,
+		GasLimit: message.GasLimit,
+	}
+	return nil
+}
+
+func (x *SimulateVProgCallRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "SimulateVProgCallRequestMessage is nil")
+	}
+	return &appmessage.SimulateVProgCallRequestMessage{
+		Code:     x.Code,
+		Data:     x.Data,
+		GasLimit: x.GasLimit,
+	}, nil
+}
+
+func (x *ZuadMessage_SimulateVProgCallResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ZuadMessage_SimulateVProgCallResponse is nil")
+	}
+	return x.SimulateVProgCallResponse.toAppMessage()
+}
+
+func (x *ZuadMessage_SimulateVProgCallResponse) fromAppMessage(message *appmessage.SimulateVProgCallResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.SimulateVProgCallResponse = &SimulateVProgCallResponseMessage{
+		ProgramId:      message.ProgramID,
+		Success:        message.Success,
+		GasUsed:        message.GasUsed,
+		ReturnData:     message.ReturnData,
+		StateChanges:   vprogStorageEntriesFromAppMessage(message.StateChanges),
+		ExecutionError: message.ExecutionError,
+
+		Error: err,
+	}
+	return nil
+}
+
+func (x *SimulateVProgCallResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "SimulateVProgCallResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	stateChanges, err := vprogStorageEntriesToAppMessage(x.StateChanges)
+	if err != nil {
+		return nil, err
+	}
+
+	return &appmessage.SimulateVProgCallResponseMessage{
+		ProgramID:      x.ProgramId,
+		Success:        x.Success,
+		GasUsed:        x.GasUsed,
+		ReturnData:     x.ReturnData,
+		StateChanges:   stateChanges,
+		ExecutionError: x.ExecutionError,
+
+		Error: rpcErr,
+	}, nil
+}
+
+func (x *RpcVProgStorageEntry) toAppMessage() (*appmessage.RPCVProgStorageEntry, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "RpcVProgStorageEntry is nil")
+	}
+	return &appmessage.RPCVProgStorageEntry{
+		Key:   x.Key,
+		Value: x.Value,
+	}, nil
+}
+
+func (x *RpcVProgStorageEntry) fromAppMessage(message *appmessage.RPCVProgStorageEntry) {
+	*x = RpcVProgStorageEntry{
+		Key:   message.Key,
+		Value: message.Value,
+	}
+}
+
+func vprogStorageEntriesToAppMessage(entries []*RpcVProgStorageEntry) ([]*appmessage.RPCVProgStorageEntry, error) {
+	appEntries := make([]*appmessage.RPCVProgStorageEntry, len(entries))
+	for i, entry := range entries {
+		appEntry, err := entry.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+		appEntries[i] = appEntry
+	}
+	return appEntries, nil
+}
+
+func vprogStorageEntriesFromAppMessage(appEntries []*appmessage.RPCVProgStorageEntry) []*RpcVProgStorageEntry {
+	entries := make([]*RpcVProgStorageEntry, len(appEntries))
+	for i, appEntry := range appEntries {
+		entries[i] = &RpcVProgStorageEntry{}
+		entries[i].fromAppMessage(appEntry)
+	}
+	return entries
+}
@@ -94,6 +94,9 @@ func (x *RpcTransaction) toAppMessage() (*appmessage.RPCTransaction, error) {
 	if x.Version > math.MaxUint16 {
 		return nil, errors.Errorf("Invalid RPC transaction version - bigger then uint16")
 	}
+	if x.VprogVersion > math.MaxUint8 {
+		return nil, errors.Errorf("Invalid RPC transaction vprog version - bigger then uint8")
+	}
 	var verboseData *appmessage.RPCTransactionVerboseData
 	if x.VerboseData != nil {
 		appMessageVerboseData, err := x.VerboseData.toAppMessage()
@@ -111,6 +114,11 @@ func (x *RpcTransaction) toAppMessage() (*appmessage.RPCTransaction, error) {
 		Gas:          x.Gas,
 		Payload:      x.Payload,
 		VerboseData:  verboseData,
+
+		VProgVersion:  byte(x.VprogVersion),
+		VProgCode:     x.VprogCode,
+		VProgData:     x.VprogData,
+		VProgGasLimit: x.VprogGasLimit,
 	}, nil
 }
 
@@ -131,14 +139,18 @@ func (x *RpcTransaction) fromAppMessage(transaction *appmessage.RPCTransaction)
 		verboseData.fromAppMessage(transaction.VerboseData)
 	}
 	*x = RpcTransaction{
-		Version:      uint32(transaction.Version),
-		Inputs:       inputs,
-		Outputs:      outputs,
-		LockTime:     transaction.LockTime,
-		SubnetworkId: transaction.SubnetworkID,
-		Gas:          transaction.Gas,
-		Payload:      transaction.Payload,
-		VerboseData:  verboseData,
+		Version:       uint32(transaction.Version),
+		Inputs:        inputs,
+		Outputs:       outputs,
+		LockTime:      transaction.LockTime,
+		SubnetworkId:  transaction.SubnetworkID,
+		Gas:           transaction.Gas,
+		Payload:       transaction.Payload,
+		VerboseData:   verboseData,
+		VprogVersion:  uint32(transaction.VProgVersion),
+		VprogCode:     transaction.VProgCode,
+		VprogData:     transaction.VProgData,
+		VprogGasLimit: transaction.VProgGasLimit,
 	}
 }
 
@@ -0,0 +1,79 @@
+package protowire
+
+import (
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *ZuadMessage_SubmitTransactionPackageRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ZuadMessage_SubmitTransactionPackageRequest is nil")
+	}
+	return x.SubmitTransactionPackageRequest.toAppMessage()
+}
+
+func (x *ZuadMessage_SubmitTransactionPackageRequest) fromAppMessage(
+	message *appmessage.SubmitTransactionPackageRequestMessage) error {
+
+	x.SubmitTransactionPackageRequest = &SubmitTransactionPackageRequestMessage{
+		Transactions: make([]*RpcTransaction, len(message.Transactions)),
+	}
+	for i, transaction := range message.Transactions {
+		x.SubmitTransactionPackageRequest.Transactions[i] = &RpcTransaction{}
+		x.SubmitTransactionPackageRequest.Transactions[i].fromAppMessage(transaction)
+	}
+	return nil
+}
+
+func (x *SubmitTransactionPackageRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "SubmitTransactionPackageRequestMessage is nil")
+	}
+	rpcTransactions := make([]*appmessage.RPCTransaction, len(x.Transactions))
+	for i, transaction := range x.Transactions {
+		rpcTransaction, err := transaction.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+		rpcTransactions[i] = rpcTransaction
+	}
+	return &appmessage.SubmitTransactionPackageRequestMessage{
+		Transactions: rpcTransactions,
+	}, nil
+}
+
+func (x *ZuadMessage_SubmitTransactionPackageResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ZuadMessage_SubmitTransactionPackageResponse is nil")
+	}
+	return x.SubmitTransactionPackageResponse.toAppMessage()
+}
+
+func (x *ZuadMessage_SubmitTransactionPackageResponse) fromAppMessage(
+	message *appmessage.SubmitTransactionPackageResponseMessage) error {
+
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.SubmitTransactionPackageResponse = &SubmitTransactionPackageResponseMessage{
+		TransactionIds: message.TransactionIDs,
+		Error:          err,
+	}
+	return nil
+}
+
+func (x *SubmitTransactionPackageResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "SubmitTransactionPackageResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.SubmitTransactionPackageResponseMessage{
+		TransactionIDs: x.TransactionIds,
+		Error:          rpcErr,
+	}, nil
+}
@@ -0,0 +1,90 @@
+package protowire
+
+import (
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *ZuadMessage_ValidateTransactionRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ZuadMessage_ValidateTransactionRequest is nil")
+	}
+	return x.ValidateTransactionRequest.toAppMessage()
+}
+
+func (x *ZuadMessage_ValidateTransactionRequest) fromAppMessage(
+	message *appmessage.ValidateTransactionRequestMessage) error {
+
+	x.ValidateTransactionRequest = &ValidateTransactionRequestMessage{
+		Transaction: &RpcTransaction{},
+	}
+	x.ValidateTransactionRequest.Transaction.fromAppMessage(message.Transaction)
+	return nil
+}
+
+func (x *ValidateTransactionRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ValidateTransactionRequestMessage is nil")
+	}
+	rpcTransaction, err := x.Transaction.toAppMessage()
+	if err != nil {
+		return nil, err
+	}
+	return &appmessage.ValidateTransactionRequestMessage{
+		Transaction: rpcTransaction,
+	}, nil
+}
+
+func (x *ZuadMessage_ValidateTransactionResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ZuadMessage_ValidateTransactionResponse is nil")
+	}
+	return x.ValidateTransactionResponse.toAppMessage()
+}
+
+func (x *ZuadMessage_ValidateTransactionResponse) fromAppMessage(
+	message *appmessage.ValidateTransactionResponseMessage) error {
+
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.ValidateTransactionResponse = &ValidateTransactionResponseMessage{
+		TransactionId: message.TransactionID,
+		Mass:          message.Mass,
+		Fee:           message.Fee,
+		FeeRate:       message.FeeRate,
+		HasFee:        message.HasFee,
+		IsStandard:    message.IsStandard,
+		IsOrphan:      message.IsOrphan,
+		IsAccepted:    message.IsAccepted,
+		RejectCode:    message.RejectCode,
+		RejectReason:  message.RejectReason,
+		Error:         err,
+	}
+	return nil
+}
+
+func (x *ValidateTransactionResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ValidateTransactionResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.ValidateTransactionResponseMessage{
+		TransactionID: x.TransactionId,
+		Mass:          x.Mass,
+		Fee:           x.Fee,
+		FeeRate:       x.FeeRate,
+		HasFee:        x.HasFee,
+		IsStandard:    x.IsStandard,
+		IsOrphan:      x.IsOrphan,
+		IsAccepted:    x.IsAccepted,
+		RejectCode:    x.RejectCode,
+		RejectReason:  x.RejectReason,
+		Error:         rpcErr,
+	}, nil
+}
@@ -359,6 +359,55 @@ func toP2PPayload(message appmessage.Message) (isZuadMessage_Payload, error) {
 			return nil, err
 		}
 		return payload, nil
+	case *appmessage.MsgTransactionPackage:
+		payload := new(ZuadMessage_TransactionPackage)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.MsgInvTransactionPackage:
+		payload := new(ZuadMessage_InvTransactionPackage)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.MsgRequestTransactionPackage:
+		payload := new(ZuadMessage_RequestTransactionPackage)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.MsgRequestCompactBlock:
+		payload := new(ZuadMessage_RequestCompactBlock)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.MsgCompactBlock:
+		payload := new(ZuadMessage_CompactBlock)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.MsgRequestBlockTransactions:
+		payload := new(ZuadMessage_RequestBlockTransactions)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.MsgBlockTransactions:
+		payload := new(ZuadMessage_BlockTransactions)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
 	default:
 		return nil, nil
 	}
@@ -968,6 +1017,167 @@ func toRPCPayload(message appmessage.Message) (isZuadMessage_Payload, error) {
 			return nil, err
 		}
 		return payload, nil
+	case *appmessage.SimulateVProgCallRequestMessage:
+		payload := new(ZuadMessage_SimulateVProgCallRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.SimulateVProgCallResponseMessage:
+		payload := new(ZuadMessage_SimulateVProgCallResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetVProgCodeRequestMessage:
+		payload := new(ZuadMessage_GetVProgCodeRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetVProgCodeResponseMessage:
+		payload := new(ZuadMessage_GetVProgCodeResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetVProgStorageRequestMessage:
+		payload := new(ZuadMessage_GetVProgStorageRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetVProgStorageResponseMessage:
+		payload := new(ZuadMessage_GetVProgStorageResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetTransactionRequestMessage:
+		payload := new(ZuadMessage_GetTransactionRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetTransactionResponseMessage:
+		payload := new(ZuadMessage_GetTransactionResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetTransactionAcceptanceRequestMessage:
+		payload := new(ZuadMessage_GetTransactionAcceptanceRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetTransactionAcceptanceResponseMessage:
+		payload := new(ZuadMessage_GetTransactionAcceptanceResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetTransactionsByAddressesRequestMessage:
+		payload := new(ZuadMessage_GetTransactionsByAddressesRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetTransactionsByAddressesResponseMessage:
+		payload := new(ZuadMessage_GetTransactionsByAddressesResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetFeeEstimateRequestMessage:
+		payload := new(ZuadMessage_GetFeeEstimateRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetFeeEstimateResponseMessage:
+		payload := new(ZuadMessage_GetFeeEstimateResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.SubmitTransactionReplacementRequestMessage:
+		payload := new(ZuadMessage_SubmitTransactionReplacementRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.SubmitTransactionReplacementResponseMessage:
+		payload := new(ZuadMessage_SubmitTransactionReplacementResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NotifyMempoolChangedRequestMessage:
+		payload := new(ZuadMessage_NotifyMempoolChangedRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NotifyMempoolChangedResponseMessage:
+		payload := new(ZuadMessage_NotifyMempoolChangedResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.MempoolChangedNotificationMessage:
+		payload := new(ZuadMessage_MempoolChangedNotification)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.ValidateTransactionRequestMessage:
+		payload := new(ZuadMessage_ValidateTransactionRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.ValidateTransactionResponseMessage:
+		payload := new(ZuadMessage_ValidateTransactionResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.SubmitTransactionPackageRequestMessage:
+		payload := new(ZuadMessage_SubmitTransactionPackageRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.SubmitTransactionPackageResponseMessage:
+		payload := new(ZuadMessage_SubmitTransactionPackageResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
 	default:
 		return nil, nil
 	}
@@ -0,0 +1,200 @@
+package jsonrpcserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/server/grpcserver/protowire"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// JSON-RPC methods are derived from the RPC payloads of ZuadMessage: the method of a
+// payload field named <name>Request is <name>, and its params are the JSON form of the
+// request message. For example, the getBlockDagInfoRequest field maps to the getBlockDagInfo
+// method. Responses carry the JSON form of the respective response message as their result,
+// and notifications are sent as JSON-RPC notifications whose method is the name of their
+// payload field, e.g. blockAddedNotification.
+
+const (
+	jsonRPCVersion = "2.0"
+
+	requestSuffix      = "Request"
+	notificationSuffix = "Notification"
+	subscriptionPrefix = "notify"
+)
+
+// Standard JSON-RPC 2.0 error codes, along with the code used for
+// errors returned by the RPC handlers
+const (
+	errorCodeParseError     = -32700
+	errorCodeInvalidRequest = -32600
+	errorCodeMethodNotFound = -32601
+	errorCodeInvalidParams  = -32602
+	errorCodeInternalError  = -32603
+	errorCodeRPCError       = -32000
+)
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *responseError  `json:"error,omitempty"`
+}
+
+type responseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type notification struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+var nullID = json.RawMessage("null")
+
+var marshalOptions = protojson.MarshalOptions{EmitUnpopulated: true}
+
+var payloadOneof = (&protowire.ZuadMessage{}).ProtoReflect().Descriptor().Oneofs().ByName("payload")
+
+// methods maps every JSON-RPC method to its request payload field
+var methods = buildMethods()
+
+func buildMethods() map[string]protoreflect.FieldDescriptor {
+	methods := make(map[string]protoreflect.FieldDescriptor)
+	fields := payloadOneof.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if !isRPCField(field) || !strings.HasSuffix(field.JSONName(), requestSuffix) {
+			continue
+		}
+		methods[strings.TrimSuffix(field.JSONName(), requestSuffix)] = field
+	}
+	return methods
+}
+
+func isRPCField(field protoreflect.FieldDescriptor) bool {
+	return field.Message().ParentFile().Path() == "rpc.proto"
+}
+
+func isSubscriptionMethod(method string) bool {
+	return strings.HasPrefix(method, subscriptionPrefix)
+}
+
+func newError(code int, format string, args ...interface{}) *responseError {
+	return &responseError{Code: code, Message: errors.Errorf(format, args...).Error()}
+}
+
+// decodeRequest parses a JSON-RPC request and converts it to the respective appmessage.
+// A non-nil responseError is returned if the request is invalid, in which case the
+// returned request is nil if the request could not be parsed at all.
+func decodeRequest(data []byte) (*request, appmessage.Message, *responseError) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		return nil, nil, newError(errorCodeInvalidRequest, "batch requests are not supported")
+	}
+
+	parsedRequest := &request{}
+	err := json.Unmarshal(data, parsedRequest)
+	if err != nil {
+		return nil, nil, newError(errorCodeParseError, "could not parse request: %s", err)
+	}
+	if parsedRequest.JSONRPC != jsonRPCVersion {
+		return parsedRequest, nil, newError(errorCodeInvalidRequest, "jsonrpc must be %s", jsonRPCVersion)
+	}
+	field, ok := methods[parsedRequest.Method]
+	if !ok {
+		return parsedRequest, nil, newError(errorCodeMethodNotFound, "method '%s' not found", parsedRequest.Method)
+	}
+
+	zuadMessage := &protowire.ZuadMessage{}
+	reflectMessage := zuadMessage.ProtoReflect()
+	payload := reflectMessage.NewField(field)
+	params := bytes.TrimSpace(parsedRequest.Params)
+	if len(params) > 0 && !bytes.Equal(params, nullID) {
+		if params[0] != '{' {
+			return parsedRequest, nil, newError(errorCodeInvalidParams, "params must be an object")
+		}
+		err = protojson.Unmarshal(params, payload.Message().Interface())
+		if err != nil {
+			return parsedRequest, nil, newError(errorCodeInvalidParams, "invalid params: %s", err)
+		}
+	}
+	reflectMessage.Set(field, payload)
+
+	message, err := zuadMessage.ToAppMessage()
+	if err != nil {
+		return parsedRequest, nil, newError(errorCodeInvalidParams, "invalid params: %s", err)
+	}
+	return parsedRequest, message, nil
+}
+
+// encodeMessage converts an outgoing appmessage to its JSON form. If the message is a
+// notification, method is the name of the notification. Otherwise, it is a response,
+// and rpcError is set if it carries an error.
+func encodeMessage(message appmessage.Message) (
+	payloadJSON json.RawMessage, method string, isNotification bool, rpcError *responseError, err error) {
+
+	zuadMessage, err := protowire.FromAppMessage(message)
+	if err != nil {
+		return nil, "", false, nil, err
+	}
+	reflectMessage := zuadMessage.ProtoReflect()
+	field := reflectMessage.WhichOneof(payloadOneof)
+	if field == nil {
+		return nil, "", false, nil, errors.Errorf("message %s has no payload", message.Command())
+	}
+	payload := reflectMessage.Get(field).Message()
+
+	payloadJSON, err = marshalOptions.Marshal(payload.Interface())
+	if err != nil {
+		return nil, "", false, nil, err
+	}
+
+	if strings.HasSuffix(field.JSONName(), notificationSuffix) {
+		return payloadJSON, field.JSONName(), true, nil, nil
+	}
+
+	errorField := payload.Descriptor().Fields().ByName("error")
+	if errorField != nil && errorField.Message() != nil && payload.Has(errorField) {
+		errorMessage := payload.Get(errorField).Message()
+		messageField := errorMessage.Descriptor().Fields().ByName("message")
+		rpcError = &responseError{Code: errorCodeRPCError, Message: errorMessage.Get(messageField).String()}
+	}
+	return payloadJSON, "", false, rpcError, nil
+}
+
+func encodeResponse(id json.RawMessage, result json.RawMessage, responseError *responseError) ([]byte, error) {
+	if id == nil {
+		id = nullID
+	}
+	if responseError != nil {
+		result = nil
+	}
+	return json.Marshal(&response{
+		JSONRPC: jsonRPCVersion,
+		ID:      id,
+		Result:  result,
+		Error:   responseError,
+	})
+}
+
+func encodeNotification(method string, params json.RawMessage) ([]byte, error) {
+	return json.Marshal(&notification{
+		JSONRPC: jsonRPCVersion,
+		Method:  method,
+		Params:  params,
+	})
+}
@@ -0,0 +1,310 @@
+package jsonrpcserver
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/zuanet/zuad/infrastructure/logger"
+	routerpkg "github.com/zuanet/zuad/infrastructure/network/netadapter/router"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/server"
+	"github.com/pkg/errors"
+	"golang.org/x/net/websocket"
+)
+
+// jsonRPCConnection is a connection of a JSON-RPC client. A WebSocket connection lives
+// as long as its socket, while an HTTP connection serves a single request and is
+// disconnected once its response is written.
+type jsonRPCConnection struct {
+	server  *jsonRPCServer
+	address *net.TCPAddr
+	router  *routerpkg.Router
+
+	// webSocket is nil for HTTP connections
+	webSocket     *websocket.Conn
+	webSocketLock sync.Mutex
+
+	// httpResponseChan receives the response of an HTTP connection, or
+	// nil if its request is a JSON-RPC notification that takes no response
+	httpResponseChan chan []byte
+
+	// pendingRequestIDs are the IDs of the requests that were passed on to the router,
+	// in order. Requests are handled in order, so each response that's not a
+	// notification belongs to the first pending request. A nil ID marks a request
+	// without an ID, whose response is discarded.
+	pendingRequestIDs     []json.RawMessage
+	pendingRequestIDsLock sync.Mutex
+
+	stopChan                chan struct{}
+	onDisconnectedHandler   server.OnDisconnectedHandler
+	onInvalidMessageHandler server.OnInvalidMessageHandler
+
+	isConnected   uint32
+	messageNumber uint64
+}
+
+func newWebSocketConnection(server *jsonRPCServer, address *net.TCPAddr, webSocket *websocket.Conn) *jsonRPCConnection {
+	return &jsonRPCConnection{
+		server:      server,
+		address:     address,
+		webSocket:   webSocket,
+		stopChan:    make(chan struct{}),
+		isConnected: 1,
+	}
+}
+
+func newHTTPConnection(server *jsonRPCServer, address *net.TCPAddr) *jsonRPCConnection {
+	return &jsonRPCConnection{
+		server:           server,
+		address:          address,
+		httpResponseChan: make(chan []byte, 1),
+		stopChan:         make(chan struct{}),
+		isConnected:      1,
+	}
+}
+
+func (c *jsonRPCConnection) isWebSocket() bool {
+	return c.webSocket != nil
+}
+
+func (c *jsonRPCConnection) Start(router *routerpkg.Router) {
+	if c.onDisconnectedHandler == nil {
+		panic(errors.New("onDisconnectedHandler is nil"))
+	}
+
+	c.router = router
+
+	spawn("jsonRPCConnection.Start-connectionLoops", func() {
+		err := c.connectionLoops()
+		if err != nil {
+			log.Errorf("error from connectionLoops for %s: %s", c.address, err)
+		}
+	})
+}
+
+func (c *jsonRPCConnection) String() string {
+	return c.Address().String()
+}
+
+func (c *jsonRPCConnection) IsConnected() bool {
+	return atomic.LoadUint32(&c.isConnected) != 0
+}
+
+func (c *jsonRPCConnection) SetOnDisconnectedHandler(onDisconnectedHandler server.OnDisconnectedHandler) {
+	c.onDisconnectedHandler = onDisconnectedHandler
+}
+
+func (c *jsonRPCConnection) SetOnInvalidMessageHandler(onInvalidMessageHandler server.OnInvalidMessageHandler) {
+	c.onInvalidMessageHandler = onInvalidMessageHandler
+}
+
+func (c *jsonRPCConnection) IsOutbound() bool {
+	return false
+}
+
+// Disconnect disconnects the connection
+// Calling this function a second time doesn't do anything
+//
+// This is part of the Connection interface
+func (c *jsonRPCConnection) Disconnect() {
+	if !c.IsConnected() {
+		return
+	}
+	atomic.StoreUint32(&c.isConnected, 0)
+
+	close(c.stopChan)
+
+	if c.isWebSocket() {
+		// ignore error because we don't really know what's the status of the connection
+		_ = c.webSocket.Close()
+	}
+	c.server.removeConnection(c)
+
+	log.Debugf("Disconnecting from %s", c)
+	if c.onDisconnectedHandler != nil {
+		c.onDisconnectedHandler()
+	}
+}
+
+func (c *jsonRPCConnection) Address() *net.TCPAddr {
+	return c.address
+}
+
+func (c *jsonRPCConnection) connectionLoops() error {
+	if !c.isWebSocket() {
+		// The request of an HTTP connection is handled by the server directly
+		err := c.sendLoop()
+		c.Disconnect()
+		return err
+	}
+
+	errChan := make(chan error, 1) // buffered channel because one of the loops might try write after disconnect
+
+	spawn("jsonRPCConnection.receiveLoop", func() { errChan <- c.receiveLoop() })
+	spawn("jsonRPCConnection.sendLoop", func() { errChan <- c.sendLoop() })
+
+	err := <-errChan
+
+	c.Disconnect()
+
+	return err
+}
+
+func (c *jsonRPCConnection) receiveLoop() error {
+	for c.IsConnected() {
+		var data []byte
+		err := websocket.Message.Receive(c.webSocket, &data)
+		if err != nil {
+			if err == io.EOF || !c.IsConnected() {
+				return nil
+			}
+			return err
+		}
+
+		err = c.handleRequest(data)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handleRequest decodes a JSON-RPC request and passes it on to the router. Invalid
+// requests are answered directly, without reaching the router.
+func (c *jsonRPCConnection) handleRequest(data []byte) error {
+	parsedRequest, message, responseError := decodeRequest(data)
+	if responseError == nil && !c.isWebSocket() && isSubscriptionMethod(parsedRequest.Method) {
+		responseError = newError(errorCodeInvalidRequest, "method '%s' is only available over WebSocket",
+			parsedRequest.Method)
+	}
+	if responseError != nil {
+		var id json.RawMessage
+		if parsedRequest != nil {
+			if parsedRequest.ID == nil && responseError.Code != errorCodeInvalidRequest {
+				// Requests without an ID take no response
+				return c.write(nil)
+			}
+			id = parsedRequest.ID
+		}
+		log.Debugf("invalid JSON-RPC request from %s: %s", c, responseError.Message)
+		responseData, err := encodeResponse(id, nil, responseError)
+		if err != nil {
+			return err
+		}
+		return c.write(responseData)
+	}
+
+	messageNumber := atomic.AddUint64(&c.messageNumber, 1)
+	message.SetMessageNumber(messageNumber)
+	message.SetReceivedAt(time.Now())
+
+	log.Debugf("incoming '%s' message from %s (message number %d)", message.Command(), c,
+		message.MessageNumber())
+
+	log.Tracef("incoming '%s' message from %s  (message number %d): %s", message.Command(),
+		c, message.MessageNumber(), logger.NewLogClosure(func() string {
+			return spew.Sdump(message)
+		}))
+
+	c.pushPendingRequestID(parsedRequest.ID)
+	err := c.router.EnqueueIncomingMessage(message)
+	if err != nil {
+		if errors.Is(err, routerpkg.ErrRouteClosed) {
+			return nil
+		}
+		if c.onInvalidMessageHandler != nil {
+			c.onInvalidMessageHandler(err)
+		}
+		return err
+	}
+	return nil
+}
+
+func (c *jsonRPCConnection) sendLoop() error {
+	outgoingRoute := c.router.OutgoingRoute()
+	for c.IsConnected() {
+		message, err := outgoingRoute.Dequeue()
+		if err != nil {
+			if errors.Is(err, routerpkg.ErrRouteClosed) {
+				return nil
+			}
+			return err
+		}
+
+		log.Debugf("outgoing '%s' message to %s", message.Command(), c)
+		log.Tracef("outgoing '%s' message to %s: %s", message.Command(), c, logger.NewLogClosure(func() string {
+			return spew.Sdump(message)
+		}))
+
+		payload, method, isNotification, responseError, err := encodeMessage(message)
+		if err != nil {
+			return err
+		}
+
+		var data []byte
+		if isNotification {
+			data, err = encodeNotification(method, payload)
+		} else {
+			id, ok := c.popPendingRequestID()
+			if !ok {
+				return errors.Errorf("got response '%s' without a pending request", message.Command())
+			}
+			if id != nil {
+				data, err = encodeResponse(id, payload, responseError)
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		err = c.write(data)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// write sends the given data to the client. A nil data marks the
+// end of an HTTP request that takes no response.
+func (c *jsonRPCConnection) write(data []byte) error {
+	if !c.isWebSocket() {
+		select {
+		case c.httpResponseChan <- data:
+		default:
+		}
+		return nil
+	}
+
+	if data == nil {
+		return nil
+	}
+
+	c.webSocketLock.Lock()
+	defer c.webSocketLock.Unlock()
+
+	return websocket.Message.Send(c.webSocket, string(data))
+}
+
+func (c *jsonRPCConnection) pushPendingRequestID(id json.RawMessage) {
+	c.pendingRequestIDsLock.Lock()
+	defer c.pendingRequestIDsLock.Unlock()
+
+	c.pendingRequestIDs = append(c.pendingRequestIDs, id)
+}
+
+func (c *jsonRPCConnection) popPendingRequestID() (json.RawMessage, bool) {
+	c.pendingRequestIDsLock.Lock()
+	defer c.pendingRequestIDsLock.Unlock()
+
+	if len(c.pendingRequestIDs) == 0 {
+		return nil, false
+	}
+	id := c.pendingRequestIDs[0]
+	c.pendingRequestIDs = c.pendingRequestIDs[1:]
+	return id, true
+}
@@ -0,0 +1,309 @@
+package jsonrpcserver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/server"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/server/grpcserver"
+	"github.com/zuanet/zuad/util/panics"
+	"github.com/pkg/errors"
+	"golang.org/x/net/websocket"
+)
+
+// maxHTTPRequestSize is the max size of the body of a JSON-RPC HTTP request
+const maxHTTPRequestSize = 32 * 1024 * 1024 // 32 MB
+
+type jsonRPCServer struct {
+	onConnectedHandler server.OnConnectedHandler
+	listeningAddresses []string
+	httpServers        []*http.Server
+	webSocketServer    *websocket.Server
+	allowedOrigins     map[string]struct{}
+
+	maxWebSockets             int
+	webSocketCount            int
+	maxConcurrentHTTPRequests int
+	httpRequestCount          int
+	countLock                 sync.Mutex
+
+	connections     map[*jsonRPCConnection]struct{}
+	connectionsLock sync.Mutex
+}
+
+// NewJSONRPCServer creates a new server that serves the RPC as JSON-RPC 2.0, both over
+// HTTP POST requests and over WebSocket connections. Notifications are only available
+// over WebSocket, since an HTTP connection only lives as long as its request.
+//
+// Browsers are only allowed to connect from the given origins. Requests that carry no
+// Origin header, as sent by non-browser clients, are always allowed.
+func NewJSONRPCServer(listeningAddresses []string, allowedOrigins []string, maxWebSockets int,
+	maxConcurrentHTTPRequests int) (server.Server, error) {
+
+	s := &jsonRPCServer{
+		listeningAddresses:        listeningAddresses,
+		allowedOrigins:            make(map[string]struct{}, len(allowedOrigins)),
+		maxWebSockets:             maxWebSockets,
+		maxConcurrentHTTPRequests: maxConcurrentHTTPRequests,
+		connections:               make(map[*jsonRPCConnection]struct{}),
+	}
+	for _, allowedOrigin := range allowedOrigins {
+		s.allowedOrigins[normalizeOrigin(allowedOrigin)] = struct{}{}
+	}
+	s.webSocketServer = &websocket.Server{Handler: s.handleWebSocket, Handshake: s.checkWebSocketOrigin}
+	return s, nil
+}
+
+func (s *jsonRPCServer) Start() error {
+	if s.onConnectedHandler == nil {
+		return errors.New("onConnectedHandler is nil")
+	}
+
+	for _, listenAddress := range s.listeningAddresses {
+		err := s.listenOn(listenAddress)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *jsonRPCServer) listenOn(listenAddr string) error {
+	listener, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return errors.Wrapf(err, "JSON-RPC error listening on %s", listenAddr)
+	}
+
+	httpServer := &http.Server{Handler: s}
+	s.httpServers = append(s.httpServers, httpServer)
+
+	spawn("jsonRPCServer.listenOn-Serve", func() {
+		err := httpServer.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			panics.Exit(log, fmt.Sprintf("error serving JSON-RPC on %s: %+v", listenAddr, err))
+		}
+	})
+
+	log.Infof("JSON-RPC Server listening on %s", listener.Addr())
+	return nil
+}
+
+func (s *jsonRPCServer) Stop() error {
+	const stopTimeout = 2 * time.Second
+
+	ctx, cancel := context.WithTimeout(context.Background(), stopTimeout)
+	defer cancel()
+
+	for _, httpServer := range s.httpServers {
+		err := httpServer.Shutdown(ctx)
+		if err != nil {
+			log.Warnf("Could not gracefully stop JSON-RPC: %s", err)
+			_ = httpServer.Close()
+		}
+	}
+
+	// WebSocket connections are hijacked from the HTTP servers,
+	// so they have to be disconnected separately
+	for _, connection := range s.activeConnections() {
+		connection.Disconnect()
+	}
+	return nil
+}
+
+// SetOnConnectedHandler sets the peer connected handler
+// function for the server
+func (s *jsonRPCServer) SetOnConnectedHandler(onConnectedHandler server.OnConnectedHandler) {
+	s.onConnectedHandler = onConnectedHandler
+}
+
+func (s *jsonRPCServer) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	defer panics.HandlePanic(log, "jsonRPCServer.ServeHTTP", nil)
+
+	if strings.EqualFold(request.Header.Get("Upgrade"), "websocket") {
+		s.webSocketServer.ServeHTTP(writer, request)
+		return
+	}
+
+	// Browsers send simple cross-origin POST requests without a preflight,
+	// so requests from origins that aren't allowed are rejected outright
+	writer.Header().Add("Vary", "Origin")
+	origin := request.Header.Get("Origin")
+	if origin != "" {
+		if !s.isOriginAllowed(origin) {
+			http.Error(writer, fmt.Sprintf("origin %s is not allowed", origin), http.StatusForbidden)
+			return
+		}
+		writer.Header().Set("Access-Control-Allow-Origin", origin)
+		writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+		writer.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+	}
+
+	switch request.Method {
+	case http.MethodOptions:
+		writer.WriteHeader(http.StatusNoContent)
+	case http.MethodPost:
+		s.handleHTTPRequest(writer, request)
+	default:
+		http.Error(writer, "JSON-RPC requests must be sent with POST", http.StatusMethodNotAllowed)
+	}
+}
+
+// checkWebSocketOrigin rejects WebSocket handshakes from origins that aren't allowed.
+// The WebSocket protocol isn't subject to the same-origin policy, so without this
+// check any web page could connect to the node through its visitor's browser.
+func (s *jsonRPCServer) checkWebSocketOrigin(config *websocket.Config, request *http.Request) error {
+	origin := request.Header.Get("Origin")
+	if origin != "" && !s.isOriginAllowed(origin) {
+		return errors.Errorf("origin %s is not allowed", origin)
+	}
+	return nil
+}
+
+func (s *jsonRPCServer) isOriginAllowed(origin string) bool {
+	_, ok := s.allowedOrigins[normalizeOrigin(origin)]
+	return ok
+}
+
+// normalizeOrigin makes origins comparable regardless of the letter case and a trailing slash
+func normalizeOrigin(origin string) string {
+	return strings.TrimSuffix(strings.ToLower(origin), "/")
+}
+
+func (s *jsonRPCServer) handleWebSocket(webSocket *websocket.Conn) {
+	defer panics.HandlePanic(log, "jsonRPCServer.handleWebSocket", nil)
+
+	webSocket.MaxPayloadBytes = grpcserver.RPCMaxMessageSize
+
+	address, err := net.ResolveTCPAddr("tcp", webSocket.Request().RemoteAddr)
+	if err != nil {
+		log.Warnf("Could not resolve the address of a JSON-RPC WebSocket connection: %s", err)
+		_ = webSocket.Close()
+		return
+	}
+
+	connectionCount, err := s.incrementCountAndLimitIfRequired(&s.webSocketCount, s.maxWebSockets, "WebSocket")
+	if err != nil {
+		_ = webSocket.Close()
+		return
+	}
+	defer s.decrementCount(&s.webSocketCount)
+
+	connection := newWebSocketConnection(s, address, webSocket)
+	s.addConnection(connection)
+
+	err = s.onConnectedHandler(connection)
+	if err != nil {
+		log.Warnf("Error handling JSON-RPC WebSocket connection from %s: %s", address, err)
+		connection.Disconnect()
+		return
+	}
+
+	log.Infof("JSON-RPC Incoming WebSocket connection from %s #%d", address, connectionCount)
+
+	<-connection.stopChan
+}
+
+func (s *jsonRPCServer) handleHTTPRequest(writer http.ResponseWriter, request *http.Request) {
+	address, err := net.ResolveTCPAddr("tcp", request.RemoteAddr)
+	if err != nil {
+		http.Error(writer, "could not resolve the remote address", http.StatusBadRequest)
+		return
+	}
+
+	_, err = s.incrementCountAndLimitIfRequired(&s.httpRequestCount, s.maxConcurrentHTTPRequests, "HTTP request")
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	defer s.decrementCount(&s.httpRequestCount)
+
+	body, err := io.ReadAll(http.MaxBytesReader(writer, request.Body, maxHTTPRequestSize))
+	if err != nil {
+		http.Error(writer, fmt.Sprintf("could not read request: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	connection := newHTTPConnection(s, address)
+	s.addConnection(connection)
+	defer connection.Disconnect()
+
+	err = s.onConnectedHandler(connection)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	err = connection.handleRequest(body)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	select {
+	case responseData := <-connection.httpResponseChan:
+		if responseData == nil {
+			writer.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		_, err = writer.Write(responseData)
+		if err != nil {
+			log.Debugf("Could not write a JSON-RPC response to %s: %s", address, err)
+		}
+	case <-connection.stopChan:
+		http.Error(writer, "the connection was closed before a response was sent", http.StatusInternalServerError)
+	case <-request.Context().Done():
+	}
+}
+
+func (s *jsonRPCServer) incrementCountAndLimitIfRequired(count *int, maxCount int, name string) (int, error) {
+	s.countLock.Lock()
+	defer s.countLock.Unlock()
+
+	if maxCount > 0 && *count >= maxCount {
+		log.Warnf("Limit of %d concurrent JSON-RPC %ss has been exceeded", maxCount, name)
+		return *count, errors.Errorf("limit of %d concurrent JSON-RPC %ss has been exceeded", maxCount, name)
+	}
+
+	*count++
+	return *count, nil
+}
+
+func (s *jsonRPCServer) decrementCount(count *int) {
+	s.countLock.Lock()
+	defer s.countLock.Unlock()
+
+	*count--
+}
+
+func (s *jsonRPCServer) addConnection(connection *jsonRPCConnection) {
+	s.connectionsLock.Lock()
+	defer s.connectionsLock.Unlock()
+
+	s.connections[connection] = struct{}{}
+}
+
+func (s *jsonRPCServer) removeConnection(connection *jsonRPCConnection) {
+	s.connectionsLock.Lock()
+	defer s.connectionsLock.Unlock()
+
+	delete(s.connections, connection)
+}
+
+func (s *jsonRPCServer) activeConnections() []*jsonRPCConnection {
+	s.connectionsLock.Lock()
+	defer s.connectionsLock.Unlock()
+
+	connections := make([]*jsonRPCConnection, 0, len(s.connections))
+	for connection := range s.connections {
+		connections = append(connections, connection)
+	}
+	return connections
+}
@@ -0,0 +1,297 @@
+package jsonrpcserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/domain/consensus/model/externalapi"
+	routerpkg "github.com/zuanet/zuad/infrastructure/network/netadapter/router"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/server"
+	"golang.org/x/net/websocket"
+)
+
+// onConnectedHandlerForTest serves getBlockCount, getSelectedTipHash (which always fails) and
+// notifyVirtualDaaScoreChanged, which is followed by a single notification
+func onConnectedHandlerForTest(t *testing.T) server.OnConnectedHandler {
+	return func(connection server.Connection) error {
+		router := routerpkg.NewRouter("test")
+		incomingRoute, err := router.AddIncomingRoute("test", []appmessage.MessageCommand{
+			appmessage.CmdGetBlockCountRequestMessage,
+			appmessage.CmdGetSelectedTipHashRequestMessage,
+			appmessage.CmdNotifyVirtualDaaScoreChangedRequestMessage,
+		})
+		if err != nil {
+			t.Fatalf("AddIncomingRoute: %+v", err)
+		}
+		connection.SetOnDisconnectedHandler(router.Close)
+
+		go func() {
+			for {
+				request, err := incomingRoute.Dequeue()
+				if err != nil {
+					return
+				}
+				var responses []appmessage.Message
+				switch request.Command() {
+				case appmessage.CmdGetBlockCountRequestMessage:
+					responses = append(responses, appmessage.NewGetBlockCountResponseMessage(
+						&externalapi.SyncInfo{BlockCount: 7, HeaderCount: 8}))
+				case appmessage.CmdGetSelectedTipHashRequestMessage:
+					responses = append(responses, &appmessage.GetSelectedTipHashResponseMessage{
+						Error: appmessage.RPCErrorf("test error")})
+				case appmessage.CmdNotifyVirtualDaaScoreChangedRequestMessage:
+					responses = append(responses, appmessage.NewNotifyVirtualDaaScoreChangedResponseMessage(),
+						appmessage.NewVirtualDaaScoreChangedNotificationMessage(42))
+				}
+				for _, response := range responses {
+					err := router.OutgoingRoute().Enqueue(response)
+					if err != nil {
+						return
+					}
+				}
+			}
+		}()
+
+		connection.Start(router)
+		return nil
+	}
+}
+
+// allowedOriginForTest is the only origin browsers are allowed to connect from in tests
+const allowedOriginForTest = "https://allowed.example"
+
+func newServerForTest(t *testing.T) (*jsonRPCServer, *httptest.Server) {
+	s, err := NewJSONRPCServer(nil, []string{allowedOriginForTest}, 0, 0)
+	if err != nil {
+		t.Fatalf("NewJSONRPCServer: %+v", err)
+	}
+	jsonRPCServer := s.(*jsonRPCServer)
+	jsonRPCServer.SetOnConnectedHandler(onConnectedHandlerForTest(t))
+	return jsonRPCServer, httptest.NewServer(jsonRPCServer)
+}
+
+func TestJSONRPCServerHTTP(t *testing.T) {
+	_, httpServer := newServerForTest(t)
+	defer httpServer.Close()
+
+	post := func(body string) (int, string) {
+		httpResponse, err := http.Post(httpServer.URL, "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("Post: %+v", err)
+		}
+		defer httpResponse.Body.Close()
+		responseBody, err := io.ReadAll(httpResponse.Body)
+		if err != nil {
+			t.Fatalf("ReadAll: %+v", err)
+		}
+		return httpResponse.StatusCode, string(responseBody)
+	}
+
+	tests := []struct {
+		name             string
+		request          string
+		expectedResponse string
+	}{
+		{
+			name:             "valid request",
+			request:          `{"jsonrpc":"2.0","id":1,"method":"getBlockCount"}`,
+			expectedResponse: `{"jsonrpc":"2.0","id":1,"result":{"blockCount":"7","headerCount":"8","error":null}}`,
+		},
+		{
+			name:             "request with a string ID and empty params",
+			request:          `{"jsonrpc":"2.0","id":"a","method":"getBlockCount","params":{}}`,
+			expectedResponse: `{"jsonrpc":"2.0","id":"a","result":{"blockCount":"7","headerCount":"8","error":null}}`,
+		},
+		{
+			name:             "handler error",
+			request:          `{"jsonrpc":"2.0","id":2,"method":"getSelectedTipHash"}`,
+			expectedResponse: `{"jsonrpc":"2.0","id":2,"error":{"code":-32000,"message":"test error"}}`,
+		},
+		{
+			name:             "unknown method",
+			request:          `{"jsonrpc":"2.0","id":3,"method":"getNothing"}`,
+			expectedResponse: `{"jsonrpc":"2.0","id":3,"error":{"code":-32601,"message":"method 'getNothing' not found"}}`,
+		},
+		{
+			name:             "subscription over HTTP",
+			request:          `{"jsonrpc":"2.0","id":4,"method":"notifyVirtualDaaScoreChanged"}`,
+			expectedResponse: `{"jsonrpc":"2.0","id":4,"error":{"code":-32600,"message":"method 'notifyVirtualDaaScoreChanged' is only available over WebSocket"}}`,
+		},
+		{
+			name:             "invalid JSON",
+			request:          `{"jsonrpc":`,
+			expectedResponse: `{"jsonrpc":"2.0","id":null,"error":{"code":-32700,"message":"could not parse request: unexpected end of JSON input"}}`,
+		},
+	}
+	for _, test := range tests {
+		statusCode, response := post(test.request)
+		if statusCode != http.StatusOK {
+			t.Fatalf("%s: unexpected status code %d", test.name, statusCode)
+		}
+		if response != test.expectedResponse {
+			t.Fatalf("%s: expected response %s but got %s", test.name, test.expectedResponse, response)
+		}
+	}
+
+	statusCode, response := post(`{"jsonrpc":"2.0","method":"getBlockCount"}`)
+	if statusCode != http.StatusNoContent || response != "" {
+		t.Fatalf("Expected no response to a request without an ID, but got %d: %s", statusCode, response)
+	}
+}
+
+func TestJSONRPCServerWebSocket(t *testing.T) {
+	jsonRPCServer, httpServer := newServerForTest(t)
+	defer httpServer.Close()
+
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http")
+	webSocket, err := websocket.Dial(url, "", allowedOriginForTest)
+	if err != nil {
+		t.Fatalf("Dial: %+v", err)
+	}
+	defer webSocket.Close()
+
+	send := func(message string) {
+		err := websocket.Message.Send(webSocket, message)
+		if err != nil {
+			t.Fatalf("Send: %+v", err)
+		}
+	}
+	receive := func() map[string]json.RawMessage {
+		var data []byte
+		err := websocket.Message.Receive(webSocket, &data)
+		if err != nil {
+			t.Fatalf("Receive: %+v", err)
+		}
+		var message map[string]json.RawMessage
+		err = json.Unmarshal(data, &message)
+		if err != nil {
+			t.Fatalf("Unmarshal: %+v", err)
+		}
+		return message
+	}
+
+	// Responses must match the IDs of their requests, in order
+	send(`{"jsonrpc":"2.0","id":1,"method":"getBlockCount"}`)
+	send(`{"jsonrpc":"2.0","id":2,"method":"notifyVirtualDaaScoreChanged"}`)
+	send(`{"jsonrpc":"2.0","id":3,"method":"getBlockCount"}`)
+
+	firstResponse := receive()
+	if string(firstResponse["id"]) != "1" {
+		t.Fatalf("Expected the first response to have ID 1 but got %s", firstResponse["id"])
+	}
+	secondResponse := receive()
+	if string(secondResponse["id"]) != "2" || secondResponse["error"] != nil {
+		t.Fatalf("Unexpected response to the subscription: %v", secondResponse)
+	}
+	notification := receive()
+	if string(notification["method"]) != `"virtualDaaScoreChangedNotification"` ||
+		!bytes.Contains(notification["params"], []byte(`"42"`)) || notification["id"] != nil {
+
+		t.Fatalf("Unexpected notification: %v", notification)
+	}
+	thirdResponse := receive()
+	if string(thirdResponse["id"]) != "3" {
+		t.Fatalf("Expected the third response to have ID 3 but got %s", thirdResponse["id"])
+	}
+
+	err = jsonRPCServer.Stop()
+	if err != nil {
+		t.Fatalf("Stop: %+v", err)
+	}
+	var data []byte
+	err = websocket.Message.Receive(webSocket, &data)
+	if err == nil {
+		t.Fatalf("Expected the WebSocket to be closed once the server is stopped")
+	}
+}
+
+func TestJSONRPCServerOrigins(t *testing.T) {
+	_, httpServer := newServerForTest(t)
+	defer httpServer.Close()
+
+	request := func(method string, origin string) *http.Response {
+		httpRequest, err := http.NewRequest(method, httpServer.URL,
+			strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"getBlockCount"}`))
+		if err != nil {
+			t.Fatalf("NewRequest: %+v", err)
+		}
+		if origin != "" {
+			httpRequest.Header.Set("Origin", origin)
+		}
+		httpResponse, err := http.DefaultClient.Do(httpRequest)
+		if err != nil {
+			t.Fatalf("Do: %+v", err)
+		}
+		httpResponse.Body.Close()
+		return httpResponse
+	}
+
+	tests := []struct {
+		name                      string
+		method                    string
+		origin                    string
+		expectedStatusCode        int
+		expectedAllowOriginHeader string
+	}{
+		{
+			name:               "POST without an origin",
+			method:             http.MethodPost,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:                      "POST from an allowed origin",
+			method:                    http.MethodPost,
+			origin:                    allowedOriginForTest,
+			expectedStatusCode:        http.StatusOK,
+			expectedAllowOriginHeader: allowedOriginForTest,
+		},
+		{
+			name:                      "preflight from an allowed origin",
+			method:                    http.MethodOptions,
+			origin:                    allowedOriginForTest,
+			expectedStatusCode:        http.StatusNoContent,
+			expectedAllowOriginHeader: allowedOriginForTest,
+		},
+		{
+			name:               "POST from another origin",
+			method:             http.MethodPost,
+			origin:             "https://evil.example",
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "preflight from another origin",
+			method:             http.MethodOptions,
+			origin:             "https://evil.example",
+			expectedStatusCode: http.StatusForbidden,
+		},
+	}
+	for _, test := range tests {
+		httpResponse := request(test.method, test.origin)
+		if httpResponse.StatusCode != test.expectedStatusCode {
+			t.Fatalf("%s: expected status code %d but got %d",
+				test.name, test.expectedStatusCode, httpResponse.StatusCode)
+		}
+		allowOriginHeader := httpResponse.Header.Get("Access-Control-Allow-Origin")
+		if allowOriginHeader != test.expectedAllowOriginHeader {
+			t.Fatalf("%s: expected Access-Control-Allow-Origin %q but got %q",
+				test.name, test.expectedAllowOriginHeader, allowOriginHeader)
+		}
+	}
+
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http")
+	_, err := websocket.Dial(url, "", "https://evil.example")
+	if err == nil {
+		t.Fatalf("Expected a WebSocket handshake from another origin to be rejected")
+	}
+	webSocket, err := websocket.Dial(url, "", allowedOriginForTest)
+	if err != nil {
+		t.Fatalf("Dial: %+v", err)
+	}
+	webSocket.Close()
+}
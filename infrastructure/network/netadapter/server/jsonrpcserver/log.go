@@ -0,0 +1,9 @@
+package jsonrpcserver
+
+import (
+	"github.com/zuanet/zuad/infrastructure/logger"
+	"github.com/zuanet/zuad/util/panics"
+)
+
+var log = logger.RegisterSubSystem("RPCS")
+var spawn = panics.GoroutineWrapperFunc(log)
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"net"
 
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/infrastructure/network/netadapter/p2pencryption"
 	"github.com/zuanet/zuad/infrastructure/network/netadapter/router"
 )
 
@@ -44,3 +46,19 @@ type Connection interface {
 	SetOnInvalidMessageHandler(onInvalidMessageHandler OnInvalidMessageHandler)
 	Address() *net.TCPAddr
 }
+
+// EncryptableConnection is a Connection whose messages can be encrypted once
+// the handshake agreed on an encryption session
+type EncryptableConnection interface {
+	Connection
+	EnableEncryption(session *p2pencryption.Session)
+	IsEncrypted() bool
+}
+
+// AddressedConnection is a Connection that knows the NetAddress of its remote
+// peer. Unlike Address, the NetAddress may be in a network other than IP, such
+// as the onion address of a peer that was dialed through Tor
+type AddressedConnection interface {
+	Connection
+	NetAddress() *appmessage.NetAddress
+}
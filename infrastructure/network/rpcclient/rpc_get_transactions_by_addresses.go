@@ -0,0 +1,23 @@
+package rpcclient
+
+import "github.com/zuanet/zuad/app/appmessage"
+
+// GetTransactionsByAddresses sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetTransactionsByAddresses(addresses []string, fromDAAScore uint64,
+	limit uint32) (*appmessage.GetTransactionsByAddressesResponseMessage, error) {
+
+	err := c.rpcRouter.outgoingRoute().Enqueue(
+		appmessage.NewGetTransactionsByAddressesRequestMessage(addresses, fromDAAScore, limit))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetTransactionsByAddressesResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getTransactionsByAddressesResponse := response.(*appmessage.GetTransactionsByAddressesResponseMessage)
+	if getTransactionsByAddressesResponse.Error != nil {
+		return nil, c.convertRPCError(getTransactionsByAddressesResponse.Error)
+	}
+	return getTransactionsByAddressesResponse, nil
+}
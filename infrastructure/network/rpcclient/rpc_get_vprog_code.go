@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/zuanet/zuad/app/appmessage"
+
+// GetVProgCode sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetVProgCode(programID string) (*appmessage.GetVProgCodeResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetVProgCodeRequestMessage(programID))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetVProgCodeResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getVProgCodeResponse := response.(*appmessage.GetVProgCodeResponseMessage)
+	if getVProgCodeResponse.Error != nil {
+		return nil, c.convertRPCError(getVProgCodeResponse.Error)
+	}
+	return getVProgCodeResponse, nil
+}
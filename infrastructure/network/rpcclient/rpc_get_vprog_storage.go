@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/zuanet/zuad/app/appmessage"
+
+// GetVProgStorage sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetVProgStorage(programID string, keys []string) (*appmessage.GetVProgStorageResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetVProgStorageRequestMessage(programID, keys))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetVProgStorageResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getVProgStorageResponse := response.(*appmessage.GetVProgStorageResponseMessage)
+	if getVProgStorageResponse.Error != nil {
+		return nil, c.convertRPCError(getVProgStorageResponse.Error)
+	}
+	return getVProgStorageResponse, nil
+}
@@ -11,18 +11,10 @@ import (
 func (c *RPCClient) RegisterForUTXOsChangedNotifications(addresses []string,
 	onUTXOsChanged func(notification *appmessage.UTXOsChangedNotificationMessage)) error {
 
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewNotifyUTXOsChangedRequestMessage(addresses))
+	err := c.notifyUTXOsChanged(addresses)
 	if err != nil {
 		return err
 	}
-	response, err := c.route(appmessage.CmdNotifyUTXOsChangedResponseMessage).DequeueWithTimeout(c.timeout)
-	if err != nil {
-		return err
-	}
-	notifyUTXOsChangedResponse := response.(*appmessage.NotifyUTXOsChangedResponseMessage)
-	if notifyUTXOsChangedResponse.Error != nil {
-		return c.convertRPCError(notifyUTXOsChangedResponse.Error)
-	}
 	spawn("RegisterForUTXOsChangedNotifications", func() {
 		for {
 			notification, err := c.route(appmessage.CmdUTXOsChangedNotificationMessage).Dequeue()
@@ -38,3 +30,26 @@ func (c *RPCClient) RegisterForUTXOsChangedNotifications(addresses []string,
 	})
 	return nil
 }
+
+// AddUTXOsChangedNotificationAddresses sends an RPC request that adds the given addresses to
+// the ones whose UTXO changes are notified. The notifications are handled by the handler that
+// was given to RegisterForUTXOsChangedNotifications, which must be called first
+func (c *RPCClient) AddUTXOsChangedNotificationAddresses(addresses []string) error {
+	return c.notifyUTXOsChanged(addresses)
+}
+
+func (c *RPCClient) notifyUTXOsChanged(addresses []string) error {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewNotifyUTXOsChangedRequestMessage(addresses))
+	if err != nil {
+		return err
+	}
+	response, err := c.route(appmessage.CmdNotifyUTXOsChangedResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return err
+	}
+	notifyUTXOsChangedResponse := response.(*appmessage.NotifyUTXOsChangedResponseMessage)
+	if notifyUTXOsChangedResponse.Error != nil {
+		return c.convertRPCError(notifyUTXOsChangedResponse.Error)
+	}
+	return nil
+}
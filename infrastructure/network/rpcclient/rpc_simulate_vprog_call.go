@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/zuanet/zuad/app/appmessage"
+
+// SimulateVProgCall sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) SimulateVProgCall(code string, data string, gasLimit uint64) (*appmessage.SimulateVProgCallResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewSimulateVProgCallRequestMessage(code, data, gasLimit))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdSimulateVProgCallResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	simulateVProgCallResponse := response.(*appmessage.SimulateVProgCallResponseMessage)
+	if simulateVProgCallResponse.Error != nil {
+		return nil, c.convertRPCError(simulateVProgCallResponse.Error)
+	}
+	return simulateVProgCallResponse, nil
+}
@@ -0,0 +1,25 @@
+package rpcclient
+
+import (
+	"github.com/zuanet/zuad/app/appmessage"
+)
+
+// SubmitTransactionPackage sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) SubmitTransactionPackage(transactions []*appmessage.RPCTransaction) (
+	*appmessage.SubmitTransactionPackageResponseMessage, error) {
+
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewSubmitTransactionPackageRequestMessage(transactions))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdSubmitTransactionPackageResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	submitTransactionPackageResponse := response.(*appmessage.SubmitTransactionPackageResponseMessage)
+	if submitTransactionPackageResponse.Error != nil {
+		return nil, c.convertRPCError(submitTransactionPackageResponse.Error)
+	}
+
+	return submitTransactionPackageResponse, nil
+}
@@ -15,6 +15,10 @@ import (
 
 const defaultTimeout = 30 * time.Second
 
+// OnReconnectedHandler defines a handler function for when the client
+// reconnected after it was disconnected
+type OnReconnectedHandler func()
+
 // RPCClient is an RPC client
 type RPCClient struct {
 	*grpcclient.GRPCClient
@@ -25,6 +29,7 @@ type RPCClient struct {
 	isClosed             uint32
 	isReconnecting       uint32
 	lastDisconnectedTime time.Time
+	onReconnectedHandler OnReconnectedHandler
 
 	timeout time.Duration
 }
@@ -118,6 +123,11 @@ func (c *RPCClient) Reconnect() error {
 		if time.Since(c.lastDisconnectedTime) > retryDelay {
 			err := c.connect()
 			if err == nil {
+				// Notification registrations don't survive the reconnection,
+				// so let the user of the client renew them
+				if c.onReconnectedHandler != nil {
+					spawn("RPCClient.Reconnect-onReconnectedHandler", c.onReconnectedHandler)
+				}
 				return nil
 			}
 			log.Warnf("Could not automatically reconnect to %s: %s", c.rpcAddress, err)
@@ -150,6 +160,12 @@ func (c *RPCClient) handleClientError(err error) {
 	c.handleClientDisconnected()
 }
 
+// SetOnReconnectedHandler sets the handler that is called every time
+// the client reconnects after it was disconnected
+func (c *RPCClient) SetOnReconnectedHandler(onReconnectedHandler OnReconnectedHandler) {
+	c.onReconnectedHandler = onReconnectedHandler
+}
+
 // SetTimeout sets the timeout by which to wait for RPC responses
 func (c *RPCClient) SetTimeout(timeout time.Duration) {
 	c.timeout = timeout
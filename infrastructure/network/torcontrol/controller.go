@@ -0,0 +1,274 @@
+/*
+Package torcontrol implements the parts of the Tor control protocol that are
+needed to create onion services for incoming P2P connections.
+
+See https://spec.torproject.org/control-spec for the protocol.
+*/
+package torcontrol
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/textproto"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	dialTimeout = 10 * time.Second
+
+	replyOK = 250
+
+	safeCookieServerKey = "Tor safe cookie authentication server-to-controller hash"
+	safeCookieClientKey = "Tor safe cookie authentication controller-to-server hash"
+	safeCookieNonceSize = 32
+	cookieSize          = 32
+)
+
+// Controller is an authenticated connection to the control port of a Tor
+// process. Onion services that are added through a Controller are removed by
+// Tor once the Controller is closed.
+type Controller struct {
+	conn *textproto.Conn
+}
+
+// Dial connects to the Tor control port at the given address and
+// authenticates with it. The password is only used if the control port is
+// protected by a hashed password.
+func Dial(address string, password string) (*Controller, error) {
+	netConn, err := net.DialTimeout("tcp", address, dialTimeout)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not connect to the Tor control port at %s", address)
+	}
+	controller := &Controller{conn: textproto.NewConn(netConn)}
+	err = controller.authenticate(password)
+	if err != nil {
+		controller.Close()
+		return nil, err
+	}
+	return controller, nil
+}
+
+// Close closes the connection to the control port
+func (c *Controller) Close() error {
+	return c.conn.Close()
+}
+
+// command sends the given command and returns its reply, whose lines are
+// separated by newlines, without their status codes
+func (c *Controller) command(format string, args ...interface{}) (string, error) {
+	id, err := c.conn.Cmd(format, args...)
+	if err != nil {
+		return "", errors.Wrap(err, "could not send a command to the Tor control port")
+	}
+	c.conn.StartResponse(id)
+	defer c.conn.EndResponse(id)
+
+	_, reply, err := c.conn.ReadResponse(replyOK)
+	if err != nil {
+		return "", errors.Wrap(err, "the Tor control port returned an error")
+	}
+	return reply, nil
+}
+
+// protocolInfo is the reply to the PROTOCOLINFO command
+type protocolInfo struct {
+	authMethods map[string]bool
+	cookieFile  string
+}
+
+func (c *Controller) protocolInfo() (*protocolInfo, error) {
+	reply, err := c.command("PROTOCOLINFO 1")
+	if err != nil {
+		return nil, err
+	}
+
+	info := &protocolInfo{authMethods: make(map[string]bool)}
+	for _, line := range strings.Split(reply, "\n") {
+		if !strings.HasPrefix(line, "AUTH ") {
+			continue
+		}
+		fields := parseReplyFields(strings.TrimPrefix(line, "AUTH "))
+		for _, method := range strings.Split(fields["METHODS"], ",") {
+			info.authMethods[method] = true
+		}
+		info.cookieFile = fields["COOKIEFILE"]
+	}
+	return info, nil
+}
+
+// authenticate authenticates with the control port using the best method it
+// supports: no authentication, a hashed password, or a cookie file that is
+// shared with the Tor process
+func (c *Controller) authenticate(password string) error {
+	info, err := c.protocolInfo()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case info.authMethods["NULL"]:
+		_, err = c.command("AUTHENTICATE")
+	case info.authMethods["HASHEDPASSWORD"] && password != "":
+		_, err = c.command("AUTHENTICATE %s", strconv.Quote(password))
+	case info.authMethods["SAFECOOKIE"]:
+		err = c.authenticateWithSafeCookie(info.cookieFile)
+	case info.authMethods["COOKIE"]:
+		var cookie []byte
+		cookie, err = readCookie(info.cookieFile)
+		if err != nil {
+			return err
+		}
+		_, err = c.command("AUTHENTICATE %x", cookie)
+	case info.authMethods["HASHEDPASSWORD"]:
+		return errors.New("the Tor control port requires a password - see --torpassword")
+	default:
+		return errors.Errorf("the Tor control port doesn't support any known authentication method")
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not authenticate with the Tor control port")
+	}
+	return nil
+}
+
+// authenticateWithSafeCookie proves that we can read the cookie file without
+// sending its content, and makes sure that the control port belongs to the Tor
+// process that wrote it
+func (c *Controller) authenticateWithSafeCookie(cookieFile string) error {
+	cookie, err := readCookie(cookieFile)
+	if err != nil {
+		return err
+	}
+	clientNonce := make([]byte, safeCookieNonceSize)
+	_, err = rand.Read(clientNonce)
+	if err != nil {
+		return err
+	}
+
+	reply, err := c.command("AUTHCHALLENGE SAFECOOKIE %x", clientNonce)
+	if err != nil {
+		return err
+	}
+	fields := parseReplyFields(strings.TrimPrefix(reply, "AUTHCHALLENGE "))
+	serverHash, err := hex.DecodeString(fields["SERVERHASH"])
+	if err != nil {
+		return errors.Wrap(err, "the Tor control port returned a malformed SERVERHASH")
+	}
+	serverNonce, err := hex.DecodeString(fields["SERVERNONCE"])
+	if err != nil {
+		return errors.Wrap(err, "the Tor control port returned a malformed SERVERNONCE")
+	}
+
+	message := make([]byte, 0, len(cookie)+len(clientNonce)+len(serverNonce))
+	message = append(message, cookie...)
+	message = append(message, clientNonce...)
+	message = append(message, serverNonce...)
+	if !hmac.Equal(serverHash, safeCookieHash(safeCookieServerKey, message)) {
+		return errors.New("the Tor control port doesn't know the content of the cookie file")
+	}
+
+	_, err = c.command("AUTHENTICATE %x", safeCookieHash(safeCookieClientKey, message))
+	return err
+}
+
+func safeCookieHash(key string, message []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write(message)
+	return mac.Sum(nil)
+}
+
+func readCookie(cookieFile string) ([]byte, error) {
+	if cookieFile == "" {
+		return nil, errors.New("the Tor control port didn't specify its cookie file")
+	}
+	cookie, err := os.ReadFile(cookieFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read the Tor cookie file")
+	}
+	if len(cookie) != cookieSize {
+		return nil, errors.Errorf("the Tor cookie file %s is %d bytes long, but must be %d bytes long",
+			cookieFile, len(cookie), cookieSize)
+	}
+	return cookie, nil
+}
+
+// AddOnion adds an onion service that forwards connections to its
+// virtualPort to the given target address. If privateKey is empty, a new key
+// is generated. It returns the service ID - the onion address without the
+// .onion suffix - and the private key of the service.
+func (c *Controller) AddOnion(privateKey string, virtualPort uint16, target string) (
+	serviceID string, servicePrivateKey string, err error) {
+
+	keyArgument := privateKey
+	if keyArgument == "" {
+		keyArgument = "NEW:ED25519-V3"
+	}
+	reply, err := c.command("ADD_ONION %s Port=%d,%s", keyArgument, virtualPort, target)
+	if err != nil {
+		return "", "", err
+	}
+
+	servicePrivateKey = privateKey
+	for _, line := range strings.Split(reply, "\n") {
+		switch {
+		case strings.HasPrefix(line, "ServiceID="):
+			serviceID = strings.TrimPrefix(line, "ServiceID=")
+		case strings.HasPrefix(line, "PrivateKey="):
+			servicePrivateKey = strings.TrimPrefix(line, "PrivateKey=")
+		}
+	}
+	if serviceID == "" {
+		return "", "", errors.New("the Tor control port didn't return the ID of the onion service")
+	}
+	if servicePrivateKey == "" {
+		return "", "", errors.New("the Tor control port didn't return the key of the onion service")
+	}
+	return serviceID, servicePrivateKey, nil
+}
+
+// parseReplyFields parses the space separated KEY=VALUE fields of a reply
+// line. Values may be quoted strings.
+func parseReplyFields(line string) map[string]string {
+	fields := make(map[string]string)
+	for line != "" {
+		line = strings.TrimLeft(line, " ")
+		separatorIndex := strings.IndexAny(line, "= ")
+		if separatorIndex < 0 || line[separatorIndex] == ' ' {
+			// A field without a value
+			nextIndex := strings.IndexByte(line, ' ')
+			if nextIndex < 0 {
+				break
+			}
+			line = line[nextIndex:]
+			continue
+		}
+		key := line[:separatorIndex]
+		line = line[separatorIndex+1:]
+
+		var value string
+		if strings.HasPrefix(line, `"`) {
+			quoted, err := strconv.QuotedPrefix(line)
+			if err != nil {
+				break
+			}
+			value, _ = strconv.Unquote(quoted)
+			line = line[len(quoted):]
+		} else {
+			valueEnd := strings.IndexByte(line, ' ')
+			if valueEnd < 0 {
+				valueEnd = len(line)
+			}
+			value = line[:valueEnd]
+			line = line[valueEnd:]
+		}
+		fields[key] = value
+	}
+	return fields
+}
@@ -0,0 +1,7 @@
+package torcontrol
+
+import (
+	"github.com/zuanet/zuad/infrastructure/logger"
+)
+
+var log = logger.RegisterSubSystem("TORC")
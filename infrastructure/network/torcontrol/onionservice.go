@@ -0,0 +1,102 @@
+package torcontrol
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+// OnionServiceKeyFileName is the name of the file in the app directory that
+// holds the private key of the node's onion service
+const OnionServiceKeyFileName = "onion_v3_private_key"
+
+// OnionService is an onion service that was created through the control port
+// of a Tor process. It exists until it's closed.
+type OnionService struct {
+	controller *Controller
+	netAddress *appmessage.NetAddress
+}
+
+// CreateOnionService creates an onion service, through the Tor control port at
+// controlAddress, that forwards connections to its virtualPort to the given
+// target address. The key of the service is stored in keyPath, so that the
+// service keeps its address when it's created again.
+func CreateOnionService(controlAddress string, password string, keyPath string,
+	virtualPort uint16, target string) (*OnionService, error) {
+
+	privateKey, err := loadOnionServiceKey(keyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	controller, err := Dial(controlAddress, password)
+	if err != nil {
+		return nil, err
+	}
+	serviceID, servicePrivateKey, err := controller.AddOnion(privateKey, virtualPort, target)
+	if err != nil {
+		controller.Close()
+		return nil, err
+	}
+	netAddress, err := appmessage.ParseNetAddress(serviceID+".onion", virtualPort)
+	if err != nil || netAddress.Network != appmessage.NetworkTorV3 {
+		controller.Close()
+		return nil, errors.Errorf("the Tor control port returned an invalid onion service ID %s", serviceID)
+	}
+
+	if privateKey == "" {
+		err = saveOnionServiceKey(keyPath, servicePrivateKey)
+		if err != nil {
+			controller.Close()
+			return nil, err
+		}
+	}
+
+	log.Infof("Created onion service %s", netAddress)
+	return &OnionService{
+		controller: controller,
+		netAddress: netAddress,
+	}, nil
+}
+
+// NetAddress returns the address of the onion service
+func (s *OnionService) NetAddress() *appmessage.NetAddress {
+	return s.netAddress
+}
+
+// Close removes the onion service
+func (s *OnionService) Close() error {
+	return s.controller.Close()
+}
+
+// loadOnionServiceKey returns the key stored in the given file, or an empty
+// string if the file doesn't exist
+func loadOnionServiceKey(keyPath string) (string, error) {
+	content, err := os.ReadFile(keyPath)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "could not read the onion service key from %s", keyPath)
+	}
+	privateKey := strings.TrimSpace(string(content))
+	if !strings.HasPrefix(privateKey, "ED25519-V3:") {
+		return "", errors.Errorf("the onion service key in %s is not an ED25519-V3 key", keyPath)
+	}
+	return privateKey, nil
+}
+
+func saveOnionServiceKey(keyPath string, privateKey string) error {
+	err := os.MkdirAll(filepath.Dir(keyPath), 0700)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(keyPath, []byte(privateKey+"\n"), 0600)
+	if err != nil {
+		return errors.Wrapf(err, "could not write the onion service key to %s", keyPath)
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+package torcontrol
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/zuanet/zuad/app/appmessage"
+)
+
+// mockControlPort is a local stand-in for the control port of a Tor process
+type mockControlPort struct {
+	t           *testing.T
+	listener    net.Listener
+	authMethods string
+	cookieFile  string
+	cookie      []byte
+	password    string
+	serviceID   string
+
+	mutex            sync.Mutex
+	authenticated    bool
+	addOnionCommands []string
+	closedChan       chan struct{}
+}
+
+func newMockControlPort(t *testing.T, authMethods string) *mockControlPort {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %+v", err)
+	}
+	cookie := bytes.Repeat([]byte{0x42}, cookieSize)
+	cookieFile := filepath.Join(t.TempDir(), "control_auth_cookie")
+	err = os.WriteFile(cookieFile, cookie, 0600)
+	if err != nil {
+		t.Fatalf("WriteFile: %+v", err)
+	}
+	publicKey := bytes.Repeat([]byte{0xab}, appmessage.TorV3PublicKeySize)
+	serviceID := strings.TrimSuffix(appmessage.NewNetAddressTorV3(publicKey, 0).HostName(), ".onion")
+
+	mock := &mockControlPort{
+		t:           t,
+		listener:    listener,
+		authMethods: authMethods,
+		cookieFile:  cookieFile,
+		cookie:      cookie,
+		password:    "hunter2",
+		serviceID:   serviceID,
+		closedChan:  make(chan struct{}, 10),
+	}
+	go mock.serve()
+	t.Cleanup(func() { listener.Close() })
+	return mock
+}
+
+func (m *mockControlPort) address() string {
+	return m.listener.Addr().String()
+}
+
+func (m *mockControlPort) serve() {
+	for {
+		conn, err := m.listener.Accept()
+		if err != nil {
+			return
+		}
+		go m.handleConnection(conn)
+	}
+}
+
+func (m *mockControlPort) handleConnection(conn net.Conn) {
+	defer func() {
+		conn.Close()
+		m.closedChan <- struct{}{}
+	}()
+
+	var clientNonce, serverNonce []byte
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.TrimRight(line, "\r\n")
+		arguments := strings.Fields(command)
+
+		var reply string
+		switch {
+		case arguments[0] == "PROTOCOLINFO":
+			reply = fmt.Sprintf("250-PROTOCOLINFO 1\r\n"+
+				"250-AUTH METHODS=%s COOKIEFILE=%s\r\n"+
+				"250-VERSION Tor=\"0.4.8.9\"\r\n"+
+				"250 OK\r\n", m.authMethods, strconv.Quote(m.cookieFile))
+		case arguments[0] == "AUTHCHALLENGE":
+			clientNonce, _ = hex.DecodeString(arguments[2])
+			serverNonce = bytes.Repeat([]byte{0x17}, safeCookieNonceSize)
+			serverHash := safeCookieHash(safeCookieServerKey, m.safeCookieMessage(clientNonce, serverNonce))
+			reply = fmt.Sprintf("250 AUTHCHALLENGE SERVERHASH=%x SERVERNONCE=%x\r\n", serverHash, serverNonce)
+		case arguments[0] == "AUTHENTICATE":
+			if m.isValidAuthentication(arguments[1:], clientNonce, serverNonce) {
+				m.mutex.Lock()
+				m.authenticated = true
+				m.mutex.Unlock()
+				reply = "250 OK\r\n"
+			} else {
+				reply = "515 Authentication failed\r\n"
+			}
+		case arguments[0] == "ADD_ONION":
+			m.mutex.Lock()
+			authenticated := m.authenticated
+			m.addOnionCommands = append(m.addOnionCommands, command)
+			m.mutex.Unlock()
+			if !authenticated {
+				reply = "514 Authentication required\r\n"
+				break
+			}
+			reply = fmt.Sprintf("250-ServiceID=%s\r\n", m.serviceID)
+			if arguments[1] == "NEW:ED25519-V3" {
+				reply += "250-PrivateKey=ED25519-V3:bW9jayBwcml2YXRlIGtleQ==\r\n"
+			}
+			reply += "250 OK\r\n"
+		default:
+			reply = "510 Unrecognized command\r\n"
+		}
+		_, err = conn.Write([]byte(reply))
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (m *mockControlPort) safeCookieMessage(clientNonce []byte, serverNonce []byte) []byte {
+	message := append([]byte{}, m.cookie...)
+	message = append(message, clientNonce...)
+	return append(message, serverNonce...)
+}
+
+func (m *mockControlPort) isValidAuthentication(arguments []string, clientNonce []byte, serverNonce []byte) bool {
+	switch m.authMethods {
+	case "NULL":
+		return len(arguments) == 0
+	case "HASHEDPASSWORD":
+		return len(arguments) == 1 && arguments[0] == strconv.Quote(m.password)
+	case "COOKIE":
+		return len(arguments) == 1 && arguments[0] == hex.EncodeToString(m.cookie)
+	case "COOKIE,SAFECOOKIE":
+		clientHash := safeCookieHash(safeCookieClientKey, m.safeCookieMessage(clientNonce, serverNonce))
+		return clientNonce != nil && len(arguments) == 1 && arguments[0] == hex.EncodeToString(clientHash)
+	default:
+		return false
+	}
+}
+
+func (m *mockControlPort) lastAddOnionCommand() string {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if len(m.addOnionCommands) == 0 {
+		return ""
+	}
+	return m.addOnionCommands[len(m.addOnionCommands)-1]
+}
+
+func TestAuthentication(t *testing.T) {
+	for _, authMethods := range []string{"NULL", "HASHEDPASSWORD", "COOKIE", "COOKIE,SAFECOOKIE"} {
+		mock := newMockControlPort(t, authMethods)
+		controller, err := Dial(mock.address(), mock.password)
+		if err != nil {
+			t.Fatalf("%s: Dial: %+v", authMethods, err)
+		}
+		controller.Close()
+	}
+
+	mock := newMockControlPort(t, "HASHEDPASSWORD")
+	_, err := Dial(mock.address(), "wrong password")
+	if err == nil {
+		t.Fatalf("Dial with a wrong password unexpectedly succeeded")
+	}
+	_, err = Dial(mock.address(), "")
+	if err == nil {
+		t.Fatalf("Dial without a password unexpectedly succeeded")
+	}
+}
+
+func TestSafeCookieAuthenticationRejectsImpostors(t *testing.T) {
+	mock := newMockControlPort(t, "COOKIE,SAFECOOKIE")
+	// A control port that doesn't know the cookie can't compute the server hash
+	mock.cookie = bytes.Repeat([]byte{0x24}, cookieSize)
+	_, err := Dial(mock.address(), "")
+	if err == nil {
+		t.Fatalf("Dial to a control port that doesn't know the cookie unexpectedly succeeded")
+	}
+}
+
+func TestCreateOnionService(t *testing.T) {
+	mock := newMockControlPort(t, "NULL")
+	keyPath := filepath.Join(t.TempDir(), "appdir", OnionServiceKeyFileName)
+
+	onionService, err := CreateOnionService(mock.address(), "", keyPath, 16111, "127.0.0.1:16111")
+	if err != nil {
+		t.Fatalf("CreateOnionService: %+v", err)
+	}
+	expectedCommand := "ADD_ONION NEW:ED25519-V3 Port=16111,127.0.0.1:16111"
+	if command := mock.lastAddOnionCommand(); command != expectedCommand {
+		t.Fatalf("expected the command %q, got %q", expectedCommand, command)
+	}
+	expectedAddress := mock.serviceID + ".onion:16111"
+	if onionService.NetAddress().String() != expectedAddress {
+		t.Fatalf("expected the onion service address %s, got %s", expectedAddress, onionService.NetAddress())
+	}
+
+	// The onion service lasts as long as its control connection
+	err = onionService.Close()
+	if err != nil {
+		t.Fatalf("Close: %+v", err)
+	}
+	<-mock.closedChan
+
+	// The key is reused when the onion service is created again
+	fileInfo, err := os.Stat(keyPath)
+	if err != nil {
+		t.Fatalf("Stat: %+v", err)
+	}
+	if fileInfo.Mode().Perm()&0077 != 0 {
+		t.Fatalf("the onion service key file is accessible by other users: %s", fileInfo.Mode())
+	}
+	onionService, err = CreateOnionService(mock.address(), "", keyPath, 16111, "127.0.0.1:16111")
+	if err != nil {
+		t.Fatalf("CreateOnionService: %+v", err)
+	}
+	defer onionService.Close()
+	expectedCommand = "ADD_ONION ED25519-V3:bW9jayBwcml2YXRlIGtleQ== Port=16111,127.0.0.1:16111"
+	if command := mock.lastAddOnionCommand(); command != expectedCommand {
+		t.Fatalf("expected the command %q, got %q", expectedCommand, command)
+	}
+}
+
+func TestCreateOnionServiceWithCorruptedKey(t *testing.T) {
+	mock := newMockControlPort(t, "NULL")
+	keyPath := filepath.Join(t.TempDir(), OnionServiceKeyFileName)
+	err := os.WriteFile(keyPath, []byte("not a key"), 0600)
+	if err != nil {
+		t.Fatalf("WriteFile: %+v", err)
+	}
+	_, err = CreateOnionService(mock.address(), "", keyPath, 16111, "127.0.0.1:16111")
+	if err == nil {
+		t.Fatalf("an onion service was created with a corrupted key")
+	}
+}
+
+func TestParseReplyFields(t *testing.T) {
+	fields := parseReplyFields(`METHODS=COOKIE,SAFECOOKIE COOKIEFILE="/var/lib/tor/control auth cookie" FLAG`)
+	if fields["METHODS"] != "COOKIE,SAFECOOKIE" {
+		t.Fatalf("unexpected METHODS %q", fields["METHODS"])
+	}
+	if fields["COOKIEFILE"] != "/var/lib/tor/control auth cookie" {
+		t.Fatalf("unexpected COOKIEFILE %q", fields["COOKIEFILE"])
+	}
+}
@@ -1,6 +1,7 @@
 package integration
 
 import (
+	"github.com/zuanet/zuad/app/appmessage"
 	"github.com/zuanet/zuad/infrastructure/network/addressmanager"
 	"testing"
 )
@@ -31,3 +32,45 @@ func TestAddressExchange(t *testing.T) {
 
 	t.Errorf("Didn't find testAddress in list of addresses of appHarness3")
 }
+
+func TestTorAndI2PAddressExchange(t *testing.T) {
+	appHarness1, appHarness2, appHarness3, teardown := standardSetup(t)
+	defer teardown()
+
+	testHosts := []string{
+		"2gzyxa5ihm7nsggfxnu52rck2vv4rvmdlkiu3zzui5du4xyclen53wid.onion",
+		"ukeu3k5oycgaauneqgtnvselmt4yemvoilkln7jpvamvfx7dnkdq.b32.i2p",
+	}
+	for _, testHost := range testHosts {
+		netAddress, err := appmessage.ParseNetAddress(testHost, 6789)
+		if err != nil {
+			t.Fatalf("ParseNetAddress: %+v", err)
+		}
+		err = appHarness1.app.AddressManager().AddAddresses(netAddress)
+		if err != nil {
+			t.Fatalf("Error adding address to addressManager: %+v", err)
+		}
+	}
+
+	connect(t, appHarness1, appHarness2)
+	connect(t, appHarness2, appHarness3)
+
+	peerAddresses, err := appHarness3.rpcClient.GetPeerAddresses()
+	if err != nil {
+		t.Fatalf("Error getting peer addresses: %+v", err)
+	}
+
+	for _, testHost := range testHosts {
+		testAddress := testHost + ":6789"
+		found := false
+		for _, peerAddress := range peerAddresses.Addresses {
+			if peerAddress.Addr == testAddress {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("Didn't find %s in list of addresses of appHarness3", testAddress)
+		}
+	}
+}
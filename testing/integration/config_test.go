@@ -40,6 +40,8 @@ func setConfig(t *testing.T, harness *appHarness, protocolVersion uint32) {
 	harness.config.Listeners = []string{harness.p2pAddress}
 	harness.config.RPCListeners = []string{harness.rpcAddress}
 	harness.config.UTXOIndex = harness.utxoIndex
+	harness.config.NoP2PEncryption = harness.noP2PEncryption
+	harness.config.PinnedP2PIdentityKeys = harness.pinnedP2PIdentityKeys
 	harness.config.AllowSubmitBlockWhenNotSynced = true
 	if protocolVersion != 0 {
 		harness.config.ProtocolVersion = protocolVersion
@@ -0,0 +1,126 @@
+package integration
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/zuanet/zuad/app/appmessage"
+	"github.com/zuanet/zuad/infrastructure/config"
+)
+
+func TestP2PEncryption(t *testing.T) {
+	harnesses, teardown := setupHarnesses(t, []*harnessParams{
+		{
+			p2pAddress:              p2pAddress1,
+			rpcAddress:              rpcAddress1,
+			miningAddress:           miningAddress1,
+			miningAddressPrivateKey: miningAddress1PrivateKey,
+		},
+		{
+			p2pAddress:              p2pAddress2,
+			rpcAddress:              rpcAddress2,
+			miningAddress:           miningAddress2,
+			miningAddressPrivateKey: miningAddress2PrivateKey,
+		},
+		{
+			p2pAddress:              p2pAddress3,
+			rpcAddress:              rpcAddress3,
+			miningAddress:           miningAddress3,
+			miningAddressPrivateKey: miningAddress3PrivateKey,
+			noP2PEncryption:         true,
+		},
+	})
+	defer teardown()
+	encryptingHarness1, encryptingHarness2, plaintextHarness := harnesses[0], harnesses[1], harnesses[2]
+
+	// The verack and everything after it are encrypted, so connecting
+	// successfully already means that the encrypted messages got through
+	connect(t, encryptingHarness1, encryptingHarness2)
+	connect(t, plaintextHarness, encryptingHarness1)
+
+	encryptedPeer := getConnectedPeerInfo(t, encryptingHarness1, encryptingHarness2)
+	if !encryptedPeer.IsEncrypted {
+		t.Fatalf("The connection between two nodes that support encryption is not encrypted")
+	}
+	if encryptedPeer.IdentityKey == "" {
+		t.Fatalf("The encrypted peer has no identity key")
+	}
+	plaintextPeer := getConnectedPeerInfo(t, encryptingHarness1, plaintextHarness)
+	if plaintextPeer.IsEncrypted || plaintextPeer.IdentityKey != "" {
+		t.Fatalf("The connection to a node with encryption disabled is encrypted")
+	}
+}
+
+func TestP2PIdentityKeyPinning(t *testing.T) {
+	tcpAddress, err := net.ResolveTCPAddr("tcp", p2pAddress2)
+	if err != nil {
+		t.Fatalf("ResolveTCPAddr: %+v", err)
+	}
+	wrongIdentityKey := bytes.Repeat([]byte{1}, 32)
+
+	harnesses, teardown := setupHarnesses(t, []*harnessParams{
+		{
+			p2pAddress:              p2pAddress1,
+			rpcAddress:              rpcAddress1,
+			miningAddress:           miningAddress1,
+			miningAddressPrivateKey: miningAddress1PrivateKey,
+			pinnedP2PIdentityKeys: []*config.PinnedP2PIdentityKey{{
+				Address:     appmessage.NewNetAddress(tcpAddress),
+				IdentityKey: wrongIdentityKey,
+			}},
+		},
+		{
+			p2pAddress:              p2pAddress2,
+			rpcAddress:              rpcAddress2,
+			miningAddress:           miningAddress2,
+			miningAddressPrivateKey: miningAddress2PrivateKey,
+		},
+		{
+			p2pAddress:              p2pAddress3,
+			rpcAddress:              rpcAddress3,
+			miningAddress:           miningAddress3,
+			miningAddressPrivateKey: miningAddress3PrivateKey,
+		},
+	})
+	defer teardown()
+	pinningHarness, pinnedHarness, unpinnedHarness := harnesses[0], harnesses[1], harnesses[2]
+
+	// Peers without a pinned key are trusted on first use
+	connect(t, pinningHarness, unpinnedHarness)
+
+	err = pinningHarness.rpcClient.AddPeer(pinnedHarness.p2pAddress, false)
+	if err != nil {
+		t.Fatalf("Error adding peer: %+v", err)
+	}
+	time.Sleep(2 * time.Second)
+
+	connectedPeerInfo, err := pinningHarness.rpcClient.GetConnectedPeerInfo()
+	if err != nil {
+		t.Fatalf("Error getting connected peer info: %+v", err)
+	}
+	pinnedPeerID := pinnedHarness.app.P2PNodeID().String()
+	for _, info := range connectedPeerInfo.Infos {
+		if info.ID == pinnedPeerID {
+			t.Fatalf("Connected to a peer that does not hold its pinned identity key")
+		}
+	}
+}
+
+func getConnectedPeerInfo(t *testing.T, harness *appHarness,
+	peerHarness *appHarness) *appmessage.GetConnectedPeerInfoMessage {
+
+	connectedPeerInfo, err := harness.rpcClient.GetConnectedPeerInfo()
+	if err != nil {
+		t.Fatalf("Error getting connected peer info: %+v", err)
+	}
+	peerID := peerHarness.app.P2PNodeID().String()
+	for _, info := range connectedPeerInfo.Infos {
+		if info.ID == peerID {
+			return info
+		}
+	}
+	t.Fatalf("%s is not connected to %s", peerHarness.p2pAddress, harness.p2pAddress)
+	return nil
+}
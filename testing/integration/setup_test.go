@@ -24,6 +24,8 @@ type appHarness struct {
 	config                  *config.Config
 	database                database.Database
 	utxoIndex               bool
+	noP2PEncryption         bool
+	pinnedP2PIdentityKeys   []*config.PinnedP2PIdentityKey
 	overrideDAGParams       *dagconfig.Params
 }
 
@@ -33,6 +35,8 @@ type harnessParams struct {
 	miningAddress           string
 	miningAddressPrivateKey string
 	utxoIndex               bool
+	noP2PEncryption         bool
+	pinnedP2PIdentityKeys   []*config.PinnedP2PIdentityKey
 	overrideDAGParams       *dagconfig.Params
 	protocolVersion         uint32
 }
@@ -45,6 +49,8 @@ func setupHarness(t *testing.T, params *harnessParams) (harness *appHarness, tea
 		miningAddress:           params.miningAddress,
 		miningAddressPrivateKey: params.miningAddressPrivateKey,
 		utxoIndex:               params.utxoIndex,
+		noP2PEncryption:         params.noP2PEncryption,
+		pinnedP2PIdentityKeys:   params.pinnedP2PIdentityKeys,
 		overrideDAGParams:       params.overrideDAGParams,
 	}
 
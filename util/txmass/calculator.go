@@ -10,14 +10,16 @@ type Calculator struct {
 	massPerTxByte           uint64
 	massPerScriptPubKeyByte uint64
 	massPerSigOp            uint64
+	vprogGasPerMass         uint64
 }
 
 // NewCalculator creates a new instance of Calculator
-func NewCalculator(massPerTxByte, massPerScriptPubKeyByte, massPerSigOp uint64) *Calculator {
+func NewCalculator(massPerTxByte, massPerScriptPubKeyByte, massPerSigOp, vprogGasPerMass uint64) *Calculator {
 	return &Calculator{
 		massPerTxByte:           massPerTxByte,
 		massPerScriptPubKeyByte: massPerScriptPubKeyByte,
 		massPerSigOp:            massPerSigOp,
+		vprogGasPerMass:         vprogGasPerMass,
 	}
 }
 
@@ -30,6 +32,9 @@ func (c *Calculator) MassPerScriptPubKeyByte() uint64 { return c.massPerScriptPu
 // MassPerSigOp returns the mass per SigOp byte configured for this Calculator
 func (c *Calculator) MassPerSigOp() uint64 { return c.massPerSigOp }
 
+// VProgGasPerMass returns the vprog gas per mass unit configured for this Calculator
+func (c *Calculator) VProgGasPerMass() uint64 { return c.vprogGasPerMass }
+
 // CalculateTransactionMass calculates the mass of the given transaction
 func (c *Calculator) CalculateTransactionMass(transaction *externalapi.DomainTransaction) uint64 {
 	if transactionhelper.IsCoinBase(transaction) {
@@ -55,8 +60,15 @@ func (c *Calculator) CalculateTransactionMass(transaction *externalapi.DomainTra
 	}
 	massForSigOps := totalSigOpCount * c.massPerSigOp
 
+	// calculate mass for vprog gas. The full gas limit is charged regardless
+	// of how much gas the execution eventually uses
+	massForVProgGas := uint64(0)
+	if transactionhelper.IsVProgTransaction(transaction) && c.vprogGasPerMass > 0 {
+		massForVProgGas = (transaction.VProgGasLimit + c.vprogGasPerMass - 1) / c.vprogGasPerMass
+	}
+
 	// Sum all components of mass
-	return massForSize + massForScriptPubKey + massForSigOps
+	return massForSize + massForScriptPubKey + massForSigOps + massForVProgGas
 }
 
 // transactionEstimatedSerializedSize is the estimated size of a transaction in some
@@ -87,6 +99,15 @@ func transactionEstimatedSerializedSize(tx *externalapi.DomainTransaction) uint6
 	size += 8 // length of the payload (uint64)
 	size += uint64(len(tx.Payload))
 
+	if transactionhelper.IsVProgTransaction(tx) {
+		size += 1 // vprog version (byte)
+		size += 8 // length of the vprog code (uint64)
+		size += uint64(len(tx.VProgCode))
+		size += 8 // length of the vprog data (uint64)
+		size += uint64(len(tx.VProgData))
+		size += 8 // vprog gas limit (uint64)
+	}
+
 	return size
 }
 